@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -29,7 +30,8 @@ import (
 
 	"github.com/wham/kaja/v2/pkg/api"
 	"github.com/wham/kaja/v2/pkg/apps"
-	"github.com/wham/kaja/v2/pkg/grpc"
+	"github.com/wham/kaja/v2/pkg/apps/rpc"
+	"github.com/wham/kaja/v2/pkg/limit"
 	"github.com/wham/kaja/v2/pkg/mcp"
 )
 
@@ -366,13 +368,15 @@ func (a *App) Twirp(method string, req []byte) ([]byte, error) {
 
 // TargetResult holds the response from a Target call, including HTTP status for
 // Twirp. RequestHeaders/ResponseHeaders are what an in-process app exchanged with its
-// upstream, surfaced in the Headers view.
+// upstream, surfaced in the Headers view. Question is what the app needs answered
+// before it can finish the call (apps.Question, as JSON), with an empty Body.
 type TargetResult struct {
 	Body            []byte            `json:"body"`
 	StatusCode      int               `json:"statusCode"`
 	Status          string            `json:"status"`
 	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
 	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	Question        string            `json:"question,omitempty"`
 }
 
 // Target proxies external API calls to configured endpoints (the desktop's
@@ -397,12 +401,14 @@ func (a *App) Target(target string, method string, req []byte, protocol int, hea
 	// The reserved header names the app the call belongs to, and goes no further:
 	// it is what the credential and the transport are looked up by.
 	appName := apps.TakeAppName(headers)
+	endpoint := apps.TakeEndpoint(headers)
 
 	// App targets (kaja-app://<id>) are invoked in-process by the app manager. InvokeApp
 	// expands the ${NAME} references the headers still carry and masks the resolved
-	// values back out of what it reports exchanging.
+	// values back out of what it reports exchanging. A call waiting its turn under the
+	// app's limits gives up when the window's context ends.
 	if apps.IsAppTarget(target) {
-		result, err := a.api.InvokeApp(target, method, req, headers)
+		result, err := a.api.InvokeApp(a.ctx, appName, target, method, req, headers)
 		var upstream *apps.UpstreamError
 		if errors.As(err, &upstream) {
 			// Hand the structured upstream failure to the transport instead of rejecting the
@@ -419,11 +425,16 @@ func (a *App) Target(target string, method string, req []byte, protocol int, hea
 		if err != nil {
 			return nil, err
 		}
-		return &TargetResult{
+		targetResult := &TargetResult{
 			Body:            result.Body,
 			RequestHeaders:  result.RequestHeaders,
 			ResponseHeaders: result.ResponseHeaders,
-		}, nil
+		}
+		if result.Question != nil {
+			question, _ := json.Marshal(result.Question)
+			targetResult.Question = string(question)
+		}
+		return targetResult, nil
 	}
 
 	headers = a.api.Variables().ExpandAll(headers)
@@ -433,22 +444,18 @@ func (a *App) Target(target string, method string, req []byte, protocol int, hea
 	headers = apps.MergeMetadata(headers, connection.Metadata)
 	switch protocol {
 	case 1: // gRPC
-		resp, err := a.targetGRPC(target, method, req, headers, connection.TLS)
-		if err != nil {
-			return nil, err
-		}
-		return &TargetResult{Body: resp}, nil
+		return a.targetGRPC(target, endpoint, method, req, headers, connection)
 	case 2: // Twirp
-		return a.targetTwirp(target, method, req, headers)
+		return a.targetTwirp(target, method, req, headers, connection)
 	default:
 		return nil, fmt.Errorf("invalid protocol: %d (must be 1 for gRPC or 2 for Twirp)", protocol)
 	}
 }
 
-func (a *App) targetGRPC(target string, method string, req []byte, headers map[string]string, options grpc.TLSOptions) ([]byte, error) {
+func (a *App) targetGRPC(target string, endpoint string, method string, req []byte, headers map[string]string, connection api.AppConnection) (*TargetResult, error) {
 	slog.Info("Invoking gRPC target", "target", target, "method", method, "headers", len(headers))
 
-	client, err := grpc.NewClientFromString(target, options)
+	client, err := connection.Client(target, endpoint)
 	if err != nil {
 		slog.Error("Failed to create gRPC client", "target", target, "error", err)
 		return nil, err
@@ -462,34 +469,58 @@ func (a *App) targetGRPC(target string, method string, req []byte, headers map[s
 		return nil, err
 	}
 
-	slog.Info("gRPC response received", "target", target, "method", method, "response_length", len(response))
-	return response, nil
+	slog.Info("gRPC response received", "target", target, "method", method, "response_length", len(response.Body))
+	// The encoding the call settled on each way is shown as the upstream hop, the
+	// same as the web proxy reports it.
+	return &TargetResult{
+		Body:            response.Body,
+		RequestHeaders:  response.RequestHeaders,
+		ResponseHeaders: response.ResponseHeaders,
+	}, nil
 }
 
-func (a *App) targetTwirp(target string, method string, req []byte, headers map[string]string) (*TargetResult, error) {
-	var url string
+func (a *App) targetTwirp(target string, method string, req []byte, headers map[string]string, connection api.AppConnection) (*TargetResult, error) {
+	var rawURL string
 	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
 		// Already a valid HTTP URL.
-		url = target + "/twirp/" + method
+		rawURL = target + "/twirp/" + method
 	} else {
 		// Assume host:port.
-		url = "http://" + target + "/twirp/" + method
+		rawURL = "http://" + target + "/twirp/" + method
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(context.Background(), "POST", url, bytes.NewReader(req))
+	// The app's transport settles the scheme and presents its certificates.
+	upstream, client, err := connection.HTTPClient(parsed)
+	if err != nil {
+		slog.Error("Failed to create Twirp client", "target", target, "error", err)
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(context.Background(), "POST", upstream.String(), bytes.NewReader(req))
 	if err != nil {
 		slog.Error("Failed to create HTTP request", "target", target, "method", method, "error", err)
 		return nil, err
 	}
 
+	// A twirp app that speaks JSON sends its own Content-Type with the headers.
 	httpReq.Header.Set("Content-Type", "application/protobuf")
 
 	for name, value := range headers {
 		httpReq.Header.Set(name, value)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
+	// A call over the app's limits waits for its turn before it is sent.
+	release, queued, err := connection.Enter(httpReq.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, attempts, err := connection.Retry.Send(client.Do, httpReq)
 	if err != nil {
 		slog.Error("Failed to make HTTP request", "target", target, "method", method, "error", err)
 		return nil, err
@@ -506,10 +537,18 @@ func (a *App) targetTwirp(target string, method string, req []byte, headers map[
 	response := responseBuffer.Bytes()
 	slog.Info("Target response", "target", target, "method", method, "status", resp.StatusCode, "response_length", len(response))
 
+	// A failure reaches the UI as a Twirp error whatever refused it.
+	if resp.StatusCode >= http.StatusBadRequest {
+		response = rpc.ParseTwirpError(resp.StatusCode, response).JSON()
+	}
+
+	// Every attempt the call took, and its wait to make the first, are reported
+	// as its upstream hop.
 	return &TargetResult{
-		Body:       response,
-		StatusCode: resp.StatusCode,
-		Status:     http.StatusText(resp.StatusCode),
+		Body:           response,
+		StatusCode:     resp.StatusCode,
+		Status:         http.StatusText(resp.StatusCode),
+		RequestHeaders: limit.Record(attempts.Record(nil), queued),
 	}, nil
 }
 
@@ -545,7 +584,8 @@ func appSupportDir() (string, error) {
 	return dir, nil
 }
 
-// TargetServerStream starts a server-streaming gRPC call.
+// TargetServerStream starts a server-streaming gRPC call, to an upstream or to an
+// in-process app.
 // Each response message is emitted as a Wails event "stream:<streamID>" with base64-encoded body.
 // When the stream ends, "stream:<streamID>:end" is emitted.
 // On error, "stream:<streamID>:error" is emitted with the error message.
@@ -564,11 +604,42 @@ func (a *App) TargetServerStream(target string, method string, req []byte, heade
 	}
 
 	appName := apps.TakeAppName(headers)
+	endpoint := apps.TakeEndpoint(headers)
+
+	// An in-process app streams from here rather than over a connection.
+	// InvokeAppStream expands the headers and redacts what it reports back.
+	if apps.IsAppTarget(target) {
+		if !a.api.AppStreams(target, method) {
+			return fmt.Errorf("%s is not a server-streaming method", method)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		a.activeStreams.Store(streamID, cancel)
+		go func() {
+			defer cancel()
+			defer a.activeStreams.Delete(streamID)
+
+			_, err := a.api.InvokeAppStream(ctx, appName, target, method, req, headers, func(message []byte) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				runtime.EventsEmit(a.ctx, "stream:"+streamID, base64.StdEncoding.EncodeToString(message))
+				return nil
+			})
+			if err != nil {
+				slog.Error("App stream error", "streamID", streamID, "error", err)
+				runtime.EventsEmit(a.ctx, "stream:"+streamID+":error", err.Error())
+				return
+			}
+			runtime.EventsEmit(a.ctx, "stream:"+streamID+":end")
+		}()
+		return nil
+	}
+
 	headers = a.api.Variables().ExpandAll(headers)
 	connection := a.api.AppConnection(appName)
 	headers = apps.MergeMetadata(headers, connection.Metadata)
 
-	client, err := grpc.NewClientFromString(target, connection.TLS)
+	client, err := connection.Client(target, endpoint)
 	if err != nil {
 		return fmt.Errorf("failed to create gRPC client: %w", err)
 	}
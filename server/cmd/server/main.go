@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"mime"
 	"net/http"
@@ -17,6 +20,10 @@ import (
 	"github.com/wham/kaja/v2/pkg/agent"
 	"github.com/wham/kaja/v2/pkg/api"
 	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/mcp"
+	"github.com/wham/kaja/v2/pkg/apps/rpc"
+	"github.com/wham/kaja/v2/pkg/limit"
+	"github.com/wham/kaja/v2/pkg/retry"
 )
 
 // GitRef is the git commit hash or tag, set at build time via ldflags
@@ -51,7 +58,10 @@ func main() {
 	// environment.
 	apiService := api.NewApiService(configurationPath, *editable, GitRef, "", nil)
 	twirpHandler := api.NewApiServer(apiService)
-	mux.Handle(twirpHandler.PathPrefix(), twirpHandler)
+	// An MCP server's sign-in comes back to this server, on the host the browser
+	// reached it at; the desktop has a loopback listener for it instead.
+	mux.Handle(twirpHandler.PathPrefix(), mcp.WithCallback(twirpHandler, configuration.PathPrefix))
+	mux.HandleFunc("GET "+mcp.CallbackPath, mcp.ServeCallback)
 
 	// The agent session. A script runs in a browser, so a deployed kaja can only answer
 	// an agent by forwarding the run to a window that has offered itself. The window makes
@@ -174,12 +184,19 @@ func main() {
 		// The reserved header names the app the call belongs to and goes no further: it is
 		// what the credential and the transport are looked up by.
 		appName := apps.TakeAppName(forwardHeaders)
+		endpoint := apps.TakeEndpoint(forwardHeaders)
 
 		// App targets (kaja-app://<id>) are invoked in-process by the app manager instead of
 		// being proxied. InvokeApp expands the headers and redacts what it reports back.
 		if apps.IsAppTarget(targetHeader) {
+			if apiService.AppStreams(targetHeader, r.PathValue("method")) {
+				grpc.ServeAppGRPCWebStream(w, r, r.PathValue("method"), func(ctx context.Context, method string, message []byte, headers map[string]string, send func([]byte) error) (*apps.InvokeResult, error) {
+					return apiService.InvokeAppStream(ctx, appName, targetHeader, method, message, headers, send)
+				}, forwardHeaders)
+				return
+			}
 			grpc.ServeAppGRPCWeb(w, r, r.PathValue("method"), func(method string, message []byte, headers map[string]string) (*apps.InvokeResult, error) {
-				return apiService.InvokeApp(targetHeader, method, message, headers)
+				return apiService.InvokeApp(r.Context(), appName, targetHeader, method, message, headers)
 			}, forwardHeaders)
 			return
 		}
@@ -202,23 +219,63 @@ func main() {
 		if strings.HasPrefix(contentType, "application/grpc-web") ||
 			strings.HasPrefix(contentType, "application/grpc-web-text") {
 
-			proxy, err := grpc.NewProxy(target, connection.TLS)
+			client, err := connection.Client(targetHeader, endpoint)
 			if err != nil {
-				slog.Error("Failed to create gRPC proxy", "error", err)
-				w.WriteHeader(http.StatusInternalServerError)
+				slog.Error("Failed to create gRPC client", "error", err)
+				grpc.WriteError(w, err)
 				return
 			}
-			proxy.ServeHTTP(w, r, r.PathValue("method"), forwardHeaders)
+			grpc.NewProxy(client).ServeHTTP(w, r, r.PathValue("method"), forwardHeaders)
 			return
 		} else {
-			proxy := httputil.NewSingleHostReverseProxy(target)
+			// The app's transport settles the scheme and presents its certificates.
+			upstream, client, err := connection.HTTPClient(target)
+			if err != nil {
+				slog.Error("Failed to create Twirp client", "error", err)
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+
+			if connection.Retry.Enabled() {
+				// A retried request is sent again, so its body has to still be there
+				// to send.
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					http.Error(w, "Failed to read request", http.StatusBadRequest)
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				r.GetBody = func() (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader(body)), nil
+				}
+			}
+
+			// A call over the app's limits waits for its turn before it is proxied.
+			release, queued, err := connection.Enter(r.Context())
+			if err != nil {
+				return
+			}
+			defer release()
+
+			var attempts retry.Attempts
+			proxy := httputil.NewSingleHostReverseProxy(upstream)
+			proxy.Transport = connection.Retry.Transport(client.Transport, func(recorded retry.Attempts) {
+				attempts = recorded
+			})
+			proxy.ModifyResponse = func(response *http.Response) error {
+				// Every attempt the call took, and its wait to make the first, are
+				// reported as its upstream hop, the way a gRPC call reports its own.
+				grpc.SetUpstreamHeaders(response.Header, limit.Record(attempts.Record(nil), queued), nil)
+				// A failure reaches the client as a Twirp error whatever refused it.
+				return rpc.RewriteTwirpError(response)
+			}
 			proxy.Director = func(req *http.Request) {
-				req.Host = target.Host
-				req.URL.Scheme = target.Scheme
-				req.URL.Host = target.Host
+				req.Host = upstream.Host
+				req.URL.Scheme = upstream.Scheme
+				req.URL.Host = upstream.Host
 				// Replace /target/ with /twirp/ and append to the target path.
 				path := strings.Replace(req.URL.Path, "/target/", "/twirp/", 1)
-				req.URL.Path = target.Path + path
+				req.URL.Path = upstream.Path + path
 				for name, value := range forwardHeaders {
 					req.Header.Set(name, value)
 				}
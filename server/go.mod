@@ -4,11 +4,16 @@ go 1.25.0
 
 require (
 	github.com/evanw/esbuild v0.28.1
+	github.com/fsnotify/fsnotify v1.10.1
+	github.com/gorilla/websocket v1.5.3
+	github.com/klauspost/compress v1.18.0
 	github.com/twitchtv/twirp v8.1.3+incompatible
+	github.com/vektah/gqlparser/v2 v2.5.60
 	github.com/wham/kaja/v2/protoc-gen-kaja v0.0.0
 	github.com/wham/protoc-go v0.0.0-20260615005337-eaf780362c1c
 	google.golang.org/grpc v1.83.0
 	google.golang.org/protobuf v1.36.11
+	modernc.org/sqlite v1.59.0
 	sigs.k8s.io/yaml v1.6.0
 )
 
@@ -17,11 +22,20 @@ replace github.com/wham/kaja/v2/protoc-gen-kaja => ../protoc-gen-kaja
 require (
 	golang.org/x/net v0.57.0 // indirect
 	golang.org/x/text v0.40.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20260729162451-8efbd57d26e0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260729162451-8efbd57d26e0
 )
 
 require (
+	github.com/agnivade/levenshtein v1.2.1 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-isatty v0.0.24 // indirect
+	github.com/ncruces/go-strftime v1.0.0 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
 	go.yaml.in/yaml/v2 v2.4.4 // indirect
 	golang.org/x/sys v0.47.0 // indirect
+	modernc.org/libc v1.75.7 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.12.1 // indirect
 )
@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
@@ -24,6 +25,11 @@ const (
 	upstreamErrorTrailer           = "kaja-upstream-error"
 )
 
+// questionTrailer carries what the app needs answered before it can finish the
+// call (apps.Question, as JSON). The response message is empty; the client asks,
+// and makes the call again with the answer.
+const questionTrailer = "kaja-question"
+
 // AppInvoker invokes an in-process app method given the de-framed request message
 // and returns the invocation result (response message plus any upstream headers).
 type AppInvoker func(method string, message []byte, headers map[string]string) (*apps.InvokeResult, error)
@@ -47,24 +53,77 @@ func ServeAppGRPCWeb(w http.ResponseWriter, r *http.Request, method string, invo
 	result, err := invoke(method, message, headers)
 	if err != nil {
 		slog.Error("App invocation failed", "method", method, "error", err)
-		var upstream *apps.UpstreamError
-		if errors.As(err, &upstream) {
-			// An upstream HTTP failure maps to the closest gRPC status so a plain
-			// gRPC-Web client still sees a sensible error, but the failure itself -
-			// status, message, request line, body - rides whole in its own trailer.
-			// That is what the client shows: the HTTP call failed, and the gRPC
-			// status it was tunnelled through is not part of the story. The
-			// exchanged headers come along too (a 401 is exactly when they matter).
-			trailers := upstreamHeaderTrailers(upstream.RequestHeaders, upstream.ResponseHeaders)
-			trailers[upstreamErrorTrailer] = string(upstream.JSON())
-			writeGRPCWebText(w, nil, grpcStatusFromHTTP(upstream.Status), upstream.Error(), trailers)
-			return
+		writeAppError(w, err)
+		return
+	}
+	trailers := upstreamHeaderTrailers(result.RequestHeaders, result.ResponseHeaders)
+	body := result.Body
+	if result.Question != nil {
+		question, _ := json.Marshal(result.Question)
+		trailers[questionTrailer] = string(question)
+		// An empty message still has to be one, or the client has no response to
+		// read the trailers of.
+		body = []byte{}
+	}
+	writeGRPCWebText(w, body, 0, "", trailers)
+}
+
+// AppStreamInvoker invokes a server-streaming in-process app method, handing each
+// response message to send as it comes.
+type AppStreamInvoker func(ctx context.Context, method string, message []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error)
+
+// ServeAppGRPCWebStream is ServeAppGRPCWeb for a server-streaming method. Each
+// message is written and flushed as its own base64 chunk, which a gRPC-Web-text
+// client decodes as it arrives, and the trailers follow the last of them.
+func ServeAppGRPCWebStream(w http.ResponseWriter, r *http.Request, method string, invoke AppStreamInvoker, headers map[string]string) {
+	isText := strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc-web-text")
+
+	message, err := readGRPCWebMessage(r.Body, isText)
+	if err != nil {
+		slog.Error("Failed to read gRPC-Web app request", "error", err)
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc-web-text")
+	flusher, _ := w.(http.Flusher)
+	send := func(message []byte) error {
+		if _, err := w.Write([]byte(base64.StdEncoding.EncodeToString(dataFrame(message)))); err != nil {
+			return err
 		}
-		// gRPC status 2 = UNKNOWN; the browser surfaces grpc-message as the error.
-		writeGRPCWebText(w, nil, 2, err.Error(), nil)
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	result, err := invoke(r.Context(), method, message, headers, send)
+	if err != nil {
+		// The messages already sent stand; the failure ends the stream.
+		slog.Error("App stream failed", "method", method, "error", err)
+		writeAppError(w, err)
 		return
 	}
-	writeGRPCWebText(w, result.Body, 0, "", upstreamHeaderTrailers(result.RequestHeaders, result.ResponseHeaders))
+	writeGRPCWebText(w, nil, 0, "", upstreamHeaderTrailers(result.RequestHeaders, result.ResponseHeaders))
+}
+
+// writeAppError ends a response with the trailers of a failed app call.
+func writeAppError(w http.ResponseWriter, err error) {
+	var upstream *apps.UpstreamError
+	if errors.As(err, &upstream) {
+		// An upstream HTTP failure maps to the closest gRPC status so a plain
+		// gRPC-Web client still sees a sensible error, but the failure itself -
+		// status, message, request line, body - rides whole in its own trailer.
+		// That is what the client shows: the HTTP call failed, and the gRPC
+		// status it was tunnelled through is not part of the story. The
+		// exchanged headers come along too (a 401 is exactly when they matter).
+		trailers := upstreamHeaderTrailers(upstream.RequestHeaders, upstream.ResponseHeaders)
+		trailers[upstreamErrorTrailer] = string(upstream.JSON())
+		writeGRPCWebText(w, nil, grpcStatusFromHTTP(upstream.Status), upstream.Error(), trailers)
+		return
+	}
+	// gRPC status 2 = UNKNOWN; the browser surfaces grpc-message as the error.
+	writeGRPCWebText(w, nil, 2, err.Error(), nil)
 }
 
 // upstreamHeaderTrailers encodes an app's exchanged upstream headers as gRPC-Web
@@ -81,6 +140,15 @@ func upstreamHeaderTrailers(requestHeaders, responseHeaders map[string]string) m
 	return trailers
 }
 
+// SetUpstreamHeaders reports an upstream hop on a response that isn't gRPC-Web,
+// and so has no trailers to carry it: a proxied Twirp call. The names and the
+// encoding are the trailers', which the client reads from headers as well.
+func SetUpstreamHeaders(header http.Header, requestHeaders, responseHeaders map[string]string) {
+	for name, value := range upstreamHeaderTrailers(requestHeaders, responseHeaders) {
+		header.Set(name, value)
+	}
+}
+
 // encodeHeaderTrailer JSON-encodes a header map for a trailer value without
 // HTML-escaping, so header names/values (which may contain <, >, &) stay
 // readable. Returns false for an empty map so no trailer is emitted.
@@ -134,11 +202,7 @@ func grpcStatusFromHTTP(status int) int {
 func writeGRPCWebText(w http.ResponseWriter, message []byte, status int, grpcMessage string, extraTrailers map[string]string) {
 	var full []byte
 	if message != nil {
-		frame := make([]byte, 5+len(message))
-		frame[0] = 0 // data frame
-		binary.BigEndian.PutUint32(frame[1:5], uint32(len(message)))
-		copy(frame[5:], message)
-		full = frame
+		full = dataFrame(message)
 	}
 
 	trailers := fmt.Sprintf("grpc-status: %d\r\ngrpc-message: %s\r\n", status, escapeTrailerValue(grpcMessage))
@@ -155,6 +219,15 @@ func writeGRPCWebText(w http.ResponseWriter, message []byte, status int, grpcMes
 	w.Write([]byte(base64.StdEncoding.EncodeToString(full)))
 }
 
+// dataFrame frames one response message.
+func dataFrame(message []byte) []byte {
+	frame := make([]byte, 5+len(message))
+	frame[0] = 0 // data frame
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(message)))
+	copy(frame[5:], message)
+	return frame
+}
+
 // escapeTrailerValue percent-encodes everything a gRPC-Web trailer line cannot
 // carry verbatim. Trailers are a text block a client splits on CRLF and reads
 // byte by byte as Latin-1, so a UTF-8 payload arrives mangled ("—" as "â€""),
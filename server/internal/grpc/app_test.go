@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
@@ -98,6 +99,22 @@ func TestServeAppGRPCWebUpstreamHeaders(t *testing.T) {
 	}
 }
 
+// A question the app needs answered rides in its own trailer, on an empty
+// message the client can read the trailers of.
+func TestServeAppGRPCWebQuestion(t *testing.T) {
+	w := serveText("svc/Method", grpcWebTextFrame([]byte{1}), func(string, []byte, map[string]string) (*apps.InvokeResult, error) {
+		return &apps.InvokeResult{Question: &apps.Question{ID: "q1", Message: "Which city?"}}, nil
+	})
+
+	message, trailers := parseGRPCWebText(t, w.Body.String())
+	if message == nil || len(message) != 0 {
+		t.Errorf("message = %v, want an empty one", message)
+	}
+	if got := trailerValue(t, trailers, "kaja-question"); got != `{"id":"q1","message":"Which city?"}` {
+		t.Errorf("kaja-question = %q", got)
+	}
+}
+
 func TestServeAppGRPCWebError(t *testing.T) {
 	w := serveText("svc/Method", grpcWebTextFrame([]byte{1}), func(string, []byte, map[string]string) (*apps.InvokeResult, error) {
 		return nil, fmt.Errorf("upstream 404\nnot found")
@@ -200,6 +217,51 @@ func TestTrailerValuesSurviveNonASCII(t *testing.T) {
 	}
 }
 
+// chunkRecorder keeps each write to the response apart, and counts the flushes
+// between them.
+type chunkRecorder struct {
+	*httptest.ResponseRecorder
+	chunks  []string
+	flushes int
+}
+
+func (r *chunkRecorder) Write(p []byte) (int, error) {
+	r.chunks = append(r.chunks, string(p))
+	return r.ResponseRecorder.Write(p)
+}
+
+func (r *chunkRecorder) Flush() { r.flushes++ }
+
+// Each streamed message goes out as a base64 chunk of its own, flushed as it is
+// sent, and the trailers close the stream in one more.
+func TestServeAppGRPCWebStream(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/target/svc/Watch", strings.NewReader(grpcWebTextFrame([]byte{1})))
+	r.Header.Set("Content-Type", "application/grpc-web-text")
+	w := &chunkRecorder{ResponseRecorder: httptest.NewRecorder()}
+	ServeAppGRPCWebStream(w, r, "svc/Watch", func(ctx context.Context, method string, message []byte, headers map[string]string, send func([]byte) error) (*apps.InvokeResult, error) {
+		for _, m := range [][]byte{{7}, {8, 9}} {
+			if err := send(m); err != nil {
+				return nil, err
+			}
+		}
+		return &apps.InvokeResult{RequestHeaders: map[string]string{"Accept": "text/event-stream"}}, nil
+	}, nil)
+
+	if len(w.chunks) != 3 || w.flushes != 2 {
+		t.Fatalf("chunks = %q, flushes = %d, want two messages flushed and the trailers", w.chunks, w.flushes)
+	}
+	for i, want := range [][]byte{{7}, {8, 9}} {
+		message, trailers := parseGRPCWebText(t, w.chunks[i])
+		if string(message) != string(want) || trailers != "" {
+			t.Errorf("chunk %d = %v %q, want message %v", i, message, trailers, want)
+		}
+	}
+	_, trailers := parseGRPCWebText(t, w.chunks[2])
+	if !strings.Contains(trailers, "grpc-status: 0") || trailerValue(t, trailers, "kaja-upstream-request-headers") != `{"Accept":"text/event-stream"}` {
+		t.Errorf("trailers = %q", trailers)
+	}
+}
+
 // trailerValue reads one trailer by name, undoing the percent-escaping the
 // client undoes with decodeURIComponent.
 func trailerValue(t *testing.T, trailers string, name string) string {
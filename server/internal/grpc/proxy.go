@@ -2,26 +2,36 @@ package grpc
 
 import (
 	"encoding/base64"
-	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 
 	pkggrpc "github.com/wham/kaja/v2/pkg/grpc"
+	"github.com/wham/kaja/v2/pkg/retry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Proxy struct {
 	client *pkggrpc.Client
 }
 
-func NewProxy(target *url.URL, options pkggrpc.TLSOptions) (*Proxy, error) {
-	return &Proxy{
-		client: pkggrpc.NewClient(target, options),
-	}, nil
+// NewProxy proxies gRPC-Web calls to client's upstream. The client carries
+// everything about how the app connects, endpoint included.
+func NewProxy(client *pkggrpc.Client) *Proxy {
+	return &Proxy{client: client}
+}
+
+// WriteError answers a gRPC-Web call that failed before it reached an upstream,
+// with the status err carries.
+func WriteError(w http.ResponseWriter, err error) {
+	failure := upstreamStatus(err)
+	w.Header().Set("Content-Type", "application/grpc-web-text")
+	writeGRPCWebText(w, nil, int(failure.Code()), failure.Message(), nil)
 }
 
 func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request, method string, headers map[string]string) {
@@ -40,47 +50,40 @@ func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request, method string,
 
 	slog.Info("Invoking gRPC server", "method", method, "tls", p.client.UseTLS(), "headers", len(headers))
 
+	w.Header().Set("Content-Type", "application/grpc-web-text")
+
 	res, err := p.client.InvokeWithTimeout(method, message, 5*time.Second, headers)
 	if err != nil {
 		slog.Error("gRPC invocation failed", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		// The upstream's own status travels on, so a response too large for the
+		// app's limit reads as RESOURCE_EXHAUSTED rather than as kaja failing.
+		failure := upstreamStatus(err)
+		// A call that was retried reports each attempt, failed ones and all.
+		var trailers map[string]string
+		var retried *retry.Error
+		if errors.As(err, &retried) {
+			trailers = upstreamHeaderTrailers(retried.Attempts.Record(nil), nil)
+		}
+		writeGRPCWebText(w, nil, int(failure.Code()), failure.Message(), trailers)
 		return
 	}
 
-	slog.Info("Received gRPC response", "length", len(res))
-
-	// Build the gRPC-Web data frame:
-	// - 1 byte flag (0 for data frame)
-	// - 4 byte big-endian uint32 length
-	// - followed by the response message bytes
-	frame := make([]byte, 5+len(res))
-	frame[0] = 0 // flag for data frame
-	binary.BigEndian.PutUint32(frame[1:5], uint32(len(res)))
-	copy(frame[5:], res)
-
-	// Build the trailers frame containing the gRPC status code.
-	// The trailers frame has:
-	// - 1 byte flag (0x80 for trailers)
-	// - 4 byte big-endian uint32 length
-	// - trailers formatted as "key: value\r\n", ending with an extra "\r\n" line.
-	trailers := "grpc-status: 0\r\ngrpc-message: \r\n"
-	trailersBytes := []byte(trailers)
-	trailerFrame := make([]byte, 5+len(trailersBytes))
-	trailerFrame[0] = 0x80 // flag for trailer frame
-	binary.BigEndian.PutUint32(trailerFrame[1:5], uint32(len(trailersBytes)))
-	copy(trailerFrame[5:], trailersBytes)
-
-	// Concatenate data frame and trailer frame to form complete response.
-	fullResponse := append(frame, trailerFrame...)
-
-	// Optionally, if sending gRPC-Web text response, base64-encode the fullResponse:
-	encodedResponse := base64.StdEncoding.EncodeToString(fullResponse)
+	slog.Info("Received gRPC response", "length", len(res.Body))
 
-	w.Header().Set("Content-Type", "application/grpc-web-text")
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(encodedResponse)))
-	if _, err := w.Write([]byte(encodedResponse)); err != nil {
-		slog.Error("Error writing response", "error", err)
+	// The encoding the call settled on each way rides in the same trailers an
+	// in-process app reports its upstream hop in.
+	writeGRPCWebText(w, res.Body, 0, "", upstreamHeaderTrailers(res.RequestHeaders, res.ResponseHeaders))
+}
+
+// upstreamStatus is the gRPC status a failed call ended with. A call that never
+// reached the server - a bad target, a certificate that won't load - has none,
+// and reads as UNKNOWN with kaja's own message.
+func upstreamStatus(err error) *status.Status {
+	var carrier interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &carrier) {
+		return carrier.GRPCStatus()
 	}
+	return status.New(codes.Unknown, err.Error())
 }
 
 func (p *Proxy) readGRPCWebMessage(r io.Reader, isText bool) ([]byte, error) {
@@ -5,16 +5,33 @@ import (
 	"errors"
 	fmt "fmt"
 	"log/slog"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/wham/kaja/v2/internal/tempdir"
 	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/anthropic"
 	"github.com/wham/kaja/v2/pkg/apps/folder"
+	"github.com/wham/kaja/v2/pkg/apps/graphql"
+	"github.com/wham/kaja/v2/pkg/apps/jsonrpc"
 	"github.com/wham/kaja/v2/pkg/apps/mcp"
 	"github.com/wham/kaja/v2/pkg/apps/openai"
 	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/apps/rawhttp"
 	"github.com/wham/kaja/v2/pkg/apps/rpc"
+	"github.com/wham/kaja/v2/pkg/apps/sqlite"
+	"github.com/wham/kaja/v2/pkg/apps/websocket"
 	"github.com/wham/kaja/v2/pkg/grpc"
+	"github.com/wham/kaja/v2/pkg/limit"
+	"github.com/wham/kaja/v2/pkg/retry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ApiService struct {
@@ -25,6 +42,8 @@ type ApiService struct {
 	buildNumber            string
 	variableStore          VariableStore
 	apps                   *apps.Manager
+	turns                  sync.Map // map[string]*atomic.Uint64 - keyed by app name
+	gates                  sync.Map // map[string]*limit.Gate - keyed by app name
 }
 
 // NewApiService builds the service. variableStore is where a "${secret}"
@@ -40,12 +59,18 @@ func NewApiService(configurationPath string, canUpdateConfiguration bool, gitRef
 		buildNumber:            buildNumber,
 		variableStore:          variableStore,
 		apps: apps.NewManager(map[string]apps.App{
-			"grpc":    rpc.New("grpc"),
-			"twirp":   rpc.New("twirp"),
-			"openapi": openapi.New(),
-			"openai":  openai.New(),
-			"folder":  folder.New(),
-			"mcp":     mcp.New(),
+			"grpc":      rpc.New("grpc"),
+			"twirp":     rpc.New("twirp"),
+			"openapi":   openapi.New(),
+			"openai":    openai.New(),
+			"anthropic": anthropic.New(),
+			"folder":    folder.New(),
+			"sqlite":    sqlite.New(),
+			"graphql":   graphql.New(),
+			"jsonrpc":   jsonrpc.New(),
+			"websocket": websocket.New(),
+			"http":      rawhttp.New(),
+			"mcp":       mcp.New(),
 		}),
 	}
 }
@@ -77,19 +102,61 @@ func (s *ApiService) variableStoreAvailable() bool {
 // every resolved value back out of the headers the app reports exchanging with
 // its upstream. Both request routers go through here, so neither can surface a
 // value kaja.json doesn't carry.
-func (s *ApiService) InvokeApp(target string, method string, message []byte, headers map[string]string) (*apps.InvokeResult, error) {
+//
+// A call over the limits of the app named name waits here for its turn, until ctx
+// is done, and the wait is reported with what it exchanged.
+func (s *ApiService) InvokeApp(ctx context.Context, name string, target string, method string, message []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	return s.invokeApp(ctx, name, headers, func(expanded map[string]string) (*apps.InvokeResult, error) {
+		return s.apps.Invoke(target, method, message, expanded)
+	})
+}
+
+// AppStreams reports whether method of the opened app at target is
+// server-streaming, and so is invoked with InvokeAppStream.
+func (s *ApiService) AppStreams(target string, method string) bool {
+	return s.apps.Streams(target, method)
+}
+
+// InvokeAppStream is InvokeApp for a server-streaming method: each response
+// message is handed to send as the app produces it. The call holds its place
+// under the app's limits until the stream ends.
+func (s *ApiService) InvokeAppStream(ctx context.Context, name string, target string, method string, message []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error) {
+	return s.invokeApp(ctx, name, headers, func(expanded map[string]string) (*apps.InvokeResult, error) {
+		return s.apps.InvokeStream(ctx, target, method, message, expanded, send)
+	})
+}
+
+func (s *ApiService) invokeApp(ctx context.Context, name string, headers map[string]string, invoke func(expanded map[string]string) (*apps.InvokeResult, error)) (*apps.InvokeResult, error) {
 	resolver := s.Variables()
 
-	result, err := s.apps.Invoke(target, method, message, resolver.ExpandAll(headers))
+	var gate *limit.Gate
+	if value, ok := s.gates.Load(name); ok && name != "" {
+		gate = value.(*limit.Gate)
+	}
+	release, queued, err := gate.Enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	expanded := resolver.ExpandAll(headers)
+	for name, value := range headers {
+		// An answer is what the user typed in reply to the app's question, passed
+		// on as written: a "${NAME}" in it is their text, not a variable.
+		if strings.EqualFold(name, apps.AnswerHeader) {
+			expanded[name] = value
+		}
+	}
+	result, err := invoke(expanded)
 	if err != nil {
 		var upstream *apps.UpstreamError
 		if errors.As(err, &upstream) {
-			upstream.RequestHeaders = resolver.Redact(upstream.RequestHeaders, headers)
+			upstream.RequestHeaders = limit.Record(resolver.Redact(upstream.RequestHeaders, headers), queued)
 		}
 		return nil, err
 	}
 
-	result.RequestHeaders = resolver.Redact(result.RequestHeaders, headers)
+	result.RequestHeaders = limit.Record(resolver.Redact(result.RequestHeaders, headers), queued)
 	return result, nil
 }
 
@@ -147,6 +214,15 @@ func (s *ApiService) OpenApp(ctx context.Context, req *OpenAppRequest) (*OpenApp
 
 	logger := NewLogger()
 	logger.info("Opening app: " + appType)
+	if !s.mayLaunch(req.App.GetMcp()) {
+		logger.error("Failed to open app", errors.New("this kaja only launches the local servers its configuration names"))
+		return &OpenAppResponse{Status: OpenStatus_OPEN_STATUS_ERROR, Logs: logger.logs}, nil
+	}
+
+	if mcpApp := req.App.GetMcp(); mcpApp != nil {
+		s.addMcpRequests(mcpApp, parameters, logger)
+		addMcpCallback(ctx, parameters)
+	}
 
 	// Expand ${NAME} variable references in the creation parameters (URLs,
 	// tokens, ...) from the variables configured in kaja.json.
@@ -166,6 +242,13 @@ func (s *ApiService) OpenApp(ctx context.Context, req *OpenAppRequest) (*OpenApp
 		return &OpenAppResponse{Status: OpenStatus_OPEN_STATUS_ERROR, Logs: logger.logs}, nil
 	}
 
+	// An in-process app's limits are read when it opens, like the rest of its
+	// parameters. The gate is the app's rather than the instance's, so opening it
+	// again keeps the calls already waiting and the budget already spent.
+	if name := req.App.GetName(); name != "" && apps.IsAppTarget(result.Target) {
+		s.gate(name, limit.Parse(parameters))
+	}
+
 	return &OpenAppResponse{
 		Status:   OpenStatus_OPEN_STATUS_OK,
 		Logs:     logger.logs,
@@ -175,18 +258,37 @@ func (s *ApiService) OpenApp(ctx context.Context, req *OpenAppRequest) (*OpenApp
 	}, nil
 }
 
-// AppConnection is how a grpc app reaches its upstream: the credential it sends
-// with every call, and the transport security it uses. Both are read from
-// kaja.json when the call is made rather than held from Open, so replacing a
-// token takes effect on the next call instead of the next compile.
+// AppConnection is how a grpc or twirp app reaches its upstream: the credential
+// it sends with every call, the transport security it uses, how each call is
+// compressed and bounded, which of its endpoints a call goes to, how a call that
+// fails in a way that passes is retried, and how fast and how widely its calls
+// may go out. All of it is read from kaja.json when the call is made rather than
+// held from Open, so replacing a token takes effect on the next call instead of
+// the next compile.
 type AppConnection struct {
 	Metadata map[string]string
 	TLS      grpc.TLSOptions
+	Calls    grpc.CallOptions
+	// Endpoints are where the app's calls may go, its url first. LoadBalancing is
+	// the policy across the addresses any one of them resolves to.
+	Endpoints     []string
+	LoadBalancing string
+	// Retry is the app's retry policy and Limits how fast and how widely its
+	// calls may go out.
+	Retry  retry.Policy
+	Limits limit.Limits
+
+	// turn counts the app's calls, for taking its endpoints in turn. It is the
+	// app's own and outlives the connection read for any one call.
+	turn *atomic.Uint64
+	// gate enforces Limits across every call the app makes, so it too is the
+	// app's own.
+	gate *limit.Gate
 }
 
 // AppConnection resolves how the named app connects. The name arrives on the
 // reserved header the client sends with every call; an app that isn't there, or
-// isn't a grpc app, connects the way it always has.
+// is neither a grpc nor a twirp app, connects the way it always has.
 func (s *ApiService) AppConnection(name string) AppConnection {
 	if name == "" {
 		return AppConnection{}
@@ -198,15 +300,114 @@ func (s *ApiService) AppConnection(name string) AppConnection {
 			continue
 		}
 		appType, parameters := flattenApp(app)
-		if appType != "grpc" {
+		if appType != "grpc" && appType != "twirp" {
 			return AppConnection{}
 		}
 		expandAppParameters(parameters, NewResolver(configuration.Variables, s.variableStore), NewLogger())
-		return AppConnection{Metadata: rpc.Metadata(parameters), TLS: rpc.TLS(parameters)}
+		limits := limit.Parse(parameters)
+		if appType == "twirp" {
+			// A twirp app holds a credential and a transport the way a grpc app
+			// does; how its calls are spoken and spread is gRPC's alone.
+			return AppConnection{
+				Metadata: rpc.Metadata(parameters),
+				TLS:      rpc.TLS(parameters),
+				Retry:    retry.Parse(parameters),
+				Limits:   limits,
+				gate:     s.gate(name, limits),
+			}
+		}
+		turn, _ := s.turns.LoadOrStore(name, &atomic.Uint64{})
+		return AppConnection{
+			Metadata:      rpc.Metadata(parameters),
+			TLS:           rpc.TLS(parameters),
+			Calls:         rpc.Calls(parameters),
+			Endpoints:     rpc.Endpoints(parameters),
+			LoadBalancing: rpc.LoadBalancing(parameters),
+			Retry:         retry.Parse(parameters),
+			Limits:        limits,
+			turn:          turn.(*atomic.Uint64),
+			gate:          s.gate(name, limits),
+		}
 	}
 	return AppConnection{}
 }
 
+// gate is the named app's gate for limits. Calls share one for as long as the
+// limits stay what they were; changed limits start a new one, and the calls
+// already through the old one finish there.
+func (s *ApiService) gate(name string, limits limit.Limits) *limit.Gate {
+	if !limits.Enabled() {
+		s.gates.Delete(name)
+		return nil
+	}
+	for {
+		value, loaded := s.gates.LoadOrStore(name, limit.NewGate(limits))
+		gate := value.(*limit.Gate)
+		if !loaded || gate.Limits() == limits {
+			return gate
+		}
+		if fresh := limit.NewGate(limits); s.gates.CompareAndSwap(name, gate, fresh) {
+			return fresh
+		}
+	}
+}
+
+// HTTPClient is how a call to a twirp app goes out: target with its scheme
+// settled by the app's transport - an app that says "on" is called over https
+// whatever its URL says - and the client that presents the app's certificates.
+func (c AppConnection) HTTPClient(target *url.URL) (*url.URL, *http.Client, error) {
+	useTLS := c.TLS.UseTLS(target)
+	client, err := grpc.HTTPClient(useTLS, c.TLS)
+	if err != nil {
+		return nil, nil, err
+	}
+	settled := *target
+	if scheme := strings.ToLower(target.Scheme); scheme == "http" || scheme == "https" {
+		settled.Scheme = "http"
+		if useTLS {
+			settled.Scheme = "https"
+		}
+	}
+	return &settled, client, nil
+}
+
+// Enter waits for a call's turn under the app's limits, for a caller that makes
+// the call itself rather than through Client. It returns the release to make
+// once the call is done and how long the call waited.
+func (c AppConnection) Enter(ctx context.Context) (func(), time.Duration, error) {
+	return c.gate.Enter(ctx)
+}
+
+// Client builds the client one call goes out on. target is where the client was
+// told to send it, which is the app's url; pinned is the endpoint the call asked
+// for, or empty. An app with several endpoints takes them in turn. A pinned
+// endpoint has to be one the app lists: the app's credential goes wherever the
+// call does.
+func (c AppConnection) Client(target string, pinned string) (*grpc.Client, error) {
+	endpoint, err := c.endpoint(target, strings.TrimSpace(pinned))
+	if err != nil {
+		return nil, err
+	}
+	client, err := grpc.NewClientFromString(endpoint, c.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return client.WithCallOptions(c.Calls).WithLoadBalancing(c.LoadBalancing).WithRetry(c.Retry).WithGate(c.gate), nil
+}
+
+func (c AppConnection) endpoint(target string, pinned string) (string, error) {
+	if pinned != "" {
+		if slices.Contains(c.Endpoints, pinned) || (len(c.Endpoints) == 0 && pinned == target) {
+			return pinned, nil
+		}
+		return "", status.Errorf(codes.InvalidArgument, "%q is not one of the app's endpoints", pinned)
+	}
+	if len(c.Endpoints) < 2 || c.turn == nil {
+		return target, nil
+	}
+	return c.Endpoints[(c.turn.Add(1)-1)%uint64(len(c.Endpoints))], nil
+}
+
 // InspectGrpc reads the surface a grpc app would be opened with - reflecting the
 // server, or reading the proto directory - without creating the app, so the New
 // gRPC app form can fill itself in from what answered.
@@ -275,21 +476,94 @@ func (s *ApiService) InspectMcp(ctx context.Context, req *InspectMcpRequest) (*I
 		return nil, fmt.Errorf("mcp app is required")
 	}
 
+	if !s.mayLaunch(req.Mcp) {
+		return &InspectMcpResponse{Problem: &McpProblem{
+			Kind:    McpProblemKind_MCP_PROBLEM_TARGET,
+			Message: "This kaja only launches the local servers its configuration names.",
+		}}, nil
+	}
+
 	_, parameters := flattenApp(&ConfigurationApp{App: &ConfigurationApp_Mcp{Mcp: req.Mcp}})
 	expandAppParameters(parameters, s.Variables(), NewLogger())
+	addMcpCallback(ctx, parameters)
 
 	surface, problem := mcp.Inspect(parameters)
 	if problem != nil {
 		return &InspectMcpResponse{Problem: &McpProblem{
-			Kind:    mcpProblemKind(problem.Kind),
-			Message: problem.Message,
-			Detail:  problem.Detail,
+			Kind:                mcpProblemKind(problem.Kind),
+			Message:             problem.Message,
+			Detail:              problem.Detail,
+			AuthorizationUrl:    problem.AuthorizationURL,
+			AuthorizationServer: problem.AuthorizationServer,
 		}}, nil
 	}
 
 	return &InspectMcpResponse{Server: describeMcpServer(surface)}, nil
 }
 
+// mayLaunch says whether the app's local server, if it names one, may be
+// started. Launching a command is as trusted as writing kaja.json, so a kaja
+// that can't update its configuration starts only the commands it names.
+func (s *ApiService) mayLaunch(app *McpApp) bool {
+	if app.GetCommand() == "" || s.canUpdateConfiguration {
+		return true
+	}
+	configuration := loadConfigurationFile(s.configurationPath, NewLogger())
+	for _, configured := range configuration.Apps {
+		if mcpApp := configured.GetMcp(); mcpApp != nil &&
+			mcpApp.Command == app.Command &&
+			slices.Equal(mcpApp.Args, app.Args) &&
+			slices.Equal(mcpApp.Env, app.Env) &&
+			mcpApp.WorkingDir == app.WorkingDir {
+			return true
+		}
+	}
+	return false
+}
+
+// addMcpCallback has an mcp app's sign-ins come back to kaja's own server, where
+// the request that opens it came in through one.
+func addMcpCallback(ctx context.Context, parameters map[string]string) {
+	if callback := mcp.CallbackFrom(ctx); callback != "" {
+		parameters[mcp.CallbackParameter] = callback
+	}
+}
+
+// addMcpRequests adds what an mcp app's server may ask of kaja to its
+// parameters, read from the configuration: the folders of the folder apps its
+// roots name, as root_folders (name=path lines), and the parameters of its
+// sampling app under "sampling.". The browser only names those apps; their
+// paths and credentials are looked up here.
+func (s *ApiService) addMcpRequests(app *McpApp, parameters map[string]string, logger *Logger) {
+	configuration := loadConfigurationFile(s.configurationPath, NewLogger())
+	var roots []string
+	for _, configured := range configuration.Apps {
+		folder := configured.GetFolder()
+		if folder == nil || (len(app.Roots) > 0 && !slices.Contains(app.Roots, configured.Name)) {
+			continue
+		}
+		if path, err := filepath.Abs(folder.Path); err == nil {
+			roots = append(roots, configured.Name+"="+path)
+		}
+	}
+	parameters["root_folders"] = strings.Join(roots, "\n")
+
+	if app.SamplingApp == "" {
+		return
+	}
+	for _, configured := range configuration.Apps {
+		if configured.Name == app.SamplingApp && configured.GetOpenai() != nil {
+			_, sampling := flattenApp(configured)
+			for name, value := range sampling {
+				parameters["sampling."+name] = value
+			}
+			logger.info("Sampling with app: " + app.SamplingApp)
+			return
+		}
+	}
+	logger.error("Sampling is off", fmt.Errorf("there is no openai app named %q", app.SamplingApp))
+}
+
 var mcpProblemKinds = map[mcp.ProblemKind]McpProblemKind{
 	mcp.ProblemTarget:       McpProblemKind_MCP_PROBLEM_TARGET,
 	mcp.ProblemUnreachable:  McpProblemKind_MCP_PROBLEM_UNREACHABLE,
@@ -1854,9 +1854,16 @@ type McpProblem struct {
 	// One line, addressed to the user.
 	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	// The underlying transport or protocol error, verbatim.
-	Detail        string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	// Where the user signs in, when the server wants an OAuth token kaja can get
+	// for them. Once they have, reading the server again succeeds.
+	AuthorizationUrl string `protobuf:"bytes,4,opt,name=authorization_url,json=authorizationUrl,proto3" json:"authorization_url,omitempty"`
+	// The authorization server a server that wants a token names, when the app
+	// doesn't sign in yet. Nothing has been started there; the app signing in is
+	// what gets an authorization_url.
+	AuthorizationServer string `protobuf:"bytes,5,opt,name=authorization_server,json=authorizationServer,proto3" json:"authorization_server,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *McpProblem) Reset() {
@@ -1910,6 +1917,20 @@ func (x *McpProblem) GetDetail() string {
 	return ""
 }
 
+func (x *McpProblem) GetAuthorizationUrl() string {
+	if x != nil {
+		return x.AuthorizationUrl
+	}
+	return ""
+}
+
+func (x *McpProblem) GetAuthorizationServer() string {
+	if x != nil {
+		return x.AuthorizationServer
+	}
+	return ""
+}
+
 type CompileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        CompileStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=CompileStatus" json:"status,omitempty"`
@@ -2818,6 +2839,12 @@ type ConfigurationApp struct {
 	//	*ConfigurationApp_Openai
 	//	*ConfigurationApp_Folder
 	//	*ConfigurationApp_Mcp
+	//	*ConfigurationApp_Anthropic
+	//	*ConfigurationApp_Sqlite
+	//	*ConfigurationApp_Graphql
+	//	*ConfigurationApp_Jsonrpc
+	//	*ConfigurationApp_Websocket
+	//	*ConfigurationApp_Http
 	App           isConfigurationApp_App `protobuf_oneof:"app"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -2921,6 +2948,60 @@ func (x *ConfigurationApp) GetMcp() *McpApp {
 	return nil
 }
 
+func (x *ConfigurationApp) GetAnthropic() *AnthropicApp {
+	if x != nil {
+		if x, ok := x.App.(*ConfigurationApp_Anthropic); ok {
+			return x.Anthropic
+		}
+	}
+	return nil
+}
+
+func (x *ConfigurationApp) GetSqlite() *SqliteApp {
+	if x != nil {
+		if x, ok := x.App.(*ConfigurationApp_Sqlite); ok {
+			return x.Sqlite
+		}
+	}
+	return nil
+}
+
+func (x *ConfigurationApp) GetGraphql() *GraphqlApp {
+	if x != nil {
+		if x, ok := x.App.(*ConfigurationApp_Graphql); ok {
+			return x.Graphql
+		}
+	}
+	return nil
+}
+
+func (x *ConfigurationApp) GetJsonrpc() *JsonrpcApp {
+	if x != nil {
+		if x, ok := x.App.(*ConfigurationApp_Jsonrpc); ok {
+			return x.Jsonrpc
+		}
+	}
+	return nil
+}
+
+func (x *ConfigurationApp) GetWebsocket() *WebsocketApp {
+	if x != nil {
+		if x, ok := x.App.(*ConfigurationApp_Websocket); ok {
+			return x.Websocket
+		}
+	}
+	return nil
+}
+
+func (x *ConfigurationApp) GetHttp() *HttpApp {
+	if x != nil {
+		if x, ok := x.App.(*ConfigurationApp_Http); ok {
+			return x.Http
+		}
+	}
+	return nil
+}
+
 type isConfigurationApp_App interface {
 	isConfigurationApp_App()
 }
@@ -2949,6 +3030,30 @@ type ConfigurationApp_Mcp struct {
 	Mcp *McpApp `protobuf:"bytes,8,opt,name=mcp,proto3,oneof"`
 }
 
+type ConfigurationApp_Anthropic struct {
+	Anthropic *AnthropicApp `protobuf:"bytes,9,opt,name=anthropic,proto3,oneof"`
+}
+
+type ConfigurationApp_Sqlite struct {
+	Sqlite *SqliteApp `protobuf:"bytes,10,opt,name=sqlite,proto3,oneof"`
+}
+
+type ConfigurationApp_Graphql struct {
+	Graphql *GraphqlApp `protobuf:"bytes,11,opt,name=graphql,proto3,oneof"`
+}
+
+type ConfigurationApp_Jsonrpc struct {
+	Jsonrpc *JsonrpcApp `protobuf:"bytes,12,opt,name=jsonrpc,proto3,oneof"`
+}
+
+type ConfigurationApp_Websocket struct {
+	Websocket *WebsocketApp `protobuf:"bytes,13,opt,name=websocket,proto3,oneof"`
+}
+
+type ConfigurationApp_Http struct {
+	Http *HttpApp `protobuf:"bytes,14,opt,name=http,proto3,oneof"`
+}
+
 func (*ConfigurationApp_Grpc) isConfigurationApp_App() {}
 
 func (*ConfigurationApp_Twirp) isConfigurationApp_App() {}
@@ -2961,6 +3066,18 @@ func (*ConfigurationApp_Folder) isConfigurationApp_App() {}
 
 func (*ConfigurationApp_Mcp) isConfigurationApp_App() {}
 
+func (*ConfigurationApp_Anthropic) isConfigurationApp_App() {}
+
+func (*ConfigurationApp_Sqlite) isConfigurationApp_App() {}
+
+func (*ConfigurationApp_Graphql) isConfigurationApp_App() {}
+
+func (*ConfigurationApp_Jsonrpc) isConfigurationApp_App() {}
+
+func (*ConfigurationApp_Websocket) isConfigurationApp_App() {}
+
+func (*ConfigurationApp_Http) isConfigurationApp_App() {}
+
 // GrpcApp calls a gRPC service. Its proto surface comes from a workspace-relative
 // proto_dir, or from server reflection when reflection is set. headers are
 // forwarded (as metadata) with each request.
@@ -2997,9 +3114,50 @@ type GrpcApp struct {
 	Password string `protobuf:"bytes,13,opt,name=password,proto3" json:"password,omitempty"`
 	// Metadata key the "apikey" credential is sent under. Empty means
 	// "x-api-key".
-	ApiKeyName    string `protobuf:"bytes,14,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ApiKeyName string `protobuf:"bytes,14,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
+	// How request messages are compressed: "gzip", "zstd", or "none". Empty means
+	// none. The server answers in whichever of those it picks, and kaja reads any
+	// of them.
+	Compression string `protobuf:"bytes,15,opt,name=compression,proto3" json:"compression,omitempty"`
+	// The largest response message kaja accepts, in bytes. Zero means gRPC's own
+	// 4 MB, which a long list outgrows quickly.
+	MaxReceiveBytes int64 `protobuf:"varint,16,opt,name=max_receive_bytes,json=maxReceiveBytes,proto3" json:"max_receive_bytes,omitempty"`
+	// The largest request message kaja sends, in bytes. Zero means no limit of
+	// kaja's own.
+	MaxSendBytes int64 `protobuf:"varint,17,opt,name=max_send_bytes,json=maxSendBytes,proto3" json:"max_send_bytes,omitempty"`
+	// The protocol calls are spoken in: "grpc", "grpc-web" or "connect". Empty
+	// means grpc. gRPC-Web and Connect reach a service over plain HTTP/1.1, behind
+	// a proxy that doesn't pass HTTP/2 through; neither serves reflection, so
+	// those apps are described by proto_dir.
+	Protocol string `protobuf:"bytes,18,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// More replicas of the service at url, each a URL of the same form. Calls are
+	// spread across url and these in turn, a script can pin one with
+	// Call.on(endpoint), and the Headers view reports which served each call.
+	Endpoints []string `protobuf:"bytes,19,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// How calls are spread across the addresses one endpoint resolves to:
+	// "pick_first" or "round_robin". Empty means pick_first. round_robin is what
+	// a "dns:///" name with several replicas behind it wants.
+	LoadBalancing string `protobuf:"bytes,20,opt,name=load_balancing,json=loadBalancing,proto3" json:"load_balancing,omitempty"`
+	// Retrying a call that fails in a way that passes. Off unless
+	// retry_max_attempts is above 1; it counts the first attempt. The wait before
+	// each retry starts at retry_backoff_ms (200 when zero) and doubles up to
+	// retry_max_backoff_ms (5000 when zero); a Retry-After header or a gRPC
+	// RetryInfo detail replaces it, and a call the server asks to wait longer than
+	// that fails instead. retry_codes are the failures retried, as gRPC code
+	// names and HTTP statuses; empty means UNAVAILABLE, 429, 502, 503 and 504.
+	RetryMaxAttempts  int64    `protobuf:"varint,21,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,22,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,23,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,24,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// How fast and how widely the app's calls go out: at most rate_limit calls a
+	// second, in bursts of up to rate_limit_burst (one second's worth when zero),
+	// and at most max_concurrency at once. Zero means no limit. A call over either
+	// waits its turn rather than failing, and the wait is reported with it.
+	RateLimit      int64 `protobuf:"varint,25,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,26,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,27,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GrpcApp) Reset() {
@@ -3130,12 +3288,131 @@ func (x *GrpcApp) GetApiKeyName() string {
 	return ""
 }
 
+func (x *GrpcApp) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *GrpcApp) GetMaxReceiveBytes() int64 {
+	if x != nil {
+		return x.MaxReceiveBytes
+	}
+	return 0
+}
+
+func (x *GrpcApp) GetMaxSendBytes() int64 {
+	if x != nil {
+		return x.MaxSendBytes
+	}
+	return 0
+}
+
+func (x *GrpcApp) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *GrpcApp) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *GrpcApp) GetLoadBalancing() string {
+	if x != nil {
+		return x.LoadBalancing
+	}
+	return ""
+}
+
+func (x *GrpcApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *GrpcApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *GrpcApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *GrpcApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *GrpcApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *GrpcApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *GrpcApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
 // TwirpApp calls a Twirp service described by a workspace-relative proto_dir.
 type TwirpApp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	ProtoDir      string                 `protobuf:"bytes,2,opt,name=proto_dir,json=protoDir,proto3" json:"proto_dir,omitempty"`
-	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Url      string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ProtoDir string                 `protobuf:"bytes,2,opt,name=proto_dir,json=protoDir,proto3" json:"proto_dir,omitempty"`
+	Headers  map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Retrying a call the proxy makes that comes back with a Twirp error whose
+	// HTTP status is in retry_codes, empty meaning 429, 502, 503 and 504 - so
+	// "unavailable" is retried and "internal" is not. The counts and waits are a
+	// GrpcApp's.
+	RetryMaxAttempts  int64    `protobuf:"varint,4,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,5,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,6,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,7,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// How fast and how widely calls to the service go out, shared by the proxy
+	// across the app's calls. Zero means no limit.
+	RateLimit      int64 `protobuf:"varint,8,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,9,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,10,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// How the proxy reaches the service, and the credential it sends: each field
+	// means what it does for a GrpcApp, with the credential sent as an HTTP header
+	// rather than metadata. It never reaches the browser.
+	Tls                string `protobuf:"bytes,11,opt,name=tls,proto3" json:"tls,omitempty"`
+	InsecureSkipVerify bool   `protobuf:"varint,12,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	CaFile             string `protobuf:"bytes,13,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	ClientCertFile     string `protobuf:"bytes,14,opt,name=client_cert_file,json=clientCertFile,proto3" json:"client_cert_file,omitempty"`
+	ClientKeyFile      string `protobuf:"bytes,15,opt,name=client_key_file,json=clientKeyFile,proto3" json:"client_key_file,omitempty"`
+	Auth               string `protobuf:"bytes,16,opt,name=auth,proto3" json:"auth,omitempty"`
+	Token              string `protobuf:"bytes,17,opt,name=token,proto3" json:"token,omitempty"`
+	Username           string `protobuf:"bytes,18,opt,name=username,proto3" json:"username,omitempty"`
+	Password           string `protobuf:"bytes,19,opt,name=password,proto3" json:"password,omitempty"`
+	ApiKeyName         string `protobuf:"bytes,20,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
+	// How calls are encoded on the wire: "protobuf" (the default) or "json".
+	Encoding      string `protobuf:"bytes,21,opt,name=encoding,proto3" json:"encoding,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -3191,6 +3468,132 @@ func (x *TwirpApp) GetHeaders() map[string]string {
 	return nil
 }
 
+func (x *TwirpApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *TwirpApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *TwirpApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *TwirpApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *TwirpApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *TwirpApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *TwirpApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *TwirpApp) GetTls() string {
+	if x != nil {
+		return x.Tls
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+func (x *TwirpApp) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetClientCertFile() string {
+	if x != nil {
+		return x.ClientCertFile
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetClientKeyFile() string {
+	if x != nil {
+		return x.ClientKeyFile
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetApiKeyName() string {
+	if x != nil {
+		return x.ApiKeyName
+	}
+	return ""
+}
+
+func (x *TwirpApp) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
 // OpenApiApp calls a REST API from its OpenAPI 3.x document. The document is
 // taken from spec_url or, when the spec is uploaded, from spec_content (raw JSON
 // or YAML). Credentials are applied per the spec's security schemes. base_url
@@ -3213,8 +3616,20 @@ type OpenApiApp struct {
 	// document and the API it describes often want different tokens.
 	SpecHeaderName  string `protobuf:"bytes,9,opt,name=spec_header_name,json=specHeaderName,proto3" json:"spec_header_name,omitempty"`
 	SpecHeaderValue string `protobuf:"bytes,10,opt,name=spec_header_value,json=specHeaderValue,proto3" json:"spec_header_value,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Retrying a request to the API that fails to connect or comes back with a
+	// status in retry_codes, empty meaning 429, 502, 503 and 504. A Retry-After
+	// header sets the wait when the API sends one.
+	RetryMaxAttempts  int64    `protobuf:"varint,11,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,12,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,13,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,14,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// At most rate_limit requests to the API a second, bursting to
+	// rate_limit_burst, and max_concurrency in flight. Zero means no limit.
+	RateLimit      int64 `protobuf:"varint,15,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,16,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,17,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *OpenApiApp) Reset() {
@@ -3317,31 +3732,984 @@ func (x *OpenApiApp) GetSpecHeaderValue() string {
 	return ""
 }
 
-// OpenAiApp calls the OpenAI chat completions API.
-type OpenAiApp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
-	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *OpenApiApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
 }
 
-func (x *OpenAiApp) Reset() {
-	*x = OpenAiApp{}
-	mi := &file_proto_api_proto_msgTypes[40]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *OpenApiApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
 }
 
-func (x *OpenAiApp) String() string {
+func (x *OpenApiApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *OpenApiApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *OpenApiApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *OpenApiApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *OpenApiApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+// OpenAiApp calls the OpenAI API, or a gateway compatible with it. endpoint is
+// the API's base URL.
+type OpenAiApp struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Token    string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Headers  map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Retrying a request the API rate limits (429) or is briefly down for (502,
+	// 503, 504), or the statuses retry_codes names instead. A streamed completion
+	// is retried only until the API starts answering it.
+	RetryMaxAttempts  int64    `protobuf:"varint,4,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,5,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,6,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,7,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// How many requests a second, and at once, go to the endpoint: a way to stay
+	// under an account's rate limits rather than retry past them.
+	RateLimit      int64 `protobuf:"varint,8,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,9,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,10,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OpenAiApp) Reset() {
+	*x = OpenAiApp{}
+	mi := &file_proto_api_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenAiApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAiApp) ProtoMessage() {}
+
+func (x *OpenAiApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAiApp.ProtoReflect.Descriptor instead.
+func (*OpenAiApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *OpenAiApp) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *OpenAiApp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *OpenAiApp) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *OpenAiApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *OpenAiApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *OpenAiApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *OpenAiApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *OpenAiApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *OpenAiApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *OpenAiApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+// AnthropicApp calls the Anthropic Messages API. endpoint is the API's base URL;
+// empty means Anthropic's own. The token is held here and sent as x-api-key,
+// never handed to the browser.
+type AnthropicApp struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Token    string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Headers  map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The anthropic-version header. Empty means 2023-06-01.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Retrying a request the API rate limits (429) or is briefly down for (502,
+	// 503, 504). An overloaded API answers 529, which is retried only when
+	// retry_codes names it.
+	RetryMaxAttempts  int64    `protobuf:"varint,5,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,6,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,7,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,8,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// How many requests a second, and at once, go to the endpoint: a way to stay
+	// under an organization's rate limits rather than retry past them.
+	RateLimit      int64 `protobuf:"varint,9,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,10,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,11,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AnthropicApp) Reset() {
+	*x = AnthropicApp{}
+	mi := &file_proto_api_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnthropicApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnthropicApp) ProtoMessage() {}
+
+func (x *AnthropicApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnthropicApp.ProtoReflect.Descriptor instead.
+func (*AnthropicApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AnthropicApp) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *AnthropicApp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AnthropicApp) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *AnthropicApp) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AnthropicApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *AnthropicApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *AnthropicApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *AnthropicApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *AnthropicApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *AnthropicApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *AnthropicApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+// FolderApp lists, searches, reads, writes, moves, deletes and watches files in a
+// folder on disk. It is local, so it forwards no headers.
+type FolderApp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FolderApp) Reset() {
+	*x = FolderApp{}
+	mi := &file_proto_api_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FolderApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FolderApp) ProtoMessage() {}
+
+func (x *FolderApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FolderApp.ProtoReflect.Descriptor instead.
+func (*FolderApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *FolderApp) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// SqliteApp queries and changes a SQLite database file on disk. It is local, so it
+// forwards no headers.
+type SqliteApp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The database file, workspace-relative or absolute. It is created on the first
+	// write when it does not exist yet.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Open the database read-only: Query and the schema methods work, Exec and
+	// anything else that would write are refused.
+	ReadOnly      bool `protobuf:"varint,2,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SqliteApp) Reset() {
+	*x = SqliteApp{}
+	mi := &file_proto_api_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SqliteApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SqliteApp) ProtoMessage() {}
+
+func (x *SqliteApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SqliteApp.ProtoReflect.Descriptor instead.
+func (*SqliteApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SqliteApp) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SqliteApp) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+// GraphqlApp calls a GraphQL endpoint. Each query and mutation is a method whose
+// request is the field's arguments and whose response is its value.
+type GraphqlApp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The endpoint queries are POSTed to, e.g. "https://example.com/graphql".
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The schema as SDL or as an introspection result, workspace-relative or
+	// absolute. Empty asks the endpoint with an introspection query.
+	SchemaFile string            `protobuf:"bytes,2,opt,name=schema_file,json=schemaFile,proto3" json:"schema_file,omitempty"`
+	Headers    map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The credential sent with every request: "bearer", "basic", "apikey", or
+	// "none". Empty means bearer when a token is set and none otherwise.
+	Auth string `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
+	// The bearer token, or the key for the "apikey" credential.
+	Token    string `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	Username string `protobuf:"bytes,6,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,7,opt,name=password,proto3" json:"password,omitempty"`
+	// Header the "apikey" credential is sent under. Empty means "X-API-Key".
+	ApiKeyName string `protobuf:"bytes,8,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
+	// Retrying a query or mutation whose POST fails to connect or comes back with
+	// a status in retry_codes, empty meaning 429, 502, 503 and 504. An answer
+	// carrying GraphQL errors is the endpoint's answer, and is not retried.
+	RetryMaxAttempts  int64    `protobuf:"varint,9,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,10,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,11,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,12,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// At most rate_limit queries and mutations a second, bursting to
+	// rate_limit_burst, and max_concurrency in flight. Zero means no limit.
+	RateLimit      int64 `protobuf:"varint,13,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,14,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,15,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// How many levels of objects the selection set generated for a call follows,
+	// 1 to 8. Zero means 2. A call can ask for another depth, or write its own
+	// selection set.
+	Depth         int64 `protobuf:"varint,16,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphqlApp) Reset() {
+	*x = GraphqlApp{}
+	mi := &file_proto_api_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphqlApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphqlApp) ProtoMessage() {}
+
+func (x *GraphqlApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphqlApp.ProtoReflect.Descriptor instead.
+func (*GraphqlApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GraphqlApp) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GraphqlApp) GetSchemaFile() string {
+	if x != nil {
+		return x.SchemaFile
+	}
+	return ""
+}
+
+func (x *GraphqlApp) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *GraphqlApp) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *GraphqlApp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GraphqlApp) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GraphqlApp) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *GraphqlApp) GetApiKeyName() string {
+	if x != nil {
+		return x.ApiKeyName
+	}
+	return ""
+}
+
+func (x *GraphqlApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *GraphqlApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *GraphqlApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *GraphqlApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *GraphqlApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *GraphqlApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *GraphqlApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *GraphqlApp) GetDepth() int64 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+// JsonrpcApp calls a JSON-RPC 2.0 server described by an OpenRPC document. Each
+// method of the document is a method, and Batch sends several calls at once.
+type JsonrpcApp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The endpoint calls are POSTed to. Empty takes the document's first server.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Where the OpenRPC document is: a URL, or a file, workspace-relative or
+	// absolute. With neither, it is asked of url with rpc.discover.
+	SpecUrl  string            `protobuf:"bytes,2,opt,name=spec_url,json=specUrl,proto3" json:"spec_url,omitempty"`
+	SpecFile string            `protobuf:"bytes,3,opt,name=spec_file,json=specFile,proto3" json:"spec_file,omitempty"`
+	Headers  map[string]string `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The credential sent with every call: "bearer", "basic", "apikey", or "none".
+	// Empty means bearer when a token is set and none otherwise.
+	Auth string `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	// The bearer token, or the key for the "apikey" credential.
+	Token    string `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	Username string `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,8,opt,name=password,proto3" json:"password,omitempty"`
+	// Header the "apikey" credential is sent under. Empty means "X-API-Key".
+	ApiKeyName string `protobuf:"bytes,9,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
+	// Retrying a call, or a whole Batch, whose POST fails to connect or comes back
+	// with a status in retry_codes, empty meaning 429, 502, 503 and 504. A
+	// JSON-RPC error object is the server's answer, and is not retried.
+	RetryMaxAttempts  int64    `protobuf:"varint,10,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,11,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,12,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,13,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// At most rate_limit calls a second, bursting to rate_limit_burst, and
+	// max_concurrency in flight; a Batch counts once. Zero means no limit.
+	RateLimit      int64 `protobuf:"varint,14,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,15,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,16,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *JsonrpcApp) Reset() {
+	*x = JsonrpcApp{}
+	mi := &file_proto_api_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JsonrpcApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonrpcApp) ProtoMessage() {}
+
+func (x *JsonrpcApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonrpcApp.ProtoReflect.Descriptor instead.
+func (*JsonrpcApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *JsonrpcApp) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetSpecUrl() string {
+	if x != nil {
+		return x.SpecUrl
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetSpecFile() string {
+	if x != nil {
+		return x.SpecFile
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *JsonrpcApp) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetApiKeyName() string {
+	if x != nil {
+		return x.ApiKeyName
+	}
+	return ""
+}
+
+func (x *JsonrpcApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *JsonrpcApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *JsonrpcApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *JsonrpcApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *JsonrpcApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *JsonrpcApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *JsonrpcApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+// WebsocketApp talks JSON to a server over a WebSocket: Send sends a frame,
+// Request sends one and waits for the reply with the same correlation field, and
+// Subscribe streams what the server sends. An AsyncAPI document adds a method of
+// each for the messages it describes. The app's calls share one connection.
+type WebsocketApp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ws:// or wss:// URL to connect to. Empty takes the document's first
+	// WebSocket server.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Where the AsyncAPI document is, if there is one: a URL, or a file,
+	// workspace-relative or absolute. YAML or JSON.
+	AsyncapiUrl  string `protobuf:"bytes,2,opt,name=asyncapi_url,json=asyncapiUrl,proto3" json:"asyncapi_url,omitempty"`
+	AsyncapiFile string `protobuf:"bytes,3,opt,name=asyncapi_file,json=asyncapiFile,proto3" json:"asyncapi_file,omitempty"`
+	// Sent with the handshake.
+	Headers map[string]string `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The credential sent with the handshake: "bearer", "basic", "apikey", or
+	// "none". Empty means bearer when a token is set and none otherwise.
+	Auth string `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	// The bearer token, or the key for the "apikey" credential.
+	Token    string `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	Username string `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,8,opt,name=password,proto3" json:"password,omitempty"`
+	// Header the "apikey" credential is sent under. Empty means "X-API-Key".
+	ApiKeyName string `protobuf:"bytes,9,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
+	// The field a reply shares with the request it answers. Empty means "id"; a
+	// dotted name, such as "meta.requestId", looks inside an object.
+	CorrelationField string `protobuf:"bytes,10,opt,name=correlation_field,json=correlationField,proto3" json:"correlation_field,omitempty"`
+	// Subprotocols offered in the handshake.
+	Subprotocols []string `protobuf:"bytes,11,rep,name=subprotocols,proto3" json:"subprotocols,omitempty"`
+	// How fast and how widely Send, Request and Subscribe go out over the shared
+	// connection. There are no retries: a frame that was sent is not sent again.
+	RateLimit      int64 `protobuf:"varint,12,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,13,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,14,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebsocketApp) Reset() {
+	*x = WebsocketApp{}
+	mi := &file_proto_api_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebsocketApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebsocketApp) ProtoMessage() {}
+
+func (x *WebsocketApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebsocketApp.ProtoReflect.Descriptor instead.
+func (*WebsocketApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WebsocketApp) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetAsyncapiUrl() string {
+	if x != nil {
+		return x.AsyncapiUrl
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetAsyncapiFile() string {
+	if x != nil {
+		return x.AsyncapiFile
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *WebsocketApp) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetApiKeyName() string {
+	if x != nil {
+		return x.ApiKeyName
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetCorrelationField() string {
+	if x != nil {
+		return x.CorrelationField
+	}
+	return ""
+}
+
+func (x *WebsocketApp) GetSubprotocols() []string {
+	if x != nil {
+		return x.Subprotocols
+	}
+	return nil
+}
+
+func (x *WebsocketApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *WebsocketApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *WebsocketApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+// HttpApp calls an HTTP API that has no document to describe it. Request sends
+// any request to base_url, and each endpoint is a method of its own.
+type HttpApp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// What request paths are joined to, e.g. "https://internal.example.com/api".
+	BaseUrl string            `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The credentials, as an OpenApiApp has them: a username or password is sent
+	// as HTTP Basic, and a token as a bearer token.
+	Token     string          `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Username  string          `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Password  string          `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	Endpoints []*HttpEndpoint `protobuf:"bytes,6,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// Retrying a request that fails to connect or comes back with a status in
+	// retry_codes, empty meaning 429, 502, 503 and 504. Any method is retried,
+	// POST included, so leave it off for an API whose writes aren't idempotent.
+	RetryMaxAttempts  int64    `protobuf:"varint,7,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,8,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,9,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,10,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// At most rate_limit requests a second, bursting to rate_limit_burst, and
+	// max_concurrency in flight, whether through Request or an endpoint's method.
+	RateLimit      int64 `protobuf:"varint,11,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,12,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,13,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HttpApp) Reset() {
+	*x = HttpApp{}
+	mi := &file_proto_api_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpApp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenAiApp) ProtoMessage() {}
+func (*HttpApp) ProtoMessage() {}
 
-func (x *OpenAiApp) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[40]
+func (x *HttpApp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3352,56 +4720,139 @@ func (x *OpenAiApp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OpenAiApp.ProtoReflect.Descriptor instead.
-func (*OpenAiApp) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use HttpApp.ProtoReflect.Descriptor instead.
+func (*HttpApp) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *OpenAiApp) GetEndpoint() string {
+func (x *HttpApp) GetBaseUrl() string {
 	if x != nil {
-		return x.Endpoint
+		return x.BaseUrl
 	}
 	return ""
 }
 
-func (x *OpenAiApp) GetToken() string {
+func (x *HttpApp) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HttpApp) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *OpenAiApp) GetHeaders() map[string]string {
+func (x *HttpApp) GetUsername() string {
 	if x != nil {
-		return x.Headers
+		return x.Username
+	}
+	return ""
+}
+
+func (x *HttpApp) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *HttpApp) GetEndpoints() []*HttpEndpoint {
+	if x != nil {
+		return x.Endpoints
 	}
 	return nil
 }
 
-// FolderApp lists, creates, reads and appends to files in a folder on disk. It
-// is local, so it forwards no headers.
-type FolderApp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+func (x *HttpApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *HttpApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *HttpApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *HttpApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *HttpApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *HttpApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *HttpApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+// HttpEndpoint is one request of an HttpApp that is a method of its own, whose
+// request is the endpoint's parameters and body.
+type HttpEndpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The method's name, e.g. "get_user" or "GetUser".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Empty means GET.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// Joined to the app's base URL. Each "{name}" in it is a parameter of the
+	// method, e.g. "/users/{id}".
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// The query parameters and headers the method takes.
+	Query   []string `protobuf:"bytes,4,rep,name=query,proto3" json:"query,omitempty"`
+	Headers []string `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty"`
+	// What the body is: "json", "text", "bytes", or "none". Empty means json for
+	// POST, PUT and PATCH and none otherwise.
+	Body          string `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FolderApp) Reset() {
-	*x = FolderApp{}
-	mi := &file_proto_api_proto_msgTypes[41]
+func (x *HttpEndpoint) Reset() {
+	*x = HttpEndpoint{}
+	mi := &file_proto_api_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FolderApp) String() string {
+func (x *HttpEndpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FolderApp) ProtoMessage() {}
+func (*HttpEndpoint) ProtoMessage() {}
 
-func (x *FolderApp) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[41]
+func (x *HttpEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3412,45 +4863,123 @@ func (x *FolderApp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FolderApp.ProtoReflect.Descriptor instead.
-func (*FolderApp) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use HttpEndpoint.ProtoReflect.Descriptor instead.
+func (*HttpEndpoint) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *FolderApp) GetPath() string {
+func (x *HttpEndpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HttpEndpoint) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HttpEndpoint) GetPath() string {
 	if x != nil {
 		return x.Path
 	}
 	return ""
 }
 
-// McpApp explores another Model Context Protocol server over the Streamable HTTP
-// transport. Its proto surface is generated from what that server exposes: one
-// method per tool, one per prompt, and the list/read methods for its resources.
+func (x *HttpEndpoint) GetQuery() []string {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *HttpEndpoint) GetHeaders() []string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HttpEndpoint) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *HttpEndpoint) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// McpApp explores another Model Context Protocol server. With url set, kaja
+// speaks to it over the Streamable HTTP transport; with command set, it launches
+// the server itself and speaks to it over the stdio transport, the server's stdin
+// and stdout. Exactly one of the two is set. Its proto surface is generated from
+// what that server exposes: one method per tool, one per prompt, and the
+// list/read methods for its resources.
 //
 // The credential is the app's, not the request's: kaja resolves it where it
 // holds it and applies it on the way out, so a "${secret}" token is never handed
-// to the browser.
+// to the browser. It is sent to a server at url only.
 type McpApp struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The server's MCP endpoint, e.g. "https://example.com/mcp".
 	Url     string            `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
 	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The credential sent with every request: "bearer", "apikey", or "none". Empty
-	// means bearer, which is what an MCP server behind a login nearly always
-	// wants.
+	// The credential sent with every request: "bearer", "apikey", "oauth", or
+	// "none". Empty means bearer, which is what an MCP server behind a login
+	// nearly always wants, and with no token sends nothing. "oauth" has kaja sign
+	// in to the server as its authorization spec describes - discovering the
+	// authorization server, registering, and the authorization code flow with
+	// PKCE - and hold and refresh the tokens itself.
 	Auth string `protobuf:"bytes,3,opt,name=auth,proto3" json:"auth,omitempty"`
 	// The bearer token, or the key for the "apikey" credential.
 	Token string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
 	// Header the "apikey" credential is sent under. Empty means "X-API-Key".
-	ApiKeyName    string `protobuf:"bytes,5,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
+	ApiKeyName string `protobuf:"bytes,5,opt,name=api_key_name,json=apiKeyName,proto3" json:"api_key_name,omitempty"`
+	// Retrying a request to a server at url that fails to connect or comes back
+	// with a status in retry_codes, empty meaning 429, 502, 503 and 504. A tool
+	// that reports an error has answered, and is not retried.
+	RetryMaxAttempts  int64    `protobuf:"varint,6,opt,name=retry_max_attempts,json=retryMaxAttempts,proto3" json:"retry_max_attempts,omitempty"`
+	RetryBackoffMs    int64    `protobuf:"varint,7,opt,name=retry_backoff_ms,json=retryBackoffMs,proto3" json:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoffMs int64    `protobuf:"varint,8,opt,name=retry_max_backoff_ms,json=retryMaxBackoffMs,proto3" json:"retry_max_backoff_ms,omitempty"`
+	RetryCodes        []string `protobuf:"bytes,9,rep,name=retry_codes,json=retryCodes,proto3" json:"retry_codes,omitempty"`
+	// How fast and how widely tool calls, prompts and resource reads go out to
+	// the server. Zero means no limit.
+	RateLimit      int64 `protobuf:"varint,10,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	RateLimitBurst int64 `protobuf:"varint,11,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`
+	MaxConcurrency int64 `protobuf:"varint,12,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// A local server kaja launches in place of url, spoken to over its stdin and
+	// stdout. args are passed as they are, with no shell between; env holds
+	// NAME=value entries added to kaja's environment; working_dir is
+	// workspace-relative and defaults to the workspace.
+	Command    string   `protobuf:"bytes,13,opt,name=command,proto3" json:"command,omitempty"`
+	Args       []string `protobuf:"bytes,14,rep,name=args,proto3" json:"args,omitempty"`
+	Env        []string `protobuf:"bytes,15,rep,name=env,proto3" json:"env,omitempty"`
+	WorkingDir string   `protobuf:"bytes,16,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	// What kaja answers when the server asks something of it mid-call. roots
+	// names the folder apps whose folders the server is told it may work in;
+	// empty means every one configured. sampling_app names the openai app the
+	// server's sampling requests are completed with, none when empty, and
+	// sampling_model the model asked; empty takes the server's first preference.
+	// Elicitation is always answered: its questions are put to whoever runs the
+	// call.
+	Roots         []string `protobuf:"bytes,17,rep,name=roots,proto3" json:"roots,omitempty"`
+	SamplingApp   string   `protobuf:"bytes,18,opt,name=sampling_app,json=samplingApp,proto3" json:"sampling_app,omitempty"`
+	SamplingModel string   `protobuf:"bytes,19,opt,name=sampling_model,json=samplingModel,proto3" json:"sampling_model,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *McpApp) Reset() {
 	*x = McpApp{}
-	mi := &file_proto_api_proto_msgTypes[42]
+	mi := &file_proto_api_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3462,7 +4991,7 @@ func (x *McpApp) String() string {
 func (*McpApp) ProtoMessage() {}
 
 func (x *McpApp) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[42]
+	mi := &file_proto_api_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3475,7 +5004,7 @@ func (x *McpApp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use McpApp.ProtoReflect.Descriptor instead.
 func (*McpApp) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{42}
+	return file_proto_api_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *McpApp) GetUrl() string {
@@ -3513,6 +5042,104 @@ func (x *McpApp) GetApiKeyName() string {
 	return ""
 }
 
+func (x *McpApp) GetRetryMaxAttempts() int64 {
+	if x != nil {
+		return x.RetryMaxAttempts
+	}
+	return 0
+}
+
+func (x *McpApp) GetRetryBackoffMs() int64 {
+	if x != nil {
+		return x.RetryBackoffMs
+	}
+	return 0
+}
+
+func (x *McpApp) GetRetryMaxBackoffMs() int64 {
+	if x != nil {
+		return x.RetryMaxBackoffMs
+	}
+	return 0
+}
+
+func (x *McpApp) GetRetryCodes() []string {
+	if x != nil {
+		return x.RetryCodes
+	}
+	return nil
+}
+
+func (x *McpApp) GetRateLimit() int64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *McpApp) GetRateLimitBurst() int64 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *McpApp) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *McpApp) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *McpApp) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *McpApp) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *McpApp) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *McpApp) GetRoots() []string {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+func (x *McpApp) GetSamplingApp() string {
+	if x != nil {
+		return x.SamplingApp
+	}
+	return ""
+}
+
+func (x *McpApp) GetSamplingModel() string {
+	if x != nil {
+		return x.SamplingModel
+	}
+	return ""
+}
+
 type UpdateConfigurationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Configuration *Configuration         `protobuf:"bytes,1,opt,name=configuration,proto3" json:"configuration,omitempty"`
@@ -3522,7 +5149,7 @@ type UpdateConfigurationRequest struct {
 
 func (x *UpdateConfigurationRequest) Reset() {
 	*x = UpdateConfigurationRequest{}
-	mi := &file_proto_api_proto_msgTypes[43]
+	mi := &file_proto_api_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3534,7 +5161,7 @@ func (x *UpdateConfigurationRequest) String() string {
 func (*UpdateConfigurationRequest) ProtoMessage() {}
 
 func (x *UpdateConfigurationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[43]
+	mi := &file_proto_api_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3547,7 +5174,7 @@ func (x *UpdateConfigurationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateConfigurationRequest.ProtoReflect.Descriptor instead.
 func (*UpdateConfigurationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{43}
+	return file_proto_api_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *UpdateConfigurationRequest) GetConfiguration() *Configuration {
@@ -3568,7 +5195,7 @@ type UpdateConfigurationResponse struct {
 
 func (x *UpdateConfigurationResponse) Reset() {
 	*x = UpdateConfigurationResponse{}
-	mi := &file_proto_api_proto_msgTypes[44]
+	mi := &file_proto_api_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3580,7 +5207,7 @@ func (x *UpdateConfigurationResponse) String() string {
 func (*UpdateConfigurationResponse) ProtoMessage() {}
 
 func (x *UpdateConfigurationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[44]
+	mi := &file_proto_api_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3593,7 +5220,7 @@ func (x *UpdateConfigurationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateConfigurationResponse.ProtoReflect.Descriptor instead.
 func (*UpdateConfigurationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{44}
+	return file_proto_api_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *UpdateConfigurationResponse) GetConfiguration() *Configuration {
@@ -3718,12 +5345,14 @@ const file_proto_api_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
-	"\tread_only\x18\x04 \x01(\bR\breadOnly\"c\n" +
+	"\tread_only\x18\x04 \x01(\bR\breadOnly\"\xc3\x01\n" +
 	"\n" +
 	"McpProblem\x12#\n" +
 	"\x04kind\x18\x01 \x01(\x0e2\x0f.McpProblemKindR\x04kind\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
-	"\x06detail\x18\x03 \x01(\tR\x06detail\"\x8a\x01\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\x12+\n" +
+	"\x11authorization_url\x18\x04 \x01(\tR\x10authorizationUrl\x121\n" +
+	"\x14authorization_server\x18\x05 \x01(\tR\x13authorizationServer\"\x8a\x01\n" +
 	"\x0fCompileResponse\x12&\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x0e.CompileStatusR\x06status\x12\x18\n" +
 	"\x04logs\x18\x02 \x03(\v2\x04.LogR\x04logs\x12!\n" +
@@ -3776,7 +5405,7 @@ const file_proto_api_proto_rawDesc = "" +
 	"\tvariables\x18\x06 \x03(\v2\x1d.Configuration.VariablesEntryR\tvariables\x1a<\n" +
 	"\x0eVariablesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\x02\x10\x03J\x04\b\x04\x10\x05R\bprojectsR\x06system\"\x92\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\x02\x10\x03J\x04\b\x04\x10\x05R\bprojectsR\x06system\"\x88\x04\n" +
 	"\x10ConfigurationApp\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
 	"\x04grpc\x18\x02 \x01(\v2\b.GrpcAppH\x00R\x04grpc\x12!\n" +
@@ -3786,8 +5415,16 @@ const file_proto_api_proto_rawDesc = "" +
 	".OpenAiAppH\x00R\x06openai\x12$\n" +
 	"\x06folder\x18\a \x01(\v2\n" +
 	".FolderAppH\x00R\x06folder\x12\x1b\n" +
-	"\x03mcp\x18\b \x01(\v2\a.McpAppH\x00R\x03mcpB\x05\n" +
-	"\x03appJ\x04\b\x06\x10\aR\bmarkdown\"\xf8\x03\n" +
+	"\x03mcp\x18\b \x01(\v2\a.McpAppH\x00R\x03mcp\x12-\n" +
+	"\tanthropic\x18\t \x01(\v2\r.AnthropicAppH\x00R\tanthropic\x12$\n" +
+	"\x06sqlite\x18\n" +
+	" \x01(\v2\n" +
+	".SqliteAppH\x00R\x06sqlite\x12'\n" +
+	"\agraphql\x18\v \x01(\v2\v.GraphqlAppH\x00R\agraphql\x12'\n" +
+	"\ajsonrpc\x18\f \x01(\v2\v.JsonrpcAppH\x00R\ajsonrpc\x12-\n" +
+	"\twebsocket\x18\r \x01(\v2\r.WebsocketAppH\x00R\twebsocket\x12\x1e\n" +
+	"\x04http\x18\x0e \x01(\v2\b.HttpAppH\x00R\x04httpB\x05\n" +
+	"\x03appJ\x04\b\x06\x10\aR\bmarkdown\"\xe9\a\n" +
 	"\aGrpcApp\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
 	"\tproto_dir\x18\x02 \x01(\tR\bprotoDir\x12\x1e\n" +
@@ -3806,17 +5443,54 @@ const file_proto_api_proto_rawDesc = "" +
 	"\busername\x18\f \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\r \x01(\tR\bpassword\x12 \n" +
 	"\fapi_key_name\x18\x0e \x01(\tR\n" +
-	"apiKeyName\x1a:\n" +
+	"apiKeyName\x12 \n" +
+	"\vcompression\x18\x0f \x01(\tR\vcompression\x12*\n" +
+	"\x11max_receive_bytes\x18\x10 \x01(\x03R\x0fmaxReceiveBytes\x12$\n" +
+	"\x0emax_send_bytes\x18\x11 \x01(\x03R\fmaxSendBytes\x12\x1a\n" +
+	"\bprotocol\x18\x12 \x01(\tR\bprotocol\x12\x1c\n" +
+	"\tendpoints\x18\x13 \x03(\tR\tendpoints\x12%\n" +
+	"\x0eload_balancing\x18\x14 \x01(\tR\rloadBalancing\x12,\n" +
+	"\x12retry_max_attempts\x18\x15 \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\x16 \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\x17 \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\x18 \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\x19 \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\x1a \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\x1b \x01(\x03R\x0emaxConcurrency\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa7\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x92\x06\n" +
 	"\bTwirpApp\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
 	"\tproto_dir\x18\x02 \x01(\tR\bprotoDir\x120\n" +
-	"\aheaders\x18\x03 \x03(\v2\x16.TwirpApp.HeadersEntryR\aheaders\x1a:\n" +
+	"\aheaders\x18\x03 \x03(\v2\x16.TwirpApp.HeadersEntryR\aheaders\x12,\n" +
+	"\x12retry_max_attempts\x18\x04 \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\x05 \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\x06 \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\a \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\b \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\t \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\n" +
+	" \x01(\x03R\x0emaxConcurrency\x12\x10\n" +
+	"\x03tls\x18\v \x01(\tR\x03tls\x120\n" +
+	"\x14insecure_skip_verify\x18\f \x01(\bR\x12insecureSkipVerify\x12\x17\n" +
+	"\aca_file\x18\r \x01(\tR\x06caFile\x12(\n" +
+	"\x10client_cert_file\x18\x0e \x01(\tR\x0eclientCertFile\x12&\n" +
+	"\x0fclient_key_file\x18\x0f \x01(\tR\rclientKeyFile\x12\x12\n" +
+	"\x04auth\x18\x10 \x01(\tR\x04auth\x12\x14\n" +
+	"\x05token\x18\x11 \x01(\tR\x05token\x12\x1a\n" +
+	"\busername\x18\x12 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x13 \x01(\tR\bpassword\x12 \n" +
+	"\fapi_key_name\x18\x14 \x01(\tR\n" +
+	"apiKeyName\x12\x1a\n" +
+	"\bencoding\x18\x15 \x01(\tR\bencoding\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbe\x05\n" +
 	"\n" +
 	"OpenApiApp\x12\x19\n" +
 	"\bspec_url\x18\x01 \x01(\tR\aspecUrl\x12\x14\n" +
@@ -3829,26 +5503,184 @@ const file_proto_api_proto_rawDesc = "" +
 	"\x0fsecurity_scheme\x18\b \x01(\tR\x0esecurityScheme\x12(\n" +
 	"\x10spec_header_name\x18\t \x01(\tR\x0especHeaderName\x12*\n" +
 	"\x11spec_header_value\x18\n" +
-	" \x01(\tR\x0fspecHeaderValue\x1a:\n" +
+	" \x01(\tR\x0fspecHeaderValue\x12,\n" +
+	"\x12retry_max_attempts\x18\v \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\f \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\r \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\x0e \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\x0f \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\x10 \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\x11 \x01(\x03R\x0emaxConcurrency\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xac\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x03\n" +
 	"\tOpenAiApp\x12\x1a\n" +
 	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x14\n" +
 	"\x05token\x18\x02 \x01(\tR\x05token\x121\n" +
-	"\aheaders\x18\x03 \x03(\v2\x17.OpenAiApp.HeadersEntryR\aheaders\x1a:\n" +
+	"\aheaders\x18\x03 \x03(\v2\x17.OpenAiApp.HeadersEntryR\aheaders\x12,\n" +
+	"\x12retry_max_attempts\x18\x04 \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\x05 \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\x06 \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\a \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\b \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\t \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\n" +
+	" \x01(\x03R\x0emaxConcurrency\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe8\x03\n" +
+	"\fAnthropicApp\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x124\n" +
+	"\aheaders\x18\x03 \x03(\v2\x1a.AnthropicApp.HeadersEntryR\aheaders\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12,\n" +
+	"\x12retry_max_attempts\x18\x05 \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\x06 \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\a \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\b \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\t \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\n" +
+	" \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\v \x01(\x03R\x0emaxConcurrency\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1f\n" +
 	"\tFolderApp\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\"\xd2\x01\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"<\n" +
+	"\tSqliteApp\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1b\n" +
+	"\tread_only\x18\x02 \x01(\bR\breadOnly\"\xe5\x04\n" +
+	"\n" +
+	"GraphqlApp\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\vschema_file\x18\x02 \x01(\tR\n" +
+	"schemaFile\x122\n" +
+	"\aheaders\x18\x03 \x03(\v2\x18.GraphqlApp.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04auth\x18\x04 \x01(\tR\x04auth\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\x12\x1a\n" +
+	"\busername\x18\x06 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\a \x01(\tR\bpassword\x12 \n" +
+	"\fapi_key_name\x18\b \x01(\tR\n" +
+	"apiKeyName\x12,\n" +
+	"\x12retry_max_attempts\x18\t \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\n" +
+	" \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\v \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\f \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\r \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\x0e \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\x0f \x01(\x03R\x0emaxConcurrency\x12\x14\n" +
+	"\x05depth\x18\x10 \x01(\x03R\x05depth\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe6\x04\n" +
+	"\n" +
+	"JsonrpcApp\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x19\n" +
+	"\bspec_url\x18\x02 \x01(\tR\aspecUrl\x12\x1b\n" +
+	"\tspec_file\x18\x03 \x01(\tR\bspecFile\x122\n" +
+	"\aheaders\x18\x04 \x03(\v2\x18.JsonrpcApp.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04auth\x18\x05 \x01(\tR\x04auth\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\x12\x1a\n" +
+	"\busername\x18\a \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\b \x01(\tR\bpassword\x12 \n" +
+	"\fapi_key_name\x18\t \x01(\tR\n" +
+	"apiKeyName\x12,\n" +
+	"\x12retry_max_attempts\x18\n" +
+	" \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\v \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\f \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\r \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\x0e \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\x0f \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\x10 \x01(\x03R\x0emaxConcurrency\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x04\n" +
+	"\fWebsocketApp\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12!\n" +
+	"\fasyncapi_url\x18\x02 \x01(\tR\vasyncapiUrl\x12#\n" +
+	"\rasyncapi_file\x18\x03 \x01(\tR\fasyncapiFile\x124\n" +
+	"\aheaders\x18\x04 \x03(\v2\x1a.WebsocketApp.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04auth\x18\x05 \x01(\tR\x04auth\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\x12\x1a\n" +
+	"\busername\x18\a \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\b \x01(\tR\bpassword\x12 \n" +
+	"\fapi_key_name\x18\t \x01(\tR\n" +
+	"apiKeyName\x12+\n" +
+	"\x11correlation_field\x18\n" +
+	" \x01(\tR\x10correlationField\x12\"\n" +
+	"\fsubprotocols\x18\v \x03(\tR\fsubprotocols\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\f \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\r \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\x0e \x01(\x03R\x0emaxConcurrency\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa8\x04\n" +
+	"\aHttpApp\x12\x19\n" +
+	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12/\n" +
+	"\aheaders\x18\x02 \x03(\v2\x15.HttpApp.HeadersEntryR\aheaders\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12+\n" +
+	"\tendpoints\x18\x06 \x03(\v2\r.HttpEndpointR\tendpoints\x12,\n" +
+	"\x12retry_max_attempts\x18\a \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\b \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\t \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\n" +
+	" \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\v \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\f \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\r \x01(\x03R\x0emaxConcurrency\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb4\x01\n" +
+	"\fHttpEndpoint\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x14\n" +
+	"\x05query\x18\x04 \x03(\tR\x05query\x12\x18\n" +
+	"\aheaders\x18\x05 \x03(\tR\aheaders\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\xaf\x05\n" +
 	"\x06McpApp\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12.\n" +
 	"\aheaders\x18\x02 \x03(\v2\x14.McpApp.HeadersEntryR\aheaders\x12\x12\n" +
 	"\x04auth\x18\x03 \x01(\tR\x04auth\x12\x14\n" +
 	"\x05token\x18\x04 \x01(\tR\x05token\x12 \n" +
 	"\fapi_key_name\x18\x05 \x01(\tR\n" +
-	"apiKeyName\x1a:\n" +
+	"apiKeyName\x12,\n" +
+	"\x12retry_max_attempts\x18\x06 \x01(\x03R\x10retryMaxAttempts\x12(\n" +
+	"\x10retry_backoff_ms\x18\a \x01(\x03R\x0eretryBackoffMs\x12/\n" +
+	"\x14retry_max_backoff_ms\x18\b \x01(\x03R\x11retryMaxBackoffMs\x12\x1f\n" +
+	"\vretry_codes\x18\t \x03(\tR\n" +
+	"retryCodes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\n" +
+	" \x01(\x03R\trateLimit\x12(\n" +
+	"\x10rate_limit_burst\x18\v \x01(\x03R\x0erateLimitBurst\x12'\n" +
+	"\x0fmax_concurrency\x18\f \x01(\x03R\x0emaxConcurrency\x12\x18\n" +
+	"\acommand\x18\r \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x0e \x03(\tR\x04args\x12\x10\n" +
+	"\x03env\x18\x0f \x03(\tR\x03env\x12\x1f\n" +
+	"\vworking_dir\x18\x10 \x01(\tR\n" +
+	"workingDir\x12\x14\n" +
+	"\x05roots\x18\x11 \x03(\tR\x05roots\x12!\n" +
+	"\fsampling_app\x18\x12 \x01(\tR\vsamplingApp\x12%\n" +
+	"\x0esampling_model\x18\x13 \x01(\tR\rsamplingModel\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"R\n" +
@@ -3939,7 +5771,7 @@ func file_proto_api_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_api_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_proto_api_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
+var file_proto_api_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_proto_api_proto_goTypes = []any{
 	(OpenStatus)(0),                     // 0: OpenStatus
 	(GrpcProblemKind)(0),                // 1: GrpcProblemKind
@@ -3989,16 +5821,28 @@ var file_proto_api_proto_goTypes = []any{
 	(*TwirpApp)(nil),                    // 45: TwirpApp
 	(*OpenApiApp)(nil),                  // 46: OpenApiApp
 	(*OpenAiApp)(nil),                   // 47: OpenAiApp
-	(*FolderApp)(nil),                   // 48: FolderApp
-	(*McpApp)(nil),                      // 49: McpApp
-	(*UpdateConfigurationRequest)(nil),  // 50: UpdateConfigurationRequest
-	(*UpdateConfigurationResponse)(nil), // 51: UpdateConfigurationResponse
-	nil,                                 // 52: Configuration.VariablesEntry
-	nil,                                 // 53: GrpcApp.HeadersEntry
-	nil,                                 // 54: TwirpApp.HeadersEntry
-	nil,                                 // 55: OpenApiApp.HeadersEntry
-	nil,                                 // 56: OpenAiApp.HeadersEntry
-	nil,                                 // 57: McpApp.HeadersEntry
+	(*AnthropicApp)(nil),                // 48: AnthropicApp
+	(*FolderApp)(nil),                   // 49: FolderApp
+	(*SqliteApp)(nil),                   // 50: SqliteApp
+	(*GraphqlApp)(nil),                  // 51: GraphqlApp
+	(*JsonrpcApp)(nil),                  // 52: JsonrpcApp
+	(*WebsocketApp)(nil),                // 53: WebsocketApp
+	(*HttpApp)(nil),                     // 54: HttpApp
+	(*HttpEndpoint)(nil),                // 55: HttpEndpoint
+	(*McpApp)(nil),                      // 56: McpApp
+	(*UpdateConfigurationRequest)(nil),  // 57: UpdateConfigurationRequest
+	(*UpdateConfigurationResponse)(nil), // 58: UpdateConfigurationResponse
+	nil,                                 // 59: Configuration.VariablesEntry
+	nil,                                 // 60: GrpcApp.HeadersEntry
+	nil,                                 // 61: TwirpApp.HeadersEntry
+	nil,                                 // 62: OpenApiApp.HeadersEntry
+	nil,                                 // 63: OpenAiApp.HeadersEntry
+	nil,                                 // 64: AnthropicApp.HeadersEntry
+	nil,                                 // 65: GraphqlApp.HeadersEntry
+	nil,                                 // 66: JsonrpcApp.HeadersEntry
+	nil,                                 // 67: WebsocketApp.HeadersEntry
+	nil,                                 // 68: HttpApp.HeadersEntry
+	nil,                                 // 69: McpApp.HeadersEntry
 }
 var file_proto_api_proto_depIdxs = []int32{
 	43, // 0: OpenAppRequest.app:type_name -> ConfigurationApp
@@ -4016,7 +5860,7 @@ var file_proto_api_proto_depIdxs = []int32{
 	20, // 12: OpenApiDocument.security_schemes:type_name -> OpenApiSecurityScheme
 	19, // 13: OpenApiServer.variables:type_name -> OpenApiServerVariable
 	2,  // 14: OpenApiProblem.kind:type_name -> OpenApiProblemKind
-	49, // 15: InspectMcpRequest.mcp:type_name -> McpApp
+	56, // 15: InspectMcpRequest.mcp:type_name -> McpApp
 	24, // 16: InspectMcpResponse.server:type_name -> McpServer
 	26, // 17: InspectMcpResponse.problem:type_name -> McpProblem
 	25, // 18: McpServer.tools:type_name -> McpTool
@@ -4034,48 +5878,60 @@ var file_proto_api_proto_depIdxs = []int32{
 	37, // 30: ListScriptsResponse.scripts:type_name -> Script
 	37, // 31: ReadScriptResponse.script:type_name -> Script
 	43, // 32: Configuration.apps:type_name -> ConfigurationApp
-	52, // 33: Configuration.variables:type_name -> Configuration.VariablesEntry
+	59, // 33: Configuration.variables:type_name -> Configuration.VariablesEntry
 	44, // 34: ConfigurationApp.grpc:type_name -> GrpcApp
 	45, // 35: ConfigurationApp.twirp:type_name -> TwirpApp
 	46, // 36: ConfigurationApp.openapi:type_name -> OpenApiApp
 	47, // 37: ConfigurationApp.openai:type_name -> OpenAiApp
-	48, // 38: ConfigurationApp.folder:type_name -> FolderApp
-	49, // 39: ConfigurationApp.mcp:type_name -> McpApp
-	53, // 40: GrpcApp.headers:type_name -> GrpcApp.HeadersEntry
-	54, // 41: TwirpApp.headers:type_name -> TwirpApp.HeadersEntry
-	55, // 42: OpenApiApp.headers:type_name -> OpenApiApp.HeadersEntry
-	56, // 43: OpenAiApp.headers:type_name -> OpenAiApp.HeadersEntry
-	57, // 44: McpApp.headers:type_name -> McpApp.HeadersEntry
-	42, // 45: UpdateConfigurationRequest.configuration:type_name -> Configuration
-	42, // 46: UpdateConfigurationResponse.configuration:type_name -> Configuration
-	33, // 47: UpdateConfigurationResponse.variable_status:type_name -> VariableStatus
-	7,  // 48: Api.Compile:input_type -> CompileRequest
-	8,  // 49: Api.OpenApp:input_type -> OpenAppRequest
-	15, // 50: Api.InspectOpenApi:input_type -> InspectOpenApiRequest
-	10, // 51: Api.InspectGrpc:input_type -> InspectGrpcRequest
-	22, // 52: Api.InspectMcp:input_type -> InspectMcpRequest
-	30, // 53: Api.GetConfiguration:input_type -> GetConfigurationRequest
-	50, // 54: Api.UpdateConfiguration:input_type -> UpdateConfigurationRequest
-	34, // 55: Api.SetStoredValue:input_type -> SetStoredValueRequest
-	35, // 56: Api.ClearStoredValue:input_type -> ClearStoredValueRequest
-	38, // 57: Api.ListScripts:input_type -> ListScriptsRequest
-	40, // 58: Api.ReadScript:input_type -> ReadScriptRequest
-	27, // 59: Api.Compile:output_type -> CompileResponse
-	9,  // 60: Api.OpenApp:output_type -> OpenAppResponse
-	16, // 61: Api.InspectOpenApi:output_type -> InspectOpenApiResponse
-	11, // 62: Api.InspectGrpc:output_type -> InspectGrpcResponse
-	23, // 63: Api.InspectMcp:output_type -> InspectMcpResponse
-	31, // 64: Api.GetConfiguration:output_type -> GetConfigurationResponse
-	51, // 65: Api.UpdateConfiguration:output_type -> UpdateConfigurationResponse
-	36, // 66: Api.SetStoredValue:output_type -> StoredValueResponse
-	36, // 67: Api.ClearStoredValue:output_type -> StoredValueResponse
-	39, // 68: Api.ListScripts:output_type -> ListScriptsResponse
-	41, // 69: Api.ReadScript:output_type -> ReadScriptResponse
-	59, // [59:70] is the sub-list for method output_type
-	48, // [48:59] is the sub-list for method input_type
-	48, // [48:48] is the sub-list for extension type_name
-	48, // [48:48] is the sub-list for extension extendee
-	0,  // [0:48] is the sub-list for field type_name
+	49, // 38: ConfigurationApp.folder:type_name -> FolderApp
+	56, // 39: ConfigurationApp.mcp:type_name -> McpApp
+	48, // 40: ConfigurationApp.anthropic:type_name -> AnthropicApp
+	50, // 41: ConfigurationApp.sqlite:type_name -> SqliteApp
+	51, // 42: ConfigurationApp.graphql:type_name -> GraphqlApp
+	52, // 43: ConfigurationApp.jsonrpc:type_name -> JsonrpcApp
+	53, // 44: ConfigurationApp.websocket:type_name -> WebsocketApp
+	54, // 45: ConfigurationApp.http:type_name -> HttpApp
+	60, // 46: GrpcApp.headers:type_name -> GrpcApp.HeadersEntry
+	61, // 47: TwirpApp.headers:type_name -> TwirpApp.HeadersEntry
+	62, // 48: OpenApiApp.headers:type_name -> OpenApiApp.HeadersEntry
+	63, // 49: OpenAiApp.headers:type_name -> OpenAiApp.HeadersEntry
+	64, // 50: AnthropicApp.headers:type_name -> AnthropicApp.HeadersEntry
+	65, // 51: GraphqlApp.headers:type_name -> GraphqlApp.HeadersEntry
+	66, // 52: JsonrpcApp.headers:type_name -> JsonrpcApp.HeadersEntry
+	67, // 53: WebsocketApp.headers:type_name -> WebsocketApp.HeadersEntry
+	68, // 54: HttpApp.headers:type_name -> HttpApp.HeadersEntry
+	55, // 55: HttpApp.endpoints:type_name -> HttpEndpoint
+	69, // 56: McpApp.headers:type_name -> McpApp.HeadersEntry
+	42, // 57: UpdateConfigurationRequest.configuration:type_name -> Configuration
+	42, // 58: UpdateConfigurationResponse.configuration:type_name -> Configuration
+	33, // 59: UpdateConfigurationResponse.variable_status:type_name -> VariableStatus
+	7,  // 60: Api.Compile:input_type -> CompileRequest
+	8,  // 61: Api.OpenApp:input_type -> OpenAppRequest
+	15, // 62: Api.InspectOpenApi:input_type -> InspectOpenApiRequest
+	10, // 63: Api.InspectGrpc:input_type -> InspectGrpcRequest
+	22, // 64: Api.InspectMcp:input_type -> InspectMcpRequest
+	30, // 65: Api.GetConfiguration:input_type -> GetConfigurationRequest
+	57, // 66: Api.UpdateConfiguration:input_type -> UpdateConfigurationRequest
+	34, // 67: Api.SetStoredValue:input_type -> SetStoredValueRequest
+	35, // 68: Api.ClearStoredValue:input_type -> ClearStoredValueRequest
+	38, // 69: Api.ListScripts:input_type -> ListScriptsRequest
+	40, // 70: Api.ReadScript:input_type -> ReadScriptRequest
+	27, // 71: Api.Compile:output_type -> CompileResponse
+	9,  // 72: Api.OpenApp:output_type -> OpenAppResponse
+	16, // 73: Api.InspectOpenApi:output_type -> InspectOpenApiResponse
+	11, // 74: Api.InspectGrpc:output_type -> InspectGrpcResponse
+	23, // 75: Api.InspectMcp:output_type -> InspectMcpResponse
+	31, // 76: Api.GetConfiguration:output_type -> GetConfigurationResponse
+	58, // 77: Api.UpdateConfiguration:output_type -> UpdateConfigurationResponse
+	36, // 78: Api.SetStoredValue:output_type -> StoredValueResponse
+	36, // 79: Api.ClearStoredValue:output_type -> StoredValueResponse
+	39, // 80: Api.ListScripts:output_type -> ListScriptsResponse
+	41, // 81: Api.ReadScript:output_type -> ReadScriptResponse
+	71, // [71:82] is the sub-list for method output_type
+	60, // [60:71] is the sub-list for method input_type
+	60, // [60:60] is the sub-list for extension type_name
+	60, // [60:60] is the sub-list for extension extendee
+	0,  // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_proto_api_proto_init() }
@@ -4090,6 +5946,12 @@ func file_proto_api_proto_init() {
 		(*ConfigurationApp_Openai)(nil),
 		(*ConfigurationApp_Folder)(nil),
 		(*ConfigurationApp_Mcp)(nil),
+		(*ConfigurationApp_Anthropic)(nil),
+		(*ConfigurationApp_Sqlite)(nil),
+		(*ConfigurationApp_Graphql)(nil),
+		(*ConfigurationApp_Jsonrpc)(nil),
+		(*ConfigurationApp_Websocket)(nil),
+		(*ConfigurationApp_Http)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -4097,7 +5959,7 @@ func file_proto_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_api_proto_rawDesc), len(file_proto_api_proto_rawDesc)),
 			NumEnums:      7,
-			NumMessages:   51,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -3877,198 +3877,268 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 3087 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4b, 0x6f, 0xe3, 0xd6,
-	0x15, 0x1e, 0xbd, 0xa5, 0x23, 0x8f, 0xc4, 0xb9, 0x7e, 0x69, 0x34, 0x6f, 0x4e, 0x26, 0x9e, 0x18,
-	0x09, 0x93, 0xba, 0x99, 0x60, 0x90, 0x16, 0x41, 0x65, 0x99, 0xb6, 0x95, 0x91, 0x25, 0x83, 0x92,
-	0x1d, 0x24, 0x2d, 0x40, 0xd0, 0xd4, 0xb5, 0xcc, 0x9a, 0x22, 0x19, 0x92, 0x72, 0xea, 0xae, 0xbb,
-	0x2a, 0xd0, 0x4d, 0x0b, 0xb4, 0xeb, 0x16, 0x2d, 0xba, 0xe9, 0x1f, 0xe8, 0x3f, 0xe8, 0xba, 0x28,
-	0xd0, 0xbf, 0xd1, 0x65, 0x17, 0x2d, 0x50, 0xdc, 0x17, 0x45, 0x52, 0xf4, 0x60, 0xd2, 0x69, 0x77,
-	0xbc, 0xdf, 0x39, 0xf7, 0x71, 0x9e, 0xf7, 0xdc, 0x43, 0x68, 0x7a, 0xbe, 0x1b, 0xba, 0x1f, 0x1a,
-	0x9e, 0xa5, 0xd0, 0x2f, 0xf9, 0x47, 0xd0, 0xe8, 0xba, 0x33, 0xcf, 0xb2, 0xb1, 0x86, 0xbf, 0x9e,
-	0xe3, 0x20, 0x44, 0x0d, 0xc8, 0x5b, 0x93, 0x56, 0xee, 0x71, 0xee, 0x79, 0x4d, 0xcb, 0x5b, 0x13,
-	0xf4, 0x00, 0xc0, 0x76, 0xa7, 0xba, 0x7b, 0x7e, 0x1e, 0xe0, 0xb0, 0x95, 0x7f, 0x9c, 0x7b, 0x5e,
-	0xd2, 0x6a, 0xb6, 0x3b, 0x1d, 0x52, 0x00, 0xdd, 0x83, 0x1a, 0x5d, 0x49, 0x9f, 0x58, 0x7e, 0xab,
-	0x40, 0x67, 0x55, 0x29, 0xb0, 0x67, 0xf9, 0xf2, 0x0b, 0x68, 0x0c, 0x3d, 0xec, 0x74, 0x3c, 0x4f,
-	0xac, 0xfe, 0x14, 0x0a, 0x86, 0xe7, 0xd1, 0xe5, 0xeb, 0x3b, 0x77, 0x94, 0xae, 0xeb, 0x9c, 0x5b,
-	0xd3, 0xb9, 0x6f, 0x84, 0x96, 0x4b, 0xd9, 0x08, 0x55, 0xfe, 0x6d, 0x0e, 0x9a, 0xd1, 0xbc, 0xc0,
-	0x73, 0x9d, 0x00, 0xa3, 0xa7, 0x50, 0x0e, 0x42, 0x23, 0x9c, 0x07, 0x74, 0x6e, 0x63, 0xa7, 0xae,
-	0x10, 0x8e, 0x11, 0x85, 0x34, 0x4e, 0x42, 0x2d, 0x28, 0xda, 0xee, 0x34, 0x68, 0xe5, 0x1f, 0x17,
-	0x9e, 0xd7, 0x77, 0x8a, 0x4a, 0xdf, 0x9d, 0x6a, 0x14, 0x79, 0xed, 0x31, 0xd1, 0x06, 0x94, 0x43,
-	0xc3, 0x9f, 0xe2, 0xb0, 0x55, 0xa4, 0x14, 0x3e, 0x42, 0x6d, 0x60, 0x3c, 0xa6, 0x6b, 0xb7, 0x4a,
-	0xb1, 0x39, 0xa6, 0x6b, 0xcb, 0x3b, 0x80, 0x7a, 0x4e, 0xe0, 0x61, 0x33, 0x3c, 0xf0, 0x3d, 0x53,
-	0x88, 0x77, 0x1f, 0x8a, 0x53, 0xdf, 0x33, 0xb9, 0x7c, 0x55, 0x85, 0xd0, 0x88, 0x14, 0x14, 0x95,
-	0xcf, 0x60, 0x35, 0x31, 0x27, 0x26, 0x1a, 0xf6, 0xaf, 0xb0, 0xcf, 0xa7, 0xd5, 0xe9, 0xb4, 0x11,
-	0x85, 0x34, 0x4e, 0x42, 0xef, 0x42, 0xc5, 0xf3, 0xdd, 0x33, 0x1b, 0xcf, 0xa8, 0x0d, 0xea, 0x3b,
-	0x2b, 0x94, 0xeb, 0x98, 0x61, 0x9a, 0x20, 0xca, 0xbf, 0xcf, 0x03, 0x2c, 0xa6, 0x13, 0xd1, 0x02,
-	0x77, 0xee, 0x9b, 0x98, 0x5b, 0x94, 0x8f, 0x62, 0x22, 0xe7, 0x13, 0x22, 0x4b, 0x50, 0x08, 0xed,
-	0x80, 0x6a, 0xa8, 0xaa, 0x91, 0x4f, 0xf4, 0x1c, 0xaa, 0xe4, 0x08, 0x96, 0x89, 0x83, 0x56, 0x91,
-	0xea, 0x75, 0x25, 0x3a, 0x9f, 0x65, 0x62, 0x2d, 0xa2, 0xa2, 0x27, 0xb0, 0x32, 0xc3, 0xe1, 0x85,
-	0x3b, 0xd1, 0x4d, 0x77, 0xee, 0x84, 0x54, 0x65, 0x25, 0xad, 0xce, 0xb0, 0x2e, 0x81, 0xd0, 0x07,
-	0x80, 0x7c, 0x7c, 0x6e, 0x63, 0x93, 0xd8, 0x5b, 0xbf, 0xc2, 0x7e, 0x60, 0xb9, 0x4e, 0xab, 0x4c,
-	0x8f, 0x70, 0x67, 0x41, 0x39, 0x65, 0x04, 0xe2, 0x7b, 0xe7, 0x96, 0x8d, 0xf9, 0x7a, 0x15, 0xe6,
-	0x7b, 0x04, 0x61, 0xab, 0x25, 0x8c, 0x5a, 0x4d, 0x19, 0xf5, 0x3e, 0xd4, 0x7c, 0x6c, 0x98, 0x17,
-	0xc6, 0x99, 0x8d, 0x5b, 0x35, 0x2a, 0xcf, 0x02, 0x90, 0x7f, 0x0a, 0xf5, 0x98, 0x10, 0x08, 0x41,
-	0xd1, 0x31, 0x66, 0x42, 0x49, 0xf4, 0x7b, 0x49, 0x9c, 0xfc, 0xb2, 0x38, 0x1f, 0xc3, 0x46, 0x10,
-	0xfa, 0xd8, 0x98, 0x59, 0xce, 0x54, 0x4f, 0x30, 0x17, 0x28, 0xf3, 0x5a, 0x44, 0x3d, 0x5a, 0xcc,
-	0x92, 0x31, 0xdb, 0x9b, 0x9b, 0x0e, 0xbd, 0x03, 0xc5, 0x4b, 0xcb, 0x99, 0x70, 0xbf, 0x96, 0xe2,
-	0x66, 0x7d, 0x65, 0x39, 0x13, 0x8d, 0x52, 0x51, 0x0b, 0x2a, 0x33, 0x1c, 0x04, 0xc6, 0x14, 0x73,
-	0x8b, 0x89, 0x21, 0x31, 0xe5, 0x04, 0x87, 0x86, 0x65, 0x73, 0xbf, 0xe6, 0x23, 0xf9, 0x33, 0x58,
-	0xe7, 0xde, 0xc6, 0x62, 0xc9, 0x12, 0x4e, 0xfa, 0x0c, 0x2a, 0xae, 0x87, 0x1d, 0xc3, 0xb3, 0x22,
-	0x87, 0xe3, 0x1c, 0xc4, 0x55, 0x05, 0x4d, 0xfe, 0x1a, 0x36, 0xd2, 0xf3, 0xb9, 0xc3, 0xbe, 0x0f,
-	0xd5, 0x89, 0x6b, 0xce, 0x67, 0xd8, 0x09, 0xf9, 0x0a, 0x92, 0x58, 0x61, 0x8f, 0xe3, 0x5a, 0xc4,
-	0x81, 0xde, 0x4b, 0x7b, 0x6e, 0x53, 0x30, 0x2f, 0x39, 0xef, 0xbf, 0xf3, 0x22, 0xf0, 0xa3, 0x85,
-	0xd0, 0x1a, 0x94, 0x42, 0x2b, 0xb4, 0x85, 0x6d, 0xd8, 0x80, 0xa8, 0x43, 0x78, 0x0f, 0x57, 0x07,
-	0x1f, 0xa2, 0x2d, 0x68, 0x72, 0x09, 0x22, 0xff, 0x62, 0x7a, 0x69, 0x70, 0xf8, 0x34, 0xc1, 0xc8,
-	0x52, 0x0f, 0xb7, 0x5a, 0x91, 0x5a, 0xad, 0x11, 0xc1, 0x91, 0x9b, 0x85, 0xc6, 0x34, 0xe1, 0xd4,
-	0xd5, 0xd0, 0x98, 0x32, 0xe2, 0x73, 0xa8, 0xb0, 0x08, 0x0d, 0x5a, 0x65, 0x1a, 0x1d, 0x0d, 0x21,
-	0x1d, 0x0f, 0x60, 0x41, 0x46, 0x1d, 0x90, 0x02, 0x6c, 0xce, 0x7d, 0x2b, 0xbc, 0xd6, 0x03, 0xf3,
-	0x02, 0xcf, 0x70, 0xd0, 0xaa, 0xd0, 0x29, 0x1b, 0x8b, 0x29, 0x8c, 0x3e, 0xa2, 0x64, 0xad, 0x19,
-	0x24, 0xc6, 0x24, 0x16, 0xa5, 0xe9, 0x1c, 0x07, 0x01, 0x9e, 0xe8, 0x67, 0x46, 0x80, 0xf5, 0xb9,
-	0x6f, 0x73, 0xbf, 0x6f, 0x70, 0x7c, 0xd7, 0x08, 0xf0, 0x89, 0x6f, 0x13, 0xcf, 0xf4, 0xb0, 0xaf,
-	0x2f, 0x04, 0x14, 0x4b, 0xf1, 0x50, 0x58, 0xf3, 0xb0, 0x3f, 0x14, 0x44, 0xb1, 0xad, 0x7c, 0x0d,
-	0xb7, 0x13, 0x87, 0x27, 0xe9, 0x80, 0xec, 0xc1, 0x54, 0x4f, 0x3e, 0xd1, 0x63, 0xa8, 0x4f, 0x70,
-	0x60, 0xfa, 0x96, 0x17, 0x2e, 0x94, 0x1f, 0x87, 0xd0, 0xc7, 0x50, 0xbb, 0x32, 0x7c, 0x8b, 0x84,
-	0x19, 0x49, 0x24, 0x29, 0x01, 0xc9, 0xb2, 0xa7, 0x9c, 0xac, 0x2d, 0x18, 0xe5, 0x5f, 0xe5, 0x60,
-	0x3d, 0x93, 0x29, 0x33, 0x36, 0x9f, 0xc2, 0xed, 0x09, 0x3e, 0x37, 0xe6, 0x76, 0xa8, 0x5f, 0x19,
-	0xf6, 0x5c, 0xc4, 0xc4, 0x0a, 0x07, 0x4f, 0x09, 0x86, 0x1e, 0x41, 0x1d, 0x3b, 0xf3, 0x19, 0xe3,
-	0x60, 0x47, 0xa9, 0x69, 0x40, 0x20, 0x4a, 0x0f, 0xd2, 0xb2, 0x14, 0x97, 0x64, 0x91, 0xff, 0x96,
-	0x8f, 0x9d, 0x2a, 0x6e, 0x0b, 0xa2, 0x99, 0x4b, 0x7c, 0x2d, 0x34, 0x73, 0x89, 0xaf, 0xc9, 0x39,
-	0xc3, 0x6b, 0x4f, 0x1c, 0x85, 0x7e, 0xd3, 0xf4, 0x4b, 0xf9, 0x45, 0x6c, 0xb2, 0x11, 0x39, 0xff,
-	0x19, 0x36, 0x7c, 0xec, 0xeb, 0xe7, 0xae, 0x3f, 0x33, 0xc4, 0xc5, 0xb3, 0xc2, 0xc0, 0x7d, 0x8a,
-	0xd1, 0x9b, 0xd8, 0xe1, 0x17, 0x4f, 0xde, 0x72, 0xd0, 0x33, 0x68, 0x78, 0x86, 0x6f, 0xcc, 0x70,
-	0x88, 0x7d, 0x9d, 0xaa, 0x84, 0x25, 0xce, 0xdb, 0x11, 0x3a, 0x20, 0xba, 0xf9, 0x00, 0x56, 0x89,
-	0xa7, 0xeb, 0x16, 0xc9, 0x45, 0x8e, 0x83, 0xcd, 0x90, 0xfa, 0x49, 0x85, 0xf2, 0x4a, 0x84, 0xd4,
-	0x9b, 0x74, 0x19, 0xe1, 0x64, 0xd9, 0xa0, 0xd5, 0x65, 0x83, 0x66, 0x04, 0x4a, 0x2d, 0x33, 0x50,
-	0xb6, 0xa0, 0xe9, 0xe3, 0xaf, 0xe7, 0x96, 0x8f, 0x03, 0xdd, 0x0d, 0x2f, 0x48, 0x4c, 0x00, 0xf5,
-	0xb6, 0x86, 0x80, 0x87, 0x14, 0x95, 0x2f, 0x45, 0x5d, 0x20, 0x52, 0x00, 0xda, 0x4a, 0x24, 0xc1,
-	0xd5, 0x54, 0x86, 0x78, 0xab, 0x3c, 0xa8, 0xc0, 0x1d, 0x9e, 0xc7, 0x8e, 0xcc, 0xa8, 0x0e, 0xb9,
-	0x0b, 0x85, 0x99, 0x29, 0xea, 0x90, 0x8a, 0x72, 0x64, 0x7a, 0xb4, 0xfa, 0x98, 0x99, 0x9e, 0xac,
-	0x47, 0x37, 0x3b, 0xe5, 0xe7, 0x39, 0x4f, 0x4e, 0x5d, 0xd2, 0x40, 0xe6, 0xa4, 0xee, 0xe8, 0x67,
-	0xe9, 0x4c, 0x57, 0x27, 0x4c, 0x4b, 0x59, 0xee, 0xd7, 0x05, 0xa8, 0x45, 0x93, 0x33, 0xdd, 0xfb,
-	0xe6, 0xec, 0xf6, 0x1e, 0x48, 0xa2, 0x04, 0x49, 0xa5, 0xb7, 0xa6, 0xc0, 0x45, 0x7e, 0xbb, 0x0f,
-	0xb5, 0x0b, 0xc3, 0x99, 0x04, 0x17, 0xc6, 0x25, 0xa6, 0xfe, 0x55, 0xd5, 0x16, 0x00, 0xb9, 0x89,
-	0x83, 0xb9, 0xe7, 0xb9, 0x7e, 0x88, 0x27, 0x62, 0xa5, 0xa0, 0x55, 0xa2, 0x31, 0x72, 0x27, 0xa2,
-	0xf0, 0xb5, 0x02, 0x72, 0x13, 0x87, 0xae, 0x6b, 0x73, 0xf3, 0x97, 0xd9, 0x4d, 0x4c, 0x10, 0x66,
-	0xf9, 0x67, 0xd0, 0xf0, 0x31, 0x2b, 0x2d, 0x12, 0x97, 0xf5, 0x6d, 0x81, 0x32, 0xb6, 0x4f, 0x60,
-	0x33, 0x62, 0x0b, 0xf1, 0xcc, 0xb3, 0x8d, 0x50, 0xf0, 0x57, 0x29, 0xff, 0xba, 0x20, 0x8f, 0x39,
-	0x95, 0xcd, 0x7b, 0x02, 0x2b, 0x9e, 0xef, 0xce, 0xbc, 0x30, 0xe1, 0x7e, 0x75, 0x86, 0x31, 0x96,
-	0x87, 0x50, 0x22, 0xc7, 0x21, 0x1e, 0x57, 0xa0, 0xa5, 0xd7, 0x91, 0xe9, 0x8d, 0x5d, 0xd7, 0xd6,
-	0x18, 0x8c, 0x64, 0x58, 0xb1, 0x9c, 0x20, 0xf4, 0xe7, 0xb4, 0xc0, 0x08, 0x5a, 0x75, 0x16, 0x70,
-	0x71, 0x4c, 0xf6, 0xa1, 0xc2, 0x67, 0x65, 0x5a, 0x25, 0xba, 0x89, 0xf2, 0xf1, 0x9b, 0x28, 0x15,
-	0x3f, 0x85, 0xe5, 0xf8, 0xb9, 0x47, 0x2b, 0x91, 0x89, 0xee, 0x3a, 0xf6, 0x35, 0x37, 0x44, 0x95,
-	0x00, 0x43, 0xc7, 0xbe, 0x96, 0x4d, 0x80, 0x85, 0x8f, 0xa0, 0xa7, 0x89, 0x30, 0x68, 0xc6, 0xdc,
-	0xe7, 0xad, 0x42, 0xe0, 0xe7, 0x39, 0x68, 0x46, 0x65, 0x3e, 0x77, 0xe8, 0x77, 0x53, 0x05, 0x75,
-	0x43, 0xe1, 0x1c, 0x6f, 0x5c, 0x53, 0x3f, 0x81, 0x0a, 0x33, 0x96, 0x48, 0xf3, 0x15, 0x65, 0x44,
-	0xc7, 0x9a, 0xc0, 0x89, 0x1a, 0x83, 0x70, 0x7e, 0xc6, 0xd3, 0x1b, 0xfd, 0x96, 0x7f, 0x00, 0x85,
-	0xbe, 0x3b, 0x45, 0x8f, 0xa0, 0x64, 0xe3, 0x2b, 0x6c, 0xf3, 0xed, 0x6b, 0x64, 0xe1, 0x3e, 0x01,
-	0x34, 0x86, 0xdf, 0x2c, 0xa6, 0xfc, 0x09, 0x94, 0xd9, 0x46, 0x64, 0x7d, 0xcf, 0x08, 0x2f, 0x84,
-	0x99, 0xc8, 0x37, 0x99, 0x67, 0xba, 0x4e, 0x88, 0x1d, 0x51, 0xdb, 0x8a, 0xa1, 0x7c, 0x17, 0x36,
-	0x0f, 0x70, 0x98, 0x78, 0x73, 0xf0, 0x7c, 0x20, 0xff, 0x25, 0x07, 0xad, 0x65, 0x1a, 0x57, 0xd5,
-	0xc7, 0x70, 0xdb, 0x8c, 0x13, 0x78, 0x0a, 0x68, 0x24, 0x9f, 0x2f, 0x5a, 0x92, 0xe9, 0x35, 0x8a,
-	0x7b, 0x09, 0x4d, 0x71, 0xf1, 0xe9, 0xdc, 0x06, 0x4c, 0x81, 0x4d, 0x45, 0xdc, 0x7a, 0xdc, 0x08,
-	0x8d, 0xab, 0xc4, 0x18, 0xc9, 0x50, 0xf1, 0xe7, 0x4e, 0x68, 0xcd, 0x58, 0x44, 0x13, 0x3f, 0xd7,
-	0xd8, 0x58, 0x13, 0x04, 0xf9, 0xcf, 0x39, 0xa8, 0x70, 0x10, 0xbd, 0x84, 0x96, 0x69, 0x38, 0xfa,
-	0xdc, 0x9b, 0xb0, 0x48, 0x4b, 0x0b, 0x51, 0xd5, 0x36, 0x4c, 0xc3, 0x39, 0xa1, 0xe4, 0x84, 0x30,
-	0x68, 0x13, 0x2a, 0x53, 0x2b, 0xd4, 0x7d, 0x7c, 0x2e, 0x5e, 0x08, 0x53, 0x2b, 0xd4, 0xf0, 0x39,
-	0x89, 0xc5, 0xb3, 0xb9, 0x65, 0x4f, 0x74, 0x67, 0x3e, 0x3b, 0xc3, 0xe2, 0x31, 0x55, 0xa7, 0xd8,
-	0x80, 0x42, 0x64, 0xd7, 0x98, 0x7c, 0xae, 0x8f, 0x75, 0xe3, 0xca, 0xb0, 0x6c, 0x5a, 0x89, 0x33,
-	0xff, 0xdf, 0x58, 0xc8, 0xe5, 0xfa, 0xb8, 0x23, 0xa8, 0xf2, 0x05, 0x34, 0x92, 0x1a, 0xc8, 0x0c,
-	0xc4, 0xad, 0xe8, 0x51, 0x93, 0xe7, 0x71, 0x12, 0x4d, 0x62, 0xfe, 0x27, 0x5e, 0x39, 0x77, 0xa1,
-	0x8a, 0x9d, 0x2b, 0x76, 0x57, 0xb2, 0x73, 0x56, 0xb0, 0x73, 0x45, 0x6e, 0x49, 0xb9, 0x03, 0xeb,
-	0x23, 0x1c, 0xd2, 0xed, 0x27, 0xb4, 0x1c, 0x10, 0x37, 0xc3, 0x0d, 0x91, 0x1f, 0x2f, 0x33, 0xd8,
-	0x40, 0xfe, 0x00, 0x36, 0xbb, 0x36, 0x36, 0xfc, 0x37, 0x5b, 0x44, 0x1e, 0xc2, 0x6a, 0x82, 0x93,
-	0x3b, 0x57, 0x86, 0x33, 0xe4, 0xde, 0xc8, 0x19, 0xe4, 0x33, 0x28, 0x8f, 0x68, 0x92, 0xc9, 0x0c,
-	0x03, 0x71, 0x84, 0x7c, 0xf2, 0x5e, 0x11, 0xa1, 0x51, 0x48, 0x84, 0x06, 0xc9, 0x1c, 0xe7, 0xae,
-	0x3d, 0xc1, 0xbe, 0x78, 0x02, 0xb3, 0x91, 0xbc, 0x06, 0xa8, 0x6f, 0x05, 0x21, 0xdb, 0x27, 0x10,
-	0xd1, 0xf2, 0x12, 0x56, 0x13, 0x28, 0x17, 0x85, 0x24, 0x04, 0x06, 0x71, 0x11, 0x2a, 0x0a, 0x63,
-	0xd1, 0x04, 0x2e, 0x6f, 0xc1, 0x1d, 0x0d, 0x1b, 0x13, 0x0e, 0xbf, 0x46, 0x5b, 0x2f, 0x00, 0xc5,
-	0x19, 0xf9, 0x0e, 0x8f, 0x48, 0x3d, 0x45, 0x90, 0xe8, 0xe6, 0xe6, 0x0c, 0x1c, 0x96, 0xff, 0x91,
-	0x83, 0xdb, 0x49, 0x47, 0x7e, 0x04, 0x75, 0xa2, 0x0f, 0xdd, 0xf3, 0xf1, 0xb9, 0xf5, 0x13, 0xbe,
-	0x07, 0x10, 0xe8, 0x98, 0x22, 0xe8, 0x19, 0x14, 0x0d, 0xcf, 0x63, 0x77, 0x5f, 0x66, 0x4f, 0x82,
-	0x92, 0xd1, 0xf7, 0xe2, 0x65, 0x2d, 0x2b, 0xf5, 0x1f, 0x24, 0x79, 0x23, 0x7b, 0x05, 0xaa, 0x13,
-	0xfa, 0xd7, 0xb1, 0xea, 0xb6, 0xfd, 0xfd, 0x85, 0x5f, 0x33, 0x62, 0x46, 0xfd, 0x98, 0xe9, 0x64,
-	0x9f, 0xe6, 0x5f, 0xe6, 0x3e, 0x2f, 0x56, 0xf3, 0x52, 0xe1, 0xf3, 0x62, 0xb5, 0x28, 0x95, 0xe8,
-	0x03, 0xf7, 0xc7, 0xd8, 0x0c, 0x49, 0x82, 0xbe, 0x0e, 0x42, 0x3c, 0x93, 0x7f, 0x99, 0x07, 0x29,
-	0x7d, 0xe6, 0x4c, 0x2f, 0x7e, 0xc8, 0x9b, 0x13, 0xf9, 0x64, 0x73, 0xe2, 0xf0, 0x16, 0x6b, 0x4f,
-	0xa0, 0x27, 0x50, 0x0a, 0xbf, 0xb1, 0x7c, 0x8f, 0xfa, 0x46, 0x7d, 0xa7, 0xa6, 0x8c, 0xc9, 0x88,
-	0x71, 0x30, 0x0a, 0xda, 0x5a, 0x3c, 0x1d, 0x8b, 0x4b, 0x4f, 0xc7, 0xc3, 0x5b, 0xd1, 0xe3, 0x11,
-	0xbd, 0x03, 0x65, 0xfa, 0x69, 0xd1, 0xfa, 0x95, 0x94, 0x4b, 0x94, 0x8f, 0xb3, 0x71, 0x1a, 0xe1,
-	0xe2, 0x5e, 0x57, 0xe1, 0x5c, 0xfb, 0x74, 0xc8, 0xb9, 0x18, 0x0d, 0xdd, 0x63, 0xb5, 0x5a, 0x35,
-	0x51, 0xab, 0x1d, 0xde, 0xa2, 0xd5, 0xda, 0x6e, 0x89, 0x36, 0x94, 0x3e, 0x2f, 0x56, 0xcb, 0x52,
-	0x45, 0xab, 0xce, 0x0c, 0xff, 0x72, 0xe2, 0x7e, 0xe3, 0xc8, 0xff, 0x2c, 0x40, 0x85, 0xcb, 0x97,
-	0xf1, 0x88, 0x49, 0x34, 0x0e, 0xf2, 0xa9, 0xc6, 0xc1, 0x43, 0x80, 0x45, 0x27, 0x82, 0x77, 0x42,
-	0x62, 0x08, 0xfa, 0x10, 0x2a, 0x17, 0xd8, 0x98, 0x90, 0xea, 0x96, 0xf5, 0x43, 0xd6, 0x85, 0x26,
-	0x95, 0x43, 0x86, 0x33, 0xf3, 0x0b, 0x2e, 0xd1, 0x53, 0x61, 0x85, 0x3c, 0xed, 0xa9, 0x7c, 0x04,
-	0x6b, 0x96, 0x43, 0x5f, 0x64, 0x58, 0x0f, 0x2e, 0x2d, 0x8f, 0x14, 0x60, 0xd6, 0xf9, 0x35, 0xad,
-	0xab, 0xaa, 0x1a, 0x12, 0xb4, 0xd1, 0xa5, 0xe5, 0x9d, 0x52, 0x0a, 0x49, 0xc7, 0xa6, 0xa1, 0x9f,
-	0x5b, 0x36, 0xe6, 0x85, 0x7c, 0xd9, 0x34, 0xf6, 0x2d, 0x1b, 0x93, 0x27, 0xa1, 0x69, 0x5b, 0xd8,
-	0x09, 0x75, 0x13, 0xfb, 0x21, 0xe3, 0xe0, 0x4f, 0x42, 0x86, 0x77, 0xb1, 0x1f, 0x52, 0xce, 0x77,
-	0xa1, 0xc9, 0x39, 0x2f, 0xf1, 0x35, 0x63, 0xac, 0xb1, 0xf7, 0x03, 0x83, 0x5f, 0xe1, 0x6b, 0xca,
-	0x87, 0xa0, 0x68, 0xcc, 0xc3, 0x0b, 0x5a, 0xba, 0xd7, 0x34, 0xfa, 0x4d, 0x4b, 0x1f, 0xf7, 0x12,
-	0x3b, 0xbc, 0x6c, 0x62, 0x03, 0xd4, 0x86, 0xea, 0x3c, 0xc0, 0x3e, 0x75, 0xb4, 0x15, 0xa6, 0x45,
-	0x31, 0xa6, 0xbd, 0x33, 0x23, 0x08, 0xbe, 0x71, 0xfd, 0x49, 0xeb, 0x36, 0xd7, 0x30, 0x1f, 0xa3,
-	0xc7, 0xb0, 0x42, 0x9e, 0xe7, 0xe4, 0x18, 0x74, 0x6e, 0x83, 0xc5, 0xa4, 0xe1, 0x59, 0xaf, 0xf0,
-	0x35, 0xc9, 0xce, 0xed, 0x4f, 0x61, 0x25, 0xae, 0xcb, 0x6f, 0x13, 0x2d, 0xf2, 0x1f, 0x73, 0x50,
-	0x15, 0x9e, 0xfb, 0x6d, 0x6d, 0xff, 0xd1, 0xc2, 0xb6, 0xe2, 0xe5, 0x2a, 0x96, 0xca, 0x36, 0xee,
-	0x5b, 0x9d, 0xf4, 0x77, 0x05, 0x80, 0x45, 0xf8, 0x90, 0xdb, 0x8a, 0x3c, 0x3b, 0xf4, 0xc5, 0x81,
-	0x2b, 0x64, 0x4c, 0x1e, 0x69, 0x91, 0xfe, 0xf3, 0x37, 0xe9, 0xbf, 0xf0, 0x1a, 0xfd, 0x17, 0x53,
-	0xfa, 0xdf, 0x59, 0x48, 0xc9, 0x92, 0x5e, 0x2b, 0x16, 0xc5, 0x37, 0x38, 0xf1, 0x13, 0x58, 0xa1,
-	0x87, 0x13, 0xf7, 0x07, 0x7b, 0x7a, 0xd6, 0x09, 0xd6, 0xe5, 0x77, 0xc8, 0x5d, 0xa8, 0x46, 0x5d,
-	0x09, 0xe6, 0xa4, 0x95, 0x33, 0xde, 0x8e, 0xd8, 0x82, 0x66, 0xaa, 0xf7, 0x21, 0x9c, 0x34, 0xd9,
-	0xe2, 0x20, 0xee, 0x4c, 0xb7, 0x61, 0xdb, 0x32, 0xf7, 0xa8, 0x71, 0x4e, 0x0f, 0x9b, 0xec, 0x6c,
-	0xf4, 0x99, 0xbb, 0x0d, 0x77, 0xe2, 0x9c, 0x4c, 0xc5, 0xcc, 0x67, 0x9b, 0x0b, 0x56, 0x7a, 0xd7,
-	0xbe, 0x95, 0x91, 0xfe, 0x94, 0x83, 0x5a, 0x94, 0xbb, 0x88, 0x5a, 0xb1, 0x33, 0xf1, 0x5c, 0x8b,
-	0xb7, 0xbe, 0x6a, 0x5a, 0x34, 0xbe, 0xc1, 0x48, 0xdf, 0x49, 0xbb, 0xd4, 0xe6, 0x22, 0x15, 0xfe,
-	0x1f, 0x7c, 0xea, 0x11, 0xd4, 0xa2, 0x1c, 0x9a, 0x55, 0x17, 0xc8, 0x7f, 0xcd, 0x41, 0x99, 0xa5,
-	0xd0, 0x8c, 0xe0, 0x50, 0x16, 0x87, 0x65, 0x65, 0xeb, 0x1a, 0x4f, 0xb7, 0x37, 0x78, 0x85, 0xc8,
-	0x15, 0x85, 0xac, 0x5c, 0x51, 0x8c, 0xab, 0x21, 0x1d, 0xf3, 0xa5, 0xff, 0x69, 0xcc, 0x6b, 0xd0,
-	0xce, 0x28, 0x62, 0x45, 0x7d, 0xf1, 0x5f, 0xd5, 0xef, 0xf2, 0x2f, 0x72, 0x70, 0x2f, 0x73, 0xd1,
-	0xb7, 0x7a, 0x15, 0x64, 0x94, 0x7b, 0xf9, 0x37, 0x2a, 0xf7, 0xb6, 0x8f, 0x59, 0xb2, 0xe0, 0x75,
-	0xf1, 0x26, 0xac, 0x0e, 0x8f, 0xd5, 0x81, 0x3e, 0x1a, 0x77, 0xc6, 0x27, 0x23, 0xfd, 0x64, 0xf0,
-	0x6a, 0x30, 0xfc, 0x62, 0x20, 0xdd, 0x42, 0x08, 0x1a, 0x71, 0xc2, 0xf0, 0x95, 0x94, 0x43, 0xeb,
-	0x70, 0x27, 0x8e, 0xa9, 0x9a, 0x36, 0xd4, 0xa4, 0xfc, 0xf6, 0xdf, 0xf3, 0xd0, 0x4c, 0x75, 0x9b,
-	0x51, 0x0b, 0xd6, 0x0e, 0xb4, 0xe3, 0xae, 0x7e, 0xac, 0x0d, 0x77, 0xfb, 0xea, 0x51, 0x6c, 0xe1,
-	0xfb, 0xd0, 0x4a, 0x51, 0x34, 0xb5, 0xd3, 0x3d, 0xec, 0xec, 0xf6, 0x55, 0x29, 0x87, 0xd6, 0x40,
-	0x4a, 0x50, 0xc7, 0xfd, 0x91, 0x94, 0x47, 0x0f, 0xa1, 0x9d, 0x40, 0x07, 0x43, 0x5d, 0x53, 0xf7,
-	0xfb, 0x6a, 0x77, 0xdc, 0x1b, 0x0e, 0xa4, 0x02, 0x7a, 0x0c, 0xf7, 0x53, 0x6b, 0x76, 0x4e, 0xc6,
-	0x87, 0xea, 0x60, 0xdc, 0xeb, 0x76, 0xc6, 0xea, 0x9e, 0x54, 0x44, 0x32, 0x3c, 0x4c, 0x70, 0x1c,
-	0xab, 0xda, 0x51, 0x6f, 0x34, 0xea, 0x0d, 0x07, 0xfa, 0x9e, 0x3a, 0xe8, 0xa9, 0x7b, 0x52, 0x69,
-	0xe9, 0x64, 0x83, 0xa1, 0x3e, 0x52, 0xb5, 0xd3, 0x5e, 0x57, 0x1d, 0x49, 0xe5, 0x25, 0x89, 0xc6,
-	0xbd, 0x23, 0x75, 0x78, 0x32, 0x96, 0x2a, 0xe8, 0x11, 0xdc, 0x4b, 0xcf, 0x3b, 0xd6, 0x86, 0xe3,
-	0xa1, 0xbe, 0xdf, 0xeb, 0xab, 0x23, 0xa9, 0xba, 0x74, 0x7c, 0x46, 0xed, 0x0d, 0x4e, 0x3b, 0xfd,
-	0xde, 0x9e, 0x54, 0x23, 0x46, 0x48, 0x2e, 0xdd, 0xd1, 0x0e, 0xd4, 0xb1, 0x04, 0xdb, 0xbf, 0xc9,
-	0x03, 0x5a, 0x6e, 0x61, 0x91, 0x83, 0x52, 0x3b, 0x74, 0x8e, 0x7b, 0x19, 0x0a, 0x7e, 0x0c, 0xf7,
-	0x33, 0xa8, 0x71, 0x25, 0x3f, 0x81, 0x07, 0x19, 0x1c, 0x44, 0x65, 0x43, 0xad, 0xf7, 0x95, 0xba,
-	0x27, 0xe5, 0x89, 0x4c, 0x4b, 0x2c, 0x87, 0xe3, 0xf1, 0x31, 0x37, 0x7a, 0x01, 0xdd, 0x85, 0xf5,
-	0x0c, 0x86, 0xa3, 0xbe, 0x54, 0x44, 0x4f, 0xe1, 0xd1, 0x12, 0x69, 0x30, 0x1c, 0xeb, 0x1d, 0x7d,
-	0x6f, 0xd8, 0x3d, 0x39, 0x52, 0x07, 0x63, 0xa9, 0x84, 0x1e, 0xc0, 0xdd, 0x25, 0xa6, 0xd1, 0x17,
-	0x9d, 0x83, 0x03, 0x55, 0xdb, 0x91, 0xca, 0x44, 0x65, 0x4b, 0xe4, 0xa3, 0x4e, 0x7f, 0x7f, 0xa8,
-	0x1d, 0xa9, 0x7b, 0x52, 0x65, 0xfb, 0x5f, 0x39, 0x68, 0x24, 0xbb, 0x1a, 0x44, 0x8b, 0x47, 0xdd,
-	0xe3, 0x0c, 0x85, 0x6c, 0x00, 0x8a, 0x13, 0xb8, 0x76, 0x73, 0xe8, 0x1e, 0x6c, 0x26, 0x27, 0x2c,
-	0x74, 0x94, 0x4f, 0xaf, 0x26, 0xac, 0x5d, 0x20, 0xca, 0x4f, 0xce, 0x8a, 0xe9, 0xad, 0x48, 0xd4,
-	0x12, 0xa7, 0xee, 0x0f, 0xb5, 0xdd, 0xde, 0xde, 0x9e, 0x3a, 0x90, 0x4a, 0xa8, 0x0d, 0x1b, 0x71,
-	0x52, 0x4c, 0x9b, 0xe5, 0xf4, 0x6e, 0x44, 0x5b, 0x47, 0xdd, 0x63, 0xa9, 0x42, 0x42, 0x2e, 0x4e,
-	0x50, 0x8f, 0x8e, 0xc7, 0x5f, 0x4a, 0xd5, 0xed, 0x1f, 0x92, 0xe7, 0x49, 0xac, 0xcd, 0x42, 0xc2,
-	0x75, 0x29, 0x84, 0x25, 0x58, 0xe1, 0x98, 0xa6, 0x76, 0xf6, 0xbe, 0x94, 0x72, 0x31, 0x84, 0xc7,
-	0x6e, 0x6c, 0x9e, 0x76, 0x32, 0x18, 0xf4, 0x06, 0x07, 0x52, 0x61, 0xbb, 0x0f, 0x55, 0xd1, 0x44,
-	0x41, 0x4d, 0xa8, 0xf7, 0xd5, 0x53, 0xb5, 0xaf, 0xef, 0xa9, 0xbb, 0x27, 0x07, 0xd2, 0x2d, 0xd4,
-	0x00, 0x60, 0x40, 0x6f, 0xb0, 0x3f, 0x94, 0x72, 0x8b, 0xf1, 0x17, 0x1d, 0x6d, 0x20, 0xe5, 0x17,
-	0x13, 0xb8, 0xa3, 0x6c, 0xff, 0x2c, 0x17, 0x7b, 0x8c, 0x8b, 0xf7, 0xf4, 0xfa, 0x69, 0x47, 0xeb,
-	0x11, 0x4d, 0xeb, 0xa3, 0xe1, 0x89, 0xd6, 0x55, 0xf5, 0x93, 0xc1, 0x48, 0x1d, 0x4b, 0xb7, 0x48,
-	0x94, 0xa5, 0x49, 0x24, 0x8a, 0xa4, 0x1c, 0xd1, 0x7b, 0x9a, 0xf2, 0x4a, 0xfd, 0xb2, 0x7b, 0xd8,
-	0xe9, 0x0d, 0x98, 0xbf, 0xa6, 0xa9, 0xea, 0xe0, 0xb4, 0xa7, 0x0d, 0x07, 0xd4, 0xdf, 0x0a, 0x3b,
-	0x7f, 0x28, 0x41, 0xa1, 0xe3, 0x59, 0xe8, 0x7d, 0xa8, 0x70, 0xcd, 0xa1, 0xa6, 0x92, 0xfc, 0x67,
-	0xdd, 0x96, 0x94, 0x74, 0x77, 0xeb, 0x7d, 0xa8, 0xf0, 0x3f, 0xc8, 0x48, 0xfc, 0x6e, 0xf2, 0x16,
-	0xdc, 0xe9, 0x9f, 0xcb, 0x1d, 0x68, 0x24, 0x7f, 0x75, 0xa1, 0x0d, 0x25, 0xf3, 0xdf, 0x59, 0x7b,
-	0x53, 0xb9, 0xe1, 0x9f, 0xd8, 0x4b, 0xa8, 0xc7, 0xfe, 0xed, 0xa2, 0x55, 0x65, 0xf9, 0xef, 0x70,
-	0x7b, 0x4d, 0xc9, 0xfa, 0xfd, 0xfb, 0x02, 0x60, 0xd1, 0x6f, 0x46, 0x48, 0x59, 0x6a, 0x56, 0xb7,
-	0x57, 0x95, 0x8c, 0x86, 0xf4, 0x01, 0x48, 0xe9, 0x86, 0x15, 0x6a, 0x29, 0x37, 0xf4, 0xb7, 0xda,
-	0x77, 0x95, 0x1b, 0xbb, 0x5b, 0xc7, 0xb0, 0x9a, 0xd5, 0x00, 0xba, 0xa7, 0xdc, 0x7c, 0xa3, 0xb6,
-	0xef, 0x2b, 0xaf, 0xbb, 0x19, 0x3f, 0x83, 0x46, 0xb2, 0xb7, 0x82, 0x36, 0x94, 0xcc, 0x66, 0x4b,
-	0x7b, 0x4d, 0xc9, 0x6a, 0x89, 0xec, 0x82, 0x94, 0x6e, 0xac, 0xa0, 0x96, 0x72, 0x43, 0xaf, 0xe5,
-	0x86, 0x35, 0x5e, 0x42, 0x3d, 0xd6, 0xa2, 0x40, 0xab, 0xca, 0x72, 0x1b, 0xa3, 0xbd, 0xa6, 0x64,
-	0x75, 0x31, 0x5e, 0x00, 0x2c, 0x3a, 0x0f, 0x08, 0x29, 0x4b, 0xfd, 0x8a, 0xf6, 0xaa, 0xb2, 0xdc,
-	0x9a, 0xd8, 0xad, 0x7d, 0x55, 0xf1, 0x2e, 0xa7, 0x1f, 0x1a, 0x9e, 0x75, 0x56, 0xa6, 0xef, 0x89,
-	0xef, 0xfe, 0x27, 0x00, 0x00, 0xff, 0xff, 0xbc, 0xf9, 0x29, 0x64, 0x6e, 0x21, 0x00, 0x00,
+	// 4194 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0x4f, 0x8f, 0xdb, 0x48,
+	0x76, 0xb7, 0xfe, 0x4b, 0x4f, 0x6a, 0x89, 0x5d, 0xfd, 0xc7, 0xb4, 0xec, 0xb1, 0xdb, 0xf4, 0x78,
+	0xec, 0xf5, 0xcc, 0x68, 0x76, 0x3b, 0x9e, 0x85, 0xb1, 0x59, 0x2c, 0xa2, 0x56, 0xcb, 0x6d, 0x8d,
+	0xbb, 0xa5, 0x06, 0xa5, 0xee, 0xc1, 0x6c, 0x02, 0x10, 0x6c, 0xaa, 0x5a, 0xcd, 0x6d, 0x8a, 0xa4,
+	0x49, 0xaa, 0x6d, 0xed, 0x39, 0x87, 0x20, 0x40, 0x2e, 0x09, 0x90, 0x9c, 0x17, 0x48, 0x80, 0xdc,
+	0x72, 0xc9, 0x25, 0x97, 0x5c, 0x72, 0xd9, 0x0f, 0x10, 0x20, 0x9f, 0x20, 0x41, 0x72, 0xcb, 0x07,
+	0x48, 0x80, 0xa0, 0xfe, 0x49, 0x24, 0x45, 0x75, 0xab, 0xd7, 0x3e, 0xe4, 0xb0, 0x37, 0xd6, 0xef,
+	0xbd, 0x22, 0xab, 0xea, 0xfd, 0xde, 0xab, 0x57, 0x4f, 0x25, 0xa8, 0xb9, 0x9e, 0x13, 0x38, 0xdf,
+	0xe8, 0xae, 0xd9, 0xa0, 0x4f, 0xca, 0x9f, 0x40, 0xb5, 0xe5, 0x8c, 0x5d, 0xd3, 0xc2, 0x2a, 0x7e,
+	0x37, 0xc1, 0x7e, 0x80, 0xaa, 0x90, 0x36, 0x87, 0x72, 0x6a, 0x27, 0xf5, 0xbc, 0xa4, 0xa6, 0xcd,
+	0x21, 0xfa, 0x0c, 0xc0, 0x72, 0x46, 0x9a, 0x73, 0x7e, 0xee, 0xe3, 0x40, 0x4e, 0xef, 0xa4, 0x9e,
+	0xe7, 0xd4, 0x92, 0xe5, 0x8c, 0x7a, 0x14, 0x40, 0xf7, 0xa1, 0x44, 0xdf, 0xa4, 0x0d, 0x4d, 0x4f,
+	0xce, 0xd0, 0x5e, 0x45, 0x0a, 0xec, 0x9b, 0x9e, 0xf2, 0x2d, 0x54, 0x7b, 0x2e, 0xb6, 0x9b, 0xae,
+	0x2b, 0xde, 0xfe, 0x04, 0x32, 0xba, 0xeb, 0xd2, 0xd7, 0x97, 0x77, 0xd7, 0x1b, 0x2d, 0xc7, 0x3e,
+	0x37, 0x47, 0x13, 0x4f, 0x0f, 0x4c, 0x87, 0xaa, 0x11, 0xa9, 0xf2, 0x9b, 0x14, 0xd4, 0x66, 0xfd,
+	0x7c, 0xd7, 0xb1, 0x7d, 0x8c, 0x9e, 0x40, 0xde, 0x0f, 0xf4, 0x60, 0xe2, 0xd3, 0xbe, 0xd5, 0xdd,
+	0x72, 0x83, 0x68, 0xf4, 0x29, 0xa4, 0x72, 0x11, 0x92, 0x21, 0x6b, 0x39, 0x23, 0x5f, 0x4e, 0xef,
+	0x64, 0x9e, 0x97, 0x77, 0xb3, 0x8d, 0x43, 0x67, 0xa4, 0x52, 0xe4, 0xda, 0x61, 0xa2, 0x6d, 0xc8,
+	0x07, 0xba, 0x37, 0xc2, 0x81, 0x9c, 0xa5, 0x12, 0xde, 0x42, 0x75, 0x60, 0x3a, 0x86, 0x63, 0xc9,
+	0xb9, 0x50, 0x1f, 0xc3, 0xb1, 0x94, 0x5d, 0x40, 0x1d, 0xdb, 0x77, 0xb1, 0x11, 0x1c, 0x78, 0xae,
+	0x21, 0xa6, 0xf7, 0x00, 0xb2, 0x23, 0xcf, 0x35, 0xf8, 0xfc, 0x8a, 0x0d, 0x22, 0x23, 0xb3, 0xa0,
+	0xa8, 0x72, 0x06, 0x1b, 0x91, 0x3e, 0xa1, 0xa9, 0x61, 0xef, 0x0a, 0x7b, 0xbc, 0x5b, 0x99, 0x76,
+	0xeb, 0x53, 0x48, 0xe5, 0x22, 0xf4, 0x05, 0x14, 0x5c, 0xcf, 0x39, 0xb3, 0xf0, 0x98, 0xda, 0xa0,
+	0xbc, 0x5b, 0xa1, 0x5a, 0xc7, 0x0c, 0x53, 0x85, 0x50, 0xf9, 0xdb, 0x34, 0xc0, 0xbc, 0x3b, 0x99,
+	0x9a, 0xef, 0x4c, 0x3c, 0x03, 0x73, 0x8b, 0xf2, 0x56, 0x68, 0xca, 0xe9, 0xc8, 0x94, 0x25, 0xc8,
+	0x04, 0x96, 0x4f, 0x57, 0xa8, 0xa8, 0x92, 0x47, 0xf4, 0x1c, 0x8a, 0x64, 0x08, 0xa6, 0x81, 0x7d,
+	0x39, 0xbb, 0x93, 0x99, 0x7d, 0xb9, 0xcf, 0x40, 0x75, 0x26, 0x45, 0x8f, 0xa1, 0x32, 0xc6, 0xc1,
+	0x85, 0x33, 0xd4, 0x0c, 0x67, 0x62, 0x07, 0x74, 0xc9, 0x72, 0x6a, 0x99, 0x61, 0x2d, 0x02, 0xa1,
+	0xaf, 0x01, 0x79, 0xf8, 0xdc, 0xc2, 0x06, 0xb1, 0xb7, 0x76, 0x85, 0x3d, 0xdf, 0x74, 0x6c, 0x39,
+	0x4f, 0x87, 0xb0, 0x3e, 0x97, 0x9c, 0x32, 0x01, 0xe1, 0xde, 0xb9, 0x69, 0x61, 0xfe, 0xbe, 0x02,
+	0xe3, 0x1e, 0x41, 0xd8, 0xdb, 0x22, 0x46, 0x2d, 0xc6, 0x8c, 0xfa, 0x00, 0x4a, 0x1e, 0xd6, 0x8d,
+	0x0b, 0xfd, 0xcc, 0xc2, 0x72, 0x89, 0xce, 0x67, 0x0e, 0x28, 0xbf, 0x86, 0x72, 0x68, 0x12, 0x08,
+	0x41, 0xd6, 0xd6, 0xc7, 0x62, 0x91, 0xe8, 0xf3, 0xc2, 0x74, 0xd2, 0x8b, 0xd3, 0x79, 0x09, 0xdb,
+	0x7e, 0xe0, 0x61, 0x7d, 0x6c, 0xda, 0x23, 0x2d, 0xa2, 0x9c, 0xa1, 0xca, 0x9b, 0x33, 0xe9, 0xd1,
+	0xbc, 0x97, 0x82, 0xa1, 0x1c, 0x32, 0x1d, 0xfa, 0x1c, 0xb2, 0x97, 0xa6, 0x3d, 0xe4, 0xbc, 0x96,
+	0xc2, 0x66, 0x7d, 0x6b, 0xda, 0x43, 0x95, 0x4a, 0x91, 0x0c, 0x85, 0x31, 0xf6, 0x7d, 0x7d, 0x84,
+	0xb9, 0xc5, 0x44, 0x93, 0x98, 0x72, 0x88, 0x03, 0xdd, 0xb4, 0x38, 0xaf, 0x79, 0x4b, 0xf9, 0x05,
+	0x6c, 0x71, 0xb6, 0x31, 0x5f, 0x32, 0x05, 0x49, 0x9f, 0x42, 0xc1, 0x71, 0xb1, 0xad, 0xbb, 0xe6,
+	0x8c, 0x70, 0x5c, 0x83, 0x50, 0x55, 0xc8, 0x94, 0x77, 0xb0, 0x1d, 0xef, 0xcf, 0x09, 0xfb, 0x15,
+	0x14, 0x87, 0x8e, 0x31, 0x19, 0x63, 0x3b, 0xe0, 0x6f, 0x90, 0xc4, 0x1b, 0xf6, 0x39, 0xae, 0xce,
+	0x34, 0xd0, 0x8f, 0xe2, 0xcc, 0xad, 0x09, 0xe5, 0x05, 0xf2, 0xfe, 0x6f, 0x1a, 0x6a, 0xb1, 0x17,
+	0xa1, 0x4d, 0xc8, 0x05, 0x66, 0x60, 0x09, 0xdb, 0xb0, 0x06, 0x59, 0x0e, 0xc1, 0x1e, 0xbe, 0x1c,
+	0xbc, 0x89, 0x9e, 0x41, 0x8d, 0xcf, 0x60, 0xc6, 0x2f, 0xb6, 0x2e, 0x55, 0x0e, 0x9f, 0x46, 0x14,
+	0x59, 0xe8, 0xe1, 0x56, 0xcb, 0x52, 0xab, 0x55, 0x67, 0xf0, 0x8c, 0x66, 0x81, 0x3e, 0x8a, 0x90,
+	0xba, 0x18, 0xe8, 0x23, 0x26, 0x7c, 0x0e, 0x05, 0xe6, 0xa1, 0xbe, 0x9c, 0xa7, 0xde, 0x51, 0x15,
+	0xb3, 0xe3, 0x0e, 0x2c, 0xc4, 0xa8, 0x09, 0x92, 0x8f, 0x8d, 0x89, 0x67, 0x06, 0x53, 0xcd, 0x37,
+	0x2e, 0xf0, 0x18, 0xfb, 0x72, 0x81, 0x76, 0xd9, 0x9e, 0x77, 0x61, 0xf2, 0x3e, 0x15, 0xab, 0x35,
+	0x3f, 0xd2, 0x26, 0xbe, 0x28, 0x8d, 0x26, 0xd8, 0xf7, 0xf1, 0x50, 0x3b, 0xd3, 0x7d, 0xac, 0x4d,
+	0x3c, 0x8b, 0xf3, 0xbe, 0xca, 0xf1, 0x3d, 0xdd, 0xc7, 0x27, 0x9e, 0x45, 0x98, 0xe9, 0x62, 0x4f,
+	0x9b, 0x4f, 0x50, 0xbc, 0x8a, 0xbb, 0xc2, 0xa6, 0x8b, 0xbd, 0x9e, 0x10, 0x8a, 0xcf, 0x2a, 0x53,
+	0x58, 0x8b, 0x0c, 0x9e, 0x84, 0x03, 0xf2, 0x0d, 0xb6, 0xf4, 0xe4, 0x11, 0xed, 0x40, 0x79, 0x88,
+	0x7d, 0xc3, 0x33, 0xdd, 0x60, 0xbe, 0xf8, 0x61, 0x08, 0xbd, 0x84, 0xd2, 0x95, 0xee, 0x99, 0xc4,
+	0xcd, 0x48, 0x20, 0x89, 0x4d, 0x90, 0xbc, 0xf6, 0x94, 0x8b, 0xd5, 0xb9, 0xa2, 0xf2, 0x57, 0x29,
+	0xd8, 0x4a, 0x54, 0x4a, 0xf4, 0xcd, 0x27, 0xb0, 0x36, 0xc4, 0xe7, 0xfa, 0xc4, 0x0a, 0xb4, 0x2b,
+	0xdd, 0x9a, 0x08, 0x9f, 0xa8, 0x70, 0xf0, 0x94, 0x60, 0xe8, 0x11, 0x94, 0xb1, 0x3d, 0x19, 0x33,
+	0x0d, 0x36, 0x94, 0x92, 0x0a, 0x04, 0xa2, 0x72, 0x3f, 0x3e, 0x97, 0xec, 0xc2, 0x5c, 0x94, 0x7f,
+	0x4d, 0x87, 0x46, 0x15, 0xb6, 0x05, 0x59, 0x99, 0x4b, 0x3c, 0x15, 0x2b, 0x73, 0x89, 0xa7, 0x64,
+	0x9c, 0xc1, 0xd4, 0x15, 0x43, 0xa1, 0xcf, 0x34, 0xfc, 0x52, 0x7d, 0xe1, 0x9b, 0xac, 0x45, 0xc6,
+	0x7f, 0x86, 0x75, 0x0f, 0x7b, 0xda, 0xb9, 0xe3, 0x8d, 0x75, 0xb1, 0xf1, 0x54, 0x18, 0xf8, 0x9a,
+	0x62, 0x74, 0x27, 0xb6, 0xf9, 0xc6, 0x93, 0x36, 0x6d, 0xf4, 0x14, 0xaa, 0xae, 0xee, 0xe9, 0x63,
+	0x1c, 0x60, 0x4f, 0xa3, 0x4b, 0xc2, 0x02, 0xe7, 0xda, 0x0c, 0xed, 0x92, 0xb5, 0xf9, 0x1a, 0x36,
+	0x08, 0xd3, 0x35, 0x93, 0xc4, 0x22, 0xdb, 0xc6, 0x46, 0x40, 0x79, 0x52, 0xa0, 0xba, 0x12, 0x11,
+	0x75, 0x86, 0x2d, 0x26, 0x38, 0x59, 0x34, 0x68, 0x71, 0xd1, 0xa0, 0x09, 0x8e, 0x52, 0x4a, 0x74,
+	0x94, 0x67, 0x50, 0xf3, 0xf0, 0xbb, 0x89, 0xe9, 0x61, 0x5f, 0x73, 0x82, 0x0b, 0xe2, 0x13, 0x40,
+	0xd9, 0x56, 0x15, 0x70, 0x8f, 0xa2, 0xca, 0x25, 0x54, 0xa3, 0x21, 0x00, 0x3d, 0x8b, 0x04, 0xc1,
+	0x8d, 0x58, 0x84, 0xf8, 0xa8, 0x38, 0xd8, 0x80, 0x75, 0x1e, 0xc7, 0x8e, 0x8c, 0x59, 0x1e, 0x72,
+	0x0f, 0x32, 0x63, 0x43, 0xe4, 0x21, 0x85, 0xc6, 0x91, 0xe1, 0xd2, 0xec, 0x63, 0x6c, 0xb8, 0x8a,
+	0x06, 0x28, 0xac, 0xcf, 0x63, 0x9e, 0x12, 0xdb, 0xa4, 0x81, 0xf4, 0x89, 0xed, 0xd1, 0x4f, 0xe3,
+	0x91, 0xae, 0x4c, 0x94, 0x16, 0xa2, 0xdc, 0x5f, 0x67, 0xa0, 0x34, 0xeb, 0x9c, 0x48, 0xef, 0xe5,
+	0xd1, 0xed, 0x47, 0x20, 0x89, 0x14, 0x24, 0x16, 0xde, 0x6a, 0x02, 0x17, 0xf1, 0xed, 0x01, 0x94,
+	0x2e, 0x74, 0x7b, 0xe8, 0x5f, 0xe8, 0x97, 0x98, 0xf2, 0xab, 0xa8, 0xce, 0x01, 0xb2, 0x13, 0xfb,
+	0x13, 0xd7, 0x75, 0xbc, 0x00, 0x0f, 0xc5, 0x9b, 0x7c, 0x39, 0x47, 0x7d, 0x64, 0x7d, 0x26, 0xe1,
+	0xef, 0xf2, 0xc9, 0x4e, 0x1c, 0x38, 0x8e, 0xc5, 0xcd, 0x9f, 0x67, 0x3b, 0x31, 0x41, 0x98, 0xe5,
+	0x9f, 0x42, 0xd5, 0xc3, 0x2c, 0xb5, 0x88, 0x6c, 0xd6, 0x6b, 0x02, 0x65, 0x6a, 0x3f, 0x85, 0xbb,
+	0x33, 0xb5, 0x00, 0x8f, 0x5d, 0x4b, 0x0f, 0x84, 0x7e, 0x91, 0xea, 0x6f, 0x09, 0xf1, 0x80, 0x4b,
+	0x59, 0xbf, 0xc7, 0x50, 0x71, 0x3d, 0x67, 0xec, 0x06, 0x11, 0xfa, 0x95, 0x19, 0xc6, 0x54, 0x1e,
+	0x42, 0x8e, 0x0c, 0x87, 0x30, 0x2e, 0x43, 0x53, 0xaf, 0x23, 0xc3, 0x1d, 0x38, 0x8e, 0xa5, 0x32,
+	0x18, 0x29, 0x50, 0x31, 0x6d, 0x3f, 0xf0, 0x26, 0x34, 0xc1, 0xf0, 0xe5, 0x32, 0x73, 0xb8, 0x30,
+	0xa6, 0x78, 0x50, 0xe0, 0xbd, 0x12, 0xad, 0x32, 0xdb, 0x89, 0xd2, 0xe1, 0x9d, 0x28, 0xe6, 0x3f,
+	0x99, 0x45, 0xff, 0xb9, 0x4f, 0x33, 0x91, 0xa1, 0xe6, 0xd8, 0xd6, 0x94, 0x1b, 0xa2, 0x48, 0x80,
+	0x9e, 0x6d, 0x4d, 0x95, 0x7f, 0x49, 0x01, 0xcc, 0x49, 0x82, 0x9e, 0x44, 0xfc, 0xa0, 0x16, 0xe2,
+	0xcf, 0xc7, 0xf8, 0x00, 0xfa, 0x12, 0xd6, 0xf5, 0x49, 0x70, 0xe1, 0x78, 0xe6, 0xaf, 0x99, 0x1b,
+	0x93, 0x88, 0xc0, 0x62, 0x8e, 0x14, 0x11, 0x90, 0x88, 0xf0, 0x13, 0xd8, 0x8c, 0x2a, 0x73, 0xe2,
+	0xb3, 0x48, 0xb4, 0x11, 0x91, 0x31, 0x12, 0x2b, 0x7f, 0x9e, 0x82, 0xda, 0xec, 0x1c, 0xc1, 0x3d,
+	0xe6, 0x8b, 0x58, 0xc6, 0x5e, 0x6d, 0x70, 0x8d, 0x95, 0x93, 0xf6, 0xc7, 0x50, 0x60, 0x6c, 0x10,
+	0xfb, 0x48, 0xa1, 0xd1, 0xa7, 0x6d, 0x55, 0xe0, 0xc4, 0x4e, 0x7e, 0x30, 0x39, 0xe3, 0x73, 0xa1,
+	0xcf, 0xca, 0x1f, 0x41, 0xe6, 0xd0, 0x19, 0xa1, 0x47, 0x90, 0xb3, 0xf0, 0x15, 0xb6, 0xf8, 0xe7,
+	0x4b, 0xe4, 0xc5, 0x87, 0x04, 0x50, 0x19, 0xbe, 0x7c, 0x19, 0x95, 0x9f, 0x42, 0x9e, 0x7d, 0x88,
+	0xbc, 0xdf, 0xd5, 0x83, 0x0b, 0xc1, 0x03, 0xf2, 0x4c, 0xfa, 0x19, 0x8e, 0x1d, 0x60, 0x5b, 0x24,
+	0xcf, 0xa2, 0xa9, 0xdc, 0x83, 0xbb, 0x07, 0x38, 0x88, 0x1c, 0x6a, 0x78, 0xc0, 0x51, 0x7e, 0x9b,
+	0x02, 0x79, 0x51, 0xc6, 0x97, 0xea, 0x25, 0xac, 0x19, 0x61, 0x01, 0x8f, 0x31, 0xd5, 0xe8, 0xf9,
+	0x48, 0x8d, 0x2a, 0x5d, 0xb3, 0x70, 0xaf, 0xa0, 0x26, 0x76, 0x56, 0x8d, 0xdb, 0x80, 0x2d, 0x60,
+	0xad, 0x21, 0xb6, 0x55, 0x6e, 0x84, 0xea, 0x55, 0xa4, 0x8d, 0x14, 0x28, 0x78, 0x13, 0x3b, 0x30,
+	0xc7, 0x2c, 0x64, 0x10, 0x47, 0x52, 0x59, 0x5b, 0x15, 0x02, 0xe5, 0x9f, 0x52, 0x50, 0xe0, 0x20,
+	0x7a, 0x05, 0xb2, 0xa1, 0xdb, 0xda, 0xc4, 0x1d, 0x32, 0x57, 0x8e, 0x4f, 0xa2, 0xa8, 0x6e, 0x1b,
+	0xba, 0x7d, 0x42, 0xc5, 0x91, 0xc9, 0xa0, 0xbb, 0x50, 0x18, 0x99, 0x81, 0xe6, 0xe1, 0x73, 0x71,
+	0x04, 0x19, 0x99, 0x81, 0x8a, 0xcf, 0x89, 0xb3, 0x9f, 0x4d, 0x4c, 0x6b, 0xa8, 0xd9, 0x93, 0xf1,
+	0x19, 0x16, 0xa7, 0xb5, 0x32, 0xc5, 0xba, 0x14, 0x22, 0x5f, 0x0d, 0xcd, 0xcf, 0xf1, 0xb0, 0xa6,
+	0x5f, 0xe9, 0xa6, 0x45, 0xda, 0xdc, 0xc1, 0xb6, 0xe7, 0xf3, 0x72, 0x3c, 0xdc, 0x14, 0x52, 0xe5,
+	0x02, 0xaa, 0xd1, 0x15, 0x48, 0xf4, 0xf4, 0x67, 0xb3, 0x53, 0x53, 0x9a, 0xfb, 0xe1, 0xac, 0x13,
+	0x85, 0x67, 0xc7, 0xa8, 0x7b, 0x50, 0xc4, 0xf6, 0x15, 0xdb, 0x8c, 0xd9, 0x38, 0x0b, 0xd8, 0xbe,
+	0x22, 0xdb, 0xb0, 0xd2, 0x84, 0xad, 0x3e, 0x0e, 0xe8, 0xe7, 0x87, 0x34, 0xdf, 0x10, 0x5b, 0xcf,
+	0x92, 0xd0, 0x12, 0xce, 0x63, 0x58, 0x43, 0xf9, 0x1a, 0xee, 0xb6, 0x2c, 0xac, 0x7b, 0xab, 0xbd,
+	0x44, 0xe9, 0xc1, 0x46, 0x44, 0x93, 0x93, 0x2b, 0x81, 0x0c, 0xa9, 0x95, 0xc8, 0xa0, 0x9c, 0x41,
+	0xbe, 0x4f, 0xa3, 0x58, 0xa2, 0x1b, 0x88, 0x21, 0xa4, 0xa3, 0x1b, 0x97, 0x70, 0x8d, 0x4c, 0xc4,
+	0x35, 0x48, 0x64, 0x3a, 0x77, 0xac, 0x21, 0xf6, 0xc4, 0x19, 0x9b, 0xb5, 0x94, 0x4d, 0x40, 0x87,
+	0xa6, 0x1f, 0xb0, 0xef, 0xf8, 0xc2, 0x5b, 0x5e, 0xc1, 0x46, 0x04, 0xe5, 0x53, 0x21, 0x01, 0x81,
+	0x41, 0x7c, 0x0a, 0x85, 0x06, 0x53, 0x51, 0x05, 0xae, 0x3c, 0x83, 0x75, 0x15, 0xeb, 0x43, 0x0e,
+	0x5f, 0xb3, 0x5a, 0xdf, 0x02, 0x0a, 0x2b, 0xf2, 0x2f, 0x3c, 0x22, 0x09, 0x1b, 0x41, 0x66, 0xa9,
+	0x01, 0x57, 0xe0, 0xb0, 0xf2, 0xdf, 0x29, 0x58, 0x8b, 0x12, 0xf9, 0x11, 0x94, 0xc9, 0x7a, 0x68,
+	0xae, 0x87, 0xcf, 0xcd, 0x0f, 0xfc, 0x1b, 0x40, 0xa0, 0x63, 0x8a, 0xa0, 0xa7, 0x90, 0xd5, 0x5d,
+	0x97, 0x6d, 0xae, 0x89, 0x45, 0x0f, 0x2a, 0x46, 0x7f, 0x18, 0xce, 0x9b, 0xd9, 0x59, 0xe2, 0xb3,
+	0xa8, 0xee, 0xcc, 0x5e, 0x7e, 0xdb, 0x0e, 0xbc, 0x69, 0x28, 0x7d, 0xae, 0xff, 0x1c, 0xaa, 0x51,
+	0x61, 0x42, 0x82, 0x9a, 0x48, 0xb2, 0x9f, 0xa5, 0x5f, 0xa5, 0xbe, 0xcb, 0x16, 0xd3, 0x52, 0xe6,
+	0xbb, 0x6c, 0x31, 0x2b, 0xe5, 0xe8, 0x09, 0xfa, 0x57, 0xd8, 0x08, 0x48, 0x80, 0x9e, 0xfa, 0x01,
+	0x1e, 0x2b, 0x7f, 0x96, 0x05, 0x29, 0x3e, 0xe6, 0x44, 0x16, 0x3f, 0xe4, 0xd5, 0x8f, 0x74, 0xb4,
+	0xfa, 0xf1, 0xe6, 0x0e, 0xab, 0x7f, 0xa0, 0xc7, 0x90, 0x0b, 0xde, 0x9b, 0x9e, 0x4b, 0xb9, 0x51,
+	0xde, 0x2d, 0x35, 0x06, 0xa4, 0xc5, 0x34, 0x98, 0x04, 0x3d, 0x9b, 0x9f, 0x4d, 0xb3, 0x0b, 0x67,
+	0xd3, 0x37, 0x77, 0x66, 0xa7, 0x53, 0xf4, 0x39, 0xe4, 0xe9, 0xa3, 0x29, 0xe7, 0x78, 0x3e, 0x46,
+	0xf5, 0xb8, 0x1a, 0x97, 0x11, 0x2d, 0xce, 0xba, 0x02, 0xd7, 0x7a, 0x4d, 0x9b, 0x5c, 0x8b, 0xc9,
+	0xd0, 0x7d, 0x96, 0x0c, 0x16, 0x23, 0xc9, 0xe0, 0x9b, 0x3b, 0x34, 0x1d, 0x44, 0x5f, 0x43, 0x49,
+	0xb7, 0x83, 0x0b, 0xcf, 0x71, 0x4d, 0x83, 0x26, 0x1e, 0xe5, 0xdd, 0xb5, 0x46, 0x53, 0x20, 0x4c,
+	0x71, 0xae, 0x41, 0xbe, 0xe8, 0xbf, 0xb3, 0xcc, 0x00, 0xcb, 0xc0, 0xbf, 0xd8, 0xa7, 0x4d, 0xfe,
+	0x45, 0x26, 0x23, 0xd3, 0x1c, 0x79, 0xba, 0x7b, 0xf1, 0xce, 0x92, 0xcb, 0x7c, 0x9a, 0x07, 0xac,
+	0xcd, 0xa7, 0xc9, 0xa5, 0x44, 0xf1, 0x57, 0xbe, 0x63, 0x93, 0x55, 0xad, 0x70, 0xc5, 0xef, 0x58,
+	0x9b, 0x2b, 0x72, 0x29, 0x19, 0xe6, 0x7b, 0x7c, 0xe6, 0x3b, 0xc6, 0x25, 0x0e, 0xe4, 0x35, 0x3e,
+	0xcc, 0xef, 0x05, 0xc2, 0x87, 0x39, 0xd3, 0x20, 0xa6, 0xba, 0x08, 0x02, 0x57, 0xae, 0x72, 0x53,
+	0xbd, 0x09, 0x02, 0x3e, 0x69, 0x8a, 0xef, 0xe5, 0x68, 0x9d, 0xee, 0xbb, 0x6c, 0x31, 0x2f, 0x15,
+	0xd4, 0xe2, 0x58, 0xf7, 0x2e, 0x87, 0xce, 0x7b, 0x5b, 0xf9, 0xaf, 0x02, 0x14, 0xb8, 0x55, 0x13,
+	0xce, 0x86, 0x91, 0x7a, 0x4c, 0x3a, 0x56, 0x8f, 0x79, 0x08, 0x30, 0x2f, 0xf0, 0xf0, 0x02, 0x53,
+	0x08, 0x41, 0xdf, 0x40, 0xe1, 0x02, 0xeb, 0x43, 0xec, 0x89, 0x32, 0xd3, 0x96, 0xe0, 0x4f, 0xe3,
+	0x0d, 0xc3, 0x19, 0xe9, 0x85, 0x96, 0x28, 0x55, 0xb1, 0xac, 0x84, 0x3c, 0xa2, 0x1f, 0xc3, 0xa6,
+	0x69, 0xd3, 0x83, 0x2e, 0xd6, 0xfc, 0x4b, 0xd3, 0x25, 0x79, 0xad, 0x79, 0x3e, 0xa5, 0xe9, 0x6a,
+	0x51, 0x45, 0x42, 0xd6, 0xbf, 0x34, 0xdd, 0x53, 0x2a, 0x21, 0x9b, 0x90, 0xa1, 0x6b, 0xa4, 0xa2,
+	0xc4, 0xcf, 0x47, 0x79, 0x43, 0x7f, 0x6d, 0x5a, 0x98, 0x9c, 0xb4, 0x0d, 0xcb, 0xc4, 0x76, 0xa0,
+	0x19, 0xd8, 0x0b, 0x98, 0x06, 0x3f, 0x69, 0x33, 0xbc, 0x85, 0xbd, 0x80, 0x6a, 0x7e, 0x01, 0x35,
+	0xae, 0x79, 0x89, 0xa7, 0x4c, 0xb1, 0xc4, 0x8e, 0x65, 0x0c, 0x7e, 0x8b, 0xa7, 0x54, 0x0f, 0x41,
+	0x96, 0x64, 0x4e, 0x94, 0x16, 0x25, 0x95, 0x3e, 0xd3, 0x8c, 0xd2, 0xb9, 0xc4, 0x36, 0xcf, 0x46,
+	0x59, 0x83, 0x94, 0x1d, 0x27, 0x3e, 0xf6, 0xa8, 0x7b, 0x55, 0xd8, 0x2a, 0x8a, 0x36, 0x91, 0xb9,
+	0xba, 0xef, 0xbf, 0x77, 0xbc, 0xa1, 0xbc, 0xc6, 0x57, 0x98, 0xb7, 0xd1, 0x0e, 0x54, 0x48, 0xd5,
+	0x83, 0x0c, 0x83, 0xf6, 0xad, 0x52, 0x39, 0xe8, 0xae, 0xf9, 0x16, 0x4f, 0xe9, 0xd1, 0x70, 0x07,
+	0xca, 0x86, 0x33, 0x76, 0x3d, 0xec, 0xd3, 0x83, 0x43, 0x8d, 0xed, 0xac, 0x21, 0x08, 0xbd, 0x80,
+	0xf5, 0xb1, 0xfe, 0x41, 0xf3, 0xb0, 0x81, 0xcd, 0x2b, 0xac, 0x9d, 0x4d, 0x03, 0xec, 0xcb, 0xd2,
+	0x4e, 0xea, 0x79, 0x46, 0xad, 0x8d, 0xf5, 0x0f, 0x2a, 0xc3, 0xf7, 0x08, 0x8c, 0x3e, 0x87, 0x2a,
+	0xd1, 0xf5, 0xb1, 0x3d, 0xe4, 0x8a, 0xeb, 0x54, 0xb1, 0x32, 0xd6, 0x3f, 0xf4, 0xb1, 0x3d, 0x64,
+	0x5a, 0xe1, 0x22, 0x2a, 0x8a, 0x16, 0x51, 0xc9, 0x11, 0x05, 0xdb, 0x43, 0xd7, 0x31, 0xed, 0xc0,
+	0x97, 0x37, 0xe8, 0xd9, 0x63, 0x0e, 0x90, 0x43, 0x85, 0xe5, 0xe8, 0xa4, 0xd4, 0x61, 0xe9, 0xb6,
+	0x61, 0xda, 0x23, 0x79, 0x93, 0x2d, 0x2c, 0x41, 0xf7, 0x04, 0x88, 0xbe, 0x02, 0xe4, 0xe1, 0xc0,
+	0x9b, 0x6a, 0x64, 0x30, 0x7a, 0x40, 0xce, 0x15, 0x81, 0x2f, 0x6f, 0xd1, 0xa1, 0x48, 0x54, 0x72,
+	0xa4, 0x7f, 0x68, 0x72, 0x9c, 0x18, 0x96, 0x69, 0x9f, 0xe9, 0xc6, 0xa5, 0x73, 0x7e, 0xae, 0x8d,
+	0x7d, 0x79, 0x9b, 0xea, 0x56, 0x29, 0xbe, 0xc7, 0xe0, 0x23, 0x1f, 0x7d, 0x03, 0x9b, 0xf3, 0xf7,
+	0x86, 0xb4, 0xef, 0x52, 0xed, 0x75, 0xf1, 0xe6, 0x79, 0x87, 0x47, 0x50, 0x66, 0x1d, 0x0c, 0x67,
+	0x88, 0x7d, 0x59, 0xa6, 0xf3, 0x01, 0x0a, 0xb5, 0x08, 0x42, 0x0e, 0x51, 0x1e, 0x49, 0x93, 0x2c,
+	0x73, 0x6c, 0x06, 0xf2, 0x3d, 0xfa, 0x9e, 0x12, 0x41, 0x0e, 0x09, 0x40, 0x87, 0x36, 0x13, 0x6b,
+	0x67, 0x13, 0xcf, 0x0f, 0xe4, 0x3a, 0x1f, 0x9a, 0x50, 0xda, 0x23, 0x28, 0x39, 0x68, 0x93, 0x41,
+	0x19, 0x8e, 0x6d, 0x4c, 0x3c, 0x0f, 0xdb, 0xc6, 0x54, 0xbe, 0xcf, 0x14, 0xc7, 0xfa, 0x87, 0xd6,
+	0x1c, 0xad, 0xff, 0x0c, 0x2a, 0x61, 0xe7, 0xb9, 0xcd, 0xa6, 0xa0, 0xfc, 0x65, 0x1e, 0x8a, 0x22,
+	0x40, 0xdf, 0xd6, 0xd9, 0x7f, 0x3c, 0x77, 0x66, 0x51, 0x01, 0x12, 0xaf, 0x5a, 0xe2, 0xcd, 0xc9,
+	0x56, 0xcc, 0xde, 0xc2, 0x8a, 0xb9, 0x5b, 0x59, 0x31, 0xbf, 0xa2, 0x15, 0x0b, 0x37, 0x58, 0xb1,
+	0xb8, 0x8a, 0x15, 0x4b, 0xab, 0x5a, 0x11, 0x92, 0xac, 0x28, 0x22, 0x5d, 0xf9, 0xe6, 0x48, 0x57,
+	0x59, 0x25, 0xd2, 0xad, 0xdd, 0x18, 0xe9, 0xaa, 0xab, 0x46, 0xba, 0xda, 0x75, 0x91, 0x4e, 0x4a,
+	0x8a, 0x74, 0xeb, 0xcb, 0x22, 0x1d, 0xba, 0x26, 0xd2, 0x6d, 0xdc, 0x10, 0xe9, 0x36, 0x17, 0x22,
+	0x5d, 0x9d, 0x24, 0xe6, 0x86, 0x33, 0x24, 0x51, 0x63, 0x8b, 0xf5, 0x16, 0xed, 0x8f, 0x72, 0x8a,
+	0x7f, 0xce, 0x01, 0xcc, 0x13, 0x12, 0x92, 0xff, 0x93, 0x4a, 0x91, 0x36, 0xf7, 0x8d, 0x02, 0x69,
+	0x93, 0x53, 0xf4, 0x6c, 0xc6, 0xe9, 0x65, 0x33, 0xce, 0x5c, 0x33, 0xe3, 0x6c, 0x6c, 0xc6, 0xbb,
+	0x73, 0x87, 0x62, 0x69, 0xa4, 0x1c, 0xca, 0x8b, 0x96, 0xb8, 0xd4, 0x63, 0xa8, 0xd0, 0xc1, 0x89,
+	0x8c, 0x9c, 0x55, 0x0b, 0xcb, 0x04, 0x6b, 0x31, 0x88, 0x8c, 0x7f, 0x56, 0x48, 0x66, 0x1b, 0x60,
+	0xe1, 0x8c, 0x57, 0x90, 0x9f, 0x41, 0x2d, 0x56, 0xae, 0x16, 0x1b, 0x60, 0xb4, 0x2a, 0x4d, 0x08,
+	0x44, 0x3f, 0xc3, 0x3e, 0xcb, 0x0c, 0x52, 0xe2, 0x9a, 0x2e, 0x36, 0xd8, 0xd8, 0xa8, 0x51, 0x5e,
+	0xc0, 0x7a, 0x58, 0x93, 0x2d, 0x31, 0xdb, 0x0f, 0x6b, 0x73, 0x55, 0x56, 0xbc, 0x4d, 0x8e, 0x07,
+	0xe5, 0x5b, 0xc4, 0x83, 0xca, 0xad, 0xe2, 0xc1, 0xda, 0x8a, 0xf1, 0xa0, 0x7a, 0x43, 0x3c, 0xa8,
+	0xad, 0x12, 0x0f, 0xa4, 0x55, 0xe3, 0xc1, 0xfa, 0x27, 0x8f, 0xea, 0xbf, 0xcd, 0x40, 0x69, 0x96,
+	0x29, 0x33, 0x37, 0x61, 0xfb, 0x2d, 0xef, 0x3e, 0x6b, 0x2f, 0x21, 0xf0, 0x4f, 0xe2, 0x91, 0xfd,
+	0xee, 0x3c, 0xf1, 0xfe, 0x7d, 0x68, 0xbf, 0x6d, 0x68, 0xff, 0x28, 0x53, 0xfe, 0x67, 0x06, 0x2a,
+	0xe1, 0x83, 0xc8, 0xef, 0x60, 0xcd, 0x97, 0x71, 0x6b, 0xd6, 0x23, 0x47, 0x9b, 0x25, 0x06, 0x0d,
+	0x95, 0xa7, 0xb3, 0xd1, 0xf2, 0x74, 0xb2, 0xa9, 0x73, 0xb7, 0x30, 0x75, 0xfe, 0x56, 0xa6, 0x2e,
+	0xac, 0x68, 0xea, 0xe2, 0x0d, 0xa6, 0x2e, 0xad, 0x62, 0x6a, 0x58, 0xd5, 0xd4, 0xe5, 0x4f, 0x6e,
+	0xea, 0x47, 0x50, 0x9a, 0x1d, 0x5c, 0x93, 0x8a, 0x31, 0xca, 0xcf, 0xa1, 0x34, 0x3b, 0x67, 0x26,
+	0x29, 0x44, 0x8b, 0xd0, 0xe9, 0x58, 0x11, 0xfa, 0xdf, 0xb3, 0xe4, 0xd2, 0x80, 0x38, 0x7f, 0x26,
+	0x24, 0x7b, 0x8f, 0xa0, 0x4c, 0xf7, 0x00, 0x9e, 0x41, 0xb0, 0xf1, 0x01, 0x83, 0xe8, 0x9e, 0xbf,
+	0x1b, 0x27, 0x92, 0x1c, 0x3a, 0xd0, 0x2e, 0xa1, 0x91, 0xc8, 0x13, 0xb2, 0x49, 0x79, 0x42, 0x6e,
+	0xd9, 0xae, 0x99, 0xbf, 0x66, 0xd7, 0x2c, 0xdc, 0x90, 0x27, 0x14, 0x17, 0xf2, 0x84, 0x64, 0xc2,
+	0x96, 0x6e, 0x41, 0x58, 0xb8, 0x15, 0x61, 0xcb, 0x2b, 0x12, 0xb6, 0x72, 0x03, 0x61, 0xd7, 0x56,
+	0x21, 0x6c, 0x75, 0x55, 0xc2, 0xd6, 0x12, 0xd3, 0xce, 0x4d, 0xc8, 0x0d, 0xb1, 0xcb, 0x13, 0xb9,
+	0x8c, 0xca, 0x1a, 0x1f, 0x45, 0xe3, 0xff, 0xc8, 0x02, 0xcc, 0xcb, 0x17, 0x09, 0x3c, 0x0b, 0xe7,
+	0x53, 0xe9, 0x68, 0x3e, 0x75, 0x1f, 0x4a, 0x54, 0x44, 0x09, 0xc8, 0x53, 0x27, 0x02, 0xc4, 0xe9,
+	0x97, 0xe5, 0xf4, 0x9b, 0x7f, 0xe7, 0x06, 0xfa, 0xe5, 0x92, 0xe8, 0x97, 0x5f, 0x46, 0xbf, 0xc2,
+	0x35, 0xf4, 0x2b, 0xde, 0x40, 0xbf, 0xd2, 0x8a, 0xf4, 0x83, 0x5b, 0xd0, 0xaf, 0x7c, 0x2b, 0xfa,
+	0x55, 0x56, 0xa4, 0xdf, 0xda, 0x0d, 0xf4, 0xab, 0xae, 0x42, 0xbf, 0xda, 0xaa, 0xf4, 0x93, 0x3e,
+	0x79, 0xbc, 0xfc, 0x4d, 0x16, 0x2a, 0xe1, 0xe2, 0x57, 0x02, 0xd5, 0x1e, 0x43, 0x45, 0xf7, 0xa7,
+	0xb6, 0x41, 0x2c, 0x34, 0xa7, 0x5b, 0x59, 0x60, 0x84, 0x72, 0x4f, 0x60, 0x6d, 0xa6, 0x12, 0xa2,
+	0xdd, 0xac, 0x1f, 0xa5, 0xde, 0xcb, 0x38, 0xf5, 0xea, 0x91, 0xb2, 0xdb, 0xff, 0x63, 0xf2, 0x7d,
+	0x09, 0xeb, 0x86, 0xe3, 0x79, 0xd8, 0x62, 0xbf, 0xf2, 0x9d, 0x9b, 0xd8, 0x1a, 0xf2, 0x74, 0x5c,
+	0x0a, 0x09, 0x5e, 0x13, 0x9c, 0xfc, 0x7e, 0xea, 0x4f, 0xce, 0x44, 0xe5, 0x86, 0xf0, 0x8e, 0x30,
+	0x24, 0x82, 0xc5, 0x38, 0x52, 0x59, 0x85, 0x23, 0x6b, 0xab, 0x72, 0xa4, 0xfa, 0xc9, 0x39, 0xf2,
+	0xf7, 0x59, 0x28, 0xf0, 0xb2, 0x67, 0xe4, 0x1c, 0x94, 0x8a, 0x9e, 0x83, 0x42, 0x75, 0xc9, 0x34,
+	0xaf, 0x4b, 0xf2, 0x5e, 0x4b, 0x4c, 0x3b, 0x33, 0x63, 0x66, 0x99, 0x19, 0xb3, 0xd7, 0x98, 0x31,
+	0x17, 0x33, 0xe3, 0x97, 0xe1, 0x12, 0x19, 0xfb, 0x55, 0x60, 0x8d, 0x0e, 0xa0, 0xcd, 0xd1, 0x70,
+	0xc5, 0x2c, 0x39, 0x9c, 0x14, 0x6e, 0x11, 0x4e, 0x8a, 0xb7, 0x0a, 0x27, 0xa5, 0x15, 0xc3, 0x09,
+	0xdc, 0x10, 0x4e, 0xca, 0xab, 0x50, 0xa5, 0xb2, 0x2a, 0x55, 0xd6, 0x3e, 0x39, 0x55, 0xfe, 0x31,
+	0x05, 0x95, 0xf0, 0x9a, 0x27, 0xfe, 0xfa, 0xb1, 0x0d, 0x79, 0x76, 0x05, 0x50, 0xfc, 0x9e, 0xc9,
+	0x5a, 0xb3, 0x6c, 0x2c, 0x13, 0xca, 0xc6, 0x36, 0x21, 0xf7, 0x6e, 0x82, 0xbd, 0x29, 0x0d, 0x19,
+	0x25, 0x95, 0x35, 0x48, 0x5e, 0x1d, 0x3e, 0xe4, 0x97, 0x22, 0xe1, 0xe2, 0xcc, 0x19, 0x4e, 0x79,
+	0x64, 0xa0, 0xcf, 0xf1, 0x8b, 0x07, 0x85, 0xc5, 0xdb, 0x4b, 0xff, 0x90, 0x83, 0x3c, 0xfb, 0x31,
+	0x23, 0x21, 0xfe, 0x35, 0xe2, 0xbc, 0xde, 0xe4, 0x3f, 0x7c, 0xdc, 0x10, 0xb1, 0x32, 0x49, 0x11,
+	0x2b, 0x1b, 0xa6, 0x7a, 0x3c, 0xf2, 0xe4, 0x56, 0xdc, 0xf6, 0xf2, 0xb7, 0xe0, 0x69, 0xe1, 0x56,
+	0x3c, 0x2d, 0xae, 0xc8, 0xd3, 0xd2, 0x0d, 0x3c, 0x85, 0x55, 0x78, 0x5a, 0x5e, 0x95, 0xa7, 0x95,
+	0xc4, 0xac, 0x8b, 0xfe, 0x84, 0x3a, 0x1e, 0xeb, 0xb6, 0x28, 0xf0, 0x8b, 0x26, 0xb5, 0x80, 0x37,
+	0x12, 0x25, 0x08, 0xfa, 0x4c, 0xec, 0x8a, 0xed, 0x2b, 0xb9, 0x46, 0x21, 0xf2, 0x48, 0xa6, 0xf4,
+	0xde, 0xf1, 0x2e, 0xc9, 0x8d, 0x54, 0x52, 0x99, 0x65, 0x45, 0x38, 0xe0, 0x10, 0xa9, 0xcd, 0x6e,
+	0x42, 0xce, 0x73, 0x9c, 0x80, 0x54, 0xeb, 0x29, 0xf7, 0x68, 0x83, 0x16, 0x8b, 0xf4, 0xb1, 0x6b,
+	0x91, 0x7e, 0xe4, 0x86, 0x36, 0xe2, 0xc5, 0x22, 0x8e, 0x11, 0x0e, 0x3d, 0x85, 0xea, 0x4c, 0x65,
+	0xec, 0x0c, 0xb1, 0xc5, 0xeb, 0x72, 0x6b, 0x02, 0x3d, 0x22, 0xe0, 0x47, 0x39, 0x9a, 0x0a, 0xf5,
+	0x84, 0xbb, 0x02, 0xe2, 0x67, 0xdc, 0xdf, 0xe9, 0x9a, 0x84, 0xf2, 0x17, 0x29, 0xb8, 0x9f, 0xf8,
+	0xd2, 0x8f, 0xba, 0x7c, 0x91, 0xf0, 0xab, 0x7a, 0x7a, 0xa5, 0x5f, 0xd5, 0x5f, 0x1c, 0xb3, 0x0a,
+	0x22, 0x6b, 0xa1, 0xbb, 0xb0, 0xd1, 0x3b, 0x6e, 0x77, 0xb5, 0xfe, 0xa0, 0x39, 0x38, 0xe9, 0x6b,
+	0x27, 0xdd, 0xb7, 0xdd, 0xde, 0xf7, 0x5d, 0xe9, 0x0e, 0x42, 0x50, 0x0d, 0x0b, 0x7a, 0x6f, 0xa5,
+	0x14, 0xda, 0x82, 0xf5, 0x30, 0xd6, 0x56, 0xd5, 0x9e, 0x2a, 0xa5, 0x5f, 0xfc, 0x5b, 0x1a, 0x6a,
+	0xb1, 0x5b, 0xc3, 0x48, 0x86, 0xcd, 0x03, 0xf5, 0xb8, 0xa5, 0x1d, 0xab, 0xbd, 0xbd, 0xc3, 0xf6,
+	0x51, 0xe8, 0xc5, 0x0f, 0x40, 0x8e, 0x49, 0xd4, 0x76, 0xb3, 0xf5, 0xa6, 0xb9, 0x77, 0xd8, 0x96,
+	0x52, 0x68, 0x13, 0xa4, 0x88, 0x74, 0x70, 0xd8, 0x97, 0xd2, 0xe8, 0x21, 0xd4, 0x23, 0x68, 0xb7,
+	0xa7, 0xa9, 0xed, 0xd7, 0x87, 0xed, 0xd6, 0xa0, 0xd3, 0xeb, 0x4a, 0x19, 0xb4, 0x03, 0x0f, 0x62,
+	0xef, 0x6c, 0x9e, 0x0c, 0xde, 0xb4, 0xbb, 0x83, 0x4e, 0xab, 0x39, 0x68, 0xef, 0x4b, 0x59, 0xa4,
+	0xc0, 0xc3, 0x88, 0xc6, 0x71, 0x5b, 0x3d, 0xea, 0xf4, 0xfb, 0x9d, 0x5e, 0x57, 0xdb, 0x6f, 0x77,
+	0x3b, 0xed, 0x7d, 0x29, 0xb7, 0x30, 0xb2, 0x6e, 0x4f, 0xeb, 0xb7, 0xd5, 0xd3, 0x4e, 0xab, 0xdd,
+	0x97, 0xf2, 0x0b, 0x33, 0x1a, 0x74, 0x8e, 0xda, 0xbd, 0x93, 0x81, 0x54, 0x40, 0x8f, 0xe0, 0x7e,
+	0xbc, 0xdf, 0xb1, 0xda, 0x1b, 0xf4, 0xb4, 0xd7, 0x9d, 0xc3, 0x76, 0x5f, 0x2a, 0x2e, 0x0c, 0x9f,
+	0x49, 0x3b, 0xdd, 0xd3, 0xe6, 0x61, 0x67, 0x5f, 0x2a, 0x11, 0x23, 0x44, 0x5f, 0xdd, 0x54, 0x0f,
+	0xda, 0x03, 0x09, 0x5e, 0xfc, 0x4d, 0x1a, 0xd0, 0xe2, 0x55, 0x44, 0x32, 0x50, 0x6a, 0x87, 0xe6,
+	0x71, 0x27, 0x61, 0x81, 0x77, 0xe0, 0x41, 0x82, 0x34, 0xbc, 0xc8, 0x8f, 0xe1, 0xb3, 0x04, 0x0d,
+	0xb2, 0x64, 0x3d, 0xb5, 0xf3, 0xcb, 0xf6, 0xbe, 0x94, 0x26, 0x73, 0x5a, 0x50, 0x79, 0x33, 0x18,
+	0x1c, 0x73, 0xa3, 0x67, 0xd0, 0x3d, 0xd8, 0x4a, 0x50, 0x38, 0x3a, 0x94, 0xb2, 0xe8, 0x09, 0x3c,
+	0x5a, 0x10, 0x75, 0x7b, 0x03, 0xad, 0xa9, 0xed, 0xf7, 0x5a, 0x27, 0x47, 0xed, 0xee, 0x40, 0xca,
+	0xa1, 0xcf, 0xe0, 0xde, 0x82, 0x52, 0xff, 0xfb, 0xe6, 0xc1, 0x41, 0x5b, 0xdd, 0x95, 0xf2, 0x64,
+	0xc9, 0x16, 0xc4, 0x47, 0xcd, 0xc3, 0xd7, 0x3d, 0xf5, 0xa8, 0xbd, 0x2f, 0x15, 0x5e, 0xfc, 0x4f,
+	0x0a, 0xaa, 0xd1, 0xcb, 0x69, 0x64, 0x15, 0x8f, 0x5a, 0xc7, 0x09, 0x0b, 0xb2, 0x0d, 0x28, 0x2c,
+	0xe0, 0xab, 0x9b, 0x42, 0xf7, 0xe1, 0x6e, 0xb4, 0xc3, 0x7c, 0x8d, 0xd2, 0xf1, 0xb7, 0x09, 0x6b,
+	0x67, 0xc8, 0xe2, 0x47, 0x7b, 0x85, 0xd6, 0x2d, 0x4b, 0x96, 0x25, 0x2c, 0x7d, 0xdd, 0x53, 0xf7,
+	0x3a, 0xfb, 0xfb, 0xed, 0xae, 0x94, 0x43, 0x75, 0xd8, 0x0e, 0x8b, 0x42, 0xab, 0x99, 0x8f, 0x7f,
+	0x8d, 0xac, 0xd6, 0x51, 0xeb, 0x58, 0x2a, 0x10, 0x97, 0x0b, 0x0b, 0xda, 0x47, 0xc7, 0x83, 0x1f,
+	0xa4, 0xe2, 0x8b, 0x3f, 0x86, 0xb5, 0xc8, 0x6d, 0x36, 0xe2, 0xae, 0x0b, 0x2e, 0x2c, 0x41, 0x85,
+	0x63, 0x6a, 0xbb, 0xb9, 0xff, 0x83, 0x94, 0x0a, 0x21, 0xdc, 0x77, 0x43, 0xfd, 0xd4, 0x93, 0x6e,
+	0xb7, 0xd3, 0x3d, 0x90, 0x32, 0x2f, 0x0e, 0xa1, 0x28, 0xee, 0xaa, 0xa1, 0x1a, 0x94, 0x0f, 0xdb,
+	0xa7, 0xed, 0x43, 0x6d, 0xbf, 0xbd, 0x77, 0x72, 0x20, 0xdd, 0x41, 0x55, 0x00, 0x06, 0x74, 0xba,
+	0xaf, 0x7b, 0x52, 0x6a, 0xde, 0xfe, 0xbe, 0xa9, 0x76, 0xa5, 0xf4, 0xbc, 0x03, 0x27, 0xca, 0x8b,
+	0x3f, 0x4d, 0x85, 0xee, 0x3c, 0x89, 0x6b, 0x4b, 0x5b, 0xa7, 0x4d, 0xb5, 0x43, 0x56, 0x5a, 0xeb,
+	0xf7, 0x4e, 0xd4, 0x56, 0x5b, 0x3b, 0xe9, 0xf6, 0xdb, 0x03, 0xe9, 0x0e, 0xf1, 0xb2, 0xb8, 0x88,
+	0x78, 0x91, 0x94, 0x22, 0xeb, 0x1e, 0x97, 0xbc, 0x6d, 0xff, 0xd0, 0x7a, 0xd3, 0xec, 0x74, 0x19,
+	0x5f, 0xe3, 0xd2, 0x76, 0xf7, 0xb4, 0xa3, 0xf6, 0xba, 0x94, 0x6f, 0x99, 0xdd, 0xbf, 0xcb, 0x41,
+	0xa6, 0xe9, 0x9a, 0xe8, 0x2b, 0x28, 0xf0, 0x95, 0x43, 0xb5, 0x46, 0xf4, 0xbf, 0x47, 0x75, 0xa9,
+	0x11, 0xbf, 0x44, 0xf8, 0x15, 0x14, 0xf8, 0x3f, 0x81, 0x90, 0xf8, 0xdb, 0x80, 0x3b, 0xd7, 0x8e,
+	0xff, 0x49, 0xa8, 0x09, 0xd5, 0xe8, 0x5f, 0x16, 0xd0, 0x76, 0x23, 0xf1, 0x3f, 0x10, 0xf5, 0xbb,
+	0x8d, 0x25, 0xff, 0x6d, 0x78, 0x05, 0xe5, 0xd0, 0x7f, 0x74, 0xd0, 0x46, 0x63, 0xf1, 0x5f, 0x3e,
+	0xf5, 0xcd, 0x46, 0xd2, 0xdf, 0x78, 0xbe, 0x05, 0x98, 0xdf, 0x1b, 0x46, 0xa8, 0xb1, 0x70, 0xe9,
+	0xb8, 0xbe, 0xd1, 0x48, 0xb8, 0x58, 0x7c, 0x00, 0x52, 0xfc, 0x5e, 0x20, 0x92, 0x1b, 0x4b, 0xae,
+	0x11, 0xd6, 0xef, 0x35, 0x96, 0x5e, 0x22, 0x3c, 0x86, 0x8d, 0xa4, 0x7b, 0x76, 0xf7, 0x1b, 0xcb,
+	0x77, 0xd4, 0xfa, 0x83, 0xc6, 0x75, 0x3b, 0xe3, 0x2f, 0xa0, 0x1a, 0xbd, 0xc2, 0x86, 0xb6, 0x1b,
+	0x89, 0x77, 0xda, 0xea, 0x9b, 0x8d, 0xa4, 0x9b, 0x67, 0x7b, 0x20, 0xc5, 0xef, 0xaf, 0x21, 0xb9,
+	0xb1, 0xe4, 0x4a, 0xdb, 0x92, 0x77, 0xbc, 0x82, 0x72, 0xe8, 0x26, 0x18, 0xda, 0x68, 0x2c, 0xde,
+	0x16, 0xab, 0x6f, 0x36, 0x92, 0x2e, 0x8b, 0x7d, 0x0b, 0x30, 0xbf, 0xe0, 0x85, 0x50, 0x63, 0xe1,
+	0x5a, 0x58, 0x7d, 0xa3, 0xb1, 0x78, 0x03, 0x6c, 0xaf, 0xf4, 0xcb, 0x82, 0x7b, 0x39, 0x22, 0x7f,
+	0x91, 0x3b, 0xcb, 0xd3, 0xa3, 0xed, 0x1f, 0xfc, 0xdf, 0x00, 0x53, 0x0a, 0xb2, 0xfe, 0x36, 0x37,
+	0x00, 0x00,
 }
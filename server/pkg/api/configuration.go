@@ -171,6 +171,14 @@ func legacyProjectToApp(project map[string]any) map[string]any {
 	return map[string]any{"name": project["name"], appType: variant}
 }
 
+// verbatimLists are the lists of strings whose items are used as they are - a
+// local MCP server's arguments and environment - so flattenApp carries them as a
+// JSON array, which keeps the commas and spaces a comma-joined list would lose.
+var verbatimLists = map[protoreflect.FullName]bool{
+	"McpApp.args": true,
+	"McpApp.env":  true,
+}
+
 // flattenApp returns an app's type (the set oneof field) and its scalar parameters
 // as a string map, the shape the in-process app contract consumes. The headers map
 // is excluded (it is forwarded per request, not a creation parameter); booleans
@@ -189,6 +197,36 @@ func flattenApp(app *ConfigurationApp) (string, map[string]string) {
 		if f.IsMap() {
 			return true // skip headers
 		}
+		if f.IsList() && f.Kind() == protoreflect.MessageKind {
+			// A list of messages, such as an http app's endpoints, is carried as the
+			// JSON array kaja.json writes it as.
+			items := make([]json.RawMessage, v.List().Len())
+			for i := range items {
+				items[i], _ = protojson.Marshal(v.List().Get(i).Message().Interface())
+			}
+			encoded, _ := json.Marshal(items)
+			params[string(f.Name())] = string(encoded)
+			return true
+		}
+		if f.IsList() && verbatimLists[f.FullName()] {
+			values := make([]string, v.List().Len())
+			for i := range values {
+				values[i] = v.List().Get(i).String()
+			}
+			encoded, _ := json.Marshal(values)
+			params[string(f.Name())] = string(encoded)
+			return true
+		}
+		if f.IsList() {
+			// A list of strings is comma-joined, which is how a text parameter
+			// carries one.
+			values := make([]string, v.List().Len())
+			for i := range values {
+				values[i] = v.List().Get(i).String()
+			}
+			params[string(f.Name())] = strings.Join(values, ",")
+			return true
+		}
 		if f.Kind() == protoreflect.BoolKind {
 			params[string(f.Name())] = strconv.FormatBool(v.Bool())
 		} else {
@@ -220,7 +258,7 @@ func expandVariables(value string, variables map[string]string) string {
 // one this machine holds outside kaja.json.
 func expandAppParameters(parameters map[string]string, resolver *Resolver, logger *Logger) {
 	for key, value := range parameters {
-		expanded := resolver.Expand(value)
+		expanded := expandParameter(value, resolver)
 		parameters[key] = expanded
 		for _, match := range variableReferencePattern.FindAllStringSubmatch(expanded, -1) {
 			logger.info(fmt.Sprintf("No variable named %q is defined; leaving ${%s} in %q as-is", match[1], match[1], key))
@@ -228,6 +266,42 @@ func expandAppParameters(parameters map[string]string, resolver *Resolver, logge
 	}
 }
 
+// expandParameter expands one parameter. A JSON array, which is how flattenApp
+// carries a list that isn't comma-joined, has the strings inside it expanded, so
+// a value holding a quote or a backslash doesn't break the JSON around it.
+func expandParameter(value string, resolver *Resolver) string {
+	if !strings.HasPrefix(value, "[") || !strings.Contains(value, "${") {
+		return resolver.Expand(value)
+	}
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return resolver.Expand(value)
+	}
+	encoded, err := json.Marshal(expandStrings(decoded, resolver))
+	if err != nil {
+		return resolver.Expand(value)
+	}
+	return string(encoded)
+}
+
+func expandStrings(value any, resolver *Resolver) any {
+	switch v := value.(type) {
+	case string:
+		return resolver.Expand(v)
+	case []any:
+		for i, item := range v {
+			v[i] = expandStrings(item, resolver)
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = expandStrings(item, resolver)
+		}
+	}
+	return value
+}
+
 func stringField(m map[string]any, keys ...string) string {
 	for _, key := range keys {
 		if value, ok := m[key].(string); ok {
@@ -265,7 +339,7 @@ func validateApps(configuration *Configuration, logger *Logger) {
 	validApps := []*ConfigurationApp{}
 	for _, app := range configuration.Apps {
 		if appType, _ := flattenApp(app); appType == "" {
-			logger.error(fmt.Sprintf("App %q has no type set. Use one of grpc, twirp, openapi, openai, folder, mcp.", app.Name), nil)
+			logger.error(fmt.Sprintf("App %q has no type set. Use one of grpc, twirp, openapi, openai, anthropic, folder, sqlite, graphql, jsonrpc, websocket, http, mcp.", app.Name), nil)
 			continue
 		}
 		validApps = append(validApps, app)
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -191,6 +192,51 @@ func TestLoadGetConfigurationResponse_MigratesMarkdownApp(t *testing.T) {
 	}
 }
 
+func TestFlattenApp_EndpointsAreJSON(t *testing.T) {
+	app := &ConfigurationApp{Name: "internal", App: &ConfigurationApp_Http{Http: &HttpApp{
+		BaseUrl:   "https://internal.example.com",
+		Endpoints: []*HttpEndpoint{{Name: "get_user", Path: "/users/{id}", Query: []string{"verbose"}}},
+	}}}
+	appType, params := flattenApp(app)
+	if appType != "http" || params["base_url"] != "https://internal.example.com" {
+		t.Errorf("unexpected http app: %q %v", appType, params)
+	}
+	var endpoints []map[string]any
+	if err := json.Unmarshal([]byte(params["endpoints"]), &endpoints); err != nil {
+		t.Fatalf("endpoints %q: %v", params["endpoints"], err)
+	}
+	if len(endpoints) != 1 || endpoints[0]["name"] != "get_user" || endpoints[0]["path"] != "/users/{id}" {
+		t.Errorf("endpoints = %v", endpoints)
+	}
+}
+
+func TestFlattenApp_CommandArgumentsAreJSON(t *testing.T) {
+	app := &ConfigurationApp{Name: "local", App: &ConfigurationApp_Mcp{Mcp: &McpApp{
+		Command: "server",
+		Args:    []string{"--filter=a,b", " padded "},
+		Env:     []string{"TOKEN=${TOKEN}"},
+		Roots:   []string{"notes", "docs"},
+	}}}
+	_, params := flattenApp(app)
+	if params["roots"] != "notes,docs" {
+		t.Errorf("roots = %q, want the comma-joined list", params["roots"])
+	}
+	expandAppParameters(params, NewResolver(map[string]string{"TOKEN": `a"b\c`}, nil), NewLogger())
+	var args, env []string
+	if err := json.Unmarshal([]byte(params["args"]), &args); err != nil {
+		t.Fatalf("args %q: %v", params["args"], err)
+	}
+	if err := json.Unmarshal([]byte(params["env"]), &env); err != nil {
+		t.Fatalf("env %q: %v", params["env"], err)
+	}
+	if len(args) != 2 || args[0] != "--filter=a,b" || args[1] != " padded " {
+		t.Errorf("args = %q", args)
+	}
+	if len(env) != 1 || env[0] != `TOKEN=a"b\c` {
+		t.Errorf("env = %q", env)
+	}
+}
+
 func TestUpdateConfiguration_DeniedWhenNotAllowed(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "config-*.json")
 	if err != nil {
@@ -249,6 +295,48 @@ func TestUpdateConfiguration_AllowedWhenEnabled(t *testing.T) {
 	}
 }
 
+// Launching a local MCP server is as trusted as writing kaja.json: a kaja that
+// can't update its configuration starts only the commands the file names.
+func TestInspectMcp_LaunchesOnlyConfiguredCommandsWhenReadOnly(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "config-*.json")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpfile.Name())
+	configured := `{"apps":[{"name":"notes","mcp":{"command":"notes-server","args":["--stdio"]}}]}`
+	if _, err := tmpfile.Write([]byte(configured)); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	service := NewApiService(tmpfile.Name(), false, "", "", nil)
+	if !service.mayLaunch(&McpApp{Command: "notes-server", Args: []string{"--stdio"}}) {
+		t.Error("expected the configured command to be launchable")
+	}
+	if !service.mayLaunch(&McpApp{Url: "https://example.com/mcp"}) {
+		t.Error("an app that launches nothing needs no permission")
+	}
+
+	response, err := service.InspectMcp(context.Background(), &InspectMcpRequest{Mcp: &McpApp{Command: "rm", Args: []string{"-rf", "/"}}})
+	if err != nil {
+		t.Fatalf("InspectMcp: %v", err)
+	}
+	if response.Problem == nil || response.Problem.Kind != McpProblemKind_MCP_PROBLEM_TARGET {
+		t.Fatalf("expected a target problem, got %+v", response)
+	}
+
+	opened, err := service.OpenApp(context.Background(), &OpenAppRequest{App: &ConfigurationApp{App: &ConfigurationApp_Mcp{Mcp: &McpApp{Command: "notes-server", Args: []string{"--other"}}}}})
+	if err != nil {
+		t.Fatalf("OpenApp: %v", err)
+	}
+	if opened.Status != OpenStatus_OPEN_STATUS_ERROR {
+		t.Errorf("status = %v, want an unconfigured command refused", opened.Status)
+	}
+
+	if !NewApiService(tmpfile.Name(), true, "", "", nil).mayLaunch(&McpApp{Command: "anything"}) {
+		t.Error("an editable kaja launches what it is given")
+	}
+}
+
 // Earlier versions wrote a "system" block into every file they saved, so
 // configurations in the wild still carry one. It must be discarded without taking
 // the rest of the file with it, and it must never unlock a server started without
@@ -453,3 +541,38 @@ func TestLoadGetConfigurationResponse_PathPrefixNormalization(t *testing.T) {
 		t.Error("expected to find debug log about path prefix normalization")
 	}
 }
+
+func TestAddMcpRequests_ResolvesRootsAndSamplingFromTheConfiguration(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "config-*.json")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpfile.Name())
+	configured := `{"apps":[` +
+		`{"name":"docs","folder":{"path":"/srv/docs"}},` +
+		`{"name":"notes","folder":{"path":"/srv/notes"}},` +
+		`{"name":"assistant","openai":{"endpoint":"https://llm.example.com/v1/chat/completions","token":"${OPENAI_KEY}"}}]}`
+	if _, err := tmpfile.Write([]byte(configured)); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	service := NewApiService(tmpfile.Name(), false, "", "", nil)
+
+	parameters := map[string]string{}
+	service.addMcpRequests(&McpApp{Roots: []string{"docs"}, SamplingApp: "assistant"}, parameters, NewLogger())
+	if parameters["root_folders"] != "docs=/srv/docs" {
+		t.Errorf("root_folders = %q, want only the folder app named", parameters["root_folders"])
+	}
+	if parameters["sampling.endpoint"] != "https://llm.example.com/v1/chat/completions" || parameters["sampling.token"] != "${OPENAI_KEY}" {
+		t.Errorf("expected the sampling app's parameters, got %v", parameters)
+	}
+
+	parameters = map[string]string{}
+	logger := NewLogger()
+	service.addMcpRequests(&McpApp{SamplingApp: "docs"}, parameters, logger)
+	if parameters["root_folders"] != "docs=/srv/docs\nnotes=/srv/notes" {
+		t.Errorf("root_folders = %q, want every folder app", parameters["root_folders"])
+	}
+	if _, ok := parameters["sampling.path"]; ok || len(logger.logs) == 0 {
+		t.Error("a sampling app that isn't an openai app is refused, and said so")
+	}
+}
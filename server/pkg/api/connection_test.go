@@ -1,11 +1,18 @@
 package api
 
 import (
+	"context"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/wham/kaja/v2/pkg/grpc"
+	"github.com/wham/kaja/v2/pkg/limit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func writeConfiguration(t *testing.T, contents string) string {
@@ -49,8 +56,8 @@ func TestAppConnection(t *testing.T) {
 		t.Errorf("TLS = %+v, want the app's transport", connection.TLS)
 	}
 
-	// A twirp app has no credential of its own to apply, and neither has an app
-	// that isn't there.
+	// A twirp app that configures no credential has none to apply, and neither
+	// has an app that isn't there.
 	if connection := service.AppConnection("quirks"); len(connection.Metadata) != 0 || connection.TLS.Mode != "" {
 		t.Errorf("AppConnection(\"quirks\") = %+v, want nothing", connection)
 	}
@@ -61,3 +68,193 @@ func TestAppConnection(t *testing.T) {
 		t.Errorf("AppConnection(\"\") = %+v, want nothing", connection)
 	}
 }
+
+func TestAppConnectionTwirp(t *testing.T) {
+	path := writeConfiguration(t, `{
+		"variables": { "QUIRKS_KEY": "k-123" },
+		"apps": [
+			{
+				"name": "quirks",
+				"twirp": {
+					"url": "quirks.example.com:8443",
+					"proto_dir": "quirks/proto",
+					"tls": "on",
+					"auth": "apikey",
+					"api_key_name": "x-api-key",
+					"token": "${QUIRKS_KEY}"
+				}
+			}
+		]
+	}`)
+
+	service := NewApiService(path, false, "", "", nil)
+
+	connection := service.AppConnection("quirks")
+	if got := connection.Metadata["x-api-key"]; got != "k-123" {
+		t.Errorf("x-api-key = %q, want the variable resolved", got)
+	}
+
+	// The app asks for TLS, so a URL written as plain http is sent over https.
+	target, err := url.Parse("http://quirks.example.com:8443/twirp/quirks.v1.Quirks/Get")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstream, client, err := connection.HTTPClient(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upstream.Scheme != "https" || upstream.Host != target.Host || upstream.Path != target.Path {
+		t.Errorf("upstream = %s, want %s over https", upstream, target)
+	}
+	if client == nil || client.Transport == nil {
+		t.Error("HTTPClient() returned no transport")
+	}
+	if target.Scheme != "http" {
+		t.Errorf("HTTPClient() changed the caller's URL to %s", target)
+	}
+}
+
+func TestAppConnectionEndpoints(t *testing.T) {
+	path := writeConfiguration(t, `{
+		"apps": [
+			{
+				"name": "seating",
+				"grpc": {
+					"url": "dns:seating-1.staging:9000",
+					"endpoints": ["dns:seating-2.staging:9000", "dns:seating-3.staging:9000"],
+					"load_balancing": "round_robin"
+				}
+			},
+			{ "name": "single", "grpc": { "url": "dns:single.staging:9000" } }
+		]
+	}`)
+	service := NewApiService(path, false, "", "", nil)
+
+	connection := service.AppConnection("seating")
+	if connection.LoadBalancing != grpc.LoadBalancingRoundRobin {
+		t.Errorf("LoadBalancing = %q, want %q", connection.LoadBalancing, grpc.LoadBalancingRoundRobin)
+	}
+
+	// Calls take the endpoints in turn, and the turn is the app's, not the
+	// connection's read for one call.
+	var served []string
+	for range 4 {
+		endpoint, err := service.AppConnection("seating").endpoint("dns:seating-1.staging:9000", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		served = append(served, endpoint)
+	}
+	want := []string{"dns:seating-1.staging:9000", "dns:seating-2.staging:9000", "dns:seating-3.staging:9000", "dns:seating-1.staging:9000"}
+	if !slices.Equal(served, want) {
+		t.Errorf("served = %v, want %v", served, want)
+	}
+
+	if endpoint, err := connection.endpoint("dns:seating-1.staging:9000", "dns:seating-3.staging:9000"); err != nil || endpoint != "dns:seating-3.staging:9000" {
+		t.Errorf("pinned endpoint = %q, %v; want the replica asked for", endpoint, err)
+	}
+
+	// The app's credential goes wherever the call does, so a pin can only name
+	// an endpoint the app lists.
+	_, err := connection.Client("dns:seating-1.staging:9000", "dns:elsewhere.example.com:443")
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("pinning an endpoint the app doesn't list: err = %v, want INVALID_ARGUMENT", err)
+	}
+
+	// An app with one endpoint goes where it always has.
+	if endpoint, err := service.AppConnection("single").endpoint("dns:single.staging:9000", ""); err != nil || endpoint != "dns:single.staging:9000" {
+		t.Errorf("endpoint = %q, %v; want the app's url", endpoint, err)
+	}
+	if endpoint, err := service.AppConnection("").endpoint("dns:unnamed:9000", ""); err != nil || endpoint != "dns:unnamed:9000" {
+		t.Errorf("endpoint = %q, %v; want the target as sent", endpoint, err)
+	}
+}
+
+func TestAppConnectionRetry(t *testing.T) {
+	path := writeConfiguration(t, `{
+		"apps": [
+			{ "name": "seating", "grpc": { "url": "dns:seating.example.com:443", "retry_max_attempts": 3, "retry_codes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"] } },
+			{ "name": "quirks", "twirp": { "url": "https://quirks.example.com", "retry_max_attempts": 2, "retry_backoff_ms": 50 } }
+		]
+	}`)
+	service := NewApiService(path, false, "", "", nil)
+
+	seating := service.AppConnection("seating").Retry
+	if seating.MaxAttempts != 3 || !slices.Equal(seating.Codes, []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"}) {
+		t.Errorf("seating retry = %+v, want 3 attempts on UNAVAILABLE and RESOURCE_EXHAUSTED", seating)
+	}
+	// A proxied twirp app has a retry policy, though nothing else of the
+	// connection is its own.
+	if quirks := service.AppConnection("quirks").Retry; quirks.MaxAttempts != 2 || quirks.Backoff != 50*time.Millisecond {
+		t.Errorf("quirks retry = %+v, want 2 attempts 50ms apart", quirks)
+	}
+}
+
+func TestAppConnectionLimits(t *testing.T) {
+	path := writeConfiguration(t, `{
+		"apps": [
+			{ "name": "seating", "grpc": { "url": "dns:seating.example.com:443", "rate_limit": 5, "max_concurrency": 2 } },
+			{ "name": "quirks", "twirp": { "url": "https://quirks.example.com", "max_concurrency": 1 } },
+			{ "name": "open", "grpc": { "url": "dns:open.example.com:443" } }
+		]
+	}`)
+	service := NewApiService(path, false, "", "", nil)
+
+	// Every call the app makes waits at the same gate, or the limits would be
+	// per call and hold nothing back.
+	first, second := service.AppConnection("seating"), service.AppConnection("seating")
+	if first.gate == nil || first.gate != second.gate {
+		t.Fatalf("gates = %p and %p, want one gate for the app", first.gate, second.gate)
+	}
+	if first.Limits != (limit.Limits{Rate: 5, MaxConcurrency: 2}) {
+		t.Errorf("limits = %+v, want 5 a second and 2 at once", first.Limits)
+	}
+	if quirks := service.AppConnection("quirks"); quirks.gate == nil || quirks.gate == first.gate {
+		t.Errorf("twirp gate = %p, want one of its own", quirks.gate)
+	}
+	if open := service.AppConnection("open"); open.gate != nil {
+		t.Errorf("unlimited app gate = %p, want none", open.gate)
+	}
+
+	// Changed limits take effect on the next call, at a new gate.
+	updated := writeConfiguration(t, `{ "apps": [ { "name": "seating", "grpc": { "url": "dns:seating.example.com:443", "rate_limit": 50 } } ] }`)
+	service.configurationPath = updated
+	if changed := service.AppConnection("seating"); changed.gate == first.gate || changed.gate.Limits().Rate != 50 {
+		t.Errorf("after the change gate = %p with %+v, want a new one at 50 a second", changed.gate, changed.gate.Limits())
+	}
+}
+
+func TestOpenAppLimits(t *testing.T) {
+	service := NewApiService(writeConfiguration(t, `{}`), false, "", "", nil)
+	open := func(rate int64) *OpenAppResponse {
+		t.Helper()
+		response, err := service.OpenApp(context.Background(), &OpenAppRequest{App: &ConfigurationApp{
+			Name: "internal",
+			App:  &ConfigurationApp_Http{Http: &HttpApp{BaseUrl: "https://internal.example.com", RateLimit: rate}},
+		}})
+		if err != nil || response.Status != OpenStatus_OPEN_STATUS_OK {
+			t.Fatalf("OpenApp = %v, %v", response, err)
+		}
+		return response
+	}
+	gate := func() *limit.Gate {
+		if value, ok := service.gates.Load("internal"); ok {
+			return value.(*limit.Gate)
+		}
+		return nil
+	}
+
+	// Opening the app again is a new instance but the same app, so its calls
+	// keep waiting at the gate they were at.
+	first := open(5)
+	before := gate()
+	if second := open(5); second.Target == first.Target || gate() != before || before == nil {
+		t.Fatalf("reopened gate = %p, want %p", gate(), before)
+	}
+	if open(50); gate() == before || gate().Limits().Rate != 50 {
+		t.Errorf("after the change gate = %p with %+v, want a new one at 50 a second", gate(), gate().Limits())
+	}
+	if open(0); gate() != nil {
+		t.Errorf("unlimited gate = %p, want none", gate())
+	}
+}
@@ -0,0 +1,338 @@
+// Package anthropic implements the built-in "anthropic" app: it exposes the
+// Anthropic Messages API - a message, unary or streamed, and token counting - as
+// a small gRPC surface kaja can render and invoke, against Anthropic or any
+// gateway that serves the same /v1/messages endpoints.
+//
+// The app's creation parameters are "endpoint" (the API's base URL, e.g.
+// https://api.anthropic.com; a URL that already ends in /v1 or /v1/messages
+// names the same base), "token" (the API key, sent as x-api-key) and "version"
+// (the anthropic-version header, 2023-06-01 when empty). Method calls arrive as
+// protobuf, are transcoded into a POST against the method's path under the base,
+// and the JSON response is shaped back into the method's protobuf response; a
+// streamed call asks for the server-sent event stream and sends each event on as
+// its own message. A failed call is an apps.UpstreamError.
+package anthropic
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
+	"github.com/wham/protoc-go/protoc"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	serviceTypeName = "anthropic.Anthropic"
+	defaultBaseURL  = "https://api.anthropic.com"
+	defaultVersion  = "2023-06-01"
+)
+
+// protoSource is the static proto surface the anthropic app renders: a
+// CreateMessage method taking what a Messages request takes (model, system
+// prompt, the conversation, tools, sampling) and returning the message with its
+// text joined for convenience, StreamMessage, which sends the message's events
+// as it is generated, and CountTokens. The fields carry the API's own JSON names,
+// so a request encodes into the body the endpoint expects.
+const protoSource = `syntax = "proto3";
+
+package anthropic;
+
+import "google/protobuf/struct.proto";
+
+// One block of a message's content. Which fields are set depends on the type.
+message ContentBlock {
+  // "text", "image", "tool_use", "tool_result", "thinking" or
+  // "redacted_thinking".
+  string type = 1 [json_name = "type"];
+  // The text of a "text" block.
+  string text = 2 [json_name = "text"];
+  // A "tool_use" block: the id its result answers to, the tool called, and
+  // the arguments.
+  string id = 3 [json_name = "id"];
+  string name = 4 [json_name = "name"];
+  google.protobuf.Struct input = 5 [json_name = "input"];
+  // A "tool_result" block: the call it answers, what the tool returned - text,
+  // or a list of blocks - and whether the tool failed.
+  string tool_use_id = 6 [json_name = "tool_use_id"];
+  google.protobuf.Value content = 7 [json_name = "content"];
+  optional bool is_error = 8 [json_name = "is_error"];
+  // The picture of an "image" block.
+  ImageSource source = 9 [json_name = "source"];
+  // A "thinking" block's reasoning and the signature that vouches for it.
+  string thinking = 10 [json_name = "thinking"];
+  string signature = 11 [json_name = "signature"];
+  // A "redacted_thinking" block's encrypted reasoning.
+  string data = 12 [json_name = "data"];
+}
+
+message ImageSource {
+  // "base64" or "url".
+  string type = 1 [json_name = "type"];
+  // The image type of base64 data, e.g. "image/png".
+  string media_type = 2 [json_name = "media_type"];
+  string data = 3 [json_name = "data"];
+  string url = 4 [json_name = "url"];
+}
+
+// One turn of the conversation. A tool result is a "user" turn with a
+// "tool_result" block.
+message Message {
+  // "user" or "assistant".
+  string role = 1 [json_name = "role"];
+  repeated ContentBlock content = 2 [json_name = "content"];
+  // The turn as plain text, ahead of any content blocks.
+  string text = 3 [json_name = "text"];
+}
+
+// A tool the model may call.
+message Tool {
+  string name = 1 [json_name = "name"];
+  string description = 2 [json_name = "description"];
+  // JSON Schema of the input object.
+  google.protobuf.Struct input_schema = 3 [json_name = "input_schema"];
+}
+
+message ToolChoice {
+  // "auto", "any", "tool" or "none".
+  string type = 1 [json_name = "type"];
+  // The tool the model must call, for "tool".
+  string name = 2 [json_name = "name"];
+  // Have the model call one tool at most.
+  optional bool disable_parallel_tool_use = 3 [json_name = "disable_parallel_tool_use"];
+}
+
+// Extended thinking: the model reasons before it answers.
+message Thinking {
+  // "enabled" or "disabled".
+  string type = 1 [json_name = "type"];
+  // How many tokens the model may spend reasoning, less than max_tokens.
+  int32 budget_tokens = 2 [json_name = "budget_tokens"];
+}
+
+message MessageRequest {
+  // Model name, e.g. "claude-sonnet-4-5".
+  string model = 1 [json_name = "model"];
+  // System prompt that sets the assistant's behavior (optional).
+  string system = 2 [json_name = "system"];
+  // The conversation so far.
+  repeated Message messages = 3 [json_name = "messages"];
+  // User prompt sent after messages, as a last "user" turn. Optional when
+  // messages carries the conversation.
+  string user_prompt = 4 [json_name = "user_prompt"];
+  // Maximum number of tokens to generate. 0 means 1024.
+  int32 max_tokens = 5 [json_name = "max_tokens"];
+  optional float temperature = 6 [json_name = "temperature"];
+  optional float top_p = 7 [json_name = "top_p"];
+  optional int32 top_k = 8 [json_name = "top_k"];
+  // Text that ends the reply where the model writes it.
+  repeated string stop_sequences = 9 [json_name = "stop_sequences"];
+  // Tools the model may call instead of replying.
+  repeated Tool tools = 10 [json_name = "tools"];
+  ToolChoice tool_choice = 11 [json_name = "tool_choice"];
+  Thinking thinking = 12 [json_name = "thinking"];
+}
+
+message Usage {
+  int32 input_tokens = 1 [json_name = "input_tokens"];
+  int32 output_tokens = 2 [json_name = "output_tokens"];
+  int32 cache_creation_input_tokens = 3 [json_name = "cache_creation_input_tokens"];
+  int32 cache_read_input_tokens = 4 [json_name = "cache_read_input_tokens"];
+}
+
+message MessageResponse {
+  string id = 1 [json_name = "id"];
+  string model = 2 [json_name = "model"];
+  string role = 3 [json_name = "role"];
+  // The text of every "text" block, joined.
+  string text = 4 [json_name = "text"];
+  repeated ContentBlock content = 5 [json_name = "content"];
+  // "end_turn", "max_tokens", "stop_sequence", "tool_use", "pause_turn" or
+  // "refusal".
+  string stop_reason = 6 [json_name = "stop_reason"];
+  // Which of the stop sequences ended the reply.
+  string stop_sequence = 7 [json_name = "stop_sequence"];
+  Usage usage = 8 [json_name = "usage"];
+}
+
+// What a streamed event adds: to a content block ("text_delta",
+// "input_json_delta", "thinking_delta", "signature_delta"), or to the message
+// as it ends.
+message Delta {
+  string type = 1 [json_name = "type"];
+  string text = 2 [json_name = "text"];
+  // A piece of a tool call's input, as JSON text to join with the rest.
+  string partial_json = 3 [json_name = "partial_json"];
+  string thinking = 4 [json_name = "thinking"];
+  string signature = 5 [json_name = "signature"];
+  string stop_reason = 6 [json_name = "stop_reason"];
+  string stop_sequence = 7 [json_name = "stop_sequence"];
+}
+
+// One message of StreamMessage: one event of the stream as the API sent it.
+message MessageEvent {
+  // "message_start", "content_block_start", "content_block_delta",
+  // "content_block_stop", "message_delta" or "message_stop".
+  string type = 1 [json_name = "type"];
+  // The text this event adds: a text delta's text.
+  string text = 2 [json_name = "text"];
+  // The message as it starts, on "message_start".
+  MessageResponse message = 3 [json_name = "message"];
+  // Which content block the event is about.
+  int32 index = 4 [json_name = "index"];
+  // The block as it starts, on "content_block_start".
+  ContentBlock content_block = 5 [json_name = "content_block"];
+  Delta delta = 6 [json_name = "delta"];
+  // The tokens used so far, on "message_delta".
+  Usage usage = 7 [json_name = "usage"];
+}
+
+message CountTokensRequest {
+  string model = 1 [json_name = "model"];
+  string system = 2 [json_name = "system"];
+  repeated Message messages = 3 [json_name = "messages"];
+  string user_prompt = 4 [json_name = "user_prompt"];
+  repeated Tool tools = 5 [json_name = "tools"];
+  ToolChoice tool_choice = 6 [json_name = "tool_choice"];
+  Thinking thinking = 7 [json_name = "thinking"];
+}
+
+message CountTokensResponse {
+  // How many tokens the request would take as input.
+  int32 input_tokens = 1 [json_name = "input_tokens"];
+}
+
+service Anthropic {
+  // Send a conversation and get the model's next message.
+  rpc CreateMessage(MessageRequest) returns (MessageResponse);
+
+  // CreateMessage, with the message sent as it is generated: one message per
+  // event the API streams.
+  rpc StreamMessage(MessageRequest) returns (stream MessageEvent);
+
+  // Count the tokens a message request would take, without sending it.
+  rpc CountTokens(CountTokensRequest) returns (CountTokensResponse);
+}
+`
+
+// App is the anthropic app factory. Register it with the apps.Manager.
+type App struct{}
+
+func New() *App { return &App{} }
+
+func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
+	base, err := baseURL(parameters["endpoint"])
+	if err != nil {
+		return nil, err
+	}
+	log("Anthropic base URL: " + base.String())
+
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	token := strings.TrimSpace(parameters["token"])
+	if token == "" {
+		log("No token configured; requests will be sent without an x-api-key header")
+	}
+	version := strings.TrimSpace(parameters["version"])
+	if version == "" {
+		version = defaultVersion
+	}
+
+	if err := os.WriteFile(filepath.Join(protoDir, "anthropic.proto"), []byte(protoSource), 0o644); err != nil {
+		return nil, fmt.Errorf("writing proto: %w", err)
+	}
+
+	methods, err := compile(protoDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log("Generated service " + serviceTypeName + " with methods " + strings.Join(names, ", "))
+
+	return &apps.Opened{Instance: &instance{
+		base:    base,
+		token:   token,
+		version: version,
+		methods: methods,
+		client:  &http.Client{Timeout: 300 * time.Second},
+		retry:   policy,
+	}}, nil
+}
+
+// compile compiles the static proto and resolves the service's methods by name,
+// whose descriptors decode each request and encode its response.
+func compile(protoDir string) (map[string]protoreflect.MethodDescriptor, error) {
+	result, err := protoc.New(protoc.WithProtoPaths(protoDir), protoc.WithIncludeImports()).Compile("anthropic.proto")
+	if err != nil {
+		return nil, fmt.Errorf("compiling generated proto: %w", err)
+	}
+	files, err := protodesc.NewFiles(result.AsFileDescriptorSet())
+	if err != nil {
+		return nil, fmt.Errorf("building descriptors: %w", err)
+	}
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceTypeName))
+	if err != nil {
+		return nil, fmt.Errorf("finding service %s: %w", serviceTypeName, err)
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceTypeName)
+	}
+	methods := map[string]protoreflect.MethodDescriptor{}
+	for i := 0; i < service.Methods().Len(); i++ {
+		method := service.Methods().Get(i)
+		methods[string(method.Name())] = method
+	}
+	return methods, nil
+}
+
+// baseURL resolves the endpoint parameter into the base URL the API's /v1 paths
+// hang off: the default when it is empty, and the base of a URL given down to
+// /v1 or to the messages endpoint itself, which is how a gateway's is often
+// written down.
+func baseURL(endpoint string) (*url.URL, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		endpoint = defaultBaseURL
+	}
+	if err := requireHTTPScheme(endpoint); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", endpoint, err)
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	path = strings.TrimSuffix(path, "/messages")
+	u.Path = strings.TrimSuffix(path, "/v1")
+	u.RawPath = ""
+	return u, nil
+}
+
+// requireHTTPScheme rejects URLs that are not plain HTTP(S), so a base URL can't
+// make the app issue requests over other schemes (file://, etc.).
+func requireHTTPScheme(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q in %q (only http and https are allowed)", u.Scheme, rawURL)
+	}
+	return nil
+}
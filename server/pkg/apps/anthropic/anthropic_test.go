@@ -0,0 +1,347 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+)
+
+// standIn stands in for the Messages API: it answers each path with its canned
+// reply and records the last request it got.
+type standIn struct {
+	url     string
+	request *http.Request
+	body    map[string]any
+}
+
+func newStandIn(t *testing.T, status int, replies map[string]string) *standIn {
+	t.Helper()
+	s := &standIn{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		s.request, s.body = r, map[string]any{}
+		json.Unmarshal(b, &s.body)
+		reply, ok := replies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"type":"error","error":{"type":"not_found_error","message":"Not found"}}`)
+			return
+		}
+		if strings.HasPrefix(reply, "event:") {
+			w.Header().Set("Content-Type", "text/event-stream")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, reply)
+	}))
+	t.Cleanup(server.Close)
+	s.url = server.URL
+	return s
+}
+
+func openTestApp(t *testing.T, endpoint, token string) *instance {
+	t.Helper()
+	opened, err := New().Open(map[string]string{"endpoint": endpoint, "token": token}, t.TempDir(), func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return opened.Instance.(*instance)
+}
+
+// encodeRequest builds the protobuf request bytes of the named method from JSON.
+func encodeRequest(t *testing.T, in *instance, name, requestJSON string) []byte {
+	t.Helper()
+	msg := dynamicpb.NewMessage(in.methods[name].Input())
+	if err := protojson.Unmarshal([]byte(requestJSON), msg); err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	return b
+}
+
+// decodeMessage turns protobuf bytes of the given message type into JSON.
+func decodeMessage(t *testing.T, desc protoreflect.MessageDescriptor, body []byte) map[string]any {
+	t.Helper()
+	msg := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(body, msg); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	j, err := protojson.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal response json: %v", err)
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(j, &out); err != nil {
+		t.Fatalf("decode response json: %v", err)
+	}
+	return out
+}
+
+func TestCreateMessage(t *testing.T) {
+	server := newStandIn(t, http.StatusOK, map[string]string{
+		"/v1/messages": `{"id":"msg_1","type":"message","role":"assistant","model":"claude-test",
+			"content":[
+				{"type":"text","text":"Let me check. ","citations":null},
+				{"type":"tool_use","id":"toolu_2","name":"get_weather","input":{"city":"Oslo"}},
+				{"type":"text","text":"One moment."}
+			],
+			"stop_reason":"tool_use","stop_sequence":null,
+			"usage":{"input_tokens":30,"output_tokens":12}}`,
+	})
+	in := openTestApp(t, server.url, "sk-ant-test")
+
+	result, err := in.Invoke("anthropic.Anthropic/CreateMessage", encodeRequest(t, in, "CreateMessage", `{
+		"model": "claude-test",
+		"system": "Be brief",
+		"messages": [
+			{"role": "user", "text": "Weather in Paris?"},
+			{"role": "assistant", "content": [{"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {"city": "Paris"}}]},
+			{"role": "user", "content": [{"type": "tool_result", "tool_use_id": "toolu_1", "content": "Sunny"}]}
+		],
+		"user_prompt": "And in Oslo?",
+		"tools": [{"name": "get_weather", "input_schema": {"type": "object", "properties": {"city": {"type": "string"}}}}],
+		"tool_choice": {"type": "auto"}
+	}`), nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+
+	if got := server.request.Header.Get("X-Api-Key"); got != "sk-ant-test" {
+		t.Errorf("x-api-key = %q", got)
+	}
+	if got := server.request.Header.Get("Anthropic-Version"); got != defaultVersion {
+		t.Errorf("anthropic-version = %q, want %q", got, defaultVersion)
+	}
+	body := server.body
+	if body["system"] != "Be brief" || body["max_tokens"].(float64) != defaultMaxTokens {
+		t.Errorf("upstream body = %v", body)
+	}
+	messages := body["messages"].([]any)
+	if len(messages) != 4 {
+		t.Fatalf("messages = %v, want the conversation and the user prompt", messages)
+	}
+	if first := messages[0].(map[string]any); first["content"] != "Weather in Paris?" || first["text"] != nil {
+		t.Errorf("a text turn = %v, want its text as the content", first)
+	}
+	result0 := messages[2].(map[string]any)["content"].([]any)[0].(map[string]any)
+	if result0["tool_use_id"] != "toolu_1" || result0["content"] != "Sunny" {
+		t.Errorf("tool result = %v", result0)
+	}
+	if last := messages[3].(map[string]any); last["role"] != "user" || last["content"] != "And in Oslo?" {
+		t.Errorf("last turn = %v, want the user prompt", last)
+	}
+	if _, ok := body["user_prompt"]; ok {
+		t.Error("user_prompt should not be sent as is")
+	}
+
+	out := decodeMessage(t, in.methods["CreateMessage"].Output(), result.Body)
+	if out["text"] != "Let me check. One moment." {
+		t.Errorf("text = %v, want the text blocks joined", out["text"])
+	}
+	call := out["content"].([]any)[1].(map[string]any)
+	if call["name"] != "get_weather" || call["input"].(map[string]any)["city"] != "Oslo" {
+		t.Errorf("tool use = %v", call)
+	}
+	if out["stop_reason"] != "tool_use" || out["usage"].(map[string]any)["output_tokens"].(float64) != 12 {
+		t.Errorf("response = %v", out)
+	}
+}
+
+func TestCreateMessageUpstreamError(t *testing.T) {
+	server := newStandIn(t, http.StatusUnauthorized, map[string]string{
+		"/v1/messages": `{"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}`,
+	})
+	in := openTestApp(t, server.url+"/v1/messages", "wrong")
+
+	_, err := in.Invoke("anthropic.Anthropic/CreateMessage", encodeRequest(t, in, "CreateMessage", `{"model":"m","user_prompt":"Hi"}`), nil)
+	var upstream *apps.UpstreamError
+	if !errors.As(err, &upstream) {
+		t.Fatalf("err = %v, want an apps.UpstreamError", err)
+	}
+	if upstream.Status != 401 || upstream.Message != "invalid x-api-key" || upstream.URL != server.url+"/v1/messages" {
+		t.Errorf("upstream error = %+v", upstream)
+	}
+	if upstream.RequestHeaders["X-Api-Key"] != "wrong" || upstream.ResponseHeaders["Content-Type"] != "application/json" {
+		t.Errorf("headers = %v / %v, want the exchange surfaced", upstream.RequestHeaders, upstream.ResponseHeaders)
+	}
+}
+
+func TestCreateMessageNeedsAMessage(t *testing.T) {
+	in := openTestApp(t, "http://127.0.0.1:1", "")
+	if _, err := in.Invoke("anthropic.Anthropic/CreateMessage", encodeRequest(t, in, "CreateMessage", `{"model":"m"}`), nil); err == nil || !strings.Contains(err.Error(), "a message is required") {
+		t.Errorf("err = %v, want the missing message named", err)
+	}
+}
+
+func TestCountTokens(t *testing.T) {
+	server := newStandIn(t, http.StatusOK, map[string]string{
+		"/v1/messages/count_tokens": `{"input_tokens":14}`,
+	})
+	in := openTestApp(t, server.url+"/v1", "")
+
+	result, err := in.Invoke("anthropic.Anthropic/CountTokens", encodeRequest(t, in, "CountTokens", `{"model":"m","system":"Be brief","user_prompt":"Hi"}`), nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if _, ok := server.body["max_tokens"]; ok {
+		t.Error("token counting takes no max_tokens")
+	}
+	if out := decodeMessage(t, in.methods["CountTokens"].Output(), result.Body); out["input_tokens"].(float64) != 14 {
+		t.Errorf("response = %v", out)
+	}
+}
+
+const messageStream = `event: message_start
+data: {"type":"message_start","message":{"id":"msg_3","type":"message","role":"assistant","model":"claude-test","content":[],"stop_reason":null,"usage":{"input_tokens":9,"output_tokens":1}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+
+event: ping
+data: {"type":"ping"}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"lo"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: content_block_start
+data: {"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"lookup","input":{}}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"q\":"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":1}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"tool_use","stop_sequence":null},"usage":{"output_tokens":7}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`
+
+func TestStreamMessage(t *testing.T) {
+	server := newStandIn(t, http.StatusOK, map[string]string{"/v1/messages": messageStream})
+	in := openTestApp(t, server.url, "")
+	if !in.Streams("anthropic.Anthropic/StreamMessage") || in.Streams("anthropic.Anthropic/CreateMessage") {
+		t.Fatal("only StreamMessage should stream")
+	}
+
+	var events []map[string]any
+	result, err := in.InvokeStream(context.Background(), "anthropic.Anthropic/StreamMessage", encodeRequest(t, in, "StreamMessage", `{"model":"m","user_prompt":"Hi","max_tokens":50}`), nil, func(message []byte) error {
+		events = append(events, decodeMessage(t, in.methods["StreamMessage"].Output(), message))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InvokeStream: %v", err)
+	}
+	if server.body["stream"] != true || server.body["max_tokens"].(float64) != 50 {
+		t.Errorf("upstream body = %v", server.body)
+	}
+	if result.ResponseHeaders["Content-Type"] != "text/event-stream" {
+		t.Errorf("response headers = %v", result.ResponseHeaders)
+	}
+
+	var types []string
+	var text string
+	for _, event := range events {
+		types = append(types, event["type"].(string))
+		if t, ok := event["text"].(string); ok {
+			text += t
+		}
+	}
+	if got := strings.Join(types, ","); got != "message_start,content_block_start,content_block_delta,content_block_delta,content_block_stop,content_block_start,content_block_delta,content_block_stop,message_delta,message_stop" {
+		t.Errorf("events = %s, want every one but the ping", got)
+	}
+	if text != "Hello" {
+		t.Errorf("text = %q, want Hello", text)
+	}
+	if got := events[6]["delta"].(map[string]any)["partial_json"]; got != `{"q":` {
+		t.Errorf("partial_json = %v", got)
+	}
+	if usage := events[8]["usage"].(map[string]any); usage["output_tokens"].(float64) != 7 {
+		t.Errorf("usage = %v", usage)
+	}
+}
+
+func TestStreamMessageErrorEvent(t *testing.T) {
+	server := newStandIn(t, http.StatusOK, map[string]string{"/v1/messages": `event: message_start
+data: {"type":"message_start","message":{"id":"msg_4","role":"assistant","content":[]}}
+
+event: error
+data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}
+
+`})
+	in := openTestApp(t, server.url, "")
+
+	sent := 0
+	_, err := in.InvokeStream(context.Background(), "anthropic.Anthropic/StreamMessage", encodeRequest(t, in, "StreamMessage", `{"model":"m","user_prompt":"Hi"}`), nil, func([]byte) error {
+		sent++
+		return nil
+	})
+	var upstream *apps.UpstreamError
+	if !errors.As(err, &upstream) || upstream.Status != 529 || upstream.Message != "Overloaded" {
+		t.Fatalf("err = %v, want the overloaded error", err)
+	}
+	if sent != 1 {
+		t.Errorf("sent %d events, want the one before the error", sent)
+	}
+}
+
+func TestStreamMessageUpstreamError(t *testing.T) {
+	server := newStandIn(t, http.StatusTooManyRequests, map[string]string{
+		"/v1/messages": `{"type":"error","error":{"type":"rate_limit_error","message":"Slow down"}}`,
+	})
+	in := openTestApp(t, server.url, "")
+
+	_, err := in.InvokeStream(context.Background(), "anthropic.Anthropic/StreamMessage", encodeRequest(t, in, "StreamMessage", `{"model":"m","user_prompt":"Hi"}`), nil, func([]byte) error {
+		t.Error("nothing should be sent")
+		return nil
+	})
+	var upstream *apps.UpstreamError
+	if !errors.As(err, &upstream) || upstream.Status != 429 || upstream.Message != "Slow down" {
+		t.Fatalf("err = %v, want the rate limit", err)
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	for endpoint, want := range map[string]string{
+		"":                                       "https://api.anthropic.com/v1/messages",
+		"https://gateway.example.com/claude":     "https://gateway.example.com/claude/v1/messages",
+		"https://gateway.example.com/claude/v1/": "https://gateway.example.com/claude/v1/messages",
+		"https://gateway.example.com/claude/v1/messages": "https://gateway.example.com/claude/v1/messages",
+	} {
+		base, err := baseURL(endpoint)
+		if err != nil {
+			t.Fatalf("baseURL(%q): %v", endpoint, err)
+		}
+		if got := (&instance{base: base}).url("v1/messages"); got != want {
+			t.Errorf("messages URL for %q = %q, want %q", endpoint, got, want)
+		}
+	}
+	if _, err := baseURL("ftp://example.com"); err == nil {
+		t.Error("an ftp URL should be refused")
+	}
+}
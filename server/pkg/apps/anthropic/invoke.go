@@ -0,0 +1,235 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
+)
+
+// defaultMaxTokens is what a message request that leaves max_tokens at 0 asks
+// for. The API has no default of its own.
+const defaultMaxTokens = 1024
+
+// instance is a live opened Anthropic app. It is a gRPC app: a call arrives as
+// protobuf, is transcoded into a POST against the method's path under the base
+// URL, and the JSON response is shaped back into the protobuf response.
+type instance struct {
+	base    *url.URL
+	token   string
+	version string
+	methods map[string]protoreflect.MethodDescriptor
+	client  *http.Client
+	retry   retry.Policy
+}
+
+// route is where a unary method is sent, under the base URL, and what
+// convenience fields are filled in from the reply.
+type route struct {
+	path  string
+	reply func(respMsg *dynamicpb.Message)
+}
+
+var routes = map[string]route{
+	"CreateMessage": {path: "v1/messages", reply: setText},
+	"CountTokens":   {path: "v1/messages/count_tokens"},
+}
+
+func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	name := lastSegment(methodPath)
+	method, route := in.methods[name], routes[name]
+	if method == nil || route.path == "" {
+		return nil, fmt.Errorf("unknown method %q", methodPath)
+	}
+
+	reqMsg, err := decodeRequest(method, request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := buildRequestBody(reqMsg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, status, reqHeaders, respHeaders, err := in.call(route.path, body, headers)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, apps.NewUpstreamError(http.MethodPost, in.url(route.path), status, respBody).WithHeaders(reqHeaders, respHeaders)
+	}
+
+	respMsg := dynamicpb.NewMessage(method.Output())
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respBody, respMsg); err != nil {
+		return nil, fmt.Errorf("decoding response JSON: %w", err)
+	}
+	if route.reply != nil {
+		route.reply(respMsg)
+	}
+	out, err := proto.Marshal(respMsg)
+	if err != nil {
+		return nil, err
+	}
+	return &apps.InvokeResult{Body: out, RequestHeaders: reqHeaders, ResponseHeaders: respHeaders}, nil
+}
+
+// decodeRequest decodes a call's protobuf request into the method's input.
+func decodeRequest(method protoreflect.MethodDescriptor, request []byte) (*dynamicpb.Message, error) {
+	reqMsg := dynamicpb.NewMessage(method.Input())
+	if len(request) > 0 {
+		if err := proto.Unmarshal(request, reqMsg); err != nil {
+			return nil, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	return reqMsg, nil
+}
+
+// buildRequestBody turns a decoded message or token counting request into the
+// JSON body the API expects. The request's fields already carry the API's
+// names, so it is the request as JSON with each turn's text folded into its
+// content, the user prompt added as the last turn, and max_tokens given its
+// default where the request has one. Unset optional fields are left out, so the
+// API's defaults apply.
+func buildRequestBody(reqMsg *dynamicpb.Message, stream bool) ([]byte, error) {
+	encoded, err := protojson.Marshal(reqMsg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	payload := map[string]any{}
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+
+	model, _ := payload["model"].(string)
+	if strings.TrimSpace(model) == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	payload["model"] = strings.TrimSpace(model)
+
+	messages, _ := payload["messages"].([]any)
+	for _, message := range messages {
+		message, ok := message.(map[string]any)
+		if !ok {
+			continue
+		}
+		text, _ := message["text"].(string)
+		blocks, _ := message["content"].([]any)
+		delete(message, "text")
+		switch {
+		case len(blocks) == 0:
+			// A turn that is text alone is sent as the API's shorthand for one.
+			message["content"] = text
+		case text != "":
+			message["content"] = append([]any{map[string]any{"type": "text", "text": text}}, blocks...)
+		}
+	}
+	if prompt, _ := payload["user_prompt"].(string); prompt != "" {
+		messages = append(messages, map[string]any{"role": "user", "content": prompt})
+	}
+	delete(payload, "user_prompt")
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("a message is required: set messages or user_prompt")
+	}
+	payload["messages"] = messages
+
+	if reqMsg.Descriptor().Fields().ByName("max_tokens") != nil && payload["max_tokens"] == nil {
+		payload["max_tokens"] = defaultMaxTokens
+	}
+	if stream {
+		payload["stream"] = true
+	}
+	return json.Marshal(payload)
+}
+
+// call POSTs the request body to the API path under the base URL, returning the
+// raw response body, HTTP status code, and the headers exchanged with the
+// upstream. An error is returned only for transport failures (the upstream
+// could not be reached); HTTP error responses are returned with their status.
+func (in *instance) call(path string, body []byte, headers map[string]string) ([]byte, int, map[string]string, map[string]string, error) {
+	resp, reqHeaders, err := in.send(context.Background(), in.client, path, body, headers, "application/json")
+	if err != nil {
+		return nil, 0, reqHeaders, nil, err
+	}
+	defer resp.Body.Close()
+	respHeaders := apps.SurfaceHeaders(resp.Header)
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, resp.StatusCode, reqHeaders, respHeaders, fmt.Errorf("reading response: %w", err)
+	}
+	return respBody, resp.StatusCode, reqHeaders, respHeaders, nil
+}
+
+// send POSTs the request body to the API path under the base URL with client
+// and returns the response unread, along with the request headers sent.
+func (in *instance) send(ctx context.Context, client *http.Client, path string, body []byte, headers map[string]string, accept string) (*http.Response, map[string]string, error) {
+	endpoint := in.url(path)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("building request: %w", err)
+	}
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Accept", accept)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Anthropic-Version", in.version)
+	if in.token != "" {
+		httpReq.Header.Set("X-Api-Key", in.token)
+	}
+	reqHeaders := apps.SurfaceHeaders(httpReq.Header)
+
+	resp, attempts, err := in.retry.Send(client.Do, httpReq)
+	reqHeaders = attempts.Record(reqHeaders)
+	if err != nil {
+		return nil, reqHeaders, fmt.Errorf("calling %s: %w", endpoint, err)
+	}
+	return resp, reqHeaders, nil
+}
+
+// url is the full URL of the API path under the base.
+func (in *instance) url(path string) string {
+	u := *in.base
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + path
+	return u.String()
+}
+
+// setText joins the text of every "text" block of the message into its
+// top-level convenience "text" field.
+func setText(respMsg *dynamicpb.Message) {
+	fields := respMsg.Descriptor().Fields()
+	contentFd, textFd := fields.ByName("content"), fields.ByName("text")
+	if contentFd == nil || textFd == nil {
+		return
+	}
+	blockFields := contentFd.Message().Fields()
+
+	var text strings.Builder
+	content := respMsg.Get(contentFd).List()
+	for i := 0; i < content.Len(); i++ {
+		block := content.Get(i).Message()
+		if block.Get(blockFields.ByName("type")).String() == "text" {
+			text.WriteString(block.Get(blockFields.ByName("text")).String())
+		}
+	}
+	respMsg.Set(textFd, protoreflect.ValueOfString(text.String()))
+}
+
+func lastSegment(s string) string {
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
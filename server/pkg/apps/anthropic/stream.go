@@ -0,0 +1,176 @@
+package anthropic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// streamPath is where StreamMessage is sent, under the base URL.
+const streamPath = "v1/messages"
+
+func (in *instance) Streams(methodPath string) bool {
+	method := in.methods[lastSegment(methodPath)]
+	return method != nil && method.IsStreamingServer()
+}
+
+// InvokeStream asks the API to stream the message and sends each event of the
+// server-sent event stream on as a MessageEvent, until "message_stop". The
+// keep-alive pings stay behind. A failure - an HTTP error before the stream,
+// or an "error" event during it - ends the stream as an apps.UpstreamError.
+func (in *instance) InvokeStream(ctx context.Context, methodPath string, request []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error) {
+	method := in.methods[lastSegment(methodPath)]
+	if method == nil || !method.IsStreamingServer() {
+		return nil, fmt.Errorf("unknown streaming method %q", methodPath)
+	}
+
+	reqMsg, err := decodeRequest(method, request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := buildRequestBody(reqMsg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// The message takes as long as the model writes, so only ctx bounds it.
+	client := *in.client
+	client.Timeout = 0
+	resp, reqHeaders, err := in.send(ctx, &client, streamPath, body, headers, "text/event-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respHeaders := apps.SurfaceHeaders(resp.Header)
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+		return nil, apps.NewUpstreamError(http.MethodPost, in.url(streamPath), resp.StatusCode, respBody).WithHeaders(reqHeaders, respHeaders)
+	}
+
+	var failure error
+	err = readEvents(resp.Body, func(data []byte) bool {
+		var event struct {
+			Type  string `json:"type"`
+			Error struct {
+				Type string `json:"type"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(data, &event); err != nil {
+			failure = fmt.Errorf("decoding stream event: %w", err)
+			return true
+		}
+		switch event.Type {
+		case "ping":
+			return false
+		case "error":
+			failure = apps.NewUpstreamError(http.MethodPost, in.url(streamPath), errorStatus(event.Error.Type), data).WithHeaders(reqHeaders, respHeaders)
+			return true
+		}
+
+		message := dynamicpb.NewMessage(method.Output())
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+			failure = fmt.Errorf("decoding stream event: %w", err)
+			return true
+		}
+		setDeltaText(message)
+		out, err := proto.Marshal(message)
+		if err == nil {
+			err = send(out)
+		}
+		if err != nil {
+			failure = err
+			return true
+		}
+		return event.Type == "message_stop"
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if failure != nil {
+		return nil, failure
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading stream: %w", err)
+	}
+	return &apps.InvokeResult{RequestHeaders: reqHeaders, ResponseHeaders: respHeaders}, nil
+}
+
+// errorStatus is the HTTP status the API answers an error of the given type
+// with, for an error that arrives in the stream after the 200 it began with.
+func errorStatus(errorType string) int {
+	switch errorType {
+	case "invalid_request_error":
+		return http.StatusBadRequest
+	case "authentication_error":
+		return http.StatusUnauthorized
+	case "permission_error":
+		return http.StatusForbidden
+	case "not_found_error":
+		return http.StatusNotFound
+	case "request_too_large":
+		return http.StatusRequestEntityTooLarge
+	case "rate_limit_error":
+		return http.StatusTooManyRequests
+	case "overloaded_error":
+		return 529
+	}
+	return http.StatusInternalServerError
+}
+
+// setDeltaText copies a text delta's text into the event's top-level
+// convenience "text" field.
+func setDeltaText(event *dynamicpb.Message) {
+	fields := event.Descriptor().Fields()
+	deltaFd, textFd := fields.ByName("delta"), fields.ByName("text")
+	if deltaFd == nil || textFd == nil || !event.Has(deltaFd) {
+		return
+	}
+	delta := event.Get(deltaFd).Message()
+	event.Set(textFd, protoreflect.ValueOfString(delta.Get(deltaFd.Message().Fields().ByName("text")).String()))
+}
+
+// readEvents reads a server-sent event stream, handing the data of each event
+// to event, which returns true once it has seen the last one it wants.
+func readEvents(stream io.Reader, event func(data []byte) bool) error {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+
+	var current []string
+	flush := func() bool {
+		if len(current) == 0 {
+			return false
+		}
+		data := []byte(strings.Join(current, "\n"))
+		current = nil
+		return event(data)
+	}
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		switch {
+		case line == "":
+			if flush() {
+				return nil
+			}
+		case strings.HasPrefix(line, ":"):
+			// A comment, used as a keep-alive.
+		case strings.HasPrefix(line, "data:"):
+			current = append(current, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	flush()
+	return nil
+}
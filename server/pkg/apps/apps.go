@@ -8,8 +8,10 @@
 package apps
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -28,11 +30,44 @@ const TargetScheme = "kaja-app"
 // base64 kaja's to do rather than yours.
 const AppHeader = "X-Kaja-App"
 
+// EndpointHeader is the reserved header a call pinned to one of its app's endpoints
+// carries, naming it. Like AppHeader it is taken out before the call goes anywhere.
+const EndpointHeader = "X-Kaja-Endpoint"
+
+// AnswerHeader is the reserved header a call carries when it answers a Question the
+// app asked on an earlier attempt at it. Its value is the Answer as JSON. The app
+// takes it out; it never reaches the app's upstream.
+const AnswerHeader = "X-Kaja-Answer"
+
 // TakeAppName removes the reserved header and returns the app it named. Header case
 // is whatever the transport made of it, so it is matched without regard to case.
 func TakeAppName(headers map[string]string) string {
+	return takeHeader(headers, AppHeader)
+}
+
+// TakeEndpoint removes the reserved header and returns the endpoint it pinned the
+// call to, or empty for a call that leaves the choice to the app.
+func TakeEndpoint(headers map[string]string) string {
+	return takeHeader(headers, EndpointHeader)
+}
+
+// TakeAnswer removes the reserved header and returns the answer it carries, or nil
+// for a call that answers nothing.
+func TakeAnswer(headers map[string]string) (*Answer, error) {
+	value := takeHeader(headers, AnswerHeader)
+	if value == "" {
+		return nil, nil
+	}
+	var answer Answer
+	if err := json.Unmarshal([]byte(value), &answer); err != nil || answer.ID == "" {
+		return nil, fmt.Errorf("the %s header is not an answer", AnswerHeader)
+	}
+	return &answer, nil
+}
+
+func takeHeader(headers map[string]string, reserved string) string {
 	for name, value := range headers {
-		if strings.EqualFold(name, AppHeader) {
+		if strings.EqualFold(name, reserved) {
 			delete(headers, name)
 			return value
 		}
@@ -104,6 +139,20 @@ type Instance interface {
 	Invoke(methodPath string, request []byte, headers map[string]string) (*InvokeResult, error)
 }
 
+// StreamingInstance is an Instance some of whose methods are server-streaming:
+// they send any number of messages before they finish.
+type StreamingInstance interface {
+	Instance
+	// Streams reports whether the method at methodPath is server-streaming, and so
+	// is called with InvokeStream rather than Invoke.
+	Streams(methodPath string) bool
+	// InvokeStream runs a server-streaming method, handing each proto response
+	// message to send as it comes. The result carries no Body, only the headers
+	// exchanged; an error from send means nobody is listening any more. ctx is done
+	// when the caller goes away.
+	InvokeStream(ctx context.Context, methodPath string, request []byte, headers map[string]string, send func(message []byte) error) (*InvokeResult, error)
+}
+
 // InvokeResult is the outcome of a single Invoke. Body is the proto3-JSON response.
 // RequestHeaders/ResponseHeaders are what the app actually exchanged with its
 // upstream, which the transports surface to the Headers view; an in-process app with
@@ -112,6 +161,27 @@ type InvokeResult struct {
 	Body            []byte
 	RequestHeaders  map[string]string
 	ResponseHeaders map[string]string
+	// Question, when set, is what the app needs answered before it can finish the
+	// call, and Body is empty. The caller asks whoever is running the call and
+	// makes it again with the Answer under AnswerHeader.
+	Question *Question
+}
+
+// Question is something an upstream asked mid-call that only the person running
+// it can answer: an MCP server's elicitation. Schema is the JSON Schema of the
+// answer's content - an object whose properties are each one field to fill in.
+type Question struct {
+	ID      string          `json:"id"`
+	Message string          `json:"message"`
+	Schema  json.RawMessage `json:"schema,omitempty"`
+}
+
+// Answer is what the person made of a Question: "accept" with the content they
+// filled in, "decline", or "cancel".
+type Answer struct {
+	ID      string          `json:"id"`
+	Action  string          `json:"action"`
+	Content json.RawMessage `json:"content,omitempty"`
 }
 
 // Manager owns the registry of app types and the set of live instances.
@@ -173,6 +243,40 @@ func IsAppTarget(target string) bool {
 
 // Invoke routes a method call to the instance referenced by target.
 func (m *Manager) Invoke(target string, methodPath string, request []byte, headers map[string]string) (*InvokeResult, error) {
+	instance, err := m.instance(target)
+	if err != nil {
+		return nil, err
+	}
+	return instance.Invoke(methodPath, request, headers)
+}
+
+// Streams reports whether the method at methodPath of the instance referenced by
+// target is server-streaming. An unknown target streams nothing; Invoke is what
+// reports it.
+func (m *Manager) Streams(target string, methodPath string) bool {
+	instance, err := m.instance(target)
+	if err != nil {
+		return false
+	}
+	streaming, ok := instance.(StreamingInstance)
+	return ok && streaming.Streams(methodPath)
+}
+
+// InvokeStream routes a server-streaming method call to the instance referenced
+// by target.
+func (m *Manager) InvokeStream(ctx context.Context, target string, methodPath string, request []byte, headers map[string]string, send func(message []byte) error) (*InvokeResult, error) {
+	instance, err := m.instance(target)
+	if err != nil {
+		return nil, err
+	}
+	streaming, ok := instance.(StreamingInstance)
+	if !ok || !streaming.Streams(methodPath) {
+		return nil, fmt.Errorf("%s is not a server-streaming method", methodPath)
+	}
+	return streaming.InvokeStream(ctx, methodPath, request, headers, send)
+}
+
+func (m *Manager) instance(target string) (Instance, error) {
 	u, err := url.Parse(target)
 	if err != nil {
 		return nil, fmt.Errorf("invalid app target %q: %w", target, err)
@@ -185,8 +289,7 @@ func (m *Manager) Invoke(target string, methodPath string, request []byte, heade
 	if !ok {
 		return nil, fmt.Errorf("app instance %q not found (the app may need to be recompiled)", id)
 	}
-
-	return instance.Invoke(methodPath, request, headers)
+	return instance, nil
 }
 
 func newID() (string, error) {
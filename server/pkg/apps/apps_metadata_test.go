@@ -28,6 +28,19 @@ func TestTakeAppName(t *testing.T) {
 	}
 }
 
+func TestTakeEndpoint(t *testing.T) {
+	headers := map[string]string{"x-kaja-endpoint": "dns:seating-2:9000", "X-Kaja-App": "seating"}
+	if endpoint := TakeEndpoint(headers); endpoint != "dns:seating-2:9000" {
+		t.Errorf("TakeEndpoint() = %q, want %q", endpoint, "dns:seating-2:9000")
+	}
+	if len(headers) != 1 || headers["X-Kaja-App"] != "seating" {
+		t.Errorf("headers = %v, want only the endpoint taken", headers)
+	}
+	if endpoint := TakeEndpoint(headers); endpoint != "" {
+		t.Errorf("TakeEndpoint() = %q, want empty for a call that pins nothing", endpoint)
+	}
+}
+
 func TestMergeMetadata(t *testing.T) {
 	// A header written out by hand is the more specific instruction, whatever case
 	// it is written in.
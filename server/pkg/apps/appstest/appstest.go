@@ -0,0 +1,52 @@
+// Package appstest calls an in-process app from a test the way a client does:
+// a request written as proto3 JSON is encoded to the method's request message,
+// and what the app answers is decoded back to plain JSON values a test can pick
+// apart.
+package appstest
+
+import (
+	"encoding/json"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// EncodeRequest encodes requestJSON, proto3 JSON, as a message of input.
+func EncodeRequest(input protoreflect.MessageDescriptor, requestJSON string) ([]byte, error) {
+	request := dynamicpb.NewMessage(input)
+	if err := protojson.Unmarshal([]byte(requestJSON), request); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(request)
+}
+
+// DecodeResponse decodes body, a message of output, to plain JSON values.
+func DecodeResponse(output protoreflect.MessageDescriptor, body []byte) (map[string]any, error) {
+	response := dynamicpb.NewMessage(output)
+	if err := proto.Unmarshal(body, response); err != nil {
+		return nil, err
+	}
+	encoded, err := protojson.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	decoded := map[string]any{}
+	return decoded, json.Unmarshal(encoded, &decoded)
+}
+
+// Call invokes the method at path, whose request and response messages are
+// input and output, with requestJSON and headers, and returns the response.
+func Call(instance apps.Instance, path string, input, output protoreflect.MessageDescriptor, requestJSON string, headers map[string]string) (map[string]any, error) {
+	body, err := EncodeRequest(input, requestJSON)
+	if err != nil {
+		return nil, err
+	}
+	result, err := instance.Invoke(path, body, headers)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeResponse(output, result.Body)
+}
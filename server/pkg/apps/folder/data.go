@@ -0,0 +1,530 @@
+package folder
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func (in *instance) readCsv(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	delimiter, err := csvDelimiter(getString(req, "delimiter"))
+	if err != nil {
+		return err
+	}
+	data, err := in.readAll(file)
+	if err != nil {
+		return err
+	}
+
+	records, err := parseCsv(data, delimiter)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+	if len(records) == 0 {
+		setString(resp, "sha256", hash(data))
+		return nil
+	}
+	columns := records[0]
+	for i, column := range columns {
+		if slices.Contains(columns[:i], column) {
+			return fmt.Errorf("reading %s: column %q appears twice", file, column)
+		}
+	}
+
+	rowsFd := resp.Descriptor().Fields().ByName("rows")
+	rows := resp.Mutable(rowsFd).List()
+	for _, record := range records[1:] {
+		row := rows.NewElement().Message()
+		values := row.Mutable(row.Descriptor().Fields().ByName("values")).Map()
+		for i, cell := range record {
+			values.Set(protoreflect.ValueOfString(columns[i]).MapKey(), protoreflect.ValueOfString(cell))
+		}
+		rows.Append(protoreflect.ValueOfMessage(row))
+	}
+	setStringList(resp, "columns", columns)
+	setString(resp, "sha256", hash(data))
+	return nil
+}
+
+// writeCsv writes rows under a header. Replacing the file goes through a
+// temporary file as WriteFile does; appending adds to the end, under the header
+// the file already has.
+func (in *instance) writeCsv(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	delimiter, err := csvDelimiter(getString(req, "delimiter"))
+	if err != nil {
+		return err
+	}
+	rows := csvRows(req)
+	columns := getStringList(req, "columns")
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	header := true
+	var existing []byte
+	if getBool(req, "append") {
+		existing, err = root.ReadFile(file)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("reading %s: %w", file, err)
+		}
+		if records, err := parseCsv(existing, delimiter); err != nil {
+			return fmt.Errorf("reading %s: %w", file, err)
+		} else if len(records) > 0 {
+			if len(columns) > 0 && !slices.Equal(columns, records[0]) {
+				return fmt.Errorf("columns %v are not those of %s: %v (leave columns empty to use them)", columns, file, records[0])
+			}
+			columns, header = records[0], false
+		}
+	}
+	if len(columns) == 0 {
+		for _, row := range rows {
+			for name := range row {
+				if !slices.Contains(columns, name) {
+					columns = append(columns, name)
+				}
+			}
+		}
+		slices.Sort(columns)
+	}
+
+	var out bytes.Buffer
+	if len(existing) > 0 && existing[len(existing)-1] != '\n' {
+		out.WriteByte('\n')
+	}
+	w := csv.NewWriter(&out)
+	w.Comma = delimiter
+	if header {
+		w.Write(columns)
+	}
+	for i, row := range rows {
+		record := make([]string, len(columns))
+		for name, cell := range row {
+			at := slices.Index(columns, name)
+			if at < 0 {
+				return fmt.Errorf("row %d has %q, which is not one of the columns %v", i+1, name, columns)
+			}
+			record[at] = cell
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+
+	if getBool(req, "append") {
+		err = appendToFile(root, file, out.Bytes())
+	} else {
+		err = replaceFile(root, file, out.Bytes(), getString(req, "expected_sha256"))
+	}
+	if err != nil {
+		return err
+	}
+	return in.setFileResponse(resp, root, file)
+}
+
+func (in *instance) readJsonl(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	data, err := in.readAll(file)
+	if err != nil {
+		return err
+	}
+
+	recordsFd := resp.Descriptor().Fields().ByName("records")
+	records := resp.Mutable(recordsFd).List()
+	line := 0
+	for text := range strings.Lines(string(data)) {
+		line++
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		record := records.NewElement()
+		if err := protojson.Unmarshal([]byte(text), record.Message().Interface()); err != nil {
+			return fmt.Errorf("reading %s: line %d is not JSON: %w", file, line, err)
+		}
+		records.Append(record)
+	}
+	return nil
+}
+
+func (in *instance) appendJsonl(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	var out bytes.Buffer
+	// A file whose last line lacks its newline would otherwise run into the
+	// first record.
+	if !endsLine(root, file) {
+		out.WriteByte('\n')
+	}
+	records := req.Get(req.Descriptor().Fields().ByName("records")).List()
+	for i := 0; i < records.Len(); i++ {
+		record, err := toJSON(records.Get(i).Message())
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+		line, err := encodeJSON(record, false)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+		out.Write(line)
+	}
+	if err := appendToFile(root, file, out.Bytes()); err != nil {
+		return err
+	}
+	return in.setFileResponse(resp, root, file)
+}
+
+func (in *instance) readJson(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	pointer, err := parsePointer(getString(req, "pointer"))
+	if err != nil {
+		return err
+	}
+	data, err := in.readAll(file)
+	if err != nil {
+		return err
+	}
+	document, err := decodeJSON(data)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	value, found := lookup(document, pointer)
+	valueFd := resp.Descriptor().Fields().ByName("value")
+	if err := fromJSON(value, resp.Mutable(valueFd).Message()); err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+	setBool(resp, "found", found)
+	setString(resp, "sha256", hash(data))
+	return nil
+}
+
+// mergeJson reads the document, puts the value in it, and writes it back through
+// a temporary file as WriteFile does. The document is written indented, with its
+// object keys sorted.
+func (in *instance) mergeJson(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	pointer, err := parsePointer(getString(req, "pointer"))
+	if err != nil {
+		return err
+	}
+	valueFd := req.Descriptor().Fields().ByName("value")
+	if !req.Has(valueFd) {
+		return fmt.Errorf("missing value (set it to null to remove what pointer names)")
+	}
+	value, err := toJSON(req.Get(valueFd).Message())
+	if err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	var document any
+	data, err := root.ReadFile(file)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("reading %s: %w", file, err)
+	case len(bytes.TrimSpace(data)) > 0:
+		if document, err = decodeJSON(data); err != nil {
+			return fmt.Errorf("reading %s: %w", file, err)
+		}
+	}
+
+	document, err = put(document, pointer, value, getBool(req, "replace"))
+	if err != nil {
+		return fmt.Errorf("merging into %s: %w", file, err)
+	}
+	out, err := encodeJSON(document, true)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+	if err := replaceFile(root, file, out, getString(req, "expected_sha256")); err != nil {
+		return err
+	}
+	return in.setFileResponse(resp, root, file)
+}
+
+// readAll reads the whole of a file of the folder.
+func (in *instance) readAll(file string) ([]byte, error) {
+	f, err := in.openFile(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	return data, nil
+}
+
+// endsLine reports whether what is appended to file starts on a line of its own:
+// the file is missing, empty, or ends in a newline.
+func endsLine(root *os.Root, file string) bool {
+	f, err := root.Open(file)
+	if err != nil {
+		return true
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return true
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return true
+	}
+	return last[0] == '\n'
+}
+
+func csvDelimiter(delimiter string) (rune, error) {
+	if delimiter == "" {
+		return ',', nil
+	}
+	r, size := utf8.DecodeRuneInString(delimiter)
+	if size != len(delimiter) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("delimiter must be one character other than a quote or newline, got %q", delimiter)
+	}
+	return r, nil
+}
+
+// parseCsv reads every record of a CSV file, dropping the byte order mark a
+// spreadsheet may start it with.
+func parseCsv(data []byte, delimiter rune) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.Comma = delimiter
+	return r.ReadAll()
+}
+
+// csvRows is the rows of a WriteCsvRequest as plain maps.
+func csvRows(req *dynamicpb.Message) []map[string]string {
+	list := req.Get(req.Descriptor().Fields().ByName("rows")).List()
+	rows := make([]map[string]string, list.Len())
+	for i := range rows {
+		row := list.Get(i).Message()
+		rows[i] = map[string]string{}
+		row.Get(row.Descriptor().Fields().ByName("values")).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			rows[i][k.String()] = v.String()
+			return true
+		})
+	}
+	return rows
+}
+
+// toJSON turns a google.protobuf.Value into the value encoding/json would
+// decode its JSON to, numbers kept as json.Number.
+func toJSON(m protoreflect.Message) (any, error) {
+	data, err := protojson.Marshal(m.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(data)
+}
+
+// fromJSON sets a google.protobuf.Value to a decoded JSON value.
+func fromJSON(v any, m protoreflect.Message) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, m.Interface())
+}
+
+// decodeJSON decodes a JSON document, keeping numbers as json.Number so that
+// writing them back does not round them through a float64.
+func decodeJSON(data []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("not JSON: %w", err)
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("not JSON: more follows the document")
+	}
+	return v, nil
+}
+
+// encodeJSON writes v as a line of JSON, or as an indented document.
+func encodeJSON(v any, indent bool) ([]byte, error) {
+	var out bytes.Buffer
+	e := json.NewEncoder(&out)
+	e.SetEscapeHTML(false)
+	if indent {
+		e.SetIndent("", "  ")
+	}
+	if err := e.Encode(v); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// parsePointer splits a JSON Pointer into its reference tokens, unescaped.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("pointer must be empty or start with \"/\", got %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// lookup finds what the pointer's tokens name in document.
+func lookup(document any, tokens []string) (any, bool) {
+	for _, token := range tokens {
+		switch node := document.(type) {
+		case map[string]any:
+			child, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			document = child
+		case []any:
+			i, ok := arrayIndex(token, len(node))
+			if !ok || i == len(node) {
+				return nil, false
+			}
+			document = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return document, true
+}
+
+// put returns document with value merged into, or with replace put in place of,
+// what the pointer's tokens name. Missing objects along the way are created; an
+// array takes its length or "-" as the index of a new last element.
+func put(document any, tokens []string, value any, replace bool) (any, error) {
+	if len(tokens) == 0 {
+		if replace {
+			return value, nil
+		}
+		return mergePatch(document, value), nil
+	}
+	token, rest := tokens[0], tokens[1:]
+	switch node := document.(type) {
+	case nil:
+		child, err := put(nil, rest, value, replace)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{token: child}, nil
+	case map[string]any:
+		child, err := put(node[token], rest, value, replace)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil && len(rest) == 0 && !replace {
+			// As within a merge patch, null removes the key.
+			delete(node, token)
+		} else {
+			node[token] = child
+		}
+		return node, nil
+	case []any:
+		i, ok := arrayIndex(token, len(node))
+		if !ok {
+			return nil, fmt.Errorf("%q is not an index of an array of %d", token, len(node))
+		}
+		var current any
+		if i < len(node) {
+			current = node[i]
+		}
+		child, err := put(current, rest, value, replace)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(node) {
+			return append(node, child), nil
+		}
+		node[i] = child
+		return node, nil
+	}
+	return nil, fmt.Errorf("%q cannot be looked up in %v, which is neither an object nor an array", token, document)
+}
+
+// arrayIndex is the element of an array of length n a pointer token names: an
+// index up to n, or "-" for n, the one past the end.
+func arrayIndex(token string, n int) (int, bool) {
+	if token == "-" {
+		return n, true
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > n {
+		return 0, false
+	}
+	return i, true
+}
+
+// mergePatch applies patch to target as RFC 7386 has it.
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergePatch(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
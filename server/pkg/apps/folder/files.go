@@ -0,0 +1,320 @@
+package folder
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// writeFile replaces a file's contents. The new content goes to a temporary file
+// beside it that is then renamed over it, so a reader sees the old file or the new
+// one and never half of either.
+func (in *instance) writeFile(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	if err := replaceFile(root, file, content(req), getString(req, "expected_sha256")); err != nil {
+		return err
+	}
+	return in.setFileResponse(resp, root, file)
+}
+
+// replaceFile puts content in place of what file holds, if it still hashes to
+// expected when that is set, creating the folders along the way. The file keeps
+// its permissions. A symbolic link is refused rather than replaced by a file or
+// written through to whatever it points at.
+//
+// expected is checked again just before the rename, but it is a check and not a
+// lock: a write elsewhere in the moment between the two is lost.
+func replaceFile(root *os.Root, file string, content []byte, expected string) error {
+	mode := fs.FileMode(0o644)
+	if info, err := root.Lstat(file); err == nil {
+		switch {
+		case info.IsDir():
+			return fmt.Errorf("%s is a folder, not a file", file)
+		case info.Mode()&fs.ModeSymlink != 0:
+			return fmt.Errorf("%s is a symbolic link; write to the file it points at instead", file)
+		}
+		mode = info.Mode().Perm()
+	}
+	if err := checkUnchanged(root, file, expected); err != nil {
+		return err
+	}
+	if err := makeParents(root, file); err != nil {
+		return err
+	}
+
+	temporary, err := writeTemporary(root, file, content, mode)
+	if err != nil {
+		return err
+	}
+	// The content took a while to write out; the file is hashed again so the
+	// check is as close to the rename as it can be.
+	if err := checkUnchanged(root, file, expected); err != nil {
+		root.Remove(temporary)
+		return err
+	}
+	if err := root.Rename(temporary, file); err != nil {
+		root.Remove(temporary)
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+	return nil
+}
+
+// checkUnchanged refuses file when expected is set and file no longer hashes to
+// it.
+func checkUnchanged(root *os.Root, file string, expected string) error {
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	if expected == "" {
+		return nil
+	}
+	current, err := hashFile(root, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s no longer exists, so it is not the file expected_sha256 names", file)
+	}
+	if err != nil {
+		return err
+	}
+	if current != expected {
+		return fmt.Errorf("%s changed since it was read: its SHA-256 is %s, not %s (read it again)", file, current, expected)
+	}
+	return nil
+}
+
+// appendToFile adds content to the end of file, creating it and the folders
+// along the way if need be.
+func appendToFile(root *os.Root, file string, content []byte) error {
+	if err := makeParents(root, file); err != nil {
+		return err
+	}
+	f, err := root.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", file, err)
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("writing to %s: %w", file, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing to %s: %w", file, err)
+	}
+	return nil
+}
+
+// writeTemporary writes content to a new file beside file, named so nothing else
+// picks it, with mode's permissions, and returns its path.
+func writeTemporary(root *os.Root, file string, content []byte, mode fs.FileMode) (string, error) {
+	suffix := make([]byte, 6)
+	rand.Read(suffix)
+	temporary := path.Join(path.Dir(file), "."+path.Base(file)+"."+hex.EncodeToString(suffix)+".tmp")
+
+	f, err := root.OpenFile(temporary, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("writing %s: %w", file, err)
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		root.Remove(temporary)
+		return "", fmt.Errorf("writing %s: %w", file, err)
+	}
+	if err := f.Close(); err != nil {
+		root.Remove(temporary)
+		return "", fmt.Errorf("writing %s: %w", file, err)
+	}
+	// Creating the file applied the umask; the mode is set outright.
+	if err := root.Chmod(temporary, mode); err != nil {
+		root.Remove(temporary)
+		return "", fmt.Errorf("writing %s: %w", file, err)
+	}
+	return temporary, nil
+}
+
+// isTemporary reports whether name is that of a file writeTemporary made.
+func isTemporary(name string) bool {
+	base := path.Base(name)
+	if !strings.HasPrefix(base, ".") || !strings.HasSuffix(base, ".tmp") {
+		return false
+	}
+	parts := strings.Split(strings.TrimSuffix(base, ".tmp"), ".")
+	_, err := hex.DecodeString(parts[len(parts)-1])
+	return len(parts) >= 3 && len(parts[len(parts)-1]) == 12 && err == nil
+}
+
+func (in *instance) deleteFile(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	info, err := root.Lstat(file)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %w", file, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a folder, not a file (delete it with DeleteFolder)", file)
+	}
+	if err := root.Remove(file); err != nil {
+		return fmt.Errorf("deleting %s: %w", file, err)
+	}
+	setString(resp, "deleted", file)
+	setString(resp, "path", in.absolute(file))
+	return nil
+}
+
+func (in *instance) moveFile(req, resp *dynamicpb.Message) error {
+	from, err := resolve(getString(req, "from"))
+	if err != nil {
+		return err
+	}
+	to, err := resolve(getString(req, "to"))
+	if err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	info, err := root.Lstat(from)
+	if err != nil {
+		return fmt.Errorf("moving %s: %w", from, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a folder, not a file", from)
+	}
+	if from == to {
+		return in.setFileResponse(resp, root, to)
+	}
+	if info, err := root.Stat(to); err == nil {
+		// As with CreateFile, a folder in the way is reported whatever overwrite
+		// says.
+		if info.IsDir() {
+			return fmt.Errorf("%s is a folder, not a file", to)
+		}
+		if !getBool(req, "overwrite") {
+			return fmt.Errorf("%s already exists (set overwrite to replace it)", to)
+		}
+	}
+	if err := makeParents(root, to); err != nil {
+		return err
+	}
+	if err := root.Rename(from, to); err != nil {
+		return fmt.Errorf("moving %s to %s: %w", from, to, err)
+	}
+	return in.setFileResponse(resp, root, to)
+}
+
+func (in *instance) deleteFolder(req, resp *dynamicpb.Message) error {
+	folder, err := resolveFolder(getString(req, "folder"))
+	if err != nil {
+		return err
+	}
+	if folder == "." {
+		// Naming none means the app's own folder everywhere else, which is the one
+		// folder this must never take.
+		return fmt.Errorf("missing folder name (the app's own folder is not deleted)")
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	info, err := root.Lstat(folder)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %w", folder, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is a file, not a folder (delete it with DeleteFile)", folder)
+	}
+	if getBool(req, "recursive") {
+		err = root.RemoveAll(folder)
+	} else if err = root.Remove(folder); err != nil {
+		if entries, readErr := readDir(root, folder); readErr == nil && len(entries) > 0 {
+			return fmt.Errorf("%s is not empty (set recursive to delete what is in it too)", folder)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("deleting %s: %w", folder, err)
+	}
+	setString(resp, "deleted", folder)
+	setString(resp, "path", in.absolute(folder))
+	return nil
+}
+
+// statFile reports on a path. Nothing there is an answer, not a failure: asking
+// is how a script finds out whether to create a file or write it.
+func (in *instance) statFile(req, resp *dynamicpb.Message) error {
+	name, err := clean("file", getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	info, err := root.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	setBool(resp, "exists", true)
+	setBool(resp, "folder", info.IsDir())
+	setInt64(resp, "size", info.Size())
+	setString(resp, "modified", info.ModTime().UTC().Format(time.RFC3339Nano))
+	if !info.IsDir() {
+		sum, err := hashFile(root, name)
+		if err != nil {
+			return err
+		}
+		setString(resp, "sha256", sum)
+	}
+	return nil
+}
+
+// hashFile is the hex SHA-256 of a file's content.
+func hashFile(root *os.Root, file string) (string, error) {
+	f, err := root.Open(file)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", file, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("reading %s: %w", file, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hash is the hex SHA-256 of content already read.
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
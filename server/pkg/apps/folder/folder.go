@@ -1,17 +1,18 @@
 // Package folder implements the built-in "folder" app: it binds to the folder named
-// by the "path" creation parameter and exposes list, create, read and append inside
-// it. On the sandboxed macOS desktop that folder is reached through a security-scoped
+// by the "path" creation parameter and exposes list, glob, search, create, read,
+// append, write, move, delete, stat and watch inside it, text and bytes alike. On
+// the sandboxed macOS desktop that folder is reached through a security-scoped
 // bookmark saved when the user picks it.
 //
 // Every method names a path relative to the folder ("team/notes.md"), and every path
 // the app reports can be handed straight back to another method. os.Root is the whole
-// access boundary, enforced at the syscall level, symlinks included. Nothing here
-// knows about file formats: content is written and read verbatim.
+// access boundary, enforced at the syscall level, symlinks included. Content is
+// written and read verbatim, apart from the CSV, JSON Lines and JSON methods, which
+// parse and write those formats so a script does not have to.
 package folder
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -33,11 +34,15 @@ const protoSource = `syntax = "proto3";
 
 package folder;
 
-// Returned by every write method: the file's path relative to the folder and its
-// absolute path on disk.
+import "google/protobuf/struct.proto";
+
+// Returned by every write method: the file's path relative to the folder, its
+// absolute path on disk, and the SHA-256 of what it holds now, to pass to WriteFile
+// as expected_sha256.
 message FileResponse {
   string file = 1 [json_name = "file"];
   string path = 2 [json_name = "path"];
+  string sha256 = 3 [json_name = "sha256"];
 }
 
 message ListFolderRequest {
@@ -56,6 +61,54 @@ message ListFolderResponse {
   bool truncated = 3 [json_name = "truncated"];
 }
 
+message GlobRequest {
+  // A pattern over paths relative to the app's folder, e.g. "reports/*.csv". "*",
+  // "?" and "[a-z]" match within one path segment; a "**" segment matches any
+  // number of folders, so "**/*.json" is every JSON file anywhere.
+  string pattern = 1 [json_name = "pattern"];
+  // Stop after this many files. 0 means as many as a listing returns.
+  int32 limit = 2 [json_name = "limit"];
+}
+message GlobResponse {
+  // Matching file paths, relative to the app's folder, shallowest first.
+  repeated string files = 1 [json_name = "files"];
+  // The match stopped at the limit.
+  bool truncated = 2 [json_name = "truncated"];
+}
+
+message SearchRequest {
+  // What to look for, in the text of each line.
+  string query = 1 [json_name = "query"];
+  // Take query as a regular expression (Go RE2 syntax) rather than literal text.
+  bool regex = 2 [json_name = "regex"];
+  // Match letters whatever their case.
+  bool ignore_case = 3 [json_name = "ignore_case"];
+  // Which files to search, as GlobRequest.pattern. Empty searches them all.
+  string glob = 4 [json_name = "glob"];
+  // Stop after this many matching lines. 0 means 100.
+  int32 max_results = 5 [json_name = "max_results"];
+  // Pass over files larger than this many bytes. 0 means 1 MiB.
+  int64 max_file_size = 6 [json_name = "max_file_size"];
+}
+message SearchMatch {
+  // Path of the file relative to the folder. Pass it to ReadFile as it is.
+  string file = 1 [json_name = "file"];
+  // The line the match is on, from 1.
+  int32 line = 2 [json_name = "line"];
+  // The character the match starts at on that line, from 1.
+  int32 column = 3 [json_name = "column"];
+  // The line, cut down around the match when it is long.
+  string excerpt = 4 [json_name = "excerpt"];
+}
+message SearchResponse {
+  // The first match on each matching line, file by file, shallowest first.
+  repeated SearchMatch matches = 1 [json_name = "matches"];
+  // The search stopped at max_results.
+  bool truncated = 2 [json_name = "truncated"];
+  // Files passed over for being larger than max_file_size. Raise it or read them.
+  repeated string skipped = 3 [json_name = "skipped"];
+}
+
 message CreateFileRequest {
   // Path of the file relative to the folder, e.g. "team/notes.md". Folders that
   // do not exist yet along the way are created.
@@ -72,6 +125,34 @@ message ReadFileRequest {
 }
 message ReadFileResponse {
   string content = 1 [json_name = "content"];
+  // SHA-256 of the content, hex. Pass it to WriteFile as expected_sha256 to write
+  // back only what nothing else changed meanwhile.
+  string sha256 = 2 [json_name = "sha256"];
+  // Size of the file in bytes.
+  int64 size = 3 [json_name = "size"];
+  // The file's type, from its extension or else its first bytes, e.g. "application/json".
+  string mime_type = 4 [json_name = "mime_type"];
+}
+
+message ReadBytesRequest {
+  // Path of the file relative to the folder, e.g. "dumps/response.bin".
+  string file = 1 [json_name = "file"];
+  // Where to start reading, in bytes. A negative offset counts back from the end,
+  // so -1024 reads the last kilobyte.
+  int64 offset = 2 [json_name = "offset"];
+  // How many bytes to read at most. 0 reads to the end.
+  int64 length = 3 [json_name = "length"];
+}
+message ReadBytesResponse {
+  bytes data = 1 [json_name = "data"];
+  // Size of the whole file in bytes.
+  int64 size = 2 [json_name = "size"];
+  // Where data starts in the file, a negative offset resolved.
+  int64 offset = 3 [json_name = "offset"];
+  // The file's type, from its extension or else its first bytes, e.g. "image/png".
+  string mime_type = 4 [json_name = "mime_type"];
+  // SHA-256 of the whole file, hex, when the read covered all of it.
+  string sha256 = 5 [json_name = "sha256"];
 }
 
 message AppendFileRequest {
@@ -82,16 +163,240 @@ message AppendFileRequest {
   string content = 2 [json_name = "content"];
 }
 
+message WriteFileRequest {
+  // Path of the file relative to the folder, e.g. "team/notes.md". Folders that
+  // do not exist yet along the way are created.
+  string file = 1 [json_name = "file"];
+  // The file's new content, written verbatim in place of what it held.
+  string content = 2 [json_name = "content"];
+  // The SHA-256 the file must still have, as ReadFile or StatFile reported it.
+  // The write is refused when the file changed since, or is gone. It is checked
+  // just before the file is replaced, not held as a lock. Empty writes whatever
+  // the file holds, and creates it if it does not exist.
+  string expected_sha256 = 3 [json_name = "expected_sha256"];
+}
+
+message WriteBytesRequest {
+  // Path of the file relative to the folder. Folders that do not exist yet along
+  // the way are created.
+  string file = 1 [json_name = "file"];
+  // The file's new content, written byte for byte in place of what it held.
+  bytes data = 2 [json_name = "data"];
+  // As WriteFileRequest.expected_sha256.
+  string expected_sha256 = 3 [json_name = "expected_sha256"];
+}
+
+message AppendBytesRequest {
+  // Path of the file relative to the folder. Folders that do not exist yet along
+  // the way are created.
+  string file = 1 [json_name = "file"];
+  // Appended byte for byte. The file is created if it does not exist.
+  bytes data = 2 [json_name = "data"];
+}
+
+message CsvRow {
+  // The row's cells by column name.
+  map<string, string> values = 1 [json_name = "values"];
+}
+
+message ReadCsvRequest {
+  // Path of the file relative to the folder, e.g. "results/run.csv". Its first
+  // line names the columns.
+  string file = 1 [json_name = "file"];
+  // The character between cells. Empty means ",".
+  string delimiter = 2 [json_name = "delimiter"];
+}
+message ReadCsvResponse {
+  // The column names, in the file's order.
+  repeated string columns = 1 [json_name = "columns"];
+  repeated CsvRow rows = 2 [json_name = "rows"];
+  // As ReadFileResponse.sha256.
+  string sha256 = 3 [json_name = "sha256"];
+}
+
+message WriteCsvRequest {
+  // Path of the file relative to the folder. Folders that do not exist yet along
+  // the way are created.
+  string file = 1 [json_name = "file"];
+  // The columns, in order. Empty means every name the rows use, sorted. When
+  // appending to a file that has rows, its header decides and this must be empty
+  // or the same.
+  repeated string columns = 2 [json_name = "columns"];
+  // A cell the row does not set is written empty.
+  repeated CsvRow rows = 3 [json_name = "rows"];
+  // The character between cells. Empty means ",".
+  string delimiter = 4 [json_name = "delimiter"];
+  // Add the rows to the end of the file rather than replacing it. A file that is
+  // not there yet is written with a header first.
+  bool append = 5 [json_name = "append"];
+  // As WriteFileRequest.expected_sha256, when not appending.
+  string expected_sha256 = 6 [json_name = "expected_sha256"];
+}
+
+message ReadJsonlRequest {
+  // Path of the file relative to the folder, e.g. "runs.jsonl".
+  string file = 1 [json_name = "file"];
+}
+message ReadJsonlResponse {
+  // One per non-blank line, in order.
+  repeated google.protobuf.Value records = 1 [json_name = "records"];
+}
+
+message AppendJsonlRequest {
+  // Path of the file relative to the folder. The file and folders that do not
+  // exist yet along the way are created.
+  string file = 1 [json_name = "file"];
+  // Each is written as one line of JSON.
+  repeated google.protobuf.Value records = 2 [json_name = "records"];
+}
+
+message ReadJsonRequest {
+  // Path of the file relative to the folder, e.g. "state.json".
+  string file = 1 [json_name = "file"];
+  // A JSON Pointer (RFC 6901) to the part of the document to read, e.g.
+  // "/runs/0/status". Empty reads the whole document.
+  string pointer = 2 [json_name = "pointer"];
+}
+message ReadJsonResponse {
+  google.protobuf.Value value = 1 [json_name = "value"];
+  // The pointer names something in the document. When it does not, value is
+  // null.
+  bool found = 2 [json_name = "found"];
+  // SHA-256 of the whole file. Pass it to MergeJson as expected_sha256.
+  string sha256 = 3 [json_name = "sha256"];
+}
+
+message MergeJsonRequest {
+  // Path of the file relative to the folder. A file that is not there yet starts
+  // as an empty document.
+  string file = 1 [json_name = "file"];
+  // A JSON Pointer to where value goes, e.g. "/runs/-" to add to the end of the
+  // runs array. Objects missing along the way are created. Empty means the whole
+  // document.
+  string pointer = 2 [json_name = "pointer"];
+  // Merged into what the pointer names as a JSON Merge Patch (RFC 7386): objects
+  // merge key by key, and a null removes its key. Numbers travel as doubles, so
+  // integers past 2^53 lose precision; those already in the file are kept as written.
+  google.protobuf.Value value = 3 [json_name = "value"];
+  // Put value in place of what the pointer names instead of merging it.
+  bool replace = 4 [json_name = "replace"];
+  // As WriteFileRequest.expected_sha256.
+  string expected_sha256 = 5 [json_name = "expected_sha256"];
+}
+
+message WatchRequest {
+  // The folder to watch, relative to the app's folder. Empty watches the app's
+  // folder itself.
+  string folder = 1 [json_name = "folder"];
+  // Watch the whole tree under the folder, folders created later included, rather
+  // than its immediate files only.
+  bool recursive = 2 [json_name = "recursive"];
+  // Only report files whose paths match this pattern, as GlobRequest.pattern.
+  string glob = 3 [json_name = "glob"];
+}
+message WatchEvent {
+  // "create", "modify" or "delete". A file moved away is deleted from where it
+  // was and created where it went.
+  string type = 1 [json_name = "type"];
+  // Path of the file relative to the folder. Pass it to ReadFile as it is.
+  string file = 2 [json_name = "file"];
+  // The file's absolute path on disk.
+  string path = 3 [json_name = "path"];
+}
+
+message DeleteFileRequest {
+  // Path of the file relative to the folder, e.g. "team/notes.md".
+  string file = 1 [json_name = "file"];
+}
+
+message MoveFileRequest {
+  // Path of the file to move, relative to the folder.
+  string from = 1 [json_name = "from"];
+  // Where it goes, relative to the folder. Folders that do not exist yet along
+  // the way are created.
+  string to = 2 [json_name = "to"];
+  // Replace a file already at to. Otherwise moving onto an existing file fails.
+  bool overwrite = 3 [json_name = "overwrite"];
+}
+
+message DeleteFolderRequest {
+  // The folder to delete, relative to the app's folder, e.g. "team/old".
+  string folder = 1 [json_name = "folder"];
+  // Delete everything in it too. Otherwise only an empty folder is deleted.
+  bool recursive = 2 [json_name = "recursive"];
+}
+
+// Returned by the delete methods: what was deleted, relative to the folder, and
+// its absolute path on disk.
+message DeleteResponse {
+  string deleted = 1 [json_name = "deleted"];
+  string path = 2 [json_name = "path"];
+}
+
+message StatFileRequest {
+  // Path of a file or folder relative to the folder, e.g. "team/notes.md".
+  string file = 1 [json_name = "file"];
+}
+message StatFileResponse {
+  // Whether anything is at the path. The rest is empty when not.
+  bool exists = 1 [json_name = "exists"];
+  // The path is a folder rather than a file.
+  bool folder = 2 [json_name = "folder"];
+  // Size in bytes.
+  int64 size = 3 [json_name = "size"];
+  // When it was last modified, RFC 3339.
+  string modified = 4 [json_name = "modified"];
+  // SHA-256 of a file's content, hex.
+  string sha256 = 5 [json_name = "sha256"];
+}
+
 service Folder {
   // List a folder's files and folders. Every path it reports can be passed back
   // to another method as it is.
   rpc ListFolder(ListFolderRequest) returns (ListFolderResponse);
+  // Find the files whose paths match a pattern such as "**/*.json".
+  rpc Glob(GlobRequest) returns (GlobResponse);
+  // Find the lines of text files that contain literal text or match a regular
+  // expression. Binary files are passed over.
+  rpc Search(SearchRequest) returns (SearchResponse);
   // Create a file, optionally with initial content.
   rpc CreateFile(CreateFileRequest) returns (FileResponse);
-  // Read a file's contents.
+  // Read a text file's contents.
   rpc ReadFile(ReadFileRequest) returns (ReadFileResponse);
+  // Read a file's bytes, all of them or a range: an image, a PDF, a payload dump.
+  rpc ReadBytes(ReadBytesRequest) returns (ReadBytesResponse);
   // Append to a file, creating it if it does not exist.
   rpc AppendFile(AppendFileRequest) returns (FileResponse);
+  // Replace a file's contents, optionally only if it is unchanged since it was read.
+  rpc WriteFile(WriteFileRequest) returns (FileResponse);
+  // WriteFile for bytes.
+  rpc WriteBytes(WriteBytesRequest) returns (FileResponse);
+  // AppendFile for bytes.
+  rpc AppendBytes(AppendBytesRequest) returns (FileResponse);
+  // Read a CSV file as rows of cells by column name.
+  rpc ReadCsv(ReadCsvRequest) returns (ReadCsvResponse);
+  // Write rows to a CSV file, or append them to it.
+  rpc WriteCsv(WriteCsvRequest) returns (FileResponse);
+  // Read a JSON Lines file, one record per line.
+  rpc ReadJsonl(ReadJsonlRequest) returns (ReadJsonlResponse);
+  // Append records to a JSON Lines file.
+  rpc AppendJsonl(AppendJsonlRequest) returns (FileResponse);
+  // Read a JSON document, or the part of it a JSON Pointer names.
+  rpc ReadJson(ReadJsonRequest) returns (ReadJsonResponse);
+  // Merge a value into a JSON document at a JSON Pointer, or replace what is there.
+  rpc MergeJson(MergeJsonRequest) returns (FileResponse);
+  // Report files created, modified or deleted in a folder as it happens, until the
+  // call is cancelled. A burst of writes to one file is reported once it settles.
+  rpc Watch(WatchRequest) returns (stream WatchEvent);
+  // Delete a file.
+  rpc DeleteFile(DeleteFileRequest) returns (DeleteResponse);
+  // Move or rename a file.
+  rpc MoveFile(MoveFileRequest) returns (FileResponse);
+  // Delete a folder, and with recursive everything in it.
+  rpc DeleteFolder(DeleteFolderRequest) returns (DeleteResponse);
+  // Report whether a file or folder exists, its size, when it last changed, and a
+  // file's SHA-256.
+  rpc StatFile(StatFileRequest) returns (StatFileResponse);
 }
 `
 
@@ -124,16 +429,18 @@ func (a *App) Open(parameters map[string]string, protoDir string, log func(strin
 	return &apps.Opened{Instance: &instance{folder: path, methods: methods, limit: maxEntries}}, nil
 }
 
-// method holds the request and response descriptors of one service method.
+// method holds the request and response descriptors of one service method, and
+// whether it is server-streaming.
 type method struct {
 	input  protoreflect.MessageDescriptor
 	output protoreflect.MessageDescriptor
+	stream bool
 }
 
 // compile compiles the static proto and resolves every method's request and
 // response descriptors, keyed by method name.
 func compile(protoDir string) (map[string]method, error) {
-	result, err := protoc.New(protoc.WithProtoPaths(protoDir)).Compile("folder.proto")
+	result, err := protoc.New(protoc.WithProtoPaths(protoDir), protoc.WithIncludeImports()).Compile("folder.proto")
 	if err != nil {
 		return nil, fmt.Errorf("compiling generated proto: %w", err)
 	}
@@ -152,7 +459,7 @@ func compile(protoDir string) (map[string]method, error) {
 	methods := map[string]method{}
 	for i := 0; i < service.Methods().Len(); i++ {
 		m := service.Methods().Get(i)
-		methods[string(m.Name())] = method{input: m.Input(), output: m.Output()}
+		methods[string(m.Name())] = method{input: m.Input(), output: m.Output(), stream: m.IsStreamingServer()}
 	}
 	return methods, nil
 }
@@ -207,6 +514,11 @@ func (in *instance) dispatch(name string, req, resp *dynamicpb.Message) error {
 		setBool(resp, "truncated", truncated)
 		return nil
 
+	case "Glob":
+		return in.glob(req, resp)
+	case "Search":
+		return in.search(req, resp)
+
 	case "CreateFile":
 		file, err := resolve(getString(req, "file"))
 		if err != nil {
@@ -234,41 +546,21 @@ func (in *instance) dispatch(name string, req, resp *dynamicpb.Message) error {
 		if err != nil {
 			return fmt.Errorf("creating %s: %w", file, err)
 		}
-		if _, err := f.WriteString(getString(req, "content")); err != nil {
+		if _, err := f.Write(content(req)); err != nil {
 			f.Close()
 			return fmt.Errorf("writing %s: %w", file, err)
 		}
 		if err := f.Close(); err != nil {
 			return fmt.Errorf("writing %s: %w", file, err)
 		}
-		return setFileResponse(resp, file, in.absolute(file))
+		return in.setFileResponse(resp, root, file)
 
 	case "ReadFile":
-		file, err := resolve(getString(req, "file"))
-		if err != nil {
-			return err
-		}
-		root, err := os.OpenRoot(in.folder)
-		if err != nil {
-			return fmt.Errorf("opening folder: %w", err)
-		}
-		defer root.Close()
-		f, err := root.Open(file)
-		if err != nil {
-			return fmt.Errorf("reading file: %w", err)
-		}
-		defer f.Close()
-		if info, err := f.Stat(); err == nil && info.IsDir() {
-			return fmt.Errorf("%s is a folder, not a file (list it with ListFolder)", file)
-		}
-		data, err := io.ReadAll(f)
-		if err != nil {
-			return fmt.Errorf("reading file: %w", err)
-		}
-		setString(resp, "content", string(data))
-		return nil
+		return in.readFile(req, resp)
+	case "ReadBytes":
+		return in.readBytes(req, resp)
 
-	case "AppendFile":
+	case "AppendFile", "AppendBytes":
 		file, err := resolve(getString(req, "file"))
 		if err != nil {
 			return err
@@ -278,18 +570,33 @@ func (in *instance) dispatch(name string, req, resp *dynamicpb.Message) error {
 			return fmt.Errorf("opening folder: %w", err)
 		}
 		defer root.Close()
-		if err := makeParents(root, file); err != nil {
+		if err := appendToFile(root, file, content(req)); err != nil {
 			return err
 		}
-		f, err := root.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
-		if err != nil {
-			return fmt.Errorf("opening %s: %w", file, err)
-		}
-		defer f.Close()
-		if _, err := f.WriteString(getString(req, "content")); err != nil {
-			return fmt.Errorf("writing to %s: %w", file, err)
-		}
-		return setFileResponse(resp, file, in.absolute(file))
+		return in.setFileResponse(resp, root, file)
+
+	case "WriteFile", "WriteBytes":
+		return in.writeFile(req, resp)
+	case "ReadCsv":
+		return in.readCsv(req, resp)
+	case "WriteCsv":
+		return in.writeCsv(req, resp)
+	case "ReadJsonl":
+		return in.readJsonl(req, resp)
+	case "AppendJsonl":
+		return in.appendJsonl(req, resp)
+	case "ReadJson":
+		return in.readJson(req, resp)
+	case "MergeJson":
+		return in.mergeJson(req, resp)
+	case "DeleteFile":
+		return in.deleteFile(req, resp)
+	case "MoveFile":
+		return in.moveFile(req, resp)
+	case "DeleteFolder":
+		return in.deleteFolder(req, resp)
+	case "StatFile":
+		return in.statFile(req, resp)
 	}
 	return fmt.Errorf("unhandled method %q", name)
 }
@@ -409,7 +716,7 @@ func resolveFolder(name string) (string, error) {
 }
 
 // makeParents creates the folders a file's path implies. A path is the only way to
-// make a folder here, which is what keeps the app at four methods.
+// make a folder here, which is why there is no CreateFolder.
 func makeParents(root *os.Root, file string) error {
 	parent := path.Dir(file)
 	if parent == "." {
@@ -426,9 +733,16 @@ func (in *instance) absolute(file string) string {
 	return filepath.Join(in.folder, filepath.FromSlash(file))
 }
 
-func setFileResponse(resp *dynamicpb.Message, name, path string) error {
-	setString(resp, "file", name)
-	setString(resp, "path", path)
+// setFileResponse fills a FileResponse for a file just written, with the hash of
+// what it holds now.
+func (in *instance) setFileResponse(resp *dynamicpb.Message, root *os.Root, file string) error {
+	sum, err := hashFile(root, file)
+	if err != nil {
+		return err
+	}
+	setString(resp, "file", file)
+	setString(resp, "path", in.absolute(file))
+	setString(resp, "sha256", sum)
 	return nil
 }
 
@@ -448,6 +762,35 @@ func getBool(m *dynamicpb.Message, name string) bool {
 	return m.Get(fd).Bool()
 }
 
+func getInt64(m *dynamicpb.Message, name string) int64 {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return 0
+	}
+	return m.Get(fd).Int()
+}
+
+func getBytes(m *dynamicpb.Message, name string) []byte {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return nil
+	}
+	return m.Get(fd).Bytes()
+}
+
+func getStringList(m *dynamicpb.Message, name string) []string {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return nil
+	}
+	list := m.Get(fd).List()
+	out := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		out = append(out, list.Get(i).String())
+	}
+	return out
+}
+
 func setString(m *dynamicpb.Message, name, value string) {
 	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
 	if fd == nil {
@@ -464,6 +807,30 @@ func setBool(m *dynamicpb.Message, name string, value bool) {
 	m.Set(fd, protoreflect.ValueOfBool(value))
 }
 
+func setBytes(m *dynamicpb.Message, name string, value []byte) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfBytes(value))
+}
+
+func setInt64(m *dynamicpb.Message, name string, value int64) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfInt64(value))
+}
+
+func setInt32(m *dynamicpb.Message, name string, value int32) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfInt32(value))
+}
+
 func setStringList(m *dynamicpb.Message, name string, values []string) {
 	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
 	if fd == nil {
@@ -1,11 +1,17 @@
 package folder
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -122,19 +128,6 @@ func listing(t *testing.T, inst *instance, requestJSON string) (files, folders [
 	return getStringList(resp, "files"), getStringList(resp, "folders")
 }
 
-func getStringList(m *dynamicpb.Message, name string) []string {
-	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
-	if fd == nil {
-		return nil
-	}
-	list := m.Get(fd).List()
-	out := make([]string, 0, list.Len())
-	for i := 0; i < list.Len(); i++ {
-		out = append(out, list.Get(i).String())
-	}
-	return out
-}
-
 // A write names the folders it wants, a listing reports them, and every path a
 // listing reports reads back without being joined to anything.
 func TestSubfolders(t *testing.T) {
@@ -222,6 +215,513 @@ func TestListFolderTruncated(t *testing.T) {
 }
 
 // Naming the wrong kind of thing says which method wants it.
+// response decodes a method's response, for the fields invoke's JSON does not
+// show plainly.
+func response(t *testing.T, inst *instance, methodName, requestJSON string) *dynamicpb.Message {
+	t.Helper()
+	body, err := call(inst, methodName, requestJSON)
+	if err != nil {
+		t.Fatalf("Invoke %q: %v", methodName, err)
+	}
+	resp := dynamicpb.NewMessage(inst.methods[methodName].output)
+	if err := proto.Unmarshal(body, resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp
+}
+
+func TestBytes(t *testing.T) {
+	inst, path := open(t)
+	// A PNG signature, then bytes that are not UTF-8.
+	png := []byte("\x89PNG\r\n\x1a\n\x00\xff\xfe\x80")
+	tail := []byte{0xc3, 0x28, 0x00, 0x01}
+	encode := base64.StdEncoding.EncodeToString
+
+	invoke(t, inst, "WriteBytes", `{"file": "art/chart.png", "data": "`+encode(png)+`"}`)
+	invoke(t, inst, "AppendBytes", `{"file": "art/chart.png", "data": "`+encode(tail)+`"}`)
+	whole := append(slices.Clone(png), tail...)
+	if got, _ := os.ReadFile(filepath.Join(path, "art", "chart.png")); !bytes.Equal(got, whole) {
+		t.Fatalf("art/chart.png = %q", got)
+	}
+
+	read := response(t, inst, "ReadBytes", `{"file": "art/chart.png"}`)
+	if got := getBytes(read, "data"); !bytes.Equal(got, whole) {
+		t.Fatalf("data = %q", got)
+	}
+	if size, mime := getInt64(read, "size"), getString(read, "mime_type"); size != int64(len(whole)) || mime != "image/png" {
+		t.Fatalf("size, mime_type = %d, %q", size, mime)
+	}
+	if sha := getString(read, "sha256"); sha != hash(whole) {
+		t.Fatalf("sha256 = %q", sha)
+	}
+
+	// Text is no way to read it.
+	if _, err := call(inst, "ReadFile", `{"file": "art/chart.png"}`); err == nil || !strings.Contains(err.Error(), "ReadBytes") {
+		t.Fatalf("ReadFile of bytes = %v", err)
+	}
+
+	// The hash guards a bytes write as it does a text one.
+	invoke(t, inst, "WriteBytes", `{"file": "art/chart.png", "data": "`+encode(tail)+`", "expected_sha256": "`+hash(whole)+`"}`)
+	if _, err := call(inst, "WriteBytes", `{"file": "art/chart.png", "data": "`+encode(png)+`", "expected_sha256": "`+hash(whole)+`"}`); err == nil {
+		t.Fatal("expected a stale bytes write to fail")
+	}
+	if got, _ := os.ReadFile(filepath.Join(path, "art", "chart.png")); !bytes.Equal(got, tail) {
+		t.Fatalf("art/chart.png = %q", got)
+	}
+}
+
+func TestReadBytesRange(t *testing.T) {
+	inst, path := open(t)
+	if err := os.WriteFile(filepath.Join(path, "dump.bin"), []byte("0123456789"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		request, data string
+		offset        int64
+	}{
+		{`"offset": 2, "length": 3`, "234", 2},
+		{`"offset": 7`, "789", 7},
+		{`"offset": 8, "length": 5`, "89", 8},
+		{`"offset": -4`, "6789", 6},
+		{`"offset": -20, "length": 2`, "01", 0},
+		{`"offset": 15`, "", 10},
+	} {
+		read := response(t, inst, "ReadBytes", `{"file": "dump.bin", `+tc.request+`}`)
+		if got := string(getBytes(read, "data")); got != tc.data {
+			t.Errorf("%s: data = %q, want %q", tc.request, got, tc.data)
+		}
+		if got := getInt64(read, "offset"); got != tc.offset {
+			t.Errorf("%s: offset = %d, want %d", tc.request, got, tc.offset)
+		}
+		if size := getInt64(read, "size"); size != 10 {
+			t.Errorf("%s: size = %d", tc.request, size)
+		}
+		// Only a read of the whole file can vouch for the whole file.
+		if sha := getString(read, "sha256"); sha != "" {
+			t.Errorf("%s: sha256 = %q for part of the file", tc.request, sha)
+		}
+	}
+
+	if _, err := call(inst, "ReadBytes", `{"file": "dump.bin", "length": -1}`); err == nil {
+		t.Fatal("expected a negative length to fail")
+	}
+	if _, err := call(inst, "ReadBytes", `{"file": "../dump.bin"}`); err == nil {
+		t.Fatal("expected a read outside the folder to fail")
+	}
+}
+
+func TestReadFileSizeAndType(t *testing.T) {
+	inst, _ := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "data.json", "content": "{\"ok\": true}"}`)
+	invoke(t, inst, "CreateFile", `{"file": "notes", "content": "plain"}`)
+
+	read := response(t, inst, "ReadFile", `{"file": "data.json"}`)
+	if size, mime := getInt64(read, "size"), getString(read, "mime_type"); size != 12 || mime != "application/json" {
+		t.Fatalf("size, mime_type of data.json = %d, %q", size, mime)
+	}
+	// Without an extension the type comes from the content.
+	if got := field(t, inst, "ReadFile", `{"file": "notes"}`, "mime_type"); got != "text/plain; charset=utf-8" {
+		t.Fatalf("mime_type of notes = %q", got)
+	}
+}
+
+func TestGlob(t *testing.T) {
+	inst, path := open(t)
+	for _, file := range []string{"a.json", "b.txt", "data/c.json", "data/deep/d.json", "data/deep/e.csv", "logs/f.json"} {
+		invoke(t, inst, "CreateFile", `{"file": "`+file+`"}`)
+	}
+	os.Mkdir(filepath.Join(path, "empty"), 0o755)
+
+	glob := func(request string) ([]string, bool) {
+		t.Helper()
+		resp := response(t, inst, "Glob", request)
+		return getStringList(resp, "files"), getBool(resp, "truncated")
+	}
+	for pattern, want := range map[string][]string{
+		"**/*.json":      {"a.json", "data/c.json", "logs/f.json", "data/deep/d.json"},
+		"*.json":         {"a.json"},
+		"data/**":        {"data/c.json", "data/deep/d.json", "data/deep/e.csv"},
+		"data/*/*.csv":   {"data/deep/e.csv"},
+		"*/[cf].json":    {"data/c.json", "logs/f.json"},
+		"missing/**":     nil,
+		"a.json":         {"a.json"},
+		"./data/c.json":  {"data/c.json"},
+		"**/deep/?.json": {"data/deep/d.json"},
+	} {
+		if files, _ := glob(`{"pattern": "` + pattern + `"}`); !slices.Equal(files, want) {
+			t.Errorf("Glob %q = %v, want %v", pattern, files, want)
+		}
+	}
+
+	if files, truncated := glob(`{"pattern": "**", "limit": 2}`); !slices.Equal(files, []string{"a.json", "b.txt"}) || !truncated {
+		t.Fatalf("limited Glob = %v, truncated %v", files, truncated)
+	}
+	for _, pattern := range []string{"", "../**", "/etc/*", "[a-"} {
+		if _, err := call(inst, "Glob", `{"pattern": "`+pattern+`"}`); err == nil {
+			t.Errorf("expected Glob %q to fail", pattern)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	inst, path := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "notes.md", "content": "# Notes\nTODO: ship it\r\nDone.\n"}`)
+	invoke(t, inst, "CreateFile", `{"file": "src/main.go", "content": "package main\n\n// todo(x): tidy\nfunc main() {}\n"}`)
+	invoke(t, inst, "CreateFile", `{"file": "long.txt", "content": "`+strings.Repeat("é", 300)+`needle`+strings.Repeat("z", 300)+`"}`)
+	if err := os.WriteFile(filepath.Join(path, "blob.bin"), []byte("TODO\x00binary"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	type match struct {
+		file         string
+		line, column int64
+		excerpt      string
+	}
+	search := func(request string) ([]match, *dynamicpb.Message) {
+		t.Helper()
+		resp := response(t, inst, "Search", request)
+		var matches []match
+		list := resp.Get(resp.Descriptor().Fields().ByName("matches")).List()
+		for i := 0; i < list.Len(); i++ {
+			m := list.Get(i).Message().Interface().(*dynamicpb.Message)
+			matches = append(matches, match{getString(m, "file"), getInt64(m, "line"), getInt64(m, "column"), getString(m, "excerpt")})
+		}
+		return matches, resp
+	}
+
+	// Literal and case-sensitive by default; the binary file is passed over.
+	if got, _ := search(`{"query": "TODO:"}`); !slices.Equal(got, []match{{"notes.md", 2, 1, "TODO: ship it"}}) {
+		t.Fatalf("literal search = %v", got)
+	}
+	if got, _ := search(`{"query": "todo", "ignore_case": true}`); len(got) != 2 || got[1] != (match{"src/main.go", 3, 4, "// todo(x): tidy"}) {
+		t.Fatalf("case-insensitive search = %v", got)
+	}
+	// A literal query is not a pattern, a regex one is.
+	if got, _ := search(`{"query": "todo(x)"}`); len(got) != 1 || got[0].file != "src/main.go" {
+		t.Fatalf("literal parentheses = %v", got)
+	}
+	if got, _ := search(`{"query": "^func \\w+", "regex": true, "glob": "**/*.go"}`); !slices.Equal(got, []match{{"src/main.go", 4, 1, "func main() {}"}}) {
+		t.Fatalf("regex search = %v", got)
+	}
+	if got, _ := search(`{"query": "TODO", "glob": "src/**"}`); got != nil {
+		t.Fatalf("search outside the glob = %v", got)
+	}
+
+	// A long line comes back cut around the match, on character boundaries.
+	got, _ := search(`{"query": "needle"}`)
+	if len(got) != 1 || got[0].column != 301 || !strings.Contains(got[0].excerpt, "needle") || !strings.HasPrefix(got[0].excerpt, "…é") || len(got[0].excerpt) > maxExcerpt+2*len("…") {
+		t.Fatalf("long line = %+v", got)
+	}
+
+	if got, resp := search(`{"query": "e", "max_results": 2}`); len(got) != 2 || !getBool(resp, "truncated") {
+		t.Fatalf("limited search = %v", got)
+	}
+	if _, resp := search(`{"query": "needle", "max_file_size": 100}`); !slices.Equal(getStringList(resp, "skipped"), []string{"long.txt"}) {
+		t.Fatalf("skipped = %v", getStringList(resp, "skipped"))
+	}
+	if _, err := call(inst, "Search", `{"query": "(", "regex": true}`); err == nil {
+		t.Fatal("expected an invalid regex to fail")
+	}
+	if _, err := call(inst, "Search", `{"query": "x", "glob": "../**"}`); err == nil {
+		t.Fatal("expected a glob outside the folder to fail")
+	}
+}
+
+func TestCsv(t *testing.T) {
+	inst, path := open(t)
+	invoke(t, inst, "WriteCsv", `{"file": "out/runs.csv", "rows": [
+		{"values": {"name": "first", "status": "ok"}},
+		{"values": {"name": "second, with a comma", "note": "said \"hi\""}}
+	]}`)
+	want := "name,note,status\nfirst,,ok\n\"second, with a comma\",\"said \"\"hi\"\"\",\n"
+	if got, _ := os.ReadFile(filepath.Join(path, "out", "runs.csv")); string(got) != want {
+		t.Fatalf("runs.csv = %q", got)
+	}
+
+	// Appending keeps the file's header, whatever order the row's keys are in.
+	invoke(t, inst, "WriteCsv", `{"file": "out/runs.csv", "append": true, "rows": [{"values": {"status": "failed", "name": "third"}}]}`)
+	if _, err := call(inst, "WriteCsv", `{"file": "out/runs.csv", "append": true, "rows": [{"values": {"extra": "x"}}]}`); err == nil || !strings.Contains(err.Error(), "not one of the columns") {
+		t.Fatalf("append with an unknown column = %v", err)
+	}
+
+	read := response(t, inst, "ReadCsv", `{"file": "out/runs.csv"}`)
+	if columns := getStringList(read, "columns"); !slices.Equal(columns, []string{"name", "note", "status"}) {
+		t.Fatalf("columns = %v", columns)
+	}
+	rows := read.Get(read.Descriptor().Fields().ByName("rows")).List()
+	var got []map[string]string
+	for i := 0; i < rows.Len(); i++ {
+		row := rows.Get(i).Message()
+		cells := map[string]string{}
+		row.Get(row.Descriptor().Fields().ByName("values")).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			cells[k.String()] = v.String()
+			return true
+		})
+		got = append(got, cells)
+	}
+	if len(got) != 3 || got[1]["name"] != "second, with a comma" || got[1]["note"] != `said "hi"` || got[2]["status"] != "failed" || got[2]["note"] != "" {
+		t.Fatalf("rows = %v", got)
+	}
+
+	// Explicit columns set the order, and a delimiter other than a comma.
+	invoke(t, inst, "WriteCsv", `{"file": "tabs.tsv", "columns": ["b", "a"], "delimiter": "\t", "rows": [{"values": {"a": "1", "b": "2"}}]}`)
+	if got, _ := os.ReadFile(filepath.Join(path, "tabs.tsv")); string(got) != "b\ta\n2\t1\n" {
+		t.Fatalf("tabs.tsv = %q", got)
+	}
+	if _, err := call(inst, "ReadCsv", `{"file": "tabs.tsv", "delimiter": "ab"}`); err == nil {
+		t.Fatal("expected a two-character delimiter to fail")
+	}
+}
+
+func TestJsonl(t *testing.T) {
+	inst, path := open(t)
+	if err := os.WriteFile(filepath.Join(path, "runs.jsonl"), []byte(`{"n":1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	invoke(t, inst, "AppendJsonl", `{"file": "runs.jsonl", "records": [{"n": 2, "tags": ["a", "<b>"]}, "text"]}`)
+	invoke(t, inst, "AppendJsonl", `{"file": "runs.jsonl", "records": [null]}`)
+	want := "{\"n\":1}\n{\"n\":2,\"tags\":[\"a\",\"<b>\"]}\n\"text\"\nnull\n"
+	if got, _ := os.ReadFile(filepath.Join(path, "runs.jsonl")); string(got) != want {
+		t.Fatalf("runs.jsonl = %q", got)
+	}
+
+	read := response(t, inst, "ReadJsonl", `{"file": "runs.jsonl"}`)
+	out, err := protojson.Marshal(read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct{ Records []any }
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Records) != 4 || decoded.Records[2] != "text" || decoded.Records[3] != nil {
+		t.Fatalf("records = %v", decoded.Records)
+	}
+
+	os.WriteFile(filepath.Join(path, "bad.jsonl"), []byte("{}\n\n{oops\n"), 0o644)
+	if _, err := call(inst, "ReadJsonl", `{"file": "bad.jsonl"}`); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("bad line = %v", err)
+	}
+}
+
+func TestJson(t *testing.T) {
+	inst, path := open(t)
+	state := filepath.Join(path, "state.json")
+
+	// A file that is not there starts empty, and objects along the way appear.
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "pointer": "/config/retries", "value": 3}`)
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "value": {"config": {"name": "nightly"}, "runs": []}}`)
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "pointer": "/runs/-", "value": {"id": 7, "ok": true}}`)
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "pointer": "/runs/0", "value": {"ok": null, "note": "a/b"}}`)
+	want := `{
+  "config": {
+    "name": "nightly",
+    "retries": 3
+  },
+  "runs": [
+    {
+      "id": 7,
+      "note": "a/b"
+    }
+  ]
+}
+`
+	if got, _ := os.ReadFile(state); string(got) != want {
+		t.Fatalf("state.json =\n%s", got)
+	}
+
+	value := func(pointer string) (string, bool) {
+		t.Helper()
+		resp := response(t, inst, "ReadJson", `{"file": "state.json", "pointer": "`+pointer+`"}`)
+		v := resp.Get(resp.Descriptor().Fields().ByName("value")).Message()
+		out, err := protojson.Marshal(v.Interface())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var compact bytes.Buffer
+		json.Compact(&compact, out)
+		return compact.String(), getBool(resp, "found")
+	}
+	if got, found := value("/config"); got != `{"name":"nightly","retries":3}` || !found {
+		t.Fatalf("/config = %s, %v", got, found)
+	}
+	if got, found := value("/runs/0/note"); got != `"a/b"` || !found {
+		t.Fatalf("/runs/0/note = %s, %v", got, found)
+	}
+	if got, found := value("/runs/1"); got != "null" || found {
+		t.Fatalf("/runs/1 = %s, %v", got, found)
+	}
+
+	// A key with a slash in it is escaped, a null removes a key, and replace puts
+	// the value in place rather than merging it.
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "pointer": "/config/a~1b", "value": "slash"}`)
+	if got, _ := value("/config/a~1b"); got != `"slash"` {
+		t.Fatalf("/config/a~1b = %s", got)
+	}
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "pointer": "/config/a~1b", "value": null}`)
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "pointer": "/config", "value": {"only": 1}, "replace": true}`)
+	if got, _ := value("/config"); got != `{"only":1}` {
+		t.Fatalf("replaced /config = %s", got)
+	}
+
+	// Numbers already in the file are written back as they were, not rounded
+	// through a double.
+	os.WriteFile(filepath.Join(path, "big.json"), []byte(`{"id": 12345678901234567}`), 0o644)
+	invoke(t, inst, "MergeJson", `{"file": "big.json", "pointer": "/ok", "value": true}`)
+	if got, _ := os.ReadFile(filepath.Join(path, "big.json")); !strings.Contains(string(got), `"id": 12345678901234567,`) {
+		t.Fatalf("big.json =\n%s", got)
+	}
+
+	sha := field(t, inst, "ReadJson", `{"file": "state.json"}`, "sha256")
+	invoke(t, inst, "MergeJson", `{"file": "state.json", "pointer": "/x", "value": 1, "expected_sha256": "`+sha+`"}`)
+	if _, err := call(inst, "MergeJson", `{"file": "state.json", "pointer": "/y", "value": 1, "expected_sha256": "`+sha+`"}`); err == nil {
+		t.Fatal("expected a stale merge to fail")
+	}
+	for _, request := range []string{
+		`{"file": "state.json", "pointer": "/runs/5", "value": 1}`,
+		`{"file": "state.json", "pointer": "/x/y", "value": 1}`,
+		`{"file": "state.json", "pointer": "runs", "value": 1}`,
+		`{"file": "state.json", "pointer": "/x"}`,
+	} {
+		if _, err := call(inst, "MergeJson", request); err == nil {
+			t.Errorf("expected MergeJson %s to fail", request)
+		}
+	}
+}
+
+// watching runs Watch until the test ends, handing back its events as
+// "type file" strings.
+func watching(t *testing.T, inst *instance, requestJSON string) <-chan string {
+	t.Helper()
+	req := dynamicpb.NewMessage(inst.methods["Watch"].input)
+	if err := protojson.Unmarshal([]byte(requestJSON), req); err != nil {
+		t.Fatal(err)
+	}
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan string, 100)
+	done := make(chan error, 1)
+	go func() {
+		_, err := inst.InvokeStream(ctx, "folder.Folder/Watch", reqBytes, nil, func(message []byte) error {
+			event := dynamicpb.NewMessage(inst.methods["Watch"].output)
+			if err := proto.Unmarshal(message, event); err != nil {
+				return err
+			}
+			events <- getString(event, "type") + " " + getString(event, "file")
+			return nil
+		})
+		done <- err
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Watch ended with %v", err)
+		}
+	})
+	return events
+}
+
+// next waits for the watch's next events, as many as want has, in any order.
+func next(t *testing.T, events <-chan string, want ...string) {
+	t.Helper()
+	var got []string
+	for len(got) < len(want) {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+// ready writes to a file until the watch reports it, which it does once it is
+// watching.
+func ready(t *testing.T, path string, events <-chan string) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		os.WriteFile(filepath.Join(path, "ready.txt"), []byte("x"), 0o644)
+		select {
+		case <-events:
+			return
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	t.Fatal("the watch never started")
+}
+
+func TestWatch(t *testing.T) {
+	inst, path := open(t)
+	if !inst.Streams("folder.Folder/Watch") || inst.Streams("folder.Folder/ReadFile") {
+		t.Fatal("only Watch should stream")
+	}
+	invoke(t, inst, "CreateFile", `{"file": "existing.txt", "content": "old"}`)
+	invoke(t, inst, "CreateFile", `{"file": "sub/kept.md"}`)
+	events := watching(t, inst, `{"recursive": true}`)
+	ready(t, path, events)
+
+	invoke(t, inst, "CreateFile", `{"file": "new.md", "content": "hi"}`)
+	next(t, events, "create new.md")
+
+	// WriteFile's temporary file stays out of it, and the rename over the file
+	// is a change to it.
+	invoke(t, inst, "WriteFile", `{"file": "existing.txt", "content": "new"}`)
+	next(t, events, "modify existing.txt")
+	invoke(t, inst, "AppendFile", `{"file": "sub/kept.md", "content": "more"}`)
+	next(t, events, "modify sub/kept.md")
+
+	invoke(t, inst, "MoveFile", `{"from": "new.md", "to": "sub/moved.md"}`)
+	next(t, events, "delete new.md", "create sub/moved.md")
+	invoke(t, inst, "DeleteFile", `{"file": "existing.txt"}`)
+	next(t, events, "delete existing.txt")
+
+	// A folder made during the watch is watched too.
+	invoke(t, inst, "CreateFile", `{"file": "sub/deeper/first.json"}`)
+	next(t, events, "create sub/deeper/first.json")
+	invoke(t, inst, "CreateFile", `{"file": "sub/deeper/second.json"}`)
+	next(t, events, "create sub/deeper/second.json")
+	invoke(t, inst, "DeleteFolder", `{"folder": "sub", "recursive": true}`)
+	next(t, events, "delete sub/kept.md", "delete sub/moved.md", "delete sub/deeper/first.json", "delete sub/deeper/second.json")
+}
+
+func TestWatchFolderAndGlob(t *testing.T) {
+	inst, path := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "logs/ready.txt"}`)
+	events := watching(t, inst, `{"folder": "logs", "glob": "logs/*.txt"}`)
+	ready(t, filepath.Join(path, "logs"), events)
+
+	// Outside the folder, a subfolder of a watch that is not recursive, and a file
+	// the glob does not take are all left out.
+	invoke(t, inst, "CreateFile", `{"file": "elsewhere.txt"}`)
+	invoke(t, inst, "CreateFile", `{"file": "logs/nested/deep.txt"}`)
+	invoke(t, inst, "CreateFile", `{"file": "logs/skipped.json"}`)
+	invoke(t, inst, "CreateFile", `{"file": "logs/run.txt"}`)
+	next(t, events, "create logs/run.txt")
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %q", event)
+	case <-time.After(3 * settle):
+	}
+
+	for _, request := range []string{`{"folder": "../"}`, `{"folder": "logs/ready.txt"}`, `{"folder": "missing"}`, `{"glob": "[x-"}`} {
+		req := dynamicpb.NewMessage(inst.methods["Watch"].input)
+		protojson.Unmarshal([]byte(request), req)
+		body, _ := proto.Marshal(req)
+		if _, err := inst.InvokeStream(context.Background(), "folder.Folder/Watch", body, nil, func([]byte) error { return nil }); err == nil {
+			t.Errorf("expected Watch %s to fail", request)
+		}
+	}
+}
+
 func TestWrongKind(t *testing.T) {
 	inst, _ := open(t)
 	invoke(t, inst, "CreateFile", `{"file": "team/tomas.md", "content": "tomas"}`)
@@ -282,3 +782,158 @@ func TestRejectsPathTraversal(t *testing.T) {
 		t.Fatalf("listing of an empty folder = %v", files)
 	}
 }
+
+// WriteFile replaces what a file holds, and with the hash a read reported it
+// refuses to clobber a change made since.
+func TestWriteFile(t *testing.T) {
+	inst, path := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "report.md", "content": "## Summary\nold\n"}`)
+
+	read := field(t, inst, "ReadFile", `{"file": "report.md"}`, "sha256")
+	written := field(t, inst, "WriteFile", `{"file": "report.md", "content": "## Summary\nnew\n", "expected_sha256": "`+read+`"}`, "sha256")
+	if got, _ := os.ReadFile(filepath.Join(path, "report.md")); string(got) != "## Summary\nnew\n" {
+		t.Fatalf("report.md = %q", got)
+	}
+	if written == read || written != hash([]byte("## Summary\nnew\n")) {
+		t.Fatalf("sha256 after the write = %q", written)
+	}
+
+	// The hash read before the last write no longer matches.
+	if _, err := call(inst, "WriteFile", `{"file": "report.md", "content": "lost", "expected_sha256": "`+read+`"}`); err == nil || !strings.Contains(err.Error(), "changed since it was read") {
+		t.Fatalf("stale write = %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(path, "report.md")); string(got) != "## Summary\nnew\n" {
+		t.Fatalf("refused write still wrote: %q", got)
+	}
+	if _, err := call(inst, "WriteFile", `{"file": "gone.md", "content": "x", "expected_sha256": "`+read+`"}`); err == nil {
+		t.Fatal("expected a write expecting a file that isn't there to fail")
+	}
+
+	// Without a hash it writes whatever is there, or creates the file, and
+	// leaves nothing of its own behind.
+	invoke(t, inst, "WriteFile", `{"file": "out/new.md", "content": "fresh"}`)
+	if got, _ := os.ReadFile(filepath.Join(path, "out", "new.md")); string(got) != "fresh" {
+		t.Fatalf("out/new.md = %q", got)
+	}
+	if files, _ := listing(t, inst, `{"recursive": true}`); !slices.Equal(files, []string{"report.md", "out/new.md"}) {
+		t.Fatalf("files = %v, want no temporary left", files)
+	}
+}
+
+func TestDeleteAndMoveFile(t *testing.T) {
+	inst, path := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "draft.md", "content": "draft"}`)
+	invoke(t, inst, "CreateFile", `{"file": "final.md", "content": "final"}`)
+
+	if _, err := call(inst, "MoveFile", `{"from": "draft.md", "to": "final.md"}`); err == nil {
+		t.Fatal("expected moving onto an existing file without overwrite to fail")
+	}
+	if got := field(t, inst, "MoveFile", `{"from": "draft.md", "to": "archive/2024/draft.md"}`, "file"); got != "archive/2024/draft.md" {
+		t.Fatalf("moved to %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(path, "draft.md")); !os.IsNotExist(err) {
+		t.Fatalf("draft.md still there: %v", err)
+	}
+	invoke(t, inst, "MoveFile", `{"from": "archive/2024/draft.md", "to": "final.md", "overwrite": true}`)
+	if got, _ := os.ReadFile(filepath.Join(path, "final.md")); string(got) != "draft" {
+		t.Fatalf("final.md = %q", got)
+	}
+
+	if got := field(t, inst, "DeleteFile", `{"file": "final.md"}`, "deleted"); got != "final.md" {
+		t.Fatalf("deleted %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(path, "final.md")); !os.IsNotExist(err) {
+		t.Fatalf("final.md still there: %v", err)
+	}
+	if _, err := call(inst, "DeleteFile", `{"file": "archive"}`); err == nil || !strings.Contains(err.Error(), "DeleteFolder") {
+		t.Fatalf("DeleteFile on a folder = %v", err)
+	}
+}
+
+func TestDeleteFolder(t *testing.T) {
+	inst, path := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "old/runs/1.log", "content": "x"}`)
+
+	if _, err := call(inst, "DeleteFolder", `{"folder": "old"}`); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Fatalf("deleting a folder with files in it = %v", err)
+	}
+	invoke(t, inst, "DeleteFolder", `{"folder": "old", "recursive": true}`)
+	if _, err := os.Stat(filepath.Join(path, "old")); !os.IsNotExist(err) {
+		t.Fatalf("old still there: %v", err)
+	}
+
+	invoke(t, inst, "CreateFile", `{"file": "empty/keep.md"}`)
+	invoke(t, inst, "DeleteFile", `{"file": "empty/keep.md"}`)
+	invoke(t, inst, "DeleteFolder", `{"folder": "empty"}`)
+
+	for _, name := range []string{"", ".", "team/..", "/"} {
+		if _, err := call(inst, "DeleteFolder", `{"folder": "`+name+`", "recursive": true}`); err == nil {
+			t.Fatalf("DeleteFolder %q took the app's own folder", name)
+		}
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("the app's folder is gone: %v", err)
+	}
+}
+
+func TestStatFile(t *testing.T) {
+	inst, _ := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "team/notes.md", "content": "hello"}`)
+
+	stat := invoke(t, inst, "StatFile", `{"file": "team/notes.md"}`)
+	for _, want := range []string{`"exists":true`, `"size":"5"`, `"sha256":"` + hash([]byte("hello")) + `"`, `"modified":"`} {
+		if !strings.Contains(stat, want) {
+			t.Fatalf("StatFile = %s, want %s", stat, want)
+		}
+	}
+	if stat := invoke(t, inst, "StatFile", `{"file": "team"}`); !strings.Contains(stat, `"folder":true`) || strings.Contains(stat, "sha256") {
+		t.Fatalf("StatFile on a folder = %s", stat)
+	}
+	if stat := invoke(t, inst, "StatFile", `{"file": "missing.md"}`); stat != "{}" {
+		t.Fatalf("StatFile on nothing = %s, want exists false", stat)
+	}
+}
+
+// The new methods are held to the same boundary.
+func TestFileOperationsRejectPathTraversal(t *testing.T) {
+	inst, _ := open(t)
+	invoke(t, inst, "CreateFile", `{"file": "inside.md", "content": "x"}`)
+	for _, request := range []struct{ method, json string }{
+		{"WriteFile", `{"file": "../escape.md", "content": "x"}`},
+		{"DeleteFile", `{"file": "../escape.md"}`},
+		{"MoveFile", `{"from": "inside.md", "to": "../escape.md"}`},
+		{"MoveFile", `{"from": "/etc/passwd", "to": "stolen"}`},
+		{"DeleteFolder", `{"folder": "..", "recursive": true}`},
+		{"StatFile", `{"file": "../.."}`},
+	} {
+		if _, err := call(inst, request.method, request.json); err == nil {
+			t.Fatalf("expected %s %s to be refused", request.method, request.json)
+		}
+	}
+}
+
+func TestWriteFileKeepsModeAndRefusesLinks(t *testing.T) {
+	inst, path := open(t)
+	script := filepath.Join(path, "deploy.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	invoke(t, inst, "WriteFile", `{"file": "deploy.sh", "content": "#!/bin/sh\necho ok\n"}`)
+	if info, err := os.Stat(script); err != nil || info.Mode().Perm() != 0o750 {
+		t.Fatalf("deploy.sh mode = %v, %v, want it kept at 0750", info.Mode(), err)
+	}
+
+	// A link is neither replaced by a file nor written through.
+	if err := os.Symlink("deploy.sh", filepath.Join(path, "latest.sh")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(inst, "WriteFile", `{"file": "latest.sh", "content": "overwritten"}`); err == nil || !strings.Contains(err.Error(), "symbolic link") {
+		t.Fatalf("write to a link = %v", err)
+	}
+	if got, _ := os.ReadFile(script); string(got) != "#!/bin/sh\necho ok\n" {
+		t.Fatalf("deploy.sh = %q, want it untouched", got)
+	}
+	if info, err := os.Lstat(filepath.Join(path, "latest.sh")); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("latest.sh = %v, %v, want it still a link", info, err)
+	}
+}
@@ -0,0 +1,134 @@
+package folder
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// sniffLength is how much of a file's start is looked at to tell its type when
+// its extension does not. It is all http.DetectContentType considers.
+const sniffLength = 512
+
+func (in *instance) readFile(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	f, err := in.openFile(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+	// A string field holds UTF-8 only, so anything else would fail on the way
+	// out, and a lossy conversion would corrupt whatever the script wrote back.
+	if !utf8.Valid(data) {
+		return fmt.Errorf("%s is not UTF-8 text (read it with ReadBytes)", file)
+	}
+	setString(resp, "content", string(data))
+	setString(resp, "sha256", hash(data))
+	setInt64(resp, "size", int64(len(data)))
+	setString(resp, "mime_type", mimeType(file, data))
+	return nil
+}
+
+// readBytes reads a file, or the range of it the request names. A range past the
+// end is cut short rather than refused, so a script can page through a file by
+// offset until it gets fewer bytes than it asked for.
+func (in *instance) readBytes(req, resp *dynamicpb.Message) error {
+	file, err := resolve(getString(req, "file"))
+	if err != nil {
+		return err
+	}
+	offset, length := getInt64(req, "offset"), getInt64(req, "length")
+	if length < 0 {
+		return fmt.Errorf("length must not be negative (0 reads to the end)")
+	}
+	f, err := in.openFile(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+	size := info.Size()
+	if offset < 0 {
+		offset = max(size+offset, 0)
+	}
+	offset = min(offset, size)
+	n := size - offset
+	if length > 0 {
+		n = min(n, length)
+	}
+
+	data, err := io.ReadAll(io.NewSectionReader(f, offset, n))
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+	head := data
+	if offset > 0 {
+		head = make([]byte, sniffLength)
+		read, _ := f.ReadAt(head, 0)
+		head = head[:read]
+	}
+
+	setBytes(resp, "data", data)
+	setInt64(resp, "size", size)
+	setInt64(resp, "offset", offset)
+	setString(resp, "mime_type", mimeType(file, head))
+	if offset == 0 && int64(len(data)) == size {
+		setString(resp, "sha256", hash(data))
+	}
+	return nil
+}
+
+// openFile opens a file of the folder for reading, refusing a folder. The file
+// stays open after the root it was opened through is closed.
+func (in *instance) openFile(file string) (*os.File, error) {
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return nil, fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+	f, err := root.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	if info, err := f.Stat(); err == nil && info.IsDir() {
+		f.Close()
+		return nil, fmt.Errorf("%s is a folder, not a file (list it with ListFolder)", file)
+	}
+	return f, nil
+}
+
+// mimeType tells a file's type from its extension, or failing that from head, the
+// first bytes of its content.
+func mimeType(file string, head []byte) string {
+	if t := mime.TypeByExtension(path.Ext(file)); t != "" {
+		return t
+	}
+	return http.DetectContentType(head)
+}
+
+// content is what a create, write or append request puts in the file: the bytes
+// of a bytes variant's data, or a text variant's content.
+func content(req *dynamicpb.Message) []byte {
+	if req.Descriptor().Fields().ByName("data") != nil {
+		return getBytes(req, "data")
+	}
+	return []byte(getString(req, "content"))
+}
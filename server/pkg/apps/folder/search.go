@@ -0,0 +1,288 @@
+package folder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// defaultMaxResults bounds a search that sets no max_results.
+	defaultMaxResults = 100
+	// defaultMaxFileSize is the largest file a search that sets no max_file_size
+	// reads.
+	defaultMaxFileSize = 1 << 20
+	// maxExcerpt is about how much of a long line a match reports, in bytes.
+	maxExcerpt = 200
+)
+
+func (in *instance) glob(req, resp *dynamicpb.Message) error {
+	pattern, err := compileGlob(getString(req, "pattern"))
+	if err != nil {
+		return err
+	}
+	limit := in.limit
+	if n := int(getInt64(req, "limit")); n > 0 && n < limit {
+		limit = n
+	}
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	var files []string
+	truncated := false
+	err = walkFiles(root, pattern.base(), pattern.depth(), func(file string) bool {
+		if !pattern.match(file) {
+			return false
+		}
+		if len(files) >= limit {
+			truncated = true
+			return true
+		}
+		files = append(files, file)
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	setStringList(resp, "files", files)
+	setBool(resp, "truncated", truncated)
+	return nil
+}
+
+// search reads the text files the glob picks, line by line, and reports the first
+// match on each line. A file with a NUL byte in it is taken for binary and passed
+// over without a word, as grep does; one over the size limit is named in skipped,
+// since a match in it may be exactly what the script is after.
+func (in *instance) search(req, resp *dynamicpb.Message) error {
+	query := getString(req, "query")
+	if query == "" {
+		return fmt.Errorf("missing query")
+	}
+	expr := query
+	if !getBool(req, "regex") {
+		expr = regexp.QuoteMeta(query)
+	}
+	if getBool(req, "ignore_case") {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	globPattern := getString(req, "glob")
+	if strings.TrimSpace(globPattern) == "" {
+		globPattern = "**"
+	}
+	pattern, err := compileGlob(globPattern)
+	if err != nil {
+		return err
+	}
+	maxResults := int(getInt64(req, "max_results"))
+	if maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+	maxFileSize := getInt64(req, "max_file_size")
+	if maxFileSize <= 0 {
+		maxFileSize = defaultMaxFileSize
+	}
+
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+
+	matchesFd := resp.Descriptor().Fields().ByName("matches")
+	matches := resp.Mutable(matchesFd).List()
+	var skipped []string
+	truncated := false
+	var readErr error
+	err = walkFiles(root, pattern.base(), pattern.depth(), func(file string) bool {
+		if !pattern.match(file) {
+			return false
+		}
+		info, err := root.Stat(file)
+		if err != nil || !info.Mode().IsRegular() {
+			return false
+		}
+		if info.Size() > maxFileSize {
+			skipped = append(skipped, file)
+			return false
+		}
+		data, err := root.ReadFile(file)
+		if err != nil {
+			readErr = fmt.Errorf("reading %s: %w", file, err)
+			return true
+		}
+		if bytes.IndexByte(data, 0) >= 0 {
+			return false
+		}
+
+		line := 0
+		for text := range strings.Lines(string(data)) {
+			line++
+			text = strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+			loc := re.FindStringIndex(text)
+			if loc == nil {
+				continue
+			}
+			if matches.Len() >= maxResults {
+				truncated = true
+				return true
+			}
+			match := matches.NewElement().Message().Interface().(*dynamicpb.Message)
+			setString(match, "file", file)
+			setInt32(match, "line", int32(line))
+			setInt32(match, "column", int32(utf8.RuneCountInString(text[:loc[0]])+1))
+			setString(match, "excerpt", excerpt(text, loc[0]))
+			matches.Append(protoreflect.ValueOfMessage(match))
+		}
+		return false
+	})
+	if err == nil {
+		err = readErr
+	}
+	if err != nil {
+		return err
+	}
+	setBool(resp, "truncated", truncated)
+	setStringList(resp, "skipped", skipped)
+	return nil
+}
+
+// excerpt is line, or when it is long the part of it around the match starting at
+// start, cut on character boundaries and marked where it was cut.
+func excerpt(line string, start int) string {
+	if len(line) > maxExcerpt {
+		from := max(0, start-maxExcerpt/4)
+		to := min(len(line), from+maxExcerpt)
+		for from > 0 && !utf8.RuneStart(line[from]) {
+			from--
+		}
+		for to < len(line) && !utf8.RuneStart(line[to]) {
+			to--
+		}
+		cut := line[from:to]
+		if from > 0 {
+			cut = "…" + cut
+		}
+		if to < len(line) {
+			cut += "…"
+		}
+		line = cut
+	}
+	return strings.ToValidUTF8(line, "\uFFFD")
+}
+
+// walkFiles visits every file under folder, breadth-first and sorted within each
+// folder as listFolder does, until visit returns true. It does not go into folders
+// whose files would have more than depth path segments, unless depth is negative.
+// A folder that does not exist has no files, which is not an error: a pattern may
+// name one that is not there yet.
+func walkFiles(root *os.Root, folder string, depth int, visit func(file string) bool) error {
+	if folder != "." {
+		info, err := root.Stat(folder)
+		if errors.Is(err, fs.ErrNotExist) || err == nil && !info.IsDir() {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("listing %s: %w", folder, err)
+		}
+	}
+	for queue := []string{folder}; len(queue) > 0; queue = queue[1:] {
+		entries, err := readDir(root, queue[0])
+		if err != nil {
+			return fmt.Errorf("listing %s: %w", queue[0], err)
+		}
+		for _, e := range entries {
+			child := childPath(queue[0], e.Name())
+			if e.IsDir() {
+				if depth < 0 || strings.Count(child, "/")+2 <= depth {
+					queue = append(queue, child)
+				}
+			} else if visit(child) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// globPattern is a compiled GlobRequest.pattern: its path segments, one of which
+// may be "**".
+type globPattern []string
+
+func compileGlob(pattern string) (globPattern, error) {
+	name, err := clean("pattern", pattern)
+	if err != nil {
+		return nil, err
+	}
+	segments := strings.Split(name, "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return segments, nil
+}
+
+// base is the folder the pattern's leading literal segments name, the only one a
+// walk for it has to cover.
+func (p globPattern) base() string {
+	literal := 0
+	for literal < len(p)-1 && !strings.ContainsAny(p[literal], `*?[\`) {
+		literal++
+	}
+	if literal == 0 {
+		return "."
+	}
+	return strings.Join(p[:literal], "/")
+}
+
+// depth is how many path segments a matching file has, or -1 when a "**" lets it
+// have any number.
+func (p globPattern) depth() int {
+	if slices.Contains(p, "**") {
+		return -1
+	}
+	return len(p)
+}
+
+func (p globPattern) match(file string) bool {
+	return matchSegments(p, strings.Split(file, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(name); skip++ {
+				if matchSegments(pattern[1:], name[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,255 @@
+package folder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wham/kaja/v2/pkg/apps"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// settle is how long a file has to go quiet before its change is reported, so
+	// that the several writes one save makes are one event.
+	settle = 100 * time.Millisecond
+	// maxSettle bounds how long a file that never goes quiet holds its changes back.
+	maxSettle = time.Second
+)
+
+func (in *instance) Streams(methodPath string) bool {
+	m, ok := in.methods[lastSegment(methodPath)]
+	return ok && m.stream
+}
+
+// InvokeStream runs Watch, the one streaming method. It ends only when ctx does
+// or the watch fails.
+func (in *instance) InvokeStream(ctx context.Context, methodPath string, request []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error) {
+	name := lastSegment(methodPath)
+	m, ok := in.methods[name]
+	if !ok || !m.stream {
+		return nil, fmt.Errorf("unknown streaming method %q", name)
+	}
+	req := dynamicpb.NewMessage(m.input)
+	if len(request) > 0 {
+		if err := proto.Unmarshal(request, req); err != nil {
+			return nil, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	folder, err := resolveFolder(getString(req, "folder"))
+	if err != nil {
+		return nil, err
+	}
+	var pattern globPattern
+	if glob := getString(req, "glob"); strings.TrimSpace(glob) != "" {
+		if pattern, err = compileGlob(glob); err != nil {
+			return nil, err
+		}
+	}
+
+	root, err := os.OpenRoot(in.folder)
+	if err != nil {
+		return nil, fmt.Errorf("opening folder: %w", err)
+	}
+	defer root.Close()
+	if info, err := root.Stat(folder); err != nil {
+		return nil, fmt.Errorf("watching %s: %w", folder, err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("%s is a file, not a folder", folder)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("watching %s: %w", folder, err)
+	}
+	defer watcher.Close()
+	w := &watch{
+		in:        in,
+		root:      root,
+		watcher:   watcher,
+		recursive: getBool(req, "recursive"),
+		known:     map[string]bool{},
+		pending:   map[string]string{},
+	}
+	if err := w.add(folder, false); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(settle)
+	timer.Stop()
+	var first time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-watcher.Errors:
+			return nil, fmt.Errorf("watching %s: %w", folder, err)
+		case event := <-watcher.Events:
+			if !w.record(event) {
+				continue
+			}
+			if first.IsZero() {
+				first = time.Now()
+			}
+			timer.Reset(max(0, min(settle, time.Until(first.Add(maxSettle)))))
+		case <-timer.C:
+			for _, file := range w.order {
+				change, ok := w.pending[file]
+				if !ok || pattern != nil && !pattern.match(file) {
+					continue
+				}
+				event := dynamicpb.NewMessage(m.output)
+				setString(event, "type", change)
+				setString(event, "file", file)
+				setString(event, "path", in.absolute(file))
+				// A file can be in order twice, when what was pending for it came to
+				// nothing and it changed again.
+				delete(w.pending, file)
+				out, err := proto.Marshal(event)
+				if err == nil {
+					err = send(out)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			w.order, w.pending, first = nil, map[string]string{}, time.Time{}
+		}
+	}
+}
+
+// watch is the state of one Watch call. fsnotify watches a folder's entries, not
+// the tree under it, so a recursive watch adds each folder as it appears. Every
+// path it handles is relative to the app's folder and read through root, which
+// keeps a watch as confined as any other method.
+type watch struct {
+	in        *instance
+	root      *os.Root
+	watcher   *fsnotify.Watcher
+	recursive bool
+	// known is the files there are, so that a file written over by a rename reads
+	// as modified rather than created, and a folder moved away deletes its files.
+	known map[string]bool
+	// pending is the changes not reported yet, by file, in the order they began.
+	pending map[string]string
+	order   []string
+	changes int
+}
+
+// add watches folder and notes its files, with subfolders too when the watch is
+// recursive. A folder that appears during the watch may have had files written
+// into it before it was added, so their creation is recorded then.
+func (w *watch) add(folder string, created bool) error {
+	if err := w.watcher.Add(w.in.absolute(folder)); err != nil {
+		return fmt.Errorf("watching %s: %w", folder, err)
+	}
+	entries, err := readDir(w.root, folder)
+	if err != nil {
+		return fmt.Errorf("watching %s: %w", folder, err)
+	}
+	for _, e := range entries {
+		child := childPath(folder, e.Name())
+		switch {
+		case e.IsDir():
+			if w.recursive {
+				if err := w.add(child, created); err != nil {
+					return err
+				}
+			}
+		case isTemporary(child):
+		case created:
+			w.change(child, "create")
+		default:
+			w.known[child] = true
+		}
+	}
+	return nil
+}
+
+// record turns an fsnotify event into a pending change, and reports whether it
+// made one.
+func (w *watch) record(event fsnotify.Event) bool {
+	rel, err := filepath.Rel(w.in.folder, event.Name)
+	if err != nil || !filepath.IsLocal(rel) {
+		return false
+	}
+	file := filepath.ToSlash(rel)
+	if isTemporary(file) {
+		return false
+	}
+	before := w.changes
+
+	switch {
+	case event.Has(fsnotify.Create):
+		info, err := w.root.Lstat(file)
+		if err != nil {
+			return false
+		}
+		if info.IsDir() {
+			// A folder that cannot be watched, gone again already say, is left
+			// out rather than ending the watch.
+			if w.recursive {
+				w.add(file, true)
+			}
+			break
+		}
+		if w.known[file] {
+			w.change(file, "modify")
+		} else {
+			w.change(file, "create")
+		}
+	case event.Has(fsnotify.Write):
+		w.change(file, "modify")
+	case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+		if w.known[file] {
+			w.change(file, "delete")
+			break
+		}
+		// A folder moved away reports nothing for the files it takes along.
+		var gone []string
+		for known := range w.known {
+			if strings.HasPrefix(known, file+"/") {
+				gone = append(gone, known)
+			}
+		}
+		slices.Sort(gone)
+		for _, known := range gone {
+			w.change(known, "delete")
+		}
+	}
+	return w.changes > before
+}
+
+// change notes a change to file, folded into any still pending for it: a file
+// created and then written is created, one created and deleted before either is
+// reported never was, and one deleted and created again is modified.
+func (w *watch) change(file, change string) {
+	w.changes++
+	switch change {
+	case "delete":
+		delete(w.known, file)
+	default:
+		w.known[file] = true
+	}
+	previous, pending := w.pending[file]
+	if !pending {
+		w.pending[file] = change
+		w.order = append(w.order, file)
+		return
+	}
+	switch {
+	case previous == "create" && change == "delete":
+		delete(w.pending, file)
+	case previous == "create":
+	case previous == "delete" && change == "create":
+		w.pending[file] = "modify"
+	default:
+		w.pending[file] = change
+	}
+}
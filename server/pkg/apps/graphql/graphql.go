@@ -0,0 +1,146 @@
+// Package graphql implements the built-in "graphql" app: it reads a GraphQL
+// schema - from an introspection query against the endpoint, or from a
+// workspace SDL file - and renders it as a proto surface kaja can browse and
+// call.
+//
+// Each field of the query type becomes a method of a Queries service, and each
+// field of the mutation type one of a Mutations service. A method's request is
+// the field's arguments, plus the selection set to ask for or the depth to
+// generate one to, and its response is the field's value. Calls are transcoded
+// into a POST of the GraphQL document and its variables, with the credential kaja
+// holds for the app; a response whose errors left nothing resolved is an
+// apps.UpstreamError.
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wham/kaja/v2/internal/workspace"
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+	"github.com/wham/kaja/v2/pkg/apps/rpc"
+	"github.com/wham/kaja/v2/pkg/retry"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// defaultDepth is how deep the generated selection set goes for an app that
+// sets no depth: the value's own fields and those of the objects it refers to.
+const defaultDepth = 2
+
+// App is the graphql app factory. Register it with the apps.Manager.
+type App struct{}
+
+func New() *App { return &App{} }
+
+func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
+	endpoint := strings.TrimSpace(parameters["url"])
+	if endpoint == "" {
+		return nil, fmt.Errorf("missing required parameter %q", "url")
+	}
+	if err := requireHTTPScheme(endpoint); err != nil {
+		return nil, err
+	}
+	log("GraphQL endpoint: " + endpoint)
+
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	depth := defaultDepth
+	if value := strings.TrimSpace(parameters["depth"]); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 || parsed > maxDepth {
+			return nil, fmt.Errorf("depth %q is not a number from 1 to %d", value, maxDepth)
+		}
+		if parsed > 0 {
+			depth = parsed
+		}
+	}
+
+	credential := Credential(parameters)
+	client := &http.Client{Timeout: 60 * time.Second}
+	schema, err := loadSchema(endpoint, workspace.Resolve(strings.TrimSpace(parameters["schema_file"])), credential, client, policy, log)
+	if err != nil {
+		return nil, err
+	}
+
+	gen, err := generateProto(schema)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(protoDir, "graphql.proto"), []byte(gen.proto), 0o644); err != nil {
+		return nil, fmt.Errorf("writing proto: %w", err)
+	}
+	log(fmt.Sprintf("Generated %d service(s) with %d method(s)", len(gen.serviceTypeNames), len(gen.bindings)))
+
+	methods, err := protogen.CompileMethods(protoDir, "graphql.proto", gen.bindings, bindMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apps.Opened{Instance: &instance{
+		endpoint:   endpoint,
+		schema:     schema,
+		methods:    methods,
+		credential: credential,
+		depth:      depth,
+		client:     client,
+		retry:      policy,
+	}}, nil
+}
+
+// Credential turns a graphql app's authentication parameters into the headers
+// each request carries, the way a gRPC app's are. A token with no scheme named
+// is a bearer token, which is what a GraphQL endpoint behind a login nearly
+// always wants.
+func Credential(parameters map[string]string) map[string]string {
+	if strings.TrimSpace(parameters["auth"]) == "" && strings.TrimSpace(parameters["token"]) != "" {
+		withScheme := make(map[string]string, len(parameters)+1)
+		for name, value := range parameters {
+			withScheme[name] = value
+		}
+		withScheme["auth"] = rpc.AuthBearer
+		parameters = withScheme
+	}
+	return rpc.Metadata(parameters)
+}
+
+// boundMethod is one generated method: the field it selects, and the
+// descriptors its request and response are decoded and encoded with.
+type boundMethod struct {
+	binding *binding
+	input   protoreflect.MessageDescriptor
+	output  protoreflect.MessageDescriptor
+}
+
+// bindMethod pairs a binding with the compiled method it is keyed by.
+func bindMethod(binding *binding, method protoreflect.MethodDescriptor) *boundMethod {
+	return &boundMethod{binding: binding, input: method.Input(), output: method.Output()}
+}
+
+// requireHTTPScheme rejects endpoints that are not plain HTTP(S), so a
+// configuration can't make the app issue requests over other schemes.
+func requireHTTPScheme(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q in %q (only http and https are allowed)", u.Scheme, rawURL)
+	}
+	return nil
+}
+
+func lastSegment(s string) string {
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,409 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/appstest"
+)
+
+const shopSchema = `"""The shop's API."""
+schema { query: Query mutation: Mutation }
+
+type Query {
+  "Look up one user."
+  user(id: ID!): User
+  users(first: Int = 10, status: Status, filter: UserFilter): [User]
+  search(term: String!): [SearchResult!]!
+  node(id: ID!): Node
+  count: Int!
+}
+
+type Mutation {
+  createUser(input: NewUser!): User
+}
+
+interface Node { id: ID! }
+
+type User implements Node {
+  id: ID!
+  name: String!
+  status: Status
+  posts(first: Int): [Post!]!
+  friends(first: Int!): [User!]!
+  createdAt: DateTime
+  legacy: String @deprecated(reason: "Use name.")
+}
+
+type Post implements Node {
+  id: ID!
+  title: String!
+  author: User
+}
+
+union SearchResult = User | Post
+
+enum Status { ACTIVE SUSPENDED }
+
+input UserFilter { status: Status nameContains: String tags: [String!] }
+
+input NewUser { name: String! status: Status = ACTIVE age: Int }
+
+scalar DateTime
+`
+
+// standIn stands in for a GraphQL endpoint: it answers each operation with its
+// canned reply and records the last request it got.
+type standIn struct {
+	url     string
+	request *http.Request
+	body    struct {
+		Query         string         `json:"query"`
+		Variables     map[string]any `json:"variables"`
+		OperationName string         `json:"operationName"`
+	}
+}
+
+func newStandIn(t *testing.T, status int, replies map[string]string) *standIn {
+	t.Helper()
+	s := &standIn{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		s.request = r
+		s.body.Variables = nil
+		json.Unmarshal(b, &s.body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, replies[s.body.OperationName])
+	}))
+	t.Cleanup(server.Close)
+	s.url = server.URL
+	return s
+}
+
+// openShop opens an app on the shop schema against the stand-in.
+func openShop(t *testing.T, s *standIn, parameters map[string]string) *instance {
+	t.Helper()
+	schemaFile := filepath.Join(t.TempDir(), "schema.graphql")
+	if err := os.WriteFile(schemaFile, []byte(shopSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	all := map[string]string{"url": s.url, "schema_file": schemaFile}
+	for name, value := range parameters {
+		all[name] = value
+	}
+	opened, err := New().Open(all, t.TempDir(), func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return opened.Instance.(*instance)
+}
+
+// call runs the query or mutation at path with headers sent along.
+func call(in *instance, path, requestJSON string, headers map[string]string) (map[string]any, error) {
+	method := in.methods[path]
+	return appstest.Call(in, path, method.input, method.output, requestJSON, headers)
+}
+
+func invoke(t *testing.T, in *instance, path, requestJSON string) map[string]any {
+	t.Helper()
+	resp, err := call(in, path, requestJSON, nil)
+	if err != nil {
+		t.Fatalf("Invoke %s %s: %v", path, requestJSON, err)
+	}
+	return resp
+}
+
+func TestGenerateProto(t *testing.T) {
+	in := openShop(t, newStandIn(t, http.StatusOK, nil), nil)
+	for _, path := range []string{"graphql.Queries/User", "graphql.Queries/Users", "graphql.Queries/Search", "graphql.Queries/Node", "graphql.Queries/Count", "graphql.Mutations/CreateUser"} {
+		if in.methods[path] == nil {
+			t.Errorf("missing method %s", path)
+		}
+	}
+
+	fields := in.methods["graphql.Queries/Users"].input.Fields()
+	if first := fields.ByJSONName("first"); first == nil || !first.HasPresence() {
+		t.Errorf("a nullable Int argument should have presence: %v", first)
+	}
+	if status := fields.ByJSONName("status"); status == nil || status.Kind().String() != "string" {
+		t.Errorf("an enum argument should be a string: %v", status)
+	}
+	if fields.ByJSONName("selection") == nil || fields.ByJSONName("depth") == nil {
+		t.Error("a method returning an object should take a selection and a depth")
+	}
+	if in.methods["graphql.Queries/Count"].input.Fields().ByJSONName("selection") != nil {
+		t.Error("a method returning a scalar has no selection to make")
+	}
+
+	search := in.methods["graphql.Queries/Search"].output.Fields().ByJSONName("search")
+	if !search.IsList() || search.Message().Fields().ByJSONName("__typename") == nil || search.Message().Fields().ByJSONName("title") == nil {
+		t.Errorf("a union should be a message with every member's fields and __typename: %v", search)
+	}
+	user := in.methods["graphql.Queries/User"].output.Fields().ByJSONName("user").Message()
+	if created := user.Fields().ByJSONName("createdAt"); created.Message() == nil || created.Message().FullName() != "google.protobuf.Value" {
+		t.Errorf("a custom scalar should be a google.protobuf.Value: %v", created)
+	}
+	if friends := user.Fields().ByJSONName("friends"); friends.Message().FullName() != user.FullName() {
+		t.Errorf("a type that refers to itself should reuse its message: %v", friends)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	s := newStandIn(t, http.StatusOK, map[string]string{
+		"User": `{"data":{"user":{"id":"u1","name":"Ada","status":"ACTIVE","posts":[{"id":"p1","title":"Hi"},null],"createdAt":"2024-01-01T00:00:00Z"}}}`,
+	})
+	in := openShop(t, s, nil)
+
+	got := invoke(t, in, "graphql.Queries/User", `{"id": "u1"}`)
+	if want := `query User($id: ID!) { user(id: $id) { id name status posts { id title } createdAt legacy } }`; s.body.Query != want {
+		t.Errorf("query = %s, want %s", s.body.Query, want)
+	}
+	if s.body.Variables["id"] != "u1" || len(s.body.Variables) != 1 {
+		t.Errorf("variables = %v", s.body.Variables)
+	}
+	if s.request.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", s.request.Header.Get("Content-Type"))
+	}
+
+	user := got["user"].(map[string]any)
+	if user["name"] != "Ada" || user["createdAt"] != "2024-01-01T00:00:00Z" {
+		t.Fatalf("user = %v", user)
+	}
+	// The post that could not be resolved is left out of the list.
+	if posts := user["posts"].([]any); len(posts) != 1 {
+		t.Fatalf("posts = %v", posts)
+	}
+}
+
+func TestVariables(t *testing.T) {
+	s := newStandIn(t, http.StatusOK, map[string]string{
+		"Users":      `{"data":{"users":[]}}`,
+		"CreateUser": `{"data":{"createUser":{"id":"u2","name":"Bo","status":"ACTIVE"}}}`,
+	})
+	in := openShop(t, s, map[string]string{"depth": "1"})
+
+	// An argument left unset is left out, so the field's own default applies.
+	invoke(t, in, "graphql.Queries/Users", `{}`)
+	if want := `query Users { users { id name status createdAt legacy } }`; s.body.Query != want || len(s.body.Variables) != 0 {
+		t.Errorf("query = %s %v, want %s", s.body.Query, s.body.Variables, want)
+	}
+
+	// One set to its zero is sent, and an input object keeps what was set of it.
+	invoke(t, in, "graphql.Queries/Users", `{"first": 0, "status": "SUSPENDED", "filter": {"nameContains": "a"}}`)
+	if !strings.HasPrefix(s.body.Query, `query Users($first: Int, $status: Status, $filter: UserFilter) { users(first: $first, status: $status, filter: $filter)`) {
+		t.Errorf("query = %s", s.body.Query)
+	}
+	filter, _ := s.body.Variables["filter"].(map[string]any)
+	if s.body.Variables["first"] != 0.0 || s.body.Variables["status"] != "SUSPENDED" || len(filter) != 1 || filter["nameContains"] != "a" {
+		t.Errorf("variables = %v", s.body.Variables)
+	}
+
+	got := invoke(t, in, "graphql.Mutations/CreateUser", `{"input": {"name": "Bo"}}`)
+	if !strings.HasPrefix(s.body.Query, `mutation CreateUser($input: NewUser!) { createUser(input: $input) {`) {
+		t.Errorf("query = %s", s.body.Query)
+	}
+	if input, _ := s.body.Variables["input"].(map[string]any); len(input) != 1 || input["name"] != "Bo" {
+		t.Errorf("variables = %v", s.body.Variables)
+	}
+	if got["createUser"].(map[string]any)["id"] != "u2" {
+		t.Errorf("createUser = %v", got)
+	}
+}
+
+func TestSelection(t *testing.T) {
+	s := newStandIn(t, http.StatusOK, map[string]string{
+		"User":   `{"data":{"user":{"id":"u1","nick":"ada"}}}`,
+		"Search": `{"data":{"search":[{"__typename":"Post","id":"p1","title":"Hi"}]}}`,
+		"Node":   `{"data":{"node":{"__typename":"User","id":"u1"}}}`,
+		"Count":  `{"data":{"count":1}}`,
+	})
+	in := openShop(t, s, nil)
+
+	// A selection written out replaces the generated one; an alias the response
+	// has no field for is left out.
+	got := invoke(t, in, "graphql.Queries/User", `{"id": "u1", "selection": "id nick: name"}`)
+	if want := `query User($id: ID!) { user(id: $id) { id nick: name } }`; s.body.Query != want {
+		t.Errorf("query = %s, want %s", s.body.Query, want)
+	}
+	if user := got["user"].(map[string]any); len(user) != 1 || user["id"] != "u1" {
+		t.Errorf("user = %v", user)
+	}
+
+	invoke(t, in, "graphql.Queries/User", `{"id": "u1", "depth": 3}`)
+	if !strings.Contains(s.body.Query, `posts { id title author { id name status createdAt legacy } }`) {
+		t.Errorf("depth 3 query = %s", s.body.Query)
+	}
+
+	got = invoke(t, in, "graphql.Queries/Search", `{"term": "hi"}`)
+	if want := `{ search(term: $term) { __typename ... on Post { id title author { id name status createdAt legacy } } ... on User { id name status posts { id title } createdAt legacy } } }`; !strings.HasSuffix(s.body.Query, want) {
+		t.Errorf("query = %s, want it to end %s", s.body.Query, want)
+	}
+	if result := got["search"].([]any)[0].(map[string]any); result["__typename"] != "Post" || result["title"] != "Hi" {
+		t.Errorf("search = %v", got)
+	}
+
+	// The interface selects its own fields once, and each type only what it adds.
+	invoke(t, in, "graphql.Queries/Node", `{"id": "u1", "depth": 1}`)
+	if want := `{ node(id: $id) { __typename id ... on Post { title } ... on User { name status createdAt legacy } } }`; !strings.HasSuffix(s.body.Query, want) {
+		t.Errorf("query = %s, want it to end %s", s.body.Query, want)
+	}
+
+	invoke(t, in, "graphql.Queries/Count", `{}`)
+	if want := `query Count { count }`; s.body.Query != want {
+		t.Errorf("query = %s, want %s", s.body.Query, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := newStandIn(t, http.StatusOK, map[string]string{
+		"User":  `{"data":{"user":null},"errors":[{"message":"not found","path":["user"]},{"message":"also bad"}]}`,
+		"Users": `{"data":{"users":[{"id":"u1","name":"Ada","posts":[]},null]},"errors":[{"message":"hidden","path":["users",1],"extensions":{"code":"FORBIDDEN"}}]}`,
+		"Count": `{"errors":[{"message":"Cannot query field \"count\""}]}`,
+	})
+	in := openShop(t, s, nil)
+
+	_, err := call(in, "graphql.Queries/User", `{"id": "u1"}`, nil)
+	var upstream *apps.UpstreamError
+	if !errors.As(err, &upstream) || upstream.Message != "user: not found (and 1 more)" || upstream.Status != http.StatusOK {
+		t.Fatalf("a field that resolved to nothing but errors = %v", err)
+	}
+	if upstream.RequestHeaders == nil || !strings.Contains(string(upstream.Body), "also bad") {
+		t.Errorf("the error should carry the exchange: %+v", upstream)
+	}
+	if _, err := call(in, "graphql.Queries/Count", `{}`, nil); !errors.As(err, &upstream) || upstream.Message != `Cannot query field "count"` {
+		t.Errorf("a response with no data = %v", err)
+	}
+
+	// Errors next to data are reported along with it.
+	got := invoke(t, in, "graphql.Queries/Users", `{}`)
+	if users := got["users"].([]any); len(users) != 1 {
+		t.Errorf("users = %v", users)
+	}
+	reported := got["errors"].([]any)[0].(map[string]any)
+	path := reported["path"].([]any)
+	if reported["message"] != "hidden" || len(path) != 2 || path[1] != "1" || reported["extensions"].(map[string]any)["code"] != "FORBIDDEN" {
+		t.Errorf("errors = %v", got["errors"])
+	}
+
+	failing := newStandIn(t, http.StatusUnauthorized, map[string]string{"User": `{"message":"token expired"}`})
+	in = openShop(t, failing, nil)
+	if _, err := call(in, "graphql.Queries/User", `{"id": "u1"}`, nil); !errors.As(err, &upstream) || upstream.Status != http.StatusUnauthorized || upstream.Message != "token expired" {
+		t.Errorf("a failed HTTP call = %v", err)
+	}
+}
+
+func TestCredential(t *testing.T) {
+	s := newStandIn(t, http.StatusOK, map[string]string{"Count": `{"data":{"count":3}}`})
+
+	in := openShop(t, s, map[string]string{"token": "secret"})
+	got, err := call(in, "graphql.Queries/Count", `{}`, map[string]string{"X-Tenant": "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["count"] != 3.0 || s.request.Header.Get("Authorization") != "Bearer secret" || s.request.Header.Get("X-Tenant") != "acme" {
+		t.Errorf("count = %v, headers = %v", got, s.request.Header)
+	}
+
+	in = openShop(t, s, map[string]string{"auth": "apikey", "token": "key", "api_key_name": "X-Shop-Key"})
+	invoke(t, in, "graphql.Queries/Count", `{}`)
+	if s.request.Header.Get("X-Shop-Key") != "key" || s.request.Header.Get("Authorization") != "" {
+		t.Errorf("headers = %v", s.request.Header)
+	}
+
+	// A header the app configures is the more specific instruction.
+	in = openShop(t, s, map[string]string{"auth": "basic", "username": "ada", "password": "pw"})
+	if _, err := call(in, "graphql.Queries/Count", `{}`, map[string]string{"Authorization": "Token override"}); err != nil {
+		t.Fatal(err)
+	}
+	if s.request.Header.Get("Authorization") != "Token override" {
+		t.Errorf("Authorization = %q", s.request.Header.Get("Authorization"))
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	const introspected = `{"data":{"__schema":{
+	  "queryType":{"name":"Root"},"mutationType":null,"subscriptionType":null,
+	  "types":[
+	    {"kind":"OBJECT","name":"Root","fields":[
+	      {"name":"hello","description":"Say hello.","args":[{"name":"name","type":{"kind":"SCALAR","name":"String"},"defaultValue":"\"world\""}],
+	       "type":{"kind":"NON_NULL","ofType":{"kind":"SCALAR","name":"String"}}},
+	      {"name":"me","args":[],"type":{"kind":"OBJECT","name":"Viewer"}},
+	      {"name":"grid","args":[],"type":{"kind":"LIST","ofType":{"kind":"LIST","ofType":{"kind":"SCALAR","name":"Int"}}}}
+	    ]},
+	    {"kind":"OBJECT","name":"Viewer","fields":[
+	      {"name":"login","args":[],"type":{"kind":"SCALAR","name":"String"}},
+	      {"name":"old","args":[],"type":{"kind":"SCALAR","name":"String"},"isDeprecated":true,"deprecationReason":"Gone."}
+	    ]},
+	    {"kind":"SCALAR","name":"String"}
+	  ]}}}`
+	var last struct {
+		Query string `json:"query"`
+	}
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&last)
+		authorization = r.Header.Get("Authorization")
+		switch {
+		case strings.Contains(last.Query, "__schema"):
+			io.WriteString(w, introspected)
+		case strings.HasPrefix(last.Query, "query Hello"):
+			io.WriteString(w, `{"data":{"hello":"hello, kaja"}}`)
+		default:
+			io.WriteString(w, `{"data":{"grid":[[1,null],[2]]}}`)
+		}
+	}))
+	defer server.Close()
+
+	protoDir := t.TempDir()
+	opened, err := New().Open(map[string]string{"url": server.URL, "token": "secret"}, protoDir, func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if authorization != "Bearer secret" {
+		t.Errorf("introspection Authorization = %q", authorization)
+	}
+	in := opened.Instance.(*instance)
+	if in.methods["graphql.Queries/Hello"] == nil || in.methods["graphql.Queries/Me"] == nil {
+		t.Fatalf("methods = %v", in.methods)
+	}
+	generated, _ := os.ReadFile(filepath.Join(protoDir, "graphql.proto"))
+	for _, want := range []string{"// Say hello.", `GraphQL type: String, default "world"`, "Deprecated: Gone.", "google.protobuf.Value grid = 1"} {
+		if !strings.Contains(string(generated), want) {
+			t.Errorf("generated proto is missing %q:\n%s", want, generated)
+		}
+	}
+
+	got := invoke(t, in, "graphql.Queries/Hello", `{"name": "kaja"}`)
+	if last.Query != `query Hello($name: String) { hello(name: $name) }` || got["hello"] != "hello, kaja" {
+		t.Errorf("query = %s, response = %v", last.Query, got)
+	}
+	// A list of lists is a JSON value, nulls and all.
+	got = invoke(t, in, "graphql.Queries/Grid", `{}`)
+	if grid := got["grid"].([]any); len(grid) != 2 || grid[0].([]any)[1] != nil {
+		t.Errorf("grid = %v", got)
+	}
+}
+
+func TestIntrospectionTurnedOff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"errors":[{"message":"GraphQL introspection is not allowed"}]}`)
+	}))
+	defer server.Close()
+
+	_, err := New().Open(map[string]string{"url": server.URL}, t.TempDir(), func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "introspection is not allowed") || !strings.Contains(err.Error(), "schema_file") {
+		t.Fatalf("Open = %v", err)
+	}
+	if _, err := New().Open(map[string]string{}, t.TempDir(), func(string) {}); err == nil {
+		t.Fatal("expected Open without a url to fail")
+	}
+}
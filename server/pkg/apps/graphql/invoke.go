@@ -0,0 +1,301 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
+)
+
+// instance is a live opened GraphQL app. It is a gRPC app: a call arrives as
+// protobuf, is transcoded into a GraphQL document and its variables POSTed to
+// the endpoint, and the field's value is shaped back into the protobuf
+// response.
+type instance struct {
+	endpoint   string
+	schema     *ast.Schema
+	methods    map[string]*boundMethod
+	credential map[string]string
+	// depth is how deep a call that sets none has its selection set generated.
+	depth  int
+	client *http.Client
+	retry  retry.Policy
+}
+
+func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	method := in.lookup(methodPath)
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %q", methodPath)
+	}
+	bound := method.binding
+
+	reqMsg := dynamicpb.NewMessage(method.input)
+	if len(request) > 0 {
+		if err := proto.Unmarshal(request, reqMsg); err != nil {
+			return nil, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	// Unpopulated fields are written out, so a non-null argument set to its zero
+	// is sent as that; variables leaves out what a nullable one didn't set.
+	reqJSON, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(reqMsg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request to JSON: %w", err)
+	}
+	fields := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(reqJSON))
+	// Numbers stay as they were written, so an Int past what a double holds
+	// exactly is sent as it is.
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("decoding request: %w", err)
+	}
+
+	document, variables := in.document(bound, fields)
+	body, err := json.Marshal(map[string]any{"query": document, "variables": variables, "operationName": bound.name})
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, in.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	// The app's configured headers are the more specific instruction, so they
+	// win over its credential.
+	for name, value := range apps.MergeMetadata(headers, in.credential) {
+		httpReq.Header.Set(name, value)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/graphql-response+json, application/json")
+	}
+	reqHeaders := apps.SurfaceHeaders(httpReq.Header)
+
+	resp, attempts, err := in.retry.Send(in.client.Do, httpReq)
+	reqHeaders = attempts.Record(reqHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s: %w", in.endpoint, err)
+	}
+	defer resp.Body.Close()
+	respHeaders := apps.SurfaceHeaders(resp.Header)
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	result, failure := readResponse(http.MethodPost, in.endpoint, resp.StatusCode, respBody)
+	if failure != nil {
+		return nil, failure.WithHeaders(reqHeaders, respHeaders)
+	}
+	data := map[string]json.RawMessage{}
+	if err := json.Unmarshal(result.Data, &data); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if isNull(data[bound.field.Name]) && len(result.Errors) > 0 {
+		failure := apps.NewUpstreamError(http.MethodPost, in.endpoint, resp.StatusCode, respBody)
+		failure.Message = errorsMessage(result.Errors)
+		return nil, failure.WithHeaders(reqHeaders, respHeaders)
+	}
+
+	respJSON, err := in.responseJSON(bound, data[bound.field.Name], result.Errors)
+	if err != nil {
+		return nil, err
+	}
+	// A custom selection set can ask for aliases the response has no field for;
+	// they are left out rather than failing the call.
+	respMsg := dynamicpb.NewMessage(method.output)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respJSON, respMsg); err != nil {
+		return nil, fmt.Errorf("decoding response JSON: %w", err)
+	}
+	out, err := proto.Marshal(respMsg)
+	if err != nil {
+		return nil, err
+	}
+	return &apps.InvokeResult{Body: out, RequestHeaders: reqHeaders, ResponseHeaders: respHeaders}, nil
+}
+
+// document writes the GraphQL document a call sends, and its variables. Each
+// argument the call set is passed as a variable of the argument's own type; one
+// it didn't is left out, so the field's default applies.
+func (in *instance) document(bound *binding, fields map[string]any) (string, map[string]any) {
+	variables := map[string]any{}
+	var declared, passed []string
+	for _, arg := range bound.field.Arguments {
+		value, keep := in.variable(fields[arg.Name], arg.Type)
+		if !keep {
+			continue
+		}
+		variables[arg.Name] = value
+		declared = append(declared, "$"+arg.Name+": "+arg.Type.String())
+		passed = append(passed, arg.Name+": $"+arg.Name)
+	}
+
+	var out strings.Builder
+	out.WriteString(bound.operation + " " + bound.name)
+	if len(declared) > 0 {
+		out.WriteString("(" + strings.Join(declared, ", ") + ")")
+	}
+	out.WriteString(" { " + bound.field.Name)
+	if len(passed) > 0 {
+		out.WriteString("(" + strings.Join(passed, ", ") + ")")
+	}
+	if bound.selectionKey != "" {
+		out.WriteString(" " + in.selection(bound, fields))
+	}
+	out.WriteString(" }")
+	return out.String(), variables
+}
+
+// selection is the selection set a call asks for: the one it wrote out, or one
+// generated to its depth.
+func (in *instance) selection(bound *binding, fields map[string]any) string {
+	if selection, _ := fields[bound.selectionKey].(string); strings.TrimSpace(selection) != "" {
+		return customSelection(selection)
+	}
+	depth := in.depth
+	if number, ok := fields[bound.depthKey].(json.Number); ok {
+		if requested, err := number.Int64(); err == nil && requested > 0 {
+			depth = int(requested)
+		}
+	}
+	return selectionSet(in.schema, in.schema.Types[bound.field.Type.Name()], depth)
+}
+
+// variable shapes one value of the request into the variable GraphQL expects
+// for type t, reporting whether it is sent at all. A null is not, and nor is an
+// empty list where the list may be null: the field's default is a better
+// reading of "not set" than an empty filter. An input object keeps only the
+// fields its type has, each shaped the same way.
+func (in *instance) variable(value any, t *ast.Type) (any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	if t.Elem != nil {
+		list, ok := value.([]any)
+		if !ok {
+			return value, true
+		}
+		if len(list) == 0 && !t.NonNull {
+			return nil, false
+		}
+		out := make([]any, len(list))
+		for i, item := range list {
+			out[i], _ = in.variable(item, t.Elem)
+		}
+		return out, true
+	}
+	def := in.schema.Types[t.NamedType]
+	object, ok := value.(map[string]any)
+	if def == nil || def.Kind != ast.InputObject || !ok {
+		return value, true
+	}
+	out := map[string]any{}
+	for _, field := range def.Fields {
+		if shaped, keep := in.variable(object[field.Name], field.Type); keep {
+			out[field.Name] = shaped
+		}
+	}
+	return out, true
+}
+
+// responseJSON builds the proto3-JSON of a call's response from the field's
+// value and the errors the server reported next to it.
+func (in *instance) responseJSON(bound *binding, raw json.RawMessage, errors []graphqlError) ([]byte, error) {
+	out := map[string]any{}
+	if !isNull(raw) {
+		var value any
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("decoding response: %w", err)
+		}
+		out[bound.resultKey] = in.tidy(value, bound.field.Type)
+	}
+	reported := make([]any, 0, len(errors))
+	for _, e := range errors {
+		reported = append(reported, map[string]any{
+			"message":    e.Message,
+			"path":       pathText(e.Path),
+			"extensions": json.RawMessage(orNull(e.Extensions)),
+		})
+	}
+	out[bound.errorsKey] = reported
+	return json.Marshal(out)
+}
+
+// tidy takes the nulls out of the lists in a value of type t. A repeated proto
+// field has no way to hold one; the item it stood for could not be resolved,
+// which the response's errors say.
+func (in *instance) tidy(value any, t *ast.Type) any {
+	if t.Elem != nil {
+		list, ok := value.([]any)
+		if !ok || t.Elem.Elem != nil {
+			// A list of lists is a google.protobuf.Value, which holds nulls.
+			return value
+		}
+		out := make([]any, 0, len(list))
+		for _, item := range list {
+			if item != nil {
+				out = append(out, in.tidy(item, t.Elem))
+			}
+		}
+		return out
+	}
+	def := in.schema.Types[t.NamedType]
+	object, ok := value.(map[string]any)
+	if def == nil || def.IsLeafType() || !ok {
+		return value
+	}
+	for name, item := range object {
+		if field := in.field(def, name); field != nil && item != nil {
+			object[name] = in.tidy(item, field.Type)
+		}
+	}
+	return object
+}
+
+// field finds a field of an object, or of any type an interface or a union can
+// be.
+func (in *instance) field(def *ast.Definition, name string) *ast.FieldDefinition {
+	if field := def.Fields.ForName(name); field != nil {
+		return field
+	}
+	for _, possible := range possibleTypes(in.schema, def) {
+		if field := possible.Fields.ForName(name); field != nil {
+			return field
+		}
+	}
+	return nil
+}
+
+// lookup finds a method by exact gRPC path, falling back to a match on the
+// method-name segment.
+func (in *instance) lookup(methodPath string) *boundMethod {
+	if m, ok := in.methods[methodPath]; ok {
+		return m
+	}
+	want := lastSegment(methodPath)
+	for path, m := range in.methods {
+		if lastSegment(path) == want {
+			return m
+		}
+	}
+	return nil
+}
+
+func orNull(raw json.RawMessage) json.RawMessage {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
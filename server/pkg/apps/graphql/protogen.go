@@ -0,0 +1,456 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+)
+
+// binding records what one generated method sends: the root field it selects,
+// under which operation, and where in the request and the response the app's
+// own fields are, which a field of the schema's could otherwise be named.
+type binding struct {
+	// operation is "query" or "mutation".
+	operation string
+	// name is the operation's name in the document, the method's own.
+	name  string
+	field *ast.FieldDefinition
+	// selectionKey and depthKey are the request's JSON names for the selection
+	// set and the depth it is generated to.
+	selectionKey string
+	depthKey     string
+	// resultKey and errorsKey are the response's JSON names for the field's
+	// value and for the errors the server reported next to it.
+	resultKey string
+	errorsKey string
+}
+
+// generated is the output of converting a schema: the proto file text, the
+// package-qualified names of the services in it, and the binding for each
+// generated method, keyed by its gRPC method path.
+type generated struct {
+	proto            string
+	serviceTypeNames []string
+	bindings         map[string]*binding
+}
+
+const protoPackage = "graphql"
+
+type fieldDef struct {
+	typ      string
+	name     string
+	number   int
+	jsonName string
+	repeated bool
+	// optional gives a scalar presence, so a nullable argument left unset is
+	// left out of the call rather than sent as its zero.
+	optional bool
+	doc      string
+}
+
+type messageDef struct {
+	name   string
+	doc    string
+	fields []fieldDef
+}
+
+type rpcDef struct {
+	name   string
+	input  string
+	output string
+	doc    string
+}
+
+type serviceDef struct {
+	name string
+	doc  string
+	rpcs []*rpcDef
+}
+
+type generator struct {
+	schema    *ast.Schema
+	messages  []*messageDef
+	usedNames map[string]bool
+	// typeNames maps a GraphQL type to the message already generated for it,
+	// which is also what keeps a type that refers to itself from recursing.
+	typeNames map[string]string
+
+	services []*serviceDef
+	bindings map[string]*binding
+}
+
+// generateProto converts a schema into the proto file kaja compiles and renders:
+// a Queries service with one method per field of the query type, and a Mutations
+// service with one per field of the mutation type. The types the fields take
+// and return become messages, each once, named as the schema names them.
+func generateProto(schema *ast.Schema) (*generated, error) {
+	g := &generator{
+		schema:    schema,
+		usedNames: map[string]bool{"GraphqlError": true},
+		typeNames: map[string]string{},
+		bindings:  map[string]*binding{},
+	}
+
+	g.addOperations("query", schema.Query, "Queries",
+		"The schema's queries. Each method selects one field of the query type: its\nrequest is the field's arguments, and its response the field's value.")
+	g.addOperations("mutation", schema.Mutation, "Mutations",
+		"The schema's mutations. Each method runs one field of the mutation type: its\nrequest is the field's arguments, and its response the field's value.")
+	if len(g.services) == 0 {
+		return nil, fmt.Errorf("the schema has no queries or mutations")
+	}
+
+	serviceTypeNames := make([]string, 0, len(g.services))
+	for _, service := range g.services {
+		serviceTypeNames = append(serviceTypeNames, protoPackage+"."+service.name)
+	}
+	return &generated{proto: g.render(), serviceTypeNames: serviceTypeNames, bindings: g.bindings}, nil
+}
+
+func (g *generator) addOperations(operation string, root *ast.Definition, serviceName, doc string) {
+	if root == nil {
+		return
+	}
+	fields := visibleFields(root.Fields)
+	if len(fields) == 0 {
+		return
+	}
+	// Services are named before any type can claim the name, since a schema's
+	// root type is as likely to be called Query as anything.
+	service := &serviceDef{name: g.reserve(serviceName), doc: doc}
+	g.services = append(g.services, service)
+
+	for _, field := range fields {
+		method := g.reserve(protogen.Identifier(field.Name, "Field"))
+		bound := &binding{operation: operation, name: method, field: field}
+
+		request := &messageDef{name: g.reserve(method + "Request")}
+		taken := map[string]bool{}
+		for _, arg := range field.Arguments {
+			typ, repeated := g.typeOf(arg.Type)
+			request.fields = append(request.fields, fieldDef{
+				typ: typ, name: uniqueField(taken, protogen.FieldName(arg.Name)), number: len(request.fields) + 1,
+				jsonName: arg.Name, repeated: repeated, optional: g.optional(arg.Type),
+				doc: g.fieldDoc(arg.Description, arg.Type, arg.DefaultValue, ""),
+			})
+		}
+		if !g.isLeaf(field.Type) {
+			bound.selectionKey = uniqueField(taken, "selection")
+			bound.depthKey = uniqueField(taken, "depth")
+			request.fields = append(request.fields,
+				fieldDef{typ: "string", name: bound.selectionKey, number: len(request.fields) + 1, jsonName: bound.selectionKey,
+					doc: "The selection set to ask for, e.g. \"{ id name }\", in place of the one generated\nfrom depth. Fields it leaves out are left unset."},
+				fieldDef{typ: "int32", name: bound.depthKey, number: len(request.fields) + 2, jsonName: bound.depthKey,
+					doc: "How many levels of objects the generated selection set follows: 1 selects\nthe value's own scalar fields, 2 those of the objects it refers to as well,\nand so on up to 8. 0 means the app's depth. Fields that need arguments are\nleft out."})
+		}
+		g.messages = append(g.messages, request)
+
+		response := &messageDef{name: g.reserve(method + "Response")}
+		taken = map[string]bool{}
+		bound.resultKey = field.Name
+		bound.errorsKey = "errors"
+		if field.Name == "errors" {
+			bound.errorsKey = "graphql_errors"
+		}
+		typ, repeated := g.typeOf(field.Type)
+		response.fields = append(response.fields,
+			fieldDef{typ: typ, name: uniqueField(taken, protogen.FieldName(field.Name)), number: 1, jsonName: bound.resultKey, repeated: repeated,
+				doc: g.fieldDoc(field.Description, field.Type, nil, deprecation(field.Directives))},
+			fieldDef{typ: "GraphqlError", name: uniqueField(taken, protogen.FieldName(bound.errorsKey)), number: 2, jsonName: bound.errorsKey, repeated: true,
+				doc: "What the server could not resolve, when it resolved the rest. A call that\nresolved nothing fails instead."})
+		g.messages = append(g.messages, response)
+
+		service.rpcs = append(service.rpcs, &rpcDef{name: method, input: request.name, output: response.name, doc: operationDoc(operation, field)})
+		g.bindings[protoPackage+"."+service.name+"/"+method] = bound
+	}
+}
+
+func operationDoc(operation string, field *ast.FieldDefinition) string {
+	parts := []string{}
+	if description := strings.TrimSpace(field.Description); description != "" {
+		parts = append(parts, description)
+	}
+	if reason := deprecation(field.Directives); reason != "" {
+		parts = append(parts, "Deprecated: "+reason)
+	}
+	parts = append(parts, "GraphQL "+operation+": "+field.Name+": "+field.Type.String())
+	return strings.Join(parts, "\n\n")
+}
+
+// typeOf is the proto type a GraphQL type travels as. A list is a repeated
+// field; a list of lists, which proto can't repeat twice, is the JSON value it
+// is.
+func (g *generator) typeOf(t *ast.Type) (string, bool) {
+	if t.Elem != nil {
+		if t.Elem.Elem != nil {
+			return "google.protobuf.Value", false
+		}
+		return g.namedType(t.Elem.NamedType), true
+	}
+	return g.namedType(t.NamedType), false
+}
+
+// namedType is a named GraphQL type's proto type. Enums travel as their names,
+// and a custom scalar - a DateTime, a JSON - as the JSON value it is, since the
+// schema does not say what that looks like.
+func (g *generator) namedType(name string) string {
+	def := g.schema.Types[name]
+	if def == nil {
+		return "google.protobuf.Value"
+	}
+	switch def.Kind {
+	case ast.Scalar:
+		switch name {
+		case "Int":
+			return "int32"
+		case "Float":
+			return "double"
+		case "String", "ID":
+			return "string"
+		case "Boolean":
+			return "bool"
+		}
+		return "google.protobuf.Value"
+	case ast.Enum:
+		return "string"
+	}
+	return g.typeMessage(def)
+}
+
+// typeMessage is the message for an object, interface, union or input object.
+// An interface or a union has the fields of every type it can be, and the
+// __typename that says which one it is.
+func (g *generator) typeMessage(def *ast.Definition) string {
+	if name, ok := g.typeNames[def.Name]; ok {
+		return name
+	}
+	message := &messageDef{name: g.reserve(protogen.Identifier(def.Name, "Type")), doc: strings.TrimSpace(def.Description)}
+	g.typeNames[def.Name] = message.name
+	g.messages = append(g.messages, message)
+
+	fields := visibleFields(def.Fields)
+	if def.Kind == ast.Interface || def.Kind == ast.Union {
+		seen := map[string]bool{}
+		for _, field := range fields {
+			seen[field.Name] = true
+		}
+		for _, possible := range possibleTypes(g.schema, def) {
+			for _, field := range visibleFields(possible.Fields) {
+				if !seen[field.Name] {
+					seen[field.Name] = true
+					fields = append(fields, field)
+				}
+			}
+		}
+	}
+
+	taken := map[string]bool{}
+	for _, field := range fields {
+		typ, repeated := g.typeOf(field.Type)
+		message.fields = append(message.fields, fieldDef{
+			typ: typ, name: uniqueField(taken, protogen.FieldName(field.Name)), number: len(message.fields) + 1,
+			jsonName: field.Name, repeated: repeated, optional: def.Kind == ast.InputObject && g.optional(field.Type),
+			doc: g.fieldDoc(field.Description, field.Type, field.DefaultValue, deprecation(field.Directives)),
+		})
+	}
+	if def.Kind == ast.Interface || def.Kind == ast.Union {
+		message.fields = append(message.fields, fieldDef{
+			typ: "string", name: uniqueField(taken, "typename"), number: len(message.fields) + 1, jsonName: "__typename",
+			doc: "The object's own type: one of " + strings.Join(possibleNames(possibleTypes(g.schema, def)), ", ") + ".",
+		})
+	}
+	return message.name
+}
+
+// possibleTypes are the object types an interface or a union can be, in name
+// order, so the surface is the same each time it is generated.
+func possibleTypes(schema *ast.Schema, def *ast.Definition) []*ast.Definition {
+	possible := append([]*ast.Definition(nil), schema.GetPossibleTypes(def)...)
+	if len(possible) == 0 && def.Kind == ast.Union {
+		for _, name := range def.Types {
+			if member := schema.Types[name]; member != nil {
+				possible = append(possible, member)
+			}
+		}
+	}
+	sort.Slice(possible, func(i, j int) bool { return possible[i].Name < possible[j].Name })
+	return possible
+}
+
+func possibleNames(defs []*ast.Definition) []string {
+	names := make([]string, len(defs))
+	for i, def := range defs {
+		names[i] = def.Name
+	}
+	return names
+}
+
+// optional reports whether an input of type t is a nullable scalar or enum,
+// which has to tell unset from its zero to leave an unset one out of the call.
+func (g *generator) optional(t *ast.Type) bool {
+	if t.NonNull || t.Elem != nil {
+		return false
+	}
+	def := g.schema.Types[t.NamedType]
+	if def == nil {
+		return false
+	}
+	switch def.Kind {
+	case ast.Enum:
+		return true
+	case ast.Scalar:
+		// A custom scalar is a google.protobuf.Value, which is a message and has
+		// presence of its own.
+		return g.namedType(t.NamedType) != "google.protobuf.Value"
+	}
+	return false
+}
+
+// isLeaf reports whether a field of type t is a scalar or an enum, or a list of
+// them, and so is selected without a selection set.
+func (g *generator) isLeaf(t *ast.Type) bool {
+	def := g.schema.Types[t.Name()]
+	return def == nil || def.IsLeafType()
+}
+
+// fieldDoc is what a field says about itself: the schema's description, its
+// GraphQL type, the values an enum allows, its default, and whether it is
+// deprecated. proto3 has no non-null, so the type in the comment is the only
+// way a caller learns an argument is required.
+func (g *generator) fieldDoc(description string, t *ast.Type, defaultValue *ast.Value, deprecated string) string {
+	parts := []string{}
+	if description = strings.TrimSpace(description); description != "" {
+		parts = append(parts, description)
+	}
+	line := "GraphQL type: " + t.String()
+	if defaultValue != nil {
+		line += ", default " + defaultValue.String()
+	}
+	parts = append(parts, line)
+	if def := g.schema.Types[t.Name()]; def != nil && def.Kind == ast.Enum && len(def.EnumValues) <= 24 {
+		values := make([]string, len(def.EnumValues))
+		for i, value := range def.EnumValues {
+			values[i] = value.Name
+		}
+		parts = append(parts, "One of: "+strings.Join(values, ", "))
+	}
+	if deprecated != "" {
+		parts = append(parts, "Deprecated: "+deprecated)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// visibleFields leaves out the introspection fields, __typename and the like,
+// which are the server's rather than the schema's.
+func visibleFields(fields ast.FieldList) []*ast.FieldDefinition {
+	visible := []*ast.FieldDefinition{}
+	for _, field := range fields {
+		if !strings.HasPrefix(field.Name, "__") {
+			visible = append(visible, field)
+		}
+	}
+	return visible
+}
+
+// reserve claims a name. Messages and services genuinely share one namespace;
+// method names are claimed out of the same pool though proto scopes them to
+// their service, so that a method is unique across the whole app and a call can
+// be routed by its name alone.
+func (g *generator) reserve(name string) string {
+	unique := name
+	for i := 2; g.usedNames[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.usedNames[unique] = true
+	return unique
+}
+
+// uniqueField claims a field name within one message. GraphQL tells userId from
+// user_id; their snake_case names don't.
+func uniqueField(taken map[string]bool, name string) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+func (g *generator) render() string {
+	var out strings.Builder
+	out.WriteString("syntax = \"proto3\";\n\npackage " + protoPackage + ";\n\nimport \"google/protobuf/struct.proto\";\n")
+	if description := strings.TrimSpace(g.schema.Description); description != "" {
+		out.WriteString("\n")
+		writeComment(&out, description, "")
+	}
+	out.WriteString(staticMessages)
+
+	for _, message := range g.messages {
+		out.WriteString("\n")
+		writeComment(&out, message.doc, "")
+		out.WriteString("message " + message.name + " {\n")
+		for _, field := range message.fields {
+			writeComment(&out, field.doc, "  ")
+			label := ""
+			switch {
+			case field.repeated:
+				label = "repeated "
+			case field.optional:
+				label = "optional "
+			}
+			out.WriteString("  " + label + field.typ + " " + field.name + " = " + strconv.Itoa(field.number) +
+				" [json_name = " + strconv.Quote(field.jsonName) + "];\n")
+		}
+		out.WriteString("}\n")
+	}
+
+	for _, service := range g.services {
+		out.WriteString("\n")
+		writeComment(&out, service.doc, "")
+		out.WriteString("service " + service.name + " {\n")
+		for i, rpc := range service.rpcs {
+			if i > 0 {
+				out.WriteString("\n")
+			}
+			writeComment(&out, rpc.doc, "  ")
+			out.WriteString("  rpc " + rpc.name + "(" + rpc.input + ") returns (" + rpc.output + ");\n")
+		}
+		out.WriteString("}\n")
+	}
+	return out.String()
+}
+
+// writeComment renders a doc block as proto line comments. Blank lines are kept,
+// so a description that has paragraphs still has them in the editor.
+func writeComment(out *strings.Builder, doc string, indent string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			out.WriteString(indent + "//\n")
+			continue
+		}
+		out.WriteString(indent + "// " + line + "\n")
+	}
+}
+
+// staticMessages are the shapes every GraphQL endpoint shares, which no schema
+// describes.
+const staticMessages = `
+// One error the server reported.
+message GraphqlError {
+  string message = 1 [json_name = "message"];
+  // Where in the response it happened: field names, and list indexes written
+  // as numbers.
+  repeated string path = 2 [json_name = "path"];
+  // Whatever else the server said about it, e.g. a code.
+  google.protobuf.Struct extensions = 3 [json_name = "extensions"];
+}
+`
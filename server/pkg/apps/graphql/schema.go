@@ -0,0 +1,399 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
+)
+
+// introspectionQuery asks the endpoint for everything the surface is generated
+// from: the root types, and every type's fields, arguments, input fields, enum
+// values and possible types. Type references are followed eight levels deep,
+// which covers [[Int!]!]! and anything a real schema nests.
+const introspectionQuery = `query IntrospectionQuery {
+  __schema {
+    description
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType { kind name ofType { kind name ofType { kind name ofType { kind name
+    ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } } }
+}`
+
+// introspection is the __schema an introspection query returns.
+type introspection struct {
+	Description      string           `json:"description"`
+	QueryType        *typeRef         `json:"queryType"`
+	MutationType     *typeRef         `json:"mutationType"`
+	SubscriptionType *typeRef         `json:"subscriptionType"`
+	Types            []introspectType `json:"types"`
+}
+
+type introspectType struct {
+	Kind          string            `json:"kind"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	Fields        []introspectField `json:"fields"`
+	InputFields   []inputValue      `json:"inputFields"`
+	Interfaces    []typeRef         `json:"interfaces"`
+	EnumValues    []enumValue       `json:"enumValues"`
+	PossibleTypes []typeRef         `json:"possibleTypes"`
+}
+
+type introspectField struct {
+	Name              string       `json:"name"`
+	Description       string       `json:"description"`
+	Args              []inputValue `json:"args"`
+	Type              typeRef      `json:"type"`
+	IsDeprecated      bool         `json:"isDeprecated"`
+	DeprecationReason string       `json:"deprecationReason"`
+}
+
+type inputValue struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Type         typeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+type enumValue struct {
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	IsDeprecated      bool   `json:"isDeprecated"`
+	DeprecationReason string `json:"deprecationReason"`
+}
+
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+// loadSchema reads the schema an app describes its API with: the file at
+// schemaFile when it names one - SDL, or the JSON an introspection query
+// returned - and otherwise an introspection query against the endpoint itself.
+func loadSchema(endpoint, schemaFile string, credential map[string]string, client *http.Client, policy retry.Policy, log func(string)) (*ast.Schema, error) {
+	if schemaFile != "" {
+		log("Reading GraphQL schema from " + schemaFile)
+		content, err := os.ReadFile(schemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading schema: %w", err)
+		}
+		if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+			return parseIntrospection(trimmed)
+		}
+		schema, err := gqlparser.LoadSchema(&ast.Source{Name: schemaFile, Input: string(content)})
+		if err != nil {
+			return nil, fmt.Errorf("parsing schema %s: %w", schemaFile, err)
+		}
+		return schema, nil
+	}
+
+	log("Introspecting " + endpoint)
+	body, _ := json.Marshal(map[string]any{"query": introspectionQuery, "operationName": "IntrospectionQuery"})
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/graphql-response+json, application/json")
+	for name, value := range credential {
+		req.Header.Set(name, value)
+	}
+	resp, _, err := policy.Send(client.Do, req)
+	if err != nil {
+		return nil, fmt.Errorf("introspecting %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return nil, fmt.Errorf("reading introspection response: %w", err)
+	}
+	result, failure := readResponse(http.MethodPost, endpoint, resp.StatusCode, respBody)
+	if failure != nil {
+		// A server that has turned introspection off says so as a GraphQL
+		// error in an answer that is otherwise fine, which is what the hint is
+		// for.
+		if resp.StatusCode < 400 {
+			failure.Message += " (if the server does not allow introspection, set schema_file to its SDL)"
+		}
+		return nil, failure
+	}
+	return parseIntrospection(result.Data)
+}
+
+// parseIntrospection reads an introspection result - the whole response, its
+// data, or the __schema alone - into the schema the surface is generated from.
+func parseIntrospection(content []byte) (*ast.Schema, error) {
+	var envelope struct {
+		Data *struct {
+			Schema *introspection `json:"__schema"`
+		} `json:"data"`
+		Schema *introspection `json:"__schema"`
+	}
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, fmt.Errorf("parsing introspection result: %w", err)
+	}
+	found := envelope.Schema
+	if envelope.Data != nil && envelope.Data.Schema != nil {
+		found = envelope.Data.Schema
+	}
+	if found == nil {
+		return nil, fmt.Errorf("the introspection result has no __schema")
+	}
+	return found.schema()
+}
+
+// schema turns an introspection result into the same shape an SDL file parses
+// into, so the rest of the app reads one kind of schema.
+func (in *introspection) schema() (*ast.Schema, error) {
+	schema := &ast.Schema{Description: in.Description}
+	for _, t := range in.Types {
+		def := &ast.Definition{Kind: ast.DefinitionKind(t.Kind), Name: t.Name, Description: t.Description}
+		for _, f := range t.Fields {
+			field := &ast.FieldDefinition{Name: f.Name, Description: f.Description, Type: astType(f.Type)}
+			for _, arg := range f.Args {
+				field.Arguments = append(field.Arguments, &ast.ArgumentDefinition{
+					Name:         arg.Name,
+					Description:  arg.Description,
+					Type:         astType(arg.Type),
+					DefaultValue: literal(arg.DefaultValue),
+				})
+			}
+			if f.IsDeprecated {
+				field.Directives = deprecated(f.DeprecationReason)
+			}
+			def.Fields = append(def.Fields, field)
+		}
+		for _, f := range t.InputFields {
+			def.Fields = append(def.Fields, &ast.FieldDefinition{
+				Name:         f.Name,
+				Description:  f.Description,
+				Type:         astType(f.Type),
+				DefaultValue: literal(f.DefaultValue),
+			})
+		}
+		for _, v := range t.EnumValues {
+			value := &ast.EnumValueDefinition{Name: v.Name, Description: v.Description}
+			if v.IsDeprecated {
+				value.Directives = deprecated(v.DeprecationReason)
+			}
+			def.EnumValues = append(def.EnumValues, value)
+		}
+		for _, i := range t.Interfaces {
+			def.Interfaces = append(def.Interfaces, i.Name)
+		}
+		for _, p := range t.PossibleTypes {
+			if def.Kind == ast.Union {
+				def.Types = append(def.Types, p.Name)
+			}
+		}
+		schema.AddTypes(def)
+	}
+
+	// A server may leave the built-in scalars out of what it lists; they exist
+	// all the same.
+	for _, name := range []string{"Int", "Float", "String", "Boolean", "ID"} {
+		if schema.Types[name] == nil {
+			schema.AddTypes(&ast.Definition{Kind: ast.Scalar, Name: name, BuiltIn: true})
+		}
+	}
+	for _, t := range in.Types {
+		for _, p := range t.PossibleTypes {
+			if possible := schema.Types[p.Name]; possible != nil {
+				schema.AddPossibleType(t.Name, possible)
+			}
+		}
+	}
+
+	root := func(ref *typeRef) (*ast.Definition, error) {
+		if ref == nil || ref.Name == "" {
+			return nil, nil
+		}
+		def := schema.Types[ref.Name]
+		if def == nil {
+			return nil, fmt.Errorf("the introspection result names root type %s but does not list it", ref.Name)
+		}
+		return def, nil
+	}
+	var err error
+	if schema.Query, err = root(in.QueryType); err != nil {
+		return nil, err
+	}
+	if schema.Mutation, err = root(in.MutationType); err != nil {
+		return nil, err
+	}
+	if schema.Subscription, err = root(in.SubscriptionType); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// astType follows a type reference's wrappers down to the named type.
+func astType(ref typeRef) *ast.Type {
+	switch ref.Kind {
+	case "NON_NULL":
+		if ref.OfType == nil {
+			return &ast.Type{NamedType: ref.Name, NonNull: true}
+		}
+		inner := astType(*ref.OfType)
+		inner.NonNull = true
+		return inner
+	case "LIST":
+		if ref.OfType == nil {
+			return &ast.Type{Elem: &ast.Type{NamedType: "String"}}
+		}
+		return &ast.Type{Elem: astType(*ref.OfType)}
+	}
+	return &ast.Type{NamedType: ref.Name}
+}
+
+// literal keeps a default value as the GraphQL text introspection gives it. It
+// is only ever shown, so it is carried as a kind that prints its text as it is.
+func literal(text *string) *ast.Value {
+	if text == nil {
+		return nil
+	}
+	return &ast.Value{Raw: *text, Kind: ast.EnumValue}
+}
+
+func deprecated(reason string) ast.DirectiveList {
+	directive := &ast.Directive{Name: "deprecated"}
+	if reason != "" {
+		directive.Arguments = ast.ArgumentList{{Name: "reason", Value: &ast.Value{Raw: reason, Kind: ast.StringValue}}}
+	}
+	return ast.DirectiveList{directive}
+}
+
+// deprecation is the reason a deprecated field or enum value gives, "" for one
+// that is not deprecated, and "deprecated" for one that gives none.
+func deprecation(directives ast.DirectiveList) string {
+	directive := directives.ForName("deprecated")
+	if directive == nil {
+		return ""
+	}
+	if reason := directive.Arguments.ForName("reason"); reason != nil && reason.Value != nil && reason.Value.Raw != "" {
+		return reason.Value.Raw
+	}
+	return "deprecated"
+}
+
+// response is what a GraphQL endpoint answers: data, errors, or both, when a
+// part of the data could not be resolved.
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphqlError  `json:"errors"`
+}
+
+type graphqlError struct {
+	Message    string          `json:"message"`
+	Path       []any           `json:"path"`
+	Extensions json.RawMessage `json:"extensions"`
+}
+
+// readResponse reads an endpoint's answer. A failed HTTP call, an answer with no
+// data, and one whose only content is errors are an apps.UpstreamError whose
+// message is what the errors say; errors next to data are the caller's to
+// report along with it.
+func readResponse(method, endpoint string, status int, body []byte) (*response, *apps.UpstreamError) {
+	var result response
+	parsed := json.Unmarshal(body, &result) == nil
+	switch {
+	case parsed && len(result.Errors) > 0 && (status >= 400 || isNull(result.Data)):
+		failure := apps.NewUpstreamError(method, endpoint, status, body)
+		failure.Message = errorsMessage(result.Errors)
+		return nil, failure
+	case status >= 400:
+		return nil, apps.NewUpstreamError(method, endpoint, status, body)
+	case !parsed || isNull(result.Data):
+		failure := apps.NewUpstreamError(method, endpoint, status, body)
+		failure.Message = "the response is not a GraphQL result"
+		return nil, failure
+	}
+	return &result, nil
+}
+
+// errorsMessage summarises a response's errors as their first message, with
+// where it happened and how many more there are.
+func errorsMessage(errors []graphqlError) string {
+	first := errors[0]
+	message := first.Message
+	if message == "" {
+		message = "unknown error"
+	}
+	if len(first.Path) > 0 {
+		message = strings.Join(pathText(first.Path), ".") + ": " + message
+	}
+	if len(errors) > 1 {
+		message += fmt.Sprintf(" (and %d more)", len(errors)-1)
+	}
+	return message
+}
+
+// pathText writes an error's path - field names and list indexes - as text.
+func pathText(path []any) []string {
+	out := make([]string, len(path))
+	for i, segment := range path {
+		switch segment := segment.(type) {
+		case string:
+			out[i] = segment
+		case float64:
+			out[i] = fmt.Sprint(int64(segment))
+		default:
+			out[i] = fmt.Sprint(segment)
+		}
+	}
+	return out
+}
+
+func isNull(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || string(trimmed) == "null"
+}
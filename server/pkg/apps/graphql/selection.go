@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// maxDepth bounds the generated selection set. Each level can multiply what is
+// asked for by the width of the schema, and servers refuse deep queries anyway.
+const maxDepth = 8
+
+// selectionSet generates the selection set for a value of the named type,
+// following objects depth levels down. Every scalar and enum field is
+// selected; an object field is selected while there are levels left, and a field
+// that needs arguments never is, since there is nothing to give them. An
+// interface or a union selects __typename and the fields of each type it can be.
+func selectionSet(schema *ast.Schema, def *ast.Definition, depth int) string {
+	set := (&selector{schema: schema}).set(def, min(max(depth, 1), maxDepth))
+	if set == "" {
+		// An object whose every field is another object, at depth 1.
+		return "{ __typename }"
+	}
+	return set
+}
+
+type selector struct {
+	schema *ast.Schema
+}
+
+func (s *selector) set(def *ast.Definition, depth int) string {
+	if def == nil {
+		return ""
+	}
+	var parts []string
+	// selected is the type each field name was selected with. Fragments of one
+	// set can't select a name with two different types, which a union's members
+	// may well give it, so the first to select it keeps it.
+	selected := map[string]string{}
+	switch def.Kind {
+	case ast.Object:
+		parts = s.fields(def.Fields, depth, selected, nil)
+	case ast.Interface, ast.Union:
+		parts = append([]string{"__typename"}, s.fields(def.Fields, depth, selected, nil)...)
+		// What the interface selects itself, each of its types has already.
+		shared := map[string]bool{}
+		for name := range selected {
+			shared[name] = true
+		}
+		for _, possible := range possibleTypes(s.schema, def) {
+			if fields := s.fields(possible.Fields, depth, selected, shared); len(fields) > 0 {
+				parts = append(parts, "... on "+possible.Name+" { "+strings.Join(fields, " ")+" }")
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{ " + strings.Join(parts, " ") + " }"
+}
+
+func (s *selector) fields(fields ast.FieldList, depth int, selected map[string]string, shared map[string]bool) []string {
+	var parts []string
+	for _, field := range visibleFields(fields) {
+		if requiresArguments(field) || shared[field.Name] {
+			continue
+		}
+		if typ, ok := selected[field.Name]; ok && typ != field.Type.String() {
+			continue
+		}
+		def := s.schema.Types[field.Type.Name()]
+		part := field.Name
+		if def != nil && !def.IsLeafType() {
+			if depth <= 1 {
+				continue
+			}
+			sub := s.set(def, depth-1)
+			if sub == "" {
+				continue
+			}
+			part += " " + sub
+		}
+		selected[field.Name] = field.Type.String()
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// requiresArguments reports whether a field has an argument it can't be
+// selected without: one that is non-null and has no default.
+func requiresArguments(field *ast.FieldDefinition) bool {
+	for _, arg := range field.Arguments {
+		if arg.Type.NonNull && arg.DefaultValue == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// customSelection is a selection set a call wrote out itself, with the braces
+// it may have left off.
+func customSelection(selection string) string {
+	selection = strings.TrimSpace(selection)
+	if !strings.HasPrefix(selection, "{") {
+		selection = "{ " + selection + " }"
+	}
+	return selection
+}
@@ -0,0 +1,408 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
+)
+
+// instance is a live opened JSON-RPC app. It is a gRPC app: a call arrives as
+// protobuf, is transcoded into a JSON-RPC request - or a batch of them - POSTed
+// to the endpoint, and the result is shaped back into the protobuf response.
+type instance struct {
+	endpoint   string
+	methods    map[string]*boundMethod
+	credential map[string]string
+	client     *http.Client
+	retry      retry.Policy
+}
+
+// rpcRequest is a JSON-RPC 2.0 request. A notification has no id.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      any    `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response. Result is "null" when the server
+// answered null, and empty when it answered with an error instead.
+type response struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int64           `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// exchange is one POST to the endpoint: what came back, and the headers to
+// surface for it.
+type exchange struct {
+	status          int
+	body            []byte
+	requestHeaders  map[string]string
+	responseHeaders map[string]string
+}
+
+func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	method := in.lookup(methodPath)
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %q", methodPath)
+	}
+
+	reqMsg := dynamicpb.NewMessage(method.input)
+	if len(request) > 0 {
+		if err := proto.Unmarshal(request, reqMsg); err != nil {
+			return nil, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	set, full, err := requestJSON(reqMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	var respJSON []byte
+	var ex *exchange
+	if method.binding.batch != nil {
+		respJSON, ex, err = in.callBatch(method.binding.batch, set, full, headers)
+	} else {
+		respJSON, ex, err = in.callOne(method.binding, set, full, headers)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	respMsg := dynamicpb.NewMessage(method.output)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respJSON, respMsg); err != nil {
+		return nil, fmt.Errorf("decoding response JSON: %w", err)
+	}
+	out, err := proto.Marshal(respMsg)
+	if err != nil {
+		return nil, err
+	}
+	return &apps.InvokeResult{Body: out, RequestHeaders: ex.requestHeaders, ResponseHeaders: ex.responseHeaders}, nil
+}
+
+// callOne makes a single call and returns the proto3-JSON of its response.
+func (in *instance) callOne(bound *binding, set, full map[string]any, headers map[string]string) ([]byte, *exchange, error) {
+	body, err := json.Marshal(bound.call(1, set, full))
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding request: %w", err)
+	}
+	ex, err := in.post(body, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bound.notification {
+		if ex.status >= 400 {
+			return nil, nil, in.failed(ex, nil)
+		}
+		return []byte("{}"), ex, nil
+	}
+
+	var answer response
+	if json.Unmarshal(ex.body, &answer) != nil || (answer.Error == nil && answer.Result == nil) {
+		return nil, nil, in.failed(ex, nil)
+	}
+	if answer.Error != nil {
+		return nil, nil, in.failed(ex, answer.Error)
+	}
+	out := map[string]any{}
+	if !isNull(answer.Result) {
+		out[bound.resultKey] = answer.Result
+	}
+	respJSON, err := json.Marshal(out)
+	return respJSON, ex, err
+}
+
+// callBatch sends a Batch method's calls as one JSON-RPC batch and returns the
+// proto3-JSON of its response: a result for each call, in their order. A call the
+// server answered with an error has that error as its result, rather than failing
+// the batch; only a batch the server refused as a whole fails.
+func (in *instance) callBatch(batch *batchBinding, set, full map[string]any, headers map[string]string) ([]byte, *exchange, error) {
+	calls, _ := set["calls"].([]any)
+	if len(calls) == 0 {
+		return nil, nil, fmt.Errorf("a batch needs at least one call")
+	}
+	fullCalls, _ := full["calls"].([]any)
+
+	requests := make([]rpcRequest, len(calls))
+	bindings := make([]*binding, len(calls))
+	for i, call := range calls {
+		object, _ := call.(map[string]any)
+		if len(object) != 1 {
+			return nil, nil, fmt.Errorf("call %d sets %d methods; set the one method it calls", i+1, len(object))
+		}
+		for name, params := range object {
+			bound := batch.methods[name]
+			if bound == nil {
+				return nil, nil, fmt.Errorf("call %d: unknown method %q", i+1, name)
+			}
+			callSet, _ := params.(map[string]any)
+			var callFull map[string]any
+			if i < len(fullCalls) {
+				if object, ok := fullCalls[i].(map[string]any); ok {
+					callFull, _ = object[name].(map[string]any)
+				}
+			}
+			requests[i] = bound.call(i+1, callSet, callFull)
+			bindings[i] = bound
+		}
+	}
+
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding request: %w", err)
+	}
+	ex, err := in.post(body, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	var answers []response
+	if trimmed := bytes.TrimSpace(ex.body); ex.status >= 400 || (len(trimmed) > 0 && json.Unmarshal(trimmed, &answers) != nil) {
+		// A batch the server could not take at all is answered with a single
+		// error rather than an array.
+		var answer response
+		json.Unmarshal(trimmed, &answer)
+		return nil, nil, in.failed(ex, answer.Error)
+	}
+
+	answered := make(map[string]response, len(answers))
+	// An error the server could not tie to a call - one it could not read - has a
+	// null id, and is all there is to say about each call it did not answer.
+	unanswered := &rpcError{Message: "the server did not answer this call"}
+	for _, answer := range answers {
+		if isNull(answer.ID) {
+			if answer.Error != nil {
+				unanswered = answer.Error
+			}
+			continue
+		}
+		answered[string(bytes.TrimSpace(answer.ID))] = answer
+	}
+
+	results := make([]any, len(requests))
+	for i, bound := range bindings {
+		answer, ok := answered[strconv.Itoa(i+1)]
+		switch {
+		case bound.notification:
+			results[i] = map[string]any{}
+		case !ok:
+			results[i] = map[string]any{batch.errorKey: unanswered}
+		case answer.Error != nil:
+			results[i] = map[string]any{batch.errorKey: answer.Error}
+		default:
+			result := map[string]any{}
+			if !isNull(answer.Result) {
+				result[bound.resultKey] = answer.Result
+			}
+			results[i] = map[string]any{bound.method: result}
+		}
+	}
+	respJSON, err := json.Marshal(map[string]any{"results": results})
+	return respJSON, ex, err
+}
+
+// call builds the JSON-RPC request for a call with the given id. set is the
+// call's request as protojson writes it, without the fields left at their zero;
+// full is the same with them. A param the call left unset is not sent - unless
+// the method requires it, when its zero is.
+func (bound *binding) call(id int, set, full map[string]any) rpcRequest {
+	req := rpcRequest{JSONRPC: "2.0", Method: bound.method}
+	if !bound.notification {
+		req.ID = id
+	}
+	if len(bound.params) == 0 {
+		return req
+	}
+	value := func(p param) (any, bool) {
+		if v, ok := set[p.name]; ok {
+			return v, true
+		}
+		if p.required {
+			v, ok := full[p.name]
+			return v, ok
+		}
+		return nil, false
+	}
+	if bound.byPosition {
+		// A param left out in the middle is null; trailing ones are dropped, which
+		// is how a server tells an optional param was not given.
+		params := make([]any, len(bound.params))
+		n := 0
+		for i, p := range bound.params {
+			if v, ok := value(p); ok {
+				params[i] = v
+				n = i + 1
+			}
+		}
+		req.Params = params[:n]
+		return req
+	}
+	params := map[string]any{}
+	for _, p := range bound.params {
+		if v, ok := value(p); ok {
+			params[p.name] = v
+		}
+	}
+	req.Params = params
+	return req
+}
+
+// post sends a request body to the endpoint with the app's credential.
+func (in *instance) post(body []byte, headers map[string]string) (*exchange, error) {
+	req, err := http.NewRequest(http.MethodPost, in.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	// The app's configured headers are the more specific instruction, so they
+	// win over its credential.
+	for name, value := range apps.MergeMetadata(headers, in.credential) {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	reqHeaders := apps.SurfaceHeaders(req.Header)
+
+	resp, attempts, err := in.retry.Send(in.client.Do, req)
+	reqHeaders = attempts.Record(reqHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s: %w", in.endpoint, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return &exchange{
+		status:          resp.StatusCode,
+		body:            respBody,
+		requestHeaders:  reqHeaders,
+		responseHeaders: apps.SurfaceHeaders(resp.Header),
+	}, nil
+}
+
+func (in *instance) failed(ex *exchange, rpcErr *rpcError) *apps.UpstreamError {
+	return failed(in.endpoint, ex.status, ex.body, rpcErr).WithHeaders(ex.requestHeaders, ex.responseHeaders)
+}
+
+// failed is the UpstreamError for a call the server did not answer with a
+// result: its JSON-RPC error when it gave one, what the HTTP response says
+// otherwise.
+func failed(endpoint string, status int, body []byte, rpcErr *rpcError) *apps.UpstreamError {
+	failure := apps.NewUpstreamError(http.MethodPost, endpoint, status, body)
+	switch {
+	case rpcErr != nil:
+		failure.Message = fmt.Sprintf("%s (code %d)", rpcErr.Message, rpcErr.Code)
+	case status < 400:
+		failure.Message = "the response is not a JSON-RPC response"
+	}
+	return failure
+}
+
+// requestJSON writes a request message as the JSON its params are read from,
+// once without the fields left at their zero and once with them.
+func requestJSON(msg *dynamicpb.Message) (set, full map[string]any, err error) {
+	for _, options := range []protojson.MarshalOptions{{}, {EmitUnpopulated: true}} {
+		encoded, err := options.Marshal(msg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encoding request to JSON: %w", err)
+		}
+		decoded := map[string]any{}
+		decoder := json.NewDecoder(bytes.NewReader(encoded))
+		decoder.UseNumber()
+		if err := decoder.Decode(&decoded); err != nil {
+			return nil, nil, fmt.Errorf("decoding request: %w", err)
+		}
+		plain(decoded, msg.Descriptor())
+		if set == nil {
+			set = decoded
+		} else {
+			full = decoded
+		}
+	}
+	return set, full, nil
+}
+
+// plain turns the JSON protojson wrote for a message of type md back into the
+// JSON the server reads: a 64-bit integer, which protojson writes as a string so
+// JavaScript can't round it, is a number again.
+func plain(value any, md protoreflect.MessageDescriptor) any {
+	object, ok := value.(map[string]any)
+	if !ok || md.FullName().Parent() == "google.protobuf" {
+		return value
+	}
+	for key, item := range object {
+		fd := md.Fields().ByJSONName(key)
+		if fd == nil {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			if list, ok := item.([]any); ok {
+				for i := range list {
+					list[i] = plainValue(list[i], fd)
+				}
+			}
+		case fd.IsMap():
+			if entries, ok := item.(map[string]any); ok {
+				for name := range entries {
+					entries[name] = plainValue(entries[name], fd.MapValue())
+				}
+			}
+		default:
+			object[key] = plainValue(item, fd)
+		}
+	}
+	return object
+}
+
+func plainValue(value any, fd protoreflect.FieldDescriptor) any {
+	switch fd.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if text, ok := value.(string); ok {
+			return json.Number(text)
+		}
+	case protoreflect.MessageKind:
+		return plain(value, fd.Message())
+	}
+	return value
+}
+
+// lookup finds a method by exact gRPC path, falling back to a match on the
+// method-name segment.
+func (in *instance) lookup(methodPath string) *boundMethod {
+	if m, ok := in.methods[methodPath]; ok {
+		return m
+	}
+	want := lastSegment(methodPath)
+	for path, m := range in.methods {
+		if lastSegment(path) == want {
+			return m
+		}
+	}
+	return nil
+}
+
+func isNull(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || string(trimmed) == "null"
+}
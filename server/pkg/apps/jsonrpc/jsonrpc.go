@@ -0,0 +1,138 @@
+// Package jsonrpc implements the built-in "jsonrpc" app: it reads an OpenRPC
+// document - from a URL, a workspace file, or the server's own rpc.discover - and
+// renders it as a proto surface kaja can browse and call.
+//
+// Each method of the document becomes a method of one service, its request the
+// method's params and its response the method's result, with messages made from
+// their JSON Schemas the way the openapi app makes them. A Batch method sends any
+// number of calls to those methods in one JSON-RPC batch. Calls are transcoded
+// into JSON-RPC 2.0 requests POSTed with the credential kaja holds for the app;
+// an error the server answers with is an apps.UpstreamError.
+package jsonrpc
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wham/kaja/v2/internal/workspace"
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+	"github.com/wham/kaja/v2/pkg/apps/rpc"
+	"github.com/wham/kaja/v2/pkg/retry"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// App is the jsonrpc app factory. Register it with the apps.Manager.
+type App struct{}
+
+func New() *App { return &App{} }
+
+func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
+	override := strings.TrimSpace(parameters["url"])
+	if override != "" {
+		if err := requireHTTPScheme(override); err != nil {
+			return nil, err
+		}
+	}
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	credential := Credential(parameters)
+	client := &http.Client{Timeout: 60 * time.Second}
+
+	specURL := strings.TrimSpace(parameters["spec_url"])
+	doc, err := loadDocument(override, specURL, workspace.Resolve(strings.TrimSpace(parameters["spec_file"])), credential, client, policy, log)
+	if err != nil {
+		return nil, err
+	}
+	log(fmt.Sprintf("Loaded %q (OpenRPC %s) with %d method(s)", doc.Info.Title, doc.OpenRPC, len(doc.Methods)))
+
+	endpoint, err := resolveEndpoint(override, specURL, doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireHTTPScheme(endpoint); err != nil {
+		return nil, err
+	}
+	log("JSON-RPC endpoint: " + endpoint)
+
+	gen, err := generateProto(doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(protoDir, "jsonrpc.proto"), []byte(gen.proto), 0o644); err != nil {
+		return nil, fmt.Errorf("writing proto: %w", err)
+	}
+	if err := openapi.WriteHTTPProto(protoDir); err != nil {
+		return nil, err
+	}
+	log(fmt.Sprintf("Generated %s with %d method(s)", gen.serviceTypeName, len(gen.bindings)))
+
+	methods, err := protogen.CompileMethods(protoDir, "jsonrpc.proto", gen.bindings, bindMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apps.Opened{Instance: &instance{
+		endpoint:   endpoint,
+		methods:    methods,
+		credential: credential,
+		client:     client,
+		retry:      policy,
+	}}, nil
+}
+
+// Credential turns a jsonrpc app's authentication parameters into the headers
+// each request carries, the way a gRPC app's are. A token with no scheme named
+// is a bearer token.
+func Credential(parameters map[string]string) map[string]string {
+	if strings.TrimSpace(parameters["auth"]) == "" && strings.TrimSpace(parameters["token"]) != "" {
+		withScheme := make(map[string]string, len(parameters)+1)
+		for name, value := range parameters {
+			withScheme[name] = value
+		}
+		withScheme["auth"] = rpc.AuthBearer
+		parameters = withScheme
+	}
+	return rpc.Metadata(parameters)
+}
+
+// boundMethod is one generated method: the JSON-RPC call it makes, and the
+// descriptors its request and response are decoded and encoded with.
+type boundMethod struct {
+	binding *binding
+	input   protoreflect.MessageDescriptor
+	output  protoreflect.MessageDescriptor
+}
+
+// bindMethod pairs a binding with the compiled method it is keyed by.
+func bindMethod(binding *binding, method protoreflect.MethodDescriptor) *boundMethod {
+	return &boundMethod{binding: binding, input: method.Input(), output: method.Output()}
+}
+
+// requireHTTPScheme rejects URLs that are not plain HTTP(S), so a configuration
+// or a document can't make the app issue requests over other schemes.
+func requireHTTPScheme(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q in %q (only http and https are allowed)", u.Scheme, rawURL)
+	}
+	return nil
+}
+
+func lastSegment(s string) string {
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
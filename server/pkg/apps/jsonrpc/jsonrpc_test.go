@@ -0,0 +1,340 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/appstest"
+)
+
+const calculatorDocument = `{
+  "openrpc": "1.2.6",
+  "info": {"title": "Calculator", "version": "1.0.0"},
+  "methods": [
+    {
+      "name": "add",
+      "summary": "Add two numbers.",
+      "params": [
+        {"name": "a", "required": true, "schema": {"type": "integer", "format": "int64"}},
+        {"name": "b", "schema": {"type": "integer", "format": "int64"}}
+      ],
+      "result": {"name": "sum", "schema": {"type": "integer", "format": "int64"}}
+    },
+    {
+      "name": "greet",
+      "paramStructure": "by-name",
+      "params": [
+        {"$ref": "#/components/contentDescriptors/Name"},
+        {"name": "greeting", "schema": {"type": "string"}}
+      ],
+      "result": {"name": "greeting", "schema": {"$ref": "#/components/schemas/Greeting"}}
+    },
+    {"name": "log", "params": [{"name": "line", "schema": {"type": "string"}}]},
+    {"name": "fail", "params": [], "result": {"name": "nothing", "schema": {}}}
+  ],
+  "components": {
+    "schemas": {
+      "Greeting": {"type": "object", "properties": {"text": {"type": "string"}, "shouted": {"type": "boolean"}}}
+    },
+    "contentDescriptors": {
+      "Name": {"name": "name", "required": true, "description": "Who to greet.", "schema": {"type": "string"}}
+    }
+  }
+}`
+
+type rpcCall struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// calculator is a JSON-RPC server for calculatorDocument. It records the last
+// request it got, and answers a batch in reverse, as a server is free to.
+type calculator struct {
+	url     string
+	header  http.Header
+	request []byte
+}
+
+func newCalculator(t *testing.T) *calculator {
+	t.Helper()
+	c := &calculator{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.header = r.Header
+		c.request, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if trimmed := bytes.TrimSpace(c.request); trimmed[0] == '[' {
+			var calls []rpcCall
+			json.Unmarshal(trimmed, &calls)
+			var answers []json.RawMessage
+			for i := len(calls) - 1; i >= 0; i-- {
+				if answer := answerCall(calls[i]); answer != nil {
+					answers = append(answers, answer)
+				}
+			}
+			json.NewEncoder(w).Encode(answers)
+			return
+		}
+		var call rpcCall
+		json.Unmarshal(c.request, &call)
+		if answer := answerCall(call); answer != nil {
+			w.Write(answer)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	c.url = server.URL
+	return c
+}
+
+func answerCall(call rpcCall) json.RawMessage {
+	if len(call.ID) == 0 {
+		return nil
+	}
+	reply := func(field string, value any) json.RawMessage {
+		answer, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": call.ID, field: value})
+		return answer
+	}
+	switch call.Method {
+	case "rpc.discover":
+		return reply("result", json.RawMessage(calculatorDocument))
+	case "add":
+		var params []int64
+		json.Unmarshal(call.Params, &params)
+		sum := int64(0)
+		for _, p := range params {
+			sum += p
+		}
+		return reply("result", sum)
+	case "greet":
+		var params struct{ Name, Greeting string }
+		json.Unmarshal(call.Params, &params)
+		if params.Greeting == "" {
+			params.Greeting = "Hello"
+		}
+		return reply("result", map[string]any{"text": params.Greeting + ", " + params.Name})
+	case "fail":
+		return reply("error", map[string]any{"code": -32000, "message": "boom", "data": map[string]any{"why": "asked to"}})
+	}
+	return reply("error", map[string]any{"code": -32601, "message": "Method not found"})
+}
+
+func open(t *testing.T, parameters map[string]string) (*instance, string) {
+	t.Helper()
+	protoDir := t.TempDir()
+	opened, err := New().Open(parameters, protoDir, func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(protoDir, "jsonrpc.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opened.Instance.(*instance), string(generated)
+}
+
+// call runs one of the calculator's methods, by name.
+func call(in *instance, method, requestJSON string) (map[string]any, error) {
+	path := "jsonrpc.Calculator/" + method
+	bound := in.methods[path]
+	return appstest.Call(in, path, bound.input, bound.output, requestJSON, nil)
+}
+
+func invoke(t *testing.T, in *instance, method, requestJSON string) map[string]any {
+	t.Helper()
+	resp, err := call(in, method, requestJSON)
+	if err != nil {
+		t.Fatalf("Invoke %s %s: %v", method, requestJSON, err)
+	}
+	return resp
+}
+
+// sent is the request the calculator last got.
+func (c *calculator) sent(t *testing.T) rpcCall {
+	t.Helper()
+	var got rpcCall
+	if err := json.Unmarshal(c.request, &got); err != nil {
+		t.Fatalf("request %s: %v", c.request, err)
+	}
+	return got
+}
+
+func TestDiscover(t *testing.T) {
+	c := newCalculator(t)
+	in, generated := open(t, map[string]string{"url": c.url, "token": "secret"})
+	if c.header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("rpc.discover Authorization = %q", c.header.Get("Authorization"))
+	}
+	for _, method := range []string{"Add", "Greet", "Log", "Fail", "Batch"} {
+		if in.methods["jsonrpc.Calculator/"+method] == nil {
+			t.Errorf("missing method %s", method)
+		}
+	}
+	for _, want := range []string{
+		"// Add two numbers.\n  rpc Add(AddRequest) returns (AddResponse);",
+		`int64 a = 1 [json_name = "a", (kaja.http_required) = true];`,
+		"// Who to greet.",
+		`Greeting greeting = 1 [json_name = "greeting"];`,
+		"A notification: the server answers nothing.",
+		`repeated BatchCall calls = 1`,
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("generated proto is missing %q:\n%s", want, generated)
+		}
+	}
+}
+
+func TestCall(t *testing.T) {
+	c := newCalculator(t)
+	in, _ := open(t, map[string]string{"url": c.url})
+
+	// A 64-bit integer is sent as the number it is, and an optional param left
+	// unset at the end is left off.
+	got := invoke(t, in, "Add", `{"a": "9007199254740993"}`)
+	if sent := c.sent(t); sent.Method != "add" || string(sent.Params) != `[9007199254740993]` || string(sent.ID) != "1" {
+		t.Errorf("sent %s", c.request)
+	}
+	if got["sum"] != "9007199254740993" {
+		t.Errorf("sum = %v", got)
+	}
+	// A required param is sent at its zero.
+	invoke(t, in, "Add", `{"b": "2"}`)
+	if sent := c.sent(t); string(sent.Params) != `[0,2]` {
+		t.Errorf("sent %s", c.request)
+	}
+
+	got = invoke(t, in, "Greet", `{"name": "Ada"}`)
+	if sent := c.sent(t); string(sent.Params) != `{"name":"Ada"}` {
+		t.Errorf("sent %s", c.request)
+	}
+	if greeting := got["greeting"].(map[string]any); greeting["text"] != "Hello, Ada" {
+		t.Errorf("greeting = %v", got)
+	}
+
+	got = invoke(t, in, "Log", `{"line": "started"}`)
+	if sent := c.sent(t); sent.ID != nil || string(sent.Params) != `["started"]` || len(got) != 0 {
+		t.Errorf("notification sent %s, got %v", c.request, got)
+	}
+}
+
+func TestError(t *testing.T) {
+	c := newCalculator(t)
+	in, _ := open(t, map[string]string{"url": c.url})
+
+	_, err := call(in, "Fail", `{}`)
+	var upstream *apps.UpstreamError
+	if !errors.As(err, &upstream) || upstream.Message != "boom (code -32000)" || !strings.Contains(string(upstream.Body), "asked to") {
+		t.Fatalf("Invoke = %v", err)
+	}
+	if upstream.RequestHeaders == nil {
+		t.Error("the error should carry the headers exchanged")
+	}
+}
+
+func TestBatch(t *testing.T) {
+	c := newCalculator(t)
+	in, _ := open(t, map[string]string{"url": c.url})
+
+	got := invoke(t, in, "Batch", `{"calls": [
+		{"add": {"a": "1", "b": "2"}},
+		{"log": {"line": "x"}},
+		{"fail": {}},
+		{"greet": {"name": "Bo", "greeting": "Hi"}}
+	]}`)
+
+	var sent []rpcCall
+	if err := json.Unmarshal(c.request, &sent); err != nil || len(sent) != 4 {
+		t.Fatalf("sent %s", c.request)
+	}
+	if sent[0].Method != "add" || string(sent[0].Params) != `[1,2]` || sent[1].ID != nil || string(sent[3].ID) != "4" {
+		t.Errorf("sent %s", c.request)
+	}
+
+	results := got["results"].([]any)
+	if len(results) != 4 {
+		t.Fatalf("results = %v", results)
+	}
+	if sum := results[0].(map[string]any)["add"].(map[string]any)["sum"]; sum != "3" {
+		t.Errorf("add = %v", results[0])
+	}
+	if len(results[1].(map[string]any)) != 0 {
+		t.Errorf("a notification's result should be empty: %v", results[1])
+	}
+	if failure := results[2].(map[string]any)["error"].(map[string]any); failure["code"] != "-32000" || failure["message"] != "boom" || failure["data"].(map[string]any)["why"] != "asked to" {
+		t.Errorf("fail = %v", results[2])
+	}
+	if text := results[3].(map[string]any)["greet"].(map[string]any)["greeting"].(map[string]any)["text"]; text != "Hi, Bo" {
+		t.Errorf("greet = %v", results[3])
+	}
+
+	if _, err := call(in, "Batch", `{"calls": [{"add": {"a": "1"}, "log": {}}]}`); err == nil {
+		t.Error("a call that sets two methods should fail")
+	}
+	if _, err := call(in, "Batch", `{}`); err == nil {
+		t.Error("an empty batch should fail")
+	}
+}
+
+func TestSpecFile(t *testing.T) {
+	c := newCalculator(t)
+	var doc map[string]any
+	json.Unmarshal([]byte(calculatorDocument), &doc)
+	doc["servers"] = []any{map[string]any{
+		"url":       "${base}/rpc",
+		"variables": map[string]any{"base": map[string]any{"default": c.url}},
+	}}
+	content, _ := json.Marshal(doc)
+	specFile := filepath.Join(t.TempDir(), "openrpc.json")
+	if err := os.WriteFile(specFile, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	in, _ := open(t, map[string]string{"spec_file": specFile})
+	if in.endpoint != c.url+"/rpc" {
+		t.Errorf("endpoint = %s", in.endpoint)
+	}
+	if c.request != nil {
+		t.Errorf("a document read from a file needs no rpc.discover: %s", c.request)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	doc := &document{Servers: []server{{URL: "/rpc"}}}
+	for _, tc := range []struct {
+		override, specURL, want string
+	}{
+		{"http://localhost:8545", "https://example.com/openrpc.json", "http://localhost:8545"},
+		{"", "https://example.com/docs/openrpc.json", "https://example.com/rpc"},
+		{"", "", ""},
+	} {
+		got, err := resolveEndpoint(tc.override, tc.specURL, doc)
+		if got != tc.want || (tc.want == "") != (err != nil) {
+			t.Errorf("resolveEndpoint(%q, %q) = %q, %v; want %q", tc.override, tc.specURL, got, err, tc.want)
+		}
+	}
+}
+
+func TestDiscoverRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`)
+	}))
+	defer server.Close()
+
+	_, err := New().Open(map[string]string{"url": server.URL}, t.TempDir(), func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "Method not found (code -32601)") || !strings.Contains(err.Error(), "spec_url") {
+		t.Fatalf("Open = %v", err)
+	}
+	if _, err := New().Open(map[string]string{}, t.TempDir(), func(string) {}); err == nil {
+		t.Fatal("expected Open with no url and no document to fail")
+	}
+}
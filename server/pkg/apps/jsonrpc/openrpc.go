@@ -0,0 +1,218 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
+)
+
+// document is the part of an OpenRPC document the app reads.
+type document struct {
+	OpenRPC string `json:"openrpc"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Servers    []server  `json:"servers"`
+	Methods    []*method `json:"methods"`
+	Components struct {
+		Schemas            map[string]json.RawMessage    `json:"schemas"`
+		ContentDescriptors map[string]*contentDescriptor `json:"contentDescriptors"`
+	} `json:"components"`
+}
+
+type server struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+// method is one method of the document. A method without a result is a
+// notification: it is sent without an id, and the server answers nothing.
+type method struct {
+	Ref         string               `json:"$ref"`
+	Name        string               `json:"name"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Params      []*contentDescriptor `json:"params"`
+	Result      *contentDescriptor   `json:"result"`
+	// ParamStructure is "by-name", "by-position", or "either", the default.
+	ParamStructure string `json:"paramStructure"`
+	Deprecated     bool   `json:"deprecated"`
+}
+
+// contentDescriptor describes one parameter, or the result, of a method.
+type contentDescriptor struct {
+	Ref         string          `json:"$ref"`
+	Name        string          `json:"name"`
+	Summary     string          `json:"summary"`
+	Description string          `json:"description"`
+	Required    bool            `json:"required"`
+	Schema      json.RawMessage `json:"schema"`
+	Deprecated  bool            `json:"deprecated"`
+}
+
+// discoverHint is added to a failed rpc.discover, which is optional for a server
+// to answer.
+const discoverHint = " (if the server does not answer rpc.discover, set spec_url or spec_file to its OpenRPC document)"
+
+// loadDocument reads the app's OpenRPC document: from a workspace file, from a
+// URL, or from the endpoint itself through rpc.discover. The document is fetched
+// from a URL without the app's credential, since it is often published somewhere
+// other than the server; rpc.discover is a call like any other and carries it.
+func loadDocument(endpoint, specURL, specFile string, credential map[string]string, client *http.Client, policy retry.Policy, log func(string)) (*document, error) {
+	switch {
+	case specFile != "":
+		log("Reading OpenRPC document from " + specFile)
+		content, err := os.ReadFile(specFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading OpenRPC document: %w", err)
+		}
+		return parseDocument(content)
+
+	case specURL != "":
+		if err := requireHTTPScheme(specURL); err != nil {
+			return nil, err
+		}
+		log("Fetching OpenRPC document from " + specURL)
+		req, err := http.NewRequest(http.MethodGet, specURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		status, body, err := send(client, policy, req)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", specURL, err)
+		}
+		if status >= 400 {
+			return nil, apps.NewUpstreamError(http.MethodGet, specURL, status, body)
+		}
+		return parseDocument(body)
+
+	case endpoint != "":
+		log("Discovering the OpenRPC document of " + endpoint)
+		body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "rpc.discover"})
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range credential {
+			req.Header.Set(name, value)
+		}
+		status, respBody, err := send(client, policy, req)
+		if err != nil {
+			return nil, fmt.Errorf("calling rpc.discover on %s: %w", endpoint, err)
+		}
+		var answer response
+		if json.Unmarshal(respBody, &answer) != nil || answer.Error != nil || isNull(answer.Result) {
+			failure := failed(endpoint, status, respBody, answer.Error)
+			failure.Message += discoverHint
+			return nil, failure
+		}
+		return parseDocument(answer.Result)
+	}
+	return nil, fmt.Errorf("missing required parameter: provide %q, or %q or %q", "url", "spec_url", "spec_file")
+}
+
+// send makes one request with the app's retries and reads the response.
+func send(client *http.Client, policy retry.Policy, req *http.Request) (int, []byte, error) {
+	resp, _, err := policy.Send(client.Do, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return 0, nil, fmt.Errorf("reading response: %w", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// parseDocument reads an OpenRPC document and resolves the content descriptors
+// its methods refer to.
+func parseDocument(content []byte) (*document, error) {
+	var doc document
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("parsing OpenRPC document: %w", err)
+	}
+	if doc.OpenRPC == "" && len(doc.Methods) == 0 {
+		return nil, fmt.Errorf("not an OpenRPC document: it has no %q version and no methods", "openrpc")
+	}
+	for _, m := range doc.Methods {
+		if m.Ref != "" {
+			return nil, fmt.Errorf("method %s: a method kept in another document is not supported", m.Ref)
+		}
+		for i, param := range m.Params {
+			resolved, err := doc.resolve(param)
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", m.Name, err)
+			}
+			m.Params[i] = resolved
+		}
+		if m.Result != nil {
+			resolved, err := doc.resolve(m.Result)
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", m.Name, err)
+			}
+			m.Result = resolved
+		}
+	}
+	return &doc, nil
+}
+
+// resolve follows a "#/components/contentDescriptors/<name>" reference.
+func (doc *document) resolve(descriptor *contentDescriptor) (*contentDescriptor, error) {
+	if descriptor == nil || descriptor.Ref == "" {
+		return descriptor, nil
+	}
+	const prefix = "#/components/contentDescriptors/"
+	if !strings.HasPrefix(descriptor.Ref, prefix) {
+		return nil, fmt.Errorf("unsupported reference %q", descriptor.Ref)
+	}
+	target := doc.Components.ContentDescriptors[strings.TrimPrefix(descriptor.Ref, prefix)]
+	if target == nil || target.Ref != "" {
+		return nil, fmt.Errorf("unresolved reference %q", descriptor.Ref)
+	}
+	return target, nil
+}
+
+// resolveEndpoint determines the URL calls are POSTed to. The url parameter wins
+// over the document's first server, whose variables take their defaults and which
+// may be relative to the document's own URL.
+func resolveEndpoint(override, specURL string, doc *document) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	if len(doc.Servers) == 0 || doc.Servers[0].URL == "" {
+		return "", fmt.Errorf("missing required parameter %q: the OpenRPC document names no server", "url")
+	}
+	serverURL := doc.Servers[0].URL
+	for name, variable := range doc.Servers[0].Variables {
+		serverURL = strings.ReplaceAll(serverURL, "${"+name+"}", variable.Default)
+	}
+	ref, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL %q: %w", serverURL, err)
+	}
+	if ref.IsAbs() {
+		return serverURL, nil
+	}
+	if specURL == "" {
+		return "", fmt.Errorf("the OpenRPC document's server URL %q is relative; set %q", serverURL, "url")
+	}
+	base, err := url.Parse(specURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid spec URL: %w", err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
@@ -0,0 +1,201 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+)
+
+const protoPackage = "jsonrpc"
+
+// binding records how a generated method maps onto a JSON-RPC request, and where
+// its result goes in the method's response.
+type binding struct {
+	method       string  // the JSON-RPC method name
+	params       []param // in the order the document lists them
+	byPosition   bool    // params are sent as an array rather than an object
+	notification bool    // sent without an id; the server answers nothing
+	resultKey    string  // response-JSON key holding the result
+	// batch is set on the Batch method, which calls the other methods.
+	batch *batchBinding
+}
+
+type param struct {
+	name     string
+	required bool
+}
+
+// batchBinding maps the Batch method's calls onto the methods they call. A call
+// and its result both key the method by its JSON-RPC name.
+type batchBinding struct {
+	methods  map[string]*binding
+	errorKey string // result-JSON key holding a call's error
+}
+
+// generated is the output of converting a document: the proto file text, the
+// package-qualified name of its service, and the per-method bindings keyed by
+// the gRPC method path "<serviceTypeName>/<MethodName>".
+type generated struct {
+	proto           string
+	serviceTypeName string
+	bindings        map[string]*binding
+}
+
+// Schemas of the messages the Batch method adds. A code is an integer of any
+// size, and data whatever the server says.
+var (
+	integerSchema = json.RawMessage(`{"type": "integer", "format": "int64"}`)
+	stringSchema  = json.RawMessage(`{"type": "string"}`)
+	anySchema     = json.RawMessage(`{}`)
+)
+
+// generateProto converts an OpenRPC document into a proto file with a single
+// service: a method for each of the document's, whose request is its params and
+// whose response its result, and Batch. Messages are made from the params' and
+// results' JSON Schemas the way the schemas of an OpenAPI document are.
+func generateProto(doc *document) (*generated, error) {
+	schemas, err := openapi.NewSchemas(protoPackage, doc.Components.Schemas)
+	if err != nil {
+		return nil, err
+	}
+	serviceName := protogen.Identifier(doc.Info.Title, "JsonRpc")
+	serviceTypeName := protoPackage + "." + serviceName
+
+	var rpcs strings.Builder
+	usedRPC := map[string]bool{}
+	bindings := map[string]*binding{}
+	batch := &batchBinding{methods: map[string]*binding{}, errorKey: "error"}
+	var calls, results []openapi.SchemaField
+
+	for _, m := range doc.Methods {
+		if m.Name == "" {
+			continue
+		}
+		name := protogen.UniqueName(usedRPC, protogen.Identifier(m.Name, "Method"))
+		b := &binding{
+			method:       m.Name,
+			byPosition:   m.ParamStructure != "by-name",
+			notification: m.Result == nil,
+		}
+
+		var fields []openapi.SchemaField
+		for _, p := range m.Params {
+			if p == nil || p.Name == "" {
+				continue
+			}
+			b.params = append(b.params, param{name: p.Name, required: p.Required})
+			fields = append(fields, openapi.SchemaField{
+				Name:     p.Name,
+				Schema:   p.Schema,
+				Required: p.Required,
+				Doc:      describe(p.Summary, p.Description, p.Deprecated),
+			})
+		}
+		request, err := schemas.Message(name+"Request", fields)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", m.Name, err)
+		}
+
+		fields = nil
+		if m.Result != nil {
+			b.resultKey = m.Result.Name
+			if b.resultKey == "" {
+				b.resultKey = "result"
+			}
+			fields = append(fields, openapi.SchemaField{
+				Name:   b.resultKey,
+				Schema: m.Result.Schema,
+				Doc:    describe(m.Result.Summary, m.Result.Description, false),
+			})
+		}
+		response, err := schemas.Message(name+"Response", fields)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", m.Name, err)
+		}
+
+		doc := describe(m.Summary, m.Description, m.Deprecated)
+		if b.notification {
+			doc = strings.TrimSpace(doc + " A notification: the server answers nothing.")
+		}
+		writeRPC(&rpcs, name, request, response, doc)
+		bindings[serviceTypeName+"/"+name] = b
+
+		batch.methods[m.Name] = b
+		if m.Name == batch.errorKey {
+			batch.errorKey = "rpcError"
+		}
+		calls = append(calls, openapi.SchemaField{Name: m.Name, Type: request, Doc: doc})
+		results = append(results, openapi.SchemaField{Name: m.Name, Type: response})
+	}
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("the OpenRPC document has no methods")
+	}
+
+	rpcError, err := schemas.Message("RpcError", []openapi.SchemaField{
+		{Name: "code", Schema: integerSchema, Doc: "The error's code: -32601 when the method does not exist, -32602 when its params are wrong."},
+		{Name: "message", Schema: stringSchema},
+		{Name: "data", Schema: anySchema, Doc: "What else the server says about the error."},
+	})
+	if err != nil {
+		return nil, err
+	}
+	call, err := schemas.Message("BatchCall", calls)
+	if err != nil {
+		return nil, err
+	}
+	result, err := schemas.Message("BatchResult", append(results, openapi.SchemaField{
+		Name: batch.errorKey,
+		Type: rpcError,
+		Doc:  "Set in place of the method's response when the call failed.",
+	}))
+	if err != nil {
+		return nil, err
+	}
+	request, err := schemas.Message("BatchRequest", []openapi.SchemaField{
+		{Name: "calls", Type: call, Repeated: true, Doc: "Each call sets the one method it calls, with its params."},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := schemas.Message("BatchResponse", []openapi.SchemaField{
+		{Name: "results", Type: result, Repeated: true, Doc: "One result for each call, in the order of the calls; a notification's is empty."},
+	})
+	if err != nil {
+		return nil, err
+	}
+	name := protogen.UniqueName(usedRPC, "Batch")
+	writeRPC(&rpcs, name, request, response, "Sends several calls in one JSON-RPC batch.")
+	bindings[serviceTypeName+"/"+name] = &binding{batch: batch}
+
+	return &generated{
+		proto:           schemas.Proto("service " + serviceName + " {\n" + rpcs.String() + "}\n"),
+		serviceTypeName: serviceTypeName,
+		bindings:        bindings,
+	}, nil
+}
+
+func writeRPC(out *strings.Builder, name, request, response, doc string) {
+	if doc != "" {
+		fmt.Fprintf(out, "  // %s\n", doc)
+	}
+	fmt.Fprintf(out, "  rpc %s(%s) returns (%s);\n", name, request, response)
+}
+
+// describe is the one-line doc of a method or content descriptor: its summary,
+// or the first sentence of its description.
+func describe(summary, description string, deprecated bool) string {
+	text := strings.Join(strings.Fields(summary), " ")
+	if text == "" {
+		text = strings.Join(strings.Fields(description), " ")
+		if i := strings.Index(text, ". "); i > 0 {
+			text = text[:i+1]
+		}
+	}
+	if deprecated {
+		text = strings.TrimSpace(text + " Deprecated.")
+	}
+	return text
+}
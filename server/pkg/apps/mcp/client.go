@@ -3,19 +3,25 @@ package mcp
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
 )
 
-// Client speaks MCP to one server over the Streamable HTTP transport.
+// Client speaks MCP to one server, over the Streamable HTTP transport or, for a
+// local server kaja launches, over the server's stdin and stdout.
 //
 // It is dual-era. The modern revision carries the protocol version, the client's
 // identity and its capabilities in every request's `_meta` and has no session at
@@ -29,6 +35,17 @@ type Client struct {
 	http     *http.Client
 	// headers the app sends with every request, credential included.
 	headers map[string]string
+	// retry is how a request that fails in a way that passes is sent again.
+	retry retry.Policy
+	// stdio is the local server the client speaks to in place of endpoint.
+	stdio *stdioServer
+	// requests answers what the server asks of the client mid-call.
+	requests *requests
+	// oauth signs the client in to a server that wants an OAuth token, and
+	// holds the token it is given; callback is where the user comes back from
+	// signing in.
+	oauth    *Authorizer
+	callback string
 
 	mu sync.Mutex
 	// version is the protocol version settled on, legacy whether the handshake
@@ -38,10 +55,24 @@ type Client struct {
 	session   string
 	handshook bool
 	nextID    int64
+	// challenge is the WWW-Authenticate header of the last 401 the server
+	// answered, which says where it is signed in to.
+	challenge string
 	// greeting is what the server said about itself while the era was settled -
 	// a DiscoverResult or an InitializeResult - kept so reading the surface
 	// doesn't ask twice.
 	greeting json.RawMessage
+	// run is which run of a local server the era was settled with. A server
+	// started again has forgotten the handshake and is settled afresh.
+	run int
+	// asking is the asker of the call in flight, for a request a local server
+	// makes on its own stream rather than in answer to one call; notifying is
+	// its notifier, for the notifications it sends there.
+	asking    asker
+	notifying notifier
+	// listeners hear what a local server says outside of any call, for as long
+	// as they Listen.
+	listeners map[*notifier]struct{}
 }
 
 // NewClient builds a client for an MCP endpoint. It performs no I/O: the era and
@@ -50,6 +81,73 @@ func NewClient(endpoint string, headers map[string]string, httpClient *http.Clie
 	return &Client{endpoint: endpoint, http: httpClient, headers: headers, version: ProtocolVersion}
 }
 
+// NewStdioClient builds a client for a local server it launches with command,
+// each exchange bounded by timeout. It performs no I/O: the server is started by
+// the first call.
+func NewStdioClient(command Command, timeout time.Duration) *Client {
+	c := &Client{endpoint: command.String(), version: ProtocolVersion}
+	c.stdio = newStdioServer(command, timeout, func(method string, params json.RawMessage) (any, *jsonRPCError) {
+		c.mu.Lock()
+		ask := c.asking
+		c.mu.Unlock()
+		return c.requests.answer(method, params, ask)
+	}, func(method string, params json.RawMessage) {
+		c.mu.Lock()
+		notify := c.notifying
+		listening := make([]notifier, 0, len(c.listeners))
+		for listener := range c.listeners {
+			listening = append(listening, *listener)
+		}
+		c.mu.Unlock()
+		if notify != nil {
+			notify(method, params)
+		}
+		for _, listener := range listening {
+			listener(method, params)
+		}
+	})
+	return c
+}
+
+// Close stops the local server a stdio client launched. An HTTP client has
+// nothing to close.
+func (c *Client) Close() {
+	if c.stdio != nil {
+		c.stdio.Close()
+	}
+}
+
+// WithRetry returns the client with each request sent again, as policy allows,
+// when it fails in a way that passes.
+func (c *Client) WithRetry(policy retry.Policy) *Client {
+	c.retry = policy
+	return c
+}
+
+// WithRequests returns the client answering the server's requests - roots,
+// sampling, elicitation - as r configures.
+func (c *Client) WithRequests(r *requests) *Client {
+	c.requests = r
+	return c
+}
+
+// WithOAuth returns the client signing in through a, when the server wants a
+// token and the app's headers carry none. The user comes back from signing in to
+// callback, a redirect URI on kaja's own server, or to a loopback listener when
+// it is empty.
+func (c *Client) WithOAuth(a *Authorizer, callback string) *Client {
+	c.oauth, c.callback = a, callback
+	return c
+}
+
+// lastChallenge is the WWW-Authenticate header the server last refused a request
+// with, or empty when it has refused none.
+func (c *Client) lastChallenge() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.challenge
+}
+
 // Exchange is what one JSON-RPC call exchanged with the server, surfaced in the
 // client's Headers view.
 type Exchange struct {
@@ -61,16 +159,193 @@ type Exchange struct {
 // request metadata (modern) or the `initialize` handshake (legacy) is applied
 // here, so callers only ever name a method and its params.
 func (c *Client) Call(method string, params map[string]any, extra map[string]string) (json.RawMessage, *Exchange, error) {
+	return c.CallAsking(method, params, extra, nil)
+}
+
+// CallAsking is Call with someone to ask: an elicitation the server makes while
+// the call is in flight is put to ask. Without one it is declined.
+//
+// The server's requests arrive the way its era sends them. A legacy server
+// sends them on the response stream and gets the answer in a POST of its own; a
+// modern one finishes the call with an input_required result, and the call is
+// sent again with the answers until it completes.
+func (c *Client) CallAsking(method string, params map[string]any, extra map[string]string, ask asker) (json.RawMessage, *Exchange, error) {
+	return c.CallListening(method, params, extra, ask, nil)
+}
+
+// CallListening is CallAsking that also hears what the server says about the
+// call while it runs: the call carries a progress token, and the progress
+// notifications for it and the log messages the server sends meanwhile are
+// passed to notify in the order they come.
+func (c *Client) CallListening(method string, params map[string]any, extra map[string]string, ask asker, notify notifier) (json.RawMessage, *Exchange, error) {
+	if notify != nil {
+		token := rand.Text()
+		if params == nil {
+			params = map[string]any{}
+		}
+		params["_meta"] = map[string]any{"progressToken": token}
+		notify = notify.forCall(token)
+	}
+	if c.stdio != nil {
+		c.mu.Lock()
+		if run := c.stdio.generation(); run != c.run {
+			c.run, c.handshook = run, false
+		}
+		c.asking, c.notifying = ask, notify
+		c.mu.Unlock()
+	}
 	if err := c.ensureEra(); err != nil {
 		return nil, nil, err
 	}
-	return c.send(method, params, extra)
+	for round := 0; ; round++ {
+		result, exchange, err := c.send(method, params, extra, ask, notify)
+		if err != nil {
+			return nil, exchange, err
+		}
+		var pending struct {
+			ResultType    string `json:"resultType"`
+			InputRequests map[string]struct {
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			} `json:"inputRequests"`
+			RequestState json.RawMessage `json:"requestState"`
+		}
+		if json.Unmarshal(result, &pending) != nil || pending.ResultType != "input_required" {
+			return result, exchange, nil
+		}
+		if round == maxInputRounds {
+			return nil, exchange, fmt.Errorf("the server asked for input %d times without finishing the call", maxInputRounds)
+		}
+		responses := map[string]any{}
+		for key, request := range pending.InputRequests {
+			answer, rpcErr := c.requests.answer(request.Method, request.Params, ask)
+			if rpcErr != nil {
+				responses[key] = map[string]any{"error": rpcErr}
+				continue
+			}
+			responses[key] = answer
+		}
+		// The protocol metadata is written afresh for each attempt; the progress
+		// token stays the call's.
+		again := map[string]any{}
+		for key, value := range params {
+			again[key] = value
+		}
+		again["inputResponses"] = responses
+		if len(pending.RequestState) > 0 {
+			again["requestState"] = pending.RequestState
+		}
+		params = again
+	}
+}
+
+// Listen hears what the server says outside of any call - a resource that
+// changed, a list that did - and passes each notification to notify. It
+// returns once the client is listening, so nothing said after is missed, and
+// wait hears until ctx is done or the server stops saying anything. It is meant
+// for an open app, so the era is already settled. An HTTP server says it on the
+// event stream a GET on the endpoint opens; a local server says it on its
+// stdout, and is kept running meanwhile.
+func (c *Client) Listen(ctx context.Context, extra map[string]string, notify notifier) (wait func() error, err error) {
+	if c.stdio != nil {
+		c.mu.Lock()
+		if c.listeners == nil {
+			c.listeners = map[*notifier]struct{}{}
+		}
+		c.listeners[&notify] = struct{}{}
+		c.mu.Unlock()
+		stop := func() {
+			c.mu.Lock()
+			delete(c.listeners, &notify)
+			c.mu.Unlock()
+		}
+		held, err := c.stdio.hold(ctx)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		return func() error {
+			defer stop()
+			return held()
+		}, nil
+	}
+
+	c.mu.Lock()
+	version, legacy, session := c.version, c.legacy, c.session
+	c.mu.Unlock()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building the request to listen: %w", err)
+	}
+	for name, value := range c.headers {
+		request.Header.Set(name, value)
+	}
+	for name, value := range extra {
+		request.Header.Set(name, value)
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	request.Header.Set("MCP-Protocol-Version", version)
+	if legacy && session != "" {
+		request.Header.Set("Mcp-Session-Id", session)
+	}
+
+	// The stream is open for as long as the listener wants it, which no
+	// request timeout knows.
+	listening := *c.http
+	listening.Timeout = 0
+	response, err := c.doOn(&listening, request)
+	if err != nil {
+		return nil, fmt.Errorf("listening to %s: %w", c.endpoint, err)
+	}
+	if response.StatusCode >= 400 {
+		defer response.Body.Close()
+		payload, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+		if response.StatusCode == http.StatusMethodNotAllowed {
+			return nil, fmt.Errorf("the server offers no stream to hear it on (GET %s answered %s)", c.endpoint, response.Status)
+		}
+		refusal := apps.NewUpstreamError(http.MethodGet, c.endpoint, response.StatusCode, payload)
+		if response.StatusCode == http.StatusUnauthorized && c.usesOAuth(request) {
+			return nil, c.oauth.require(c.endpoint, c.callback, response.Header.Get("WWW-Authenticate"), refusal)
+		}
+		return nil, refusal
+	}
+
+	return func() error {
+		defer response.Body.Close()
+		var answerErr error
+		err := readSSE(response.Body, func(data []byte) bool {
+			var message struct {
+				ID     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if json.Unmarshal(bytes.TrimSpace(data), &message) != nil || message.Method == "" {
+				return false
+			}
+			if len(message.ID) > 0 {
+				// A request the server makes here is about no call, so there
+				// is no one to put an elicitation to.
+				result, rpcErr := c.requests.answer(message.Method, message.Params, nil)
+				answerErr = c.reply(message.ID, result, rpcErr)
+				return answerErr != nil
+			}
+			notify(message.Method, message.Params)
+			return false
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if answerErr != nil {
+			return answerErr
+		}
+		return err
+	}, nil
 }
 
 // send issues one request in the era already settled on, re-running a legacy
 // handshake once if the server has forgotten the session.
-func (c *Client) send(method string, params map[string]any, extra map[string]string) (json.RawMessage, *Exchange, error) {
-	result, exchange, err := c.attempt(method, params, extra)
+func (c *Client) send(method string, params map[string]any, extra map[string]string, ask asker, notify notifier) (json.RawMessage, *Exchange, error) {
+	result, exchange, err := c.attempt(method, params, extra, ask, notify)
 	if err == nil {
 		return result, exchange, nil
 	}
@@ -88,7 +363,7 @@ func (c *Client) send(method string, params map[string]any, extra map[string]str
 		if err := c.handshake(); err != nil {
 			return nil, nil, err
 		}
-		return c.attempt(method, params, extra)
+		return c.attempt(method, params, extra, ask, notify)
 	}
 
 	// A server that rejects the version names the ones it has; retry on the best
@@ -102,7 +377,7 @@ func (c *Client) send(method string, params map[string]any, extra map[string]str
 			if err := c.ensureEra(); err != nil {
 				return nil, nil, err
 			}
-			return c.attempt(method, params, extra)
+			return c.attempt(method, params, extra, ask, notify)
 		}
 	}
 	return nil, exchange, err
@@ -129,7 +404,7 @@ func (c *Client) ensureEra() error {
 		return c.handshake()
 	}
 
-	result, _, err := c.attempt("server/discover", nil, nil)
+	result, _, err := c.attempt("server/discover", nil, nil, nil, nil)
 	if err == nil {
 		c.mu.Lock()
 		c.handshook, c.greeting = true, result
@@ -137,6 +412,12 @@ func (c *Client) ensureEra() error {
 		return nil
 	}
 
+	// A server that wants the user signed in says so whichever era it speaks.
+	var required *AuthorizationRequired
+	if errors.As(err, &required) {
+		return err
+	}
+
 	// Only an error the modern revision defines identifies a modern server.
 	// Anything else - an unknown method, a rejected `_meta`, a bare HTTP status,
 	// a transport failure - is a server from the handshake era, or not a server
@@ -181,9 +462,9 @@ func (c *Client) handshake() error {
 
 	result, exchange, err := c.attempt("initialize", map[string]any{
 		"protocolVersion": version,
-		"capabilities":    map[string]any{},
+		"capabilities":    c.requests.capabilities(),
 		"clientInfo":      map[string]any{"name": clientName, "version": "2"},
-	}, nil)
+	}, nil, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -210,13 +491,13 @@ func (c *Client) handshake() error {
 	// The handshake is only complete once the server has been told so. It is a
 	// notification, so nothing is expected back and a server that refuses it is
 	// not worth failing the whole app over.
-	_, _, _ = c.attempt("notifications/initialized", nil, nil)
+	_, _, _ = c.attempt("notifications/initialized", nil, nil, nil, nil)
 	return nil
 }
 
 // attempt performs one HTTP POST carrying one JSON-RPC message. A notification
 // (a method with no id) returns no result.
-func (c *Client) attempt(method string, params map[string]any, extra map[string]string) (json.RawMessage, *Exchange, error) {
+func (c *Client) attempt(method string, params map[string]any, extra map[string]string, ask asker, notify notifier) (json.RawMessage, *Exchange, error) {
 	notification := strings.HasPrefix(method, "notifications/")
 
 	c.mu.Lock()
@@ -234,12 +515,16 @@ func (c *Client) attempt(method string, params map[string]any, extra map[string]
 	}
 	if !legacy && method != "initialize" {
 		// The modern era carries the protocol metadata in the body; the headers
-		// below only mirror it.
-		params["_meta"] = map[string]any{
-			metaProtocolVersion:    version,
-			metaClientInfo:         map[string]any{"name": clientName, "version": "2"},
-			metaClientCapabilities: map[string]any{},
+		// below only mirror it. What the caller put in _meta - a progress token -
+		// stays beside it.
+		meta := map[string]any{}
+		if given, ok := params["_meta"].(map[string]any); ok {
+			maps.Copy(meta, given)
 		}
+		meta[metaProtocolVersion] = version
+		meta[metaClientInfo] = map[string]any{"name": clientName, "version": "2"}
+		meta[metaClientCapabilities] = c.requests.capabilities()
+		params["_meta"] = meta
 	}
 	if len(params) > 0 {
 		message["params"] = params
@@ -250,6 +535,10 @@ func (c *Client) attempt(method string, params map[string]any, extra map[string]
 		return nil, nil, fmt.Errorf("encoding %s request: %w", method, err)
 	}
 
+	if c.stdio != nil {
+		return c.exchangeStdio(method, id, body, notification)
+	}
+
 	request, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
 	if err != nil {
 		return nil, nil, fmt.Errorf("building %s request: %w", method, err)
@@ -280,15 +569,41 @@ func (c *Client) attempt(method string, params map[string]any, extra map[string]
 		}
 	}
 
-	requestHeaders := apps.SurfaceHeaders(request.Header)
-	response, err := c.http.Do(request)
+	response, attempts, err := c.retry.Send(c.do, request)
+	requestHeaders := attempts.Record(apps.SurfaceHeaders(request.Header))
+	if c.signedIn(request) {
+		// The token is kaja's, not the app's: it is shown for what it is, not
+		// as itself.
+		requestHeaders["Authorization"] = "Bearer ‹OAuth token›"
+	}
 	if err != nil {
 		return nil, &Exchange{RequestHeaders: requestHeaders}, fmt.Errorf("calling %s: %w", c.endpoint, err)
 	}
 	defer response.Body.Close()
 
 	exchange := &Exchange{RequestHeaders: requestHeaders, ResponseHeaders: apps.SurfaceHeaders(response.Header)}
-	payload, err := io.ReadAll(io.LimitReader(response.Body, 32<<20))
+	if response.StatusCode == http.StatusUnauthorized {
+		c.mu.Lock()
+		c.challenge = response.Header.Get("WWW-Authenticate")
+		c.mu.Unlock()
+	}
+	if response.StatusCode == http.StatusUnauthorized && c.usesOAuth(request) {
+		payload, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+		refusal := apps.NewUpstreamError(http.MethodPost, c.endpoint, response.StatusCode, payload)
+		// A token that is still turned down once refreshed is no token at all.
+		c.oauth.signOut(c.endpoint)
+		return nil, exchange, c.oauth.require(c.endpoint, c.callback, response.Header.Get("WWW-Authenticate"), refusal)
+	}
+	contentType := response.Header.Get("Content-Type")
+	var payload []byte
+	if isEventStream(contentType) && !notification && response.StatusCode < 400 {
+		// The stream is read as it arrives: a request the server makes on it has
+		// to be answered before the response it is holding back will come.
+		payload, err = c.readStream(response.Body, ask, notify)
+		contentType = "application/json"
+	} else {
+		payload, err = io.ReadAll(io.LimitReader(response.Body, 32<<20))
+	}
 	if err != nil {
 		return nil, exchange, fmt.Errorf("reading %s response: %w", method, err)
 	}
@@ -299,7 +614,7 @@ func (c *Client) attempt(method string, params map[string]any, extra map[string]
 
 	// A JSON-RPC error may arrive under a 4xx status, so the body is read before
 	// the status is judged.
-	result, rpcErr, decodeErr := decodeResponse(response.Header.Get("Content-Type"), payload)
+	result, rpcErr, decodeErr := decodeResponse(contentType, payload)
 	if rpcErr != nil {
 		return nil, exchange, rpcErr
 	}
@@ -316,11 +631,179 @@ func (c *Client) attempt(method string, params map[string]any, extra map[string]
 	return result, exchange, nil
 }
 
+// exchangeStdio sends one message to a local server. There are no headers to
+// show and no HTTP status to judge: what comes back is the JSON-RPC message.
+func (c *Client) exchangeStdio(method string, id int64, body []byte, notification bool) (json.RawMessage, *Exchange, error) {
+	payload, err := c.stdio.call(id, body, notification)
+	if err != nil {
+		return nil, nil, fmt.Errorf("calling %s: %w", method, err)
+	}
+	if notification {
+		return nil, nil, nil
+	}
+	result, rpcErr, decodeErr := decodeResponse("application/json", payload)
+	if rpcErr != nil {
+		return nil, nil, rpcErr
+	}
+	return result, nil, decodeErr
+}
+
+// readStream reads a response event stream up to the response it carries, and
+// returns that. Requests the server makes on the way are answered with a POST
+// of their own; notifications go to notify, or are passed over without one.
+func (c *Client) readStream(body io.Reader, ask asker, notify notifier) ([]byte, error) {
+	var response []byte
+	var answerErr error
+	err := readSSE(io.LimitReader(body, 32<<20), func(data []byte) bool {
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if json.Unmarshal(bytes.TrimSpace(data), &message) != nil {
+			return false
+		}
+		if message.Method != "" {
+			if len(message.ID) > 0 {
+				result, rpcErr := c.requests.answer(message.Method, message.Params, ask)
+				if answerErr = c.reply(message.ID, result, rpcErr); answerErr != nil {
+					return true
+				}
+			} else if notify != nil {
+				notify(message.Method, message.Params)
+			}
+			return false
+		}
+		if isJSONRPCResponse(data) {
+			response = data
+			return true
+		}
+		return false
+	})
+	if answerErr != nil {
+		return nil, answerErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, fmt.Errorf("the event stream carried no response")
+	}
+	return response, nil
+}
+
+// reply sends the answer to a request the server made on a response stream.
+// It is a JSON-RPC response, POSTed like any message, and accepted with no body.
+func (c *Client) reply(id json.RawMessage, result any, rpcErr *jsonRPCError) error {
+	message := map[string]any{"jsonrpc": "2.0", "id": id}
+	if rpcErr != nil {
+		message["error"] = rpcErr
+	} else {
+		message["result"] = result
+	}
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("encoding the answer to the server: %w", err)
+	}
+
+	c.mu.Lock()
+	version, session := c.version, c.session
+	c.mu.Unlock()
+	request, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building the answer to the server: %w", err)
+	}
+	for name, value := range c.headers {
+		request.Header.Set(name, value)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json, text/event-stream")
+	request.Header.Set("MCP-Protocol-Version", version)
+	if session != "" {
+		request.Header.Set("Mcp-Session-Id", session)
+	}
+	response, err := c.do(request)
+	if err != nil {
+		return fmt.Errorf("answering the server: %w", err)
+	}
+	defer response.Body.Close()
+	payload, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if response.StatusCode >= 400 {
+		return apps.NewUpstreamError(http.MethodPost, c.endpoint, response.StatusCode, payload)
+	}
+	return nil
+}
+
+// do sends one request, with the OAuth token held for the server when the
+// client signs in. A token the server turns down is refreshed, and the request
+// sent once more with the new one; a refusal after that is the caller's to read.
+func (c *Client) do(request *http.Request) (*http.Response, error) {
+	return c.doOn(c.http, request)
+}
+
+// doOn is do through httpClient.
+func (c *Client) doOn(httpClient *http.Client, request *http.Request) (*http.Response, error) {
+	if !c.usesOAuth(request) {
+		return httpClient.Do(request)
+	}
+	token := c.oauth.token(c.endpoint)
+	if token == "" {
+		return httpClient.Do(request)
+	}
+	response, err := httpClient.Do(withBearer(request, token))
+	resendable := request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+	if err != nil || response.StatusCode != http.StatusUnauthorized || !resendable {
+		return response, err
+	}
+	fresh, err := c.oauth.refresh(c.endpoint, token)
+	if err != nil {
+		io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+		response.Body.Close()
+		return nil, err
+	}
+	if fresh == "" {
+		return response, nil
+	}
+	again := withBearer(request, fresh)
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return response, nil
+		}
+		again.Body = body
+	}
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+	response.Body.Close()
+	return httpClient.Do(again)
+}
+
+// usesOAuth reports whether request is one the client signs in for: it has an
+// authorizer, and the app's headers carry no credential of their own.
+func (c *Client) usesOAuth(request *http.Request) bool {
+	return c.oauth != nil && request.Header.Get("Authorization") == ""
+}
+
+// signedIn reports whether request went out with an OAuth token.
+func (c *Client) signedIn(request *http.Request) bool {
+	return c.usesOAuth(request) && c.oauth.holds(c.endpoint)
+}
+
+// withBearer is request carrying token, leaving request itself as it was.
+func withBearer(request *http.Request, token string) *http.Request {
+	signed := request.Clone(request.Context())
+	signed.Header.Set("Authorization", "Bearer "+token)
+	return signed
+}
+
+func isEventStream(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "text/event-stream")
+}
+
 // decodeResponse reads the JSON-RPC message out of a response body, which is
 // either a single JSON object or an SSE stream whose last data event carries the
 // response.
 func decodeResponse(contentType string, payload []byte) (json.RawMessage, *jsonRPCError, error) {
-	if strings.Contains(strings.ToLower(contentType), "text/event-stream") {
+	if isEventStream(contentType) {
 		payload = lastSSEData(payload)
 		if payload == nil {
 			return nil, nil, fmt.Errorf("the event stream carried no response")
@@ -344,36 +827,52 @@ func decodeResponse(contentType string, payload []byte) (json.RawMessage, *jsonR
 
 // lastSSEData returns the data of the last SSE event in the stream, which is
 // where the final response sits. Notifications sent ahead of it (progress, log
-// messages) are passed over: kaja has nowhere to put them mid-call.
+// messages) are passed over; a call that wants them reads the stream with
+// readStream.
 func lastSSEData(payload []byte) []byte {
-	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	var last []byte
+	_ = readSSE(bytes.NewReader(payload), func(data []byte) bool {
+		if isJSONRPCResponse(data) {
+			last = data
+		}
+		return false
+	})
+	return last
+}
+
+// readSSE hands each event's data to event as the stream delivers it, until the
+// stream ends or event returns true.
+func readSSE(stream io.Reader, event func(data []byte) bool) error {
+	scanner := bufio.NewScanner(stream)
 	scanner.Buffer(make([]byte, 0, 64*1024), 32<<20)
 
-	var last []byte
 	var current []string
-	flush := func() {
+	flush := func() bool {
 		if len(current) == 0 {
-			return
+			return false
 		}
 		data := []byte(strings.Join(current, "\n"))
 		current = nil
-		if isJSONRPCResponse(data) {
-			last = data
-		}
+		return event(data)
 	}
 	for scanner.Scan() {
 		line := strings.TrimSuffix(scanner.Text(), "\r")
 		switch {
 		case line == "":
-			flush()
+			if flush() {
+				return nil
+			}
 		case strings.HasPrefix(line, ":"):
 			// A comment, used as a keep-alive.
 		case strings.HasPrefix(line, "data:"):
 			current = append(current, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
 	flush()
-	return last
+	return nil
 }
 
 // isJSONRPCResponse reports whether an SSE event's data is a response rather
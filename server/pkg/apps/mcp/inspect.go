@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"os/exec"
 	"strings"
 	"time"
 
@@ -37,6 +39,12 @@ type Problem struct {
 	Kind    ProblemKind
 	Message string
 	Detail  string
+	// AuthorizationURL is where the user signs in, for a server kaja can sign
+	// in to on their behalf.
+	AuthorizationURL string
+	// AuthorizationServer is the authorization server a server that wants a
+	// token names, for one kaja could sign in to were the app to say "oauth".
+	AuthorizationServer string
 }
 
 func (p *Problem) Error() string {
@@ -50,17 +58,31 @@ func (p *Problem) Error() string {
 // app form can fill itself in from what answered.
 func Inspect(parameters map[string]string) (*Surface, *Problem) {
 	endpoint := strings.TrimSpace(parameters["url"])
-	if endpoint == "" {
-		return nil, &Problem{Kind: ProblemTarget, Message: "Enter the server's MCP endpoint."}
-	}
-	if err := requireHTTPScheme(endpoint); err != nil {
-		return nil, &Problem{Kind: ProblemTarget, Message: "That isn't an HTTP endpoint.", Detail: err.Error()}
+	if _, local, _ := LocalCommand(parameters); !local {
+		if endpoint == "" {
+			return nil, &Problem{Kind: ProblemTarget, Message: "Enter the server's MCP endpoint."}
+		}
+		if err := requireHTTPScheme(endpoint); err != nil {
+			return nil, &Problem{Kind: ProblemTarget, Message: "That isn't an HTTP endpoint.", Detail: err.Error()}
+		}
 	}
 
-	client := NewClient(endpoint, Credential(parameters), &http.Client{Timeout: inspectTimeout})
+	client, err := connect(parameters, inspectTimeout, nil)
+	if err != nil {
+		return nil, &Problem{Kind: ProblemTarget, Message: "That isn't a server kaja can reach.", Detail: err.Error()}
+	}
+	// A local server started to be asked about is stopped once it has answered.
+	defer client.Close()
 	surface, err := client.ReadSurface(nil)
 	if err != nil {
-		return nil, classify(err)
+		problem := classify(err)
+		// A server that turned away a read with no token may say where one is
+		// signed in for.
+		tokenless := client.stdio == nil && strings.TrimSpace(parameters["token"]) == ""
+		if problem.Kind == ProblemUnauthorized && problem.AuthorizationURL == "" && tokenless {
+			offerSignIn(problem, endpoint, client.lastChallenge())
+		}
+		return nil, problem
 	}
 	if len(surface.Tools) == 0 && len(surface.Resources) == 0 && len(surface.ResourceTemplates) == 0 && len(surface.Prompts) == 0 {
 		return surface, &Problem{
@@ -71,11 +93,35 @@ func Inspect(parameters map[string]string) (*Surface, *Problem) {
 	return surface, nil
 }
 
+// offerSignIn reads whether a server that refused a read without a token can be
+// signed in to, and names its authorization server on problem when it can. It
+// goes no further than the metadata: registering with the authorization server
+// and starting a sign-in wait on the app saying "oauth", which is the user's
+// choice rather than the form's.
+func offerSignIn(problem *Problem, endpoint string, challenge string) {
+	resource, _, err := authorizer.discover(endpoint, challenge)
+	if err != nil {
+		return
+	}
+	problem.Message = "The server wants you to sign in, or a token."
+	problem.AuthorizationServer = resource.AuthorizationServers[0]
+}
+
 // classify turns a failure to read a server into the one line that says what to
 // do about it.
 func classify(err error) *Problem {
 	detail := err.Error()
 
+	var required *AuthorizationRequired
+	if errors.As(err, &required) {
+		return &Problem{
+			Kind:             ProblemUnauthorized,
+			Message:          "The server wants you to sign in.",
+			Detail:           required.Err.Error(),
+			AuthorizationURL: required.URL,
+		}
+	}
+
 	var upstream *apps.UpstreamError
 	if errors.As(err, &upstream) {
 		switch {
@@ -106,6 +152,13 @@ func classify(err error) *Problem {
 		}
 	}
 
+	if errors.Is(err, exec.ErrNotFound) || errors.Is(err, os.ErrNotExist) {
+		return &Problem{Kind: ProblemTarget, Message: "That command isn't installed, or isn't on the PATH.", Detail: detail}
+	}
+	if errors.Is(err, ErrServerExited) {
+		return &Problem{Kind: ProblemUnreachable, Message: "The server exited before it answered.", Detail: detail}
+	}
+
 	var netErr net.Error
 	if errors.As(err, &netErr) && netErr.Timeout() {
 		return &Problem{Kind: ProblemTimeout, Message: "The server didn't answer in time.", Detail: detail}
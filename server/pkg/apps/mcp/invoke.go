@@ -1,9 +1,12 @@
 package mcp
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/wham/kaja/v2/pkg/apps"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -16,14 +19,106 @@ import (
 type instance struct {
 	client  *Client
 	methods map[string]*boundMethod
+
+	mu sync.Mutex
+	// conversations are the calls waiting on an answer from their caller, by the
+	// id of the question they asked.
+	conversations map[string]*conversation
+}
+
+// conversation is a call held open while the server waits on the person who
+// made it. The call runs on; Invoke returns its question, and the answer comes
+// back in a later Invoke, which then waits on the same call.
+type conversation struct {
+	questions chan *apps.Question
+	answers   chan apps.Answer
+	done      chan invoked
 }
 
+type invoked struct {
+	result *apps.InvokeResult
+	err    error
+}
+
+// answerTimeout is how long a question waits for its answer before the server
+// is told the user cancelled.
+const answerTimeout = callTimeout
+
 func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	answer, err := apps.TakeAnswer(headers)
+	if err != nil {
+		return nil, err
+	}
+	if answer != nil {
+		in.mu.Lock()
+		waiting := in.conversations[answer.ID]
+		delete(in.conversations, answer.ID)
+		in.mu.Unlock()
+		if waiting == nil {
+			return nil, fmt.Errorf("the call that asked this question is no longer waiting for the answer; make the call again")
+		}
+		waiting.answers <- *answer
+		return in.await(waiting)
+	}
+
+	c := &conversation{
+		questions: make(chan *apps.Question),
+		answers:   make(chan apps.Answer, 1),
+		done:      make(chan invoked, 1),
+	}
+	go func() {
+		result, err := in.call(methodPath, request, headers, c.ask(in))
+		c.done <- invoked{result, err}
+	}()
+	return in.await(c)
+}
+
+// await returns what the call does next: ask a question, or finish.
+func (in *instance) await(c *conversation) (*apps.InvokeResult, error) {
+	select {
+	case question := <-c.questions:
+		in.mu.Lock()
+		in.conversations[question.ID] = c
+		in.mu.Unlock()
+		return &apps.InvokeResult{Body: []byte{}, Question: question}, nil
+	case done := <-c.done:
+		return done.result, done.err
+	}
+}
+
+// ask is the asker of one call: it hands the server's elicitation to whoever
+// awaits the call and waits for their answer.
+func (c *conversation) ask(in *instance) asker {
+	return func(message string, schema json.RawMessage) (json.RawMessage, error) {
+		question := &apps.Question{ID: rand.Text(), Message: message, Schema: schema}
+		c.questions <- question
+		select {
+		case answer := <-c.answers:
+			reply := map[string]any{"action": answer.Action}
+			if answer.Action == "accept" && len(answer.Content) > 0 {
+				reply["content"] = answer.Content
+			}
+			return json.Marshal(reply)
+		case <-time.After(answerTimeout):
+			in.mu.Lock()
+			delete(in.conversations, question.ID)
+			in.mu.Unlock()
+			return json.RawMessage(`{"action":"cancel"}`), nil
+		}
+	}
+}
+
+// call makes one call to the server, asking ask whatever it needs answered.
+func (in *instance) call(methodPath string, request []byte, headers map[string]string, ask asker) (*apps.InvokeResult, error) {
 	method := in.lookup(methodPath)
 	if method == nil {
 		return nil, fmt.Errorf("unknown method %q (the app may need to be recompiled)", methodPath)
 	}
 
+	if method.binding.kind == "subscribe" {
+		return nil, fmt.Errorf("%s streams the resource's updates, and is called as a server-streaming method", lastSegment(methodPath))
+	}
+
 	arguments, err := decodeRequest(method, request)
 	if err != nil {
 		return nil, err
@@ -34,7 +129,7 @@ func (in *instance) Invoke(methodPath string, request []byte, headers map[string
 		return nil, err
 	}
 
-	result, exchange, err := in.client.Call(method.binding.method, params, headers)
+	result, exchange, err := in.client.CallAsking(method.binding.method, params, headers, ask)
 	if err != nil {
 		return nil, withExchange(err, exchange)
 	}
@@ -88,8 +183,9 @@ func decodeRequest(method *boundMethod, request []byte) (map[string]json.RawMess
 }
 
 // callParams builds the JSON-RPC params for one call. A tool call and a prompt
-// carry the request under `arguments` beside the name they address; everything
-// else is the params.
+// carry the request under `arguments` beside the name they address; a
+// completion names what it completes as a reference; everything else is the
+// params.
 func callParams(bound *binding, arguments map[string]json.RawMessage) (map[string]any, error) {
 	params := map[string]any{}
 	switch bound.kind {
@@ -109,6 +205,8 @@ func callParams(bound *binding, arguments map[string]json.RawMessage) (map[strin
 		}
 		params["name"] = bound.name
 		params["arguments"] = values
+	case "complete":
+		return completeParams(arguments)
 	default:
 		for name, raw := range arguments {
 			var value any
@@ -121,14 +219,50 @@ func callParams(bound *binding, arguments map[string]json.RawMessage) (map[strin
 	return params, nil
 }
 
+// completeParams reshapes a CompleteRequest into completion/complete params:
+// the prompt or template it names becomes the reference, and the rest the
+// argument and its context.
+func completeParams(arguments map[string]json.RawMessage) (map[string]any, error) {
+	encoded, _ := json.Marshal(arguments)
+	var request struct {
+		Prompt      string            `json:"prompt"`
+		URITemplate string            `json:"uriTemplate"`
+		Argument    string            `json:"argument"`
+		Value       string            `json:"value"`
+		Context     map[string]string `json:"context"`
+	}
+	if err := json.Unmarshal(encoded, &request); err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	var ref map[string]string
+	switch {
+	case request.Prompt != "" && request.URITemplate != "":
+		return nil, fmt.Errorf("set either prompt or uri_template, not both")
+	case request.Prompt != "":
+		ref = map[string]string{"type": "ref/prompt", "name": request.Prompt}
+	case request.URITemplate != "":
+		ref = map[string]string{"type": "ref/resource", "uri": request.URITemplate}
+	default:
+		return nil, fmt.Errorf("set the prompt or the uri_template whose argument to complete")
+	}
+	if request.Argument == "" {
+		return nil, fmt.Errorf("name the argument to complete")
+	}
+	params := map[string]any{
+		"ref":      ref,
+		"argument": map[string]string{"name": request.Argument, "value": request.Value},
+	}
+	if len(request.Context) > 0 {
+		params["context"] = map[string]any{"arguments": request.Context}
+	}
+	return params, nil
+}
+
 // encodeResult shapes a JSON-RPC result into the method's protobuf response. The
 // generated response fields carry the result's own keys as their json_name, so
 // the result decodes into it directly; anything the response has no field for -
 // the `resultType` and `_meta` every modern result carries - is dropped.
 func encodeResult(method *boundMethod, result json.RawMessage) ([]byte, error) {
-	if err := rejectInputRequired(result); err != nil {
-		return nil, err
-	}
 	message := dynamicpb.NewMessage(method.output)
 	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(result, message); err != nil {
 		return nil, fmt.Errorf("decoding response: %w", err)
@@ -136,20 +270,6 @@ func encodeResult(method *boundMethod, result json.RawMessage) ([]byte, error) {
 	return proto.Marshal(message)
 }
 
-// rejectInputRequired says so when a server asks the client for something back
-// mid-call - a model completion, a question for the user, the roots it may read.
-// kaja declares none of those capabilities, so there is nothing to answer with,
-// and a silent empty result would read as the tool having returned nothing.
-func rejectInputRequired(result json.RawMessage) error {
-	var envelope struct {
-		ResultType string `json:"resultType"`
-	}
-	if json.Unmarshal(result, &envelope) != nil || envelope.ResultType != "input_required" {
-		return nil
-	}
-	return fmt.Errorf("the server asked for input to finish this call (sampling, elicitation or roots), which kaja does not provide")
-}
-
 // withExchange attaches the headers a failed call exchanged to the error, so the
 // Headers view still has them. A 401 is exactly when they matter most.
 func withExchange(err error, exchange *Exchange) error {
@@ -6,12 +6,18 @@
 // inputSchema becomes the request message and its outputSchema, where it
 // declares one, the shape of the result - its prompts become the methods of a
 // Prompts service, and its resources the fixed list/read methods of a Resources
-// service. Calls are transcoded back into `tools/call`, `prompts/get` and
-// `resources/*` on the way out.
+// service, with a server-streaming Subscribe where the server lets resources be
+// subscribed to. A server that completes arguments gets a Completions service
+// for its prompts' and resource templates'. Calls are transcoded back into
+// `tools/call`, `prompts/get`, `resources/*` and `completion/complete` on the
+// way out. Each tool has a server-streaming variant too, which sends the
+// progress and log notifications the server sends about the call ahead of its
+// result.
 //
-// The transport is Streamable HTTP, in both eras of the protocol: the modern
-// revision, which carries the version and the client's capabilities in every
-// request and has no session, and the handshake revisions that came before it.
+// The transport is Streamable HTTP or, for a local server kaja launches itself,
+// stdio - in both eras of the protocol: the modern revision, which carries the
+// version and the client's capabilities in every request and has no session,
+// and the handshake revisions that came before it.
 package mcp
 
 import (
@@ -24,8 +30,8 @@ import (
 	"time"
 
 	"github.com/wham/kaja/v2/pkg/apps"
-	"github.com/wham/protoc-go/protoc"
-	"google.golang.org/protobuf/reflect/protodesc"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+	"github.com/wham/kaja/v2/pkg/retry"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -40,18 +46,13 @@ type App struct{}
 func New() *App { return &App{} }
 
 func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
-	endpoint := strings.TrimSpace(parameters["url"])
-	if endpoint == "" {
-		return nil, fmt.Errorf("missing required parameter %q", "url")
-	}
-	if err := requireHTTPScheme(endpoint); err != nil {
+	client, err := connect(parameters, callTimeout, log)
+	if err != nil {
 		return nil, err
 	}
-	log("MCP endpoint: " + endpoint)
-
-	client := NewClient(endpoint, Credential(parameters), &http.Client{Timeout: callTimeout})
 	surface, err := client.ReadSurface(log)
 	if err != nil {
+		client.Close()
 		return nil, err
 	}
 
@@ -64,12 +65,57 @@ func (a *App) Open(parameters map[string]string, protoDir string, log func(strin
 	}
 	log(fmt.Sprintf("Generated %d service(s) with %d method(s)", len(gen.serviceTypeNames), len(gen.bindings)))
 
-	methods, err := compileMethods(protoDir, gen)
+	methods, err := protogen.CompileMethods(protoDir, "mcp.proto", gen.bindings, bindMethod)
 	if err != nil {
 		return nil, err
 	}
 
-	return &apps.Opened{Instance: &instance{client: client, methods: methods}}, nil
+	return &apps.Opened{Instance: &instance{client: client, methods: methods, conversations: map[string]*conversation{}}}, nil
+}
+
+// connect builds the client an app's parameters describe: a local server when
+// they name a command, the endpoint at url otherwise.
+func connect(parameters map[string]string, timeout time.Duration, log func(string)) (*Client, error) {
+	endpoint := strings.TrimSpace(parameters["url"])
+	command, local, err := LocalCommand(parameters)
+	switch {
+	case err != nil:
+		return nil, err
+	case local && endpoint != "":
+		return nil, fmt.Errorf("set either %q or %q, not both", "url", "command")
+	case local:
+		if err := command.Validate(); err != nil {
+			return nil, err
+		}
+		if log != nil {
+			log("MCP command: " + command.String())
+		}
+		return NewStdioClient(command, timeout).WithRequests(readRequests(parameters)), nil
+	case endpoint == "":
+		return nil, fmt.Errorf("missing required parameter %q (or %q for a local server)", "url", "command")
+	}
+	if err := requireHTTPScheme(endpoint); err != nil {
+		return nil, err
+	}
+	if log != nil {
+		log("MCP endpoint: " + endpoint)
+	}
+
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	client := NewClient(endpoint, Credential(parameters), &http.Client{Timeout: timeout}).WithRetry(policy).WithRequests(readRequests(parameters))
+	if usesOAuth(parameters) {
+		client.WithOAuth(authorizer, parameters[CallbackParameter])
+	}
+	return client, nil
+}
+
+// usesOAuth reports whether an mcp app signs in to its server with OAuth, which
+// it does only when its auth says "oauth".
+func usesOAuth(parameters map[string]string) bool {
+	return strings.TrimSpace(parameters["auth"]) == "oauth"
 }
 
 // Credential turns an mcp app's authentication parameters into the headers the
@@ -81,7 +127,7 @@ func Credential(parameters map[string]string) map[string]string {
 		return nil
 	}
 	switch strings.TrimSpace(parameters["auth"]) {
-	case "none":
+	case "none", "oauth":
 		return nil
 	case "apikey":
 		name := strings.TrimSpace(parameters["api_key_name"])
@@ -91,7 +137,8 @@ func Credential(parameters map[string]string) map[string]string {
 		return map[string]string{name: token}
 	default:
 		// An MCP server behind a login is behind an OAuth bearer token in all but
-		// name, which is why it is the default and the only other choice.
+		// name, which is why it is the default: one pasted in, or one kaja signs
+		// in for ("oauth").
 		return map[string]string{"Authorization": "Bearer " + token}
 	}
 }
@@ -104,44 +151,9 @@ type boundMethod struct {
 	output  protoreflect.MessageDescriptor
 }
 
-// compileMethods compiles the generated proto and resolves each method's request
-// and response descriptors, keyed by the gRPC method path.
-func compileMethods(protoDir string, gen *generated) (map[string]*boundMethod, error) {
-	result, err := protoc.New(protoc.WithProtoPaths(protoDir), protoc.WithIncludeImports()).Compile("mcp.proto")
-	if err != nil {
-		return nil, fmt.Errorf("compiling generated proto: %w", err)
-	}
-	files, err := protodesc.NewFiles(result.AsFileDescriptorSet())
-	if err != nil {
-		return nil, fmt.Errorf("building descriptors: %w", err)
-	}
-
-	services := make(map[string]protoreflect.ServiceDescriptor, len(gen.serviceTypeNames))
-	for _, name := range gen.serviceTypeNames {
-		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(name))
-		if err != nil {
-			return nil, fmt.Errorf("finding service %s: %w", name, err)
-		}
-		service, ok := descriptor.(protoreflect.ServiceDescriptor)
-		if !ok {
-			return nil, fmt.Errorf("%s is not a service", name)
-		}
-		services[name] = service
-	}
-
-	methods := make(map[string]*boundMethod, len(gen.bindings))
-	for path, bound := range gen.bindings {
-		service := services[serviceOfMethodPath(path)]
-		if service == nil {
-			return nil, fmt.Errorf("no service for method path %s", path)
-		}
-		method := service.Methods().ByName(protoreflect.Name(lastSegment(path)))
-		if method == nil {
-			return nil, fmt.Errorf("method %s missing from compiled descriptors", path)
-		}
-		methods[path] = &boundMethod{binding: bound, input: method.Input(), output: method.Output()}
-	}
-	return methods, nil
+// bindMethod pairs a binding with the compiled method it is keyed by.
+func bindMethod(binding *binding, method protoreflect.MethodDescriptor) *boundMethod {
+	return &boundMethod{binding: binding, input: method.Input(), output: method.Output()}
 }
 
 // requireHTTPScheme rejects endpoints that are not plain HTTP(S), so a
@@ -163,10 +175,3 @@ func lastSegment(s string) string {
 	}
 	return s
 }
-
-func serviceOfMethodPath(path string) string {
-	if i := strings.LastIndex(path, "/"); i >= 0 {
-		return path[:i]
-	}
-	return path
-}
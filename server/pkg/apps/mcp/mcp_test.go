@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,6 +29,12 @@ type fakeServer struct {
 	sse bool
 	// session, when set, is the Mcp-Session-Id a legacy server pins.
 	session string
+	// answer, when set, works a method's result out from its params in place of
+	// results; an empty string leaves it to them.
+	answer func(method string, params map[string]json.RawMessage) string
+	// notify, when set, gives the notifications an event stream sends ahead of
+	// the response to a method, worked out from its params.
+	notify func(method string, params map[string]json.RawMessage) []string
 
 	requests []recorded
 }
@@ -67,15 +74,24 @@ func (f *fakeServer) handler() http.HandlerFunc {
 		}
 
 		result, ok := f.results[message.Method]
+		if f.answer != nil {
+			if answered := f.answer(message.Method, message.Params); answered != "" {
+				result, ok = answered, true
+			}
+		}
 		if !ok {
 			f.write(w, string(message.ID), "", `{"code":-32601,"message":"Method not found"}`)
 			return
 		}
-		f.write(w, string(message.ID), result, "")
+		var ahead []string
+		if f.notify != nil {
+			ahead = f.notify(message.Method, message.Params)
+		}
+		f.write(w, string(message.ID), result, "", ahead...)
 	}
 }
 
-func (f *fakeServer) write(w http.ResponseWriter, id, result, rpcError string) {
+func (f *fakeServer) write(w http.ResponseWriter, id, result, rpcError string, ahead ...string) {
 	payload := `{"jsonrpc":"2.0","id":` + id
 	if rpcError != "" {
 		payload += `,"error":` + rpcError + `}`
@@ -93,6 +109,9 @@ func (f *fakeServer) write(w http.ResponseWriter, id, result, rpcError string) {
 		// A notification ahead of the response, which the client must step over.
 		fmt.Fprint(w, ":\r\n\r\n")
 		fmt.Fprint(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{}}\n\n")
+		for _, notification := range ahead {
+			fmt.Fprint(w, "event: message\ndata: "+notification+"\n\n")
+		}
 		fmt.Fprint(w, "event: message\ndata: "+payload+"\n\n")
 		return
 	}
@@ -188,8 +207,8 @@ func TestOpenModernServer(t *testing.T) {
 	fake, endpoint := modernServer(t, nil)
 	in, logs := openApp(t, endpoint, nil)
 
-	if len(in.methods) != 1 {
-		t.Fatalf("expected one generated method, got %d", len(in.methods))
+	if len(in.methods) != 2 {
+		t.Fatalf("expected the tool and its streaming variant, got %v", methodPaths(in))
 	}
 	bound, ok := in.methods["mcp.Tools/GetWeather"]
 	if !ok {
@@ -277,6 +296,170 @@ func TestInvokeTool(t *testing.T) {
 	}
 }
 
+// The streaming variant of a tool sends the progress reported for its own call
+// and what the server logged, in order, and the result last.
+func TestInvokeToolStream(t *testing.T) {
+	fake, endpoint := modernServer(t, nil)
+	in, _ := openApp(t, endpoint, nil)
+	fake.sse = true
+	fake.notify = func(method string, params map[string]json.RawMessage) []string {
+		var meta struct {
+			ProgressToken string `json:"progressToken"`
+		}
+		if method != "tools/call" || json.Unmarshal(params["_meta"], &meta) != nil || meta.ProgressToken == "" {
+			return nil
+		}
+		return []string{
+			`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":"` + meta.ProgressToken + `","progress":1,"total":2,"message":"Asking the station"}}`,
+			`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":"another-call","progress":9}}`,
+			`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info","logger":"weather","data":"Station 7 answered"}}`,
+			`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"debug","data":{"station":7}}}`,
+			`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`,
+		}
+	}
+
+	if !in.Streams("mcp.Tools/GetWeatherStream") || in.Streams("mcp.Tools/GetWeather") {
+		t.Fatal("expected only the variant to stream")
+	}
+	bound := in.methods["mcp.Tools/GetWeatherStream"]
+	var updates []string
+	result, err := in.InvokeStream(context.Background(), "mcp.Tools/GetWeatherStream", encodeRequest(t, bound, `{"location":"Seattle"}`), nil, func(message []byte) error {
+		updates = append(updates, decodeResponseJSON(t, bound, message))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InvokeStream: %v", err)
+	}
+
+	want := []string{
+		`{"progress":{"progress":1,"total":2,"message":"Asking the station"}}`,
+		`{"log":{"level":"info","logger":"weather","data":"Station 7 answered"}}`,
+		`{"log":{"level":"debug","data":"{\"station\":7}"}}`,
+	}
+	if len(updates) != 4 {
+		t.Fatalf("updates = %q, want two progress and log messages and the result", updates)
+	}
+	for i, w := range want {
+		if compact(updates[i]) != w {
+			t.Errorf("update %d = %s, want %s", i, updates[i], w)
+		}
+	}
+	if !strings.Contains(updates[3], `"result"`) || !strings.Contains(updates[3], "Partly cloudy") {
+		t.Errorf("last update = %s, want the tool's result", updates[3])
+	}
+	if result.RequestHeaders["Mcp-Method"] != "tools/call" {
+		t.Errorf("request headers = %v, want the exchange reported", result.RequestHeaders)
+	}
+
+	var arguments map[string]any
+	json.Unmarshal(fake.asked("tools/call").Params["arguments"], &arguments)
+	if arguments["location"] != "Seattle" {
+		t.Errorf("arguments = %v", arguments)
+	}
+}
+
+// resourceServer is a modern server whose resources can be subscribed to and
+// whose prompt arguments it completes.
+func resourceServer(t *testing.T) *fakeServer {
+	t.Helper()
+	return &fakeServer{era: "modern", results: map[string]string{
+		"server/discover":       `{"resultType":"complete","capabilities":{"resources":{"subscribe":true},"prompts":{},"completions":{}},"_meta":{}}`,
+		"resources/list":        `{"resultType":"complete","resources":[{"uri":"file:///notes.md","name":"notes"}]}`,
+		"prompts/list":          `{"resultType":"complete","prompts":[{"name":"review","arguments":[{"name":"language"}]}]}`,
+		"resources/read":        `{"resultType":"complete","contents":[{"uri":"file:///notes.md","text":"second draft"}]}`,
+		"completion/complete":   `{"resultType":"complete","completion":{"values":["go","golang"],"total":2,"hasMore":false}}`,
+		"resources/subscribe":   `{"resultType":"complete"}`,
+		"resources/unsubscribe": `{"resultType":"complete"}`,
+	}}
+}
+
+func TestComplete(t *testing.T) {
+	fake := resourceServer(t)
+	server := httptest.NewServer(fake.handler())
+	defer server.Close()
+	in, _ := openApp(t, server.URL+"/mcp", nil)
+	bound := in.methods["mcp.Completions/Complete"]
+	if bound == nil {
+		t.Fatalf("expected mcp.Completions/Complete, got %v", methodPaths(in))
+	}
+
+	result, err := in.Invoke("mcp.Completions/Complete", encodeRequest(t, bound, `{"prompt":"review","argument":"language","value":"g","context":{"style":"terse"}}`), nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if got := compact(decodeResponseJSON(t, bound, result.Body)); got != `{"completion":{"values":["go","golang"],"total":"2"}}` {
+		t.Errorf("response = %s", got)
+	}
+	params := fake.asked("completion/complete").Params
+	if compact(string(params["ref"])) != `{"name":"review","type":"ref/prompt"}` ||
+		compact(string(params["argument"])) != `{"name":"language","value":"g"}` ||
+		compact(string(params["context"])) != `{"arguments":{"style":"terse"}}` {
+		t.Errorf("params = %v", params)
+	}
+
+	if _, err := in.Invoke("mcp.Completions/Complete", encodeRequest(t, bound, `{"argument":"language"}`), nil); err == nil {
+		t.Error("expected a completion that names no prompt or template to be refused")
+	}
+}
+
+// A subscription hears the resource's updates on the server's own stream, and
+// is given up when the caller goes away.
+func TestSubscribe(t *testing.T) {
+	fake := resourceServer(t)
+	subscribed := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			fake.handler()(w, r)
+			if r.Header.Get("Mcp-Method") == "resources/subscribe" {
+				close(subscribed)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-subscribed
+		fmt.Fprint(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/resources/updated\",\"params\":{\"uri\":\"file:///other.md\"}}\n\n")
+		fmt.Fprint(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/resources/updated\",\"params\":{\"uri\":\"file:///notes.md\",\"title\":\"Notes\"}}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	in, _ := openApp(t, server.URL+"/mcp", nil)
+	if !in.Streams("mcp.Resources/Subscribe") {
+		t.Fatalf("expected mcp.Resources/Subscribe to stream, got %v", methodPaths(in))
+	}
+	bound := in.methods["mcp.Resources/Subscribe"]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var updates []string
+	_, err := in.InvokeStream(ctx, "mcp.Resources/Subscribe", encodeRequest(t, bound, `{"uri":"file:///notes.md","read":true}`), nil, func(message []byte) error {
+		updates = append(updates, decodeResponseJSON(t, bound, message))
+		cancel()
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("err = %v, want the stream to end with the caller", err)
+	}
+	if len(updates) != 1 || !strings.Contains(updates[0], `"title":"Notes"`) || !strings.Contains(updates[0], "second draft") {
+		t.Errorf("updates = %q, want the resource's update with what it holds now", updates)
+	}
+	if fake.asked("resources/unsubscribe") == nil {
+		t.Error("expected the subscription to be given up")
+	}
+	if _, err := in.Invoke("mcp.Resources/Subscribe", encodeRequest(t, bound, `{"uri":"file:///notes.md"}`), nil); err == nil {
+		t.Error("expected a unary call to the subscription to be refused")
+	}
+}
+
+func compact(s string) string {
+	var b bytes.Buffer
+	if json.Compact(&b, []byte(s)) != nil {
+		return s
+	}
+	return b.String()
+}
+
 // A tool that fails is a result, not a transport failure: the run has to show
 // what the tool said about it.
 func TestInvokeToolExecutionError(t *testing.T) {
@@ -296,11 +479,72 @@ func TestInvokeToolExecutionError(t *testing.T) {
 	}
 }
 
-// A server that needs something back from the client can't be answered, so the
-// call says so rather than reporting an empty result.
+// A modern server that needs something back finishes the call with the
+// requests it has; the call is made again with the answers, and with the state
+// the server asked to have echoed, until it completes.
 func TestInvokeInputRequired(t *testing.T) {
+	fake, endpoint := modernServer(t, nil)
+	in, _ := openApp(t, endpoint, map[string]string{"root_folders": "docs=/srv/docs"})
+	fake.answer = func(method string, params map[string]json.RawMessage) string {
+		if method != "tools/call" || params["inputResponses"] != nil {
+			return ""
+		}
+		return `{"resultType":"input_required","requestState":"step-1","inputRequests":{` +
+			`"where":{"method":"roots/list","params":{}},` +
+			`"who":{"method":"elicitation/create","params":{"message":"Who is asking?","requestedSchema":{"type":"object","properties":{"name":{"type":"string"}}}}}}}`
+	}
+	bound := in.methods["mcp.Tools/GetWeather"]
+	request := encodeRequest(t, bound, `{"location":"Seattle"}`)
+
+	// The elicitation is the caller's to answer: the call comes back with the
+	// question, and is held open on the server meanwhile.
+	asked, err := in.Invoke("mcp.Tools/GetWeather", request, nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if asked.Question == nil || asked.Question.Message != "Who is asking?" || !strings.Contains(string(asked.Question.Schema), `"name"`) {
+		t.Fatalf("expected the server's question, got %+v", asked.Question)
+	}
+
+	answer := fmt.Sprintf(`{"id":%q,"action":"accept","content":{"name":"Ada"}}`, asked.Question.ID)
+	result, err := in.Invoke("mcp.Tools/GetWeather", request, map[string]string{apps.AnswerHeader: answer})
+	if err != nil {
+		t.Fatalf("Invoke with the answer: %v", err)
+	}
+	if !strings.Contains(decodeResponseJSON(t, bound, result.Body), "partly cloudy") {
+		t.Error("expected the call to finish once answered")
+	}
+
+	var retried *recorded
+	for i := range fake.requests {
+		if fake.requests[i].Method == "tools/call" && fake.requests[i].Params["inputResponses"] != nil {
+			retried = &fake.requests[i]
+		}
+	}
+	if retried == nil {
+		t.Fatal("expected the call to be made again with the answers")
+	}
+	if got := string(retried.Params["requestState"]); got != `"step-1"` {
+		t.Errorf("requestState = %s, want it echoed", got)
+	}
+	responses := string(retried.Params["inputResponses"])
+	if !strings.Contains(responses, `"uri":"file:///srv/docs"`) || !strings.Contains(responses, `"name":"Ada"`) || !strings.Contains(responses, `"action":"accept"`) {
+		t.Errorf("inputResponses = %s", responses)
+	}
+	if retried.Headers.Get(apps.AnswerHeader) != "" {
+		t.Error("the answer header is kaja's, and goes no further than the app")
+	}
+
+	// An answer to a question nobody is waiting on any more says so.
+	if _, err := in.Invoke("mcp.Tools/GetWeather", request, map[string]string{apps.AnswerHeader: answer}); err == nil || !strings.Contains(err.Error(), "no longer waiting") {
+		t.Errorf("err = %v, want the stale answer refused", err)
+	}
+}
+
+// A server that never stops asking is cut off rather than called forever.
+func TestInvokeInputRequiredGivesUp(t *testing.T) {
 	_, endpoint := modernServer(t, map[string]string{
-		"tools/call": `{"resultType":"input_required","inputRequests":{"login":{"method":"elicitation/create","params":{}}}}`,
+		"tools/call": `{"resultType":"input_required","inputRequests":{"where":{"method":"roots/list","params":{}}}}`,
 	})
 	in, _ := openApp(t, endpoint, nil)
 	bound := in.methods["mcp.Tools/GetWeather"]
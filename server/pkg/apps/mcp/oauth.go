@@ -0,0 +1,760 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// signInTimeout is how long a sign-in stays open for the user to finish. Asking
+// again within it hands out the same URL, so a form that reads the server on
+// every settled keystroke doesn't register a client each time.
+const signInTimeout = 10 * time.Minute
+
+// refreshMargin is how close to expiring a token is refreshed before it is sent
+// rather than after the server turns it down.
+const refreshMargin = 30 * time.Second
+
+// oauthClientName is what kaja registers itself as with an authorization server. It
+// is what the consent screen shows the user.
+const oauthClientName = "kaja"
+
+// AuthorizationRequired is a call the server refused until the user signs in.
+// URL is where they do; once they have, the call can be made again.
+type AuthorizationRequired struct {
+	URL string
+	// Err is the server's refusal.
+	Err error
+}
+
+func (e *AuthorizationRequired) Error() string {
+	return "the server wants you to sign in: open " + e.URL + " and try again once you have"
+}
+
+func (e *AuthorizationRequired) Unwrap() error { return e.Err }
+
+// Authorizer signs kaja in to MCP servers that follow the protocol's
+// authorization spec, and holds the tokens they hand out. A server that wants a
+// token answers 401, naming its protected-resource metadata; that names the
+// authorization server, whose metadata names the endpoints. kaja registers
+// itself there, and the user signs in with the authorization code flow and PKCE.
+// The code comes back to kaja's own web server at CallbackPath, on the host the
+// browser reached it at. The desktop has no server a browser reaches, so there
+// it comes back to a listener on the loopback interface, the way a native app
+// receives it, which is only up while a sign-in waits on it.
+//
+// Tokens never leave this process: the browser only ever sees the sign-in URL.
+// They are held in memory, by the endpoint they were issued for, and refreshed
+// as they expire.
+type Authorizer struct {
+	http *http.Client
+
+	mu sync.Mutex
+	// grants are the tokens held, by endpoint.
+	grants map[string]*grant
+	// signIns are the sign-ins started and not yet finished, by their state.
+	signIns map[string]*signIn
+	// clients are the registrations made, by authorization server and redirect
+	// URI, both of which a registration is bound to.
+	clients map[string]*registration
+	// loopback is the listener sign-ins with no callback come back to, while
+	// any waits on it, and loopbackURI its redirect URI.
+	loopback    *http.Server
+	loopbackURI string
+}
+
+// authorizer is the process's own: every mcp app signs in through it, so a
+// server signed in to once is signed in to for every app that reaches it.
+var authorizer = NewAuthorizer(&http.Client{Timeout: inspectTimeout})
+
+// CallbackPath is where kaja's web server, under its path prefix, takes the user
+// back from signing in to an MCP server.
+const CallbackPath = "/mcp/oauth/callback"
+
+// CallbackParameter is the parameter an mcp app is opened with naming the
+// redirect URI on kaja's own server its sign-ins come back to. Without it they
+// come back to a loopback listener.
+const CallbackParameter = "oauth_callback"
+
+// ServeCallback finishes the sign-in the user comes back from. The web server
+// serves it at CallbackPath.
+func ServeCallback(w http.ResponseWriter, r *http.Request) {
+	authorizer.serveCallback(w, r)
+}
+
+type callbackKey struct{}
+
+// WithCallback has every request next serves carry the redirect URI sign-ins
+// come back to: CallbackPath under pathPrefix, on the scheme and host the
+// request came in on, which is how the browser that made it reaches kaja.
+// CallbackFrom reads it.
+func WithCallback(next http.Handler, pathPrefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		// Behind a proxy that ends TLS, the proxy says what the browser used.
+		if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded == "http" || forwarded == "https" {
+			scheme = forwarded
+		}
+		callback := scheme + "://" + r.Host + pathPrefix + CallbackPath
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callbackKey{}, callback)))
+	})
+}
+
+// CallbackFrom is the redirect URI WithCallback had ctx carry, or empty.
+func CallbackFrom(ctx context.Context) string {
+	callback, _ := ctx.Value(callbackKey{}).(string)
+	return callback
+}
+
+// NewAuthorizer builds an Authorizer that reaches authorization servers with
+// httpClient. It performs no I/O: a loopback listener is started by the sign-in
+// that needs one.
+func NewAuthorizer(httpClient *http.Client) *Authorizer {
+	return &Authorizer{
+		http:    httpClient,
+		grants:  map[string]*grant{},
+		signIns: map[string]*signIn{},
+		clients: map[string]*registration{},
+	}
+}
+
+// grant is what a sign-in left kaja holding for one endpoint.
+type grant struct {
+	accessToken  string
+	refreshToken string
+	// expires is when the access token stops working; zero when the
+	// authorization server didn't say.
+	expires       time.Time
+	tokenEndpoint string
+	client        *registration
+	resource      string
+	// refreshing is closed when the refresh under way finishes; nil when none is.
+	refreshing chan struct{}
+}
+
+// registration is a client kaja registered as with an authorization server.
+type registration struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	// AuthMethod is how the secret is presented at the token endpoint.
+	AuthMethod string `json:"token_endpoint_auth_method"`
+}
+
+// signIn is a sign-in started and waiting on the user.
+type signIn struct {
+	endpoint      string
+	resource      string
+	verifier      string
+	redirectURI   string
+	tokenEndpoint string
+	client        *registration
+	url           string
+	started       time.Time
+	// callback is the one the sign-in was started with; empty for the loopback
+	// listener.
+	callback string
+}
+
+// authServer is the authorization server metadata (RFC 8414) kaja reads.
+type authServer struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	RegistrationEndpoint          string   `json:"registration_endpoint"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// protectedResource is the protected resource metadata (RFC 9728) kaja reads.
+type protectedResource struct {
+	Resource             string   `json:"resource"`
+	AuthorizationServers []string `json:"authorization_servers"`
+	ScopesSupported      []string `json:"scopes_supported"`
+}
+
+// token is the access token held for endpoint, refreshed first when it is about
+// to expire, or empty when kaja holds none.
+func (a *Authorizer) token(endpoint string) string {
+	a.mu.Lock()
+	held := a.grants[endpoint]
+	a.mu.Unlock()
+	if held == nil {
+		return ""
+	}
+	if !held.expires.IsZero() && time.Until(held.expires) < refreshMargin {
+		fresh, err := a.refresh(endpoint, held.accessToken)
+		if err == nil {
+			return fresh
+		}
+		// The server may take it yet; a call it turns down refreshes again.
+	}
+	return held.accessToken
+}
+
+// holds reports whether the authorizer holds a token for endpoint.
+func (a *Authorizer) holds(endpoint string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.grants[endpoint] != nil
+}
+
+// refresh trades the refresh token held for endpoint for a new access token,
+// when rejected is still the one held - a call that raced this one may have
+// refreshed it already - and returns what is held after. Only one refresh of a
+// grant is made at a time; a call that wants one meanwhile waits for it and
+// takes what it got. A grant the authorization server won't refresh is dropped,
+// and empty returned: the user has to sign in again. A refresh that fails on the
+// way there is an error, and the grant is kept for the next call to try again.
+func (a *Authorizer) refresh(endpoint string, rejected string) (string, error) {
+	a.mu.Lock()
+	held := a.grants[endpoint]
+	for held != nil && held.refreshing != nil {
+		refreshing := held.refreshing
+		a.mu.Unlock()
+		<-refreshing
+		a.mu.Lock()
+		held = a.grants[endpoint]
+	}
+	if held == nil {
+		a.mu.Unlock()
+		return "", nil
+	}
+	if held.accessToken != rejected {
+		a.mu.Unlock()
+		return held.accessToken, nil
+	}
+	if held.refreshToken == "" {
+		delete(a.grants, endpoint)
+		a.mu.Unlock()
+		return "", nil
+	}
+	refreshing := make(chan struct{})
+	held.refreshing = refreshing
+	a.mu.Unlock()
+
+	// The round trip is made without the lock, so one slow authorization
+	// server holds up no other endpoint's token.
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {held.refreshToken},
+		"resource":      {held.resource},
+	}
+	fresh, err := a.requestToken(held.tokenEndpoint, held.client, form)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	held.refreshing = nil
+	close(refreshing)
+	current := a.grants[endpoint]
+	var refused *tokenRefusal
+	switch {
+	case errors.As(err, &refused) && refused.code == "invalid_grant":
+		if current == held {
+			delete(a.grants, endpoint)
+		}
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("refreshing the OAuth token: %w", err)
+	case current != held:
+		// Signed out, or signed in again, while this was on its way.
+		if current == nil {
+			return "", nil
+		}
+		return current.accessToken, nil
+	}
+	if fresh.refreshToken == "" {
+		// The refresh token wasn't rotated, and still works.
+		fresh.refreshToken = held.refreshToken
+	}
+	fresh.tokenEndpoint, fresh.client, fresh.resource = held.tokenEndpoint, held.client, held.resource
+	a.grants[endpoint] = fresh
+	return fresh.accessToken, nil
+}
+
+// signOut forgets the tokens held for endpoint.
+func (a *Authorizer) signOut(endpoint string) {
+	a.mu.Lock()
+	delete(a.grants, endpoint)
+	a.mu.Unlock()
+}
+
+// require is the answer to a 401 from endpoint: the sign-in the user has to
+// finish before the call can be made again. callback is the redirect URI on
+// kaja's own server, or empty for the loopback listener; challenge is the
+// WWW-Authenticate header the server answered with; refusal is the 401 itself,
+// which is what the caller gets when the server can't be signed in to at all.
+func (a *Authorizer) require(endpoint string, callback string, challenge string, refusal error) error {
+	a.mu.Lock()
+	for state, started := range a.signIns {
+		if time.Since(started.started) > signInTimeout {
+			delete(a.signIns, state)
+			continue
+		}
+		if started.endpoint == endpoint && started.callback == callback {
+			a.mu.Unlock()
+			return &AuthorizationRequired{URL: started.url, Err: refusal}
+		}
+	}
+	a.mu.Unlock()
+
+	started, err := a.begin(endpoint, callback, challenge)
+	if err != nil {
+		return fmt.Errorf("%w (signing in: %v)", refusal, err)
+	}
+	return &AuthorizationRequired{URL: started.url, Err: refusal}
+}
+
+// discover reads how endpoint is signed in to: its protected resource metadata,
+// from where challenge points or the well-known locations, and the metadata of
+// the first authorization server that names. It only reads; nothing is
+// registered and no sign-in is started.
+func (a *Authorizer) discover(endpoint string, challenge string) (*protectedResource, *authServer, error) {
+	resource, err := a.discoverResource(endpoint, challengeParams(challenge)["resource_metadata"])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resource.AuthorizationServers) == 0 {
+		return nil, nil, fmt.Errorf("the server names no authorization server")
+	}
+	server, err := a.discoverAuthServer(resource.AuthorizationServers[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if methods := server.CodeChallengeMethodsSupported; len(methods) > 0 && !slices.Contains(methods, "S256") {
+		return nil, nil, fmt.Errorf("the authorization server doesn't support PKCE with S256")
+	}
+	return resource, server, nil
+}
+
+// begin discovers how endpoint is signed in to and starts a sign-in there.
+func (a *Authorizer) begin(endpoint string, callback string, challenge string) (*signIn, error) {
+	params := challengeParams(challenge)
+	resource, server, err := a.discover(endpoint, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURI := callback
+	if redirectURI == "" {
+		if redirectURI, err = a.listen(); err != nil {
+			return nil, err
+		}
+	}
+	client, err := a.register(server, redirectURI)
+	if err != nil {
+		a.closeLoopback()
+		return nil, err
+	}
+
+	verifier := rand.Text() + rand.Text()
+	challengeHash := sha256.Sum256([]byte(verifier))
+	state := rand.Text()
+	resourceID := resource.Resource
+	if resourceID == "" {
+		resourceID = canonicalResource(endpoint)
+	}
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {client.ClientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challengeHash[:])},
+		"code_challenge_method": {"S256"},
+		"resource":              {resourceID},
+	}
+	// The scope the server asked for in its challenge, or every one it says it
+	// has; none at all leaves it to the authorization server.
+	scope := params["scope"]
+	if scope == "" {
+		scope = strings.Join(resource.ScopesSupported, " ")
+	}
+	if scope != "" {
+		query.Set("scope", scope)
+	}
+	authorizationURL, err := url.Parse(server.AuthorizationEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("the authorization endpoint %q is not a URL: %w", server.AuthorizationEndpoint, err)
+	}
+	for key, values := range authorizationURL.Query() {
+		query[key] = values
+	}
+	authorizationURL.RawQuery = query.Encode()
+
+	started := &signIn{
+		endpoint:      endpoint,
+		resource:      resourceID,
+		verifier:      verifier,
+		redirectURI:   redirectURI,
+		callback:      callback,
+		tokenEndpoint: server.TokenEndpoint,
+		client:        client,
+		url:           authorizationURL.String(),
+		started:       time.Now(),
+	}
+	a.mu.Lock()
+	a.signIns[state] = started
+	a.mu.Unlock()
+	if callback == "" {
+		time.AfterFunc(signInTimeout, a.closeLoopback)
+	}
+	return started, nil
+}
+
+// discoverResource reads the server's protected resource metadata: from where
+// its challenge points, or from the well-known locations under its origin.
+func (a *Authorizer) discoverResource(endpoint string, pointed string) (*protectedResource, error) {
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	if pointed != "" {
+		candidates = append(candidates, pointed)
+	} else {
+		path := strings.TrimSuffix(base.Path, "/")
+		if path != "" {
+			candidates = append(candidates, originOf(base)+"/.well-known/oauth-protected-resource"+path)
+		}
+		candidates = append(candidates, originOf(base)+"/.well-known/oauth-protected-resource")
+	}
+
+	var resource protectedResource
+	if err := a.getFirst(candidates, &resource); err != nil {
+		return nil, fmt.Errorf("reading the server's protected resource metadata: %w", err)
+	}
+	// Metadata that speaks for another server's resource would have the user
+	// sign in to that one on this one's say-so.
+	if resource.Resource != "" {
+		named, err := url.Parse(resource.Resource)
+		if err != nil || originOf(named) != originOf(base) {
+			return nil, fmt.Errorf("the protected resource metadata is for %q, not this server", resource.Resource)
+		}
+	}
+	return &resource, nil
+}
+
+// discoverAuthServer reads an authorization server's metadata from the
+// well-known locations RFC 8414 and OpenID Connect Discovery give it.
+func (a *Authorizer) discoverAuthServer(issuer string) (*authServer, error) {
+	base, err := url.Parse(issuer)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") {
+		return nil, fmt.Errorf("the authorization server %q is not an HTTP URL", issuer)
+	}
+	origin, path := originOf(base), strings.TrimSuffix(base.Path, "/")
+	candidates := []string{
+		origin + "/.well-known/oauth-authorization-server" + path,
+		origin + "/.well-known/openid-configuration" + path,
+	}
+	if path != "" {
+		candidates = append(candidates, origin+path+"/.well-known/openid-configuration")
+	}
+
+	var server authServer
+	if err := a.getFirst(candidates, &server); err != nil {
+		return nil, fmt.Errorf("reading the authorization server's metadata: %w", err)
+	}
+	if server.AuthorizationEndpoint == "" || server.TokenEndpoint == "" {
+		return nil, fmt.Errorf("the authorization server's metadata names no authorization or token endpoint")
+	}
+	return &server, nil
+}
+
+// register registers kaja with the authorization server (RFC 7591), once per
+// server and redirect URI.
+func (a *Authorizer) register(server *authServer, redirectURI string) (*registration, error) {
+	key := server.TokenEndpoint + " " + redirectURI
+	a.mu.Lock()
+	client := a.clients[key]
+	a.mu.Unlock()
+	if client != nil {
+		return client, nil
+	}
+	if server.RegistrationEndpoint == "" {
+		return nil, fmt.Errorf("the authorization server doesn't register clients, which kaja needs to sign in")
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"client_name":                oauthClientName,
+		"redirect_uris":              []string{redirectURI},
+		"grant_types":                []string{"authorization_code", "refresh_token"},
+		"response_types":             []string{"code"},
+		"token_endpoint_auth_method": "none",
+	})
+	response, err := a.http.Post(server.RegistrationEndpoint, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("registering with the authorization server: %w", err)
+	}
+	defer response.Body.Close()
+	payload, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("registering with the authorization server: %s", oauthFailure(response.Status, payload))
+	}
+	client = &registration{}
+	if err := json.Unmarshal(payload, client); err != nil || client.ClientID == "" {
+		return nil, fmt.Errorf("registering with the authorization server: no client_id came back")
+	}
+
+	a.mu.Lock()
+	a.clients[key] = client
+	a.mu.Unlock()
+	return client, nil
+}
+
+// complete finishes the sign-in the authorization server sent the user back
+// from, trading the code for tokens.
+func (a *Authorizer) complete(state string, code string) (string, error) {
+	a.mu.Lock()
+	started := a.signIns[state]
+	delete(a.signIns, state)
+	a.mu.Unlock()
+	if started == nil || time.Since(started.started) > signInTimeout {
+		return "", fmt.Errorf("this sign-in is not one kaja started, or it took too long; try again")
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {started.redirectURI},
+		"code_verifier": {started.verifier},
+		"resource":      {started.resource},
+	}
+	held, err := a.requestToken(started.tokenEndpoint, started.client, form)
+	if err != nil {
+		return "", err
+	}
+	held.tokenEndpoint, held.client, held.resource = started.tokenEndpoint, started.client, started.resource
+	a.mu.Lock()
+	a.grants[started.endpoint] = held
+	a.mu.Unlock()
+	return started.endpoint, nil
+}
+
+// requestToken makes one token request, authenticating as client.
+func (a *Authorizer) requestToken(tokenEndpoint string, client *registration, form url.Values) (*grant, error) {
+	form.Set("client_id", client.ClientID)
+	if client.ClientSecret != "" && client.AuthMethod != "client_secret_basic" {
+		form.Set("client_secret", client.ClientSecret)
+	}
+	request, err := http.NewRequest(http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("building the token request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if client.ClientSecret != "" && client.AuthMethod == "client_secret_basic" {
+		request.SetBasicAuth(url.QueryEscape(client.ClientID), url.QueryEscape(client.ClientSecret))
+	}
+	response, err := a.http.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("requesting a token: %w", err)
+	}
+	defer response.Body.Close()
+	payload, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if response.StatusCode >= 400 {
+		return nil, &tokenRefusal{code: oauthErrorCode(payload), message: oauthFailure(response.Status, payload)}
+	}
+
+	var token struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(payload, &token); err != nil || token.AccessToken == "" {
+		return nil, fmt.Errorf("requesting a token: no access_token came back")
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return nil, fmt.Errorf("requesting a token: a %s token came back, and MCP takes a bearer token", token.TokenType)
+	}
+	held := &grant{accessToken: token.AccessToken, refreshToken: token.RefreshToken}
+	if token.ExpiresIn > 0 {
+		held.expires = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return held, nil
+}
+
+// listen starts the loopback listener the authorization server sends the user
+// back to, unless it is up already, and returns its redirect URI.
+func (a *Authorizer) listen() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.loopback != nil {
+		return a.loopbackURI, nil
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("listening for the sign-in to finish: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", a.serveCallback)
+	a.loopback = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	a.loopbackURI = "http://" + listener.Addr().String() + "/callback"
+	go a.loopback.Serve(listener)
+	return a.loopbackURI, nil
+}
+
+// closeLoopback shuts the loopback listener down once no sign-in waits on it:
+// when the last one has come back, or has taken too long.
+func (a *Authorizer) closeLoopback() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.loopback == nil {
+		return
+	}
+	for state, started := range a.signIns {
+		if time.Since(started.started) > signInTimeout {
+			delete(a.signIns, state)
+			continue
+		}
+		if started.callback == "" {
+			return
+		}
+	}
+	// Shut down rather than closed, so the page of the callback that got here
+	// is still sent.
+	go a.loopback.Shutdown(context.Background())
+	a.loopback, a.loopbackURI = nil, ""
+}
+
+// serveCallback is where the user lands once they have signed in, or refused.
+// What they read there is all they will see of it: the call is made again from
+// kaja.
+func (a *Authorizer) serveCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if failure := query.Get("error"); failure != "" {
+		a.mu.Lock()
+		delete(a.signIns, query.Get("state"))
+		a.mu.Unlock()
+		message := failure
+		if description := query.Get("error_description"); description != "" {
+			message += ": " + description
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		writeCallbackPage(w, "Sign-in failed", message)
+		a.closeLoopback()
+		return
+	}
+	endpoint, err := a.complete(query.Get("state"), query.Get("code"))
+	defer a.closeLoopback()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		writeCallbackPage(w, "Sign-in failed", err.Error())
+		return
+	}
+	writeCallbackPage(w, "Signed in", "kaja is signed in to "+endpoint+". Close this tab and go back to kaja.")
+}
+
+func writeCallbackPage(w io.Writer, title string, message string) {
+	fmt.Fprintf(w, "<!doctype html><title>%s</title><body style=\"font-family: sans-serif; margin: 3em\"><h1>%s</h1><p>%s</p></body>",
+		html.EscapeString(title), html.EscapeString(title), html.EscapeString(message))
+}
+
+// getFirst decodes the first of candidates that answers with JSON.
+func (a *Authorizer) getFirst(candidates []string, into any) error {
+	var failures []string
+	for _, candidate := range candidates {
+		if err := requireHTTPScheme(candidate); err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		response, err := a.http.Get(candidate)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		payload, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			failures = append(failures, candidate+" answered "+response.Status)
+			continue
+		}
+		if err := json.Unmarshal(payload, into); err != nil {
+			failures = append(failures, candidate+" is not JSON")
+			continue
+		}
+		return nil
+	}
+	return errors.New(strings.Join(failures, "; "))
+}
+
+// challengePattern matches one auth-param of a WWW-Authenticate challenge.
+var challengePattern = regexp.MustCompile(`([A-Za-z_]+)\s*=\s*(?:"((?:[^"\\]|\\.)*)"|([^\s,]+))`)
+
+// challengeParams reads the parameters of a Bearer challenge: resource_metadata
+// and scope are the ones kaja uses.
+func challengeParams(header string) map[string]string {
+	params := map[string]string{}
+	for _, match := range challengePattern.FindAllStringSubmatch(header, -1) {
+		value := match[3]
+		if match[2] != "" || match[3] == "" {
+			value = strings.ReplaceAll(match[2], `\"`, `"`)
+		}
+		params[strings.ToLower(match[1])] = value
+	}
+	return params
+}
+
+// canonicalResource is the resource indicator (RFC 8707) for an endpoint that
+// names none of its own: the endpoint, without a fragment.
+func canonicalResource(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	u.Fragment = ""
+	return u.String()
+}
+
+func originOf(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// tokenRefusal is a token request the authorization server answered with an
+// error. code is the OAuth error code (RFC 6749) it gave, if any.
+type tokenRefusal struct {
+	code    string
+	message string
+}
+
+func (e *tokenRefusal) Error() string { return "requesting a token: " + e.message }
+
+// oauthErrorCode is the error code of an OAuth error response, or empty.
+func oauthErrorCode(payload []byte) string {
+	var failure struct {
+		Error string `json:"error"`
+	}
+	json.Unmarshal(payload, &failure)
+	return failure.Error
+}
+
+// oauthFailure is the one line an OAuth error response (RFC 6749) comes down to.
+func oauthFailure(status string, payload []byte) string {
+	var failure struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if json.Unmarshal(payload, &failure) == nil && failure.Error != "" {
+		if failure.ErrorDescription != "" {
+			return failure.Error + ": " + failure.ErrorDescription
+		}
+		return failure.Error
+	}
+	return status + ": " + summarize(payload)
+}
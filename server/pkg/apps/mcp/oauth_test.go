@@ -0,0 +1,377 @@
+package mcp
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+)
+
+// standIn is a stand-in for an MCP server behind OAuth: the server itself,
+// its protected resource metadata, and the authorization server that issues its
+// tokens, which signs the user in without asking.
+type standIn struct {
+	url  string
+	mcp  *fakeServer
+	mu   sync.Mutex
+	next int
+	// valid are the access tokens the server takes; codes the verifier's
+	// challenge and redirect URI each code was issued for.
+	valid    map[string]bool
+	codes    map[string][2]string
+	refresh  map[string]bool
+	grants   []string
+	resource string
+	// registered counts the clients registered with the authorization server.
+	registered int
+	// down has the token endpoint fail as a server that is briefly down does.
+	down bool
+}
+
+func newStandIn(t *testing.T) *standIn {
+	t.Helper()
+	s := &standIn{valid: map[string]bool{}, codes: map[string][2]string{}, refresh: map[string]bool{}}
+	s.mcp = &fakeServer{era: "modern", results: map[string]string{
+		"server/discover": `{"resultType":"complete","capabilities":{"tools":{}},"_meta":{}}`,
+		"tools/list":      weatherTools,
+	}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		ok := s.valid[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		s.mu.Unlock()
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer resource_metadata="`+s.url+`/.well-known/oauth-protected-resource/mcp", scope="weather"`)
+			http.Error(w, `{"error":"invalid_token"}`, http.StatusUnauthorized)
+			return
+		}
+		s.mcp.handler()(w, r)
+	})
+	mux.HandleFunc("/.well-known/oauth-protected-resource/mcp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"resource":%q,"authorization_servers":[%q]}`, s.url+"/mcp", s.url+"/auth")
+	})
+	mux.HandleFunc("/.well-known/oauth-authorization-server/auth", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"issuer":%q,"authorization_endpoint":%q,"token_endpoint":%q,"registration_endpoint":%q,"code_challenge_methods_supported":["S256"]}`,
+			s.url+"/auth", s.url+"/auth/authorize", s.url+"/auth/token", s.url+"/auth/register")
+	})
+	mux.HandleFunc("/auth/register", func(w http.ResponseWriter, r *http.Request) {
+		var client struct {
+			RedirectURIs []string `json:"redirect_uris"`
+		}
+		if json.NewDecoder(r.Body).Decode(&client) != nil || len(client.RedirectURIs) != 1 || !strings.HasPrefix(client.RedirectURIs[0], "http://127.0.0.1:") {
+			http.Error(w, `{"error":"invalid_redirect_uri"}`, http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.registered++
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"client_id":"kaja-client"}`)
+	})
+	mux.HandleFunc("/auth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("client_id") != "kaja-client" || query.Get("code_challenge_method") != "S256" || query.Get("scope") != "weather" {
+			http.Error(w, "bad authorization request", http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.next++
+		code := fmt.Sprintf("code-%d", s.next)
+		s.codes[code] = [2]string{query.Get("code_challenge"), query.Get("redirect_uri")}
+		s.resource = query.Get("resource")
+		s.mu.Unlock()
+		back := query.Get("redirect_uri") + "?" + url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+		http.Redirect(w, r, back, http.StatusFound)
+	})
+	mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.grants = append(s.grants, r.PostForm.Get("grant_type"))
+		if s.down {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			issued, ok := s.codes[r.PostForm.Get("code")]
+			hash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if !ok || base64.RawURLEncoding.EncodeToString(hash[:]) != issued[0] || r.PostForm.Get("redirect_uri") != issued[1] {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			delete(s.codes, r.PostForm.Get("code"))
+		case "refresh_token":
+			if !s.refresh[r.PostForm.Get("refresh_token")] {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, `{"error":"unsupported_grant_type"}`, http.StatusBadRequest)
+			return
+		}
+		s.next++
+		access, refresh := fmt.Sprintf("access-%d", s.next), fmt.Sprintf("refresh-%d", s.next)
+		s.valid[access], s.refresh[refresh] = true, true
+		fmt.Fprintf(w, `{"access_token":%q,"token_type":"Bearer","expires_in":3600,"refresh_token":%q}`, access, refresh)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	s.url = server.URL
+	return s
+}
+
+// revoke has the server turn down every access token issued so far, as it does
+// one that has expired.
+func (s *standIn) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valid = map[string]bool{}
+}
+
+func TestOAuthSignIn(t *testing.T) {
+	server := newStandIn(t)
+	authorizer := NewAuthorizer(http.DefaultClient)
+	client := NewClient(server.url+"/mcp", nil, http.DefaultClient).WithOAuth(authorizer, "")
+
+	_, _, err := client.Call("tools/list", nil, nil)
+	var required *AuthorizationRequired
+	if !errors.As(err, &required) {
+		t.Fatalf("err = %v, want the sign-in the server wants", err)
+	}
+	// Asking again before the user has signed in hands out the same sign-in.
+	if _, _, again := client.Call("tools/list", nil, nil); !errors.As(again, &required) || again.Error() != err.Error() {
+		t.Errorf("second err = %v, want the same sign-in", again)
+	}
+
+	// The user signs in: the authorization server sends the browser back to
+	// kaja's loopback listener with the code.
+	response, err := http.Get(required.URL)
+	if err != nil {
+		t.Fatalf("signing in: %v", err)
+	}
+	page, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || !strings.Contains(string(page), "Signed in") {
+		t.Fatalf("callback answered %s: %s", response.Status, page)
+	}
+	if server.resource != server.url+"/mcp" {
+		t.Errorf("resource = %q, want the server's own", server.resource)
+	}
+	// With no sign-in left waiting on it, the loopback listener is shut down.
+	authorizer.mu.Lock()
+	loopback := authorizer.loopback
+	authorizer.mu.Unlock()
+	if loopback != nil {
+		t.Error("the loopback listener is still up after the sign-in came back")
+	}
+
+	_, exchange, err := client.Call("tools/list", nil, nil)
+	if err != nil {
+		t.Fatalf("Call after signing in: %v", err)
+	}
+	if got := exchange.RequestHeaders["Authorization"]; got != "Bearer ‹OAuth token›" {
+		t.Errorf("Authorization shown as %q, want the token masked", got)
+	}
+	if got := server.mcp.requests[len(server.mcp.requests)-1].Headers.Get("Authorization"); !strings.HasPrefix(got, "Bearer access-") {
+		t.Errorf("Authorization sent = %q, want the issued token", got)
+	}
+
+	// A token the server turns down is refreshed, and the call made again.
+	server.revoke()
+	if _, _, err := client.Call("tools/list", nil, nil); err != nil {
+		t.Fatalf("Call after the token was turned down: %v", err)
+	}
+	if got := strings.Join(server.grants, ","); got != "authorization_code,refresh_token" {
+		t.Errorf("grants = %s, want the code and then a refresh", got)
+	}
+}
+
+// A refresh that fails on the way keeps the grant for the next call; one the
+// authorization server won't make drops it.
+func TestOAuthRefreshFailures(t *testing.T) {
+	server := newStandIn(t)
+	authorizer := NewAuthorizer(http.DefaultClient)
+	client := NewClient(server.url+"/mcp", nil, http.DefaultClient).WithOAuth(authorizer, "")
+	_, _, err := client.Call("tools/list", nil, nil)
+	var required *AuthorizationRequired
+	if !errors.As(err, &required) {
+		t.Fatalf("err = %v, want the sign-in the server wants", err)
+	}
+	if response, err := http.Get(required.URL); err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("signing in: %v %v", response, err)
+	}
+	if _, _, err := client.Call("tools/list", nil, nil); err != nil {
+		t.Fatalf("Call after signing in: %v", err)
+	}
+	endpoint := server.url + "/mcp"
+
+	server.revoke()
+	server.mu.Lock()
+	server.down = true
+	server.mu.Unlock()
+	if _, _, err := client.Call("tools/list", nil, nil); err == nil || errors.As(err, &required) {
+		t.Fatalf("err = %v, want the failed refresh and no sign-in", err)
+	}
+	if !authorizer.holds(endpoint) {
+		t.Fatal("a refresh that failed on the way dropped the grant")
+	}
+	server.mu.Lock()
+	server.down = false
+	server.mu.Unlock()
+	if _, _, err := client.Call("tools/list", nil, nil); err != nil {
+		t.Fatalf("Call once the authorization server is back: %v", err)
+	}
+
+	server.revoke()
+	server.mu.Lock()
+	server.refresh = map[string]bool{}
+	server.mu.Unlock()
+	if _, _, err := client.Call("tools/list", nil, nil); !errors.As(err, &required) {
+		t.Fatalf("err = %v, want a new sign-in once the refresh token is refused", err)
+	}
+	if authorizer.holds(endpoint) {
+		t.Error("a grant the authorization server won't refresh was kept")
+	}
+}
+
+// On the web server the user comes back to kaja's own server, and no loopback
+// listener is started.
+func TestOAuthCallbackOnKajasServer(t *testing.T) {
+	server := newStandIn(t)
+	authorizer := NewAuthorizer(http.DefaultClient)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /kaja"+CallbackPath, authorizer.serveCallback)
+	kaja := httptest.NewServer(mux)
+	t.Cleanup(kaja.Close)
+
+	client := NewClient(server.url+"/mcp", nil, http.DefaultClient).WithOAuth(authorizer, kaja.URL+"/kaja"+CallbackPath)
+	_, _, err := client.Call("tools/list", nil, nil)
+	var required *AuthorizationRequired
+	if !errors.As(err, &required) {
+		t.Fatalf("err = %v, want the sign-in the server wants", err)
+	}
+	signInURL, _ := url.Parse(required.URL)
+	if got := signInURL.Query().Get("redirect_uri"); got != kaja.URL+"/kaja"+CallbackPath {
+		t.Errorf("redirect_uri = %q, want kaja's own callback", got)
+	}
+	authorizer.mu.Lock()
+	loopback := authorizer.loopback
+	authorizer.mu.Unlock()
+	if loopback != nil {
+		t.Error("a loopback listener was started for a sign-in that comes back to kaja")
+	}
+
+	response, err := http.Get(required.URL)
+	if err != nil {
+		t.Fatalf("signing in: %v", err)
+	}
+	page, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || !strings.Contains(string(page), "Signed in") {
+		t.Fatalf("callback answered %s: %s", response.Status, page)
+	}
+	if _, _, err := client.Call("tools/list", nil, nil); err != nil {
+		t.Fatalf("Call after signing in: %v", err)
+	}
+}
+
+func TestWithCallback(t *testing.T) {
+	var got string
+	handler := WithCallback(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = CallbackFrom(r.Context())
+	}), "/kaja")
+
+	request := httptest.NewRequest(http.MethodPost, "http://kaja.internal:41520/twirp/Api/OpenApp", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	if got != "http://kaja.internal:41520/kaja"+CallbackPath {
+		t.Errorf("callback = %q", got)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "http://kaja.example.com/twirp/Api/OpenApp", nil)
+	request.Header.Set("X-Forwarded-Proto", "https")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	if got != "https://kaja.example.com/kaja"+CallbackPath {
+		t.Errorf("callback behind a proxy = %q", got)
+	}
+}
+
+func TestOAuthInspect(t *testing.T) {
+	server := newStandIn(t)
+	endpoint := server.url + "/mcp"
+
+	// An app that says nothing about signing in is told where it could, and no
+	// more: nothing is registered and no sign-in is waiting on the user.
+	_, problem := Inspect(map[string]string{"url": endpoint})
+	if problem == nil || problem.Kind != ProblemUnauthorized || problem.AuthorizationServer != server.url+"/auth" || problem.AuthorizationURL != "" {
+		t.Fatalf("problem = %+v, want unauthorized naming the authorization server", problem)
+	}
+	server.mu.Lock()
+	registered := server.registered
+	server.mu.Unlock()
+	if registered != 0 {
+		t.Errorf("registered %d clients while only reading the server", registered)
+	}
+	authorizer.mu.Lock()
+	for _, started := range authorizer.signIns {
+		if started.endpoint == endpoint {
+			t.Errorf("a sign-in was started while only reading the server")
+		}
+	}
+	authorizer.mu.Unlock()
+
+	// Choosing to sign in is the app saying "oauth", and that starts one.
+	_, problem = Inspect(map[string]string{"url": endpoint, "auth": "oauth"})
+	if problem == nil || problem.Kind != ProblemUnauthorized || !strings.HasPrefix(problem.AuthorizationURL, server.url+"/auth/authorize?") {
+		t.Fatalf("problem = %+v, want unauthorized with where to sign in", problem)
+	}
+
+	// A token pasted in is the app's choice, and no sign-in is offered.
+	_, problem = Inspect(map[string]string{"url": endpoint, "token": "pasted"})
+	if problem == nil || problem.Kind != ProblemUnauthorized || problem.AuthorizationURL != "" || problem.AuthorizationServer != "" {
+		t.Fatalf("problem = %+v, want unauthorized and nothing to sign in to", problem)
+	}
+}
+
+// An app signs in only when it says so: one that says nothing about how to
+// authenticate gets the server's refusal, as it did before kaja could sign in.
+func TestOAuthIsOptedInto(t *testing.T) {
+	server := newStandIn(t)
+	client, err := connect(map[string]string{"url": server.url + "/mcp"}, inspectTimeout, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Call("tools/list", nil, nil)
+	var required *AuthorizationRequired
+	var upstream *apps.UpstreamError
+	if errors.As(err, &required) || !errors.As(err, &upstream) || upstream.Status != http.StatusUnauthorized {
+		t.Errorf("err = %v, want the 401 and no sign-in", err)
+	}
+
+	client, err = connect(map[string]string{"url": server.url + "/mcp", "auth": "oauth"}, inspectTimeout, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Call("tools/list", nil, nil); !errors.As(err, &required) {
+		t.Errorf("err = %v, want the sign-in the server wants", err)
+	}
+}
+
+func TestChallengeParams(t *testing.T) {
+	params := challengeParams(`Bearer error="invalid_token", resource_metadata="https://example.com/.well-known/oauth-protected-resource", scope=files`)
+	if params["resource_metadata"] != "https://example.com/.well-known/oauth-protected-resource" || params["scope"] != "files" || params["error"] != "invalid_token" {
+		t.Errorf("params = %v", params)
+	}
+}
@@ -65,14 +65,22 @@ type Implementation struct {
 	Version string `json:"version"`
 }
 
-// Capabilities is what a server says it serves. Only the presence of a block
-// matters here, so each is a raw message rather than a shape.
+// Capabilities is what a server says it serves. Mostly only the presence of a
+// block matters here, so most are empty shapes; resources also say whether they
+// can be subscribed to.
 type Capabilities struct {
-	Tools     *struct{} `json:"tools,omitempty"`
-	Resources *struct{} `json:"resources,omitempty"`
-	Prompts   *struct{} `json:"prompts,omitempty"`
-	Logging   *struct{} `json:"logging,omitempty"`
-	Completio *struct{} `json:"completions,omitempty"`
+	Tools       *struct{}            `json:"tools,omitempty"`
+	Resources   *ResourcesCapability `json:"resources,omitempty"`
+	Prompts     *struct{}            `json:"prompts,omitempty"`
+	Logging     *struct{}            `json:"logging,omitempty"`
+	Completions *struct{}            `json:"completions,omitempty"`
+}
+
+// ResourcesCapability is what a server says about its resources.
+type ResourcesCapability struct {
+	// Subscribe is whether a resource can be subscribed to, for a notification
+	// each time it changes.
+	Subscribe bool `json:"subscribe,omitempty"`
 }
 
 // Tool is one tool the server exposes.
@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"unicode"
+
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
 )
 
 // binding records what one generated method does on the wire: the MCP method it
@@ -13,11 +14,17 @@ import (
 type binding struct {
 	// kind decides how the request message becomes params and how the result is
 	// read back: "tool" (the message is the tool's arguments), "prompt" (the
-	// message is the prompt's arguments, all strings) or "passthrough" (the
-	// message is the params, and the result is the response).
+	// message is the prompt's arguments, all strings), "complete" (the message
+	// names what is completed, and is reshaped into the reference the server
+	// wants), "subscribe" (a resource subscription, streamed) or "passthrough"
+	// (the message is the params, and the result is the response).
 	kind   string
 	method string
 	name   string
+	// stream marks a server-streaming method: a tool's streaming variant, which
+	// sends the tool's progress and log messages ahead of its result, or a
+	// resource subscription.
+	stream bool
 }
 
 // generated is the output of converting a server's surface: the proto file text,
@@ -54,6 +61,8 @@ type rpcDef struct {
 	input  string
 	output string
 	doc    string
+	// serverStreaming renders the output as a stream.
+	serverStreaming bool
 }
 
 type serviceDef struct {
@@ -87,7 +96,7 @@ func generateProto(surface *Surface) (*generated, error) {
 	}
 	// The shapes every server shares are written out verbatim, so their names are
 	// spoken for before anything the server named can claim them.
-	for _, name := range []string{"Content", "ResourceContents"} {
+	for _, name := range []string{"Content", "ResourceContents", "Progress", "LogMessage"} {
 		g.usedNames[name] = true
 	}
 
@@ -95,11 +104,14 @@ func generateProto(surface *Surface) (*generated, error) {
 		g.addTools(surface.Tools)
 	}
 	if len(surface.Resources) > 0 || len(surface.ResourceTemplates) > 0 || surface.Capabilities.Resources != nil {
-		g.addResources()
+		g.addResources(surface.Capabilities.Resources != nil && surface.Capabilities.Resources.Subscribe)
 	}
 	if len(surface.Prompts) > 0 {
 		g.addPrompts(surface.Prompts)
 	}
+	if surface.Capabilities.Completions != nil {
+		g.addCompletions(surface.Prompts, surface.ResourceTemplates)
+	}
 
 	if len(g.services) == 0 {
 		return nil, fmt.Errorf("the server exposes no tools, resources or prompts")
@@ -119,10 +131,10 @@ func generateProto(surface *Surface) (*generated, error) {
 }
 
 func (g *generator) addTools(tools []Tool) {
-	service := g.service("Tools", "The tools the server exposes. Each method is one tool: its request is the\ntool's arguments and its response the result the tool returned.")
+	service := g.service("Tools", "The tools the server exposes. Each method is one tool: its request is the\ntool's arguments and its response the result the tool returned. Each tool\nalso has a streaming variant, which sends what the tool reports while it\nruns ahead of the result.")
 
 	for _, tool := range tools {
-		method := g.reserve(protoIdentifier(tool.Name, "Tool"))
+		method := g.reserve(protogen.Identifier(tool.Name, "Tool"))
 		requestName := g.message(method+"Request", "", nil)
 		g.fillFromSchema(requestName, tool.InputSchema)
 
@@ -143,6 +155,23 @@ func (g *generator) addTools(tools []Tool) {
 			name: method, input: requestName, output: response.name, doc: toolDoc(tool),
 		})
 		g.bindings[protoPackage+"."+service.name+"/"+method] = &binding{kind: "tool", method: "tools/call", name: tool.Name}
+
+		stream := g.reserve(method + "Stream")
+		update := &messageDef{
+			name: g.reserve(method + "Update"),
+			doc:  "One message of " + stream + ": the progress the tool reported, a message the\nserver logged, or - last - the tool's result. Exactly one field is set.",
+			fields: []fieldDef{
+				{typ: "Progress", name: "progress", number: 1, jsonName: "progress"},
+				{typ: "LogMessage", name: "log", number: 2, jsonName: "log"},
+				{typ: response.name, name: "result", number: 3, jsonName: "result"},
+			},
+		}
+		g.messages = append(g.messages, update)
+		service.rpcs = append(service.rpcs, &rpcDef{
+			name: stream, input: requestName, output: update.name, serverStreaming: true,
+			doc: method + ", with the progress the tool reports and the messages the server\nlogs while it runs streamed ahead of its result.\n\nMCP tool: " + tool.Name,
+		})
+		g.bindings[protoPackage+"."+service.name+"/"+stream] = &binding{kind: "tool", method: "tools/call", name: tool.Name, stream: true}
 	}
 }
 
@@ -216,7 +245,7 @@ func annotationHint(annotations *ToolAnnotation) string {
 	return "The server describes this tool as " + strings.Join(hints, ", ") + "."
 }
 
-func (g *generator) addResources() {
+func (g *generator) addResources(subscribe bool) {
 	g.messages = append(g.messages,
 		&messageDef{name: g.reserve("ResourceInfo"), doc: "One resource the server lists.", fields: []fieldDef{
 			{typ: "string", name: "uri", number: 1, jsonName: "uri"},
@@ -270,6 +299,79 @@ func (g *generator) addResources() {
 		doc: "List the resource URI templates the server declares.",
 	})
 	g.bindings[protoPackage+".Resources/ListResourceTemplates"] = &binding{kind: "passthrough", method: "resources/templates/list"}
+
+	if !subscribe {
+		return
+	}
+	request := g.message("SubscribeRequest", "", []fieldDef{
+		{typ: "string", name: "uri", number: 1, jsonName: "uri", doc: "URI of the resource to watch."},
+		{typ: "bool", name: "read", number: 2, jsonName: "read", doc: "Read the resource again with each update, and send what it holds along."},
+	})
+	update := g.message("ResourceUpdate", "The server says a subscribed resource changed.", []fieldDef{
+		{typ: "string", name: "uri", number: 1, jsonName: "uri"},
+		{typ: "string", name: "title", number: 2, jsonName: "title"},
+		{typ: "ResourceContents", name: "contents", number: 3, jsonName: "contents", repeated: true,
+			doc: "What the resource holds now, when the request asked to read it."},
+	})
+	method := g.reserve("Subscribe")
+	service.rpcs = append(service.rpcs, &rpcDef{
+		name: method, input: request, output: update, serverStreaming: true,
+		doc: "Subscribe to one resource, and hear each time it changes until the call is\ncancelled. Changes that come while an update is still being sent are\nfolded into the next one.",
+	})
+	g.bindings[protoPackage+".Resources/"+method] = &binding{kind: "subscribe", method: "resources/subscribe", stream: true}
+}
+
+// addCompletions adds the method that asks the server to complete an argument
+// of a prompt or a resource template, as the user types it.
+func (g *generator) addCompletions(prompts []Prompt, templates []ResourceTemplate) {
+	var promptNames, templateURIs []string
+	for _, prompt := range prompts {
+		if len(prompt.Arguments) > 0 {
+			promptNames = append(promptNames, prompt.Name)
+		}
+	}
+	for _, template := range templates {
+		templateURIs = append(templateURIs, template.URITemplate)
+	}
+	if len(promptNames) == 0 && len(templateURIs) == 0 {
+		// Nothing takes an argument, so there is nothing to complete.
+		return
+	}
+
+	request := g.message("CompleteRequest", "", []fieldDef{
+		{typ: "string", name: "prompt", number: 1, jsonName: "prompt",
+			doc: oneOfDoc("The prompt whose argument is completed. Set this or uri_template.", promptNames)},
+		{typ: "string", name: "uri_template", number: 2, jsonName: "uriTemplate",
+			doc: oneOfDoc("The resource template whose argument is completed.", templateURIs)},
+		{typ: "string", name: "argument", number: 3, jsonName: "argument", doc: "Name of the argument."},
+		{typ: "string", name: "value", number: 4, jsonName: "value", doc: "What has been typed of it so far."},
+		{typ: "string", name: "context", number: 5, jsonName: "context", mapKey: "string",
+			doc: "The other arguments already filled in, which the server may narrow its\nsuggestions by."},
+	})
+	completion := g.message("Completion", "", []fieldDef{
+		{typ: "string", name: "values", number: 1, jsonName: "values", repeated: true, doc: "Suggestions, best first."},
+		{typ: "int64", name: "total", number: 2, jsonName: "total", doc: "How many suggestions there are in all, when the server knows."},
+		{typ: "bool", name: "has_more", number: 3, jsonName: "hasMore", doc: "There are more suggestions than were sent."},
+	})
+	response := g.message("CompleteResponse", "", []fieldDef{
+		{typ: completion, name: "completion", number: 1, jsonName: "completion"},
+	})
+
+	service := g.service("Completions", "Suggestions for the arguments of prompts and resource templates, as the\nserver offers them.")
+	method := g.reserve("Complete")
+	service.rpcs = append(service.rpcs, &rpcDef{
+		name: method, input: request, output: response,
+		doc: "Suggest values for one argument of a prompt or a resource template, from what\nhas been typed of it so far.",
+	})
+	g.bindings[protoPackage+"."+service.name+"/"+method] = &binding{kind: "complete", method: "completion/complete"}
+}
+
+// oneOfDoc is a field's doc, listing the values it takes when there are any.
+func oneOfDoc(doc string, values []string) string {
+	if len(values) == 0 {
+		return doc
+	}
+	return doc + "\n\nOne of: " + strings.Join(values, ", ")
 }
 
 func (g *generator) addPrompts(prompts []Prompt) {
@@ -310,7 +412,7 @@ func (g *generator) addPrompts(prompts []Prompt) {
 	g.bindings[protoPackage+".Prompts/ListPrompts"] = &binding{kind: "passthrough", method: "prompts/list"}
 
 	for _, prompt := range prompts {
-		method := g.reserve(protoIdentifier(prompt.Name, "Prompt"))
+		method := g.reserve(protogen.Identifier(prompt.Name, "Prompt"))
 		request := &messageDef{name: g.reserve(method + "Request")}
 		for i, argument := range prompt.Arguments {
 			doc := strings.TrimSpace(argument.Description)
@@ -318,7 +420,7 @@ func (g *generator) addPrompts(prompts []Prompt) {
 				doc = strings.TrimSpace(doc + "\n\n[required]")
 			}
 			request.fields = append(request.fields, fieldDef{
-				typ: "string", name: protoFieldName(argument.Name), number: i + 1, jsonName: argument.Name, doc: doc,
+				typ: "string", name: protogen.FieldName(argument.Name), number: i + 1, jsonName: argument.Name, doc: doc,
 			})
 		}
 		g.messages = append(g.messages, request)
@@ -370,12 +472,12 @@ func (w *walker) fieldsOf(s *schema, owner string) []fieldDef {
 	fields := make([]fieldDef, 0, len(s.Properties))
 	taken := map[string]bool{}
 	for _, entry := range s.Properties {
-		typ, repeated, mapKey := w.typeOf(entry.Schema, owner+pascal(entry.Name))
+		typ, repeated, mapKey := w.typeOf(entry.Schema, owner+protogen.Pascal(entry.Name))
 		// Two properties can render to one field name (fooBar and foo_bar); the
 		// json_name keeps them apart on the wire either way.
-		name := protoFieldName(entry.Name)
+		name := protogen.FieldName(entry.Name)
 		for suffix := 2; taken[name]; suffix++ {
-			name = protoFieldName(entry.Name) + "_" + strconv.Itoa(suffix)
+			name = protogen.FieldName(entry.Name) + "_" + strconv.Itoa(suffix)
 		}
 		taken[name] = true
 		fields = append(fields, fieldDef{
@@ -410,7 +512,7 @@ func (w *walker) typeOf(s *schema, nameHint string) (typ string, repeated bool,
 			return name, false, ""
 		}
 		if isObject(w.merge(resolved)) {
-			name := w.g.reserve(protoIdentifier(refName(ref), "Type"))
+			name := w.g.reserve(protogen.Identifier(refName(ref), "Type"))
 			w.g.refNames[ref] = name
 			message := &messageDef{name: name, doc: strings.TrimSpace(resolved.Description)}
 			w.g.messages = append(w.g.messages, message)
@@ -642,98 +744,7 @@ func (g *generator) find(name string) *messageDef {
 // their service, so that a method is unique across the whole app and a call can
 // be routed by its name alone.
 func (g *generator) reserve(name string) string {
-	unique := g.uniqueName(name)
-	g.usedNames[unique] = true
-	return unique
-}
-
-func (g *generator) uniqueName(name string) string {
-	if !g.usedNames[name] {
-		return name
-	}
-	for i := 2; ; i++ {
-		candidate := name + strconv.Itoa(i)
-		if !g.usedNames[candidate] {
-			return candidate
-		}
-	}
-}
-
-// protoIdentifier turns a name the server chose - a tool called `get_weather`,
-// `search.web` or `Créer` - into a PascalCase proto identifier. A name with
-// nothing usable in it falls back to the given noun so the surface still
-// compiles.
-func protoIdentifier(name, fallback string) string {
-	identifier := pascal(name)
-	if identifier == "" {
-		return fallback
-	}
-	if r := rune(identifier[0]); r >= '0' && r <= '9' {
-		return fallback + identifier
-	}
-	return identifier
-}
-
-// pascal splits a name on anything that isn't a letter or a digit, and on the
-// case boundaries inside each word, then joins the parts capitalized.
-func pascal(name string) string {
-	var out strings.Builder
-	for _, word := range splitWords(name) {
-		runes := []rune(word)
-		out.WriteRune(unicode.ToUpper(runes[0]))
-		out.WriteString(string(runes[1:]))
-	}
-	return out.String()
-}
-
-// protoFieldName is the snake_case field name for a JSON property. The property
-// travels under its own name regardless, through the field's json_name.
-func protoFieldName(name string) string {
-	words := splitWords(name)
-	if len(words) == 0 {
-		return "value"
-	}
-	lowered := make([]string, 0, len(words))
-	for _, word := range words {
-		lowered = append(lowered, strings.ToLower(word))
-	}
-	joined := strings.Join(lowered, "_")
-	if r := rune(joined[0]); r >= '0' && r <= '9' {
-		return "f_" + joined
-	}
-	return joined
-}
-
-func splitWords(name string) []string {
-	var words []string
-	var current []rune
-	flush := func() {
-		if len(current) > 0 {
-			words = append(words, string(current))
-			current = nil
-		}
-	}
-	runes := []rune(name)
-	upper := func(i int) bool { return i >= 0 && i < len(runes) && runes[i] >= 'A' && runes[i] <= 'Z' }
-	lower := func(i int) bool { return i >= 0 && i < len(runes) && runes[i] >= 'a' && runes[i] <= 'z' }
-	for i, r := range runes {
-		switch {
-		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
-			current = append(current, r)
-		case r >= 'A' && r <= 'Z':
-			// A capital starts a word, except inside a run of them - and the last
-			// capital of a run belongs to the word that follows it, which is what
-			// splits "HTTPUrl" into HTTP and Url.
-			if !upper(i-1) || lower(i+1) {
-				flush()
-			}
-			current = append(current, r)
-		default:
-			flush()
-		}
-	}
-	flush()
-	return words
+	return protogen.UniqueName(g.usedNames, name)
 }
 
 func (g *generator) render(surface *Surface) string {
@@ -745,6 +756,9 @@ func (g *generator) render(surface *Surface) string {
 	out.WriteString("\n")
 	writeComment(&out, surfaceDoc(surface), "")
 	out.WriteString(staticMessages)
+	if g.hasToolStreams() {
+		out.WriteString(updateMessages)
+	}
 
 	for _, message := range g.messages {
 		out.WriteString("\n")
@@ -767,13 +781,26 @@ func (g *generator) render(surface *Surface) string {
 				out.WriteString("\n")
 			}
 			writeComment(&out, rpc.doc, "  ")
-			out.WriteString("  rpc " + rpc.name + "(" + rpc.input + ") returns (" + rpc.output + ");\n")
+			output := rpc.output
+			if rpc.serverStreaming {
+				output = "stream " + output
+			}
+			out.WriteString("  rpc " + rpc.name + "(" + rpc.input + ") returns (" + output + ");\n")
 		}
 		out.WriteString("}\n")
 	}
 	return out.String()
 }
 
+func (g *generator) hasToolStreams() bool {
+	for _, bound := range g.bindings {
+		if bound.stream && bound.kind == "tool" {
+			return true
+		}
+	}
+	return false
+}
+
 func fieldTypeText(field fieldDef) string {
 	switch {
 	case field.mapKey != "":
@@ -850,3 +877,25 @@ message ResourceContents {
   string blob = 4 [json_name = "blob"];
 }
 `
+
+// updateMessages are what a tool's streaming variant sends ahead of its result:
+// the notifications the server sends about the call.
+const updateMessages = `
+// How far along the tool is, as it reported it. total is zero when the tool
+// doesn't know.
+message Progress {
+  double progress = 1 [json_name = "progress"];
+  double total = 2 [json_name = "total"];
+  string message = 3 [json_name = "message"];
+}
+
+// A message the server logged while the tool ran.
+message LogMessage {
+  // debug, info, notice, warning, error, critical, alert or emergency.
+  string level = 1 [json_name = "level"];
+  // The part of the server that logged it, when it said.
+  string logger = 2 [json_name = "logger"];
+  // What it logged: the text, or the JSON of anything else.
+  string data = 3 [json_name = "data"];
+}
+`
@@ -267,6 +267,46 @@ func TestOnlyExposedKindsBecomeServices(t *testing.T) {
 	}
 }
 
+// Subscribe and Complete are offered where the server says it has them.
+func TestSubscribeAndCompleteFollowCapabilities(t *testing.T) {
+	surface := &Surface{
+		Resources:         []Resource{{URI: "file:///a"}},
+		ResourceTemplates: []ResourceTemplate{{URITemplate: "file:///{path}"}},
+		Prompts:           []Prompt{{Name: "review", Arguments: []PromptArgument{{Name: "language"}}}, {Name: "greet"}},
+	}
+	plain, err := generateProto(surface)
+	if err != nil {
+		t.Fatalf("generateProto: %v", err)
+	}
+	for _, unwanted := range []string{"mcp.Resources/Subscribe", "mcp.Completions/Complete"} {
+		if _, ok := plain.bindings[unwanted]; ok {
+			t.Errorf("%s generated for a server that didn't declare it", unwanted)
+		}
+	}
+
+	surface.Capabilities = Capabilities{Resources: &ResourcesCapability{Subscribe: true}, Completions: &struct{}{}}
+	gen, err := generateProto(surface)
+	if err != nil {
+		t.Fatalf("generateProto: %v", err)
+	}
+	if bound := gen.bindings["mcp.Resources/Subscribe"]; bound == nil || !bound.stream || bound.kind != "subscribe" {
+		t.Errorf("Subscribe binding = %+v", bound)
+	}
+	if bound := gen.bindings["mcp.Completions/Complete"]; bound == nil || bound.method != "completion/complete" {
+		t.Errorf("Complete binding = %+v", bound)
+	}
+	requireLines(t, gen.proto,
+		"rpc Subscribe(SubscribeRequest) returns (stream ResourceUpdate);",
+		"rpc Complete(CompleteRequest) returns (CompleteResponse);",
+		"// One of: review\n",
+		"// One of: file:///{path}",
+		`map<string, string> context = 5 [json_name = "context"];`,
+	)
+	if strings.Contains(gen.proto, "message Progress") {
+		t.Error("a subscription alone sends no tool progress")
+	}
+}
+
 // The server's own hints about a tool are shown, and named as its word.
 func TestAnnotationsReachTheComment(t *testing.T) {
 	yes := true
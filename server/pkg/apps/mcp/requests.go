@@ -0,0 +1,240 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/apps/openai"
+)
+
+// samplingPrefix marks the parameters of the openai app an mcp app answers
+// sampling requests with. kaja copies them in when the app is opened, so the
+// server's completions are made with that app's endpoint and token.
+const samplingPrefix = "sampling."
+
+// maxInputRounds bounds how many times a modern call is sent again with answers
+// to what the server asked for, so a server that never stops asking can't hold
+// the call forever.
+const maxInputRounds = 8
+
+// Root is one folder the server may work in, as the roots capability lists it.
+type Root struct {
+	URI  string `json:"uri"`
+	Name string `json:"name,omitempty"`
+}
+
+// asker puts an elicitation to the person running the call and returns their
+// reply: the {action, content} result the server gets back.
+type asker func(message string, schema json.RawMessage) (json.RawMessage, error)
+
+// requests answers what a server asks of kaja mid-call: the roots it may work
+// in, a completion from a model, a question only the user can answer.
+type requests struct {
+	roots []Root
+	// sampling is the openai app's parameters, nil when none was configured; model
+	// is the model to ask, or empty to take the server's first preference.
+	sampling map[string]string
+	model    string
+}
+
+// readRequests reads what an app's parameters configure for the server's
+// requests: root_folders, a name=path line per folder kaja resolved when the app
+// was opened, and the sampling app's parameters under samplingPrefix.
+func readRequests(parameters map[string]string) *requests {
+	r := &requests{model: strings.TrimSpace(parameters["sampling_model"])}
+	for _, entry := range strings.Split(parameters["root_folders"], "\n") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		name, path, ok := strings.Cut(entry, "=")
+		if !ok {
+			name, path = filepath.Base(entry), entry
+		}
+		r.roots = append(r.roots, Root{URI: fileURI(path), Name: strings.TrimSpace(name)})
+	}
+	for key, value := range parameters {
+		if name, ok := strings.CutPrefix(key, samplingPrefix); ok {
+			if r.sampling == nil {
+				r.sampling = map[string]string{}
+			}
+			r.sampling[name] = value
+		}
+	}
+	return r
+}
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(strings.TrimSpace(path))}).String()
+}
+
+// capabilities is what the client declares it answers. Sampling is only
+// declared with an app to sample from; elicitation always is, and is declined
+// where there is nobody to ask.
+func (r *requests) capabilities() map[string]any {
+	capabilities := map[string]any{
+		"roots":       map[string]any{"listChanged": false},
+		"elicitation": map[string]any{},
+	}
+	if r != nil && r.sampling != nil {
+		capabilities["sampling"] = map[string]any{}
+	}
+	return capabilities
+}
+
+// answer handles one request from the server. ask is the call's, nil when the
+// request came while nobody was calling.
+func (r *requests) answer(method string, params json.RawMessage, ask asker) (any, *jsonRPCError) {
+	switch method {
+	case "ping":
+		return map[string]any{}, nil
+	case "roots/list":
+		roots := []Root{}
+		if r != nil {
+			roots = append(roots, r.roots...)
+		}
+		return map[string]any{"roots": roots}, nil
+	case "elicitation/create":
+		var request struct {
+			Message         string          `json:"message"`
+			RequestedSchema json.RawMessage `json:"requestedSchema"`
+		}
+		if err := json.Unmarshal(params, &request); err != nil {
+			return nil, &jsonRPCError{Code: -32602, Message: "Invalid params: " + err.Error()}
+		}
+		if ask == nil {
+			return map[string]any{"action": "decline"}, nil
+		}
+		reply, err := ask(request.Message, request.RequestedSchema)
+		if err != nil {
+			return nil, &jsonRPCError{Code: -32603, Message: err.Error()}
+		}
+		return reply, nil
+	case "sampling/createMessage":
+		if r == nil || r.sampling == nil {
+			break
+		}
+		result, err := r.sample(params)
+		if err != nil {
+			return nil, &jsonRPCError{Code: -32603, Message: err.Error()}
+		}
+		return result, nil
+	}
+	return nil, &jsonRPCError{Code: -32601, Message: "Method not found"}
+}
+
+// sample asks the sampling app for the completion a sampling/createMessage
+// request describes, and shapes the reply the way the server expects it.
+func (r *requests) sample(params json.RawMessage) (map[string]any, error) {
+	var request struct {
+		Messages []struct {
+			Role    string          `json:"role"`
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+		SystemPrompt     string   `json:"systemPrompt"`
+		MaxTokens        int      `json:"maxTokens"`
+		Temperature      *float64 `json:"temperature"`
+		StopSequences    []string `json:"stopSequences"`
+		ModelPreferences struct {
+			Hints []struct {
+				Name string `json:"name"`
+			} `json:"hints"`
+		} `json:"modelPreferences"`
+	}
+	if err := json.Unmarshal(params, &request); err != nil {
+		return nil, fmt.Errorf("reading the sampling request: %w", err)
+	}
+
+	model := r.model
+	for _, hint := range request.ModelPreferences.Hints {
+		if model != "" {
+			break
+		}
+		model = hint.Name
+	}
+	if model == "" {
+		return nil, fmt.Errorf("no model to sample from: set sampling_model on the app")
+	}
+
+	var messages []map[string]any
+	if request.SystemPrompt != "" {
+		messages = append(messages, map[string]any{"role": "system", "content": request.SystemPrompt})
+	}
+	for _, message := range request.Messages {
+		text, err := contentText(message.Content)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, map[string]any{"role": message.Role, "content": text})
+	}
+	body := map[string]any{"model": model, "messages": messages}
+	if request.MaxTokens > 0 {
+		body["max_tokens"] = request.MaxTokens
+	}
+	if request.Temperature != nil {
+		body["temperature"] = *request.Temperature
+	}
+	if len(request.StopSequences) > 0 {
+		body["stop"] = request.StopSequences
+	}
+
+	reply, err := openai.Chat(r.sampling, body)
+	if err != nil {
+		return nil, err
+	}
+	var completion struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(reply, &completion); err != nil || len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("the sampling app returned no completion")
+	}
+	choice := completion.Choices[0]
+	if completion.Model != "" {
+		model = completion.Model
+	}
+	result := map[string]any{
+		"role":    "assistant",
+		"content": map[string]any{"type": "text", "text": choice.Message.Content},
+		"model":   model,
+	}
+	switch choice.FinishReason {
+	case "stop":
+		result["stopReason"] = "endTurn"
+	case "length":
+		result["stopReason"] = "maxTokens"
+	}
+	return result, nil
+}
+
+// contentText reads the text of a sampling message, whose content is one
+// content block or a list of them. Only text can be passed on.
+func contentText(raw json.RawMessage) (string, error) {
+	type block struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	var blocks []block
+	if json.Unmarshal(raw, &blocks) != nil {
+		var one block
+		if err := json.Unmarshal(raw, &one); err != nil {
+			return "", fmt.Errorf("reading the sampling request: %w", err)
+		}
+		blocks = []block{one}
+	}
+	var texts []string
+	for _, b := range blocks {
+		if b.Type != "text" {
+			return "", fmt.Errorf("the sampling request has %s content, and only text can be sampled", b.Type)
+		}
+		texts = append(texts, b.Text)
+	}
+	return strings.Join(texts, "\n"), nil
+}
@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadRequests(t *testing.T) {
+	r := readRequests(map[string]string{
+		"root_folders":     "docs=/srv/docs\n\n/srv/notes\n",
+		"sampling.token":   "sk-test",
+		"sampling_model":   "gpt-test",
+		"sampling_app":     "assistant",
+		"sampling.retry_x": "1",
+	})
+	if len(r.roots) != 2 || r.roots[0] != (Root{URI: "file:///srv/docs", Name: "docs"}) || r.roots[1].Name != "notes" {
+		t.Errorf("roots = %+v", r.roots)
+	}
+	if r.sampling["token"] != "sk-test" || len(r.sampling) != 2 || r.model != "gpt-test" {
+		t.Errorf("sampling = %v, model = %q", r.sampling, r.model)
+	}
+	if _, ok := r.capabilities()["sampling"]; !ok {
+		t.Error("expected sampling declared with an app to sample from")
+	}
+	if _, ok := readRequests(nil).capabilities()["sampling"]; ok {
+		t.Error("sampling declared with no app to sample from")
+	}
+}
+
+func TestAnswerWithoutAnyoneToAsk(t *testing.T) {
+	var r *requests
+	reply, rpcErr := r.answer("elicitation/create", json.RawMessage(`{"message":"Sure?"}`), nil)
+	if rpcErr != nil || !strings.Contains(fmt.Sprint(reply), "decline") {
+		t.Errorf("reply = %v, %v, want the question declined", reply, rpcErr)
+	}
+	if _, rpcErr := r.answer("sampling/createMessage", json.RawMessage(`{}`), nil); rpcErr == nil || rpcErr.Code != -32601 {
+		t.Errorf("err = %v, want sampling refused with no app for it", rpcErr)
+	}
+}
+
+// Sampling is completed by the configured openai app, and the reply shaped back
+// into what the server expects.
+func TestSampling(t *testing.T) {
+	var asked map[string]any
+	openai := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, `{"error":{"message":"bad key"}}`, http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&asked)
+		fmt.Fprint(w, `{"model":"gpt-test-0613","choices":[{"message":{"role":"assistant","content":"Paris"},"finish_reason":"stop"}]}`)
+	}))
+	defer openai.Close()
+
+	r := readRequests(map[string]string{"sampling.endpoint": openai.URL, "sampling.token": "sk-test"})
+	reply, rpcErr := r.answer("sampling/createMessage", json.RawMessage(`{
+		"messages": [{"role": "user", "content": {"type": "text", "text": "Capital of France?"}}],
+		"systemPrompt": "Answer in one word.",
+		"maxTokens": 10,
+		"modelPreferences": {"hints": [{"name": "gpt-test"}]}
+	}`), nil)
+	if rpcErr != nil {
+		t.Fatalf("answer: %v", rpcErr)
+	}
+	encoded, _ := json.Marshal(reply)
+	if got := string(encoded); got != `{"content":{"text":"Paris","type":"text"},"model":"gpt-test-0613","role":"assistant","stopReason":"endTurn"}` {
+		t.Errorf("reply = %s", got)
+	}
+	if asked["model"] != "gpt-test" || asked["max_tokens"] != float64(10) {
+		t.Errorf("asked = %v", asked)
+	}
+	if messages, _ := json.Marshal(asked["messages"]); !strings.Contains(string(messages), `{"content":"Answer in one word.","role":"system"}`) {
+		t.Errorf("messages = %s", messages)
+	}
+
+	r.sampling["token"] = "wrong"
+	if _, rpcErr := r.answer("sampling/createMessage", json.RawMessage(`{"messages":[],"modelPreferences":{"hints":[{"name":"gpt-test"}]}}`), nil); rpcErr == nil || !strings.Contains(rpcErr.Message, "401") {
+		t.Errorf("err = %v, want the sampling app's failure passed on", rpcErr)
+	}
+}
+
+// A legacy server asks on the response stream, and is answered in a POST of the
+// client's own before it sends the response it was holding back.
+func TestLegacyServerRequestOnTheStream(t *testing.T) {
+	replies := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		json.Unmarshal(body, &message)
+		switch {
+		case message.Method == "" && string(message.ID) == `"ask-1"`:
+			if r.Header.Get("Mcp-Session-Id") != "sess-7" {
+				http.Error(w, "no session", http.StatusBadRequest)
+				return
+			}
+			replies <- string(body)
+			w.WriteHeader(http.StatusAccepted)
+		case message.Method == "server/discover":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32601,"message":"Method not found"}}`, message.ID)
+		case message.Method == "initialize":
+			w.Header().Set("Mcp-Session-Id", "sess-7")
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"2025-11-25","capabilities":{"tools":{}}}}`, message.ID)
+		case len(message.ID) == 0:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/message\",\"params\":{}}\n\n")
+			fmt.Fprint(w, "data: {\"jsonrpc\":\"2.0\",\"id\":\"ask-1\",\"method\":\"roots/list\"}\n\n")
+			w.(http.Flusher).Flush()
+			reply := <-replies
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"reply\":%s}}\n\n", message.ID, reply)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil, http.DefaultClient).WithRequests(readRequests(map[string]string{"root_folders": "docs=/srv/docs"}))
+	result, _, err := client.Call("tools/call", map[string]any{"name": "list_roots"}, nil)
+	if err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if !strings.Contains(string(result), `"roots":[{"uri":"file:///srv/docs","name":"docs"}]`) {
+		t.Errorf("result = %s, want the roots the server was answered with", result)
+	}
+}
@@ -0,0 +1,433 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wham/kaja/v2/internal/workspace"
+)
+
+// idleTimeout is how long a local server is left running with nothing asked of
+// it. It is stopped after that and started again by the next call, so an app
+// recompiled a dozen times doesn't leave a dozen servers behind.
+const idleTimeout = 5 * time.Minute
+
+// stderrLimit is how much of what a local server wrote to stderr is kept, for
+// saying why it exited.
+const stderrLimit = 4000
+
+// Command is how a local MCP server is launched: the program, its arguments,
+// what is added to kaja's environment for it, and the directory it runs in.
+type Command struct {
+	Path string
+	Args []string
+	Env  []string
+	Dir  string
+}
+
+// LocalCommand reads an mcp app's launch parameters: command, args and env
+// (NAME=value entries), each a JSON array of strings used as they are, and
+// working_dir, which is workspace-relative like proto_dir and defaults to the
+// workspace itself. ok is false for an app that names no command.
+func LocalCommand(parameters map[string]string) (Command, bool, error) {
+	path := strings.TrimSpace(parameters["command"])
+	if path == "" {
+		return Command{}, false, nil
+	}
+	command := Command{Path: path}
+	var err error
+	if command.Args, err = list(parameters, "args"); err != nil {
+		return Command{}, true, err
+	}
+	if command.Env, err = list(parameters, "env"); err != nil {
+		return Command{}, true, err
+	}
+	if dir := strings.TrimSpace(parameters["working_dir"]); dir != "" {
+		command.Dir = workspace.Resolve(dir)
+	} else if root := workspace.Resolve("."); isDir(root) {
+		command.Dir = root
+	}
+	return command, true, nil
+}
+
+func list(parameters map[string]string, key string) ([]string, error) {
+	value := strings.TrimSpace(parameters[key])
+	if value == "" {
+		return nil, nil
+	}
+	var items []string
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return nil, fmt.Errorf("%s is not a list of strings: %w", key, err)
+	}
+	return items, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// Validate rejects an env entry that isn't NAME=value.
+func (c Command) Validate() error {
+	for _, entry := range c.Env {
+		if name, _, ok := strings.Cut(entry, "="); !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("env entry %q is not NAME=value", entry)
+		}
+	}
+	return nil
+}
+
+func (c Command) String() string {
+	return strings.Join(append([]string{c.Path}, c.Args...), " ")
+}
+
+// stdioServer is a local MCP server spoken to over its stdin and stdout: one
+// JSON-RPC message per line each way. It is started by the first call, and
+// again by the first call after it exited - crashed, or stopped for being idle.
+type stdioServer struct {
+	command Command
+	// timeout bounds one exchange.
+	timeout time.Duration
+	// respond answers a request the server makes of kaja, and notify hears the
+	// notifications it sends.
+	respond responder
+	notify  notifier
+
+	mu      sync.Mutex
+	process *stdioProcess
+	// started counts the processes run, so a client can tell the server it
+	// settled an era with is gone.
+	started int
+}
+
+// responder answers one request a server makes of the client.
+type responder func(method string, params json.RawMessage) (any, *jsonRPCError)
+
+func newStdioServer(command Command, timeout time.Duration, respond responder, notify notifier) *stdioServer {
+	return &stdioServer{command: command, timeout: timeout, respond: respond, notify: notify}
+}
+
+// generation is the process calls are currently going to, or the one the next
+// call will start.
+func (s *stdioServer) generation() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.process == nil || s.process.exited() {
+		return s.started + 1
+	}
+	return s.started
+}
+
+// running returns the live process, starting one if there is none.
+func (s *stdioServer) running() (*stdioProcess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.process != nil && !s.process.exited() {
+		return s.process, nil
+	}
+	process, err := startProcess(s.command, s.respond, s.notify)
+	if err != nil {
+		return nil, err
+	}
+	s.process = process
+	s.started++
+	return process, nil
+}
+
+// call sends one encoded message and, unless it is a notification, waits for
+// the response with the same id.
+func (s *stdioServer) call(id int64, body []byte, notification bool) (json.RawMessage, error) {
+	process, err := s.running()
+	if err != nil {
+		return nil, err
+	}
+	return process.call(id, body, notification, s.timeout)
+}
+
+// hold starts the server if it isn't running and keeps it running, idle or
+// not: wait returns once ctx is done, or with why the server exited if it does
+// first.
+func (s *stdioServer) hold(ctx context.Context) (wait func() error, err error) {
+	process, err := s.running()
+	if err != nil {
+		return nil, err
+	}
+	process.mu.Lock()
+	process.holds++
+	process.mu.Unlock()
+	return func() error {
+		defer func() {
+			process.mu.Lock()
+			process.holds--
+			process.mu.Unlock()
+		}()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-process.done:
+			return process.exitErr()
+		}
+	}, nil
+}
+
+// Close stops the server, if it is running.
+func (s *stdioServer) Close() {
+	s.mu.Lock()
+	process := s.process
+	s.process = nil
+	s.mu.Unlock()
+	if process != nil {
+		process.stop()
+	}
+}
+
+// stdioProcess is one run of a local server.
+type stdioProcess struct {
+	command *exec.Cmd
+	stdin   io.WriteCloser
+	stderr  *tail
+	respond responder
+	notify  notifier
+	// writes is held while a message is written, so two never interleave.
+	writes sync.Mutex
+
+	mu      sync.Mutex
+	pending map[int64]chan json.RawMessage
+	idle    *time.Timer
+	// holds counts who is listening to the process, which keeps it from being
+	// stopped for being idle.
+	holds int
+	done  chan struct{}
+	// err is why the process exited, once it has.
+	err error
+}
+
+func startProcess(command Command, respond responder, notify notifier) (*stdioProcess, error) {
+	cmd := exec.Command(command.Path, command.Args...)
+	cmd.Dir = command.Dir
+	cmd.Env = append(os.Environ(), command.Env...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &tail{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting %s: %w", command.Path, err)
+	}
+
+	process := &stdioProcess{
+		command: cmd,
+		stdin:   stdin,
+		stderr:  stderr,
+		respond: respond,
+		notify:  notify,
+		pending: map[int64]chan json.RawMessage{},
+		done:    make(chan struct{}),
+	}
+	process.idle = time.AfterFunc(idleTimeout, process.stopIdle)
+	go process.read(stdout)
+	return process, nil
+}
+
+// read takes the server's messages off its stdout until it closes: responses
+// go to the call waiting on their id, a request the server makes of kaja is
+// answered in place, and a notification is passed to notify.
+func (p *stdioProcess) read(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 32<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if json.Unmarshal(line, &message) != nil {
+			// A server that logs to stdout by mistake; there is no message to
+			// route.
+			continue
+		}
+		if message.Method != "" {
+			if len(message.ID) > 0 {
+				// Answering may wait on the user, and the responses meanwhile
+				// still have to reach their calls.
+				go p.answer(message.ID, message.Method, message.Params)
+			} else if p.notify != nil {
+				p.notify(message.Method, message.Params)
+			}
+			continue
+		}
+		var id int64
+		if json.Unmarshal(message.ID, &id) != nil {
+			continue
+		}
+		p.mu.Lock()
+		waiting := p.pending[id]
+		delete(p.pending, id)
+		p.mu.Unlock()
+		if waiting != nil {
+			waiting <- append(json.RawMessage(nil), line...)
+		}
+	}
+
+	err := p.command.Wait()
+	p.mu.Lock()
+	p.err = exitError(err, p.stderr.String())
+	p.mu.Unlock()
+	close(p.done)
+}
+
+// answer replies to a request the server made of kaja.
+func (p *stdioProcess) answer(id json.RawMessage, method string, params json.RawMessage) {
+	reply := map[string]any{"jsonrpc": "2.0", "id": id}
+	result, rpcErr := p.respond(method, params)
+	if rpcErr != nil {
+		reply["error"] = rpcErr
+	} else {
+		reply["result"] = result
+	}
+	body, _ := json.Marshal(reply)
+	p.write(body)
+}
+
+// stopIdle stops a process nothing has been asked of for a while, unless
+// someone is listening to it.
+func (p *stdioProcess) stopIdle() {
+	p.mu.Lock()
+	held := p.holds > 0
+	p.mu.Unlock()
+	if held {
+		p.idle.Reset(idleTimeout)
+		return
+	}
+	p.stop()
+}
+
+func (p *stdioProcess) write(body []byte) error {
+	p.writes.Lock()
+	defer p.writes.Unlock()
+	_, err := p.stdin.Write(append(body, '\n'))
+	return err
+}
+
+func (p *stdioProcess) call(id int64, body []byte, notification bool, timeout time.Duration) (json.RawMessage, error) {
+	p.idle.Reset(idleTimeout)
+
+	var response chan json.RawMessage
+	if !notification {
+		response = make(chan json.RawMessage, 1)
+		p.mu.Lock()
+		p.pending[id] = response
+		p.mu.Unlock()
+		defer func() {
+			p.mu.Lock()
+			delete(p.pending, id)
+			p.mu.Unlock()
+		}()
+	}
+
+	if err := p.write(body); err != nil {
+		select {
+		case <-p.done:
+			return nil, p.exitErr()
+		case <-time.After(time.Second):
+			return nil, fmt.Errorf("writing to the server: %w", err)
+		}
+	}
+	if notification {
+		return nil, nil
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case payload := <-response:
+		return payload, nil
+	case <-p.done:
+		return nil, p.exitErr()
+	case <-timer.C:
+		return nil, fmt.Errorf("the server didn't answer within %s", timeout)
+	}
+}
+
+func (p *stdioProcess) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *stdioProcess) exitErr() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// stop ends the process: its stdin is closed, which is how a stdio server is
+// told to exit, and it is killed if it hasn't after a grace period.
+func (p *stdioProcess) stop() {
+	p.idle.Stop()
+	p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(2 * time.Second):
+		p.command.Process.Kill()
+		<-p.done
+	}
+}
+
+// ErrServerExited is what a call gets when the local server exited under it.
+var ErrServerExited = errors.New("the server exited")
+
+func exitError(err error, stderr string) error {
+	reason := ""
+	if err != nil {
+		reason = " (" + err.Error() + ")"
+	}
+	if stderr = strings.TrimSpace(stderr); stderr != "" {
+		reason += ": " + stderr
+	}
+	return fmt.Errorf("%w%s", ErrServerExited, reason)
+}
+
+// tail keeps the last stderrLimit bytes written to it.
+type tail struct {
+	mu     sync.Mutex
+	buffer []byte
+}
+
+func (t *tail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buffer = append(t.buffer, p...)
+	if over := len(t.buffer) - stderrLimit; over > 0 {
+		t.buffer = t.buffer[over:]
+	}
+	return len(p), nil
+}
+
+func (t *tail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buffer)
+}
@@ -0,0 +1,277 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The test binary doubles as a local MCP server: run with helperEnv set, it
+// speaks the handshake era over stdin and stdout instead of running the tests.
+const helperEnv = "KAJA_MCP_HELPER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnv) == "1" {
+		serveHelper()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+const helperTools = `{"tools":[` +
+	`{"name":"whoami","description":"Says which process answered","inputSchema":{"type":"object","properties":{}}},` +
+	`{"name":"crash","description":"Exits mid-call","inputSchema":{"type":"object","properties":{}}},` +
+	`{"name":"roots","description":"Asks the client for its roots","inputSchema":{"type":"object","properties":{}}}]}`
+
+func serveHelper() {
+	scanner := bufio.NewScanner(os.Stdin)
+	// asking is the id of the call waiting on the client's roots.
+	var asking json.RawMessage
+	for scanner.Scan() {
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Name string `json:"name"`
+				URI  string `json:"uri"`
+				Meta struct {
+					ProgressToken json.RawMessage `json:"progressToken"`
+				} `json:"_meta"`
+			} `json:"params"`
+			Result json.RawMessage `json:"result"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &message) != nil || len(message.ID) == 0 || os.Getenv("HELPER_SILENT") == "1" {
+			continue
+		}
+		if message.Method == "" {
+			// The client's answer to the roots request.
+			text, _ := json.Marshal(string(message.Result))
+			fmt.Printf(`{"jsonrpc":"2.0","id":%s,"result":{"content":[{"type":"text","text":%s}]}}`+"\n", asking, text)
+			continue
+		}
+		var result string
+		switch message.Method {
+		case "initialize":
+			result = `{"protocolVersion":"2025-06-18","capabilities":{"tools":{},"resources":{"subscribe":true}},"serverInfo":{"name":"helper","version":"1.0.0"}}`
+		case "tools/list":
+			result = helperTools
+		case "resources/list":
+			result = `{"resources":[{"uri":"file:///mirror","name":"mirror"}]}`
+		case "resources/subscribe":
+			// The update comes on stdout outside of any call, once the
+			// subscription has been answered.
+			fmt.Printf(`{"jsonrpc":"2.0","id":%s,"result":{}}`+"\n", message.ID)
+			fmt.Printf(`{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":%q}}`+"\n", message.Params.URI)
+			continue
+		case "resources/unsubscribe":
+			result = `{}`
+		case "tools/call":
+			if message.Params.Name == "roots" {
+				asking = message.ID
+				fmt.Println(`{"jsonrpc":"2.0","id":"helper-1","method":"roots/list"}`)
+				continue
+			}
+			if message.Params.Name == "crash" {
+				fmt.Fprintln(os.Stderr, "helper: asked to crash")
+				os.Exit(3)
+			}
+			if token := message.Params.Meta.ProgressToken; len(token) > 0 {
+				fmt.Printf(`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":%s,"progress":1,"total":1}}`+"\n", token)
+				fmt.Println(`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info","data":"looked in the mirror"}}`)
+			}
+			text, _ := json.Marshal(fmt.Sprintf("pid %d, greeted by %s, last argument %s", os.Getpid(), os.Getenv("HELPER_GREETING"), os.Args[len(os.Args)-1]))
+			result = fmt.Sprintf(`{"content":[{"type":"text","text":%s}]}`, text)
+		default:
+			fmt.Printf(`{"jsonrpc":"2.0","id":%s,"error":{"code":-32601,"message":"Method not found"}}`+"\n", message.ID)
+			continue
+		}
+		// Something logged to stdout by mistake is stepped over.
+		fmt.Println("helper is answering")
+		fmt.Printf(`{"jsonrpc":"2.0","id":%s,"result":%s}`+"\n", message.ID, result)
+	}
+}
+
+func helperParameters(t *testing.T) map[string]string {
+	t.Helper()
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]string{
+		"command":     executable,
+		"args":        `["-test.run=^$", "--filter=a,b c"]`,
+		"env":         `["` + helperEnv + `=1", "HELPER_GREETING=kaja, twice"]`,
+		"working_dir": t.TempDir(),
+	}
+}
+
+func openHelper(t *testing.T) *instance {
+	t.Helper()
+	opened, err := New().Open(helperParameters(t), t.TempDir(), func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	in := opened.Instance.(*instance)
+	t.Cleanup(in.client.Close)
+	return in
+}
+
+func TestStdioServer(t *testing.T) {
+	in := openHelper(t)
+	bound, ok := in.methods["mcp.Tools/Whoami"]
+	if !ok {
+		t.Fatalf("expected mcp.Tools/Whoami, got %v", methodPaths(in))
+	}
+
+	result, err := in.Invoke("mcp.Tools/Whoami", encodeRequest(t, bound, `{}`), nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	answer := decodeResponseJSON(t, bound, result.Body)
+	if !strings.Contains(answer, "greeted by kaja, twice, last argument --filter=a,b c") {
+		t.Errorf("response = %s, want the args and env passed to the server as they are", answer)
+	}
+	if result.RequestHeaders != nil || result.ResponseHeaders != nil {
+		t.Error("a stdio exchange has no headers to show")
+	}
+}
+
+func TestStdioServerRestartsAfterACrash(t *testing.T) {
+	in := openHelper(t)
+	whoami := in.methods["mcp.Tools/Whoami"]
+	first, err := in.Invoke("mcp.Tools/Whoami", encodeRequest(t, whoami, `{}`), nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+
+	_, err = in.Invoke("mcp.Tools/Crash", encodeRequest(t, in.methods["mcp.Tools/Crash"], `{}`), nil)
+	if !errors.Is(err, ErrServerExited) || !strings.Contains(err.Error(), "asked to crash") {
+		t.Fatalf("err = %v, want the exit with what the server wrote to stderr", err)
+	}
+
+	// The next call starts the server again, and settles the handshake afresh.
+	second, err := in.Invoke("mcp.Tools/Whoami", encodeRequest(t, whoami, `{}`), nil)
+	if err != nil {
+		t.Fatalf("Invoke after the crash: %v", err)
+	}
+	if decodeResponseJSON(t, whoami, first.Body) == decodeResponseJSON(t, whoami, second.Body) {
+		t.Error("expected a new process to answer after the crash")
+	}
+}
+
+// A local server asks on its own stdout, and is answered on its stdin.
+func TestStdioServerAsksForRoots(t *testing.T) {
+	parameters := helperParameters(t)
+	parameters["root_folders"] = "docs=/srv/docs"
+	opened, err := New().Open(parameters, t.TempDir(), func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	in := opened.Instance.(*instance)
+	t.Cleanup(in.client.Close)
+
+	bound := in.methods["mcp.Tools/Roots"]
+	result, err := in.Invoke("mcp.Tools/Roots", encodeRequest(t, bound, `{}`), nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if answer := decodeResponseJSON(t, bound, result.Body); !strings.Contains(answer, `file:///srv/docs`) {
+		t.Errorf("response = %s, want the roots the server was answered with", answer)
+	}
+}
+
+// A local server's notifications come on its stdout among the responses, and
+// reach the stream of the call they are about.
+func TestStdioServerStreamsNotifications(t *testing.T) {
+	in := openHelper(t)
+	bound := in.methods["mcp.Tools/WhoamiStream"]
+	var updates []string
+	_, err := in.InvokeStream(context.Background(), "mcp.Tools/WhoamiStream", encodeRequest(t, bound, `{}`), nil, func(message []byte) error {
+		updates = append(updates, decodeResponseJSON(t, bound, message))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InvokeStream: %v", err)
+	}
+	if len(updates) != 3 || !strings.Contains(updates[0], `"progress"`) || !strings.Contains(updates[1], "looked in the mirror") || !strings.Contains(updates[2], "greeted by kaja") {
+		t.Errorf("updates = %q, want the progress, the log message and the result", updates)
+	}
+}
+
+// A local server's updates to a subscribed resource come on its stdout between
+// calls.
+func TestStdioServerSubscription(t *testing.T) {
+	in := openHelper(t)
+	bound := in.methods["mcp.Resources/Subscribe"]
+	if bound == nil {
+		t.Fatalf("expected mcp.Resources/Subscribe, got %v", methodPaths(in))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var updates []string
+	_, err := in.InvokeStream(ctx, "mcp.Resources/Subscribe", encodeRequest(t, bound, `{"uri":"file:///mirror"}`), nil, func(message []byte) error {
+		updates = append(updates, decodeResponseJSON(t, bound, message))
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want the stream to end with the caller", err)
+	}
+	if len(updates) != 1 || !strings.Contains(updates[0], "file:///mirror") {
+		t.Errorf("updates = %q, want the resource's update", updates)
+	}
+}
+
+func TestStdioServerExchangeTimesOut(t *testing.T) {
+	parameters := helperParameters(t)
+	command, _, _ := LocalCommand(parameters)
+	command.Env = append(command.Env, "HELPER_SILENT=1")
+	client := NewStdioClient(command, 200*time.Millisecond)
+	defer client.Close()
+	if _, _, err := client.Call("tools/list", nil, nil); err == nil || !strings.Contains(err.Error(), "didn't answer") {
+		t.Fatalf("err = %v, want the exchange to time out", err)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	if _, err := connect(map[string]string{"url": "https://example.com/mcp", "command": "server"}, time.Second, nil); err == nil {
+		t.Error("connect accepted both a url and a command")
+	}
+	if _, err := connect(map[string]string{}, time.Second, nil); err == nil {
+		t.Error("connect accepted neither")
+	}
+	if _, err := connect(map[string]string{"command": "server", "env": `["TOKEN"]`}, time.Second, nil); err == nil {
+		t.Error("connect accepted an env entry that isn't NAME=value")
+	}
+}
+
+func TestLocalCommand(t *testing.T) {
+	if _, ok, _ := LocalCommand(map[string]string{"url": "https://example.com/mcp"}); ok {
+		t.Error("an app with no command is no local server")
+	}
+	command, ok, err := LocalCommand(map[string]string{"command": " npx ", "args": `["-y", "@acme/server"]`, "env": `["A=1", "B=x=y"]`, "working_dir": "servers"})
+	if !ok || err != nil {
+		t.Fatalf("expected a local command, got %v", err)
+	}
+	if command.Path != "npx" || strings.Join(command.Args, "|") != "-y|@acme/server" {
+		t.Errorf("command = %+v", command)
+	}
+	if err := command.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if !strings.HasSuffix(command.Dir, "servers") {
+		t.Errorf("Dir = %q, want the workspace-relative directory", command.Dir)
+	}
+	if command.String() != "npx -y @acme/server" {
+		t.Errorf("String() = %q", command.String())
+	}
+	if _, _, err := LocalCommand(map[string]string{"command": "npx", "args": "-y, @acme/server"}); err == nil {
+		t.Error("args that aren't a JSON array were read")
+	}
+}
@@ -0,0 +1,263 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// notifier hears a notification the server sends while a call is in flight.
+type notifier func(method string, params json.RawMessage)
+
+// forCall narrows a notifier to what concerns the call with the given progress
+// token: its own progress, and the log messages the server sends meanwhile.
+// Anything else - a list changing, another call's progress - is passed over.
+func (n notifier) forCall(token string) notifier {
+	return func(method string, params json.RawMessage) {
+		switch method {
+		case "notifications/progress":
+			var progress struct {
+				ProgressToken json.RawMessage `json:"progressToken"`
+			}
+			var got string
+			if json.Unmarshal(params, &progress) != nil || json.Unmarshal(progress.ProgressToken, &got) != nil || got != token {
+				return
+			}
+		case "notifications/message":
+		default:
+			return
+		}
+		n(method, params)
+	}
+}
+
+func (in *instance) Streams(methodPath string) bool {
+	method := in.lookup(methodPath)
+	return method != nil && method.binding.stream
+}
+
+// InvokeStream calls a tool and sends what the server says about the call as it
+// comes: a Progress for each progress notification, a LogMessage for each log
+// message, and the result last. An elicitation the server makes meanwhile is
+// declined; only a unary call can put a question to its caller. A resource
+// subscription streams the resource's updates instead.
+func (in *instance) InvokeStream(ctx context.Context, methodPath string, request []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error) {
+	method := in.lookup(methodPath)
+	if method == nil || !method.binding.stream {
+		return nil, fmt.Errorf("unknown streaming method %q (the app may need to be recompiled)", methodPath)
+	}
+	if method.binding.kind == "subscribe" {
+		return in.subscribe(ctx, method, request, headers, send)
+	}
+	arguments, err := decodeRequest(method, request)
+	if err != nil {
+		return nil, err
+	}
+	params, err := callParams(method.binding, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Notifications arrive on whichever goroutine reads the server, and may
+	// still arrive once the call is over; finished keeps them out of a stream
+	// that has ended, and sendErr remembers a caller who went away.
+	var mu sync.Mutex
+	var finished bool
+	var sendErr error
+	emit := func(update map[string]any) {
+		mu.Lock()
+		defer mu.Unlock()
+		if finished || sendErr != nil || ctx.Err() != nil {
+			return
+		}
+		message, err := encodeUpdate(method, update)
+		if err != nil {
+			return
+		}
+		sendErr = send(message)
+	}
+
+	result, exchange, err := in.client.CallListening(method.binding.method, params, headers, nil, func(notification string, params json.RawMessage) {
+		if update := updateOf(notification, params); update != nil {
+			emit(update)
+		}
+	})
+	mu.Lock()
+	finished = true
+	mu.Unlock()
+	if err != nil {
+		return nil, withExchange(err, exchange)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	message, err := encodeUpdate(method, map[string]any{"result": result})
+	if err != nil {
+		return nil, err
+	}
+	if err := send(message); err != nil {
+		return nil, err
+	}
+	invoked := &apps.InvokeResult{}
+	if exchange != nil {
+		invoked.RequestHeaders = exchange.RequestHeaders
+		invoked.ResponseHeaders = exchange.ResponseHeaders
+	}
+	return invoked, nil
+}
+
+// subscribe subscribes to a resource and sends a ResourceUpdate each time the
+// server says it changed, until the caller goes away or the server stops
+// talking; the subscription is then given up. The notifications come on the
+// server's own stream rather than a call's, so one that comes while an update
+// is still being read or sent is folded into the next.
+func (in *instance) subscribe(ctx context.Context, method *boundMethod, request []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error) {
+	arguments, err := decodeRequest(method, request)
+	if err != nil {
+		return nil, err
+	}
+	encoded, _ := json.Marshal(arguments)
+	var subscription struct {
+		URI  string `json:"uri"`
+		Read bool   `json:"read"`
+	}
+	if err := json.Unmarshal(encoded, &subscription); err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	if subscription.URI == "" {
+		return nil, fmt.Errorf("set the uri of the resource to subscribe to")
+	}
+	uri := map[string]any{"uri": subscription.URI}
+
+	// The client listens before it subscribes, so an update that comes right
+	// away is heard.
+	updated := make(chan string, 1)
+	listening, stop := context.WithCancel(ctx)
+	defer stop()
+	wait, err := in.client.Listen(listening, headers, func(notification string, params json.RawMessage) {
+		var changed struct {
+			URI   string `json:"uri"`
+			Title string `json:"title"`
+		}
+		if notification != "notifications/resources/updated" || json.Unmarshal(params, &changed) != nil || changed.URI != subscription.URI {
+			return
+		}
+		select {
+		case updated <- changed.Title:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	stopped := make(chan error, 1)
+	go func() { stopped <- wait() }()
+
+	_, exchange, err := in.client.Call(method.binding.method, uri, headers)
+	if err != nil {
+		return nil, withExchange(err, exchange)
+	}
+	defer in.client.Call("resources/unsubscribe", uri, headers)
+
+	invoked := &apps.InvokeResult{}
+	if exchange != nil {
+		invoked.RequestHeaders = exchange.RequestHeaders
+		invoked.ResponseHeaders = exchange.ResponseHeaders
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-stopped:
+			if err != nil {
+				return nil, err
+			}
+			return invoked, nil
+		case title := <-updated:
+			update := map[string]any{"uri": subscription.URI, "title": title}
+			if subscription.Read {
+				read, exchange, err := in.client.Call("resources/read", uri, headers)
+				if err != nil {
+					return nil, withExchange(err, exchange)
+				}
+				var contents struct {
+					Contents json.RawMessage `json:"contents"`
+				}
+				if json.Unmarshal(read, &contents) == nil && len(contents.Contents) > 0 {
+					update["contents"] = contents.Contents
+				}
+			}
+			message, err := encodeUpdate(method, update)
+			if err != nil {
+				return nil, err
+			}
+			if err := send(message); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// updateOf shapes a notification into the update message that carries it, or
+// nil for one that carries nothing to show.
+func updateOf(method string, params json.RawMessage) map[string]any {
+	switch method {
+	case "notifications/progress":
+		var progress struct {
+			Progress float64 `json:"progress"`
+			Total    float64 `json:"total"`
+			Message  string  `json:"message"`
+		}
+		if json.Unmarshal(params, &progress) != nil {
+			return nil
+		}
+		return map[string]any{"progress": progress}
+	case "notifications/message":
+		var message struct {
+			Level  string          `json:"level"`
+			Logger string          `json:"logger"`
+			Data   json.RawMessage `json:"data"`
+		}
+		if json.Unmarshal(params, &message) != nil {
+			return nil
+		}
+		return map[string]any{"log": map[string]string{"level": message.Level, "logger": message.Logger, "data": logData(message.Data)}}
+	}
+	return nil
+}
+
+// logData is a log message's data as text: a string as it is, anything else as
+// its JSON.
+func logData(raw json.RawMessage) string {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+// encodeUpdate shapes one update into the method's streamed message. The
+// result is decoded the way encodeResult decodes it, dropping what the response
+// has no field for.
+func encodeUpdate(method *boundMethod, update map[string]any) ([]byte, error) {
+	encoded, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("encoding update: %w", err)
+	}
+	message := dynamicpb.NewMessage(method.output)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(encoded, message); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return proto.Marshal(message)
+}
@@ -117,7 +117,7 @@ func listAll[T any](c *Client, method string, key string) ([]T, error) {
 		if cursor != "" {
 			params["cursor"] = cursor
 		}
-		result, _, err := c.send(method, params, nil)
+		result, _, err := c.send(method, params, nil, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,46 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
+)
+
+// Chat sends one chat completions request body on behalf of something in kaja
+// rather than a script - an mcp app answering its server's sampling request -
+// with the endpoint, token and retries of the openai app whose parameters are
+// given. It returns the response JSON; an HTTP failure is an apps.UpstreamError.
+func Chat(parameters map[string]string, body map[string]any) (json.RawMessage, error) {
+	base, err := baseURL(parameters["endpoint"])
+	if err != nil {
+		return nil, err
+	}
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	in := &instance{
+		base:   base,
+		token:  strings.TrimSpace(parameters["token"]),
+		client: &http.Client{Timeout: 120 * time.Second},
+		retry:  policy,
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	respBody, status, reqHeaders, respHeaders, err := in.call(http.MethodPost, "chat/completions", payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, apps.NewUpstreamError(http.MethodPost, in.url("chat/completions"), status, respBody).WithHeaders(reqHeaders, respHeaders)
+	}
+	return respBody, nil
+}
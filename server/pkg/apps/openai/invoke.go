@@ -2,10 +2,12 @@ package openai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"google.golang.org/protobuf/encoding/protojson"
@@ -14,53 +16,86 @@ import (
 	"google.golang.org/protobuf/types/dynamicpb"
 
 	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
 )
 
-// instance is a live opened OpenAI app. It is a gRPC app: a ChatCompletion call
-// arrives as protobuf, is transcoded into a POST against the chat completions
-// endpoint, and the JSON response is shaped back into the protobuf response.
+// instance is a live opened OpenAI app. It is a gRPC app: a call arrives as
+// protobuf, is transcoded into a request against the method's path under the
+// base URL, and the JSON response is shaped back into the protobuf response.
 type instance struct {
-	endpoint string
-	token    string
-	input    protoreflect.MessageDescriptor
-	output   protoreflect.MessageDescriptor
-	client   *http.Client
+	base    *url.URL
+	token   string
+	methods map[string]protoreflect.MethodDescriptor
+	client  *http.Client
+	retry   retry.Policy
+}
+
+// route is how a unary method reaches the API: the HTTP method and the path
+// under the base URL, how the request becomes a body (none for a GET), and what
+// convenience fields are filled in from the reply.
+type route struct {
+	method string
+	path   string
+	body   func(reqMsg *dynamicpb.Message) ([]byte, error)
+	reply  func(respMsg *dynamicpb.Message)
+}
+
+var routes = map[string]route{
+	"ChatCompletion": {
+		method: http.MethodPost,
+		path:   "chat/completions",
+		body:   func(reqMsg *dynamicpb.Message) ([]byte, error) { return buildRequestBody(reqMsg, false) },
+		reply:  func(respMsg *dynamicpb.Message) { setReplyContent(respMsg, "message") },
+	},
+	"ListModels":      {method: http.MethodGet, path: "models"},
+	"CreateEmbedding": {method: http.MethodPost, path: "embeddings", body: requireModel},
+	"CreateResponse":  {method: http.MethodPost, path: "responses", body: buildResponseBody, reply: setOutputText},
+	"CreateModeration": {
+		method: http.MethodPost,
+		path:   "moderations",
+		body:   func(reqMsg *dynamicpb.Message) ([]byte, error) { return protojson.Marshal(reqMsg) },
+	},
 }
 
 func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
-	if lastSegment(methodPath) != "ChatCompletion" {
+	name := lastSegment(methodPath)
+	method, route := in.methods[name], routes[name]
+	if method == nil || route.method == "" {
 		return nil, fmt.Errorf("unknown method %q", methodPath)
 	}
 
-	reqMsg := dynamicpb.NewMessage(in.input)
-	if len(request) > 0 {
-		if err := proto.Unmarshal(request, reqMsg); err != nil {
-			return nil, fmt.Errorf("decoding request: %w", err)
-		}
-	}
-
-	body, err := in.buildRequestBody(reqMsg)
+	reqMsg, err := decodeRequest(method, request)
 	if err != nil {
 		return nil, err
 	}
+	var body []byte
+	if route.body != nil {
+		if body, err = route.body(reqMsg); err != nil {
+			return nil, err
+		}
+	}
 
-	respBody, status, reqHeaders, respHeaders, err := in.call(body, headers)
+	respBody, status, reqHeaders, respHeaders, err := in.call(route.method, route.path, body, headers)
 	if err != nil {
 		return nil, err
 	}
 
-	respMsg := dynamicpb.NewMessage(in.output)
+	respMsg := dynamicpb.NewMessage(method.Output())
 	if status >= 400 {
 		// Surface the upstream failure as a structured error on the response
 		// rather than a flat transport error, so the status code, the OpenAI
 		// error type/code/param and the raw body are all visible in the response.
-		return in.encodeError(respMsg, parseUpstreamError(status, respBody), reqHeaders, respHeaders)
-	}
-
-	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respBody, respMsg); err != nil {
-		return nil, fmt.Errorf("decoding response JSON: %w", err)
+		if err := setError(respMsg, parseUpstreamError(status, respBody)); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respBody, respMsg); err != nil {
+			return nil, fmt.Errorf("decoding response JSON: %w", err)
+		}
+		if route.reply != nil {
+			route.reply(respMsg)
+		}
 	}
-	setReplyContent(respMsg)
 	out, err := proto.Marshal(respMsg)
 	if err != nil {
 		return nil, err
@@ -68,98 +103,163 @@ func (in *instance) Invoke(methodPath string, request []byte, headers map[string
 	return &apps.InvokeResult{Body: out, RequestHeaders: reqHeaders, ResponseHeaders: respHeaders}, nil
 }
 
-// encodeError populates the response's "error" field from the structured upstream
-// error and marshals it into an InvokeResult that still carries the upstream
-// headers exchanged.
-func (in *instance) encodeError(respMsg *dynamicpb.Message, upstream map[string]any, reqHeaders, respHeaders map[string]string) (*apps.InvokeResult, error) {
+// url is the full URL of the API path under the base.
+func (in *instance) url(path string) string {
+	u := *in.base
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + path
+	return u.String()
+}
+
+// requireModel encodes a request as it is, once it names a model.
+func requireModel(reqMsg *dynamicpb.Message) ([]byte, error) {
+	if strings.TrimSpace(reqMsg.Get(reqMsg.Descriptor().Fields().ByName("model")).String()) == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	return protojson.Marshal(reqMsg)
+}
+
+// decodeRequest decodes a call's protobuf request into the method's input.
+func decodeRequest(method protoreflect.MethodDescriptor, request []byte) (*dynamicpb.Message, error) {
+	reqMsg := dynamicpb.NewMessage(method.Input())
+	if len(request) > 0 {
+		if err := proto.Unmarshal(request, reqMsg); err != nil {
+			return nil, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	return reqMsg, nil
+}
+
+// setError populates the response's "error" field from the structured upstream
+// error.
+func setError(respMsg *dynamicpb.Message, upstream map[string]any) error {
 	payload, err := json.Marshal(map[string]any{"error": upstream})
 	if err != nil {
-		return nil, fmt.Errorf("encoding error response: %w", err)
+		return fmt.Errorf("encoding error response: %w", err)
 	}
 	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(payload, respMsg); err != nil {
-		return nil, fmt.Errorf("encoding error response: %w", err)
-	}
-	out, err := proto.Marshal(respMsg)
-	if err != nil {
-		return nil, err
+		return fmt.Errorf("encoding error response: %w", err)
 	}
-	return &apps.InvokeResult{Body: out, RequestHeaders: reqHeaders, ResponseHeaders: respHeaders}, nil
+	return nil
 }
 
 // buildRequestBody turns the decoded ChatCompletion request into the JSON body
-// the OpenAI chat completions endpoint expects: the system and user prompts are
-// folded into a messages array and the optional sampling fields are passed
-// through only when the caller set them.
-func (in *instance) buildRequestBody(reqMsg *dynamicpb.Message) ([]byte, error) {
-	fields := in.input.Fields()
-	getString := func(name string) string {
-		return reqMsg.Get(fields.ByName(protoreflect.Name(name))).String()
+// the OpenAI chat completions endpoint expects. The request's fields already
+// carry the API's names, so it is the request as JSON with the prompts folded
+// into the messages array - the system prompt ahead of the conversation, the
+// user prompt after it - and tool_choice given the shape the API wants. Unset
+// optional fields are left out, so the API's defaults apply.
+func buildRequestBody(reqMsg *dynamicpb.Message, stream bool) ([]byte, error) {
+	encoded, err := protojson.Marshal(reqMsg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	payload := map[string]any{}
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
 	}
 
-	model := strings.TrimSpace(getString("model"))
-	if model == "" {
+	model, _ := payload["model"].(string)
+	if strings.TrimSpace(model) == "" {
 		return nil, fmt.Errorf("model is required")
 	}
+	payload["model"] = strings.TrimSpace(model)
 
-	messages := []map[string]string{}
-	if system := getString("system_prompt"); system != "" {
-		messages = append(messages, map[string]string{"role": "system", "content": system})
-	}
-	messages = append(messages, map[string]string{"role": "user", "content": getString("user_prompt")})
+	system, _ := payload["system_prompt"].(string)
+	user, _ := payload["user_prompt"].(string)
+	conversation, _ := payload["messages"].([]any)
+	delete(payload, "system_prompt")
+	delete(payload, "user_prompt")
 
-	payload := map[string]any{
-		"model":    model,
-		"messages": messages,
+	messages := []any{}
+	if system != "" {
+		messages = append(messages, map[string]any{"role": "system", "content": system})
 	}
-	if fd := fields.ByName("temperature"); reqMsg.Has(fd) {
-		payload["temperature"] = reqMsg.Get(fd).Float()
+	messages = append(messages, conversation...)
+	// Without a conversation the user prompt is the whole request, so it is
+	// sent even when empty, for the API to judge.
+	if user != "" || len(conversation) == 0 {
+		messages = append(messages, map[string]any{"role": "user", "content": user})
 	}
-	if fd := fields.ByName("max_tokens"); reqMsg.Has(fd) {
-		payload["max_tokens"] = reqMsg.Get(fd).Int()
+	payload["messages"] = messages
+
+	if tools, ok := payload["tools"].([]any); ok {
+		for _, tool := range tools {
+			if tool, ok := tool.(map[string]any); ok && tool["type"] == nil {
+				tool["type"] = "function"
+			}
+		}
 	}
-	if fd := fields.ByName("top_p"); reqMsg.Has(fd) {
-		payload["top_p"] = reqMsg.Get(fd).Float()
+	if choice, ok := payload["tool_choice"].(string); ok {
+		switch choice {
+		case "auto", "none", "required":
+		default:
+			payload["tool_choice"] = map[string]any{"type": "function", "function": map[string]any{"name": choice}}
+		}
 	}
 
+	if stream {
+		payload["stream"] = true
+		// Have the last chunk carry the token usage, as a unary reply does.
+		payload["stream_options"] = map[string]any{"include_usage": true}
+	}
 	return json.Marshal(payload)
 }
 
-// call POSTs the request body to the configured endpoint, returning the raw
+// call sends the request body to the API path under the base URL, returning the raw
 // response body, HTTP status code, and the headers exchanged with the upstream.
 // An error is returned only for transport failures (the upstream could not be
 // reached); HTTP error responses are returned with their status so the caller
 // can shape them into a structured error.
-func (in *instance) call(body []byte, headers map[string]string) ([]byte, int, map[string]string, map[string]string, error) {
-	httpReq, err := http.NewRequest(http.MethodPost, in.endpoint, bytes.NewReader(body))
+func (in *instance) call(method, path string, body []byte, headers map[string]string) ([]byte, int, map[string]string, map[string]string, error) {
+	resp, reqHeaders, err := in.send(context.Background(), in.client, method, path, body, headers, "application/json")
+	if err != nil {
+		return nil, 0, reqHeaders, nil, err
+	}
+	defer resp.Body.Close()
+	respHeaders := apps.SurfaceHeaders(resp.Header)
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, resp.StatusCode, reqHeaders, respHeaders, fmt.Errorf("reading response: %w", err)
+	}
+	return respBody, resp.StatusCode, reqHeaders, respHeaders, nil
+}
+
+// send sends the request body to the API path under the base URL with client
+// and returns the response unread, along with the request headers sent. A nil
+// body sends none.
+func (in *instance) send(ctx context.Context, client *http.Client, method, path string, body []byte, headers map[string]string, accept string) (*http.Response, map[string]string, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	endpoint := in.url(path)
+	httpReq, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
 	if err != nil {
-		return nil, 0, nil, nil, fmt.Errorf("building request: %w", err)
+		return nil, nil, fmt.Errorf("building request: %w", err)
 	}
 	for k, v := range headers {
 		httpReq.Header.Set(k, v)
 	}
-	httpReq.Header.Set("Accept", "application/json")
-	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", accept)
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
 	if in.token != "" {
 		httpReq.Header.Set("Authorization", "Bearer "+in.token)
 	}
 	reqHeaders := apps.SurfaceHeaders(httpReq.Header)
 
-	resp, err := in.client.Do(httpReq)
+	resp, attempts, err := in.retry.Send(client.Do, httpReq)
+	reqHeaders = attempts.Record(reqHeaders)
 	if err != nil {
-		return nil, 0, reqHeaders, nil, fmt.Errorf("calling %s: %w", in.endpoint, err)
+		return nil, reqHeaders, fmt.Errorf("calling %s: %w", endpoint, err)
 	}
-	defer resp.Body.Close()
-	respHeaders := apps.SurfaceHeaders(resp.Header)
-
-	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
-	if err != nil {
-		return nil, resp.StatusCode, reqHeaders, respHeaders, fmt.Errorf("reading response: %w", err)
-	}
-	return respBody, resp.StatusCode, reqHeaders, respHeaders, nil
+	return resp, reqHeaders, nil
 }
 
 // parseUpstreamError turns a failed (HTTP >= 400) upstream response into the
-// structured error shape exposed on ChatCompletionResponse.error. It understands
+// structured error shape exposed on every response's error field. It understands
 // the standard OpenAI error envelope ({"error": {"message", "type", "code",
 // "param"}}) and falls back to the raw body for anything else.
 func parseUpstreamError(status int, body []byte) map[string]any {
@@ -215,9 +315,10 @@ func rawString(raw json.RawMessage) string {
 	return trimmed
 }
 
-// setReplyContent copies the first choice's message content into the top-level
-// convenience "content" field of the response.
-func setReplyContent(respMsg *dynamicpb.Message) {
+// setReplyContent copies the content of the first choice's part - its
+// "message", or a streamed chunk's "delta" - into the top-level convenience
+// "content" field of the response.
+func setReplyContent(respMsg *dynamicpb.Message, part protoreflect.Name) {
 	desc := respMsg.Descriptor()
 	choicesFd := desc.Fields().ByName("choices")
 	contentFd := desc.Fields().ByName("content")
@@ -229,12 +330,12 @@ func setReplyContent(respMsg *dynamicpb.Message) {
 		return
 	}
 	choice := choices.Get(0).Message()
-	messageFd := choicesFd.Message().Fields().ByName("message")
-	if messageFd == nil || !choice.Has(messageFd) {
+	partFd := choicesFd.Message().Fields().ByName(part)
+	if partFd == nil || !choice.Has(partFd) {
 		return
 	}
-	message := choice.Get(messageFd).Message()
-	msgContentFd := messageFd.Message().Fields().ByName("content")
+	message := choice.Get(partFd).Message()
+	msgContentFd := partFd.Message().Fields().ByName("content")
 	if msgContentFd == nil {
 		return
 	}
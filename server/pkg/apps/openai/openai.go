@@ -1,11 +1,15 @@
 // Package openai implements the built-in "openai" app: it exposes the standard
-// OpenAI chat completions API as a small gRPC surface kaja can render and invoke.
+// OpenAI API - chat completions, unary or streamed, models, embeddings, the
+// Responses API and moderation - as a small gRPC surface kaja can render and
+// invoke, against OpenAI or any gateway compatible with it.
 //
-// The app has two creation parameters: "endpoint" (the full chat completions URL,
-// e.g. https://api.openai.com/v1/chat/completions) and "token" (the API key sent
-// as a Bearer token). Method calls arrive as protobuf, are transcoded into a POST
-// against the endpoint, and the JSON response is shaped back into the method's
-// protobuf response.
+// The app has two creation parameters: "endpoint" (the API's base URL, e.g.
+// https://api.openai.com/v1, or - as it used to be given - the full chat
+// completions URL, whose base is the rest of it) and "token" (the API key sent
+// as a Bearer token). Method calls arrive as protobuf, are transcoded into a
+// request against the method's path under the base, and the JSON response is
+// shaped back into the method's protobuf response; a streamed call asks for the
+// server-sent event stream and sends each chunk on as its own message.
 package openai
 
 import (
@@ -14,10 +18,12 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
 	"github.com/wham/protoc-go/protoc"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -25,28 +31,89 @@ import (
 
 const (
 	serviceTypeName = "openai.OpenAI"
-	defaultEndpoint = "https://api.openai.com/v1/chat/completions"
+	defaultBaseURL  = "https://api.openai.com/v1"
 )
 
-// protoSource is the static proto surface the openai app renders: a single
-// ChatCompletion method exposing the most common chat completion inputs (model,
-// system/user prompt, temperature, ...) and a response carrying the assistant's
-// reply alongside the raw choices and token usage.
+// protoSource is the static proto surface the openai app renders: a
+// ChatCompletion method exposing the chat completion inputs scripts reach for
+// (model, prompts or a whole conversation, tools, a response format, sampling)
+// and a response carrying the assistant's reply alongside the raw choices and
+// token usage, plus StreamChatCompletion, which sends the reply as it is
+// generated; and beside them the models list, embeddings, the Responses API and
+// moderation. The fields carry the API's own JSON names, so a request encodes
+// into the body the endpoint expects, and every response carries a failure in
+// the same Error.
 const protoSource = `syntax = "proto3";
 
 package openai;
 
+import "google/protobuf/struct.proto";
+
+// One turn of the conversation. A tool result is a "tool" message naming the
+// call it answers in tool_call_id; an assistant turn that called tools lists
+// them in tool_calls.
 message Message {
+  // "system", "user", "assistant" or "tool".
   string role = 1 [json_name = "role"];
   string content = 2 [json_name = "content"];
+  // Name of the participant, to tell apart several of the same role.
+  string name = 3 [json_name = "name"];
+  repeated ToolCall tool_calls = 4 [json_name = "tool_calls"];
+  string tool_call_id = 5 [json_name = "tool_call_id"];
+}
+
+// A call the model made to one of the request's tools.
+message ToolCall {
+  string id = 1 [json_name = "id"];
+  // Always "function".
+  string type = 2 [json_name = "type"];
+  FunctionCall function = 3 [json_name = "function"];
+}
+
+message FunctionCall {
+  string name = 1 [json_name = "name"];
+  // The arguments as JSON text, which the model wrote and may not be valid.
+  string arguments = 2 [json_name = "arguments"];
+}
+
+// A tool the model may call.
+message Tool {
+  // Empty means "function", the only kind there is.
+  string type = 1 [json_name = "type"];
+  Function function = 2 [json_name = "function"];
+}
+
+message Function {
+  string name = 1 [json_name = "name"];
+  string description = 2 [json_name = "description"];
+  // JSON Schema of the arguments object.
+  google.protobuf.Struct parameters = 3 [json_name = "parameters"];
+  // Hold the model to the schema exactly.
+  optional bool strict = 4 [json_name = "strict"];
+}
+
+message ResponseFormat {
+  // "text", "json_object" or "json_schema".
+  string type = 1 [json_name = "type"];
+  // The schema the reply follows, for "json_schema".
+  JsonSchema json_schema = 2 [json_name = "json_schema"];
+}
+
+message JsonSchema {
+  string name = 1 [json_name = "name"];
+  string description = 2 [json_name = "description"];
+  google.protobuf.Struct schema = 3 [json_name = "schema"];
+  optional bool strict = 4 [json_name = "strict"];
 }
 
 message ChatCompletionRequest {
   // Model name, e.g. "gpt-4o-mini".
   string model = 1 [json_name = "model"];
-  // System prompt that sets the assistant's behavior (optional).
+  // System prompt that sets the assistant's behavior (optional). It goes ahead
+  // of messages.
   string system_prompt = 2 [json_name = "system_prompt"];
-  // User prompt sent to the model.
+  // User prompt sent to the model, after messages. Optional when messages
+  // carries the conversation.
   string user_prompt = 3 [json_name = "user_prompt"];
   // Sampling temperature between 0 and 2. Higher is more random.
   optional float temperature = 4 [json_name = "temperature"];
@@ -54,6 +121,18 @@ message ChatCompletionRequest {
   optional int32 max_tokens = 5 [json_name = "max_tokens"];
   // Nucleus sampling probability mass between 0 and 1.
   optional float top_p = 6 [json_name = "top_p"];
+  // The conversation so far, for a multi-turn request.
+  repeated Message messages = 7 [json_name = "messages"];
+  // Tools the model may call instead of replying.
+  repeated Tool tools = 8 [json_name = "tools"];
+  // "auto", "none", "required", or the name of the function the model must
+  // call. Empty leaves it to the API.
+  string tool_choice = 9 [json_name = "tool_choice"];
+  // Whether the model may call several tools in one turn.
+  optional bool parallel_tool_calls = 10 [json_name = "parallel_tool_calls"];
+  // The shape of the reply: free text, any JSON object, or JSON following a
+  // schema.
+  ResponseFormat response_format = 11 [json_name = "response_format"];
 }
 
 message Usage {
@@ -65,6 +144,7 @@ message Usage {
 message Choice {
   int32 index = 1 [json_name = "index"];
   Message message = 2 [json_name = "message"];
+  // "stop", "length", "tool_calls" or "content_filter".
   string finish_reason = 3 [json_name = "finish_reason"];
 }
 
@@ -98,9 +178,204 @@ message ChatCompletionResponse {
   Error error = 6 [json_name = "error"];
 }
 
+// What one streamed chunk adds to a message.
+message Delta {
+  string role = 1 [json_name = "role"];
+  string content = 2 [json_name = "content"];
+  repeated ToolCallDelta tool_calls = 3 [json_name = "tool_calls"];
+}
+
+// A piece of a tool call. The first piece of a call carries its id and name;
+// the rest add to its arguments. index says which call a piece belongs to.
+message ToolCallDelta {
+  int32 index = 1 [json_name = "index"];
+  string id = 2 [json_name = "id"];
+  string type = 3 [json_name = "type"];
+  FunctionCall function = 4 [json_name = "function"];
+}
+
+message ChunkChoice {
+  int32 index = 1 [json_name = "index"];
+  Delta delta = 2 [json_name = "delta"];
+  string finish_reason = 3 [json_name = "finish_reason"];
+}
+
+// One message of StreamChatCompletion: one chunk of the reply as the API sent
+// it.
+message ChatCompletionChunk {
+  string id = 1 [json_name = "id"];
+  string model = 2 [json_name = "model"];
+  // The text this chunk adds: the content of the first choice's delta.
+  string content = 3 [json_name = "content"];
+  repeated ChunkChoice choices = 4 [json_name = "choices"];
+  // Set on the last chunk, which carries no choices.
+  Usage usage = 5 [json_name = "usage"];
+  // Set when the upstream API fails, before the stream or during it. It is the
+  // last message.
+  Error error = 6 [json_name = "error"];
+}
+
+message ListModelsRequest {}
+
+message Model {
+  // The name requests pass as "model".
+  string id = 1 [json_name = "id"];
+  string object = 2 [json_name = "object"];
+  // When the model was created, in seconds since the Unix epoch.
+  int64 created = 3 [json_name = "created"];
+  string owned_by = 4 [json_name = "owned_by"];
+}
+
+message ListModelsResponse {
+  repeated Model data = 1 [json_name = "data"];
+  Error error = 2 [json_name = "error"];
+}
+
+message EmbeddingRequest {
+  // Model name, e.g. "text-embedding-3-small".
+  string model = 1 [json_name = "model"];
+  // The texts to embed, one vector each.
+  repeated string input = 2 [json_name = "input"];
+  // Length of the vectors, for models that can shorten them.
+  optional int32 dimensions = 3 [json_name = "dimensions"];
+  // An identifier for the end user, for the API's abuse monitoring.
+  string user = 4 [json_name = "user"];
+}
+
+message Embedding {
+  // Which of the inputs the vector is for.
+  int32 index = 1 [json_name = "index"];
+  repeated float embedding = 2 [json_name = "embedding"];
+}
+
+message EmbeddingResponse {
+  string model = 1 [json_name = "model"];
+  repeated Embedding data = 2 [json_name = "data"];
+  Usage usage = 3 [json_name = "usage"];
+  Error error = 4 [json_name = "error"];
+}
+
+// One message of a Responses API input.
+message InputMessage {
+  // "user", "assistant", "system" or "developer".
+  string role = 1 [json_name = "role"];
+  string content = 2 [json_name = "content"];
+}
+
+// A function the model may call, as the Responses API describes one.
+message ResponseTool {
+  // Empty means "function".
+  string type = 1 [json_name = "type"];
+  string name = 2 [json_name = "name"];
+  string description = 3 [json_name = "description"];
+  // JSON Schema of the arguments object.
+  google.protobuf.Struct parameters = 4 [json_name = "parameters"];
+  optional bool strict = 5 [json_name = "strict"];
+}
+
+message ResponseRequest {
+  // Model name, e.g. "gpt-4o-mini".
+  string model = 1 [json_name = "model"];
+  // System-level instructions for this response.
+  string instructions = 2 [json_name = "instructions"];
+  // The user's input as text, after messages.
+  string input = 3 [json_name = "input"];
+  // The conversation so far, for a multi-turn request.
+  repeated InputMessage messages = 4 [json_name = "messages"];
+  // Continue from an earlier response the API has stored, instead of sending
+  // the conversation again.
+  string previous_response_id = 5 [json_name = "previous_response_id"];
+  repeated ResponseTool tools = 6 [json_name = "tools"];
+  optional float temperature = 7 [json_name = "temperature"];
+  optional float top_p = 8 [json_name = "top_p"];
+  optional int32 max_output_tokens = 9 [json_name = "max_output_tokens"];
+  // Whether the API keeps the response, for previous_response_id to name.
+  optional bool store = 10 [json_name = "store"];
+  map<string, string> metadata = 11 [json_name = "metadata"];
+}
+
+message OutputContent {
+  // "output_text" or "refusal".
+  string type = 1 [json_name = "type"];
+  string text = 2 [json_name = "text"];
+  string refusal = 3 [json_name = "refusal"];
+}
+
+// One item of a response's output: a message, or a call to one of the tools.
+message OutputItem {
+  // "message", "function_call", "reasoning", ...
+  string type = 1 [json_name = "type"];
+  string id = 2 [json_name = "id"];
+  string status = 3 [json_name = "status"];
+  string role = 4 [json_name = "role"];
+  repeated OutputContent content = 5 [json_name = "content"];
+  // The function a "function_call" item calls, the id its result answers to,
+  // and its arguments as JSON text.
+  string name = 6 [json_name = "name"];
+  string call_id = 7 [json_name = "call_id"];
+  string arguments = 8 [json_name = "arguments"];
+}
+
+message ResponseUsage {
+  int32 input_tokens = 1 [json_name = "input_tokens"];
+  int32 output_tokens = 2 [json_name = "output_tokens"];
+  int32 total_tokens = 3 [json_name = "total_tokens"];
+}
+
+message ResponseObject {
+  string id = 1 [json_name = "id"];
+  string model = 2 [json_name = "model"];
+  // "completed", "incomplete", "failed", ...
+  string status = 3 [json_name = "status"];
+  // The text of every output message, joined.
+  string output_text = 4 [json_name = "output_text"];
+  repeated OutputItem output = 5 [json_name = "output"];
+  ResponseUsage usage = 6 [json_name = "usage"];
+  // Set when the upstream API returns an error, or the response failed.
+  Error error = 7 [json_name = "error"];
+}
+
+message ModerationRequest {
+  // Model name, e.g. "omni-moderation-latest". Empty leaves it to the API.
+  string model = 1 [json_name = "model"];
+  // The texts to classify, one result each.
+  repeated string input = 2 [json_name = "input"];
+}
+
+message ModerationResult {
+  bool flagged = 1 [json_name = "flagged"];
+  // Whether the input falls in each category, e.g. "harassment".
+  map<string, bool> categories = 2 [json_name = "categories"];
+  // The model's confidence in each category, between 0 and 1.
+  map<string, double> category_scores = 3 [json_name = "category_scores"];
+}
+
+message ModerationResponse {
+  string id = 1 [json_name = "id"];
+  string model = 2 [json_name = "model"];
+  repeated ModerationResult results = 3 [json_name = "results"];
+  Error error = 4 [json_name = "error"];
+}
+
 service OpenAI {
   // Create a chat completion using the standard OpenAI chat completions API.
   rpc ChatCompletion(ChatCompletionRequest) returns (ChatCompletionResponse);
+
+  // ChatCompletion, with the reply sent as it is generated: one message per
+  // chunk the API streams.
+  rpc StreamChatCompletion(ChatCompletionRequest) returns (stream ChatCompletionChunk);
+
+  // List the models the endpoint serves.
+  rpc ListModels(ListModelsRequest) returns (ListModelsResponse);
+
+  // Turn texts into embedding vectors.
+  rpc CreateEmbedding(EmbeddingRequest) returns (EmbeddingResponse);
+
+  // Create a model response with the Responses API.
+  rpc CreateResponse(ResponseRequest) returns (ResponseObject);
+
+  // Classify texts as potentially harmful.
+  rpc CreateModeration(ModerationRequest) returns (ModerationResponse);
 }
 `
 
@@ -110,14 +385,16 @@ type App struct{}
 func New() *App { return &App{} }
 
 func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
-	endpoint := strings.TrimSpace(parameters["endpoint"])
-	if endpoint == "" {
-		endpoint = defaultEndpoint
+	base, err := baseURL(parameters["endpoint"])
+	if err != nil {
+		return nil, err
 	}
-	if err := requireHTTPScheme(endpoint); err != nil {
+	log("OpenAI base URL: " + base.String())
+
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
 		return nil, err
 	}
-	log("OpenAI endpoint: " + endpoint)
 
 	token := strings.TrimSpace(parameters["token"])
 	if token == "" {
@@ -128,45 +405,72 @@ func (a *App) Open(parameters map[string]string, protoDir string, log func(strin
 		return nil, fmt.Errorf("writing proto: %w", err)
 	}
 
-	input, output, err := compile(protoDir)
+	methods, err := compile(protoDir)
 	if err != nil {
 		return nil, err
 	}
-	log("Generated service " + serviceTypeName + " with method ChatCompletion")
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log("Generated service " + serviceTypeName + " with methods " + strings.Join(names, ", "))
 
 	return &apps.Opened{Instance: &instance{
-		endpoint: endpoint,
-		token:    token,
-		input:    input,
-		output:   output,
-		client:   &http.Client{Timeout: 120 * time.Second},
+		base:    base,
+		token:   token,
+		methods: methods,
+		client:  &http.Client{Timeout: 120 * time.Second},
+		retry:   policy,
 	}}, nil
 }
 
-// compile compiles the static proto and resolves ChatCompletion's request and
-// response descriptors, used to decode the request and encode the response.
-func compile(protoDir string) (protoreflect.MessageDescriptor, protoreflect.MessageDescriptor, error) {
-	result, err := protoc.New(protoc.WithProtoPaths(protoDir)).Compile("openai.proto")
+// compile compiles the static proto and resolves the service's methods by name,
+// whose descriptors decode each request and encode its response.
+func compile(protoDir string) (map[string]protoreflect.MethodDescriptor, error) {
+	result, err := protoc.New(protoc.WithProtoPaths(protoDir), protoc.WithIncludeImports()).Compile("openai.proto")
 	if err != nil {
-		return nil, nil, fmt.Errorf("compiling generated proto: %w", err)
+		return nil, fmt.Errorf("compiling generated proto: %w", err)
 	}
 	files, err := protodesc.NewFiles(result.AsFileDescriptorSet())
 	if err != nil {
-		return nil, nil, fmt.Errorf("building descriptors: %w", err)
+		return nil, fmt.Errorf("building descriptors: %w", err)
 	}
 	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceTypeName))
 	if err != nil {
-		return nil, nil, fmt.Errorf("finding service %s: %w", serviceTypeName, err)
+		return nil, fmt.Errorf("finding service %s: %w", serviceTypeName, err)
 	}
 	service, ok := descriptor.(protoreflect.ServiceDescriptor)
 	if !ok {
-		return nil, nil, fmt.Errorf("%s is not a service", serviceTypeName)
+		return nil, fmt.Errorf("%s is not a service", serviceTypeName)
+	}
+	methods := map[string]protoreflect.MethodDescriptor{}
+	for i := 0; i < service.Methods().Len(); i++ {
+		method := service.Methods().Get(i)
+		methods[string(method.Name())] = method
+	}
+	return methods, nil
+}
+
+// baseURL resolves the endpoint parameter into the base URL the API's paths
+// hang off: the default when it is empty, and the base of a full chat
+// completions URL. A query, which some gateways use to pin an API version, is
+// kept for every path.
+func baseURL(endpoint string) (*url.URL, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		endpoint = defaultBaseURL
+	}
+	if err := requireHTTPScheme(endpoint); err != nil {
+		return nil, err
 	}
-	method := service.Methods().ByName("ChatCompletion")
-	if method == nil {
-		return nil, nil, fmt.Errorf("method ChatCompletion missing from compiled descriptors")
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", endpoint, err)
 	}
-	return method.Input(), method.Output(), nil
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/chat/completions")
+	u.RawPath = ""
+	return u, nil
 }
 
 // requireHTTPScheme rejects URLs that are not plain HTTP(S), so a base URL can't
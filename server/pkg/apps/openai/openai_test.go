@@ -1,14 +1,17 @@
 package openai
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/dynamicpb"
 
 	"github.com/wham/kaja/v2/pkg/apps"
@@ -27,7 +30,7 @@ func openTestApp(t *testing.T, endpoint, token string) *instance {
 // encodeRequest builds the protobuf ChatCompletion request bytes from a JSON object.
 func encodeRequest(t *testing.T, in *instance, requestJSON string) []byte {
 	t.Helper()
-	msg := dynamicpb.NewMessage(in.input)
+	msg := dynamicpb.NewMessage(in.methods["ChatCompletion"].Input())
 	if err := protojson.Unmarshal([]byte(requestJSON), msg); err != nil {
 		t.Fatalf("build request: %v", err)
 	}
@@ -41,8 +44,14 @@ func encodeRequest(t *testing.T, in *instance, requestJSON string) []byte {
 // decodeResponse turns the protobuf response bytes back into JSON.
 func decodeResponse(t *testing.T, in *instance, result *apps.InvokeResult) map[string]any {
 	t.Helper()
-	msg := dynamicpb.NewMessage(in.output)
-	if err := proto.Unmarshal(result.Body, msg); err != nil {
+	return decodeMessage(t, in.methods["ChatCompletion"].Output(), result.Body)
+}
+
+// decodeMessage turns protobuf bytes of the given message type into JSON.
+func decodeMessage(t *testing.T, desc protoreflect.MessageDescriptor, body []byte) map[string]any {
+	t.Helper()
+	msg := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(body, msg); err != nil {
 		t.Fatalf("unmarshal response: %v", err)
 	}
 	j, err := protojson.Marshal(msg)
@@ -157,6 +166,155 @@ func TestChatCompletionOmitsSystemPromptWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestChatCompletionConversationAndTools(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		json.Unmarshal(b, &gotBody)
+		io.WriteString(w, `{"id":"chatcmpl-2","choices":[{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant","content":null,
+			"tool_calls":[{"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Oslo\"}"}}]}}]}`)
+	}))
+	defer server.Close()
+
+	in := openTestApp(t, server.URL+"/chat/completions", "")
+	req := encodeRequest(t, in, `{
+		"model": "m",
+		"system_prompt": "Be brief",
+		"messages": [
+			{"role": "user", "content": "Weather in Paris?"},
+			{"role": "assistant", "tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}}]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "Sunny"}
+		],
+		"user_prompt": "And in Oslo?",
+		"tools": [{"function": {"name": "get_weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}, "required": ["city"]}}}],
+		"tool_choice": "get_weather",
+		"response_format": {"type": "json_schema", "json_schema": {"name": "weather", "schema": {"type": "object"}, "strict": true}}
+	}`)
+	resp, err := in.Invoke("openai.OpenAI/ChatCompletion", req, nil)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+
+	messages := gotBody["messages"].([]any)
+	var roles []string
+	for _, message := range messages {
+		roles = append(roles, message.(map[string]any)["role"].(string))
+	}
+	if got := strings.Join(roles, ","); got != "system,user,assistant,tool,user" {
+		t.Fatalf("roles = %s, want the system prompt, the conversation and the user prompt", got)
+	}
+	if got := messages[3].(map[string]any)["tool_call_id"]; got != "call_1" {
+		t.Errorf("tool result answers %v, want call_1", got)
+	}
+	tool := gotBody["tools"].([]any)[0].(map[string]any)
+	if tool["type"] != "function" {
+		t.Errorf("tool type = %v, want function", tool["type"])
+	}
+	if required := tool["function"].(map[string]any)["parameters"].(map[string]any)["required"]; len(required.([]any)) != 1 {
+		t.Errorf("parameters.required = %v", required)
+	}
+	choice, _ := json.Marshal(gotBody["tool_choice"])
+	if string(choice) != `{"function":{"name":"get_weather"},"type":"function"}` {
+		t.Errorf("tool_choice = %s, want the named function", choice)
+	}
+	if format := gotBody["response_format"].(map[string]any); format["type"] != "json_schema" || format["json_schema"].(map[string]any)["strict"] != true {
+		t.Errorf("response_format = %v", format)
+	}
+	if _, ok := gotBody["parallel_tool_calls"]; ok {
+		t.Errorf("parallel_tool_calls should be omitted when unset")
+	}
+
+	out := decodeResponse(t, in, resp)
+	message := out["choices"].([]any)[0].(map[string]any)["message"].(map[string]any)
+	call := message["tool_calls"].([]any)[0].(map[string]any)
+	if call["id"] != "call_2" || call["function"].(map[string]any)["arguments"] != `{"city":"Oslo"}` {
+		t.Errorf("tool call = %v", call)
+	}
+}
+
+func TestStreamChatCompletion(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		json.Unmarshal(b, &gotBody)
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, `data: {"id":"chatcmpl-3","model":"m","choices":[{"index":0,"delta":{"role":"assistant","content":""}}]}
+
+data: {"id":"chatcmpl-3","model":"m","choices":[{"index":0,"delta":{"content":"Hel"}}]}
+
+: keep-alive
+
+data: {"id":"chatcmpl-3","model":"m","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}
+
+data: {"id":"chatcmpl-3","model":"m","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}
+
+data: [DONE]
+
+`)
+	}))
+	defer server.Close()
+
+	in := openTestApp(t, server.URL+"/chat/completions", "")
+	if !in.Streams("openai.OpenAI/StreamChatCompletion") || in.Streams("openai.OpenAI/ChatCompletion") {
+		t.Fatal("only StreamChatCompletion should stream")
+	}
+	req := encodeRequest(t, in, `{"model": "m", "user_prompt": "Hi"}`)
+	var chunks []map[string]any
+	result, err := in.InvokeStream(context.Background(), "openai.OpenAI/StreamChatCompletion", req, nil, func(message []byte) error {
+		chunks = append(chunks, decodeMessage(t, in.methods["StreamChatCompletion"].Output(), message))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InvokeStream: %v", err)
+	}
+	if result.ResponseHeaders["Content-Type"] != "text/event-stream" {
+		t.Errorf("response headers = %v", result.ResponseHeaders)
+	}
+
+	if gotBody["stream"] != true || gotBody["stream_options"].(map[string]any)["include_usage"] != true {
+		t.Errorf("upstream body = %v, want a stream with usage", gotBody)
+	}
+	if len(chunks) != 4 {
+		t.Fatalf("got %d chunks, want 4: %v", len(chunks), chunks)
+	}
+	var text string
+	for _, chunk := range chunks {
+		content, _ := chunk["content"].(string)
+		text += content
+	}
+	if text != "Hello" {
+		t.Errorf("text = %q, want Hello", text)
+	}
+	if usage := chunks[3]["usage"].(map[string]any); usage["total_tokens"].(float64) != 7 {
+		t.Errorf("usage = %v", usage)
+	}
+}
+
+func TestStreamChatCompletionUpstreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, `{"error":{"message":"Rate limit reached","type":"requests","code":"rate_limit_exceeded"}}`)
+	}))
+	defer server.Close()
+
+	in := openTestApp(t, server.URL+"/chat/completions", "")
+	req := encodeRequest(t, in, `{"model": "m", "user_prompt": "Hi"}`)
+	var chunks []map[string]any
+	if _, err := in.InvokeStream(context.Background(), "openai.OpenAI/StreamChatCompletion", req, nil, func(message []byte) error {
+		chunks = append(chunks, decodeMessage(t, in.methods["StreamChatCompletion"].Output(), message))
+		return nil
+	}); err != nil {
+		t.Fatalf("InvokeStream: %v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("got %d chunks, want the error alone", len(chunks))
+	}
+	errObj := chunks[0]["error"].(map[string]any)
+	if errObj["status"].(float64) != 429 || errObj["code"] != "rate_limit_exceeded" {
+		t.Errorf("error = %v", errObj)
+	}
+}
+
 func TestChatCompletionUpstreamError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -237,7 +395,191 @@ func TestDefaultEndpoint(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Open: %v", err)
 	}
-	if got := opened.Instance.(*instance).endpoint; got != defaultEndpoint {
-		t.Errorf("endpoint = %q, want %q", got, defaultEndpoint)
+	if got := opened.Instance.(*instance).url("chat/completions"); got != "https://api.openai.com/v1/chat/completions" {
+		t.Errorf("chat completions URL = %q, want OpenAI's", got)
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	for endpoint, want := range map[string]string{
+		"https://gateway.example.com/v1":                  "https://gateway.example.com/v1/models",
+		"https://gateway.example.com/v1/":                 "https://gateway.example.com/v1/models",
+		"https://gateway.example.com/v1/chat/completions": "https://gateway.example.com/v1/models",
+		"http://localhost:8080/openai?api-version=2024-1": "http://localhost:8080/openai/models?api-version=2024-1",
+	} {
+		base, err := baseURL(endpoint)
+		if err != nil {
+			t.Fatalf("baseURL(%q): %v", endpoint, err)
+		}
+		if got := (&instance{base: base}).url("models"); got != want {
+			t.Errorf("models URL for %q = %q, want %q", endpoint, got, want)
+		}
+	}
+	if _, err := baseURL("file:///etc/passwd"); err == nil {
+		t.Error("a file URL should be refused")
+	}
+}
+
+// invokeMethod encodes requestJSON as the named method's request, invokes it
+// and returns the response as JSON.
+func invokeMethod(t *testing.T, in *instance, name, requestJSON string) map[string]any {
+	t.Helper()
+	method := in.methods[name]
+	msg := dynamicpb.NewMessage(method.Input())
+	if err := protojson.Unmarshal([]byte(requestJSON), msg); err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	req, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	result, err := in.Invoke("openai.OpenAI/"+name, req, nil)
+	if err != nil {
+		t.Fatalf("Invoke %s: %v", name, err)
+	}
+	return decodeMessage(t, method.Output(), result.Body)
+}
+
+// gateway stands in for an OpenAI-compatible gateway under /v1, answering each
+// path with its canned reply and recording the requests it gets by path.
+func gateway(t *testing.T, replies map[string]string) (string, map[string]*http.Request, map[string]map[string]any) {
+	t.Helper()
+	requests, bodies := map[string]*http.Request{}, map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reply, ok := replies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"message":"Unknown path","type":"invalid_request_error"}}`)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		body := map[string]any{}
+		json.Unmarshal(b, &body)
+		requests[r.URL.Path], bodies[r.URL.Path] = r, body
+		io.WriteString(w, reply)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL + "/v1", requests, bodies
+}
+
+func TestListModels(t *testing.T) {
+	endpoint, requests, _ := gateway(t, map[string]string{
+		"/v1/models": `{"object":"list","data":[{"id":"gpt-4o-mini","object":"model","created":1721172741,"owned_by":"system"}]}`,
+	})
+	in := openTestApp(t, endpoint, "secret")
+	out := invokeMethod(t, in, "ListModels", `{}`)
+
+	request := requests["/v1/models"]
+	if request.Method != http.MethodGet || request.Header.Get("Content-Type") != "" {
+		t.Errorf("request = %s with Content-Type %q, want a plain GET", request.Method, request.Header.Get("Content-Type"))
+	}
+	if request.Header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("Authorization = %q", request.Header.Get("Authorization"))
+	}
+	model := out["data"].([]any)[0].(map[string]any)
+	if model["id"] != "gpt-4o-mini" || model["created"] != "1721172741" || model["owned_by"] != "system" {
+		t.Errorf("model = %v", model)
+	}
+}
+
+func TestCreateEmbedding(t *testing.T) {
+	endpoint, _, bodies := gateway(t, map[string]string{
+		"/v1/embeddings": `{"object":"list","model":"text-embedding-3-small","data":[{"object":"embedding","index":0,"embedding":[0.25,-0.5]}],"usage":{"prompt_tokens":2,"total_tokens":2}}`,
+	})
+	in := openTestApp(t, endpoint, "")
+	out := invokeMethod(t, in, "CreateEmbedding", `{"model":"text-embedding-3-small","input":["hello"],"dimensions":2}`)
+
+	body := bodies["/v1/embeddings"]
+	if body["model"] != "text-embedding-3-small" || body["dimensions"].(float64) != 2 || len(body["input"].([]any)) != 1 {
+		t.Errorf("upstream body = %v", body)
+	}
+	vector := out["data"].([]any)[0].(map[string]any)["embedding"].([]any)
+	if len(vector) != 2 || vector[0].(float64) != 0.25 || vector[1].(float64) != -0.5 {
+		t.Errorf("embedding = %v", vector)
+	}
+	if out["usage"].(map[string]any)["total_tokens"].(float64) != 2 {
+		t.Errorf("usage = %v", out["usage"])
+	}
+}
+
+func TestCreateResponse(t *testing.T) {
+	endpoint, _, bodies := gateway(t, map[string]string{
+		"/v1/responses": `{"id":"resp_1","object":"response","status":"completed","model":"gpt-4o-mini","error":null,
+			"output":[
+				{"type":"reasoning","id":"rs_1","summary":[]},
+				{"type":"message","id":"msg_1","role":"assistant","status":"completed","content":[{"type":"output_text","text":"Bonjour","annotations":[]},{"type":"output_text","text":"!"}]}
+			],
+			"usage":{"input_tokens":12,"output_tokens":3,"total_tokens":15}}`,
+	})
+	in := openTestApp(t, endpoint, "")
+	out := invokeMethod(t, in, "CreateResponse", `{
+		"model": "gpt-4o-mini",
+		"instructions": "Answer in French",
+		"messages": [{"role": "user", "content": "Hi"}, {"role": "assistant", "content": "Salut"}],
+		"input": "Again?",
+		"tools": [{"name": "lookup", "parameters": {"type": "object"}}],
+		"max_output_tokens": 50
+	}`)
+
+	body := bodies["/v1/responses"]
+	input, ok := body["input"].([]any)
+	if !ok || len(input) != 3 || input[2].(map[string]any)["content"] != "Again?" {
+		t.Errorf("input = %v, want the conversation and then the text", body["input"])
+	}
+	if _, ok := body["messages"]; ok {
+		t.Errorf("messages should be sent as input, not as is")
+	}
+	if tool := body["tools"].([]any)[0].(map[string]any); tool["type"] != "function" || tool["name"] != "lookup" {
+		t.Errorf("tool = %v", tool)
+	}
+	if body["instructions"] != "Answer in French" || body["max_output_tokens"].(float64) != 50 {
+		t.Errorf("upstream body = %v", body)
+	}
+	if out["output_text"] != "Bonjour!" || out["status"] != "completed" {
+		t.Errorf("response = %v", out)
+	}
+	if out["usage"].(map[string]any)["total_tokens"].(float64) != 15 {
+		t.Errorf("usage = %v", out["usage"])
+	}
+
+	// Text alone is sent as text.
+	invokeMethod(t, in, "CreateResponse", `{"model": "gpt-4o-mini", "input": "Hi"}`)
+	if got := bodies["/v1/responses"]["input"]; got != "Hi" {
+		t.Errorf("input = %v, want the text", got)
+	}
+}
+
+func TestCreateModeration(t *testing.T) {
+	endpoint, _, bodies := gateway(t, map[string]string{
+		"/v1/moderations": `{"id":"modr-1","model":"omni-moderation-latest","results":[{"flagged":true,"categories":{"harassment":true,"violence":false},"category_scores":{"harassment":0.9,"violence":0.01},"category_applied_input_types":{"harassment":["text"]}}]}`,
+	})
+	in := openTestApp(t, endpoint, "")
+	out := invokeMethod(t, in, "CreateModeration", `{"input":["you are awful"]}`)
+
+	if _, ok := bodies["/v1/moderations"]["model"]; ok {
+		t.Errorf("model should be left to the API when empty")
+	}
+	result := out["results"].([]any)[0].(map[string]any)
+	if result["flagged"] != true || result["categories"].(map[string]any)["harassment"] != true {
+		t.Errorf("result = %v", result)
+	}
+	if result["category_scores"].(map[string]any)["harassment"].(float64) != 0.9 {
+		t.Errorf("scores = %v", result["category_scores"])
+	}
+}
+
+func TestUpstreamErrorOnEveryMethod(t *testing.T) {
+	endpoint, _, _ := gateway(t, map[string]string{})
+	in := openTestApp(t, endpoint, "")
+	for name, request := range map[string]string{
+		"ListModels":       `{}`,
+		"CreateEmbedding":  `{"model":"m","input":["x"]}`,
+		"CreateResponse":   `{"model":"m","input":"x"}`,
+		"CreateModeration": `{"input":["x"]}`,
+	} {
+		errObj, ok := invokeMethod(t, in, name, request)["error"].(map[string]any)
+		if !ok || errObj["status"].(float64) != 404 || errObj["message"] != "Unknown path" || errObj["type"] != "invalid_request_error" {
+			t.Errorf("%s error = %v, want the upstream's", name, errObj)
+		}
 	}
 }
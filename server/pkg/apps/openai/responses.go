@@ -0,0 +1,83 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// buildResponseBody turns the decoded CreateResponse request into the JSON body
+// the Responses API expects. Its input is the text alone when there is no
+// conversation, and otherwise the conversation's messages with the text as the
+// last user message.
+func buildResponseBody(reqMsg *dynamicpb.Message) ([]byte, error) {
+	encoded, err := protojson.Marshal(reqMsg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	payload := map[string]any{}
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+
+	model, _ := payload["model"].(string)
+	if strings.TrimSpace(model) == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	payload["model"] = strings.TrimSpace(model)
+
+	text, _ := payload["input"].(string)
+	conversation, _ := payload["messages"].([]any)
+	delete(payload, "messages")
+	if len(conversation) > 0 {
+		if text != "" {
+			conversation = append(conversation, map[string]any{"role": "user", "content": text})
+		}
+		payload["input"] = conversation
+	} else {
+		payload["input"] = text
+	}
+
+	if tools, ok := payload["tools"].([]any); ok {
+		for _, tool := range tools {
+			if tool, ok := tool.(map[string]any); ok && tool["type"] == nil {
+				tool["type"] = "function"
+			}
+		}
+	}
+	return json.Marshal(payload)
+}
+
+// setOutputText joins the text of every output message into the top-level
+// convenience "output_text" field, as the API's own SDKs do.
+func setOutputText(respMsg *dynamicpb.Message) {
+	fields := respMsg.Descriptor().Fields()
+	outputFd, textFd := fields.ByName("output"), fields.ByName("output_text")
+	if outputFd == nil || textFd == nil {
+		return
+	}
+	itemFields := outputFd.Message().Fields()
+	contentFd := itemFields.ByName("content")
+	partFields := contentFd.Message().Fields()
+
+	var text strings.Builder
+	output := respMsg.Get(outputFd).List()
+	for i := 0; i < output.Len(); i++ {
+		item := output.Get(i).Message()
+		if item.Get(itemFields.ByName("type")).String() != "message" {
+			continue
+		}
+		content := item.Get(contentFd).List()
+		for j := 0; j < content.Len(); j++ {
+			part := content.Get(j).Message()
+			if part.Get(partFields.ByName("type")).String() == "output_text" {
+				text.WriteString(part.Get(partFields.ByName("text")).String())
+			}
+		}
+	}
+	respMsg.Set(textFd, protoreflect.ValueOfString(text.String()))
+}
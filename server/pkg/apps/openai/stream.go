@@ -0,0 +1,143 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func (in *instance) Streams(methodPath string) bool {
+	method := in.methods[lastSegment(methodPath)]
+	return method != nil && method.IsStreamingServer()
+}
+
+// InvokeStream asks the endpoint to stream its reply and sends each chunk of
+// the server-sent event stream on as a ChatCompletionChunk, until the stream's
+// closing [DONE]. An upstream failure - an HTTP error before the stream, or an
+// error event during it - is sent as a last chunk carrying it in "error", as
+// ChatCompletion carries one on its response.
+func (in *instance) InvokeStream(ctx context.Context, methodPath string, request []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error) {
+	method := in.methods[lastSegment(methodPath)]
+	if method == nil || !method.IsStreamingServer() {
+		return nil, fmt.Errorf("unknown streaming method %q", methodPath)
+	}
+
+	reqMsg, err := decodeRequest(method, request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := buildRequestBody(reqMsg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// The reply takes as long as the model writes, so only ctx bounds it.
+	client := *in.client
+	client.Timeout = 0
+	resp, reqHeaders, err := in.send(ctx, &client, http.MethodPost, "chat/completions", body, headers, "text/event-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	result := &apps.InvokeResult{RequestHeaders: reqHeaders, ResponseHeaders: apps.SurfaceHeaders(resp.Header)}
+
+	sendChunk := func(chunk *dynamicpb.Message) error {
+		out, err := proto.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+		return send(out)
+	}
+	failed := func(upstream map[string]any) error {
+		chunk := dynamicpb.NewMessage(method.Output())
+		if err := setError(chunk, upstream); err != nil {
+			return err
+		}
+		return sendChunk(chunk)
+	}
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+		if err := failed(parseUpstreamError(resp.StatusCode, respBody)); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	var sendErr error
+	err = readEvents(resp.Body, func(data []byte) bool {
+		if string(bytes.TrimSpace(data)) == "[DONE]" {
+			return true
+		}
+		var envelope struct {
+			Error json.RawMessage `json:"error"`
+		}
+		if json.Unmarshal(data, &envelope) == nil && len(envelope.Error) > 0 && string(envelope.Error) != "null" {
+			sendErr = failed(parseUpstreamError(resp.StatusCode, data))
+			return true
+		}
+		chunk := dynamicpb.NewMessage(method.Output())
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, chunk); err != nil {
+			sendErr = fmt.Errorf("decoding stream chunk: %w", err)
+			return true
+		}
+		setReplyContent(chunk, "delta")
+		sendErr = sendChunk(chunk)
+		return sendErr != nil
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading stream: %w", err)
+	}
+	return result, nil
+}
+
+// readEvents reads a server-sent event stream, handing the data of each event
+// to event, which returns true once it has seen the last one it wants.
+func readEvents(stream io.Reader, event func(data []byte) bool) error {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+
+	var current []string
+	flush := func() bool {
+		if len(current) == 0 {
+			return false
+		}
+		data := []byte(strings.Join(current, "\n"))
+		current = nil
+		return event(data)
+	}
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		switch {
+		case line == "":
+			if flush() {
+				return nil
+			}
+		case strings.HasPrefix(line, ":"):
+			// A comment, used as a keep-alive.
+		case strings.HasPrefix(line, "data:"):
+			current = append(current, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	flush()
+	return nil
+}
@@ -200,3 +200,31 @@ func (a *auth) describe() string {
 	}
 	return ""
 }
+
+// Credentials is the credential of an app that calls an HTTP API the way an
+// openapi app does, but with no document to say how it wants it: sent the way
+// an openapi app sends it when its document declares no security scheme.
+type Credentials struct {
+	auth *auth
+}
+
+// NewCredentials reads an app's token, username and password parameters. A
+// username or password is sent as HTTP Basic, a token as a bearer token.
+func NewCredentials(parameters map[string]string) Credentials {
+	return Credentials{auth: resolveAuth(&spec{}, "",
+		strings.TrimSpace(parameters["token"]),
+		strings.TrimSpace(parameters["username"]),
+		strings.TrimSpace(parameters["password"]),
+	)}
+}
+
+// Apply sets the credential on a request.
+func (c Credentials) Apply(req *http.Request) {
+	c.auth.applyRequest(req)
+}
+
+// Describe is how the credential is sent, for the open-time log. It is "" when
+// there is none.
+func (c Credentials) Describe() string {
+	return c.auth.describe()
+}
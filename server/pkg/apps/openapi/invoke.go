@@ -16,6 +16,7 @@ import (
 	"google.golang.org/protobuf/types/dynamicpb"
 
 	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/retry"
 )
 
 // boundMethod pairs a method's HTTP binding with the protobuf descriptors used to
@@ -26,6 +27,11 @@ type boundMethod struct {
 	output  protoreflect.MessageDescriptor
 }
 
+// bindMethod pairs a binding with the compiled method it is keyed by.
+func bindMethod(binding *methodBinding, method protoreflect.MethodDescriptor) *boundMethod {
+	return &boundMethod{binding: binding, input: method.Input(), output: method.Output()}
+}
+
 // instance is a live opened OpenAPI app. It is a gRPC app: method calls arrive as
 // protobuf, are transcoded into HTTP requests against the upstream REST API, and
 // the responses are shaped back into the method's protobuf response.
@@ -34,6 +40,7 @@ type instance struct {
 	methods map[string]*boundMethod
 	client  *http.Client
 	auth    *auth
+	retry   retry.Policy
 }
 
 func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
@@ -175,7 +182,8 @@ func (in *instance) transcode(binding *methodBinding, request []byte, headers ma
 	}
 	reqHeaders := apps.SurfaceHeaders(httpReq.Header)
 
-	resp, err := in.client.Do(httpReq)
+	resp, attempts, err := in.retry.Send(in.client.Do, httpReq)
+	reqHeaders = attempts.Record(reqHeaders)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("calling %s %s: %w", binding.verb, fullURL, err)
 	}
@@ -216,15 +224,6 @@ func lastSegment(s string) string {
 	return s
 }
 
-// serviceOfMethodPath returns the service-type portion of a "<service>/<Method>"
-// gRPC method path.
-func serviceOfMethodPath(s string) string {
-	if i := strings.LastIndex(s, "/"); i >= 0 {
-		return s[:i]
-	}
-	return s
-}
-
 // jsonScalar renders a JSON value as a plain string for use in a path or query.
 func jsonScalar(raw json.RawMessage) string {
 	if len(raw) == 0 {
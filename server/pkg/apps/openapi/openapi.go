@@ -14,9 +14,8 @@ import (
 	"time"
 
 	"github.com/wham/kaja/v2/pkg/apps"
-	"github.com/wham/protoc-go/protoc"
-	"google.golang.org/protobuf/reflect/protodesc"
-	"google.golang.org/protobuf/reflect/protoreflect"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+	"github.com/wham/kaja/v2/pkg/retry"
 )
 
 // httpProto declares the kaja options the generated proto marks its methods and
@@ -36,6 +35,12 @@ func (gen *generated) write(protoDir string) error {
 	if err := os.WriteFile(filepath.Join(protoDir, "service.proto"), []byte(gen.proto), 0o644); err != nil {
 		return fmt.Errorf("writing proto: %w", err)
 	}
+	return WriteHTTPProto(protoDir)
+}
+
+// WriteHTTPProto writes kaja/http.proto into protoDir, for a generated file that
+// imports it.
+func WriteHTTPProto(protoDir string) error {
 	dir := filepath.Join(protoDir, "kaja")
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return fmt.Errorf("creating kaja proto directory: %w", err)
@@ -113,56 +118,24 @@ func (a *App) Open(parameters map[string]string, protoDir string, log func(strin
 		log("Authentication: " + summary)
 	}
 
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &apps.Opened{Instance: &instance{
 		baseURL: baseURL,
 		methods: methods,
 		client:  &http.Client{Timeout: 30 * time.Second},
 		auth:    authentication,
+		retry:   policy,
 	}}, nil
 }
 
 // compileMethods compiles the generated proto and resolves each method's input
 // and output message descriptors, keyed by the gRPC method path.
 func compileMethods(protoDir string, gen *generated) (map[string]*boundMethod, error) {
-	// WithIncludeImports keeps imported descriptors (google/protobuf/struct.proto,
-	// pulled in when a field maps to google.protobuf.Value) in the set so
-	// protodesc.NewFiles can resolve them.
-	result, err := protoc.New(protoc.WithProtoPaths(protoDir), protoc.WithIncludeImports()).Compile("service.proto")
-	if err != nil {
-		return nil, fmt.Errorf("compiling generated proto: %w", err)
-	}
-	files, err := protodesc.NewFiles(result.AsFileDescriptorSet())
-	if err != nil {
-		return nil, fmt.Errorf("building descriptors: %w", err)
-	}
-
-	services := make(map[string]protoreflect.ServiceDescriptor, len(gen.serviceTypeNames))
-	for _, serviceTypeName := range gen.serviceTypeNames {
-		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceTypeName))
-		if err != nil {
-			return nil, fmt.Errorf("finding service %s: %w", serviceTypeName, err)
-		}
-		service, ok := descriptor.(protoreflect.ServiceDescriptor)
-		if !ok {
-			return nil, fmt.Errorf("%s is not a service", serviceTypeName)
-		}
-		services[serviceTypeName] = service
-	}
-
-	methods := make(map[string]*boundMethod, len(gen.bindings))
-	for key, binding := range gen.bindings {
-		service := services[serviceOfMethodPath(key)]
-		if service == nil {
-			return nil, fmt.Errorf("no service for method path %s", key)
-		}
-		name := lastSegment(key)
-		method := service.Methods().ByName(protoreflect.Name(name))
-		if method == nil {
-			return nil, fmt.Errorf("method %s missing from compiled descriptors", name)
-		}
-		methods[key] = &boundMethod{binding: binding, input: method.Input(), output: method.Output()}
-	}
-	return methods, nil
+	return protogen.CompileMethods(protoDir, "service.proto", gen.bindings, bindMethod)
 }
 
 // requireHTTPScheme rejects URLs that are not plain HTTP(S), so a spec can't make
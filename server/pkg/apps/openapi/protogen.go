@@ -822,10 +822,32 @@ func (g *generator) uniqueRPCName(base string) string {
 
 func (g *generator) render() string {
 	var b strings.Builder
+	g.renderMessages(&b)
+
+	for i, svc := range g.services {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "service %s {\n", svc.name)
+		for _, r := range svc.rpcs {
+			if r.summary != "" {
+				fmt.Fprintf(&b, "  // %s\n", strings.ReplaceAll(r.summary, "\n", " "))
+			}
+			fmt.Fprintf(&b, "  rpc %s(%s) returns (%s) {\n", r.name, r.input, r.output)
+			fmt.Fprintf(&b, "    option (kaja.http_request) = %q;\n", r.httpRequest)
+			b.WriteString("  }\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// renderMessages writes the head of the proto file and every generated message.
+func (g *generator) renderMessages(b *strings.Builder) {
 	b.WriteString("syntax = \"proto3\";\n\n")
-	fmt.Fprintf(&b, "package %s;\n\n", g.pkg)
-	// Every method carries (kaja.http_request), so the option file is always part
-	// of the generated surface.
+	fmt.Fprintf(b, "package %s;\n\n", g.pkg)
+	// Every method carries (kaja.http_request) and fields their marks, so the
+	// option file is always part of the generated surface.
 	b.WriteString("import \"kaja/http.proto\";\n")
 	if g.usesValue {
 		b.WriteString("import \"google/protobuf/struct.proto\";\n")
@@ -833,10 +855,10 @@ func (g *generator) render() string {
 	b.WriteString("\n")
 
 	for _, m := range g.messages {
-		fmt.Fprintf(&b, "message %s {\n", m.name)
+		fmt.Fprintf(b, "message %s {\n", m.name)
 		for _, f := range m.fields {
 			if f.doc != "" {
-				fmt.Fprintf(&b, "  // %s\n", f.doc)
+				fmt.Fprintf(b, "  // %s\n", f.doc)
 			}
 			prefix := ""
 			if f.repeated {
@@ -852,27 +874,10 @@ func (g *generator) render() string {
 			if f.required {
 				options += ", (kaja.http_required) = true"
 			}
-			fmt.Fprintf(&b, "  %s%s %s = %d [%s];\n", prefix, f.typ, f.name, f.number, options)
+			fmt.Fprintf(b, "  %s%s %s = %d [%s];\n", prefix, f.typ, f.name, f.number, options)
 		}
 		b.WriteString("}\n\n")
 	}
-
-	for i, svc := range g.services {
-		if i > 0 {
-			b.WriteString("\n")
-		}
-		fmt.Fprintf(&b, "service %s {\n", svc.name)
-		for _, r := range svc.rpcs {
-			if r.summary != "" {
-				fmt.Fprintf(&b, "  // %s\n", strings.ReplaceAll(r.summary, "\n", " "))
-			}
-			fmt.Fprintf(&b, "  rpc %s(%s) returns (%s) {\n", r.name, r.input, r.output)
-			fmt.Fprintf(&b, "    option (kaja.http_request) = %q;\n", r.httpRequest)
-			b.WriteString("  }\n")
-		}
-		b.WriteString("}\n")
-	}
-	return b.String()
 }
 
 // mergedParameters combines path-item-level and operation-level parameters,
@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Schemas generates protobuf messages from JSON Schemas, mapping them the way
+// the schemas of an OpenAPI document are. It is for apps whose documents
+// describe their values in JSON Schema too - an OpenRPC document, for one - so a
+// value has the same shape in kaja whichever kind of document described it.
+type Schemas struct {
+	g *generator
+}
+
+// SchemaField is one field of a message built by Schemas.
+type SchemaField struct {
+	// Name is the field's name in the JSON it travels in. The proto field name is
+	// derived from it.
+	Name string
+	// Schema is the JSON Schema of the field's value.
+	Schema json.RawMessage
+	// Type, when set, is the proto type the field has in place of a value of
+	// Schema: a message Schemas already made, or a scalar such as bytes.
+	Type string
+	// Repeated makes the field a list of Type.
+	Repeated bool
+	// Required is emitted as (kaja.http_required).
+	Required bool
+	// Doc describes the field. Empty takes the schema's own description.
+	Doc string
+}
+
+// NewSchemas starts the proto package pkg. named are the document's component
+// schemas, which a schema refers to as "#/components/schemas/<name>".
+func NewSchemas(pkg string, named map[string]json.RawMessage) (*Schemas, error) {
+	parsed := make(map[string]*schema, len(named))
+	for name, raw := range named {
+		s, err := parseSchema(raw)
+		if err != nil {
+			return nil, fmt.Errorf("schema %q: %w", name, err)
+		}
+		parsed[name] = s
+	}
+	return &Schemas{g: &generator{
+		spec:         &spec{Components: components{Schemas: parsed}},
+		pkg:          pkg,
+		seenMsg:      map[string]bool{},
+		refMsgName:   map[string]string{},
+		resolvingRef: map[string]bool{},
+	}}, nil
+}
+
+// Message adds a message with the given fields, in their order, and returns its
+// name: base as a proto identifier, made unique in the package.
+func (s *Schemas) Message(base string, fields []SchemaField) (string, error) {
+	name := s.g.uniqueMessageName(ensureName(pascal(base), "Message"))
+	message := &messageDef{name: name}
+	// Added before its fields are generated, so the names nested messages take
+	// from it come after its own.
+	s.g.addMessage(message)
+
+	used := map[string]bool{}
+	for i, field := range fields {
+		number := i + 1
+		typ, repeated := field.Type, field.Repeated
+		doc := field.Doc
+		if typ == "" {
+			parsed, err := parseSchema(field.Schema)
+			if err != nil {
+				return "", fmt.Errorf("field %q of %s: %w", field.Name, name, err)
+			}
+			typ, repeated = s.g.protoType(name, pascal(field.Name), parsed)
+			if doc == "" {
+				doc = s.g.description(parsed)
+			}
+		}
+		fieldName := ensureName(lowerSnake(field.Name), fmt.Sprintf("field%d", number))
+		if used[fieldName] {
+			fieldName = fmt.Sprintf("%s%d", fieldName, number)
+		}
+		used[fieldName] = true
+		message.fields = append(message.fields, fieldDef{
+			typ:      typ,
+			name:     fieldName,
+			number:   number,
+			jsonName: field.Name,
+			repeated: repeated,
+			required: field.Required,
+			doc:      docComment(doc),
+		})
+	}
+	return name, nil
+}
+
+// Object adds the message a JSON Schema of an object maps to, named after name,
+// and returns its name. It is "" when the schema maps to something else - a
+// scalar, a list, a map or any JSON value - which only a field of a message can
+// hold.
+func (s *Schemas) Object(name string, raw json.RawMessage) (string, error) {
+	parsed, err := parseSchema(raw)
+	if err != nil {
+		return "", err
+	}
+	typ, repeated := s.g.protoType("", ensureName(pascal(name), "Message"), parsed)
+	if repeated || !s.g.seenMsg[typ] {
+		return "", nil
+	}
+	return typ, nil
+}
+
+// Proto renders the package as a proto file: its messages, then services, the
+// service definitions the caller writes itself. The file imports kaja/http.proto,
+// which WriteHTTPProto lays out next to it.
+func (s *Schemas) Proto(services string) string {
+	var b strings.Builder
+	s.g.renderMessages(&b)
+	b.WriteString(services)
+	return b.String()
+}
+
+// parseSchema reads a JSON Schema. The boolean schemas of newer drafts, and an
+// absent one, admit any value.
+func parseSchema(raw json.RawMessage) (*schema, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '{' {
+		return &schema{}, nil
+	}
+	var s schema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
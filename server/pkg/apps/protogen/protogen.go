@@ -0,0 +1,138 @@
+// Package protogen is what the apps that generate a proto surface from another
+// description - a GraphQL schema, an OpenRPC or AsyncAPI document, a list of
+// endpoints, what an MCP server exposes - share: the names they give what they
+// describe, and compiling what they generated into the descriptors their calls
+// are made with.
+package protogen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/wham/protoc-go/protoc"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Identifier turns a name the described API chose - a tool called
+// `get_weather`, a field called `createUser`, an endpoint called `list-orders`
+// or a type called `Créer` - into a PascalCase proto identifier. A name with
+// nothing usable in it falls back to the given noun, and one that starts with a
+// digit is prefixed with it, so the surface still compiles.
+func Identifier(name, fallback string) string {
+	identifier := Pascal(name)
+	if identifier == "" {
+		return fallback
+	}
+	if r := rune(identifier[0]); r >= '0' && r <= '9' {
+		return fallback + identifier
+	}
+	return identifier
+}
+
+// Pascal splits a name on anything that isn't a letter or a digit, and on the
+// case boundaries inside each word, then joins the parts capitalized. A name
+// with nothing usable in it is empty.
+func Pascal(name string) string {
+	var out strings.Builder
+	for _, word := range splitWords(name) {
+		runes := []rune(word)
+		out.WriteRune(unicode.ToUpper(runes[0]))
+		out.WriteString(string(runes[1:]))
+	}
+	return out.String()
+}
+
+// FieldName is the snake_case field name for a property of the described API.
+// The value travels under the property's own name regardless, through the
+// field's json_name.
+func FieldName(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return "value"
+	}
+	lowered := make([]string, 0, len(words))
+	for _, word := range words {
+		lowered = append(lowered, strings.ToLower(word))
+	}
+	joined := strings.Join(lowered, "_")
+	if r := rune(joined[0]); r >= '0' && r <= '9' {
+		return "f_" + joined
+	}
+	return joined
+}
+
+// UniqueName claims base in used, or base numbered from 2 when it is taken, and
+// returns the name it claimed.
+func UniqueName(used map[string]bool, base string) string {
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+	runes := []rune(name)
+	upper := func(i int) bool { return i >= 0 && i < len(runes) && runes[i] >= 'A' && runes[i] <= 'Z' }
+	lower := func(i int) bool { return i >= 0 && i < len(runes) && runes[i] >= 'a' && runes[i] <= 'z' }
+	for i, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			current = append(current, r)
+		case r >= 'A' && r <= 'Z':
+			// A capital starts a word, except inside a run of them - and the last
+			// capital of a run belongs to the word that follows it, which is what
+			// splits "HTTPUrl" into HTTP and Url.
+			if !upper(i-1) || lower(i+1) {
+				flush()
+			}
+			current = append(current, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return words
+}
+
+// CompileMethods compiles file, generated into protoDir, and resolves the method
+// each binding is keyed by - its gRPC method path, "<package>.<Service>/<Method>"
+// - into what bind makes of the two, keyed the same.
+func CompileMethods[B, M any](protoDir, file string, bindings map[string]B, bind func(binding B, method protoreflect.MethodDescriptor) M) (map[string]M, error) {
+	// WithIncludeImports keeps imported descriptors (google/protobuf/struct.proto,
+	// pulled in when a field maps to google.protobuf.Value) in the set so
+	// protodesc.NewFiles can resolve them.
+	result, err := protoc.New(protoc.WithProtoPaths(protoDir), protoc.WithIncludeImports()).Compile(file)
+	if err != nil {
+		return nil, fmt.Errorf("compiling generated proto: %w", err)
+	}
+	files, err := protodesc.NewFiles(result.AsFileDescriptorSet())
+	if err != nil {
+		return nil, fmt.Errorf("building descriptors: %w", err)
+	}
+
+	methods := make(map[string]M, len(bindings))
+	for path, binding := range bindings {
+		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(strings.ReplaceAll(path, "/", ".")))
+		if err != nil {
+			return nil, fmt.Errorf("method %s missing from compiled descriptors", path)
+		}
+		method, ok := descriptor.(protoreflect.MethodDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a method", path)
+		}
+		methods[path] = bind(binding, method)
+	}
+	return methods, nil
+}
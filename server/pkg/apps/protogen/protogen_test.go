@@ -0,0 +1,40 @@
+package protogen
+
+import "testing"
+
+func TestIdentifier(t *testing.T) {
+	for name, want := range map[string]string{
+		"get_weather": "GetWeather",
+		"list-orders": "ListOrders",
+		"HTTPUrl":     "HTTPUrl",
+		"createUser":  "CreateUser",
+		"3d_render":   "Tool3dRender",
+		"¿?":          "Tool",
+	} {
+		if got := Identifier(name, "Tool"); got != want {
+			t.Errorf("Identifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	for name, want := range map[string]string{
+		"userId":   "user_id",
+		"HTTPUrl":  "http_url",
+		"2fa-code": "f_2fa_code",
+		"":         "value",
+	} {
+		if got := FieldName(name); got != want {
+			t.Errorf("FieldName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	used := map[string]bool{}
+	for _, want := range []string{"Request", "Request2", "Request3"} {
+		if got := UniqueName(used, "Request"); got != want {
+			t.Errorf("UniqueName = %q, want %q", got, want)
+		}
+	}
+}
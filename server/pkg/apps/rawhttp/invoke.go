@@ -0,0 +1,282 @@
+package rawhttp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/retry"
+)
+
+// instance is a live opened http app. It is a gRPC app: a call arrives as
+// protobuf, is made as the HTTP request it describes, and the response is shaped
+// into the Response message.
+type instance struct {
+	baseURL     string
+	methods     map[string]*boundMethod
+	credentials openapi.Credentials
+	client      *http.Client
+	retry       retry.Policy
+}
+
+// outgoing is the request a call makes, before it is joined to the base URL.
+type outgoing struct {
+	method      string
+	path        string
+	query       url.Values
+	headers     map[string]string
+	body        []byte
+	hasBody     bool
+	contentType string
+}
+
+func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	method := in.lookup(methodPath)
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %q", methodPath)
+	}
+
+	reqMsg := dynamicpb.NewMessage(method.input)
+	if len(request) > 0 {
+		if err := proto.Unmarshal(request, reqMsg); err != nil {
+			return nil, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	reqJSON, err := protojson.Marshal(reqMsg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request to JSON: %w", err)
+	}
+	req := map[string]json.RawMessage{}
+	if err := json.Unmarshal(reqJSON, &req); err != nil {
+		return nil, fmt.Errorf("decoding request: %w", err)
+	}
+
+	var out *outgoing
+	if method.binding.generic {
+		out, err = genericRequest(req)
+	} else {
+		out, err = method.binding.request(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	respJSON, reqHeaders, respHeaders, err := in.send(out, headers)
+	if err != nil {
+		return nil, err
+	}
+	respMsg := dynamicpb.NewMessage(method.output)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respJSON, respMsg); err != nil {
+		return nil, fmt.Errorf("decoding response JSON: %w", err)
+	}
+	body, err := proto.Marshal(respMsg)
+	if err != nil {
+		return nil, err
+	}
+	return &apps.InvokeResult{Body: body, RequestHeaders: reqHeaders, ResponseHeaders: respHeaders}, nil
+}
+
+// genericRequest reads the request a Request call describes.
+func genericRequest(req map[string]json.RawMessage) (*outgoing, error) {
+	out := &outgoing{query: url.Values{}, headers: map[string]string{}}
+	out.path = jsonString(req["path"])
+	if err := requireRelative(out.path); err != nil {
+		return nil, err
+	}
+	var query map[string]string
+	json.Unmarshal(req["query"], &query)
+	for name, value := range query {
+		out.query.Add(name, value)
+	}
+	json.Unmarshal(req["headers"], &out.headers)
+
+	set := 0
+	if raw, ok := req["json"]; ok {
+		out.body, out.hasBody, out.contentType = raw, true, "application/json"
+		set++
+	}
+	if raw, ok := req["text"]; ok {
+		out.body, out.hasBody, out.contentType = []byte(jsonString(raw)), true, "text/plain; charset=utf-8"
+		set++
+	}
+	if raw, ok := req["bytes"]; ok {
+		decoded, err := base64.StdEncoding.DecodeString(jsonString(raw))
+		if err != nil {
+			return nil, fmt.Errorf("decoding bytes: %w", err)
+		}
+		out.body, out.hasBody, out.contentType = decoded, true, "application/octet-stream"
+		set++
+	}
+	if set > 1 {
+		return nil, fmt.Errorf("set one of json, text and bytes as the body")
+	}
+
+	out.method = strings.ToUpper(strings.TrimSpace(jsonString(req["method"])))
+	switch {
+	case out.method == "" && out.hasBody:
+		out.method = http.MethodPost
+	case out.method == "":
+		out.method = http.MethodGet
+	case !methodPattern.MatchString(out.method):
+		return nil, fmt.Errorf("%q is not an HTTP method", out.method)
+	}
+	return out, nil
+}
+
+// request reads the request a call to an endpoint makes.
+func (b *binding) request(req map[string]json.RawMessage) (*outgoing, error) {
+	out := &outgoing{method: b.method, path: b.path, query: url.Values{}, headers: map[string]string{}}
+	for _, name := range b.pathParams {
+		value := jsonString(req[name])
+		if value == "" {
+			return nil, fmt.Errorf("%s is required: it is part of the path", name)
+		}
+		out.path = strings.ReplaceAll(out.path, "{"+name+"}", url.PathEscape(value))
+	}
+	for _, name := range b.queryParams {
+		if raw, ok := req[name]; ok {
+			out.query.Add(name, jsonString(raw))
+		}
+	}
+	for _, name := range b.headerParams {
+		if value := jsonString(req[name]); value != "" {
+			out.headers[name] = value
+		}
+	}
+	raw, ok := req["body"]
+	if !ok {
+		return out, nil
+	}
+	out.hasBody = true
+	switch b.body {
+	case bodyJSON:
+		out.body, out.contentType = raw, "application/json"
+	case bodyText:
+		out.body, out.contentType = []byte(jsonString(raw)), "text/plain; charset=utf-8"
+	case bodyBytes:
+		decoded, err := base64.StdEncoding.DecodeString(jsonString(raw))
+		if err != nil {
+			return nil, fmt.Errorf("decoding body: %w", err)
+		}
+		out.body, out.contentType = decoded, "application/octet-stream"
+	}
+	return out, nil
+}
+
+// send makes a request on the base URL and returns the proto3-JSON of its
+// Response, along with the request headers actually sent and the response
+// headers received.
+func (in *instance) send(out *outgoing, headers map[string]string) ([]byte, map[string]string, map[string]string, error) {
+	path := out.path
+	if path != "" && !strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "?") {
+		path = "/" + path
+	}
+	fullURL := in.baseURL + path
+	if len(out.query) > 0 {
+		separator := "?"
+		if strings.Contains(fullURL, "?") {
+			separator = "&"
+		}
+		fullURL += separator + out.query.Encode()
+	}
+
+	var body io.Reader
+	if out.hasBody {
+		body = bytes.NewReader(out.body)
+	}
+	httpReq, err := http.NewRequest(out.method, fullURL, body)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("building request: %w", err)
+	}
+	// Least specific first: the app's credential, then its configured headers,
+	// then the headers of this one call - so the more precise statement of what
+	// to send always wins.
+	in.credentials.Apply(httpReq)
+	for name, value := range headers {
+		httpReq.Header.Set(name, value)
+	}
+	for name, value := range out.headers {
+		httpReq.Header.Set(name, value)
+	}
+	if out.hasBody && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", out.contentType)
+	}
+	reqHeaders := apps.SurfaceHeaders(httpReq.Header)
+
+	resp, attempts, err := in.retry.Send(in.client.Do, httpReq)
+	reqHeaders = attempts.Record(reqHeaders)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("calling %s %s: %w", out.method, fullURL, err)
+	}
+	defer resp.Body.Close()
+	respHeaders := apps.SurfaceHeaders(resp.Header)
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, nil, nil, apps.NewUpstreamError(out.method, fullURL, resp.StatusCode, respBody).WithHeaders(reqHeaders, respHeaders)
+	}
+
+	respJSON := map[string]any{"status": resp.StatusCode, "headers": respHeaders}
+	if len(respBody) > 0 {
+		switch {
+		case isJSON(resp.Header.Get("Content-Type")) && json.Valid(respBody):
+			respJSON["json"] = json.RawMessage(respBody)
+		case utf8.Valid(respBody):
+			respJSON["text"] = string(respBody)
+		default:
+			respJSON["bytes"] = respBody
+		}
+	}
+	encoded, err := json.Marshal(respJSON)
+	return encoded, reqHeaders, respHeaders, err
+}
+
+// isJSON reports whether a content type is JSON, such as application/json or
+// application/problem+json.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// jsonString is a JSON string's value, or a scalar's JSON text.
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+// lookup finds a method by exact gRPC path, falling back to a match on the
+// method-name segment.
+func (in *instance) lookup(methodPath string) *boundMethod {
+	if m, ok := in.methods[methodPath]; ok {
+		return m
+	}
+	want := lastSegment(methodPath)
+	for path, m := range in.methods {
+		if lastSegment(path) == want {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package rawhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+)
+
+const (
+	protoPackage = "http"
+	serviceName  = "Http"
+)
+
+// binding records how a generated method maps onto an HTTP request. The Request
+// method's is generic: its request says everything.
+type binding struct {
+	generic      bool
+	method       string
+	path         string
+	pathParams   []string
+	queryParams  []string
+	headerParams []string
+	body         string
+}
+
+// generated is the output of generating the proto: the file text, the
+// package-qualified name of its service, and the per-method bindings keyed by
+// the gRPC method path "<serviceTypeName>/<MethodName>".
+type generated struct {
+	proto           string
+	serviceTypeName string
+	bindings        map[string]*binding
+}
+
+var (
+	anySchema    = json.RawMessage(`{}`)
+	stringSchema = json.RawMessage(`{"type": "string"}`)
+	statusSchema = json.RawMessage(`{"type": "integer"}`)
+	valuesSchema = json.RawMessage(`{"type": "object", "additionalProperties": {"type": "string"}}`)
+)
+
+// generateProto makes the proto file of an http app: a service with Request,
+// and a method for each of the app's endpoints.
+func generateProto(endpoints []*endpoint) (*generated, error) {
+	schemas, err := openapi.NewSchemas(protoPackage, nil)
+	if err != nil {
+		return nil, err
+	}
+	serviceTypeName := protoPackage + "." + serviceName
+
+	request, err := schemas.Message("Request", []openapi.SchemaField{
+		{Name: "method", Schema: stringSchema, Doc: "GET, POST, or any other; empty means GET, or POST when there is a body."},
+		{Name: "path", Schema: stringSchema, Required: true, Doc: "Joined to the app's base URL; it may carry a query string."},
+		{Name: "query", Schema: valuesSchema, Doc: "Added to the query string."},
+		{Name: "headers", Schema: valuesSchema, Doc: "Sent with the request, over the app's own."},
+		{Name: "json", Schema: anySchema, Doc: "A JSON body; set one of json, text and bytes."},
+		{Name: "text", Schema: stringSchema, Doc: "A text body."},
+		{Name: "bytes", Type: "bytes", Doc: "A body of any other content."},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := schemas.Message("Response", []openapi.SchemaField{
+		{Name: "status", Schema: statusSchema, Doc: "The status code."},
+		{Name: "headers", Schema: valuesSchema, Doc: "The response's headers; one sent more than once has its values comma-joined."},
+		{Name: "json", Schema: anySchema, Doc: "A body that is JSON."},
+		{Name: "text", Schema: stringSchema, Doc: "A body that is other text."},
+		{Name: "bytes", Type: "bytes", Doc: "A body that is not text."},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcs strings.Builder
+	used := map[string]bool{}
+	bindings := map[string]*binding{}
+	name := protogen.UniqueName(used, "Request")
+	writeRPC(&rpcs, name, request, response, "Sends a request to the app's base URL.")
+	bindings[serviceTypeName+"/"+name] = &binding{generic: true}
+
+	for _, e := range endpoints {
+		name := protogen.UniqueName(used, protogen.Identifier(e.Name, "Endpoint"))
+		b := &binding{
+			method:       e.Method,
+			path:         e.Path,
+			queryParams:  e.Query,
+			headerParams: e.Headers,
+			body:         e.Body,
+		}
+		var fields []openapi.SchemaField
+		for _, p := range pathParamPattern.FindAllStringSubmatch(e.Path, -1) {
+			b.pathParams = append(b.pathParams, p[1])
+			fields = append(fields, openapi.SchemaField{Name: p[1], Schema: stringSchema, Required: true})
+		}
+		for _, q := range e.Query {
+			fields = append(fields, openapi.SchemaField{Name: q, Schema: stringSchema})
+		}
+		for _, h := range e.Headers {
+			fields = append(fields, openapi.SchemaField{Name: h, Schema: stringSchema, Doc: "Sent as the " + h + " header."})
+		}
+		switch e.Body {
+		case bodyJSON:
+			fields = append(fields, openapi.SchemaField{Name: "body", Schema: anySchema})
+		case bodyText:
+			fields = append(fields, openapi.SchemaField{Name: "body", Schema: stringSchema})
+		case bodyBytes:
+			fields = append(fields, openapi.SchemaField{Name: "body", Type: "bytes"})
+		}
+		input, err := schemas.Message(name+"Request", fields)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", e.Name, err)
+		}
+
+		doc := strings.Join(strings.Fields(e.Description), " ")
+		if doc == "" {
+			doc = e.Method + " " + e.Path
+		}
+		writeRPC(&rpcs, name, input, response, doc)
+		bindings[serviceTypeName+"/"+name] = b
+	}
+
+	return &generated{
+		proto:           schemas.Proto("service " + serviceName + " {\n" + rpcs.String() + "}\n"),
+		serviceTypeName: serviceTypeName,
+		bindings:        bindings,
+	}, nil
+}
+
+func writeRPC(out *strings.Builder, name, request, response, doc string) {
+	if doc != "" {
+		fmt.Fprintf(out, "  // %s\n", doc)
+	}
+	fmt.Fprintf(out, "  rpc %s(%s) returns (%s);\n", name, request, response)
+}
@@ -0,0 +1,211 @@
+// Package rawhttp implements the built-in "http" app: an HTTP API with no
+// document to describe it, called on a base URL.
+//
+// Its service has Request, which sends any request - a method, a path joined to
+// the base URL, a query, headers and a body of JSON, text or bytes - and returns
+// the response's status, headers and body. Each endpoint the app names in
+// kaja.json is a method of its own, whose request is the endpoint's path and
+// query parameters, headers and body. Calls carry the credential kaja holds for
+// the app the way an openapi app's do; an error status is an apps.UpstreamError.
+package rawhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+	"github.com/wham/kaja/v2/pkg/retry"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// App is the http app factory. Register it with the apps.Manager.
+type App struct{}
+
+func New() *App { return &App{} }
+
+func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
+	baseURL := strings.TrimRight(strings.TrimSpace(parameters["base_url"]), "/")
+	if baseURL == "" {
+		return nil, fmt.Errorf("base_url is required")
+	}
+	if err := requireHTTPScheme(baseURL); err != nil {
+		return nil, err
+	}
+	policy := retry.Parse(parameters)
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	endpoints, err := readEndpoints(parameters["endpoints"])
+	if err != nil {
+		return nil, err
+	}
+	credentials := openapi.NewCredentials(parameters)
+	log("Base URL: " + baseURL)
+	if described := credentials.Describe(); described != "" {
+		log("Authentication: " + described)
+	}
+
+	gen, err := generateProto(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(protoDir, "http.proto"), []byte(gen.proto), 0o644); err != nil {
+		return nil, fmt.Errorf("writing proto: %w", err)
+	}
+	if err := openapi.WriteHTTPProto(protoDir); err != nil {
+		return nil, err
+	}
+	log(fmt.Sprintf("Generated %s with %d method(s)", gen.serviceTypeName, len(gen.bindings)))
+
+	methods, err := protogen.CompileMethods(protoDir, "http.proto", gen.bindings, bindMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apps.Opened{Instance: &instance{
+		baseURL:     baseURL,
+		methods:     methods,
+		credentials: credentials,
+		client:      &http.Client{Timeout: 60 * time.Second},
+		retry:       policy,
+	}}, nil
+}
+
+// endpoint is one of the endpoints an http app names in kaja.json.
+type endpoint struct {
+	Name        string   `json:"name"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Query       []string `json:"query"`
+	Headers     []string `json:"headers"`
+	Body        string   `json:"body"`
+	Description string   `json:"description"`
+}
+
+// What an endpoint's body is.
+const (
+	bodyNone  = "none"
+	bodyJSON  = "json"
+	bodyText  = "text"
+	bodyBytes = "bytes"
+)
+
+var (
+	methodPattern    = regexp.MustCompile(`^[A-Z]+$`)
+	pathParamPattern = regexp.MustCompile(`\{([^{}/]+)\}`)
+)
+
+// readEndpoints reads the endpoints parameter, the JSON array of the app's
+// endpoints, and checks each says what it is. Left out, an endpoint's method is
+// GET, and its body JSON when its method is one that has a body.
+func readEndpoints(value string) ([]*endpoint, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var endpoints []*endpoint
+	if err := json.Unmarshal([]byte(value), &endpoints); err != nil {
+		return nil, fmt.Errorf("reading endpoints: %w", err)
+	}
+	for i, e := range endpoints {
+		e.Name = strings.TrimSpace(e.Name)
+		if e.Name == "" {
+			return nil, fmt.Errorf("endpoint %d has no name", i+1)
+		}
+		e.Method = strings.ToUpper(strings.TrimSpace(e.Method))
+		if e.Method == "" {
+			e.Method = http.MethodGet
+		}
+		if !methodPattern.MatchString(e.Method) {
+			return nil, fmt.Errorf("endpoint %s: %q is not an HTTP method", e.Name, e.Method)
+		}
+		if err := requireRelative(e.Path); err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", e.Name, err)
+		}
+		e.Body = strings.ToLower(strings.TrimSpace(e.Body))
+		switch e.Body {
+		case "":
+			e.Body = bodyNone
+			if e.Method == http.MethodPost || e.Method == http.MethodPut || e.Method == http.MethodPatch {
+				e.Body = bodyJSON
+			}
+		case bodyNone, bodyJSON, bodyText, bodyBytes:
+		default:
+			return nil, fmt.Errorf("endpoint %s: body is %q; use json, text, bytes or none", e.Name, e.Body)
+		}
+
+		named := map[string]string{}
+		for _, p := range pathParamPattern.FindAllStringSubmatch(e.Path, -1) {
+			named[p[1]] = "path"
+		}
+		if e.Body != bodyNone {
+			named["body"] = "body"
+		}
+		for _, list := range []struct {
+			kind  string
+			names []string
+		}{{"query", e.Query}, {"header", e.Headers}} {
+			for _, name := range list.names {
+				if kind, ok := named[name]; ok {
+					return nil, fmt.Errorf("endpoint %s: %q is both a %s and a %s parameter", e.Name, name, kind, list.kind)
+				}
+				named[name] = list.kind
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// boundMethod is one generated method: the request it makes, and the
+// descriptors its request and response are decoded and encoded with.
+type boundMethod struct {
+	binding *binding
+	input   protoreflect.MessageDescriptor
+	output  protoreflect.MessageDescriptor
+}
+
+// bindMethod pairs a binding with the compiled method it is keyed by.
+func bindMethod(binding *binding, method protoreflect.MethodDescriptor) *boundMethod {
+	return &boundMethod{binding: binding, input: method.Input(), output: method.Output()}
+}
+
+// requireHTTPScheme rejects URLs that are not plain HTTP(S), so a configuration
+// can't make the app issue requests over other schemes.
+func requireHTTPScheme(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q in %q (only http and https are allowed)", u.Scheme, rawURL)
+	}
+	return nil
+}
+
+// requireRelative rejects a path that names a host of its own, so the app's
+// credential only ever goes to its base URL.
+func requireRelative(path string) error {
+	u, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	if u.Scheme != "" || u.Host != "" || strings.HasPrefix(path, "//") {
+		return fmt.Errorf("the path %q is a URL of its own; it is joined to the base URL", path)
+	}
+	return nil
+}
+
+func lastSegment(s string) string {
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,193 @@
+package rawhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/appstest"
+)
+
+// newEcho is a server that answers with what it got as JSON - except at
+// /binary, which answers bytes, and /missing, which is not found.
+func newEcho(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/binary":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte{0xff, 0x00, 0xfe})
+			return
+		case "/missing":
+			http.Error(w, "no such thing", http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Served-By", "echo")
+		json.NewEncoder(w).Encode(map[string]any{
+			"method":        r.Method,
+			"path":          r.URL.Path,
+			"query":         r.URL.Query(),
+			"authorization": r.Header.Get("Authorization"),
+			"tenant":        r.Header.Get("X-Tenant"),
+			"contentType":   r.Header.Get("Content-Type"),
+			"body":          string(body),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func open(t *testing.T, parameters map[string]string) (*instance, string) {
+	t.Helper()
+	protoDir := t.TempDir()
+	opened, err := New().Open(parameters, protoDir, func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(protoDir, "http.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opened.Instance.(*instance), string(generated)
+}
+
+// call runs Request or one of the endpoints' methods, by name, with headers
+// sent along.
+func call(in *instance, method, requestJSON string, headers map[string]string) (map[string]any, error) {
+	path := "http.Http/" + method
+	bound := in.methods[path]
+	return appstest.Call(in, path, bound.input, bound.output, requestJSON, headers)
+}
+
+func invoke(t *testing.T, in *instance, method, requestJSON string) map[string]any {
+	t.Helper()
+	resp, err := call(in, method, requestJSON, nil)
+	if err != nil {
+		t.Fatalf("Invoke %s %s: %v", method, requestJSON, err)
+	}
+	return resp
+}
+
+// echoed is what the echo server said it got.
+func echoed(t *testing.T, resp map[string]any) map[string]any {
+	t.Helper()
+	got, ok := resp["json"].(map[string]any)
+	if !ok {
+		t.Fatalf("response %v has no JSON body", resp)
+	}
+	return got
+}
+
+func TestRequest(t *testing.T) {
+	in, generated := open(t, map[string]string{"base_url": newEcho(t) + "/", "token": "secret"})
+	if !strings.Contains(generated, "rpc Request(Request) returns (Response);") {
+		t.Errorf("generated proto is missing Request:\n%s", generated)
+	}
+
+	resp, err := call(in, "Request", `{"path": "things?x=1", "query": {"y": "2"}, "headers": {"X-Tenant": "acme"}}`, map[string]string{"X-Tenant": "configured"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := echoed(t, resp)
+	if got["method"] != "GET" || got["path"] != "/things" || got["authorization"] != "Bearer secret" || got["tenant"] != "acme" {
+		t.Errorf("server got %v", got)
+	}
+	if query, _ := json.Marshal(got["query"]); string(query) != `{"x":["1"],"y":["2"]}` {
+		t.Errorf("query = %s", query)
+	}
+	if resp["status"] != float64(200) || resp["headers"].(map[string]any)["X-Served-By"] != "echo" {
+		t.Errorf("response = %v", resp)
+	}
+
+	got = echoed(t, invoke(t, in, "Request", `{"path": "/notes", "text": "hello"}`))
+	if got["method"] != "POST" || got["body"] != "hello" || got["contentType"] != "text/plain; charset=utf-8" {
+		t.Errorf("text body: server got %v", got)
+	}
+	got = echoed(t, invoke(t, in, "Request", `{"method": "put", "path": "/notes/1", "json": {"done": true}, "headers": {"Content-Type": "application/merge-patch+json"}}`))
+	if got["method"] != "PUT" || got["body"] != `{"done":true}` || got["contentType"] != "application/merge-patch+json" {
+		t.Errorf("JSON body: server got %v", got)
+	}
+
+	if resp := invoke(t, in, "Request", `{"path": "/binary"}`); resp["bytes"] != "/wD+" {
+		t.Errorf("binary response = %v", resp)
+	}
+
+	_, err = call(in, "Request", `{"path": "/missing"}`, nil)
+	var upstream *apps.UpstreamError
+	if !errors.As(err, &upstream) || upstream.Status != http.StatusNotFound || !strings.Contains(string(upstream.Body), "no such thing") {
+		t.Errorf("missing = %v, want the 404", err)
+	}
+	if _, err := call(in, "Request", `{"path": "https://elsewhere.example.com/steal"}`, nil); err == nil {
+		t.Error("a path that is a URL of its own was sent")
+	}
+}
+
+func TestBasicAuth(t *testing.T) {
+	in, _ := open(t, map[string]string{"base_url": newEcho(t), "username": "ada", "password": "lovelace"})
+	got := echoed(t, invoke(t, in, "Request", `{"path": "/"}`))
+	if got["authorization"] != "Basic YWRhOmxvdmVsYWNl" {
+		t.Errorf("Authorization = %v", got["authorization"])
+	}
+}
+
+const endpoints = `[
+  {"name": "get_user", "path": "/users/{id}", "query": ["verbose"], "headers": ["X-Tenant"], "description": "Fetch a user."},
+  {"name": "CreateUser", "method": "post", "path": "/users"},
+  {"name": "upload", "method": "PUT", "path": "/files/{name}", "body": "bytes"}
+]`
+
+func TestEndpoints(t *testing.T) {
+	in, generated := open(t, map[string]string{"base_url": newEcho(t), "endpoints": endpoints})
+	for _, rpc := range []string{
+		"// Fetch a user.\n  rpc GetUser(GetUserRequest) returns (Response);",
+		"// POST /users\n  rpc CreateUser(CreateUserRequest) returns (Response);",
+		"rpc Upload(UploadRequest) returns (Response);",
+	} {
+		if !strings.Contains(generated, rpc) {
+			t.Errorf("generated proto is missing %q:\n%s", rpc, generated)
+		}
+	}
+
+	got := echoed(t, invoke(t, in, "GetUser", `{"id": "a/b", "verbose": "true", "X-Tenant": "acme"}`))
+	if got["method"] != "GET" || got["path"] != "/users/a/b" || got["tenant"] != "acme" {
+		t.Errorf("GetUser: server got %v", got)
+	}
+	if query, _ := json.Marshal(got["query"]); string(query) != `{"verbose":["true"]}` {
+		t.Errorf("GetUser query = %s", query)
+	}
+	got = echoed(t, invoke(t, in, "CreateUser", `{"body": {"name": "Ada"}}`))
+	if got["method"] != "POST" || got["body"] != `{"name":"Ada"}` || got["contentType"] != "application/json" {
+		t.Errorf("CreateUser: server got %v", got)
+	}
+	got = echoed(t, invoke(t, in, "Upload", `{"name": "notes.txt", "body": "aGk="}`))
+	if got["method"] != "PUT" || got["path"] != "/files/notes.txt" || got["body"] != "hi" || got["contentType"] != "application/octet-stream" {
+		t.Errorf("Upload: server got %v", got)
+	}
+	if _, err := call(in, "GetUser", `{}`, nil); err == nil || !strings.Contains(err.Error(), "id is required") {
+		t.Errorf("GetUser without its id = %v", err)
+	}
+}
+
+func TestInvalidEndpoints(t *testing.T) {
+	for _, tc := range []struct{ endpoints, want string }{
+		{`[{"path": "/users"}]`, "has no name"},
+		{`[{"name": "a", "path": "https://elsewhere.example.com/"}]`, "URL of its own"},
+		{`[{"name": "a", "path": "/", "body": "xml"}]`, "use json, text, bytes or none"},
+		{`[{"name": "a", "path": "/{id}", "query": ["id"]}]`, `"id" is both a path and a query parameter`},
+		{`[{"name": "a", "method": "GET /", "path": "/"}]`, "not an HTTP method"},
+	} {
+		_, err := New().Open(map[string]string{"base_url": "http://localhost", "endpoints": tc.endpoints}, t.TempDir(), func(string) {})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Open with %s = %v, want %q", tc.endpoints, err, tc.want)
+		}
+	}
+}
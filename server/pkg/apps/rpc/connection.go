@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"encoding/base64"
+	"strconv"
 	"strings"
 
 	"github.com/wham/kaja/v2/internal/workspace"
@@ -66,3 +67,44 @@ func TLS(parameters map[string]string) grpc.TLSOptions {
 		KeyFile:    workspace.Resolve(strings.TrimSpace(parameters["client_key_file"])),
 	}
 }
+
+// Calls reads how each call is made off an app's parameters: the protocol it is
+// spoken in, the compression its requests use and the message sizes it allows. A size that isn't a
+// positive number leaves grpc-go's own limit in place.
+func Calls(parameters map[string]string) grpc.CallOptions {
+	return grpc.CallOptions{
+		Protocol:        strings.ToLower(strings.TrimSpace(parameters["protocol"])),
+		Compression:     strings.ToLower(strings.TrimSpace(parameters["compression"])),
+		MaxReceiveBytes: byteLimit(parameters["max_receive_bytes"]),
+		MaxSendBytes:    byteLimit(parameters["max_send_bytes"]),
+	}
+}
+
+func byteLimit(value string) int {
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// Endpoints reads where an app's calls may go: its url, then each further
+// replica in the order configured, with blanks and repeats dropped.
+func Endpoints(parameters map[string]string) []string {
+	endpoints := []string{}
+	seen := map[string]bool{}
+	for _, endpoint := range append([]string{parameters["url"]}, strings.Split(parameters["endpoints"], ",")...) {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" || seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+// LoadBalancing reads the policy for the addresses one endpoint resolves to.
+func LoadBalancing(parameters map[string]string) string {
+	return strings.ToLower(strings.TrimSpace(parameters["load_balancing"]))
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/wham/kaja/v2/pkg/grpc"
@@ -57,3 +58,42 @@ func TestTLS(t *testing.T) {
 		t.Errorf("CAFile = %q, want the absolute path left alone", options.CAFile)
 	}
 }
+
+func TestCalls(t *testing.T) {
+	options := Calls(map[string]string{"compression": " GZIP ", "max_receive_bytes": "16777216", "max_send_bytes": "1024"})
+	if options.Compression != grpc.CompressionGzip {
+		t.Errorf("Compression = %q, want %q", options.Compression, grpc.CompressionGzip)
+	}
+	if options.MaxReceiveBytes != 16<<20 || options.MaxSendBytes != 1024 {
+		t.Errorf("sizes = %d/%d, want 16777216/1024", options.MaxReceiveBytes, options.MaxSendBytes)
+	}
+
+	// A size that isn't a count of bytes leaves grpc-go's limit where it is.
+	options = Calls(map[string]string{"max_receive_bytes": "16MB", "max_send_bytes": "-1"})
+	if options.MaxReceiveBytes != 0 || options.MaxSendBytes != 0 {
+		t.Errorf("sizes = %d/%d, want both left alone", options.MaxReceiveBytes, options.MaxSendBytes)
+	}
+
+	options = Calls(map[string]string{"protocol": " Connect "})
+	if options.Protocol != grpc.ProtocolConnect || options.Native() {
+		t.Errorf("Protocol = %q, want %q spoken over HTTP", options.Protocol, grpc.ProtocolConnect)
+	}
+
+	if options := Calls(map[string]string{}); options != (grpc.CallOptions{}) {
+		t.Errorf("an app that says nothing = %+v, want the zero options", options)
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	endpoints := Endpoints(map[string]string{"url": "dns:seating-1:9000", "endpoints": " dns:seating-2:9000,,dns:seating-1:9000, dns:seating-3:9000"})
+	want := []string{"dns:seating-1:9000", "dns:seating-2:9000", "dns:seating-3:9000"}
+	if !slices.Equal(endpoints, want) {
+		t.Errorf("Endpoints() = %v, want %v", endpoints, want)
+	}
+	if endpoints := Endpoints(map[string]string{"url": "dns:seating:9000"}); !slices.Equal(endpoints, []string{"dns:seating:9000"}) {
+		t.Errorf("Endpoints() = %v, want the url alone", endpoints)
+	}
+	if policy := LoadBalancing(map[string]string{"load_balancing": " Round_Robin "}); policy != grpc.LoadBalancingRoundRobin {
+		t.Errorf("LoadBalancing() = %q, want %q", policy, grpc.LoadBalancingRoundRobin)
+	}
+}
@@ -82,6 +82,13 @@ const (
 // a server that guards reflection wants them before it will say anything.
 func Inspect(parameters map[string]string, log func(string)) (*Server, *Problem) {
 	if strings.TrimSpace(parameters["reflection"]) == "true" {
+		if !Calls(parameters).Native() {
+			return nil, &Problem{
+				Kind:    ProblemNoReflection,
+				Message: "Reflection is only served over native gRPC.",
+				Detail:  "gRPC-Web and Connect apps are described by their proto files.",
+			}
+		}
 		return inspectReflection(parameters, log)
 	}
 	return inspectProtoDir(parameters, log)
@@ -168,7 +175,9 @@ func inspectProtoDir(parameters map[string]string, log func(string)) (*Server, *
 	// whether it is there, and over which transport, because that is the other
 	// half of what the form has to fill in. Nothing here is fatal - an app can be
 	// configured before the service it calls is running.
-	if target, problem := parseTarget(parameters["url"]); problem == nil {
+	// The probe is a reflection call, which only native gRPC carries, so an app
+	// spoken over HTTP is taken as configured.
+	if target, problem := parseTarget(parameters["url"]); problem == nil && Calls(parameters).Native() {
 		if usedTLS, reachable := probe(target, TLS(parameters), Metadata(parameters), log); reachable {
 			server.Target = grpc.ToGRPCTarget(target)
 			server.TLS = usedTLS
@@ -32,10 +32,23 @@ func (a *App) Open(parameters map[string]string, protoDir string, log func(strin
 	}
 	log(strings.ToUpper(a.protocol) + " target: " + url)
 
+	if a.protocol == "twirp" {
+		encoding, err := Encoding(parameters)
+		if err != nil {
+			return nil, err
+		}
+		if encoding != EncodingProtobuf {
+			log("Encoding: " + encoding)
+		}
+	}
+
 	if strings.TrimSpace(parameters["reflection"]) == "true" {
 		if a.protocol != "grpc" {
 			return nil, fmt.Errorf("reflection is only supported for grpc apps")
 		}
+		if !Calls(parameters).Native() {
+			return nil, fmt.Errorf("reflection is only served over native gRPC; set %q for a %s app", "proto_dir", parameters["protocol"])
+		}
 		if err := reflect(url, TLS(parameters), Metadata(parameters), protoDir, log); err != nil {
 			return nil, err
 		}
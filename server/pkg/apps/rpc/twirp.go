@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/mcp"
+)
+
+// Encodings a twirp app can speak to its upstream. Protobuf is what kaja has
+// always sent; JSON is for a service that turned protobuf off, or for reading
+// the exchange as it went over the wire.
+const (
+	EncodingProtobuf = "protobuf"
+	EncodingJSON     = "json"
+)
+
+// Encoding is the app's "encoding" parameter, defaulting to protobuf.
+func Encoding(parameters map[string]string) (string, error) {
+	switch encoding := strings.ToLower(strings.TrimSpace(parameters["encoding"])); encoding {
+	case "", EncodingProtobuf:
+		return EncodingProtobuf, nil
+	case EncodingJSON:
+		return EncodingJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q (use %q or %q)", encoding, EncodingProtobuf, EncodingJSON)
+	}
+}
+
+// twirpCodes are the codes a Twirp error may carry. A body that names any other
+// is no Twirp error.
+var twirpCodes = []string{
+	"canceled", "unknown", "invalid_argument", "malformed", "deadline_exceeded",
+	"not_found", "bad_route", "already_exists", "permission_denied", "unauthenticated",
+	"resource_exhausted", "failed_precondition", "aborted", "out_of_range",
+	"unimplemented", "internal", "unavailable", "dataloss",
+}
+
+// TwirpError is a failed Twirp call as Twirp's error model has it: a code from a
+// fixed set, a message, and meta - string pairs the service attached for the
+// caller, such as which argument it refused.
+type TwirpError struct {
+	Code string
+	Msg  string
+	Meta map[string]string
+}
+
+// ParseTwirpError reads a failed response's body as a Twirp error. A body that
+// isn't one - a load balancer's 502 page - is read the way Twirp's own clients
+// read it: an error from an intermediary, its code taken from the HTTP status,
+// with the status and the body kept in meta.
+func ParseTwirpError(status int, body []byte) *TwirpError {
+	var envelope struct {
+		Code string         `json:"code"`
+		Msg  string         `json:"msg"`
+		Meta map[string]any `json:"meta"`
+	}
+	if json.Unmarshal(body, &envelope) == nil && slices.Contains(twirpCodes, envelope.Code) {
+		failure := &TwirpError{Code: envelope.Code, Msg: envelope.Msg}
+		for key, value := range envelope.Meta {
+			if failure.Meta == nil {
+				failure.Meta = map[string]string{}
+			}
+			if text, ok := value.(string); ok {
+				failure.Meta[key] = text
+			} else {
+				encoded, _ := json.Marshal(value)
+				failure.Meta[key] = string(encoded)
+			}
+		}
+		return failure
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > upstreamBodyLimit {
+		trimmed = trimmed[:upstreamBodyLimit]
+	}
+	return &TwirpError{
+		Code: intermediaryCode(status),
+		Msg:  fmt.Sprintf("Error from intermediary with HTTP status code %d %q", status, http.StatusText(status)),
+		Meta: map[string]string{
+			"http_error_from_intermediary": "true",
+			"status_code":                  strconv.Itoa(status),
+			"body":                         string(trimmed),
+		},
+	}
+}
+
+const upstreamBodyLimit = 4000
+
+// intermediaryCode is the code Twirp's clients give a failure that came back
+// without a Twirp error body.
+func intermediaryCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "internal"
+	case http.StatusUnauthorized:
+		return "unauthenticated"
+	case http.StatusForbidden:
+		return "permission_denied"
+	case http.StatusNotFound:
+		return "bad_route"
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return "unavailable"
+	}
+	return "unknown"
+}
+
+func (e *TwirpError) Error() string {
+	return "twirp error " + e.Code + ": " + e.Msg
+}
+
+// Failure is the error as a run reports it, its code read as the kind of
+// failure it is.
+func (e *TwirpError) Failure() mcp.CallFailure {
+	return mcp.CallFailure{Kind: mcp.CodeKind(e.Code), Message: e.Msg, Code: e.Code, Meta: e.Meta}
+}
+
+// JSON is the error as a Twirp error body, which every Twirp client reads, with
+// its kind beside the code for a caller that has no table of codes.
+func (e *TwirpError) JSON() []byte {
+	meta := e.Meta
+	if meta == nil {
+		meta = map[string]string{}
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"code": e.Code,
+		"msg":  e.Msg,
+		"meta": meta,
+		"kind": mcp.CodeKind(e.Code),
+	})
+	return payload
+}
+
+// RewriteTwirpError replaces a failed response's body with its error read as a
+// TwirpError, so a proxied call fails the same way whether the service or
+// something in front of it refused it. A successful response, or one encoded
+// in a way this can't read, is left as it is.
+func RewriteTwirpError(response *http.Response) error {
+	if response.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	var reader io.Reader = response.Body
+	switch encoding := strings.ToLower(response.Header.Get("Content-Encoding")); encoding {
+	case "", "identity":
+	case "gzip":
+		unzipped, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil
+		}
+		reader = unzipped
+	default:
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(reader, 1<<20))
+	response.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	rewritten := ParseTwirpError(response.StatusCode, body).JSON()
+	response.Body = io.NopCloser(bytes.NewReader(rewritten))
+	response.ContentLength = int64(len(rewritten))
+	response.Header.Set("Content-Type", "application/json")
+	response.Header.Set("Content-Length", strconv.Itoa(len(rewritten)))
+	response.Header.Del("Content-Encoding")
+	return nil
+}
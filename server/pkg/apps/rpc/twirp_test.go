@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	for value, want := range map[string]string{"": EncodingProtobuf, "protobuf": EncodingProtobuf, " JSON ": EncodingJSON} {
+		if got, err := Encoding(map[string]string{"encoding": value}); err != nil || got != want {
+			t.Errorf("Encoding(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := Encoding(map[string]string{"encoding": "xml"}); err == nil {
+		t.Error("Encoding() accepted xml")
+	}
+}
+
+func TestParseTwirpError(t *testing.T) {
+	failure := ParseTwirpError(http.StatusBadRequest, []byte(`{"code":"invalid_argument","msg":"seat is required","meta":{"argument":"seat","retryable":false}}`))
+	if failure.Code != "invalid_argument" || failure.Msg != "seat is required" {
+		t.Errorf("failure = %+v, want the service's error", failure)
+	}
+	if failure.Meta["argument"] != "seat" || failure.Meta["retryable"] != "false" {
+		t.Errorf("meta = %v, want every pair kept as a string", failure.Meta)
+	}
+	if got := failure.Failure(); got.Kind != "INVALID_REQUEST" || got.Meta["argument"] != "seat" {
+		t.Errorf("Failure() = %+v, want INVALID_REQUEST with the meta", got)
+	}
+
+	// What came back from something in front of the service is read the way
+	// Twirp's own clients read it.
+	failure = ParseTwirpError(http.StatusServiceUnavailable, []byte("<html>upstream connect error</html>"))
+	if failure.Code != "unavailable" || failure.Meta["http_error_from_intermediary"] != "true" || failure.Meta["status_code"] != "503" {
+		t.Errorf("failure = %+v, want an intermediary's unavailable", failure)
+	}
+	if !strings.Contains(failure.Meta["body"], "upstream connect error") {
+		t.Errorf("body = %q, want the page kept", failure.Meta["body"])
+	}
+
+	// A code Twirp doesn't have is not a Twirp error.
+	if failure := ParseTwirpError(http.StatusNotFound, []byte(`{"code":"nope","msg":"x"}`)); failure.Code != "bad_route" {
+		t.Errorf("code = %q, want bad_route from the status", failure.Code)
+	}
+}
+
+func TestRewriteTwirpError(t *testing.T) {
+	var zipped bytes.Buffer
+	writer := gzip.NewWriter(&zipped)
+	writer.Write([]byte(`{"code":"not_found","msg":"no such seat","meta":{"seat":"14C"}}`))
+	writer.Close()
+
+	response := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"Content-Encoding": {"gzip"}},
+		Body:       io.NopCloser(&zipped),
+	}
+	if err := RewriteTwirpError(response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Header.Get("Content-Encoding") != "" {
+		t.Error("the rewritten body is still marked gzip")
+	}
+	var envelope map[string]any
+	body, _ := io.ReadAll(response.Body)
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope["code"] != "not_found" || envelope["kind"] != "NOT_FOUND" || envelope["meta"].(map[string]any)["seat"] != "14C" {
+		t.Errorf("body = %s, want the error with its kind and meta", body)
+	}
+
+	// A call that succeeded is left alone.
+	ok := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("\x0a\x01x"))}
+	if err := RewriteTwirpError(ok); err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := io.ReadAll(ok.Body); string(body) != "\x0a\x01x" {
+		t.Errorf("body = %q, want it untouched", body)
+	}
+}
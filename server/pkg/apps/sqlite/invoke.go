@@ -0,0 +1,387 @@
+package sqlite
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// defaultLimit bounds a query that sets no limit.
+const defaultLimit = 1000
+
+// instance is a live opened sqlite app. A call's request is decoded into a plain
+// struct through its JSON, and the response is built as JSON and decoded into
+// the method's output, which is what lets rows be google.protobuf.Struct without
+// building one field at a time.
+type instance struct {
+	path     string
+	readOnly bool
+	methods  map[string]protoreflect.MethodDescriptor
+}
+
+// request is every field any method takes; each reads the ones its own takes.
+type request struct {
+	SQL    string `json:"sql"`
+	Params []any  `json:"params"`
+	Limit  int    `json:"limit"`
+	Table  string `json:"table"`
+}
+
+func (in *instance) Invoke(methodPath string, body []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	name := lastSegment(methodPath)
+	method := in.methods[name]
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %q", methodPath)
+	}
+
+	req, err := decodeRequest(method, body)
+	if err != nil {
+		return nil, err
+	}
+	db, err := in.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var result any
+	switch name {
+	case "Query":
+		result, err = in.query(db, req)
+	case "Exec":
+		result, err = in.exec(db, req)
+	case "ListTables":
+		result, err = listTables(db)
+	case "DescribeTable":
+		result, err = describeTable(db, req.Table)
+	default:
+		return nil, fmt.Errorf("unhandled method %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("encoding response: %w", err)
+	}
+	resp := dynamicpb.NewMessage(method.Output())
+	if err := protojson.Unmarshal(encoded, resp); err != nil {
+		return nil, fmt.Errorf("encoding response: %w", err)
+	}
+	// The sqlite app is local: it makes no upstream call, so it surfaces no
+	// upstream headers.
+	out, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return &apps.InvokeResult{Body: out}, nil
+}
+
+// open opens the database for one call. The busy timeout has a call wait out
+// another process's write rather than fail on it; foreign keys are enforced, as
+// a script that declares them expects. One connection is all a call needs, and
+// it keeps what a call changes in one place for the rest of the call to see.
+func (in *instance) open() (*sql.DB, error) {
+	dsn := "file:" + (&url.URL{Path: filepath.ToSlash(in.path)}).EscapedPath() + "?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)"
+	if in.readOnly {
+		dsn += "&mode=ro&_pragma=query_only(1)"
+	}
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", in.path, err)
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+func (in *instance) query(db *sql.DB, req request) (any, error) {
+	if strings.TrimSpace(req.SQL) == "" {
+		return nil, fmt.Errorf("missing sql")
+	}
+	args, err := bind(req.Params)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	rows, err := db.Query(req.SQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("running query: %w", err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("running query: %w", err)
+	}
+
+	result := []map[string]any{}
+	truncated := false
+	cells := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range cells {
+		pointers[i] = &cells[i]
+	}
+	for rows.Next() {
+		if len(result) >= limit {
+			truncated = true
+			break
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("reading row: %w", err)
+		}
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			row[column] = jsonCell(cells[i])
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("running query: %w", err)
+	}
+	return map[string]any{"columns": columns, "rows": result, "truncated": truncated}, nil
+}
+
+func (in *instance) exec(db *sql.DB, req request) (any, error) {
+	if in.readOnly {
+		return nil, fmt.Errorf("%s is open read-only, so Exec is refused", in.path)
+	}
+	if strings.TrimSpace(req.SQL) == "" {
+		return nil, fmt.Errorf("missing sql")
+	}
+	args, err := bind(req.Params)
+	if err != nil {
+		return nil, err
+	}
+	result, err := db.Exec(req.SQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("running statement: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	lastID, _ := result.LastInsertId()
+	return map[string]any{"rows_affected": affected, "last_insert_id": lastID}, nil
+}
+
+func listTables(db *sql.DB) (any, error) {
+	rows, err := db.Query(`SELECT name, type FROM sqlite_schema WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite\_%' ESCAPE '\' ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	defer rows.Close()
+	tables, views := []string{}, []string{}
+	for rows.Next() {
+		var name, kind string
+		if err := rows.Scan(&name, &kind); err != nil {
+			return nil, fmt.Errorf("listing tables: %w", err)
+		}
+		if kind == "view" {
+			views = append(views, name)
+		} else {
+			tables = append(tables, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	return map[string]any{"tables": tables, "views": views}, nil
+}
+
+// describeTable reads a table's schema through SQLite's pragma functions. The
+// connection is the only one there is, so each query's rows are read to the end
+// before the next begins.
+func describeTable(db *sql.DB, table string) (any, error) {
+	if strings.TrimSpace(table) == "" {
+		return nil, fmt.Errorf("missing table")
+	}
+	var kind string
+	var createSQL sql.NullString
+	err := db.QueryRow(`SELECT type, sql FROM sqlite_schema WHERE name = ? AND type IN ('table', 'view')`, table).Scan(&kind, &createSQL)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("no table or view named %q (list them with ListTables)", table)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("describing %s: %w", table, err)
+	}
+
+	columns, err := collect(db, `SELECT name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`, table, func(rows *sql.Rows) (any, error) {
+		var name, declared string
+		var notNull bool
+		var defaultValue sql.NullString
+		var primaryKey int
+		err := rows.Scan(&name, &declared, &notNull, &defaultValue, &primaryKey)
+		return map[string]any{"name": name, "type": declared, "not_null": notNull, "default_value": defaultValue.String, "primary_key": primaryKey}, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing %s: %w", table, err)
+	}
+
+	indexes, err := collect(db, `SELECT name, "unique" FROM pragma_index_list(?) ORDER BY name`, table, func(rows *sql.Rows) (any, error) {
+		var name string
+		var unique bool
+		err := rows.Scan(&name, &unique)
+		return map[string]any{"name": name, "unique": unique}, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing %s: %w", table, err)
+	}
+	for _, index := range indexes {
+		index := index.(map[string]any)
+		names, err := collect(db, `SELECT name FROM pragma_index_info(?) ORDER BY seqno`, index["name"], func(rows *sql.Rows) (any, error) {
+			// An index on an expression has no column name to give.
+			var name sql.NullString
+			err := rows.Scan(&name)
+			if !name.Valid {
+				return "(expression)", err
+			}
+			return name.String, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describing %s: %w", table, err)
+		}
+		index["columns"] = names
+	}
+
+	foreignKeys, err := collect(db, `SELECT "from", "table", "to" FROM pragma_foreign_key_list(?) ORDER BY id, seq`, table, func(rows *sql.Rows) (any, error) {
+		var column, other string
+		// A key that names no column refers to the other table's primary key.
+		var references sql.NullString
+		err := rows.Scan(&column, &other, &references)
+		return map[string]any{"column": column, "table": other, "references": references.String}, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing %s: %w", table, err)
+	}
+
+	return map[string]any{
+		"table":        table,
+		"type":         kind,
+		"sql":          createSQL.String,
+		"columns":      columns,
+		"indexes":      indexes,
+		"foreign_keys": foreignKeys,
+	}, nil
+}
+
+// collect runs a query with one argument and turns each of its rows into a
+// value with scan.
+func collect(db *sql.DB, query string, arg any, scan func(rows *sql.Rows) (any, error)) ([]any, error) {
+	rows, err := db.Query(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	values := []any{}
+	for rows.Next() {
+		value, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// bind turns a request's params, decoded from JSON, into query arguments.
+func bind(params []any) ([]any, error) {
+	args := make([]any, len(params))
+	for i, param := range params {
+		switch param := param.(type) {
+		case json.Number:
+			if n, err := param.Int64(); err == nil {
+				args[i] = n
+			} else if f, err := param.Float64(); err == nil {
+				args[i] = f
+			} else {
+				return nil, fmt.Errorf("param %d: %w", i+1, err)
+			}
+		case bool:
+			if param {
+				args[i] = int64(1)
+			} else {
+				args[i] = int64(0)
+			}
+		case map[string]any, []any:
+			encoded, err := json.Marshal(param)
+			if err != nil {
+				return nil, fmt.Errorf("param %d: %w", i+1, err)
+			}
+			args[i] = string(encoded)
+		default:
+			args[i] = param
+		}
+	}
+	return args, nil
+}
+
+// jsonCell is a column's value as JSON can carry it.
+func jsonCell(value any) any {
+	switch value := value.(type) {
+	case int64:
+		// google.protobuf.Value holds numbers as doubles, which are exact only so
+		// far.
+		if value > 1<<53 || value < -(1<<53) {
+			return strconv.FormatInt(value, 10)
+		}
+		return value
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return strconv.FormatFloat(value, 'g', -1, 64)
+		}
+		return value
+	case []byte:
+		return base64.StdEncoding.EncodeToString(value)
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case nil, string, bool:
+		return value
+	}
+	return fmt.Sprint(value)
+}
+
+// decodeRequest decodes a call's protobuf request and reads its fields through
+// their JSON.
+func decodeRequest(method protoreflect.MethodDescriptor, body []byte) (request, error) {
+	var req request
+	msg := dynamicpb.NewMessage(method.Input())
+	if len(body) > 0 {
+		if err := proto.Unmarshal(body, msg); err != nil {
+			return req, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	encoded, err := protojson.Marshal(msg)
+	if err != nil {
+		return req, fmt.Errorf("decoding request: %w", err)
+	}
+	d := json.NewDecoder(bytes.NewReader(encoded))
+	// Numbers stay json.Number, so an integer param is bound as one.
+	d.UseNumber()
+	if err := d.Decode(&req); err != nil {
+		return req, fmt.Errorf("decoding request: %w", err)
+	}
+	return req, nil
+}
+
+func lastSegment(s string) string {
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,205 @@
+// Package sqlite implements the built-in "sqlite" app: it binds to the SQLite
+// database file named by the "path" creation parameter and exposes Query, Exec,
+// ListTables and DescribeTable on it, so a script can keep results across runs in
+// tables and a service's own database can be looked into. With "read_only" set to
+// true the database is opened read-only and Exec is refused.
+//
+// Rows come back as google.protobuf.Struct, one field per column, so a script
+// reads row.name rather than a cell by index. Everything is local: the database is
+// opened for each call and closed after it, as the folder app opens its folder,
+// so nothing holds the file between calls.
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wham/kaja/v2/internal/workspace"
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/protoc-go/protoc"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	_ "modernc.org/sqlite"
+)
+
+const serviceTypeName = "sqlite.Sqlite"
+
+// protoSource is the static proto surface the sqlite app renders.
+const protoSource = `syntax = "proto3";
+
+package sqlite;
+
+import "google/protobuf/struct.proto";
+
+message QueryRequest {
+  // One SQL statement that returns rows, e.g.
+  // "SELECT name, status FROM runs WHERE status = ?".
+  string sql = 1 [json_name = "sql"];
+  // The values of the statement's ? placeholders, in order. A JSON object or
+  // array is bound as its JSON text, a boolean as 1 or 0. Numbers travel as
+  // doubles, so pass an integer past 2^53 as a string: an INTEGER column stores
+  // it as the integer.
+  repeated google.protobuf.Value params = 2 [json_name = "params"];
+  // Stop after this many rows. 0 means 1000.
+  int32 limit = 3 [json_name = "limit"];
+}
+message QueryResponse {
+  // The result's column names, in order.
+  repeated string columns = 1 [json_name = "columns"];
+  // One object per row, keyed by column name. A column name that repeats keeps
+  // the last column's value, so alias one of them. Integers past 2^53 come back
+  // as strings, BLOBs as base64, and NULL as null.
+  repeated google.protobuf.Struct rows = 2 [json_name = "rows"];
+  // The query had more rows than limit.
+  bool truncated = 3 [json_name = "truncated"];
+}
+
+message ExecRequest {
+  // SQL that changes the database: one statement with params, or several
+  // separated by semicolons without, e.g. a CREATE TABLE and its indexes.
+  string sql = 1 [json_name = "sql"];
+  // As QueryRequest.params.
+  repeated google.protobuf.Value params = 2 [json_name = "params"];
+}
+message ExecResponse {
+  // How many rows the statement inserted, updated or deleted.
+  int64 rows_affected = 1 [json_name = "rows_affected"];
+  // The rowid of the last row inserted.
+  int64 last_insert_id = 2 [json_name = "last_insert_id"];
+}
+
+message ListTablesRequest {}
+message ListTablesResponse {
+  // Table names, sorted. SQLite's own tables are left out.
+  repeated string tables = 1 [json_name = "tables"];
+  // View names, sorted.
+  repeated string views = 2 [json_name = "views"];
+}
+
+message DescribeTableRequest {
+  // The table or view, as ListTables names it.
+  string table = 1 [json_name = "table"];
+}
+message Column {
+  string name = 1 [json_name = "name"];
+  // The declared type, e.g. "INTEGER" or "TEXT". Empty when none was declared.
+  string type = 2 [json_name = "type"];
+  bool not_null = 3 [json_name = "not_null"];
+  // The default as SQL, e.g. "'pending'" or "CURRENT_TIMESTAMP". Empty when
+  // there is none.
+  string default_value = 4 [json_name = "default_value"];
+  // The column's place in the primary key, from 1. 0 when it is not part of it.
+  int32 primary_key = 5 [json_name = "primary_key"];
+}
+message Index {
+  string name = 1 [json_name = "name"];
+  bool unique = 2 [json_name = "unique"];
+  repeated string columns = 3 [json_name = "columns"];
+}
+message ForeignKey {
+  // The column of this table, the table it refers to, and the column there.
+  string column = 1 [json_name = "column"];
+  string table = 2 [json_name = "table"];
+  string references = 3 [json_name = "references"];
+}
+message DescribeTableResponse {
+  string table = 1 [json_name = "table"];
+  // "table" or "view".
+  string type = 2 [json_name = "type"];
+  // The statement that created it.
+  string sql = 3 [json_name = "sql"];
+  repeated Column columns = 4 [json_name = "columns"];
+  repeated Index indexes = 5 [json_name = "indexes"];
+  repeated ForeignKey foreign_keys = 6 [json_name = "foreign_keys"];
+}
+
+service Sqlite {
+  // Run a statement that returns rows.
+  rpc Query(QueryRequest) returns (QueryResponse);
+  // Run SQL that changes the database. Refused when the app is read-only.
+  rpc Exec(ExecRequest) returns (ExecResponse);
+  // List the database's tables and views.
+  rpc ListTables(ListTablesRequest) returns (ListTablesResponse);
+  // Describe a table's columns, indexes and foreign keys.
+  rpc DescribeTable(DescribeTableRequest) returns (DescribeTableResponse);
+}
+`
+
+// App is the sqlite app factory. Register it with the apps.Manager.
+type App struct{}
+
+func New() *App { return &App{} }
+
+func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
+	path := strings.TrimSpace(parameters["path"])
+	if path == "" {
+		return nil, fmt.Errorf("missing required parameter %q", "path")
+	}
+	path = filepath.Clean(workspace.Resolve(path))
+	readOnly := strings.TrimSpace(parameters["read_only"]) == "true"
+
+	// A database that is not there yet is made by the first write, which a
+	// read-only app never makes, so it has to be there already.
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		return nil, fmt.Errorf("%s is a folder, not a database file", path)
+	case errors.Is(err, fs.ErrNotExist) && readOnly:
+		return nil, fmt.Errorf("%s does not exist (a read-only app does not create it)", path)
+	case errors.Is(err, fs.ErrNotExist):
+		log("SQLite: " + path + " (created on the first write)")
+	case err != nil:
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	case readOnly:
+		log("SQLite: " + path + " (read-only)")
+	default:
+		log("SQLite: " + path)
+	}
+
+	if err := os.WriteFile(filepath.Join(protoDir, "sqlite.proto"), []byte(protoSource), 0o644); err != nil {
+		return nil, fmt.Errorf("writing proto: %w", err)
+	}
+	methods, err := compile(protoDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log("Generated service " + serviceTypeName + " with methods " + strings.Join(names, ", "))
+
+	return &apps.Opened{Instance: &instance{path: path, readOnly: readOnly, methods: methods}}, nil
+}
+
+// compile compiles the static proto and returns its methods by name.
+func compile(protoDir string) (map[string]protoreflect.MethodDescriptor, error) {
+	result, err := protoc.New(protoc.WithProtoPaths(protoDir), protoc.WithIncludeImports()).Compile("sqlite.proto")
+	if err != nil {
+		return nil, fmt.Errorf("compiling generated proto: %w", err)
+	}
+	files, err := protodesc.NewFiles(result.AsFileDescriptorSet())
+	if err != nil {
+		return nil, fmt.Errorf("building descriptors: %w", err)
+	}
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceTypeName))
+	if err != nil {
+		return nil, fmt.Errorf("finding service %s: %w", serviceTypeName, err)
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceTypeName)
+	}
+	methods := map[string]protoreflect.MethodDescriptor{}
+	for i := 0; i < service.Methods().Len(); i++ {
+		method := service.Methods().Get(i)
+		methods[string(method.Name())] = method
+	}
+	return methods, nil
+}
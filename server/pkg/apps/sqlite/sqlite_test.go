@@ -0,0 +1,193 @@
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wham/kaja/v2/pkg/apps/appstest"
+)
+
+// open opens the app on a database file in a fresh temp folder.
+func open(t *testing.T, parameters map[string]string) *instance {
+	t.Helper()
+	opened, err := New().Open(parameters, t.TempDir(), func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return opened.Instance.(*instance)
+}
+
+// call runs one of the Sqlite service's methods, by name.
+func call(inst *instance, methodName, requestJSON string) (map[string]any, error) {
+	method := inst.methods[methodName]
+	return appstest.Call(inst, "sqlite.Sqlite/"+methodName, method.Input(), method.Output(), requestJSON, nil)
+}
+
+func invoke(t *testing.T, inst *instance, methodName, requestJSON string) map[string]any {
+	t.Helper()
+	resp, err := call(inst, methodName, requestJSON)
+	if err != nil {
+		t.Fatalf("Invoke %s %s: %v", methodName, requestJSON, err)
+	}
+	return resp
+}
+
+func TestExecAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+	inst := open(t, map[string]string{"path": path})
+
+	invoke(t, inst, "Exec", `{"sql": "CREATE TABLE runs (id INTEGER PRIMARY KEY, name TEXT NOT NULL, score REAL, big INTEGER, data BLOB, meta TEXT); CREATE INDEX runs_name ON runs (name);"}`)
+	inserted := invoke(t, inst, "Exec", `{"sql": "INSERT INTO runs (name, score, big, meta) VALUES (?, ?, ?, ?)", "params": ["first", 0.5, "9007199254740993", {"tags": ["a"]}]}`)
+	if inserted["rows_affected"] != "1" || inserted["last_insert_id"] != "1" {
+		t.Fatalf("Exec = %v", inserted)
+	}
+	invoke(t, inst, "Exec", `{"sql": "INSERT INTO runs (name, score, data) VALUES (?, ?, X'00FF')", "params": ["second", true]}`)
+
+	// The file is on disk, so another run reads what this one wrote.
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("database file: %v", err)
+	}
+
+	got := invoke(t, inst, "Query", `{"sql": "SELECT id, name, score, big, data, meta, NULL AS missing FROM runs ORDER BY id"}`)
+	if columns := got["columns"].([]any); len(columns) != 7 || columns[1] != "name" {
+		t.Fatalf("columns = %v", columns)
+	}
+	rows := got["rows"].([]any)
+	if len(rows) != 2 {
+		t.Fatalf("rows = %v", rows)
+	}
+	first, second := rows[0].(map[string]any), rows[1].(map[string]any)
+	if first["id"] != 1.0 || first["name"] != "first" || first["score"] != 0.5 || first["missing"] != nil {
+		t.Fatalf("first row = %v", first)
+	}
+	// Past 2^53 an integer is a string, so it is not rounded.
+	if first["big"] != "9007199254740993" || first["meta"] != `{"tags":["a"]}` {
+		t.Fatalf("first row = %v", first)
+	}
+	if second["score"] != 1.0 || second["data"] != "AP8=" {
+		t.Fatalf("second row = %v", second)
+	}
+
+	got = invoke(t, inst, "Query", `{"sql": "SELECT name FROM runs WHERE score < ?", "params": [1]}`)
+	if rows := got["rows"].([]any); len(rows) != 1 || rows[0].(map[string]any)["name"] != "first" {
+		t.Fatalf("filtered rows = %v", rows)
+	}
+	got = invoke(t, inst, "Query", `{"sql": "SELECT id FROM runs", "limit": 1}`)
+	if rows := got["rows"].([]any); len(rows) != 1 || got["truncated"] != true {
+		t.Fatalf("limited query = %v", got)
+	}
+
+	for _, request := range []struct{ method, json string }{
+		{"Query", `{"sql": "SELECT * FROM nowhere"}`},
+		{"Query", `{}`},
+		{"Exec", `{"sql": "INSERT INTO runs (name) VALUES (NULL)"}`},
+	} {
+		if _, err := call(inst, request.method, request.json); err == nil {
+			t.Errorf("expected %s %s to fail", request.method, request.json)
+		}
+	}
+}
+
+func TestListAndDescribeTables(t *testing.T) {
+	inst := open(t, map[string]string{"path": filepath.Join(t.TempDir(), "shop.db")})
+	invoke(t, inst, "Exec", `{"sql": "CREATE TABLE customers (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE); CREATE TABLE orders (id INTEGER PRIMARY KEY, customer_id INTEGER REFERENCES customers (id), status TEXT DEFAULT 'pending'); CREATE INDEX orders_status ON orders (status, customer_id); CREATE VIEW open_orders AS SELECT * FROM orders WHERE status = 'pending';"}`)
+
+	listed := invoke(t, inst, "ListTables", `{}`)
+	if !slices.Equal(listed["tables"].([]any), []any{"customers", "orders"}) || !slices.Equal(listed["views"].([]any), []any{"open_orders"}) {
+		t.Fatalf("ListTables = %v", listed)
+	}
+
+	described := invoke(t, inst, "DescribeTable", `{"table": "orders"}`)
+	if described["type"] != "table" || !strings.HasPrefix(described["sql"].(string), "CREATE TABLE orders") {
+		t.Fatalf("DescribeTable = %v", described)
+	}
+	columns := described["columns"].([]any)
+	id, status := columns[0].(map[string]any), columns[2].(map[string]any)
+	if len(columns) != 3 || id["primary_key"] != 1.0 || id["type"] != "INTEGER" || status["default_value"] != "'pending'" {
+		t.Fatalf("columns = %v", columns)
+	}
+	indexes := described["indexes"].([]any)
+	if len(indexes) != 1 || !slices.Equal(indexes[0].(map[string]any)["columns"].([]any), []any{"status", "customer_id"}) {
+		t.Fatalf("indexes = %v", indexes)
+	}
+	keys := described["foreign_keys"].([]any)
+	if len(keys) != 1 || keys[0].(map[string]any)["table"] != "customers" || keys[0].(map[string]any)["references"] != "id" {
+		t.Fatalf("foreign_keys = %v", keys)
+	}
+
+	// A UNIQUE column has the index SQLite made for it.
+	customers := invoke(t, inst, "DescribeTable", `{"table": "customers"}`)
+	if indexes := customers["indexes"].([]any); len(indexes) != 1 || indexes[0].(map[string]any)["unique"] != true {
+		t.Fatalf("customers indexes = %v", indexes)
+	}
+	if view := invoke(t, inst, "DescribeTable", `{"table": "open_orders"}`); view["type"] != "view" || len(view["columns"].([]any)) != 3 {
+		t.Fatalf("view = %v", view)
+	}
+	if _, err := call(inst, "DescribeTable", `{"table": "nowhere"}`); err == nil || !strings.Contains(err.Error(), "ListTables") {
+		t.Fatalf("unknown table = %v", err)
+	}
+
+	// Foreign keys are enforced.
+	if _, err := call(inst, "Exec", `{"sql": "INSERT INTO orders (customer_id) VALUES (42)"}`); err == nil {
+		t.Fatal("expected an order for a missing customer to fail")
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.db")
+	if _, err := New().Open(map[string]string{"path": path, "read_only": "true"}, t.TempDir(), func(string) {}); err == nil {
+		t.Fatal("expected a read-only app on a missing database to fail")
+	}
+
+	writable := open(t, map[string]string{"path": path})
+	invoke(t, writable, "Exec", `{"sql": "CREATE TABLE state (key TEXT, value TEXT); INSERT INTO state VALUES ('mode', 'live')"}`)
+
+	inst := open(t, map[string]string{"path": path, "read_only": "true"})
+	got := invoke(t, inst, "Query", `{"sql": "SELECT value FROM state WHERE key = ?", "params": ["mode"]}`)
+	if rows := got["rows"].([]any); len(rows) != 1 || rows[0].(map[string]any)["value"] != "live" {
+		t.Fatalf("rows = %v", rows)
+	}
+	if _, err := call(inst, "Exec", `{"sql": "DELETE FROM state"}`); err == nil || !strings.Contains(err.Error(), "read-only") {
+		t.Fatalf("Exec on a read-only app = %v", err)
+	}
+	// A write that comes in through Query is refused by SQLite itself.
+	if _, err := call(inst, "Query", `{"sql": "DELETE FROM state RETURNING key"}`); err == nil {
+		t.Fatal("expected a write through Query to fail")
+	}
+	if got := invoke(t, writable, "Query", `{"sql": "SELECT count(*) AS n FROM state"}`); got["rows"].([]any)[0].(map[string]any)["n"] != 1.0 {
+		t.Fatalf("state = %v", got)
+	}
+}
+
+func TestOpenNeedsAPath(t *testing.T) {
+	if _, err := New().Open(map[string]string{}, t.TempDir(), func(string) {}); err == nil {
+		t.Fatal("expected Open without a path to fail")
+	}
+	if _, err := New().Open(map[string]string{"path": t.TempDir()}, t.TempDir(), func(string) {}); err == nil {
+		t.Fatal("expected Open on a folder to fail")
+	}
+}
+
+func TestRelativePathIsInTheWorkspace(t *testing.T) {
+	// The workspace is the folder beside the one kaja runs from.
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "workspace"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, "server"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Join(root, "server"))
+
+	inst := open(t, map[string]string{"path": "local.db"})
+	invoke(t, inst, "Exec", `{"sql": "CREATE TABLE notes (body TEXT)"}`)
+	if _, err := os.Stat(filepath.Join(root, "workspace", "local.db")); err != nil {
+		t.Fatalf("database in the workspace: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "server", "local.db")); err == nil {
+		t.Fatal("the database was made beside the binary")
+	}
+}
@@ -0,0 +1,346 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+)
+
+// description is what an AsyncAPI document says about a WebSocket API: the
+// messages a client sends, each with the reply it gets when the document names
+// one, and the messages it receives.
+type description struct {
+	title    string
+	server   string
+	schemas  map[string]json.RawMessage
+	sends    []*sendable
+	receives []*message
+}
+
+type message struct {
+	name    string
+	doc     string
+	payload json.RawMessage
+}
+
+type sendable struct {
+	message *message
+	reply   *message
+}
+
+// loadAsyncAPI reads the app's AsyncAPI document from a workspace file or a URL.
+// It is nil for an app that has neither.
+func loadAsyncAPI(specURL, specFile string, client *http.Client, log func(string)) (*description, error) {
+	var content []byte
+	switch {
+	case specFile != "":
+		log("Reading AsyncAPI document from " + specFile)
+		read, err := os.ReadFile(specFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading AsyncAPI document: %w", err)
+		}
+		content = read
+	case specURL != "":
+		if err := requireScheme(specURL, "http", "https"); err != nil {
+			return nil, err
+		}
+		log("Fetching AsyncAPI document from " + specURL)
+		req, err := http.NewRequest(http.MethodGet, specURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+		req.Header.Set("Accept", "application/yaml, application/json, text/yaml, text/plain, */*")
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", specURL, err)
+		}
+		defer resp.Body.Close()
+		body, err := readLimited(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			return nil, apps.NewUpstreamError(http.MethodGet, specURL, resp.StatusCode, body)
+		}
+		content = body
+	default:
+		return nil, nil
+	}
+	return readAsyncAPI(content)
+}
+
+// readAsyncAPI reads an AsyncAPI 2 or 3 document, in YAML or JSON. Operations
+// are read the way the documents of public WebSocket APIs write them, as what a
+// client does: in 2.x it sends what a channel's publish names and receives what
+// its subscribe does; in 3.x it sends the messages of a send operation, and
+// receives those of a receive operation.
+func readAsyncAPI(content []byte) (*description, error) {
+	converted, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing AsyncAPI document: %w", err)
+	}
+	var root map[string]any
+	if err := json.Unmarshal(converted, &root); err != nil {
+		return nil, fmt.Errorf("parsing AsyncAPI document: %w", err)
+	}
+	version, _ := root["asyncapi"].(string)
+	if version == "" {
+		return nil, fmt.Errorf("not an AsyncAPI document: it has no %q version", "asyncapi")
+	}
+
+	r := &reader{root: root, sent: map[string]*sendable{}, received: map[string]bool{}}
+	d := &description{schemas: map[string]json.RawMessage{}}
+	if info, ok := root["info"].(map[string]any); ok {
+		d.title, _ = info["title"].(string)
+	}
+	for name, schema := range object(object(root["components"])["schemas"]) {
+		d.schemas[name], _ = json.Marshal(schema)
+	}
+
+	if strings.HasPrefix(version, "2.") {
+		for _, name := range sortedKeys(object(root["channels"])) {
+			channel, _ := r.deref(object(root["channels"])[name])
+			if publish, _ := r.deref(channel["publish"]); publish != nil {
+				for _, m := range r.messages(publish["message"], operationName(publish, name)) {
+					r.send(d, m, nil)
+				}
+			}
+			if subscribe, _ := r.deref(channel["subscribe"]); subscribe != nil {
+				for _, m := range r.messages(subscribe["message"], operationName(subscribe, name)) {
+					r.receive(d, m)
+				}
+			}
+		}
+	} else {
+		operations := object(root["operations"])
+		for _, name := range sortedKeys(operations) {
+			operation, _ := r.deref(operations[name])
+			messages := r.operationMessages(operation, name)
+			switch operation["action"] {
+			case "send":
+				var reply *message
+				if replyOf, _ := r.deref(operation["reply"]); replyOf != nil {
+					if replies := r.operationMessages(replyOf, name+"Reply"); len(replies) > 0 {
+						reply = replies[0]
+					}
+				}
+				for _, m := range messages {
+					r.send(d, m, reply)
+				}
+			case "receive":
+				for _, m := range messages {
+					r.receive(d, m)
+				}
+			}
+		}
+	}
+	if len(d.sends) == 0 && len(d.receives) == 0 {
+		return nil, fmt.Errorf("the AsyncAPI document describes no messages")
+	}
+	d.server = r.server(version)
+	return d, nil
+}
+
+// reader walks the JSON of an AsyncAPI document, following its local references.
+type reader struct {
+	root     map[string]any
+	sent     map[string]*sendable
+	received map[string]bool
+}
+
+func (r *reader) send(d *description, m *message, reply *message) {
+	if existing := r.sent[m.name]; existing != nil {
+		if existing.reply == nil {
+			existing.reply = reply
+		}
+		return
+	}
+	s := &sendable{message: m, reply: reply}
+	r.sent[m.name] = s
+	d.sends = append(d.sends, s)
+}
+
+func (r *reader) receive(d *description, m *message) {
+	if !r.received[m.name] {
+		r.received[m.name] = true
+		d.receives = append(d.receives, m)
+	}
+}
+
+// deref follows a value's "$ref" to what it points at in the document, and
+// returns it with the last segment of the reference, which names it.
+func (r *reader) deref(value any) (map[string]any, string) {
+	name := ""
+	for range 16 {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, name
+		}
+		ref, _ := m["$ref"].(string)
+		if ref == "" {
+			return m, name
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, name
+		}
+		value = r.root
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+			if unescaped, err := url.PathUnescape(segment); err == nil {
+				segment = unescaped
+			}
+			value = object(value)[segment]
+			name = segment
+		}
+	}
+	return nil, name
+}
+
+// operationMessages lists the messages of a 3.x operation or reply: those it
+// names, or all its channel's when it names none.
+func (r *reader) operationMessages(operation map[string]any, fallback string) []*message {
+	var out []*message
+	if named, _ := operation["messages"].([]any); len(named) > 0 {
+		for _, m := range named {
+			out = append(out, r.messages(m, fallback)...)
+		}
+		return out
+	}
+	channel, _ := r.deref(operation["channel"])
+	messages := object(channel["messages"])
+	for _, key := range sortedKeys(messages) {
+		out = append(out, r.messages(messages[key], key)...)
+	}
+	return out
+}
+
+// messages reads a message, or each of a oneOf of them.
+func (r *reader) messages(value any, fallback string) []*message {
+	m, refName := r.deref(value)
+	if m == nil {
+		return nil
+	}
+	if variants, ok := m["oneOf"].([]any); ok {
+		var out []*message
+		for _, variant := range variants {
+			out = append(out, r.messages(variant, fallback)...)
+		}
+		return out
+	}
+	name := firstString(m["name"], refName, m["title"], fallback)
+	if name == "" {
+		name = "message"
+	}
+	payload := m["payload"]
+	// A payload in another schema format says which, and holds the schema.
+	if multi := object(payload); multi["schemaFormat"] != nil && multi["schema"] != nil {
+		payload = multi["schema"]
+	}
+	if p := object(payload); p != nil {
+		if ref, _ := p["$ref"].(string); ref != "" && !strings.HasPrefix(ref, "#/components/schemas/") {
+			payload, _ = r.deref(p)
+		}
+	}
+	raw, _ := json.Marshal(payload)
+	if payload == nil {
+		raw = nil
+	}
+	return []*message{{
+		name:    name,
+		doc:     firstString(m["summary"], m["title"], m["description"]),
+		payload: raw,
+	}}
+}
+
+// server is the WebSocket URL of the document's first server that speaks ws or
+// wss, with its variables at their defaults.
+func (r *reader) server(version string) string {
+	servers := object(r.root["servers"])
+	for _, name := range sortedKeys(servers) {
+		s, _ := r.deref(servers[name])
+		protocol, _ := s["protocol"].(string)
+		protocol = strings.ToLower(protocol)
+		var address string
+		if strings.HasPrefix(version, "2.") {
+			address, _ = s["url"].(string)
+		} else {
+			host, _ := s["host"].(string)
+			pathname, _ := s["pathname"].(string)
+			address = host + pathname
+		}
+		if address == "" {
+			continue
+		}
+		if !strings.Contains(address, "://") {
+			address = protocol + "://" + address
+		}
+		for variable, value := range object(s["variables"]) {
+			if def, ok := object(value)["default"].(string); ok {
+				address = strings.ReplaceAll(address, "{"+variable+"}", def)
+			}
+		}
+		if requireScheme(address, "ws", "wss") == nil {
+			return address
+		}
+	}
+	return ""
+}
+
+// discriminators reads the properties a payload fixes to one value, with const
+// or a one-value enum, which is how a message tells itself apart from the
+// others that come over the same connection.
+func discriminators(payload json.RawMessage, schemas map[string]json.RawMessage) map[string]any {
+	var schema map[string]any
+	if json.Unmarshal(payload, &schema) != nil {
+		return nil
+	}
+	if ref, _ := schema["$ref"].(string); strings.HasPrefix(ref, "#/components/schemas/") {
+		schema = nil
+		json.Unmarshal(schemas[strings.TrimPrefix(ref, "#/components/schemas/")], &schema)
+	}
+	fixed := map[string]any{}
+	for name, property := range object(schema["properties"]) {
+		p := object(property)
+		if value, ok := p["const"]; ok {
+			fixed[name] = value
+		} else if enum, _ := p["enum"].([]any); len(enum) == 1 {
+			fixed[name] = enum[0]
+		}
+	}
+	return fixed
+}
+
+func operationName(operation map[string]any, channel string) string {
+	return firstString(operation["operationId"], channel)
+}
+
+func object(value any) map[string]any {
+	m, _ := value.(map[string]any)
+	return m
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func firstString(values ...any) string {
+	for _, value := range values {
+		if s, ok := value.(string); ok && strings.TrimSpace(s) != "" {
+			return strings.TrimSpace(s)
+		}
+	}
+	return ""
+}
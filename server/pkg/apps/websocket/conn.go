@@ -0,0 +1,210 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+)
+
+// idleTimeout is how long a connection is left open with nothing asked of it.
+// It is closed after that and dialled again by the next call, so an app
+// recompiled a dozen times doesn't leave a dozen connections behind.
+const idleTimeout = 5 * time.Minute
+
+// writeTimeout bounds writing one frame.
+const writeTimeout = 30 * time.Second
+
+// ErrClosed is what a call gets when the connection closed under it.
+var ErrClosed = errors.New("the connection closed")
+
+// frame is one message the server sent: text, or binary when binary is set.
+type frame struct {
+	binary bool
+	data   []byte
+}
+
+// connection is one WebSocket connection to the server, which the calls of an
+// opened app share.
+type connection struct {
+	ws *gorilla.Conn
+	// The handshake's headers, which each call surfaces.
+	requestHeaders  map[string]string
+	responseHeaders map[string]string
+	// writes is held while a frame is written, so two never interleave.
+	writes sync.Mutex
+
+	mu sync.Mutex
+	// listeners hear each frame that comes in. A call waiting for a reply and a
+	// subscription each add one, which keeps the connection from being closed for
+	// being idle while it is there.
+	listeners map[int]func(frame)
+	next      int
+	idle      *time.Timer
+	done      chan struct{}
+	// err is why the connection closed, once it has.
+	err error
+}
+
+// dial opens a connection. A handshake the server refused is an
+// apps.UpstreamError with what it answered.
+func dial(endpoint string, headers map[string]string, subprotocols []string, timeout time.Duration) (*connection, error) {
+	header := http.Header{}
+	for name, value := range headers {
+		header.Set(name, value)
+	}
+	dialer := gorilla.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: timeout,
+		Subprotocols:     subprotocols,
+	}
+	ws, resp, err := dialer.Dial(endpoint, header)
+	if err != nil {
+		if resp != nil {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+			resp.Body.Close()
+			failure := apps.NewUpstreamError(http.MethodGet, endpoint, resp.StatusCode, body)
+			if resp.StatusCode < 400 {
+				failure.Message = "the server did not accept the WebSocket handshake"
+			}
+			return nil, failure.WithHeaders(apps.SurfaceHeaders(header), apps.SurfaceHeaders(resp.Header))
+		}
+		return nil, fmt.Errorf("connecting to %s: %w", endpoint, err)
+	}
+	ws.SetReadLimit(32 << 20)
+
+	c := &connection{
+		ws:              ws,
+		requestHeaders:  apps.SurfaceHeaders(header),
+		responseHeaders: apps.SurfaceHeaders(resp.Header),
+		listeners:       map[int]func(frame){},
+		done:            make(chan struct{}),
+	}
+	c.idle = time.AfterFunc(idleTimeout, c.closeIdle)
+	go c.read()
+	return c, nil
+}
+
+// read takes frames off the connection until it closes, passing each to every
+// listener.
+func (c *connection) read() {
+	var err error
+	for {
+		var kind int
+		var data []byte
+		kind, data, err = c.ws.ReadMessage()
+		if err != nil {
+			break
+		}
+		c.mu.Lock()
+		listeners := make([]func(frame), 0, len(c.listeners))
+		for _, listener := range c.listeners {
+			listeners = append(listeners, listener)
+		}
+		c.mu.Unlock()
+		for _, listener := range listeners {
+			listener(frame{binary: kind == gorilla.BinaryMessage, data: data})
+		}
+	}
+
+	reason := ErrClosed
+	var closeErr *gorilla.CloseError
+	if errors.As(err, &closeErr) {
+		if closeErr.Text != "" {
+			reason = fmt.Errorf("%w (%d): %s", ErrClosed, closeErr.Code, closeErr.Text)
+		} else {
+			reason = fmt.Errorf("%w (%d)", ErrClosed, closeErr.Code)
+		}
+	} else if !errors.Is(err, net.ErrClosed) {
+		reason = fmt.Errorf("%w: %v", ErrClosed, err)
+	}
+	c.idle.Stop()
+	c.ws.Close()
+	c.mu.Lock()
+	c.err = reason
+	c.mu.Unlock()
+	close(c.done)
+}
+
+// listen adds a listener; the function it returns removes it. A listener runs
+// on the goroutine reading the connection, so it must not block.
+func (c *connection) listen(listener func(frame)) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := c.next
+	c.next++
+	c.listeners[id] = listener
+	return func() {
+		c.mu.Lock()
+		delete(c.listeners, id)
+		c.mu.Unlock()
+	}
+}
+
+// send writes one frame.
+func (c *connection) send(f frame) error {
+	c.idle.Reset(idleTimeout)
+	kind := gorilla.TextMessage
+	if f.binary {
+		kind = gorilla.BinaryMessage
+	}
+	c.writes.Lock()
+	defer c.writes.Unlock()
+	c.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := c.ws.WriteMessage(kind, f.data); err != nil {
+		if c.closed() {
+			return c.closeErr()
+		}
+		return fmt.Errorf("sending: %w", err)
+	}
+	return nil
+}
+
+// closeIdle closes a connection nothing has been asked of for a while, unless
+// someone is listening to it.
+func (c *connection) closeIdle() {
+	c.mu.Lock()
+	held := len(c.listeners) > 0
+	c.mu.Unlock()
+	if held {
+		c.idle.Reset(idleTimeout)
+		return
+	}
+	c.close()
+}
+
+// close says goodbye to the server and closes the connection, if it is open.
+func (c *connection) close() {
+	c.idle.Stop()
+	c.writes.Lock()
+	c.ws.WriteControl(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	c.writes.Unlock()
+	select {
+	case <-c.done:
+	case <-time.After(2 * time.Second):
+		c.ws.Close()
+		<-c.done
+	}
+}
+
+func (c *connection) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *connection) closeErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
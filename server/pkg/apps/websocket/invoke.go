@@ -0,0 +1,511 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+)
+
+// subscriptionBuffer is how many frames a subscription holds while the caller
+// takes the ones before them. A subscription that falls further behind ends,
+// rather than hold up the calls sharing the connection.
+const subscriptionBuffer = 256
+
+// instance is a live opened WebSocket app. It is a gRPC app: a call arrives as
+// protobuf, and is sent as a frame over a connection the app's calls share -
+// dialled by the first, and again by the first after it closed. Calls whose
+// handshake headers differ get a connection each, since a server reads who is
+// calling from the handshake only.
+type instance struct {
+	endpoint     string
+	methods      map[string]*boundMethod
+	credential   map[string]string
+	subprotocols []string
+	// correlation is the path of the field a reply shares with its request.
+	correlation []string
+	ids         atomic.Int64
+
+	mu sync.Mutex
+	// conns are the open connections, by the handshake headers they were dialled
+	// with.
+	conns map[string]*connection
+}
+
+func (in *instance) Invoke(methodPath string, request []byte, headers map[string]string) (*apps.InvokeResult, error) {
+	method := in.lookup(methodPath)
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %q", methodPath)
+	}
+	if method.binding.kind == kindSubscribe {
+		return nil, fmt.Errorf("%s is a server-streaming method", methodPath)
+	}
+	req, err := requestJSON(method.input, request)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := in.connect(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	respJSON := []byte("{}")
+	if method.binding.kind == kindSend {
+		f, err := method.binding.outgoing(req)
+		if err != nil {
+			return nil, err
+		}
+		if err := conn.send(f); err != nil {
+			return nil, err
+		}
+	} else {
+		if respJSON, err = in.request(conn, method.binding, req); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := responseMessage(method.output, respJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &apps.InvokeResult{Body: out, RequestHeaders: conn.requestHeaders, ResponseHeaders: conn.responseHeaders}, nil
+}
+
+func (in *instance) Streams(methodPath string) bool {
+	method := in.lookup(methodPath)
+	return method != nil && method.binding.kind == kindSubscribe
+}
+
+// InvokeStream runs a subscription: it streams the frames the request matches
+// until ctx is done or the connection closes.
+func (in *instance) InvokeStream(ctx context.Context, methodPath string, request []byte, headers map[string]string, send func(message []byte) error) (*apps.InvokeResult, error) {
+	method := in.lookup(methodPath)
+	if method == nil || method.binding.kind != kindSubscribe {
+		return nil, fmt.Errorf("unknown streaming method %q", methodPath)
+	}
+	req, err := requestJSON(method.input, request)
+	if err != nil {
+		return nil, err
+	}
+	bound := method.binding
+	match := map[string]any{}
+	for name, value := range bound.fixed {
+		match[name] = value
+	}
+	if asked, ok := req["match"].(map[string]any); ok {
+		for name, value := range asked {
+			match[name] = value
+		}
+	}
+	conn, err := in.connect(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make(chan []byte, subscriptionBuffer)
+	behind := make(chan struct{})
+	var fellBehind sync.Once
+	stop := conn.listen(func(f frame) {
+		respJSON, ok := bound.incoming(f, match)
+		if !ok {
+			return
+		}
+		select {
+		case frames <- respJSON:
+		default:
+			fellBehind.Do(func() { close(behind) })
+		}
+	})
+	defer stop()
+
+	if message, ok := req["message"]; ok && message != nil {
+		body, err := json.Marshal(message)
+		if err != nil {
+			return nil, fmt.Errorf("encoding message: %w", err)
+		}
+		if err := conn.send(frame{data: body}); err != nil {
+			return nil, err
+		}
+	}
+
+	forward := func(respJSON []byte) error {
+		out, err := responseMessage(method.output, respJSON)
+		if err != nil {
+			return err
+		}
+		return send(out)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-behind:
+			return nil, fmt.Errorf("the subscription fell %d frames behind the server, and missed what came after", subscriptionBuffer)
+		case respJSON := <-frames:
+			if err := forward(respJSON); err != nil {
+				return nil, err
+			}
+		case <-conn.done:
+			// What came before the connection closed is still said.
+			for {
+				select {
+				case respJSON := <-frames:
+					if err := forward(respJSON); err != nil {
+						return nil, err
+					}
+				default:
+					return nil, conn.closeErr()
+				}
+			}
+		}
+	}
+}
+
+// connect returns the app's connection for headers, dialling one if it has none
+// open. The handshake carries the app's credential and configured headers,
+// which are the more specific instruction and win over it.
+func (in *instance) connect(headers map[string]string) (*connection, error) {
+	handshake := apps.MergeMetadata(headers, in.credential)
+	key := handshakeKey(handshake)
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if conn := in.conns[key]; conn != nil && !conn.closed() {
+		return conn, nil
+	}
+	for other, conn := range in.conns {
+		if conn.closed() {
+			delete(in.conns, other)
+		}
+	}
+	conn, err := dial(in.endpoint, handshake, in.subprotocols, replyTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if in.conns == nil {
+		in.conns = map[string]*connection{}
+	}
+	in.conns[key] = conn
+	return conn, nil
+}
+
+// handshakeKey is headers written out in one order, names canonicalized the way
+// the handshake sends them, so the same headers always give the same key.
+func handshakeKey(headers map[string]string) string {
+	header := http.Header{}
+	for name, value := range headers {
+		header.Set(name, value)
+	}
+	var key strings.Builder
+	header.WriteSubset(&key, nil)
+	return key.String()
+}
+
+// request sends a request's JSON object, with its correlation field filled in
+// when it has none, and returns the proto3-JSON response built from the first
+// frame with the same value in the field.
+func (in *instance) request(conn *connection, bound *binding, req map[string]any) ([]byte, error) {
+	var object map[string]any
+	if bound.typed {
+		object, _ = bound.payload(req).(map[string]any)
+	} else {
+		object, _ = req["message"].(map[string]any)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("a request is a JSON object, which its correlation field %q goes in", strings.Join(in.correlation, "."))
+	}
+	bound.fill(object)
+	id := lookupPath(object, in.correlation)
+	if id == nil {
+		id = in.ids.Add(1)
+		setPath(object, in.correlation, id)
+	}
+	timeout := replyTimeout
+	if ms, ok := req["timeoutMs"].(json.Number); ok {
+		if n, err := ms.Int64(); err == nil && n > 0 {
+			timeout = time.Duration(n) * time.Millisecond
+		}
+	}
+	body, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("encoding message: %w", err)
+	}
+
+	replies := make(chan frame, 1)
+	stop := conn.listen(func(f frame) {
+		if f.binary {
+			return
+		}
+		if decoded, ok := decodeJSON(f.data); ok && sameJSON(lookupPath(decoded, in.correlation), id) {
+			select {
+			case replies <- f:
+			default:
+			}
+		}
+	})
+	defer stop()
+	if err := conn.send(frame{data: body}); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case reply := <-replies:
+		if !bound.replyTyped {
+			return frameJSON(reply), nil
+		}
+		if bound.replyKey != "" {
+			return json.Marshal(map[string]json.RawMessage{bound.replyKey: reply.data})
+		}
+		return reply.data, nil
+	case <-conn.done:
+		return nil, conn.closeErr()
+	case <-timer.C:
+		return nil, fmt.Errorf("the server didn't answer within %s", timeout)
+	}
+}
+
+// outgoing is the frame a Send call sends.
+func (bound *binding) outgoing(req map[string]any) (frame, error) {
+	if bound.typed {
+		payload := bound.payload(req)
+		if object, ok := payload.(map[string]any); ok {
+			bound.fill(object)
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return frame{}, fmt.Errorf("encoding message: %w", err)
+		}
+		return frame{data: body}, nil
+	}
+	if message, ok := req["message"]; ok {
+		body, err := json.Marshal(message)
+		if err != nil {
+			return frame{}, fmt.Errorf("encoding message: %w", err)
+		}
+		return frame{data: body}, nil
+	}
+	if text, ok := req["text"].(string); ok {
+		return frame{data: []byte(text)}, nil
+	}
+	if encoded, ok := req["binary"].(string); ok {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return frame{}, fmt.Errorf("decoding binary: %w", err)
+		}
+		return frame{binary: true, data: data}, nil
+	}
+	return frame{}, fmt.Errorf("set message, text or binary to send")
+}
+
+// incoming is the proto3-JSON response a subscription streams for a frame, and
+// whether it streams the frame at all: it has to have each value of match, and
+// for a typed subscription be the JSON of its payload.
+func (bound *binding) incoming(f frame, match map[string]any) ([]byte, bool) {
+	if !bound.typed && len(match) == 0 {
+		return frameJSON(f), true
+	}
+	if f.binary {
+		return nil, false
+	}
+	decoded, ok := decodeJSON(f.data)
+	if !ok {
+		return nil, false
+	}
+	for name, value := range match {
+		if !sameJSON(lookupPath(decoded, strings.Split(name, ".")), value) {
+			return nil, false
+		}
+	}
+	switch {
+	case !bound.typed:
+		return frameJSON(f), true
+	case bound.payloadKey != "":
+		respJSON, err := json.Marshal(map[string]json.RawMessage{bound.payloadKey: f.data})
+		return respJSON, err == nil
+	default:
+		_, isObject := decoded.(map[string]any)
+		return f.data, isObject
+	}
+}
+
+// payload is the JSON a typed method sends.
+func (bound *binding) payload(req map[string]any) any {
+	if bound.payloadKey != "" {
+		return req[bound.payloadKey]
+	}
+	return req
+}
+
+// fill sets the values the payload's schema fixes that a call left out, so a
+// message says what it is without the caller having to.
+func (bound *binding) fill(object map[string]any) {
+	for name, value := range bound.fixed {
+		if _, ok := object[name]; !ok {
+			object[name] = value
+		}
+	}
+}
+
+// frameJSON is the proto3-JSON of a Frame.
+func frameJSON(f frame) []byte {
+	var out []byte
+	switch {
+	case f.binary:
+		out, _ = json.Marshal(map[string][]byte{"binary": f.data})
+	case json.Valid(f.data):
+		out, _ = json.Marshal(map[string]json.RawMessage{"message": f.data})
+	default:
+		out, _ = json.Marshal(map[string]string{"text": string(f.data)})
+	}
+	return out
+}
+
+// requestJSON decodes a request message and writes it as the JSON it is sent as.
+func requestJSON(md protoreflect.MessageDescriptor, request []byte) (map[string]any, error) {
+	msg := dynamicpb.NewMessage(md)
+	if len(request) > 0 {
+		if err := proto.Unmarshal(request, msg); err != nil {
+			return nil, fmt.Errorf("decoding request: %w", err)
+		}
+	}
+	encoded, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request to JSON: %w", err)
+	}
+	decoded, ok := decodeJSON(encoded)
+	object, _ := decoded.(map[string]any)
+	if !ok || object == nil {
+		return nil, fmt.Errorf("decoding request: not a JSON object")
+	}
+	plain(object, md)
+	return object, nil
+}
+
+func responseMessage(md protoreflect.MessageDescriptor, respJSON []byte) ([]byte, error) {
+	respMsg := dynamicpb.NewMessage(md)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respJSON, respMsg); err != nil {
+		return nil, fmt.Errorf("decoding response JSON: %w", err)
+	}
+	return proto.Marshal(respMsg)
+}
+
+// plain turns the JSON protojson wrote for a message of type md back into the
+// JSON the server reads: a 64-bit integer, which protojson writes as a string so
+// JavaScript can't round it, is a number again.
+func plain(value any, md protoreflect.MessageDescriptor) any {
+	object, ok := value.(map[string]any)
+	if !ok || md.FullName().Parent() == "google.protobuf" {
+		return value
+	}
+	for key, item := range object {
+		fd := md.Fields().ByJSONName(key)
+		if fd == nil {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			if list, ok := item.([]any); ok {
+				for i := range list {
+					list[i] = plainValue(list[i], fd)
+				}
+			}
+		case fd.IsMap():
+			if entries, ok := item.(map[string]any); ok {
+				for name := range entries {
+					entries[name] = plainValue(entries[name], fd.MapValue())
+				}
+			}
+		default:
+			object[key] = plainValue(item, fd)
+		}
+	}
+	return object
+}
+
+func plainValue(value any, fd protoreflect.FieldDescriptor) any {
+	switch fd.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if text, ok := value.(string); ok {
+			return json.Number(text)
+		}
+	case protoreflect.MessageKind:
+		return plain(value, fd.Message())
+	}
+	return value
+}
+
+// decodeJSON decodes JSON keeping its numbers as written, so an id too large
+// for a float64 still matches.
+func decodeJSON(data []byte) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if decoder.Decode(&value) != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// sameJSON reports whether two decoded values are the same JSON.
+func sameJSON(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// lookupPath is the value at a path of object keys, or nil.
+func lookupPath(value any, path []string) any {
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+func setPath(object map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := object[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			object[key] = next
+		}
+		object = next
+	}
+	object[path[len(path)-1]] = value
+}
+
+// lookup finds a method by exact gRPC path, falling back to a match on the
+// method-name segment.
+func (in *instance) lookup(methodPath string) *boundMethod {
+	if m, ok := in.methods[methodPath]; ok {
+		return m
+	}
+	want := lastSegment(methodPath)
+	for path, m := range in.methods {
+		if lastSegment(path) == want {
+			return m
+		}
+	}
+	return nil
+}
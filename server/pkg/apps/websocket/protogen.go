@@ -0,0 +1,173 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+)
+
+const protoPackage = "websocket"
+
+// What a method does with the connection.
+const (
+	kindSend      = "send"      // sends a frame and returns
+	kindRequest   = "request"   // sends a frame and waits for the reply correlated with it
+	kindSubscribe = "subscribe" // streams the frames that come in
+)
+
+// binding records how a generated method maps onto frames.
+type binding struct {
+	kind string
+	// typed is set on a method generated from an AsyncAPI message: its request,
+	// or its response for a subscription, is the message's payload rather than a
+	// frame.
+	typed bool
+	// payloadKey is the request-JSON key holding a typed payload that is not an
+	// object, which only a field can hold. Empty when the request is the payload.
+	payloadKey string
+	// replyTyped is set on a typed request whose reply the document describes;
+	// replyKey is like payloadKey, for the reply.
+	replyTyped bool
+	replyKey   string
+	// fixed are the values the payload's schema fixes - its discriminators. A
+	// typed send fills them in; a typed subscription streams only frames with them.
+	fixed map[string]any
+}
+
+// generated is the output of generating the proto: the file text, the
+// package-qualified name of its service, and the per-method bindings keyed by
+// the gRPC method path "<serviceTypeName>/<MethodName>".
+type generated struct {
+	proto           string
+	serviceTypeName string
+	bindings        map[string]*binding
+}
+
+var (
+	anySchema    = json.RawMessage(`{}`)
+	objectSchema = json.RawMessage(`{"type": "object"}`)
+	stringSchema = json.RawMessage(`{"type": "string"}`)
+	matchSchema  = json.RawMessage(`{"type": "object", "additionalProperties": true}`)
+	msSchema     = json.RawMessage(`{"type": "integer", "format": "int64"}`)
+)
+
+// generateProto makes the proto file of a WebSocket app: a service with Send,
+// Request and Subscribe, which take and return frames of any content, and - when
+// an AsyncAPI document describes the API - a method of each for its messages,
+// which take and return their payloads.
+func generateProto(d *description) (*generated, error) {
+	if d == nil {
+		d = &description{}
+	}
+	schemas, err := openapi.NewSchemas(protoPackage, d.schemas)
+	if err != nil {
+		return nil, err
+	}
+	serviceName := protogen.Identifier(d.title, "WebSocket")
+	serviceTypeName := protoPackage + "." + serviceName
+
+	frame, err := schemas.Message("Frame", []openapi.SchemaField{
+		{Name: "message", Schema: anySchema, Doc: "A text frame holding JSON."},
+		{Name: "text", Schema: stringSchema, Doc: "A text frame holding anything else."},
+		{Name: "binary", Type: "bytes", Doc: "A binary frame."},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sendRequest, err := schemas.Message("SendRequest", []openapi.SchemaField{
+		{Name: "message", Schema: anySchema, Doc: "JSON to send as a text frame."},
+		{Name: "text", Schema: stringSchema, Doc: "Text to send as it is; set one of message, text and binary."},
+		{Name: "binary", Type: "bytes", Doc: "Bytes to send as a binary frame."},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sendResponse, err := schemas.Message("SendResponse", nil)
+	if err != nil {
+		return nil, err
+	}
+	requestRequest, err := schemas.Message("RequestRequest", []openapi.SchemaField{
+		{Name: "message", Schema: objectSchema, Required: true, Doc: "The JSON object to send; its correlation field is filled in when it has none."},
+		{Name: "timeoutMs", Schema: msSchema, Doc: "How long to wait for the reply; zero waits as long as the app's timeout."},
+	})
+	if err != nil {
+		return nil, err
+	}
+	subscribeRequest, err := schemas.Message("SubscribeRequest", []openapi.SchemaField{
+		{Name: "message", Schema: anySchema, Doc: "JSON sent once the subscription is listening, such as the message that subscribes to a channel."},
+		{Name: "match", Schema: matchSchema, Doc: "Only JSON frames with these values are streamed; a dotted name looks inside an object."},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcs strings.Builder
+	used := map[string]bool{}
+	bindings := map[string]*binding{}
+	add := func(name, request, response string, stream bool, doc string, b *binding) {
+		name = protogen.UniqueName(used, name)
+		writeRPC(&rpcs, name, request, response, stream, doc)
+		bindings[serviceTypeName+"/"+name] = b
+	}
+	add("Send", sendRequest, sendResponse, false, "Sends a frame.", &binding{kind: kindSend})
+	add("Request", requestRequest, frame, false, "Sends a JSON object and waits for the frame that answers it: the one with the same correlation field.", &binding{kind: kindRequest})
+	add("Subscribe", subscribeRequest, frame, true, "Streams the frames the server sends until the call is cancelled.", &binding{kind: kindSubscribe})
+	for _, s := range d.sends {
+		name := protogen.Identifier(s.message.name, "Message")
+		request, key, err := payloadMessage(schemas, name, s.message)
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", s.message.name, err)
+		}
+		fixed := discriminators(s.message.payload, d.schemas)
+		add("Send"+name, request, sendResponse, false, s.message.doc, &binding{kind: kindSend, typed: true, payloadKey: key, fixed: fixed})
+		if s.reply == nil {
+			continue
+		}
+		reply, replyKey, err := payloadMessage(schemas, protogen.Identifier(s.reply.name, "Reply"), s.reply)
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", s.reply.name, err)
+		}
+		doc := strings.TrimSpace(s.message.doc + " Waits for the " + s.reply.name + " that answers it.")
+		add("Request"+name, request, reply, false, doc, &binding{kind: kindRequest, typed: true, payloadKey: key, fixed: fixed, replyTyped: true, replyKey: replyKey})
+	}
+	for _, m := range d.receives {
+		name := protogen.Identifier(m.name, "Message")
+		response, key, err := payloadMessage(schemas, name, m)
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", m.name, err)
+		}
+		doc := strings.TrimSpace(m.doc + " Streams each " + m.name + " the server sends.")
+		add("Subscribe"+name, subscribeRequest, response, true, doc, &binding{kind: kindSubscribe, typed: true, payloadKey: key, fixed: discriminators(m.payload, d.schemas)})
+	}
+
+	return &generated{
+		proto:           schemas.Proto("service " + serviceName + " {\n" + rpcs.String() + "}\n"),
+		serviceTypeName: serviceTypeName,
+		bindings:        bindings,
+	}, nil
+}
+
+// payloadMessage is the message a payload travels in: the one its schema maps
+// to when it is an object, or else one with the payload in its "payload" field,
+// which is then the key it returns.
+func payloadMessage(schemas *openapi.Schemas, name string, m *message) (string, string, error) {
+	object, err := schemas.Object(name, m.payload)
+	if err != nil || object != "" {
+		return object, "", err
+	}
+	wrapper, err := schemas.Message(name+"Payload", []openapi.SchemaField{{Name: "payload", Schema: m.payload}})
+	return wrapper, "payload", err
+}
+
+func writeRPC(out *strings.Builder, name, request, response string, stream bool, doc string) {
+	if doc != "" {
+		fmt.Fprintf(out, "  // %s\n", doc)
+	}
+	if stream {
+		response = "stream " + response
+	}
+	fmt.Fprintf(out, "  rpc %s(%s) returns (%s);\n", name, request, response)
+}
@@ -0,0 +1,164 @@
+// Package websocket implements the built-in "websocket" app: a JSON-over-WebSocket
+// API, rendered as a proto surface kaja can browse and call.
+//
+// Every app has Send, which sends a frame; Request, which sends a JSON object and
+// waits for the frame that answers it - the one whose correlation field, "id"
+// unless the app names another, has the same value; and Subscribe, which streams
+// the frames the server sends. An AsyncAPI document, from a URL or a workspace
+// file, adds a method of each for the messages it describes, which take and
+// return their payloads. The calls of an opened app share one connection, dialled
+// by the first with the credential kaja holds for the app; a handshake the server
+// refuses is an apps.UpstreamError.
+package websocket
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wham/kaja/v2/internal/workspace"
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/openapi"
+	"github.com/wham/kaja/v2/pkg/apps/protogen"
+	"github.com/wham/kaja/v2/pkg/apps/rpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// replyTimeout is how long Request waits for its reply, unless the call says
+// otherwise, and bounds the handshake.
+const replyTimeout = 30 * time.Second
+
+// App is the websocket app factory. Register it with the apps.Manager.
+type App struct{}
+
+func New() *App { return &App{} }
+
+func (a *App) Open(parameters map[string]string, protoDir string, log func(string)) (*apps.Opened, error) {
+	override := strings.TrimSpace(parameters["url"])
+	if override != "" {
+		if err := requireScheme(override, "ws", "wss"); err != nil {
+			return nil, err
+		}
+	}
+	credential := Credential(parameters)
+
+	d, err := loadAsyncAPI(
+		strings.TrimSpace(parameters["asyncapi_url"]),
+		workspace.Resolve(strings.TrimSpace(parameters["asyncapi_file"])),
+		&http.Client{Timeout: 60 * time.Second},
+		log,
+	)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := override
+	if d != nil {
+		log(fmt.Sprintf("Loaded %q with %d message(s) to send and %d to receive", d.title, len(d.sends), len(d.receives)))
+		if endpoint == "" {
+			endpoint = d.server
+		}
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("set url, or an AsyncAPI document with a ws or wss server")
+	}
+	log("WebSocket endpoint: " + endpoint)
+
+	gen, err := generateProto(d)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(protoDir, "websocket.proto"), []byte(gen.proto), 0o644); err != nil {
+		return nil, fmt.Errorf("writing proto: %w", err)
+	}
+	if err := openapi.WriteHTTPProto(protoDir); err != nil {
+		return nil, err
+	}
+	log(fmt.Sprintf("Generated %s with %d method(s)", gen.serviceTypeName, len(gen.bindings)))
+
+	methods, err := protogen.CompileMethods(protoDir, "websocket.proto", gen.bindings, bindMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	correlation := strings.TrimSpace(parameters["correlation_field"])
+	if correlation == "" {
+		correlation = "id"
+	}
+	var subprotocols []string
+	for _, protocol := range strings.Split(parameters["subprotocols"], ",") {
+		if protocol = strings.TrimSpace(protocol); protocol != "" {
+			subprotocols = append(subprotocols, protocol)
+		}
+	}
+
+	return &apps.Opened{Instance: &instance{
+		endpoint:     endpoint,
+		methods:      methods,
+		credential:   credential,
+		subprotocols: subprotocols,
+		correlation:  strings.Split(correlation, "."),
+	}}, nil
+}
+
+// Credential turns a websocket app's authentication parameters into the headers
+// its handshake carries, the way a gRPC app's are. A token with no scheme named
+// is a bearer token.
+func Credential(parameters map[string]string) map[string]string {
+	if strings.TrimSpace(parameters["auth"]) == "" && strings.TrimSpace(parameters["token"]) != "" {
+		withScheme := make(map[string]string, len(parameters)+1)
+		for name, value := range parameters {
+			withScheme[name] = value
+		}
+		withScheme["auth"] = rpc.AuthBearer
+		parameters = withScheme
+	}
+	return rpc.Metadata(parameters)
+}
+
+// boundMethod is one generated method: what it does with the connection, and
+// the descriptors its request and response are decoded and encoded with.
+type boundMethod struct {
+	binding *binding
+	input   protoreflect.MessageDescriptor
+	output  protoreflect.MessageDescriptor
+}
+
+// bindMethod pairs a binding with the compiled method it is keyed by.
+func bindMethod(binding *binding, method protoreflect.MethodDescriptor) *boundMethod {
+	return &boundMethod{binding: binding, input: method.Input(), output: method.Output()}
+}
+
+// requireScheme rejects URLs with a scheme other than those given, so a
+// configuration or a document can't make the app connect over other schemes.
+func requireScheme(rawURL string, schemes ...string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported URL scheme %q in %q (only %s are allowed)", u.Scheme, rawURL, strings.Join(schemes, " and "))
+}
+
+func readLimited(body io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return content, nil
+}
+
+func lastSegment(s string) string {
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
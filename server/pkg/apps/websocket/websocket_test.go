@@ -0,0 +1,351 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+
+	"github.com/wham/kaja/v2/pkg/apps"
+	"github.com/wham/kaja/v2/pkg/apps/appstest"
+)
+
+// market is a WebSocket server that answers a ping with a pong carrying its id,
+// after a heartbeat that answers nothing, and a subscribe with trades among
+// frames of other kinds. It refuses a handshake without its token, when it has
+// one.
+type market struct {
+	url         string
+	token       string
+	dials       atomic.Int32
+	header      http.Header
+	received    chan map[string]any
+	subprotocol string
+}
+
+func newMarket(t *testing.T, token string) *market {
+	t.Helper()
+	m := &market{token: token, received: make(chan map[string]any, 16)}
+	upgrader := gorilla.Upgrader{Subprotocols: []string{"market.v1"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.token != "" && r.Header.Get("Authorization") != "Bearer "+m.token {
+			http.Error(w, "no entry", http.StatusUnauthorized)
+			return
+		}
+		m.header = r.Header
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		m.dials.Add(1)
+		m.subprotocol = ws.Subprotocol()
+		write := func(value any) {
+			if text, ok := value.(string); ok {
+				ws.WriteMessage(gorilla.TextMessage, []byte(text))
+				return
+			}
+			ws.WriteJSON(value)
+		}
+		for {
+			_, data, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			var message map[string]any
+			json.Unmarshal(data, &message)
+			m.received <- message
+			switch message["type"] {
+			case "ping":
+				write(map[string]any{"type": "heartbeat"})
+				write(map[string]any{"type": "pong", "id": message["id"]})
+			case "subscribe":
+				write("tick")
+				write(map[string]any{"type": "heartbeat"})
+				write(map[string]any{"type": "trade", "symbol": "ABC", "price": 1})
+				write(map[string]any{"type": "trade", "symbol": "XYZ", "price": 2})
+				write(map[string]any{"type": "trade", "symbol": "ABC", "price": 3})
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	m.url = "ws" + strings.TrimPrefix(server.URL, "http")
+	return m
+}
+
+func open(t *testing.T, parameters map[string]string) (*instance, string) {
+	t.Helper()
+	protoDir := t.TempDir()
+	opened, err := New().Open(parameters, protoDir, func(string) {})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(protoDir, "websocket.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := opened.Instance.(*instance)
+	t.Cleanup(func() {
+		for _, conn := range in.conns {
+			conn.close()
+		}
+	})
+	return in, string(generated)
+}
+
+// request encodes requestJSON as the request of the method at path.
+func request(t *testing.T, in *instance, path, requestJSON string) []byte {
+	t.Helper()
+	bound := in.methods[path]
+	if bound == nil {
+		t.Fatalf("no method %s", path)
+	}
+	body, err := appstest.EncodeRequest(bound.input, requestJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// decode decodes body, a response of the method at path.
+func decode(t *testing.T, in *instance, path string, body []byte) map[string]any {
+	t.Helper()
+	decoded, err := appstest.DecodeResponse(in.methods[path].output, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+// call runs Send, Request, or a method the document adds, at path.
+func call(in *instance, t *testing.T, path, requestJSON string) (map[string]any, error) {
+	t.Helper()
+	result, err := in.Invoke(path, request(t, in, path, requestJSON), nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode(t, in, path, result.Body), nil
+}
+
+// subscribe runs a subscription until it has streamed n messages.
+func subscribe(t *testing.T, in *instance, path, requestJSON string, n int) []map[string]any {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var streamed []map[string]any
+	_, err := in.InvokeStream(ctx, path, request(t, in, path, requestJSON), nil, func(message []byte) error {
+		streamed = append(streamed, decode(t, in, path, message))
+		if len(streamed) == n {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("InvokeStream %s ended with %v after %v", path, err, streamed)
+	}
+	return streamed
+}
+
+func TestSendAndRequest(t *testing.T) {
+	m := newMarket(t, "secret")
+	in, generated := open(t, map[string]string{"url": m.url, "token": "secret", "subprotocols": "market.v1"})
+	for _, rpc := range []string{
+		"rpc Send(SendRequest) returns (SendResponse);",
+		"rpc Request(RequestRequest) returns (Frame);",
+		"rpc Subscribe(SubscribeRequest) returns (stream Frame);",
+	} {
+		if !strings.Contains(generated, rpc) {
+			t.Errorf("generated proto is missing %q:\n%s", rpc, generated)
+		}
+	}
+
+	if _, err := call(in, t, "websocket.WebSocket/Send", `{"message": {"type": "hello"}}`); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-m.received; got["type"] != "hello" {
+		t.Errorf("server received %v", got)
+	}
+	if got := m.header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("handshake Authorization = %q", got)
+	}
+	if m.subprotocol != "market.v1" {
+		t.Errorf("subprotocol = %q", m.subprotocol)
+	}
+
+	resp, err := call(in, t, "websocket.WebSocket/Request", `{"message": {"type": "ping"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := json.Marshal(resp["message"]); string(got) != `{"id":1,"type":"pong"}` {
+		t.Errorf("Request = %v, want the pong with the id it was given", resp)
+	}
+	resp, err = call(in, t, "websocket.WebSocket/Request", `{"message": {"type": "ping", "id": "mine"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := json.Marshal(resp["message"]); string(got) != `{"id":"mine","type":"pong"}` {
+		t.Errorf("Request = %v, want the pong with the caller's id", resp)
+	}
+	if n := m.dials.Load(); n != 1 {
+		t.Errorf("%d connections, want the calls to share one", n)
+	}
+
+	if _, err := call(in, t, "websocket.WebSocket/Request", `{"message": {"type": "quiet"}, "timeoutMs": "50"}`); err == nil || !strings.Contains(err.Error(), "didn't answer within 50ms") {
+		t.Errorf("Request without a reply = %v", err)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	m := newMarket(t, "")
+	in, _ := open(t, map[string]string{"url": m.url})
+	streamed := subscribe(t, in, "websocket.WebSocket/Subscribe", `{"message": {"type": "subscribe"}, "match": {"type": "trade", "symbol": "ABC"}}`, 2)
+	var prices []any
+	for _, frame := range streamed {
+		prices = append(prices, frame["message"].(map[string]any)["price"])
+	}
+	if got, _ := json.Marshal(prices); string(got) != "[1,3]" {
+		t.Errorf("streamed %v, want the ABC trades", streamed)
+	}
+
+	streamed = subscribe(t, in, "websocket.WebSocket/Subscribe", `{"message": {"type": "subscribe"}}`, 2)
+	if streamed[0]["text"] != "tick" || streamed[1]["message"].(map[string]any)["type"] != "heartbeat" {
+		t.Errorf("streamed %v, want every frame", streamed)
+	}
+	if n := m.dials.Load(); n != 1 {
+		t.Errorf("%d connections, want the subscriptions to share one", n)
+	}
+}
+
+func TestHandshakeHeaders(t *testing.T) {
+	m := newMarket(t, "")
+	in, _ := open(t, map[string]string{"url": m.url})
+	send := func(user string) {
+		t.Helper()
+		body := request(t, in, "websocket.WebSocket/Send", `{"message": {"type": "hello"}}`)
+		if _, err := in.Invoke("websocket.WebSocket/Send", body, map[string]string{"x-user": user}); err != nil {
+			t.Fatal(err)
+		}
+		<-m.received
+	}
+
+	send("ada")
+	send("ada")
+	if n := m.dials.Load(); n != 1 {
+		t.Errorf("%d connections, want calls with the same headers to share one", n)
+	}
+	send("bob")
+	if n := m.dials.Load(); n != 2 {
+		t.Errorf("%d connections, want a call with other headers to get its own", n)
+	}
+	if got := m.header.Get("X-User"); got != "bob" {
+		t.Errorf("handshake X-User = %q, want the second caller's", got)
+	}
+	send("ada")
+	if n := m.dials.Load(); n != 2 {
+		t.Errorf("%d connections, want the first caller's connection reused", n)
+	}
+}
+
+func TestHandshakeRefused(t *testing.T) {
+	m := newMarket(t, "secret")
+	in, _ := open(t, map[string]string{"url": m.url, "token": "wrong"})
+	_, err := call(in, t, "websocket.WebSocket/Send", `{"text": "hello"}`)
+	var upstream *apps.UpstreamError
+	if !errors.As(err, &upstream) || upstream.Status != http.StatusUnauthorized || !strings.Contains(string(upstream.Body), "no entry") {
+		t.Fatalf("Send = %v, want the 401 the handshake got", err)
+	}
+}
+
+const marketDocument = `asyncapi: 3.0.0
+info:
+  title: Market
+servers:
+  production:
+    host: HOST
+    protocol: ws
+channels:
+  market:
+    address: /
+    messages:
+      ping:
+        payload:
+          type: object
+          properties:
+            type: {type: string, const: ping}
+            id: {type: integer}
+      pong:
+        payload: {$ref: '#/components/schemas/Pong'}
+      trade:
+        summary: A trade was made.
+        payload:
+          type: object
+          properties:
+            type: {type: string, enum: [trade]}
+            symbol: {type: string}
+            price: {type: number}
+operations:
+  ping:
+    action: send
+    channel: {$ref: '#/channels/market'}
+    messages: [{$ref: '#/channels/market/messages/ping'}]
+    reply:
+      channel: {$ref: '#/channels/market'}
+      messages: [{$ref: '#/channels/market/messages/pong'}]
+  trades:
+    action: receive
+    channel: {$ref: '#/channels/market'}
+    messages: [{$ref: '#/channels/market/messages/trade'}]
+components:
+  schemas:
+    Pong:
+      type: object
+      properties:
+        type: {type: string}
+        id: {type: integer}
+`
+
+func TestAsyncAPI(t *testing.T) {
+	m := newMarket(t, "")
+	file := filepath.Join(t.TempDir(), "market.yaml")
+	document := strings.Replace(marketDocument, "HOST", strings.TrimPrefix(m.url, "ws://"), 1)
+	if err := os.WriteFile(file, []byte(document), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	in, generated := open(t, map[string]string{"asyncapi_file": file})
+	for _, rpc := range []string{
+		"service Market {",
+		"rpc SendPing(Ping) returns (SendResponse);",
+		"rpc RequestPing(Ping) returns (Pong);",
+		"// A trade was made. Streams each trade the server sends.",
+		"rpc SubscribeTrade(SubscribeRequest) returns (stream Trade);",
+	} {
+		if !strings.Contains(generated, rpc) {
+			t.Errorf("generated proto is missing %q:\n%s", rpc, generated)
+		}
+	}
+
+	resp, err := call(in, t, "websocket.Market/RequestPing", `{}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := <-m.received; got["type"] != "ping" {
+		t.Errorf("server received %v, want the ping with its type filled in", got)
+	}
+	if resp["type"] != "pong" || resp["id"] != float64(1) {
+		t.Errorf("RequestPing = %v", resp)
+	}
+
+	streamed := subscribe(t, in, "websocket.Market/SubscribeTrade", `{"message": {"type": "subscribe"}}`, 3)
+	if got, _ := json.Marshal(streamed); string(got) != `[{"price":1,"symbol":"ABC","type":"trade"},{"price":2,"symbol":"XYZ","type":"trade"},{"price":3,"symbol":"ABC","type":"trade"}]` {
+		t.Errorf("SubscribeTrade streamed %s, want the trades", got)
+	}
+}
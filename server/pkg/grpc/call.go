@@ -0,0 +1,230 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+// Compression modes. An empty mode sends requests uncompressed, which is what
+// kaja did before an app could say.
+const (
+	CompressionNone = "none"
+	CompressionGzip = gzip.Name
+	CompressionZstd = "zstd"
+)
+
+// CallOptions is everything about a call the connection doesn't decide: the
+// protocol it is spoken in, the encoding its request travels in, and how large
+// a message either side may carry. The zero value is grpc-go's defaults -
+// native gRPC, uncompressed, and 4 MB received - so a caller with nothing to
+// configure passes it and behaves as kaja always has.
+type CallOptions struct {
+	// Protocol is ProtocolGRPC, ProtocolGRPCWeb, ProtocolConnect or empty for
+	// native gRPC.
+	Protocol string
+	// Compression is CompressionGzip, CompressionZstd, CompressionNone or empty.
+	Compression string
+	// MaxReceiveBytes and MaxSendBytes bound a single message. Zero leaves
+	// grpc-go's own limit in place.
+	MaxReceiveBytes int
+	MaxSendBytes    int
+}
+
+// validate rejects an encoding kaja can't produce, rather than letting grpc-go
+// fail the call with a message about its codec registry.
+func (o CallOptions) validate() error {
+	switch o.Protocol {
+	case "", ProtocolGRPC, ProtocolGRPCWeb, ProtocolConnect:
+	default:
+		return fmt.Errorf("unsupported protocol %q (use %q, %q or %q)", o.Protocol, ProtocolGRPC, ProtocolGRPCWeb, ProtocolConnect)
+	}
+	switch o.Compression {
+	case "", CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	}
+	return fmt.Errorf("unsupported compression %q (use %q, %q or %q)", o.Compression, CompressionGzip, CompressionZstd, CompressionNone)
+}
+
+// Native reports whether the call is spoken as native gRPC, over HTTP/2 on a
+// grpc-go connection, rather than over one of the HTTP protocols.
+func (o CallOptions) Native() bool {
+	return o.Protocol == "" || o.Protocol == ProtocolGRPC
+}
+
+// grpcOptions turns the options into the per-call options grpc-go reads.
+func (o CallOptions) grpcOptions() ([]grpc.CallOption, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	var options []grpc.CallOption
+	if o.Compression != "" && o.Compression != CompressionNone {
+		options = append(options, grpc.UseCompressor(o.Compression))
+	}
+	if o.MaxReceiveBytes > 0 {
+		options = append(options, grpc.MaxCallRecvMsgSize(o.MaxReceiveBytes))
+	}
+	if o.MaxSendBytes > 0 {
+		options = append(options, grpc.MaxCallSendMsgSize(o.MaxSendBytes))
+	}
+	return options, nil
+}
+
+func init() {
+	// gzip registers itself on import. zstd is not one grpc-go ships, and a
+	// registered compressor is also one the server is told it may answer in.
+	encoding.RegisterCompressor(zstdCompressor{})
+}
+
+// zstdCompressor is a grpc-go compressor over klauspost's zstd. Both the
+// encoder and the decoder are safe for concurrent use through their *All
+// methods, so one of each serves every call.
+type zstdCompressor struct{}
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+)
+
+func (zstdCompressor) Name() string { return CompressionZstd }
+
+func (zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &zstdWriter{destination: w}, nil
+}
+
+func (zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	compressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	decompressed, err := zstdDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(decompressed), nil
+}
+
+// zstdWriter collects a message and compresses it whole on Close, which is
+// when grpc-go is done writing it.
+type zstdWriter struct {
+	destination io.Writer
+	buffer      bytes.Buffer
+}
+
+func (w *zstdWriter) Write(p []byte) (int, error) { return w.buffer.Write(p) }
+
+func (w *zstdWriter) Close() error {
+	_, err := w.destination.Write(zstdEncoder.EncodeAll(w.buffer.Bytes(), nil))
+	return err
+}
+
+// exchange is what one call put on the wire and got back, beyond the message:
+// the address it went to, the encoding each way and the headers the server
+// answered with. grpc-go
+// keeps the encoding out of the metadata it hands back, so it is read off the
+// stats events as the transport sees them.
+type exchange struct {
+	mu               sync.Mutex
+	peer             string
+	requestEncoding  string
+	responseEncoding string
+	response         metadata.MD
+}
+
+type exchangeKey struct{}
+
+// withExchange attaches a fresh exchange to a call's context for exchangeHandler
+// to fill in.
+func withExchange(ctx context.Context) (context.Context, *exchange) {
+	e := &exchange{}
+	return context.WithValue(ctx, exchangeKey{}, e), e
+}
+
+// exchangeHandler records into the exchange on a call's context. It is a dial
+// option, so it sees every call on a connection; a call that attached no
+// exchange is passed over.
+type exchangeHandler struct{}
+
+func (exchangeHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context { return ctx }
+
+func (exchangeHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	e, ok := ctx.Value(exchangeKey{}).(*exchange)
+	if !ok {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch s := s.(type) {
+	case *stats.OutHeader:
+		e.requestEncoding = s.Compression
+		if s.RemoteAddr != nil {
+			e.peer = s.RemoteAddr.String()
+		}
+	case *stats.InHeader:
+		e.responseEncoding = s.Compression
+		e.response = s.Header
+	}
+}
+
+func (exchangeHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (exchangeHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// requestHeaders is the request side as the Headers view shows it. It carries
+// only what the transport settled, not the call's metadata: that is the
+// client's own headers plus the app's credential, and the credential stays
+// where kaja holds it.
+func (e *exchange) requestHeaders() map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	headers := map[string]string{"grpc-accept-encoding": acceptedEncodings()}
+	if e.requestEncoding != "" {
+		headers["grpc-encoding"] = e.requestEncoding
+	}
+	if e.peer != "" {
+		headers[PeerHeader] = e.peer
+	}
+	return headers
+}
+
+// responseHeaders is the header metadata the server answered with, and the
+// encoding its messages arrived in.
+func (e *exchange) responseHeaders() map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	headers := map[string]string{}
+	for name, values := range e.response {
+		headers[name] = strings.Join(values, ", ")
+	}
+	if e.responseEncoding != "" {
+		headers["grpc-encoding"] = e.responseEncoding
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+// acceptedEncodings is what kaja tells a server it may answer in: everything
+// registered with grpc-go, which is what it advertises on its own.
+func acceptedEncodings() string {
+	names := []string{}
+	for _, name := range []string{CompressionGzip, CompressionZstd} {
+		if encoding.GetCompressor(name) != nil {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
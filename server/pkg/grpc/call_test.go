@@ -0,0 +1,213 @@
+package grpc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+)
+
+func TestCallOptionsGRPCOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  CallOptions
+		expected int
+		fails    bool
+	}{
+		{"the zero value changes nothing", CallOptions{}, 0, false},
+		{"none is no compressor", CallOptions{Compression: CompressionNone}, 0, false},
+		{"gzip", CallOptions{Compression: CompressionGzip}, 1, false},
+		{"zstd and both limits", CallOptions{Compression: CompressionZstd, MaxReceiveBytes: 1 << 24, MaxSendBytes: 1 << 20}, 3, false},
+		{"an encoding kaja can't produce", CallOptions{Compression: "brotli"}, 0, true},
+		{"the HTTP protocols add nothing grpc-go reads", CallOptions{Protocol: ProtocolConnect}, 0, false},
+		{"a protocol kaja doesn't speak", CallOptions{Protocol: "thrift"}, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := test.options.grpcOptions()
+			if test.fails {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(options) != test.expected {
+				t.Errorf("got %d call options, want %d", len(options), test.expected)
+			}
+		})
+	}
+}
+
+func TestZstdCompressorRoundTrip(t *testing.T) {
+	message := []byte(strings.Repeat("seat 14C, row 14, aisle; ", 200))
+
+	var compressed bytes.Buffer
+	writer, err := zstdCompressor{}.Compress(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if compressed.Len() >= len(message) {
+		t.Errorf("compressed to %d bytes from %d, want smaller", compressed.Len(), len(message))
+	}
+
+	reader, err := zstdCompressor{}.Decompress(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decompressed, message) {
+		t.Error("the message did not survive the round trip")
+	}
+}
+
+func TestExchangeHeaders(t *testing.T) {
+	e := &exchange{requestEncoding: CompressionZstd, responseEncoding: CompressionGzip}
+	if got := e.requestHeaders()["grpc-encoding"]; got != CompressionZstd {
+		t.Errorf("request grpc-encoding = %q, want %q", got, CompressionZstd)
+	}
+	if got := e.requestHeaders()["grpc-accept-encoding"]; got != "gzip,zstd" {
+		t.Errorf("request grpc-accept-encoding = %q, want both registered encodings", got)
+	}
+	if got := e.responseHeaders()["grpc-encoding"]; got != CompressionGzip {
+		t.Errorf("response grpc-encoding = %q, want %q", got, CompressionGzip)
+	}
+
+	// A server that sent nothing and compressed nothing leaves no response side.
+	if headers := (&exchange{}).responseHeaders(); headers != nil {
+		t.Errorf("responseHeaders() = %v, want nil", headers)
+	}
+}
+
+// echoServer answers every method with the request message repeated times
+// times, over the raw-bytes codec kaja's client speaks.
+func echoServer(t *testing.T, times int) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(
+		grpc.ForceServerCodec(&grpcCodec{}),
+		grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+			var request []byte
+			if err := stream.RecvMsg(&request); err != nil {
+				return err
+			}
+			return stream.SendMsg(bytes.Repeat(request, times))
+		}),
+	)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return "dns:" + listener.Addr().String()
+}
+
+func TestInvokeCompression(t *testing.T) {
+	target := echoServer(t, 1)
+
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			client, err := NewClientFromString(target, TLSOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.WithCallOptions(CallOptions{Compression: compression})
+
+			response, err := client.InvokeWithTimeout("/echo.Echo/Echo", []byte("hello"), 5*time.Second, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(response.Body) != "hello" {
+				t.Errorf("body = %q, want hello", response.Body)
+			}
+			if got := response.RequestHeaders["grpc-encoding"]; got != compression {
+				t.Errorf("request grpc-encoding = %q, want %q", got, compression)
+			}
+			if response.RequestHeaders[EndpointHeader] != target || response.RequestHeaders[PeerHeader] != strings.TrimPrefix(target, "dns:") {
+				t.Errorf("RequestHeaders = %v, want the endpoint and the address that answered", response.RequestHeaders)
+			}
+			// grpc-go answers in the encoding it was asked in.
+			if got := response.ResponseHeaders["grpc-encoding"]; got != compression {
+				t.Errorf("response grpc-encoding = %q, want %q", got, compression)
+			}
+		})
+	}
+}
+
+func TestInvokeMaxReceiveBytes(t *testing.T) {
+	target := echoServer(t, 1000)
+
+	client, err := NewClientFromString(target, TLSOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.WithCallOptions(CallOptions{MaxReceiveBytes: 100})
+	_, err = client.InvokeWithTimeout("/echo.Echo/Echo", []byte("0123456789"), 5*time.Second, nil)
+	if status.Code(errors.Unwrap(err)) != codes.ResourceExhausted {
+		t.Fatalf("err = %v, want RESOURCE_EXHAUSTED", err)
+	}
+
+	client.WithCallOptions(CallOptions{MaxReceiveBytes: 1 << 20})
+	response, err := client.InvokeWithTimeout("/echo.Echo/Echo", []byte("0123456789"), 5*time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Body) != 10000 {
+		t.Errorf("body is %d bytes, want 10000", len(response.Body))
+	}
+}
+
+func TestInvokeRoundRobin(t *testing.T) {
+	// Two servers behind one target, the way replicas sit behind a DNS name.
+	first, second := echoServer(t, 1), echoServer(t, 1)
+	addresses := []string{strings.TrimPrefix(first, "dns:"), strings.TrimPrefix(second, "dns:")}
+	builder := manual.NewBuilderWithScheme("replicas")
+	builder.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: addresses[0]}, {Addr: addresses[1]}}})
+	resolver.Register(builder)
+
+	client, err := NewClientFromString("replicas:///seating", TLSOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithLoadBalancing(LoadBalancingRoundRobin)
+
+	served := map[string]bool{}
+	for range 10 {
+		response, err := client.InvokeWithTimeout("/echo.Echo/Echo", []byte("hello"), 5*time.Second, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		served[response.RequestHeaders[PeerHeader]] = true
+	}
+	for _, address := range addresses {
+		if !served[address] {
+			t.Errorf("served by %v, want both replicas", served)
+		}
+	}
+
+	if _, err := client.WithLoadBalancing("least_request").InvokeWithTimeout("/echo.Echo/Echo", nil, time.Second, nil); err == nil {
+		t.Error("expected an unsupported policy to fail the call")
+	}
+}
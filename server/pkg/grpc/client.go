@@ -11,6 +11,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/wham/kaja/v2/pkg/limit"
+	"github.com/wham/kaja/v2/pkg/retry"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -26,12 +28,20 @@ var (
 // sharedConnection returns the cached connection for the given target, dialing
 // (lazily — grpc.NewClient does not block) and caching one on first use. Two apps
 // pointing at one host with different certificates are two connections, so the options
-// are part of the key.
-func sharedConnection(target string, useTLS bool, options TLSOptions) (*grpc.ClientConn, error) {
+// are part of the key, and so is the load-balancing policy.
+func sharedConnection(target string, useTLS bool, options TLSOptions, balancing string) (*grpc.ClientConn, error) {
 	key := target
 	if useTLS {
 		key = "tls\x00" + options.key() + "\x00" + target
 	}
+	if balancing != "" {
+		key = balancing + "\x00" + key
+	}
+
+	serviceConfig, err := loadBalancingConfig(balancing)
+	if err != nil {
+		return nil, err
+	}
 
 	connectionsMu.Lock()
 	defer connectionsMu.Unlock()
@@ -45,7 +55,16 @@ func sharedConnection(target string, useTLS bool, options TLSOptions) (*grpc.Cli
 		return nil, err
 	}
 
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds), grpc.WithDefaultCallOptions(grpc.ForceCodec(&grpcCodec{})))
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(&grpcCodec{})),
+		grpc.WithStatsHandler(exchangeHandler{}),
+	}
+	if serviceConfig != "" {
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
+	conn, err := grpc.NewClient(target, dialOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
 	}
@@ -78,9 +97,22 @@ func (c *grpcCodec) Name() string {
 
 // Client is a gRPC client that can invoke methods on a target server.
 type Client struct {
-	target  string
-	useTLS  bool
-	options TLSOptions
+	target    string
+	base      *url.URL
+	useTLS    bool
+	options   TLSOptions
+	calls     CallOptions
+	balancing string
+	retry     retry.Policy
+	gate      *limit.Gate
+}
+
+// Response is what a unary call returned: the response message, and what the
+// call exchanged on the wire besides it, for the Headers view.
+type Response struct {
+	Body            []byte
+	RequestHeaders  map[string]string
+	ResponseHeaders map[string]string
 }
 
 // ShouldUseTLS reads the transport off the target URL: an "https" or "grpcs" scheme,
@@ -133,6 +165,7 @@ func ToGRPCTarget(target *url.URL) string {
 func NewClient(target *url.URL, options TLSOptions) *Client {
 	return &Client{
 		target:  ToGRPCTarget(target),
+		base:    target,
 		useTLS:  options.UseTLS(target),
 		options: options,
 	}
@@ -148,19 +181,101 @@ func NewClientFromString(target string, options TLSOptions) (*Client, error) {
 	return NewClient(parsed, options), nil
 }
 
+// WithCallOptions sets how every call c makes is shaped - spoken in the
+// protocol, compressed, and bounded in size, the way the app asks - and returns
+// c. It changes c itself rather than deriving a client from it.
+func (c *Client) WithCallOptions(options CallOptions) *Client {
+	c.calls = options
+	return c
+}
+
+// WithLoadBalancing sets how c's connection spreads calls across the addresses
+// its target resolves to - LoadBalancingPickFirst or LoadBalancingRoundRobin;
+// empty is grpc-go's default, pick_first - and returns c.
+func (c *Client) WithLoadBalancing(policy string) *Client {
+	c.balancing = policy
+	return c
+}
+
+// WithRetry sets c to make each unary call again, as policy allows, when it
+// fails in a way that passes, and returns c.
+func (c *Client) WithRetry(policy retry.Policy) *Client {
+	c.retry = policy
+	return c
+}
+
+// WithGate sets c's calls to wait their turn at gate, the app's limits on how
+// fast and how widely its calls go out, and returns c.
+func (c *Client) WithGate(gate *limit.Gate) *Client {
+	c.gate = gate
+	return c
+}
+
 // UseTLS returns whether TLS is enabled for this client.
 func (c *Client) UseTLS() bool {
 	return c.useTLS
 }
 
 // Invoke calls a gRPC method, named "/package.Service/Method". Request and response
-// are raw protobuf bytes; headers are passed as gRPC metadata.
-func (c *Client) Invoke(ctx context.Context, method string, request []byte, headers map[string]string) ([]byte, error) {
+// are raw protobuf bytes; headers are passed as gRPC metadata, or as HTTP headers
+// over gRPC-Web and Connect.
+func (c *Client) Invoke(ctx context.Context, method string, request []byte, headers map[string]string) (*Response, error) {
+	if err := c.retry.Validate(); err != nil {
+		return nil, err
+	}
+
+	release, queued, err := c.gate.Enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var response *Response
+	attempts, err := c.retry.Invoke(ctx, func() error {
+		var err error
+		response, err = c.invoke(ctx, method, request, headers)
+		return err
+	})
+	if err != nil {
+		if len(attempts) > 1 {
+			return nil, &retry.Error{Err: err, Attempts: attempts}
+		}
+		return nil, err
+	}
+	// Which endpoint a call went to is part of what it exchanged, for an app
+	// that spreads its calls across several, and so is every attempt it took
+	// to get an answer there and how long it waited to make the first.
+	response.RequestHeaders = limit.Record(attempts.Record(response.RequestHeaders), queued)
+	response.RequestHeaders[EndpointHeader] = c.target
+	return response, nil
+}
+
+func (c *Client) invoke(ctx context.Context, method string, request []byte, headers map[string]string) (*Response, error) {
 	if !strings.HasPrefix(method, "/") {
 		method = "/" + method
 	}
 
-	conn, err := sharedConnection(c.target, c.useTLS, c.options)
+	callOptions, err := c.calls.grpcOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.calls.Protocol {
+	case ProtocolGRPCWeb:
+		response, err := c.invokeGRPCWeb(ctx, method, request, headers)
+		if err != nil {
+			return nil, fmt.Errorf("gRPC-Web invocation failed: %w", err)
+		}
+		return response, nil
+	case ProtocolConnect:
+		response, err := c.invokeConnect(ctx, method, request, headers)
+		if err != nil {
+			return nil, fmt.Errorf("Connect invocation failed: %w", err)
+		}
+		return response, nil
+	}
+
+	conn, err := sharedConnection(c.target, c.useTLS, c.options, c.balancing)
 	if err != nil {
 		return nil, err
 	}
@@ -170,17 +285,23 @@ func (c *Client) Invoke(ctx context.Context, method string, request []byte, head
 		ctx = metadata.NewOutgoingContext(ctx, md)
 	}
 
+	ctx, exchange := withExchange(ctx)
+
 	var response []byte
-	err = conn.Invoke(ctx, method, request, &response)
+	err = conn.Invoke(ctx, method, request, &response, callOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("gRPC invocation failed: %w", err)
 	}
 
-	return response, nil
+	return &Response{
+		Body:            response,
+		RequestHeaders:  exchange.requestHeaders(),
+		ResponseHeaders: exchange.responseHeaders(),
+	}, nil
 }
 
 // InvokeWithTimeout calls a gRPC method with a default timeout.
-func (c *Client) InvokeWithTimeout(method string, request []byte, timeout time.Duration, headers map[string]string) ([]byte, error) {
+func (c *Client) InvokeWithTimeout(method string, request []byte, timeout time.Duration, headers map[string]string) (*Response, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	return c.Invoke(ctx, method, request, headers)
@@ -200,7 +321,35 @@ func (c *Client) ServerStream(ctx context.Context, method string, request []byte
 			method = "/" + method
 		}
 
-		conn, err := sharedConnection(c.target, c.useTLS, c.options)
+		callOptions, err := c.calls.grpcOptions()
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		// A stream holds its place in the app's concurrency for as long as it
+		// is open.
+		release, _, err := c.gate.Enter(ctx)
+		if err != nil {
+			return
+		}
+		defer release()
+
+		var overHTTP func(context.Context, string, []byte, map[string]string, chan<- []byte) error
+		switch c.calls.Protocol {
+		case ProtocolGRPCWeb:
+			overHTTP = c.streamGRPCWeb
+		case ProtocolConnect:
+			overHTTP = c.streamConnect
+		}
+		if overHTTP != nil {
+			if err := overHTTP(ctx, method, request, headers, messages); err != nil && ctx.Err() == nil {
+				errc <- fmt.Errorf("stream receive failed: %w", err)
+			}
+			return
+		}
+
+		conn, err := sharedConnection(c.target, c.useTLS, c.options, c.balancing)
 		if err != nil {
 			errc <- err
 			return
@@ -215,7 +364,7 @@ func (c *Client) ServerStream(ctx context.Context, method string, request []byte
 			ServerStreams: true,
 		}
 
-		stream, err := conn.NewStream(ctx, streamDesc, method)
+		stream, err := conn.NewStream(ctx, streamDesc, method, callOptions...)
 		if err != nil {
 			errc <- fmt.Errorf("failed to open stream: %w", err)
 			return
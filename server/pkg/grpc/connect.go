@@ -0,0 +1,227 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Connect speaks unary calls as a plain POST of the message, with errors as a
+// JSON body, and streams as length-prefixed messages closed by a JSON
+// end-of-stream message. https://connectrpc.com/docs/protocol
+
+// invokeConnect is Invoke over Connect's unary protocol.
+func (c *Client) invokeConnect(ctx context.Context, method string, message []byte, headers map[string]string) (*Response, error) {
+	if err := c.calls.checkSend(message); err != nil {
+		return nil, err
+	}
+
+	encoding := c.calls.compressing()
+	if encoding != "" {
+		compressed, err := compress(encoding, message)
+		if err != nil {
+			return nil, err
+		}
+		message = compressed
+	}
+
+	var peer string
+	request, err := c.newHTTPRequest(ctx, method, message, headers, &peer)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/proto")
+	request.Header.Set("Connect-Protocol-Version", "1")
+	request.Header.Set("Accept-Encoding", acceptedEncodings())
+	if encoding != "" {
+		request.Header.Set("Content-Encoding", encoding)
+	}
+	if left, ok := remaining(ctx); ok {
+		request.Header.Set("Connect-Timeout-Ms", formatTimeout(left, time.Millisecond))
+	}
+
+	response, err := c.doHTTP(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	limit := c.calls.receiveLimit()
+	body, err := io.ReadAll(io.LimitReader(response.Body, int64(limit)+1))
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "reading the response: %v", err)
+	}
+	if len(body) > limit {
+		return nil, status.Errorf(codes.ResourceExhausted, "received message larger than max (%d)", limit)
+	}
+	if contentEncoding := response.Header.Get("Content-Encoding"); contentEncoding != "" && contentEncoding != "identity" {
+		if body, err = decompress(contentEncoding, body, limit); err != nil {
+			return nil, err
+		}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, connectUnaryError(response, body)
+	}
+
+	return &Response{
+		Body:            body,
+		RequestHeaders:  requestHeadersOf(request, peer, "Content-Type", "Connect-Protocol-Version", "Accept-Encoding", "Content-Encoding", "Connect-Timeout-Ms"),
+		ResponseHeaders: flattenHeaders(response.Header),
+	}, nil
+}
+
+// streamConnect is ServerStream over Connect's streaming protocol.
+func (c *Client) streamConnect(ctx context.Context, method string, message []byte, headers map[string]string, messages chan<- []byte) error {
+	if err := c.calls.checkSend(message); err != nil {
+		return err
+	}
+
+	flags := byte(0)
+	encoding := c.calls.compressing()
+	if encoding != "" {
+		compressed, err := compress(encoding, message)
+		if err != nil {
+			return err
+		}
+		message, flags = compressed, flagCompressed
+	}
+
+	var peer string
+	request, err := c.newHTTPRequest(ctx, method, envelope(flags, message), headers, &peer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/connect+proto")
+	request.Header.Set("Connect-Protocol-Version", "1")
+	request.Header.Set("Connect-Accept-Encoding", acceptedEncodings())
+	if encoding != "" {
+		request.Header.Set("Connect-Content-Encoding", encoding)
+	}
+	if left, ok := remaining(ctx); ok {
+		request.Header.Set("Connect-Timeout-Ms", formatTimeout(left, time.Millisecond))
+	}
+
+	response, err := c.doHTTP(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return status.Errorf(codeFromHTTP(response.StatusCode), "the server answered %s", response.Status)
+	}
+
+	limit := c.calls.receiveLimit()
+	responseEncoding := response.Header.Get("Connect-Content-Encoding")
+	for {
+		flags, payload, err := readEnvelope(response.Body, limit)
+		if err == io.EOF {
+			return status.Error(codes.Internal, "the server closed the stream without ending it")
+		}
+		if err != nil {
+			return err
+		}
+
+		if flags&flagCompressed != 0 {
+			if responseEncoding == "" || responseEncoding == "identity" {
+				return status.Error(codes.Internal, "the server sent a compressed message without naming its encoding")
+			}
+			if payload, err = decompress(responseEncoding, payload, limit); err != nil {
+				return err
+			}
+		}
+
+		if flags&flagEndStream != 0 {
+			var end struct {
+				Error *connectError `json:"error"`
+			}
+			if err := json.Unmarshal(payload, &end); err != nil {
+				return status.Errorf(codes.Internal, "the server ended the stream unreadably: %v", err)
+			}
+			if end.Error != nil {
+				return end.Error.err(codes.Unknown)
+			}
+			return nil
+		}
+
+		select {
+		case messages <- payload:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// connectError is the JSON a Connect server reports a failure in, as the body
+// of a unary response or inside a stream's end-of-stream message.
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details []struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"details"`
+}
+
+// connectCodes are Connect's names for the gRPC codes, which are the same codes
+// spelled in snake case.
+var connectCodes = map[string]codes.Code{
+	"canceled":            codes.Canceled,
+	"unknown":             codes.Unknown,
+	"invalid_argument":    codes.InvalidArgument,
+	"deadline_exceeded":   codes.DeadlineExceeded,
+	"not_found":           codes.NotFound,
+	"already_exists":      codes.AlreadyExists,
+	"permission_denied":   codes.PermissionDenied,
+	"resource_exhausted":  codes.ResourceExhausted,
+	"failed_precondition": codes.FailedPrecondition,
+	"aborted":             codes.Aborted,
+	"out_of_range":        codes.OutOfRange,
+	"unimplemented":       codes.Unimplemented,
+	"internal":            codes.Internal,
+	"unavailable":         codes.Unavailable,
+	"data_loss":           codes.DataLoss,
+	"unauthenticated":     codes.Unauthenticated,
+}
+
+// err is the failure as a status error, details included, so a caller reads it
+// the same way whichever protocol it came over. fallback is the code when the
+// server named none kaja knows.
+func (e *connectError) err(fallback codes.Code) error {
+	code, ok := connectCodes[e.Code]
+	if !ok {
+		code = fallback
+	}
+	detailed := &spb.Status{Code: int32(code), Message: e.Message}
+	for _, detail := range e.Details {
+		value, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(detail.Value, "="))
+		if err != nil {
+			continue
+		}
+		detailed.Details = append(detailed.Details, &anypb.Any{TypeUrl: "type.googleapis.com/" + detail.Type, Value: value})
+	}
+	return status.FromProto(detailed).Err()
+}
+
+// connectUnaryError reads a failed unary response: the JSON error when the
+// server wrote one, or else the code its HTTP status implies.
+func connectUnaryError(response *http.Response, body []byte) error {
+	fallback := codeFromHTTP(response.StatusCode)
+	if strings.HasPrefix(response.Header.Get("Content-Type"), "application/json") {
+		var failure connectError
+		if json.Unmarshal(body, &failure) == nil && failure.Code != "" {
+			return failure.err(fallback)
+		}
+	}
+	return status.Errorf(fallback, "the server answered %s", response.Status)
+}
@@ -0,0 +1,236 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// gRPC-Web is gRPC's framing over a plain HTTP POST: the same length-prefixed
+// messages, with the trailers HTTP/1.1 can't carry sent as one last frame in the
+// body. https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-WEB.md
+
+// invokeGRPCWeb is Invoke over gRPC-Web.
+func (c *Client) invokeGRPCWeb(ctx context.Context, method string, request []byte, headers map[string]string) (*Response, error) {
+	call, err := c.openGRPCWeb(ctx, method, request, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer call.response.Body.Close()
+
+	message, err := call.next()
+	if err == io.EOF {
+		return nil, status.Error(codes.Internal, "the server answered without a message")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := call.next(); err != io.EOF {
+		if err == nil {
+			return nil, status.Error(codes.Internal, "the server answered a unary call with more than one message")
+		}
+		return nil, err
+	}
+
+	return &Response{
+		Body:            message,
+		RequestHeaders:  call.requestHeaders(),
+		ResponseHeaders: call.responseHeaders(),
+	}, nil
+}
+
+// streamGRPCWeb is ServerStream over gRPC-Web.
+func (c *Client) streamGRPCWeb(ctx context.Context, method string, request []byte, headers map[string]string, messages chan<- []byte) error {
+	call, err := c.openGRPCWeb(ctx, method, request, headers)
+	if err != nil {
+		return err
+	}
+	defer call.response.Body.Close()
+
+	for {
+		message, err := call.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case messages <- message:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// grpcWebCall is one gRPC-Web call in flight: the request as sent, and the
+// response read a frame at a time.
+type grpcWebCall struct {
+	request  *http.Request
+	response *http.Response
+	peer     string
+	encoding string
+	limit    int
+	trailers http.Header
+}
+
+func (c *Client) openGRPCWeb(ctx context.Context, method string, message []byte, headers map[string]string) (*grpcWebCall, error) {
+	if err := c.calls.checkSend(message); err != nil {
+		return nil, err
+	}
+
+	flags := byte(0)
+	encoding := c.calls.compressing()
+	if encoding != "" {
+		compressed, err := compress(encoding, message)
+		if err != nil {
+			return nil, err
+		}
+		message, flags = compressed, flagCompressed
+	}
+
+	var peer string
+	request, err := c.newHTTPRequest(ctx, method, envelope(flags, message), headers, &peer)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/grpc-web+proto")
+	request.Header.Set("Accept", "application/grpc-web+proto")
+	request.Header.Set("X-Grpc-Web", "1")
+	request.Header.Set("Grpc-Accept-Encoding", acceptedEncodings())
+	if encoding != "" {
+		request.Header.Set("Grpc-Encoding", encoding)
+	}
+	if left, ok := remaining(ctx); ok {
+		request.Header.Set("Grpc-Timeout", formatTimeout(left, time.Millisecond)+"m")
+	}
+
+	response, err := c.doHTTP(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// A call that failed before it produced anything is answered trailers-only:
+	// the status is in the headers and the body is empty. A non-200 without one
+	// never reached a gRPC server at all.
+	if response.Header.Get("Grpc-Status") != "" {
+		err := grpcWebStatus(response.Header)
+		if err != nil || response.StatusCode != http.StatusOK {
+			response.Body.Close()
+			if err == nil {
+				err = status.Error(codeFromHTTP(response.StatusCode), response.Status)
+			}
+			return nil, err
+		}
+	} else if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, status.Errorf(codeFromHTTP(response.StatusCode), "the server answered %s", response.Status)
+	}
+
+	return &grpcWebCall{
+		request:  request,
+		response: response,
+		peer:     peer,
+		encoding: response.Header.Get("Grpc-Encoding"),
+		limit:    c.calls.receiveLimit(),
+	}, nil
+}
+
+// next returns the next message. io.EOF means the call ended with an OK status;
+// any other status is returned as the error.
+func (c *grpcWebCall) next() ([]byte, error) {
+	if c.trailers != nil {
+		return nil, io.EOF
+	}
+
+	flags, payload, err := readEnvelope(c.response.Body, c.limit)
+	if err == io.EOF {
+		// No trailers frame: the status, if any, came in the headers.
+		c.trailers = http.Header{}
+		if c.response.Header.Get("Grpc-Status") == "" {
+			return nil, status.Error(codes.Internal, "the server closed the stream without a status")
+		}
+		if err := grpcWebStatus(c.response.Header); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if flags&flagTrailers != 0 {
+		c.trailers = parseGRPCWebTrailers(payload)
+		if err := grpcWebStatus(c.trailers); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	if flags&flagCompressed != 0 {
+		if c.encoding == "" {
+			return nil, status.Error(codes.Internal, "the server sent a compressed message without naming its encoding")
+		}
+		return decompress(c.encoding, payload, c.limit)
+	}
+	return payload, nil
+}
+
+func (c *grpcWebCall) requestHeaders() map[string]string {
+	return requestHeadersOf(c.request, c.peer, "Content-Type", "X-Grpc-Web", "Grpc-Accept-Encoding", "Grpc-Encoding", "Grpc-Timeout")
+}
+
+func (c *grpcWebCall) responseHeaders() map[string]string {
+	return flattenHeaders(c.response.Header, c.trailers)
+}
+
+// parseGRPCWebTrailers reads the trailers frame, which is an HTTP/1 header block.
+func parseGRPCWebTrailers(payload []byte) http.Header {
+	trailers := http.Header{}
+	for _, line := range strings.Split(string(payload), "\r\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		trailers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return trailers
+}
+
+// grpcWebStatus reads a status out of headers or trailers: nil for OK, a status
+// error otherwise, with any details the server attached.
+func grpcWebStatus(header http.Header) error {
+	code, err := strconv.Atoi(header.Get("Grpc-Status"))
+	if err != nil {
+		return status.Errorf(codes.Internal, "the server sent an unreadable grpc-status %q", header.Get("Grpc-Status"))
+	}
+	if code == int(codes.OK) {
+		return nil
+	}
+
+	message := header.Get("Grpc-Message")
+	if unescaped, err := url.PathUnescape(message); err == nil {
+		message = unescaped
+	}
+
+	if details := header.Get("Grpc-Status-Details-Bin"); details != "" {
+		if encoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(details, "=")); err == nil {
+			var detailed spb.Status
+			if proto.Unmarshal(encoded, &detailed) == nil && detailed.GetCode() == int32(code) {
+				return status.FromProto(&detailed).Err()
+			}
+		}
+	}
+
+	return status.Error(codes.Code(code), message)
+}
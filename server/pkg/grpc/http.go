@@ -0,0 +1,299 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+// Protocols a Client speaks to its upstream. Native gRPC is HTTP/2 end to end;
+// the other two are what a service behind an Envoy gRPC-Web filter, or a
+// Connect server, accepts over plain HTTP/1.1. All three carry the same
+// protobuf messages, so the choice is the app's and nothing above the client
+// notices it.
+const (
+	ProtocolGRPC    = "grpc"
+	ProtocolGRPCWeb = "grpc-web"
+	ProtocolConnect = "connect"
+)
+
+// defaultMaxReceiveBytes is grpc-go's own limit on a received message, which
+// the HTTP protocols keep so an app reads the same whichever it speaks.
+const defaultMaxReceiveBytes = 4 << 20
+
+// httpClients caches one HTTP client per way of connecting, for the same reason
+// connections does: the transport pools its connections, and a client per call
+// would dial per call.
+var (
+	httpClientsMu sync.Mutex
+	httpClients   = map[string]*http.Client{}
+)
+
+func sharedHTTPClient(useTLS bool, options TLSOptions) (*http.Client, error) {
+	key := "plaintext"
+	if useTLS {
+		key = "tls\x00" + options.key()
+	}
+
+	httpClientsMu.Lock()
+	defer httpClientsMu.Unlock()
+
+	if client, ok := httpClients[key]; ok {
+		return client, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if useTLS {
+		configuration, err := options.config()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = configuration
+	}
+
+	client := &http.Client{Transport: transport}
+	httpClients[key] = client
+	return client, nil
+}
+
+// HTTPClient is the shared HTTP client for a connection that speaks TLS or not,
+// with options' certificates, for a caller that makes its own HTTP calls the
+// way a gRPC app makes its: a proxied Twirp app.
+func HTTPClient(useTLS bool, options TLSOptions) (*http.Client, error) {
+	return sharedHTTPClient(useTLS, options)
+}
+
+// endpoint is the URL a method is POSTed to over the HTTP protocols. A dns: or
+// grpc: target has no scheme an HTTP client reads, so the scheme follows
+// whether the connection speaks TLS; an http(s) URL keeps its path, which is
+// where a gateway that serves more than one service usually mounts each.
+func (c *Client) endpoint(method string) string {
+	scheme := "http"
+	if c.useTLS {
+		scheme = "https"
+	}
+
+	host := strings.TrimPrefix(c.target, "dns:")
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "//"), "/")
+
+	prefix := ""
+	if s := strings.ToLower(c.base.Scheme); s == "http" || s == "https" {
+		prefix = strings.TrimSuffix(c.base.Path, "/")
+	}
+
+	return scheme + "://" + host + prefix + method
+}
+
+// newHTTPRequest builds the POST both HTTP protocols open a call with, the
+// call's metadata as its headers. peer is set to the address the request is
+// sent to once it has a connection.
+func (c *Client) newHTTPRequest(ctx context.Context, method string, body []byte, headers map[string]string, peer *string) (*http.Request, error) {
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			*peer = info.Conn.RemoteAddr().String()
+		},
+	})
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(method), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	return request, nil
+}
+
+// doHTTP sends a request on the shared client for this connection.
+func (c *Client) doHTTP(request *http.Request) (*http.Response, error) {
+	client, err := sharedHTTPClient(c.useTLS, c.options)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return response, nil
+}
+
+// remaining is how long the context has left, and whether it has a deadline
+// at all.
+func remaining(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	left := time.Until(deadline)
+	if left < time.Millisecond {
+		left = time.Millisecond
+	}
+	return left, true
+}
+
+// The flags on a length-prefixed message. gRPC-Web and Connect's streaming
+// encoding frame messages the same way: a flags byte, a four-byte big-endian
+// length, and the payload. The high bit means the payload is not a message:
+// gRPC-Web's trailers, or Connect's end-of-stream.
+const (
+	flagCompressed = 0x01
+	flagEndStream  = 0x02
+	flagTrailers   = 0x80
+)
+
+func envelope(flags byte, payload []byte) []byte {
+	framed := make([]byte, 5+len(payload))
+	framed[0] = flags
+	binary.BigEndian.PutUint32(framed[1:5], uint32(len(payload)))
+	copy(framed[5:], payload)
+	return framed
+}
+
+// readEnvelope reads one length-prefixed message, refusing one longer than
+// limit before reading it. io.EOF means the stream ended between messages.
+func readEnvelope(r io.Reader, limit int) (byte, []byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, nil, status.Error(codes.Internal, "the stream ended inside a message prefix")
+		}
+		return 0, nil, err
+	}
+	length := int(binary.BigEndian.Uint32(prefix[1:5]))
+	if prefix[0]&(flagTrailers|flagEndStream) == 0 && length > limit {
+		return 0, nil, status.Errorf(codes.ResourceExhausted, "received message larger than max (%d vs. %d)", length, limit)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, status.Error(codes.Internal, "the stream ended inside a message")
+	}
+	return prefix[0], payload, nil
+}
+
+// compress and decompress run a message through the named registered
+// compressor, the same ones native gRPC negotiates with.
+func compress(name string, message []byte) ([]byte, error) {
+	compressor := encoding.GetCompressor(name)
+	if compressor == nil {
+		return nil, fmt.Errorf("unsupported compression %q", name)
+	}
+	var buffer bytes.Buffer
+	writer, err := compressor.Compress(&buffer)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decompress(name string, message []byte, limit int) ([]byte, error) {
+	compressor := encoding.GetCompressor(name)
+	if compressor == nil {
+		return nil, status.Errorf(codes.Internal, "the server answered in %q, which kaja doesn't read", name)
+	}
+	reader, err := compressor.Decompress(bytes.NewReader(message))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decompressing the response: %v", err)
+	}
+	decompressed, err := io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decompressing the response: %v", err)
+	}
+	if len(decompressed) > limit {
+		return nil, status.Errorf(codes.ResourceExhausted, "received message after decompression larger than max (%d)", limit)
+	}
+	return decompressed, nil
+}
+
+// compressing is the encoding the call's requests use, or empty for none.
+func (o CallOptions) compressing() string {
+	if o.Compression == CompressionNone {
+		return ""
+	}
+	return o.Compression
+}
+
+func (o CallOptions) receiveLimit() int {
+	if o.MaxReceiveBytes > 0 {
+		return o.MaxReceiveBytes
+	}
+	return defaultMaxReceiveBytes
+}
+
+// checkSend refuses a request over the app's send limit, as grpc-go does for
+// the native protocol.
+func (o CallOptions) checkSend(request []byte) error {
+	if o.MaxSendBytes > 0 && len(request) > o.MaxSendBytes {
+		return status.Errorf(codes.ResourceExhausted, "trying to send message larger than max (%d vs. %d)", len(request), o.MaxSendBytes)
+	}
+	return nil
+}
+
+// flattenHeaders renders HTTP headers the way the Headers view reads them:
+// lowercase names, multiple values comma-joined.
+func flattenHeaders(headers ...http.Header) map[string]string {
+	flattened := map[string]string{}
+	for _, header := range headers {
+		for name, values := range header {
+			flattened[strings.ToLower(name)] = strings.Join(values, ", ")
+		}
+	}
+	if len(flattened) == 0 {
+		return nil
+	}
+	return flattened
+}
+
+// requestHeadersOf is the request side of an HTTP call as the Headers view
+// shows it: what the protocol put on the wire, not the call's metadata, which
+// carries the app's credential.
+func requestHeadersOf(request *http.Request, peer string, names ...string) map[string]string {
+	headers := map[string]string{}
+	if peer != "" {
+		headers[PeerHeader] = peer
+	}
+	for _, name := range names {
+		if value := request.Header.Get(name); value != "" {
+			headers[strings.ToLower(name)] = value
+		}
+	}
+	return headers
+}
+
+// codeFromHTTP maps an HTTP status that came back without a gRPC status to the
+// code the gRPC-Web and Connect specifications both give it.
+func codeFromHTTP(status int) codes.Code {
+	switch status {
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	}
+	return codes.Unknown
+}
+
+func formatTimeout(left time.Duration, unit time.Duration) string {
+	return strconv.FormatInt(int64((left+unit-1)/unit), 10)
+}
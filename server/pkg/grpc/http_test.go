@@ -0,0 +1,333 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wham/kaja/v2/pkg/retry"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// httpEchoServer answers the way echoServer does - the request, x-times times -
+// over gRPC-Web and Connect. /echo.Echo/Fail answers with NOT_FOUND instead.
+func httpEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times, _ := strconv.Atoi(r.Header.Get("X-Times"))
+		if times == 0 {
+			times = 1
+		}
+		fail := strings.HasSuffix(r.URL.Path, "/Fail")
+
+		switch r.Header.Get("Content-Type") {
+		case "application/grpc-web+proto":
+			w.Header().Set("Content-Type", "application/grpc-web+proto")
+			if fail {
+				w.Header().Set("Grpc-Status", strconv.Itoa(int(codes.NotFound)))
+				w.Header().Set("Grpc-Message", "no%20such%20seat")
+				return
+			}
+			message := readTestEnvelope(t, r.Body, r.Header.Get("Grpc-Encoding"))
+			encoding := r.Header.Get("Grpc-Encoding")
+			if encoding != "" {
+				w.Header().Set("Grpc-Encoding", encoding)
+			}
+			for range times {
+				w.Write(testEnvelope(t, 0, message, encoding))
+			}
+			w.Write(envelope(flagTrailers, []byte("grpc-status: 0\r\ngrpc-message: \r\nx-trailer: yes\r\n")))
+
+		case "application/proto":
+			if fail {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"code":"not_found","message":"no such seat"}`)
+				return
+			}
+			message, _ := io.ReadAll(r.Body)
+			encoding := r.Header.Get("Content-Encoding")
+			if encoding != "" {
+				message = mustDecompress(t, encoding, message)
+			}
+			message = bytes.Repeat(message, times)
+			if encoding != "" {
+				message = mustCompress(t, encoding, message)
+				w.Header().Set("Content-Encoding", encoding)
+			}
+			w.Header().Set("Content-Type", "application/proto")
+			w.Write(message)
+
+		case "application/connect+proto":
+			w.Header().Set("Content-Type", "application/connect+proto")
+			if fail {
+				w.Write(envelope(flagEndStream, []byte(`{"error":{"code":"not_found","message":"no such seat"}}`)))
+				return
+			}
+			message := readTestEnvelope(t, r.Body, r.Header.Get("Connect-Content-Encoding"))
+			for range times {
+				w.Write(envelope(0, message))
+			}
+			w.Write(envelope(flagEndStream, []byte(`{}`)))
+
+		default:
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func readTestEnvelope(t *testing.T, r io.Reader, encoding string) []byte {
+	t.Helper()
+	flags, payload, err := readEnvelope(r, defaultMaxReceiveBytes)
+	if err != nil {
+		t.Errorf("reading the request: %v", err)
+		return nil
+	}
+	if flags&flagCompressed != 0 {
+		return mustDecompress(t, encoding, payload)
+	}
+	return payload
+}
+
+func testEnvelope(t *testing.T, flags byte, message []byte, encoding string) []byte {
+	if encoding == "" {
+		return envelope(flags, message)
+	}
+	return envelope(flags|flagCompressed, mustCompress(t, encoding, message))
+}
+
+func mustCompress(t *testing.T, encoding string, message []byte) []byte {
+	t.Helper()
+	compressed, err := compress(encoding, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return compressed
+}
+
+func mustDecompress(t *testing.T, encoding string, message []byte) []byte {
+	t.Helper()
+	decompressed, err := decompress(encoding, message, defaultMaxReceiveBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decompressed
+}
+
+func TestInvokeOverHTTP(t *testing.T) {
+	server := httpEchoServer(t)
+	request := []byte("seat 14C")
+
+	for _, protocol := range []string{ProtocolGRPCWeb, ProtocolConnect} {
+		for _, compression := range []string{"", CompressionGzip, CompressionZstd} {
+			t.Run(protocol+"/"+compression, func(t *testing.T) {
+				client, err := NewClientFromString(server.URL, TLSOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				client.WithCallOptions(CallOptions{Protocol: protocol, Compression: compression})
+
+				response, err := client.InvokeWithTimeout("/echo.Echo/Echo", request, 5*time.Second, map[string]string{"authorization": "Bearer secret"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(response.Body, request) {
+					t.Errorf("Body = %q, want %q", response.Body, request)
+				}
+				if _, ok := response.RequestHeaders["authorization"]; ok {
+					t.Error("the credential was reported among the request headers")
+				}
+				if response.RequestHeaders["content-type"] == "" {
+					t.Errorf("RequestHeaders = %v, want the protocol's content type", response.RequestHeaders)
+				}
+				if protocol == ProtocolGRPCWeb && response.ResponseHeaders["x-trailer"] != "yes" {
+					t.Errorf("ResponseHeaders = %v, want the trailers included", response.ResponseHeaders)
+				}
+			})
+		}
+	}
+}
+
+func TestInvokeOverHTTPStatus(t *testing.T) {
+	server := httpEchoServer(t)
+
+	for _, protocol := range []string{ProtocolGRPCWeb, ProtocolConnect} {
+		t.Run(protocol, func(t *testing.T) {
+			client, err := NewClientFromString(server.URL, TLSOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.WithCallOptions(CallOptions{Protocol: protocol})
+
+			_, err = client.InvokeWithTimeout("/echo.Echo/Fail", nil, 5*time.Second, nil)
+			s := status.Convert(errors.Unwrap(err))
+			if s.Code() != codes.NotFound || s.Message() != "no such seat" {
+				t.Errorf("status = %v %q, want NOT_FOUND %q", s.Code(), s.Message(), "no such seat")
+			}
+		})
+	}
+}
+
+func TestInvokeOverHTTPMaxReceiveBytes(t *testing.T) {
+	server := httpEchoServer(t)
+
+	for _, protocol := range []string{ProtocolGRPCWeb, ProtocolConnect} {
+		t.Run(protocol, func(t *testing.T) {
+			client, err := NewClientFromString(server.URL, TLSOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.WithCallOptions(CallOptions{Protocol: protocol, MaxReceiveBytes: 16})
+
+			_, err = client.InvokeWithTimeout("/echo.Echo/Echo", bytes.Repeat([]byte("x"), 64), 5*time.Second, nil)
+			if code := status.Code(errors.Unwrap(err)); code != codes.ResourceExhausted {
+				t.Errorf("code = %v, want RESOURCE_EXHAUSTED (err: %v)", code, err)
+			}
+		})
+	}
+}
+
+func TestInvokeRetries(t *testing.T) {
+	// The first two calls are turned away with UNAVAILABLE, the second asking
+	// for a wait of its own in a RetryInfo detail.
+	retryInfo, err := proto.Marshal(&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Millisecond)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message, _ := io.ReadAll(r.Body)
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"code":"unavailable","message":"warming up"}`)
+		case 2:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, `{"code":"unavailable","message":"still warming up","details":[{"type":"google.rpc.RetryInfo","value":%q}]}`, base64.RawStdEncoding.EncodeToString(retryInfo))
+		default:
+			w.Header().Set("Content-Type", "application/proto")
+			w.Write(message)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromString(server.URL, TLSOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithCallOptions(CallOptions{Protocol: ProtocolConnect}).WithRetry(retry.Policy{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	response, err := client.InvokeWithTimeout("/echo.Echo/Echo", []byte("seat"), 5*time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(response.Body) != "seat" {
+		t.Errorf("body = %q, want %q", response.Body, "seat")
+	}
+	want := map[string]string{
+		retry.AttemptsHeader: "3",
+		"kaja-attempt-2":     "UNAVAILABLE: still warming up; retried after 30ms",
+		"kaja-attempt-3":     "OK",
+	}
+	for name, value := range want {
+		if got := response.RequestHeaders[name]; got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	if got := response.RequestHeaders["kaja-attempt-1"]; !strings.HasPrefix(got, "UNAVAILABLE: warming up; retried after ") {
+		t.Errorf("kaja-attempt-1 = %q, want the first failure and its wait", got)
+	}
+
+	// Out of attempts, the call fails with the last one's status and carries
+	// every attempt.
+	calls.Store(0)
+	client.WithRetry(retry.Policy{MaxAttempts: 2, Backoff: time.Millisecond})
+	_, err = client.InvokeWithTimeout("/echo.Echo/Echo", []byte("seat"), 5*time.Second, nil)
+	var retried *retry.Error
+	if !errors.As(err, &retried) || len(retried.Attempts) != 2 {
+		t.Fatalf("err = %v, want a retry.Error of 2 attempts", err)
+	}
+	if s := status.Convert(errors.Unwrap(retried.Err)); s.Code() != codes.Unavailable || s.Message() != "still warming up" {
+		t.Errorf("status = %v %q, want UNAVAILABLE %q", s.Code(), s.Message(), "still warming up")
+	}
+}
+
+func TestServerStreamOverHTTP(t *testing.T) {
+	server := httpEchoServer(t)
+
+	for _, protocol := range []string{ProtocolGRPCWeb, ProtocolConnect} {
+		t.Run(protocol, func(t *testing.T) {
+			client, err := NewClientFromString(server.URL, TLSOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.WithCallOptions(CallOptions{Protocol: protocol})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			messages, errc := client.ServerStream(ctx, "/echo.Echo/Echo", []byte("tick"), map[string]string{"x-times": "3"})
+			count := 0
+			for message := range messages {
+				if string(message) != "tick" {
+					t.Errorf("message = %q, want %q", message, "tick")
+				}
+				count++
+			}
+			if err := <-errc; err != nil {
+				t.Fatal(err)
+			}
+			if count != 3 {
+				t.Errorf("received %d messages, want 3", count)
+			}
+
+			messages, errc = client.ServerStream(ctx, "/echo.Echo/Fail", nil, nil)
+			for range messages {
+			}
+			if code := status.Code(errors.Unwrap(<-errc)); code != codes.NotFound {
+				t.Errorf("code = %v, want NOT_FOUND", code)
+			}
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		target   string
+		tls      TLSOptions
+		expected string
+	}{
+		{"http://localhost:8080", TLSOptions{}, "http://localhost:8080/echo.Echo/Echo"},
+		{"https://api.example.com/rpc/", TLSOptions{}, "https://api.example.com/rpc/echo.Echo/Echo"},
+		{"dns:api.example.com:443", TLSOptions{}, "https://api.example.com:443/echo.Echo/Echo"},
+		{"grpc://localhost:9000/ignored", TLSOptions{Mode: TLSOn}, "https://localhost:9000/echo.Echo/Echo"},
+	}
+
+	for _, test := range tests {
+		client, err := NewClientFromString(test.target, test.tls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := client.endpoint("/echo.Echo/Echo"); got != test.expected {
+			t.Errorf("endpoint(%q) = %q, want %q", test.target, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package grpc
+
+import "fmt"
+
+// The headers a call's request side reports where it went: the target it was
+// routed to, and the address that answered there. One target can be many
+// replicas behind a DNS name, so the two are not the same question.
+const (
+	EndpointHeader = "kaja-endpoint"
+	PeerHeader     = "kaja-peer"
+)
+
+// Load-balancing policies for the addresses one target resolves to. An empty
+// policy is grpc-go's default, which is pick_first: every call goes to the first
+// address that connects.
+const (
+	LoadBalancingPickFirst  = "pick_first"
+	LoadBalancingRoundRobin = "round_robin"
+)
+
+// loadBalancingConfig is the service config that selects policy, or empty for
+// the default.
+func loadBalancingConfig(policy string) (string, error) {
+	switch policy {
+	case "", LoadBalancingPickFirst:
+		return "", nil
+	case LoadBalancingRoundRobin:
+		return `{"loadBalancingConfig":[{"round_robin":{}}]}`, nil
+	}
+	return "", fmt.Errorf("unsupported load balancing %q (use %q or %q)", policy, LoadBalancingPickFirst, LoadBalancingRoundRobin)
+}
@@ -61,6 +61,17 @@ func (o TLSOptions) credentials(useTLS bool) (credentials.TransportCredentials,
 		return insecure.NewCredentials(), nil
 	}
 
+	configuration, err := o.config()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(configuration), nil
+}
+
+// config builds the TLS configuration the options describe. The native gRPC
+// connection wraps it in transport credentials; the HTTP protocols hand it to
+// their transport as it is.
+func (o TLSOptions) config() (*tls.Config, error) {
 	configuration := &tls.Config{InsecureSkipVerify: o.SkipVerify}
 
 	if o.CAFile != "" {
@@ -86,7 +97,7 @@ func (o TLSOptions) credentials(useTLS bool) (credentials.TransportCredentials,
 		configuration.Certificates = []tls.Certificate{certificate}
 	}
 
-	return credentials.NewTLS(configuration), nil
+	return configuration, nil
 }
 
 // key fingerprints the options so the connection cache keeps one connection per
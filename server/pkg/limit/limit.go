@@ -0,0 +1,162 @@
+// Package limit holds an app's calls back before they leave kaja: no faster than
+// the app's rate, and no more at once than its concurrency. A call over either
+// limit waits its turn rather than failing, and how long it waited is reported
+// with it, so a script that fans out a thousand calls at a fragile service
+// reaches it at the pace the service can take.
+package limit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueuedHeader is the request-side header a call that waited its turn reports
+// the wait under, in milliseconds.
+const QueuedHeader = "kaja-queued-ms"
+
+// Limits are how fast and how widely an app's calls may go out. The zero value
+// holds nothing back.
+type Limits struct {
+	// Rate is the calls per second the app may make. Zero means no limit.
+	Rate int
+	// Burst is how many calls may leave at once after a quiet spell, before
+	// Rate paces them. Zero means one second's worth of Rate.
+	Burst int
+	// MaxConcurrency is how many of the app's calls may be in flight at once.
+	// Zero means no limit.
+	MaxConcurrency int
+}
+
+// Parse reads an app's limits off its parameters: rate_limit, rate_limit_burst
+// and max_concurrency. What isn't a positive number is no limit.
+func Parse(parameters map[string]string) Limits {
+	return Limits{
+		Rate:           positive(parameters["rate_limit"]),
+		Burst:          positive(parameters["rate_limit_burst"]),
+		MaxConcurrency: positive(parameters["max_concurrency"]),
+	}
+}
+
+func positive(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Enabled reports whether the limits hold any call back.
+func (l Limits) Enabled() bool {
+	return l.Rate > 0 || l.MaxConcurrency > 0
+}
+
+func (l Limits) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return max(l.Rate, 1)
+}
+
+// Gate is one app's limits, shared by every call the app makes. It is safe for
+// concurrent use.
+type Gate struct {
+	limits Limits
+	// slots holds a token per call in flight, when concurrency is limited.
+	slots chan struct{}
+
+	mu sync.Mutex
+	// due is when the call after the ones already let through would leave if
+	// calls were spaced exactly 1/Rate apart. A burst lets a call leave up to
+	// Burst-1 intervals ahead of it.
+	due time.Time
+}
+
+// NewGate builds a gate that enforces limits.
+func NewGate(limits Limits) *Gate {
+	gate := &Gate{limits: limits}
+	if limits.MaxConcurrency > 0 {
+		gate.slots = make(chan struct{}, limits.MaxConcurrency)
+	}
+	return gate
+}
+
+// Limits are what the gate enforces.
+func (g *Gate) Limits() Limits {
+	if g == nil {
+		return Limits{}
+	}
+	return g.limits
+}
+
+// Enter waits for the call's turn: a free slot, then its place in the rate. It
+// returns how long that took and the release the caller makes once the call is
+// done, which frees its slot. A nil gate lets every call straight through. The
+// error is ctx's, for a call given up on while it waited.
+func (g *Gate) Enter(ctx context.Context) (func(), time.Duration, error) {
+	if g == nil || !g.limits.Enabled() {
+		return func() {}, 0, nil
+	}
+	started := time.Now()
+
+	release := func() {}
+	if g.slots != nil {
+		select {
+		case g.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, time.Since(started), ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-g.slots }) }
+	}
+
+	if wait := g.reserve(time.Now()); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			release()
+			return nil, time.Since(started), ctx.Err()
+		}
+	}
+
+	return release, time.Since(started), nil
+}
+
+// reserve takes the next place in the rate and returns how long until it comes
+// up.
+func (g *Gate) reserve(now time.Time) time.Duration {
+	if g.limits.Rate <= 0 {
+		return 0
+	}
+	interval := time.Second / time.Duration(g.limits.Rate)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.due.Before(now) {
+		g.due = now
+	}
+	leaves := g.due.Add(-time.Duration(g.limits.burst()-1) * interval)
+	g.due = g.due.Add(interval)
+	if leaves.After(now) {
+		return leaves.Sub(now)
+	}
+	return 0
+}
+
+// Record adds a call's wait to its request headers, creating them when headers
+// is nil. A call that didn't wait has nothing to add.
+func Record(headers map[string]string, waited time.Duration) map[string]string {
+	if waited < time.Millisecond {
+		return headers
+	}
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	headers[QueuedHeader] = strconv.FormatInt(waited.Milliseconds(), 10)
+	return headers
+}
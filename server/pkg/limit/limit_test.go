@@ -0,0 +1,111 @@
+package limit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	limits := Parse(map[string]string{"rate_limit": "20", "rate_limit_burst": "", "max_concurrency": "-3"})
+	if limits != (Limits{Rate: 20}) {
+		t.Errorf("limits = %+v, want 20 a second and nothing else", limits)
+	}
+	if Parse(nil).Enabled() {
+		t.Error("limits with nothing configured hold calls back; want them off")
+	}
+}
+
+func TestReserve(t *testing.T) {
+	// Ten a second in bursts of three: three leave at once, and each after them
+	// a tenth of a second after the last.
+	gate := NewGate(Limits{Rate: 10, Burst: 3})
+	now := time.Now()
+	want := []time.Duration{0, 0, 0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i, wait := range want {
+		if got := gate.reserve(now); got != wait {
+			t.Errorf("call %d waits %v, want %v", i+1, got, wait)
+		}
+	}
+
+	// After a quiet spell the burst is back.
+	later := now.Add(time.Second)
+	if got := gate.reserve(later); got != 0 {
+		t.Errorf("after a quiet second the call waits %v, want none", got)
+	}
+}
+
+func TestEnterConcurrency(t *testing.T) {
+	gate := NewGate(Limits{MaxConcurrency: 2})
+
+	var inFlight, most atomic.Int32
+	var waited atomic.Bool
+	var group sync.WaitGroup
+	for range 6 {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			release, queued, err := gate.Enter(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+			if queued > 0 {
+				waited.Store(true)
+			}
+			n := inFlight.Add(1)
+			for {
+				m := most.Load()
+				if n <= m || most.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			inFlight.Add(-1)
+		}()
+	}
+	group.Wait()
+
+	if most.Load() > 2 {
+		t.Errorf("%d calls were in flight at once, want at most 2", most.Load())
+	}
+	if !waited.Load() {
+		t.Error("no call reported queueing, want the ones past the first two to")
+	}
+}
+
+func TestEnterGivenUp(t *testing.T) {
+	gate := NewGate(Limits{MaxConcurrency: 1})
+	release, _, err := gate.Enter(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := gate.Enter(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Enter() = %v, want the context's error for a call given up on", err)
+	}
+}
+
+func TestNilGate(t *testing.T) {
+	var gate *Gate
+	release, queued, err := gate.Enter(context.Background())
+	if err != nil || queued != 0 {
+		t.Fatalf("Enter() = %v, %v; want straight through", queued, err)
+	}
+	release()
+}
+
+func TestRecord(t *testing.T) {
+	if headers := Record(nil, 0); headers != nil {
+		t.Errorf("Record() = %v for a call that didn't wait, want nothing", headers)
+	}
+	if headers := Record(nil, 1500*time.Millisecond); headers[QueuedHeader] != "1500" {
+		t.Errorf("Record() = %v, want %s: 1500", headers, QueuedHeader)
+	}
+}
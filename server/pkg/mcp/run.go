@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"unicode/utf8"
 )
@@ -17,7 +19,7 @@ var failureAdvice = map[string]string{
 	"INVALID_REQUEST": "The service rejected the request. Check the field names and values against describe_method.",
 	"UNAUTHORIZED":    "The credentials were missing or refused. This is the app's configuration, not the request.",
 	"NOT_FOUND":       "The target does not exist. The request shape is fine; the identifier or the route is not.",
-	"RATE_LIMITED":    "Too many calls. Wait and retry the same request.",
+	"RATE_LIMITED":    "Too many calls. Wait and retry the same request. An app with a retry policy has already retried it as far as the policy allows.",
 	"SERVER":          "The service reached an error of its own. Retrying the same request may or may not help; changing its shape will not.",
 	"TRANSPORT":       "The call never completed a valid exchange - a connection or codec failure, not a rejected request. Sending different parameters will not help.",
 	"UNKNOWN":         "The failure carried nothing to classify it by.",
@@ -114,6 +116,9 @@ func renderCall(index int, call MethodCallLog) string {
 	fmt.Fprintf(&b, "  %d. %s  %s", index, where, status)
 	if call.DurationMs > 0 {
 		fmt.Fprintf(&b, "  %.0f ms", call.DurationMs)
+		if call.QueuedMs > 0 {
+			fmt.Fprintf(&b, " (%.0f ms queued)", call.QueuedMs)
+		}
 	}
 	b.WriteString("\n")
 
@@ -122,6 +127,10 @@ func renderCall(index int, call MethodCallLog) string {
 			fmt.Fprintf(&b, "     %s\n", label)
 		}
 		fmt.Fprintf(&b, "     %s\n", call.Failure.Message)
+		// What the service attached to its error is often the answer to why.
+		for _, key := range slices.Sorted(maps.Keys(call.Failure.Meta)) {
+			fmt.Fprintf(&b, "     %s: %s\n", key, truncate(call.Failure.Meta[key]))
+		}
 		if advice := failureAdvice[call.Failure.Kind]; advice != "" {
 			fmt.Fprintf(&b, "     %s\n", advice)
 		}
@@ -40,13 +40,16 @@ type ScriptInfo struct {
 // MethodCallLog is a single RPC made while a script ran, mirrored from the UI so
 // the agent can see what the script actually did.
 type MethodCallLog struct {
-	App        string          `json:"app,omitempty"`
-	Service    string          `json:"service"`
-	Method     string          `json:"method"`
-	DurationMs float64         `json:"durationMs,omitempty"`
-	Input      json.RawMessage `json:"input,omitempty"`
-	Output     json.RawMessage `json:"output,omitempty"`
-	Failure    *CallFailure    `json:"failure,omitempty"`
+	App        string  `json:"app,omitempty"`
+	Service    string  `json:"service"`
+	Method     string  `json:"method"`
+	DurationMs float64 `json:"durationMs,omitempty"`
+	// QueuedMs is how much of DurationMs the call waited under its app's limits
+	// before it left kaja.
+	QueuedMs float64         `json:"queuedMs,omitempty"`
+	Input    json.RawMessage `json:"input,omitempty"`
+	Output   json.RawMessage `json:"output,omitempty"`
+	Failure  *CallFailure    `json:"failure,omitempty"`
 }
 
 // CallFailure is why a call failed, in the one distinction a caller can act on:
@@ -57,6 +60,38 @@ type CallFailure struct {
 	Message string `json:"message"`
 	Status  int    `json:"status,omitempty"`
 	Code    string `json:"code,omitempty"`
+	// Meta is what a Twirp service attached to its error for the caller, such as
+	// which argument it refused.
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// codeKinds is how a gRPC or Twirp status code maps onto the kinds, as
+// callFailure.ts maps it. Twirp spells a few codes of its own.
+var codeKinds = map[string]string{
+	"invalid_argument":    "INVALID_REQUEST",
+	"out_of_range":        "INVALID_REQUEST",
+	"failed_precondition": "INVALID_REQUEST",
+	"already_exists":      "INVALID_REQUEST",
+	"malformed":           "INVALID_REQUEST",
+	"unauthenticated":     "UNAUTHORIZED",
+	"permission_denied":   "UNAUTHORIZED",
+	"not_found":           "NOT_FOUND",
+	"unimplemented":       "NOT_FOUND",
+	"bad_route":           "NOT_FOUND",
+	"resource_exhausted":  "RATE_LIMITED",
+	"unavailable":         "TRANSPORT",
+	"deadline_exceeded":   "TRANSPORT",
+	"cancelled":           "TRANSPORT",
+	"canceled":            "TRANSPORT",
+}
+
+// CodeKind is the kind of failure a gRPC or Twirp status code reports. A code
+// not listed reached a server and the server refused the call: SERVER.
+func CodeKind(code string) string {
+	if kind, ok := codeKinds[strings.ToLower(code)]; ok {
+		return kind
+	}
+	return "SERVER"
 }
 
 // BlockLog is something the script drew. The agent produced the contents already,
@@ -576,6 +576,27 @@ func TestRunScriptReport(t *testing.T) {
 	}
 }
 
+// A Twirp error's meta is the service saying why, and a caller reads it in the
+// report.
+func TestRunScriptReportsFailureMeta(t *testing.T) {
+	bridge := newFakeBridge()
+	bridge.runValue = RunResult{
+		MethodCalls: []MethodCallLog{
+			{Service: "Seats", Method: "Book", Failure: &CallFailure{Kind: CodeKind("malformed"), Message: "bad body", Code: "malformed", Meta: map[string]string{"field": "seat"}}},
+		},
+	}
+	srv := NewServer(bridge, token)
+
+	contains(t, tool(t, srv, "run_script", map[string]string{"code": "1"}),
+		"1. Seats.Book  INVALID_REQUEST",
+		"malformed",
+		"field: seat",
+	)
+	if kind := CodeKind("SOMETHING_NEW"); kind != "SERVER" {
+		t.Errorf("CodeKind() = %q, want SERVER for a code it doesn't know", kind)
+	}
+}
+
 // What a script drew is the receipt that its output landed - an agent's run has
 // a canvas but nobody looking at it.
 func TestRunScriptReportsWhatItDrew(t *testing.T) {
@@ -0,0 +1,56 @@
+package retry
+
+import (
+	"strconv"
+	"time"
+)
+
+// AttemptsHeader is the request-side header a retried call reports how many
+// attempts it took under. Each attempt has its own header after it, numbered
+// from 1: kaja-attempt-1, kaja-attempt-2, and so on.
+const AttemptsHeader = "kaja-attempts"
+
+const attemptHeaderPrefix = "kaja-attempt-"
+
+// Attempt is one try at a call: how it went and, when it was retried, how long
+// kaja waited before the next.
+type Attempt struct {
+	Outcome string
+	Wait    time.Duration
+}
+
+// Attempts are the tries a call took, in order. The last is the one the call
+// ended with.
+type Attempts []Attempt
+
+// Record adds the attempts to a call's request headers, creating them when
+// headers is nil. A call that was sent once has nothing to add.
+func (a Attempts) Record(headers map[string]string) map[string]string {
+	if len(a) < 2 {
+		return headers
+	}
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	headers[AttemptsHeader] = strconv.Itoa(len(a))
+	for i, attempt := range a {
+		entry := attempt.Outcome
+		if attempt.Wait > 0 {
+			entry += "; retried after " + attempt.Wait.Round(time.Millisecond).String()
+		}
+		headers[attemptHeaderPrefix+strconv.Itoa(i+1)] = entry
+	}
+	return headers
+}
+
+// Error is a call that failed on every attempt it was allowed. It reads as the
+// last attempt's failure and unwraps to it; the attempts ride along for a
+// caller with somewhere to report them.
+type Error struct {
+	Err      error
+	Attempts Attempts
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
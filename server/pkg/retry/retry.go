@@ -0,0 +1,334 @@
+// Package retry makes an upstream call again when it fails in a way that
+// passes: a service that is briefly unavailable, or one asking kaja to slow
+// down. It is opt-in per app, and every attempt a call took is reported with
+// it, so a script that succeeded on the third try says so in the Headers view.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The defaults for a policy that names only how many attempts it makes.
+const (
+	DefaultBackoff    = 200 * time.Millisecond
+	DefaultMaxBackoff = 5 * time.Second
+)
+
+// DefaultCodes are what a policy retries when it names nothing: a gRPC service
+// that is unavailable, and an HTTP one that is rate limiting or briefly down.
+// A connection that fails outright reads as UNAVAILABLE over either.
+var DefaultCodes = []string{"UNAVAILABLE", "429", "502", "503", "504"}
+
+// Policy is how an app's calls are retried. The zero value makes one attempt,
+// which is what every app did before it could say otherwise.
+type Policy struct {
+	// MaxAttempts counts the first attempt, so 3 is the call and two retries.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled before each one
+	// after it up to MaxBackoff. Each wait is jittered so a script's calls that
+	// failed together don't retry together.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Codes are the failures worth another attempt: gRPC code names
+	// ("UNAVAILABLE") and HTTP statuses ("503"). Empty means DefaultCodes.
+	Codes []string
+}
+
+// Parse reads an app's retry policy off its parameters: retry_max_attempts,
+// retry_backoff_ms, retry_max_backoff_ms and the comma-separated retry_codes.
+// What isn't a positive number is left to the default.
+func Parse(parameters map[string]string) Policy {
+	policy := Policy{
+		MaxAttempts: positive(parameters["retry_max_attempts"]),
+		Backoff:     time.Duration(positive(parameters["retry_backoff_ms"])) * time.Millisecond,
+		MaxBackoff:  time.Duration(positive(parameters["retry_max_backoff_ms"])) * time.Millisecond,
+	}
+	for _, code := range strings.Split(parameters["retry_codes"], ",") {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+			policy.Codes = append(policy.Codes, code)
+		}
+	}
+	return policy
+}
+
+func positive(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Enabled reports whether the policy ever makes a second attempt.
+func (p Policy) Enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// Validate rejects a code the policy could never match, so a typo in kaja.json
+// fails the call that reads it rather than quietly never retrying.
+func (p Policy) Validate() error {
+	for _, code := range p.Codes {
+		if _, err := strconv.Atoi(code); err == nil {
+			continue
+		}
+		if !slices.Contains(slices.Collect(maps.Values(codeNames)), code) {
+			return fmt.Errorf("unsupported retry code %q (use a gRPC code such as %q or an HTTP status such as %q)", code, "UNAVAILABLE", "503")
+		}
+	}
+	return nil
+}
+
+func (p Policy) retries(code string) bool {
+	listed := p.Codes
+	if len(listed) == 0 {
+		listed = DefaultCodes
+	}
+	return slices.Contains(listed, code)
+}
+
+// backoff is the wait before retry n, counting from 1, before jitter.
+func (p Policy) backoff(n int) time.Duration {
+	wait, ceiling := p.Backoff, p.MaxBackoff
+	if wait <= 0 {
+		wait = DefaultBackoff
+	}
+	if ceiling <= 0 {
+		ceiling = DefaultMaxBackoff
+	}
+	for i := 1; i < n && wait < ceiling; i++ {
+		wait *= 2
+	}
+	return min(wait, ceiling)
+}
+
+func (p Policy) ceiling() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return DefaultMaxBackoff
+}
+
+// outcome is how one attempt went, as the policy judges it.
+type outcome struct {
+	// summary is the attempt as its record reads.
+	summary string
+	// code is what the policy's codes are matched against, or empty for an
+	// attempt that succeeded.
+	code string
+	// after is how long the server asked kaja to wait, or zero.
+	after time.Duration
+}
+
+// run makes attempts until one succeeds, fails in a way the policy doesn't
+// retry, or the policy runs out of them. A server that asks for a longer wait
+// than the policy allows, or than the call has left, is taken at its word: the
+// call fails now rather than after a wait it was told would not help.
+func (p Policy) run(ctx context.Context, attempt func() outcome) Attempts {
+	var attempts Attempts
+	for n := 1; ; n++ {
+		result := attempt()
+		attempts = append(attempts, Attempt{Outcome: result.summary})
+		if result.code == "" || n >= max(p.MaxAttempts, 1) || !p.retries(result.code) {
+			return attempts
+		}
+
+		wait := result.after
+		if wait == 0 {
+			wait = jitter(p.backoff(n))
+		} else if wait > p.ceiling() {
+			return attempts
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return attempts
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempts
+		case <-timer.C:
+		}
+		attempts[len(attempts)-1].Wait = wait
+	}
+}
+
+// jitter spreads a wait across its upper half.
+func jitter(wait time.Duration) time.Duration {
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+	return half + rand.N(half+1)
+}
+
+// Invoke makes a gRPC call, again as the policy allows. A failure is judged by
+// its status code, and a RetryInfo detail on it is the wait the server asked
+// for. The error is the last attempt's.
+func (p Policy) Invoke(ctx context.Context, call func() error) (Attempts, error) {
+	var err error
+	attempts := p.run(ctx, func() outcome {
+		err = call()
+		if err == nil {
+			return outcome{summary: codeName(codes.OK)}
+		}
+		return grpcOutcome(err)
+	})
+	return attempts, err
+}
+
+func grpcOutcome(err error) outcome {
+	failure, ok := statusOf(err)
+	if !ok {
+		return outcome{summary: err.Error(), code: codeName(codes.Unknown)}
+	}
+	result := outcome{
+		summary: codeName(failure.Code()) + ": " + failure.Message(),
+		code:    codeName(failure.Code()),
+	}
+	for _, detail := range failure.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			result.after = info.GetRetryDelay().AsDuration()
+		}
+	}
+	return result
+}
+
+// statusOf finds the status in an error that may wrap it.
+func statusOf(err error) (*status.Status, bool) {
+	var carrier interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &carrier) {
+		return carrier.GRPCStatus(), true
+	}
+	return nil, false
+}
+
+// codeNames are the gRPC codes the way kaja.json spells them: UNAVAILABLE,
+// not Unavailable.
+var codeNames = map[codes.Code]string{
+	codes.OK:                 "OK",
+	codes.Canceled:           "CANCELLED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
+
+func codeName(code codes.Code) string {
+	if name, ok := codeNames[code]; ok {
+		return name
+	}
+	return code.String()
+}
+
+// Send makes an HTTP request through do, again as the policy allows. A response
+// is judged by its status and a Retry-After header on it is the wait the server
+// asked for; a request that got no response at all reads as UNAVAILABLE. The
+// response and error are the last attempt's. A request whose body can't be
+// read a second time is only sent once.
+func (p Policy) Send(do func(*http.Request) (*http.Response, error), request *http.Request) (*http.Response, Attempts, error) {
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		p.MaxAttempts = 1
+	}
+
+	var (
+		response *http.Response
+		err      error
+	)
+	n := 0
+	attempts := p.run(request.Context(), func() outcome {
+		n++
+		if response != nil {
+			// The previous attempt's response is being retried past; its
+			// connection goes back to the pool once its body is drained.
+			io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+			response.Body.Close()
+		}
+		attempt := request
+		if n > 1 {
+			attempt = request.Clone(request.Context())
+			if request.GetBody != nil {
+				body, bodyErr := request.GetBody()
+				if bodyErr != nil {
+					response, err = nil, bodyErr
+					return outcome{summary: bodyErr.Error()}
+				}
+				attempt.Body = body
+			}
+		}
+		response, err = do(attempt)
+		if err != nil {
+			return outcome{summary: err.Error(), code: codeName(codes.Unavailable)}
+		}
+		result := outcome{summary: response.Status}
+		if response.StatusCode >= 400 {
+			result.code = strconv.Itoa(response.StatusCode)
+			result.after = retryAfter(response.Header.Get("Retry-After"), time.Now())
+		}
+		return result
+	})
+	return response, attempts, err
+}
+
+// retryAfter reads a Retry-After header, which is either a number of seconds
+// or an HTTP date.
+func retryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// Transport is base with every round trip sent as the policy allows, for a
+// caller that hands its requests to something else to send - a reverse proxy.
+// record, when set, is called with each request's attempts.
+func (p Policy) Transport(base http.RoundTripper, record func(Attempts)) http.RoundTripper {
+	return roundTripper(func(request *http.Request) (*http.Response, error) {
+		response, attempts, err := p.Send(base.RoundTrip, request)
+		if record != nil {
+			record(attempts)
+		}
+		return response, err
+	})
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,171 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParse(t *testing.T) {
+	policy := Parse(map[string]string{
+		"retry_max_attempts":   "4",
+		"retry_backoff_ms":     "50",
+		"retry_max_backoff_ms": "not a number",
+		"retry_codes":          " unavailable ,429,, ",
+	})
+	if policy.MaxAttempts != 4 || policy.Backoff != 50*time.Millisecond || policy.MaxBackoff != 0 {
+		t.Errorf("policy = %+v, want 4 attempts, 50ms backoff and the default ceiling", policy)
+	}
+	if strings.Join(policy.Codes, ",") != "UNAVAILABLE,429" {
+		t.Errorf("codes = %q, want UNAVAILABLE and 429", policy.Codes)
+	}
+	if Parse(nil).Enabled() {
+		t.Error("a policy with nothing configured retries; want it off")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (Policy{Codes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED", "503"}}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+	if err := (Policy{Codes: []string{"UNAVAILBLE"}}).Validate(); err == nil {
+		t.Error("Validate() accepted a misspelt code")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := Policy{Backoff: 100 * time.Millisecond, MaxBackoff: 350 * time.Millisecond}
+	for n, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 350 * time.Millisecond, 10: 350 * time.Millisecond} {
+		if got := policy.backoff(n); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", n, got, want)
+		}
+	}
+	if got := (Policy{}).backoff(1); got != DefaultBackoff {
+		t.Errorf("default backoff = %v, want %v", got, DefaultBackoff)
+	}
+	for range 100 {
+		if got := jitter(100 * time.Millisecond); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("jitter(100ms) = %v, want within [50ms, 100ms]", got)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"2":                             2 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Fri, 02 Jan 2026 03:04:15 GMT": 10 * time.Second,
+		"Fri, 02 Jan 2026 03:04:00 GMT": 0,
+	}
+	for value, want := range tests {
+		if got := retryAfter(value, now); got != want {
+			t.Errorf("retryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	calls := 0
+	attempts, err := policy.Invoke(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "down")
+		}
+		return nil
+	})
+	if err != nil || len(attempts) != 3 {
+		t.Fatalf("Invoke() = %d attempts, %v; want 3 attempts and success", len(attempts), err)
+	}
+	if attempts[0].Outcome != "UNAVAILABLE: down" || attempts[0].Wait == 0 || attempts[2].Outcome != "OK" {
+		t.Errorf("attempts = %+v", attempts)
+	}
+
+	// A failure the policy doesn't name is the call's answer at once.
+	calls = 0
+	attempts, err = policy.Invoke(context.Background(), func() error {
+		calls++
+		return status.Error(codes.NotFound, "no such seat")
+	})
+	if status.Code(err) != codes.NotFound || calls != 1 || len(attempts) != 1 {
+		t.Errorf("Invoke() = %d calls, %v; want one NOT_FOUND", calls, err)
+	}
+}
+
+func TestSend(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	request, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte("seat")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, attempts, err := Policy{MaxAttempts: 3, Backoff: time.Millisecond}.Send(http.DefaultClient.Do, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	if string(body) != "seat" {
+		t.Errorf("body = %q, want the request sent again whole", body)
+	}
+
+	headers := attempts.Record(nil)
+	if headers[AttemptsHeader] != "3" || headers["kaja-attempt-3"] != "200 OK" || !strings.HasPrefix(headers["kaja-attempt-1"], "429 Too Many Requests; retried after ") {
+		t.Errorf("recorded = %v", headers)
+	}
+}
+
+func TestSendRetryAfterBeyondPolicy(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := time.Now()
+	response, attempts, err := Policy{MaxAttempts: 5, MaxBackoff: time.Second}.Send(http.DefaultClient.Do, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	// The server asked for a minute and the policy allows a second: the call
+	// fails now rather than after either.
+	if response.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 || len(attempts) != 1 {
+		t.Errorf("status %d after %d calls, want 503 after 1", response.StatusCode, calls.Load())
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("Send() took %v, want no wait", elapsed)
+	}
+	if headers := attempts.Record(nil); headers != nil {
+		t.Errorf("recorded %v for a call sent once, want nothing", headers)
+	}
+}
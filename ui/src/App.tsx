@@ -2803,6 +2803,7 @@ function toMethodCallLog(call: MethodCall) {
     service: call.service.name,
     method: call.method.name,
     durationMs: call.durationMs,
+    queuedMs: call.queuedMs,
     input: call.input,
     output: call.output,
     failure: call.error === undefined ? undefined : classifyFailure(call.error),
@@ -207,6 +207,8 @@ export function AppForm({ mode, initialData, allApps, variables, readOnly = fals
   const [type, setType] = useState("grpc");
   const [parameters, setParameters] = useState<Record<string, string>>({});
   const [headers, setHeaders] = useState<HeaderEntry[]>([]);
+  // The app the form was last filled from, which keeps the fields it doesn't show.
+  const [base, setBase] = useState<ConfigurationApp | undefined>(undefined);
   const [advancedOpen, setAdvancedOpen] = useState(false);
   // The parameter value itself holds the file's text content.
   const [uploadNames, setUploadNames] = useState<Record<string, string>>({});
@@ -231,11 +233,12 @@ export function AppForm({ mode, initialData, allApps, variables, readOnly = fals
       const headerName = header.name.trim();
       if (headerName) headerMap[headerName] = header.value;
     }
-    return buildApp(name, type, params, headerMap);
-  }, [name, type, parameters, headers]);
+    return buildApp(name, type, params, headerMap, base);
+  }, [name, type, parameters, headers, base]);
 
   const updateFormFromApp = useCallback((app: ConfigurationApp) => {
     setName(app.name);
+    setBase(app);
     setType(appType(app) || "grpc");
     setParameters(appParameters(app));
     setHeaders(Object.entries(appHeaders(app)).map(([headerName, value]) => ({ name: headerName, value })));
@@ -1,4 +1,4 @@
-import { Blocks, CircleAlert, CircleCheck, CircleX, Info, Key, RefreshCw, ShieldOff, Sparkles, TriangleAlert, type LucideIcon } from "lucide-react";
+import { Blocks, CircleAlert, CircleCheck, CircleX, Info, Key, LogIn, RefreshCw, ShieldOff, Sparkles, TriangleAlert, type LucideIcon } from "lucide-react";
 import { useCallback, useEffect, useRef, useState } from "react";
 import { Button } from "./components/button";
 import { IconButton } from "./components/icon-button";
@@ -12,17 +12,23 @@ import {
   AUTH_APIKEY,
   AUTH_BEARER,
   AUTH_NONE,
+  AUTH_OAUTH,
   DEFAULT_API_KEY_NAME,
   authNote,
   authSchemes,
   count,
   deriveAppName,
+  endpointParameters,
+  endpointText,
   eraLabel,
+  isCommandLine,
   isReadableEndpoint,
   uniqueAppName,
 } from "./mcpServer";
 import { InspectMcpResponse, McpApp, McpProblem, McpProblemKind, McpServer } from "./server/api";
 import { getApiClient } from "./server/connection";
+import { isWailsEnvironment } from "./wails";
+import { BrowserOpenURL } from "./wailsjs/runtime/runtime";
 
 type ReadState = { status: "idle" } | { status: "reading" } | { status: "read"; server: McpServer } | { status: "problem"; problem: McpProblem };
 
@@ -33,10 +39,11 @@ const READ_DEBOUNCE_MS = 600;
 const inspected = new Map<string, McpServer>();
 const INSPECTED_LIMIT = 20;
 
-// inspectionKey is the endpoint and nothing else. Credentials are sent with the read
-// but are not part of the key — a token is typed a character at a time.
+// inspectionKey is the endpoint, or the command and what it is launched with, and
+// nothing else. Credentials are sent with the read but are not part of the key — a
+// token is typed a character at a time.
 function inspectionKey(parameters: Record<string, string>): string {
-  return (parameters.url ?? "").trim();
+  return [endpointText(parameters).trim(), parameters.env ?? "", parameters.workingDir ?? ""].join("\n");
 }
 
 // Reads already on the wire, so two forms ask the server the same question once.
@@ -109,12 +116,18 @@ export function McpForm({
   // Typing is the only change that waits for a pause before reading.
   const typedRef = useRef(false);
 
-  const url = parameters.url ?? "";
+  const endpoint = endpointText(parameters);
+  const local = isCommandLine(endpoint);
+  // What is in the field, which the parameters can't give back exactly: a command
+  // typed up to a space has no argument yet.
+  const [field, setField] = useState(endpoint);
   const server = state.status === "read" ? state.server : undefined;
   const problem = state.status === "problem" ? state.problem : undefined;
   const source = inspectionKey(parameters);
 
-  const read = useCallback(async (options?: { fresh?: boolean }) => {
+  // read reads the server and returns the problem it had, if it had one and this is
+  // still the latest read.
+  const read = useCallback(async (options?: { fresh?: boolean }): Promise<McpProblem | undefined> => {
     const parameters = parametersRef.current;
     const key = inspectionKey(parameters);
     const readId = ++readIdRef.current;
@@ -135,10 +148,15 @@ export function McpForm({
         remember(key, response.server);
         setState({ status: "read", server: response.server });
       } else {
-        setState({
-          status: "problem",
-          problem: response.problem ?? { kind: McpProblemKind.MCP_PROBLEM_UNKNOWN, message: "Couldn't read the server", detail: "" },
-        });
+        const problem = response.problem ?? {
+          kind: McpProblemKind.MCP_PROBLEM_UNKNOWN,
+          message: "Couldn't read the server",
+          detail: "",
+          authorizationUrl: "",
+          authorizationServer: "",
+        };
+        setState({ status: "problem", problem });
+        return problem;
       }
     } catch (error) {
       if (readId !== readIdRef.current) return;
@@ -148,6 +166,8 @@ export function McpForm({
           kind: McpProblemKind.MCP_PROBLEM_UNKNOWN,
           message: "Couldn't read the server",
           detail: error instanceof Error ? error.message : String(error),
+          authorizationUrl: "",
+          authorizationServer: "",
         },
       });
     }
@@ -159,7 +179,7 @@ export function McpForm({
     const typed = typedRef.current;
     typedRef.current = false;
 
-    if (!isReadableEndpoint(parametersRef.current.url ?? "")) {
+    if (!isReadableEndpoint(endpointText(parametersRef.current))) {
       readIdRef.current++;
       setState({ status: "idle" });
       return;
@@ -172,25 +192,41 @@ export function McpForm({
     return () => clearTimeout(timer);
   }, [source, read]);
 
+  // A change that didn't come from the field - the demo link - is shown in it.
+  useEffect(() => {
+    setField((previous) => (endpointText(endpointParameters(previous)) === endpoint ? previous : endpoint));
+  }, [endpoint]);
+
   useEffect(() => onSurfaceChange(server ? { count: surfaceCount(server) } : undefined), [server, onSurfaceChange]);
 
   // Derive the name from what the server calls itself until the user types one.
   useEffect(() => {
     if (!server || nameTouched) return;
-    const derived = uniqueAppName(deriveAppName(parametersRef.current.url ?? "", server.name), takenNames);
+    const derived = uniqueAppName(deriveAppName(endpointText(parametersRef.current), server.name), takenNames);
     if (derived) onNameChange(derived);
   }, [server, nameTouched, takenNames, onNameChange]);
 
   // A server that won't say what it exposes without a credential is asking for the one
-  // MCP's own authorization framework hands out.
+  // MCP's own authorization framework hands out. One that names where it is signed in
+  // to is offered the sign-in; one that doesn't needs the token pasted.
   useEffect(() => {
     if (readOnly || problem?.kind !== McpProblemKind.MCP_PROBLEM_UNAUTHORIZED) return;
-    onParametersChange((previous) => ((previous.auth ?? "") === "" ? { ...previous, auth: AUTH_BEARER } : previous));
+    const wanted = problem.authorizationUrl ? AUTH_OAUTH : AUTH_BEARER;
+    onParametersChange((previous) => ((previous.auth ?? "") === "" ? { ...previous, auth: wanted } : previous));
   }, [problem, readOnly, onParametersChange]);
 
+  // Signing in is the user's to choose: the server only said where it would be done.
+  // Choosing it has the app say "oauth", and the read that follows starts the sign-in.
+  const signIn = useCallback(async () => {
+    parametersRef.current = { ...parametersRef.current, auth: AUTH_OAUTH };
+    onParametersChange((previous) => ({ ...previous, auth: AUTH_OAUTH }));
+    const problem = await read({ fresh: true });
+    if (problem?.authorizationUrl) openSignIn(problem.authorizationUrl);
+  }, [read, onParametersChange]);
+
   useEffect(() => {
-    onReadyChange(Boolean(server) && isReadableEndpoint(parametersRef.current.url ?? ""));
-  }, [server, url, onReadyChange]);
+    onReadyChange(Boolean(server) && isReadableEndpoint(endpointText(parametersRef.current)));
+  }, [server, endpoint, onReadyChange]);
 
   const setParameter = (key: string, value: string) => onParametersChange((previous) => ({ ...previous, [key]: value }));
 
@@ -198,26 +234,28 @@ export function McpForm({
   const auth = (parameters.auth ?? "").trim() || AUTH_BEARER;
 
   // A credential the server wants is asked for before anything has been read: it is the
-  // whole reason nothing has been.
+  // whole reason nothing has been. A local server is handed its credentials in env.
   const showAuthentication =
-    Boolean(server) || problem?.kind === McpProblemKind.MCP_PROBLEM_UNAUTHORIZED || problem?.kind === McpProblemKind.MCP_PROBLEM_FORBIDDEN;
+    !local &&
+    (Boolean(server) || problem?.kind === McpProblemKind.MCP_PROBLEM_UNAUTHORIZED || problem?.kind === McpProblemKind.MCP_PROBLEM_FORBIDDEN);
 
   return (
     <div className="flex max-w-[640px] flex-col gap-6">
       <div className="flex flex-col gap-2">
         <label className="text-sm font-medium text-foreground" htmlFor="mcp-url">
-          MCP endpoint
+          MCP endpoint or command
         </label>
 
         <VariableSuggestInput
           id="mcp-url"
-          value={url}
+          value={field}
           onValueChange={(value) => {
             typedRef.current = true;
-            setParameter("url", value);
+            setField(value);
+            onParametersChange((previous) => ({ ...previous, ...endpointParameters(value) }));
           }}
           variables={variables}
-          placeholder="https://example.com/mcp"
+          placeholder="https://example.com/mcp or npx -y @example/mcp-server"
           disabled={readOnly}
           onKeyDown={(event) => {
             if (event.key === "Enter") {
@@ -231,15 +269,20 @@ export function McpForm({
           state={state}
           readOnly={readOnly}
           demoLabel={demo?.label}
-          onDemo={demo ? () => onParametersChange((previous) => ({ ...previous, ...demo.parameters })) : undefined}
+          onDemo={
+            demo ? () => onParametersChange((previous) => ({ ...previous, ...endpointParameters(demo.parameters.url ?? ""), ...demo.parameters })) : undefined
+          }
           onCancel={() => {
             readIdRef.current++;
             setState({ status: "idle" });
           }}
           onRetry={() => read({ fresh: true })}
+          onSignIn={signIn}
         />
       </div>
 
+      {local && <LocalServerSection parameters={parameters} onParameterChange={setParameter} variables={variables} readOnly={readOnly} />}
+
       {(server || showAuthentication) && (
         <>
           <div className="h-px bg-border" />
@@ -282,11 +325,12 @@ interface EndpointStatusProps {
   onDemo?: () => void;
   onCancel: () => void;
   onRetry: () => void;
+  onSignIn: () => void;
 }
 
 // EndpointStatus is the one slot under the endpoint carrying every state: the caption,
 // the read in progress, the server that answered, and each way reaching it can fail.
-function EndpointStatus({ state, readOnly, demoLabel, onDemo, onCancel, onRetry }: EndpointStatusProps) {
+function EndpointStatus({ state, readOnly, demoLabel, onDemo, onCancel, onRetry, onSignIn }: EndpointStatusProps) {
   if (state.status === "idle") {
     return (
       <div className="flex items-center gap-1.5">
@@ -320,7 +364,7 @@ function EndpointStatus({ state, readOnly, demoLabel, onDemo, onCancel, onRetry
     return <ServerSummary server={state.server} onRefresh={onRetry} />;
   }
 
-  return <ProblemBanner problem={state.problem} readOnly={readOnly} onRetry={onRetry} />;
+  return <ProblemBanner problem={state.problem} readOnly={readOnly} onRetry={onRetry} onSignIn={onSignIn} />;
 }
 
 function ServerSummary({ server, onRefresh }: { server: McpServer; onRefresh: () => void }) {
@@ -364,7 +408,7 @@ function ServerSummary({ server, onRefresh }: { server: McpServer; onRefresh: ()
   );
 }
 
-function ProblemBanner({ problem, readOnly, onRetry }: { problem: McpProblem; readOnly: boolean; onRetry: () => void }) {
+function ProblemBanner({ problem, readOnly, onRetry, onSignIn }: { problem: McpProblem; readOnly: boolean; onRetry: () => void; onSignIn: () => void }) {
   const warning = problem.kind === McpProblemKind.MCP_PROBLEM_EMPTY || problem.kind === McpProblemKind.MCP_PROBLEM_UNAUTHORIZED;
   const Icon: LucideIcon = warning ? TriangleAlert : problem.kind === McpProblemKind.MCP_PROBLEM_NOT_MCP ? CircleAlert : CircleX;
 
@@ -384,6 +428,12 @@ function ProblemBanner({ problem, readOnly, onRetry }: { problem: McpProblem; re
       </div>
       {!readOnly && (
         <div className="ml-auto flex shrink-0 items-center gap-2">
+          {(problem.authorizationUrl || problem.authorizationServer) && (
+            <Button variant="outline" size="sm" onClick={() => (problem.authorizationUrl ? openSignIn(problem.authorizationUrl) : onSignIn())}>
+              <LogIn size={14} />
+              Sign in
+            </Button>
+          )}
           <Button variant="ghost" size="sm" onClick={onRetry}>
             Retry
           </Button>
@@ -393,6 +443,59 @@ function ProblemBanner({ problem, readOnly, onRetry }: { problem: McpProblem; re
   );
 }
 
+// The sign-in is the user's own, in their browser: Kaja only hears back once it is done,
+// on a listener of its own, and the form is read again with Retry.
+function openSignIn(url: string) {
+  if (isWailsEnvironment()) {
+    BrowserOpenURL(url);
+  } else {
+    window.open(url, "_blank");
+  }
+}
+
+interface LocalServerSectionProps {
+  parameters: Record<string, string>;
+  onParameterChange: (key: string, value: string) => void;
+  variables: { [key: string]: string };
+  readOnly: boolean;
+}
+
+// What a local server is launched with besides its arguments. Its credentials go in
+// env, where a ${NAME} is expanded by the server kaja runs on and never reaches here.
+function LocalServerSection({ parameters, onParameterChange, variables, readOnly }: LocalServerSectionProps) {
+  return (
+    <div className="flex flex-col gap-4">
+      <div className="flex flex-col gap-2">
+        <label className="text-sm font-medium text-foreground" htmlFor="mcp-env">
+          Environment
+        </label>
+        <VariableSuggestInput
+          id="mcp-env"
+          value={parameters.env ?? ""}
+          onValueChange={(value) => onParameterChange("env", value)}
+          variables={variables}
+          placeholder="NAME=value, NAME=${VARIABLE}"
+          disabled={readOnly}
+        />
+        <p className="text-xs text-muted-foreground">Added to Kaja's own environment for the server.</p>
+      </div>
+      <div className="flex flex-col gap-2">
+        <label className="text-sm font-medium text-foreground" htmlFor="mcp-working-dir">
+          Working directory
+        </label>
+        <VariableSuggestInput
+          id="mcp-working-dir"
+          value={parameters.workingDir ?? ""}
+          onValueChange={(value) => onParameterChange("workingDir", value)}
+          variables={variables}
+          placeholder="The workspace"
+          disabled={readOnly}
+        />
+      </div>
+    </div>
+  );
+}
+
 interface AuthenticationSectionProps {
   selected: string;
   onSelect: (value: string) => void;
@@ -402,8 +505,8 @@ interface AuthenticationSectionProps {
   readOnly: boolean;
 }
 
-// A fixed list: there is no document declaring what a server accepts, so the two
-// shapes a credential comes in are offered outright.
+// A fixed list: there is no document declaring what a server accepts, so the shapes
+// a credential comes in are offered outright, and signing in for one.
 function AuthenticationSection({ selected, onSelect, parameters, onParameterChange, variables, readOnly }: AuthenticationSectionProps) {
   return (
     <div className="flex flex-col gap-2">
@@ -414,7 +517,7 @@ function AuthenticationSection({ selected, onSelect, parameters, onParameterChan
           const note = authNote(scheme.key, parameters.apiKeyName ?? "");
           return (
             <ChoiceCard key={scheme.key} selected={active}>
-              <ChoiceRow selected={active} disabled={readOnly} onSelect={() => onSelect(scheme.key)} icon={scheme.key === AUTH_APIKEY ? Key : Blocks}>
+              <ChoiceRow selected={active} disabled={readOnly} onSelect={() => onSelect(scheme.key)} icon={scheme.key === AUTH_APIKEY ? Key : scheme.key === AUTH_OAUTH ? LogIn : Blocks}>
                 <span className="flex min-w-0 flex-col">
                   <span className="truncate text-sm text-foreground">{scheme.label}</span>
                   <span className="truncate text-xs text-muted-foreground">{scheme.summary}</span>
@@ -431,13 +534,15 @@ function AuthenticationSection({ selected, onSelect, parameters, onParameterChan
                       disabled={readOnly}
                     />
                   )}
-                  <VariableSuggestInput
-                    value={parameters.token ?? ""}
-                    onValueChange={(value) => onParameterChange("token", value)}
-                    variables={variables}
-                    placeholder={scheme.key === AUTH_APIKEY ? "API key or ${VARIABLE}" : "Token or ${VARIABLE}"}
-                    disabled={readOnly}
-                  />
+                  {scheme.key !== AUTH_OAUTH && (
+                    <VariableSuggestInput
+                      value={parameters.token ?? ""}
+                      onValueChange={(value) => onParameterChange("token", value)}
+                      variables={variables}
+                      placeholder={scheme.key === AUTH_APIKEY ? "API key or ${VARIABLE}" : "Token or ${VARIABLE}"}
+                      disabled={readOnly}
+                    />
+                  )}
                   {note.text && <p className={cn("text-xs text-muted-foreground", note.mono && "font-mono")}>{note.text}</p>}
                 </div>
               )}
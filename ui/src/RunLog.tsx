@@ -151,6 +151,7 @@ export function RunLog({
                     loopKey={item.key}
                     status={itemStatus(item)}
                     durationMs={item.call.durationMs}
+                    queuedMs={item.call.queuedMs}
                     errorCode={callErrorCode(item.call)}
                     fraction={barFraction(item.call.durationMs, slowest)}
                     selected={item.id === selectedItemId}
@@ -427,6 +428,7 @@ interface CallRowProps {
   loopKey?: string;
   status: RunStatus;
   durationMs?: number;
+  queuedMs?: number;
   errorCode?: string;
   fraction?: number;
   selected: boolean;
@@ -439,7 +441,7 @@ interface CallRowProps {
  * One call, and the same shape for every one of them.
  *
  * Every prop is a value rather than an object, which is what makes the memo hold: a
- * settled row is handed the same twelve values on every repaint and doesn't render
+ * settled row is handed the same thirteen values on every repaint and doesn't render
  * again. `now` is the exception and is passed as zero unless the row is counting up.
  */
 RunLog.CallRow = memo(function CallRow({
@@ -449,6 +451,7 @@ RunLog.CallRow = memo(function CallRow({
   loopKey,
   status,
   durationMs,
+  queuedMs,
   errorCode,
   fraction,
   selected,
@@ -478,7 +481,9 @@ RunLog.CallRow = memo(function CallRow({
           />
         </span>
       )}
-      <span className={DURATION_COLUMN_CLASS}>{pending ? formatElapsed(now - timestamp) : formatDuration(durationMs)}</span>
+      <span className={DURATION_COLUMN_CLASS} title={queuedMs !== undefined ? `${formatDuration(queuedMs)} of it queued under the app's limits` : undefined}>
+        {pending ? formatElapsed(now - timestamp) : formatDuration(durationMs)}
+      </span>
     </div>
   );
 });
@@ -1,10 +1,12 @@
-import { Blocks, Globe, FolderOpen, Plug, Server, Sparkles, type LucideIcon } from "lucide-react";
+import { Blocks, Bot, Braces, Database, Globe, FolderOpen, Network, Plug, Radio, Server, Share2, Sparkles, type LucideIcon } from "lucide-react";
 import { ConfigurationApp } from "./server/api";
 
 // Parameter kinds an app exposes in the New form. "file" and "folder" render a native
 // picker on the desktop and a plain text field elsewhere; "upload" reads a chosen
-// file's text content into the parameter value, on both.
-export type AppParameterType = "text" | "url" | "file" | "folder" | "boolean" | "upload";
+// file's text content into the parameter value, on both. "number" is a text field
+// whose empty value is the field's zero. "list" is a comma-separated text field for a
+// repeated field.
+export type AppParameterType = "text" | "url" | "file" | "folder" | "boolean" | "upload" | "number" | "list";
 
 export interface AppParameterDefinition {
   key: string;
@@ -69,6 +71,19 @@ export const appTypes: AppTypeDefinition[] = [
       { key: "username", label: "Username", type: "text", optional: true },
       { key: "password", label: "Password", type: "text", optional: true },
       { key: "apiKeyName", label: "Metadata key", type: "text", optional: true },
+      { key: "compression", label: "Compression", type: "text", optional: true },
+      { key: "maxReceiveBytes", label: "Largest response (bytes)", type: "number", optional: true },
+      { key: "maxSendBytes", label: "Largest request (bytes)", type: "number", optional: true },
+      { key: "protocol", label: "Protocol", type: "text", optional: true },
+      { key: "endpoints", label: "More endpoints", type: "list", placeholder: "dns:replica-2.example.com:443", optional: true },
+      { key: "loadBalancing", label: "Load balancing", type: "text", optional: true },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
     ],
     demo: {
       label: "try the grpcb.in demo server",
@@ -96,6 +111,24 @@ export const appTypes: AppTypeDefinition[] = [
         placeholder: "path/to/proto",
         caption: "Directory of .proto files (Twirp has no reflection).",
       },
+      { key: "tls", label: "Transport", type: "text", optional: true },
+      { key: "insecureSkipVerify", label: "Accept any certificate", type: "boolean", optional: true },
+      { key: "caFile", label: "Certificate authority", type: "file", optional: true },
+      { key: "clientCertFile", label: "Client certificate", type: "file", optional: true },
+      { key: "clientKeyFile", label: "Client key", type: "file", optional: true },
+      { key: "auth", label: "Authentication", type: "text", optional: true },
+      { key: "token", label: "Token or API key", type: "text", optional: true },
+      { key: "username", label: "Username", type: "text", optional: true },
+      { key: "password", label: "Password", type: "text", optional: true },
+      { key: "apiKeyName", label: "Header name", type: "text", optional: true },
+      { key: "encoding", label: "Encoding", type: "text", placeholder: "protobuf", optional: true },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
     ],
   },
   {
@@ -117,6 +150,13 @@ export const appTypes: AppTypeDefinition[] = [
       { key: "password", label: "Password", type: "text", optional: true },
       { key: "specHeaderName", label: "Document header", type: "text", optional: true },
       { key: "specHeaderValue", label: "Document header value", type: "text", optional: true },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
     ],
     demo: {
       label: "try the Petstore demo",
@@ -134,10 +174,27 @@ export const appTypes: AppTypeDefinition[] = [
     customForm: true,
     surfaceNoun: "method",
     parameters: [
-      { key: "url", label: "MCP endpoint", type: "url", placeholder: "https://example.com/mcp" },
+      { key: "url", label: "MCP endpoint", type: "url", placeholder: "https://example.com/mcp", optional: true },
+      // A local server is launched from command in place of url.
+      { key: "command", label: "Command", type: "text", placeholder: "npx", optional: true },
+      { key: "args", label: "Arguments", type: "list", placeholder: "-y, @example/mcp-server", optional: true },
+      { key: "env", label: "Environment", type: "list", placeholder: "NAME=value", optional: true },
+      { key: "workingDir", label: "Working directory", type: "folder", optional: true },
       { key: "auth", label: "Authentication", type: "text", optional: true },
       { key: "token", label: "Token or API key", type: "text", optional: true },
       { key: "apiKeyName", label: "Header name", type: "text", optional: true },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
+      // What the server may ask of Kaja mid-call: the folder apps it may work in, and the
+      // openai app its sampling requests are completed with.
+      { key: "roots", label: "Roots", type: "list", placeholder: "Every folder app", optional: true },
+      { key: "samplingApp", label: "Sampling app", type: "text", optional: true },
+      { key: "samplingModel", label: "Sampling model", type: "text", placeholder: "gpt-4o-mini", optional: true },
     ],
     demo: {
       label: "try the DeepWiki demo server",
@@ -149,15 +206,15 @@ export const appTypes: AppTypeDefinition[] = [
     preview: true,
     type: "openai",
     label: "OpenAI",
-    description: "Call the standard OpenAI chat completions API.",
+    description: "Call the OpenAI API, or a gateway compatible with it: chat, models, embeddings, responses and moderation.",
     icon: Sparkles,
     parameters: [
       {
         key: "endpoint",
-        label: "Chat completions endpoint",
+        label: "Base URL",
         type: "url",
-        placeholder: "https://api.openai.com/v1/chat/completions",
-        caption: "Full URL of the chat completions endpoint. Requests are POSTed directly to it.",
+        placeholder: "https://api.openai.com/v1",
+        caption: "Base URL of the API. Each method calls its own path under it, e.g. /chat/completions or /embeddings.",
       },
       {
         key: "token",
@@ -166,18 +223,57 @@ export const appTypes: AppTypeDefinition[] = [
         placeholder: "sk-...",
         caption: "Sent as a Bearer token in the Authorization header of each request.",
       },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
     ],
     demo: {
       label: "Use the OpenAI endpoint",
       name: "OpenAI",
-      parameters: { endpoint: "https://api.openai.com/v1/chat/completions" },
+      parameters: { endpoint: "https://api.openai.com/v1" },
     },
   },
+  {
+    preview: true,
+    type: "anthropic",
+    label: "Anthropic",
+    description: "Call the Anthropic Messages API, or a gateway that serves it: messages, streamed or not, and token counts.",
+    icon: Bot,
+    parameters: [
+      {
+        key: "endpoint",
+        label: "Base URL",
+        type: "url",
+        placeholder: "https://api.anthropic.com",
+        caption: "Base URL of the API. Messages are sent to /v1/messages under it.",
+        optional: true,
+      },
+      {
+        key: "token",
+        label: "API key",
+        type: "text",
+        placeholder: "sk-ant-...",
+        caption: "Sent in the x-api-key header of each request. Kaja holds it; the browser never sees it.",
+      },
+      { key: "version", label: "API version", type: "text", placeholder: "2023-06-01", optional: true },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
+    ],
+  },
   {
     preview: true,
     type: "folder",
     label: "Folder",
-    description: "List, create, read and append to files in a folder on disk.",
+    description: "List, search, read, write, move, delete and watch files, text or binary, in a folder on disk.",
     icon: FolderOpen,
     parameters: [
       {
@@ -185,10 +281,166 @@ export const appTypes: AppTypeDefinition[] = [
         label: "Folder",
         type: "folder",
         placeholder: "/path/to/notes",
-        caption: "Methods list, create, read and append to files in this folder. On the desktop, pick the folder to grant access.",
+        caption: "Methods list, search, read, write, move, delete and watch files in this folder. On the desktop, pick the folder to grant access.",
       },
     ],
   },
+  {
+    preview: true,
+    type: "sqlite",
+    label: "SQLite",
+    description: "Query and change a SQLite database file, and look into its tables.",
+    icon: Database,
+    parameters: [
+      {
+        key: "path",
+        label: "Database",
+        type: "file",
+        placeholder: "data/results.db",
+        caption: "Created on the first write when it does not exist yet.",
+      },
+      { key: "readOnly", label: "Read-only", type: "boolean", optional: true },
+    ],
+  },
+  {
+    preview: true,
+    type: "graphql",
+    label: "GraphQL",
+    description: "Call a GraphQL endpoint: every query and mutation is a method, with its arguments as the request.",
+    icon: Share2,
+    parameters: [
+      { key: "url", label: "Endpoint", type: "url", placeholder: "https://example.com/graphql" },
+      {
+        key: "schemaFile",
+        label: "Schema",
+        type: "file",
+        placeholder: "schema.graphql",
+        caption: "SDL or an introspection result. Leave empty to ask the endpoint with an introspection query.",
+        optional: true,
+      },
+      { key: "auth", label: "Authentication", type: "text", placeholder: "bearer, basic, apikey, none", optional: true },
+      { key: "token", label: "Token or API key", type: "text", optional: true },
+      { key: "username", label: "Username", type: "text", optional: true },
+      { key: "password", label: "Password", type: "text", optional: true },
+      { key: "apiKeyName", label: "Header name", type: "text", optional: true },
+      {
+        key: "depth",
+        label: "Selection depth",
+        type: "number",
+        placeholder: "2",
+        caption: "How many levels of objects a call's response asks for, unless the call writes its own selection.",
+        optional: true,
+      },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
+    ],
+  },
+  {
+    preview: true,
+    type: "jsonrpc",
+    label: "JSON-RPC",
+    description: "Call a JSON-RPC 2.0 server described by an OpenRPC document, one call at a time or in batches.",
+    icon: Braces,
+    parameters: [
+      {
+        key: "url",
+        label: "Endpoint",
+        type: "url",
+        placeholder: "http://localhost:8545",
+        caption: "Calls are POSTed here. Leave empty to use the first server the document names.",
+        optional: true,
+      },
+      {
+        key: "specUrl",
+        label: "OpenRPC URL",
+        type: "url",
+        placeholder: "https://example.com/openrpc.json",
+        caption: "Leave this and the file empty to ask the endpoint with rpc.discover.",
+        optional: true,
+      },
+      { key: "specFile", label: "OpenRPC file", type: "file", placeholder: "openrpc.json", optional: true },
+      { key: "auth", label: "Authentication", type: "text", placeholder: "bearer, basic, apikey, none", optional: true },
+      { key: "token", label: "Token or API key", type: "text", optional: true },
+      { key: "username", label: "Username", type: "text", optional: true },
+      { key: "password", label: "Password", type: "text", optional: true },
+      { key: "apiKeyName", label: "Header name", type: "text", optional: true },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
+    ],
+  },
+  {
+    preview: true,
+    type: "websocket",
+    label: "WebSocket",
+    description: "Send JSON over a WebSocket, await correlated replies, and stream what the server sends.",
+    icon: Radio,
+    parameters: [
+      {
+        key: "url",
+        label: "URL",
+        type: "url",
+        placeholder: "wss://example.com/ws",
+        caption: "Leave empty to use the first WebSocket server the AsyncAPI document names.",
+        optional: true,
+      },
+      {
+        key: "asyncapiUrl",
+        label: "AsyncAPI URL",
+        type: "url",
+        placeholder: "https://example.com/asyncapi.yaml",
+        caption: "Adds a method for each message the document describes.",
+        optional: true,
+      },
+      { key: "asyncapiFile", label: "AsyncAPI file", type: "file", placeholder: "asyncapi.yaml", optional: true },
+      {
+        key: "correlationField",
+        label: "Correlation field",
+        type: "text",
+        placeholder: "id",
+        caption: "The field a reply shares with the request it answers.",
+        optional: true,
+      },
+      { key: "subprotocols", label: "Subprotocols", type: "list", optional: true },
+      { key: "auth", label: "Authentication", type: "text", placeholder: "bearer, basic, apikey, none", optional: true },
+      { key: "token", label: "Token or API key", type: "text", optional: true },
+      { key: "username", label: "Username", type: "text", optional: true },
+      { key: "password", label: "Password", type: "text", optional: true },
+      { key: "apiKeyName", label: "Header name", type: "text", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
+    ],
+  },
+  {
+    preview: true,
+    type: "http",
+    label: "HTTP",
+    description: "Call an HTTP API that has no OpenAPI document, and name its endpoints in kaja.json.",
+    icon: Network,
+    parameters: [
+      { key: "baseUrl", label: "Base URL", type: "url", placeholder: "https://internal.example.com/api" },
+      { key: "token", label: "Token", type: "text", optional: true },
+      { key: "username", label: "Username", type: "text", optional: true },
+      { key: "password", label: "Password", type: "text", optional: true },
+      { key: "retryMaxAttempts", label: "Attempts per call", type: "number", optional: true },
+      { key: "retryBackoffMs", label: "First retry after (ms)", type: "number", optional: true },
+      { key: "retryMaxBackoffMs", label: "Longest retry wait (ms)", type: "number", optional: true },
+      { key: "retryCodes", label: "Retried failures", type: "list", placeholder: "UNAVAILABLE, 429, 503", optional: true },
+      { key: "rateLimit", label: "Calls per second", type: "number", optional: true },
+      { key: "rateLimitBurst", label: "Burst", type: "number", optional: true },
+      { key: "maxConcurrency", label: "Calls at once", type: "number", optional: true },
+    ],
+  },
 ];
 
 export function getAppType(type: string): AppTypeDefinition | undefined {
@@ -211,17 +463,32 @@ function appVariant(app: ConfigurationApp): Record<string, unknown> | undefined
 }
 
 // appParameters reads the fields the app's type declares into the string map the form
-// works with. Booleans become "true"/"". Keys are the camelCase field names.
+// works with. Booleans become "true"/"", lists comma-separated text. Keys are the
+// camelCase field names.
 export function appParameters(app: ConfigurationApp): Record<string, string> {
   const variant = appVariant(app);
   const params: Record<string, string> = {};
   for (const parameter of getAppType(appType(app))?.parameters ?? []) {
     const value = variant?.[parameter.key];
-    params[parameter.key] = typeof value === "boolean" ? (value ? "true" : "") : String(value ?? "");
+    if (typeof value === "boolean") {
+      params[parameter.key] = value ? "true" : "";
+    } else if (Array.isArray(value)) {
+      params[parameter.key] = value.join(", ");
+    } else if (parameter.type === "number") {
+      params[parameter.key] = value && value !== "0" ? String(value) : "";
+    } else {
+      params[parameter.key] = String(value ?? "");
+    }
   }
   return params;
 }
 
+// twirpSendsJson is whether a twirp app speaks JSON to its service rather than the
+// protobuf kaja sends otherwise.
+export function twirpSendsJson(app: ConfigurationApp): boolean {
+  return app.app.oneofKind === "twirp" && app.app.twirp.encoding.trim().toLowerCase() === "json";
+}
+
 // appHeaders reads the headers an app forwards upstream. They live inside the typed
 // block (every type but the local Folder app has them).
 export function appHeaders(app: ConfigurationApp): Record<string, string> {
@@ -234,10 +501,26 @@ export function appHeaders(app: ConfigurationApp): Record<string, string> {
 // is applied where it lives rather than handed to the browser to send.
 export const APP_HEADER = "X-Kaja-App";
 
+// ENDPOINT_HEADER pins a call to one of its app's endpoints, for a script that said
+// which with Call.on. It is reserved the way APP_HEADER is, and goes no further than
+// the router that picks the endpoint.
+export const ENDPOINT_HEADER = "X-Kaja-Endpoint";
+
+// ANSWER_HEADER carries the answer to a question the app asked on an earlier attempt
+// at the call (elicitation.ts). The app takes it out; it never reaches the upstream.
+export const ANSWER_HEADER = "X-Kaja-Answer";
+
 // transportHeaders is what a call actually sends. `appHeaders` stays what the Headers
 // view shows, which is the configuration and nothing kaja added to route the call.
-export function transportHeaders(app: ConfigurationApp): Record<string, string> {
-  return { ...appHeaders(app), [APP_HEADER]: app.name };
+export function transportHeaders(app: ConfigurationApp, endpoint?: string, answer?: string): Record<string, string> {
+  const headers = { ...appHeaders(app), [APP_HEADER]: app.name };
+  if (endpoint) {
+    headers[ENDPOINT_HEADER] = endpoint;
+  }
+  if (answer) {
+    headers[ANSWER_HEADER] = answer;
+  }
+  return headers;
 }
 
 // Only the local Folder app does not.
@@ -246,13 +529,31 @@ export function typeForwardsHeaders(type: string): boolean {
 }
 
 // buildApp constructs a ConfigurationApp from the generic form state: the typed block
-// for `type` with the declared params (coercing booleans) and, for types that forward
-// them, the headers.
-export function buildApp(name: string, type: string, params: Record<string, string>, headers: Record<string, string>): ConfigurationApp {
-  const variant: Record<string, unknown> = {};
+// for `type` with the declared params (coercing booleans, numbers and lists) and, for types that forward
+// them, the headers. Fields the form doesn't show, such as an http app's endpoints, are kept from
+// `base` when it is an app of the same type.
+export function buildApp(
+  name: string,
+  type: string,
+  params: Record<string, string>,
+  headers: Record<string, string>,
+  base?: ConfigurationApp,
+): ConfigurationApp {
+  const variant: Record<string, unknown> = base && appType(base) === type ? { ...appVariant(base) } : {};
   for (const parameter of getAppType(type)?.parameters ?? []) {
     const value = params[parameter.key] ?? "";
-    variant[parameter.key] = parameter.type === "boolean" ? value === "true" : value;
+    if (parameter.type === "boolean") {
+      variant[parameter.key] = value === "true";
+    } else if (parameter.type === "number") {
+      variant[parameter.key] = value.trim() || "0";
+    } else if (parameter.type === "list") {
+      variant[parameter.key] = value
+        .split(",")
+        .map((item) => item.trim())
+        .filter(Boolean);
+    } else {
+      variant[parameter.key] = value;
+    }
   }
   if (typeForwardsHeaders(type)) {
     variant.headers = { ...headers };
@@ -69,4 +69,18 @@ describe("Call", () => {
     expect(call.label).toBe("Shows.ListShows");
     expect(call.input).toEqual({ pageSize: 25 });
   });
+
+  it("is pinned to an endpoint in the tick it was written in", async () => {
+    const { call } = stub("shows");
+    expect(call.on("dns:shows-2.staging:9000")).toBe(call);
+    expect(call.endpoint).toBe("dns:shows-2.staging:9000");
+    expect(await call).toBe("shows");
+  });
+
+  it("can't be pinned once it has gone out", async () => {
+    const { call } = stub("shows");
+    await tick();
+    expect(() => call.on("dns:shows-2.staging:9000")).toThrow("already been sent");
+    expect(call.endpoint).toBeUndefined();
+  });
 });
@@ -30,6 +30,16 @@ describe("classifyFailure", () => {
     expect(classifyFailure({ message: "?", code: "SOMETHING_NEW" }).kind).toBe("SERVER");
   });
 
+  it("reads a Twirp error by its code and keeps its meta", () => {
+    const failure = classifyFailure({ message: "no such route", code: "bad_route", meta: { twirp_invalid_route: "POST /x" } });
+    expect(failure).toEqual({ kind: "NOT_FOUND", message: "no such route", code: "bad_route", meta: { twirp_invalid_route: "POST /x" } });
+    expect(classifyFailure({ message: "bad body", code: "malformed" }).kind).toBe("INVALID_REQUEST");
+    // The server read the error already; its kind stands, and one it can't have
+    // meant falls back to the code.
+    expect(classifyFailure({ message: "down", code: "unavailable", kind: "RATE_LIMITED" }).kind).toBe("RATE_LIMITED");
+    expect(classifyFailure({ message: "down", code: "unavailable", kind: "MAYBE" }).kind).toBe("TRANSPORT");
+  });
+
   // The failure the audit stalled on: no status and no code, so retrying with a
   // different request shape is wasted work.
   it("calls a broken exchange a transport failure", () => {
@@ -16,35 +16,49 @@ export interface CallFailure {
   // service failure. What labels the failure where it is shown.
   status?: number;
   code?: string;
+  // What a Twirp service attached to its error for the caller, such as which
+  // argument it refused.
+  meta?: { [key: string]: string };
 }
 
+const failureKinds: FailureKind[] = ["INVALID_REQUEST", "UNAUTHORIZED", "NOT_FOUND", "RATE_LIMITED", "SERVER", "TRANSPORT", "UNKNOWN"];
+
 // How a gRPC/Twirp status maps onto the kinds. Codes not listed here are read as
-// SERVER: the call reached a server and the server refused it.
+// SERVER: the call reached a server and the server refused it. Twirp spells a few
+// codes of its own. The server keeps the same table (mcp.CodeKind).
 const codeKinds: { [code: string]: FailureKind } = {
   invalid_argument: "INVALID_REQUEST",
   out_of_range: "INVALID_REQUEST",
   failed_precondition: "INVALID_REQUEST",
   already_exists: "INVALID_REQUEST",
+  malformed: "INVALID_REQUEST",
   unauthenticated: "UNAUTHORIZED",
   permission_denied: "UNAUTHORIZED",
   not_found: "NOT_FOUND",
   unimplemented: "NOT_FOUND",
+  bad_route: "NOT_FOUND",
   resource_exhausted: "RATE_LIMITED",
   unavailable: "TRANSPORT",
   deadline_exceeded: "TRANSPORT",
   cancelled: "TRANSPORT",
+  canceled: "TRANSPORT",
 };
 
 export function classifyFailure(error: unknown): CallFailure {
   const message = failureMessage(error);
   const status = numberField(error, "status");
   const code = stringField(error, "code");
+  const meta = stringMap(error, "meta");
 
   if (status !== undefined && status > 0) {
     return { kind: statusKind(status), message, status, code };
   }
   if (code) {
-    return { kind: codeKinds[code.toLowerCase()] ?? "SERVER", message, code };
+    // A Twirp error the server already read says what kind it is.
+    const kind = stringField(error, "kind") as FailureKind | undefined;
+    const failure: CallFailure = { kind: kind && failureKinds.includes(kind) ? kind : (codeKinds[code.toLowerCase()] ?? "SERVER"), message, code };
+    if (meta) failure.meta = meta;
+    return failure;
   }
   // Neither an HTTP status nor a status code: the call never completed an
   // exchange either side could report on. Changing the request won't help.
@@ -78,6 +92,14 @@ function stringField(value: unknown, key: string): string | undefined {
   return typeof field === "string" && field !== "" ? field : undefined;
 }
 
+function stringMap(value: unknown, key: string): { [key: string]: string } | undefined {
+  if (!value || typeof value !== "object") return undefined;
+  const field = (value as Record<string, unknown>)[key];
+  if (!field || typeof field !== "object" || Array.isArray(field)) return undefined;
+  const entries = Object.entries(field).filter((entry): entry is [string, string] => typeof entry[1] === "string");
+  return entries.length > 0 ? Object.fromEntries(entries) : undefined;
+}
+
 function numberField(value: unknown, key: string): number | undefined {
   if (!value || typeof value !== "object") return undefined;
   const field = (value as Record<string, unknown>)[key];
@@ -2,7 +2,8 @@ import { GrpcWebFetchTransport } from "@protobuf-ts/grpcweb-transport";
 import type { IMessageType } from "@protobuf-ts/runtime";
 import type { MethodInfo, RpcMetadata, RpcOptions, ServerStreamingCall, UnaryCall } from "@protobuf-ts/runtime-rpc";
 import { TwirpFetchTransport } from "@protobuf-ts/twirp-transport";
-import { appHeaders, transportHeaders } from "./appTypes";
+import { ANSWER_HEADER, ENDPOINT_HEADER, appHeaders, transportHeaders, twirpSendsJson } from "./appTypes";
+import { QUESTION_TRAILER, answerQuestion, parseQuestion } from "./elicitation";
 import { Call, Kaja, MethodCall, MethodCallHeaders } from "./kaja";
 import {
   UPSTREAM_ERROR_TRAILER,
@@ -10,6 +11,7 @@ import {
   UPSTREAM_RESPONSE_HEADERS_TRAILER,
   parseUpstreamError,
   parseUpstreamHeaders,
+  queuedMs,
 } from "./upstreamHeaders";
 import { Client, AppRef, Methods, Service, serviceId, Transport } from "./apps";
 import { getBaseUrlForTarget } from "./server/connection";
@@ -40,7 +42,9 @@ function collectResponseHeaders(methodCall: MethodCall, headers?: RpcMetadata, t
 // where headers belong whether or not the call succeeded — a 401 is exactly when they
 // matter. The kaja trailers become the upstream hop; what a server sent of its own
 // becomes the response headers. Web errors carry it on the RpcError; Wails mirrors it.
-function applyErrorMetadata(methodCall: MethodCall, error: unknown): void {
+// A Twirp error's meta is the service's word on the failure rather than headers, and
+// stays with the error.
+function applyErrorMetadata(methodCall: MethodCall, error: unknown, isTwirp: boolean): void {
   const metaRecord = errorMeta(error);
   if (!metaRecord) return;
 
@@ -57,7 +61,9 @@ function applyErrorMetadata(methodCall: MethodCall, error: unknown): void {
         // The failure itself, already shown as the error.
         break;
       default:
-        responseHeaders[key] = String(value);
+        if (!isTwirp) {
+          responseHeaders[key] = String(value);
+        }
     }
   }
   if (Object.keys(responseHeaders).length > 0) {
@@ -81,6 +87,7 @@ export function createClient(service: Service, stub: Stub, appRef: AppRef): Clie
     if (isTwirp) {
       transport = new TwirpFetchTransport({
         baseUrl: getBaseUrlForTarget(),
+        sendJson: twirpSendsJson(appRef.configuration),
       });
     } else {
       transport = new GrpcWebFetchTransport({
@@ -92,24 +99,38 @@ export function createClient(service: Service, stub: Stub, appRef: AppRef): Clie
   const stubModule = stub[service.clientStubModuleId];
   const ClientClass = stubModule[service.name + "Client"];
   const clientStub = new ClientClass(transport);
+  // Outside Wails the call goes through the server's /target route, which reads the target
+  // and the headers to forward from the call's metadata.
+  const targetMeta = (options: RpcOptions): RpcOptions => {
+    if (!options.meta) {
+      options.meta = {};
+    }
+    if (!isWailsEnvironment()) {
+      options.meta["X-Target"] = appRef.target;
+      // A pinned endpoint and an answer ride with the configured headers, which is where
+      // the server takes the reserved ones from.
+      const endpoint = options.meta[ENDPOINT_HEADER] as string | undefined;
+      const answer = options.meta[ANSWER_HEADER] as string | undefined;
+      delete options.meta[ENDPOINT_HEADER];
+      delete options.meta[ANSWER_HEADER];
+      // Configured headers travel with an X-Header- prefix for the backend to forward.
+      // Their ${NAME} references travel unexpanded: the server resolves them, because a
+      // variable's value may be one it holds and the browser is not allowed to know.
+      const headers = transportHeaders(appRef.configuration, endpoint, answer);
+      for (const [key, value] of Object.entries(headers)) {
+        options.meta["X-Header-" + key] = value;
+      }
+    }
+    return options;
+  };
   const options: RpcOptions = {
     interceptors: [
       {
         interceptUnary(next, method, input, options: RpcOptions): UnaryCall {
-          if (!options.meta) {
-            options.meta = {};
-          }
-          if (!isWailsEnvironment()) {
-            options.meta["X-Target"] = appRef.target;
-            // Configured headers travel with an X-Header- prefix for the backend to forward.
-            // Their ${NAME} references travel unexpanded: the server resolves them, because a
-            // variable's value may be one it holds and the browser is not allowed to know.
-            const headers = transportHeaders(appRef.configuration);
-            for (const [key, value] of Object.entries(headers)) {
-              options.meta["X-Header-" + key] = value;
-            }
-          }
-          return next(method, input, options);
+          return next(method, input, targetMeta(options));
+        },
+        interceptServerStreaming(next, method, input, options: RpcOptions): ServerStreamingCall {
+          return next(method, input, targetMeta(options));
         },
       },
     ],
@@ -129,7 +150,7 @@ export function createClient(service: Service, stub: Stub, appRef: AppRef): Clie
   const bind = (kaja: Kaja): Methods => {
     const methods: Methods = {};
     for (const { method, isServerStreaming, inputType } of prepared) {
-      const send = async (input: any) => {
+      const send = async (input: any, endpoint?: string) => {
         // Shown as configured, with their ${NAME} references intact — the Headers view reads
         // better that way, and the values behind them stay outside the browser.
         const requestHeaders: { [key: string]: string } = appHeaders(appRef.configuration);
@@ -159,7 +180,14 @@ export function createClient(service: Service, stub: Stub, appRef: AppRef): Clie
           // wire format omits anyway. The literal itself stays on the method call, so the
           // console and the value completions keep showing what was actually written.
           const message = inputType ? inputType.create(input) : input;
-          const call = clientStub[lcfirst(method.name)](message, abort ? { ...options, abort } : options);
+          const start = (answer?: string) => {
+            const meta: RpcMetadata = {};
+            if (endpoint) meta[ENDPOINT_HEADER] = endpoint;
+            if (answer) meta[ANSWER_HEADER] = answer;
+            const pinned = Object.keys(meta).length > 0 ? { ...options, meta } : options;
+            return clientStub[lcfirst(method.name)](message, abort ? { ...pinned, abort } : pinned);
+          };
+          let call = start();
 
           if (isServerStreaming) {
             const streamCall = call as ServerStreamingCall<any, any>;
@@ -183,7 +211,16 @@ export function createClient(service: Service, stub: Stub, appRef: AppRef): Clie
             const [headers, trailers] = await Promise.all([streamCall.headers, streamCall.trailers]);
             collectResponseHeaders(methodCall, headers, trailers);
           } else {
-            const [response, headers, trailers] = await Promise.all([call.response, call.headers, call.trailers]);
+            let [response, headers, trailers] = await Promise.all([call.response, call.headers, call.trailers]);
+            // The app can't finish without something only the user knows. The call is held
+            // open on the server while they are asked, and made again with their answer.
+            let question = parseQuestion(trailers?.[QUESTION_TRAILER]);
+            while (question) {
+              const answer = await answerQuestion(kaja, question);
+              call = start(JSON.stringify(answer));
+              [response, headers, trailers] = await Promise.all([call.response, call.headers, call.trailers]);
+              question = parseQuestion(trailers?.[QUESTION_TRAILER]);
+            }
             methodCall.durationMs = elapsed();
             methodCall.output = response;
             methodCall.inputTypeName = call.method?.I?.typeName;
@@ -195,9 +232,10 @@ export function createClient(service: Service, stub: Stub, appRef: AppRef): Clie
           }
         } catch (error: any) {
           methodCall.durationMs = elapsed();
-          methodCall.error = callError(error);
-          applyErrorMetadata(methodCall, error);
+          methodCall.error = callError(error, isTwirp);
+          applyErrorMetadata(methodCall, error, isTwirp);
         }
+        methodCall.queuedMs = queuedMs(methodCall.upstreamRequestHeaders);
 
         kaja._internal.methodCallUpdate(methodCall);
 
@@ -208,7 +246,10 @@ export function createClient(service: Service, stub: Stub, appRef: AppRef): Clie
       // at the end of the tick if nothing has claimed it. Everything above happens when the
       // call starts, its log row included, so a call that was never approved was never
       // anywhere.
-      methods[method.name] = (input: any) => new Call(`${service.name}.${method.name}`, input, () => send(input));
+      methods[method.name] = (input: any) => {
+        const call: Call<any> = new Call(`${service.name}.${method.name}`, input, () => send(input, call.endpoint));
+        return call;
+      };
     }
     return methods;
   };
@@ -234,13 +275,27 @@ function lcfirst(str: string): string {
 // gRPC error only because that is how the app is invoked, and the frame it travelled
 // in — the status code, the trailers carrying the real failure, the exchanged headers
 // — is not part of what went wrong.
-function callError(error: unknown): any {
+//
+// A Twirp error's meta is part of what went wrong: the pairs the service attached to
+// say why, such as which argument it refused. It is kept, without kaja's own trailers.
+function callError(error: unknown, isTwirp: boolean): any {
   const meta = errorMeta(error);
   if (meta) {
     const upstream = parseUpstreamError(meta[UPSTREAM_ERROR_TRAILER]);
     if (upstream) return upstream;
   }
-  return serializeError(error);
+  const serialized = serializeError(error);
+  if (isTwirp && meta && error instanceof Error) {
+    const twirpMeta = Object.fromEntries(
+      Object.entries(meta)
+        .filter(([key]) => key !== UPSTREAM_REQUEST_HEADERS_TRAILER && key !== UPSTREAM_RESPONSE_HEADERS_TRAILER)
+        .map(([key, value]) => [key, String(value)]),
+    );
+    if (Object.keys(twirpMeta).length > 0) {
+      serialized.meta = twirpMeta;
+    }
+  }
+  return serialized;
 }
 
 function errorMeta(error: unknown): Record<string, unknown> | undefined {
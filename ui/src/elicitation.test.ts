@@ -0,0 +1,61 @@
+import { describe, expect, test } from "bun:test";
+import { fieldValue, parseQuestion, questionFields } from "./elicitation";
+
+describe("parseQuestion", () => {
+  test("reads the percent-encoded trailer", () => {
+    const question = parseQuestion(encodeURIComponent(JSON.stringify({ id: "q1", message: "Which city — exactly?", schema: { type: "object" } })));
+    expect(question).toEqual({ id: "q1", message: "Which city — exactly?", schema: { type: "object" } });
+  });
+
+  test("is undefined for a call that asked nothing", () => {
+    expect(parseQuestion(undefined)).toBeUndefined();
+    expect(parseQuestion("not json")).toBeUndefined();
+    expect(parseQuestion(JSON.stringify({ message: "no id" }))).toBeUndefined();
+  });
+});
+
+describe("questionFields", () => {
+  test("puts each property to the user as the ask its type wants", () => {
+    const fields = questionFields({
+      type: "object",
+      properties: {
+        name: { type: "string", title: "Your name" },
+        age: { type: "integer", description: "Age in years" },
+        height: { type: "number" },
+        subscribe: { type: "boolean" },
+        plan: { type: "string", enum: ["free", "pro"], enumNames: ["Free", "Pro"] },
+        size: { type: "string", oneOf: [{ const: "s", title: "Small" }, { const: "l", title: "Large" }] },
+      },
+      required: ["name", "age"],
+    });
+    expect(fields.map((field) => [field.name, field.kind, field.prompt])).toEqual([
+      ["name", "str", "Your name"],
+      ["age", "int", "Age in years"],
+      ["height", "number", "height (optional)"],
+      ["subscribe", "boolean", "subscribe (optional)"],
+      ["plan", "select", "plan (optional)"],
+      ["size", "select", "size (optional)"],
+    ]);
+    expect(fields[3].values).toEqual([true, false]);
+    expect(fields[4].choices).toEqual(["Free", "Pro"]);
+    expect(fields[4].values).toEqual(["free", "pro"]);
+    expect(fields[5].choices).toEqual(["Small", "Large"]);
+    expect(fields[5].values).toEqual(["s", "l"]);
+  });
+
+  test("has nothing to ask for a confirmation", () => {
+    expect(questionFields(undefined)).toEqual([]);
+    expect(questionFields({ type: "object" })).toEqual([]);
+  });
+});
+
+describe("fieldValue", () => {
+  test("reads a number as one", () => {
+    expect(fieldValue({ name: "height", prompt: "", kind: "number", required: true }, "1.8")).toBe(1.8);
+  });
+
+  test("leaves an empty optional field out", () => {
+    expect(fieldValue({ name: "nick", prompt: "", kind: "str", required: false }, " ")).toBeUndefined();
+    expect(fieldValue({ name: "name", prompt: "", kind: "str", required: true }, "")).toBe("");
+  });
+});
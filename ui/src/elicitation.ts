@@ -0,0 +1,140 @@
+import type { Kaja } from "./kaja";
+import { decodeTrailer } from "./upstreamHeaders";
+
+// The trailer an in-process app puts a question in when it can't finish a call without
+// an answer from whoever made it: an MCP server's elicitation. The response message is
+// empty; the call is made again with the answer under ANSWER_HEADER.
+export const QUESTION_TRAILER = "kaja-question";
+
+// Question is the server's request: a message, and the JSON Schema of the answer —
+// an object whose properties are each one thing to ask.
+export interface Question {
+  id: string;
+  message: string;
+  schema?: unknown;
+}
+
+// Answer is what goes back: "accept" with the content, "decline" or "cancel".
+export interface Answer {
+  id: string;
+  action: "accept" | "decline" | "cancel";
+  content?: { [key: string]: unknown };
+}
+
+// QuestionField is one property of the answer, as the ask it is put to the user with.
+// A select carries the labels shown and the values they stand for.
+export interface QuestionField {
+  name: string;
+  prompt: string;
+  kind: "str" | "int" | "number" | "boolean" | "select";
+  required: boolean;
+  choices?: string[];
+  values?: unknown[];
+}
+
+// parseQuestion reads the question trailer, or undefined for a call that asked nothing.
+export function parseQuestion(value: unknown): Question | undefined {
+  const decoded = decodeTrailer(value);
+  if (decoded === undefined) return undefined;
+  try {
+    const parsed = JSON.parse(decoded);
+    if (parsed && typeof parsed === "object" && typeof parsed.id === "string" && parsed.id !== "") {
+      return { id: parsed.id, message: String(parsed.message ?? ""), schema: parsed.schema };
+    }
+  } catch {
+    // Not a question; the call's response stands as it is.
+  }
+  return undefined;
+}
+
+// questionFields turns the requested schema into the asks that fill it in, in the order
+// the properties are declared. Elicitation schemas are flat: strings, numbers, booleans
+// and enums, nothing nested.
+export function questionFields(schema: unknown): QuestionField[] {
+  if (!schema || typeof schema !== "object") return [];
+  const { properties, required } = schema as { properties?: unknown; required?: unknown };
+  if (!properties || typeof properties !== "object") return [];
+  const requiredNames = new Set(Array.isArray(required) ? required.map(String) : []);
+
+  return Object.entries(properties as { [name: string]: any }).map(([name, property]): QuestionField => {
+    const isRequired = requiredNames.has(name);
+    const label = String(property?.title || property?.description || name);
+    const prompt = isRequired ? label : `${label} (optional)`;
+    const field: QuestionField = { name, prompt, kind: "str", required: isRequired };
+
+    const options = enumOptions(property);
+    if (options) {
+      return { ...field, kind: "select", ...options };
+    }
+    switch (property?.type) {
+      case "integer":
+        return { ...field, kind: "int" };
+      case "number":
+        return { ...field, kind: "number" };
+      case "boolean":
+        return { ...field, kind: "boolean", choices: ["Yes", "No"], values: [true, false] };
+    }
+    return field;
+  });
+}
+
+// enumOptions reads an enum property in either of the forms the schema allows: `enum`
+// with `enumNames` beside it, or `oneOf` entries each with a `const` and a `title`.
+function enumOptions(property: any): { choices: string[]; values: unknown[] } | undefined {
+  if (Array.isArray(property?.enum) && property.enum.length > 0) {
+    const names = Array.isArray(property.enumNames) ? property.enumNames : [];
+    return { choices: property.enum.map((value: unknown, i: number) => String(names[i] ?? value)), values: property.enum };
+  }
+  if (Array.isArray(property?.oneOf) && property.oneOf.length > 0 && property.oneOf.every((option: any) => option && "const" in option)) {
+    return {
+      choices: property.oneOf.map((option: any) => String(option.title ?? option.const)),
+      values: property.oneOf.map((option: any) => option.const),
+    };
+  }
+  return undefined;
+}
+
+// fieldValue reads a typed answer as the value the property wants. An optional field
+// left empty is left out.
+export function fieldValue(field: QuestionField, text: string): unknown {
+  if (text.trim() === "" && !field.required) return undefined;
+  if (field.kind === "number") {
+    const number = Number(text);
+    return Number.isFinite(number) ? number : text;
+  }
+  return text;
+}
+
+// answerQuestion puts the server's question to the user, one ask per field, and
+// gathers their answers into the content the server asked for. A question with nothing
+// to fill in is a confirmation: accept or decline.
+export async function answerQuestion(kaja: Kaja, question: Question): Promise<Answer> {
+  const fields = questionFields(question.schema);
+  if (fields.length === 0) {
+    const choice = await kaja.askSelect(question.message || "The server asks to continue.", ["Accept", "Decline"]);
+    return { id: question.id, action: choice === "Accept" ? "accept" : "decline" };
+  }
+
+  if (question.message) {
+    kaja.text(question.message);
+  }
+  const content: { [key: string]: unknown } = {};
+  for (const field of fields) {
+    let value: unknown;
+    switch (field.kind) {
+      case "int":
+        value = await kaja.askInt(field.prompt);
+        break;
+      case "select":
+      case "boolean":
+        value = await kaja.askSelect(field.prompt, field.choices!.map((label, i) => ({ label, value: field.values![i] })));
+        break;
+      default:
+        value = fieldValue(field, await kaja.askStr(field.prompt));
+    }
+    if (value !== undefined) {
+      content[field.name] = value;
+    }
+  }
+  return { id: question.id, action: "accept", content };
+}
@@ -49,6 +49,7 @@ export class Call<T> implements PromiseLike<T> {
   #send: () => Promise<T>;
   #sent?: Promise<T>;
   #claimed = false;
+  #endpoint?: string;
 
   constructor(label: string, input: unknown, send: () => Promise<T>) {
     this.label = label;
@@ -64,6 +65,24 @@ export class Call<T> implements PromiseLike<T> {
     return this.#sent !== undefined;
   }
 
+  /** The endpoint `on` pinned the call to, if any. */
+  get endpoint(): string | undefined {
+    return this.#endpoint;
+  }
+
+  /**
+   * Send the call to one of its app's endpoints rather than the next one in turn.
+   * Like approving, it has to happen in the tick the call was written in, which
+   * chaining it onto the call does.
+   */
+  on(endpoint: string): this {
+    if (this.started) {
+      throw new Error(`${this.label} has already been sent, so it can't be pinned to ${endpoint}`);
+    }
+    this.#endpoint = endpoint;
+    return this;
+  }
+
   /**
    * Take the call out of the tick's hands. `kaja.approve` claims before its own first
    * await, which is the whole of how it can hold a call back.
@@ -858,6 +877,9 @@ export interface MethodCall {
   // Wall-clock time the call took, set once it succeeds, fails, or its stream
   // completes. Undefined while still in flight.
   durationMs?: number;
+  // How much of durationMs the call spent waiting for its turn under its app's
+  // limits, before it left kaja. Undefined when it didn't wait.
+  queuedMs?: number;
 }
 
 export interface MethodCallUpdate {
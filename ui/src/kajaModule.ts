@@ -44,7 +44,17 @@ export function kajaModuleDeclaration(variableNames: string[]): string {
  * \`Promise.all([a(), b()])\` still runs both at once. The gap of one tick is
  * what kaja.approve holds a call in.
  */
-export interface Call<T> extends PromiseLike<T> {}
+export interface Call<T> extends PromiseLike<T> {
+  /**
+   * Send the call to one of its app's endpoints — the url, or one listed under
+   * endpoints — rather than the next one in turn. Chain it onto the call:
+   *
+   *   await Shows.Ping({}).on("dns:shows-2.staging:9000");
+   *
+   * The Headers view reports which endpoint and address served every call.
+   */
+  on(endpoint: string): Call<T>;
+}
 
 /** A plain JSON value, as accepted by kaja.value and friends. */
 export type JsonValue = string | number | boolean | null | JsonValue[] | { [key: string]: JsonValue };
@@ -1,5 +1,19 @@
 import { describe, expect, test } from "bun:test";
-import { AUTH_APIKEY, AUTH_BEARER, AUTH_NONE, authNote, count, deriveAppName, eraLabel, isReadableEndpoint, uniqueAppName } from "./mcpServer";
+import {
+  AUTH_APIKEY,
+  AUTH_BEARER,
+  AUTH_NONE,
+  AUTH_OAUTH,
+  authNote,
+  count,
+  deriveAppName,
+  endpointParameters,
+  endpointText,
+  eraLabel,
+  isCommandLine,
+  isReadableEndpoint,
+  uniqueAppName,
+} from "./mcpServer";
 
 describe("isReadableEndpoint", () => {
   test("takes an absolute HTTP endpoint", () => {
@@ -21,6 +35,35 @@ describe("isReadableEndpoint", () => {
   });
 });
 
+describe("isCommandLine", () => {
+  test("takes a command that launches a local server", () => {
+    expect(isCommandLine("npx -y @modelcontextprotocol/server-everything")).toBe(true);
+    expect(isCommandLine("./bin/server --stdio")).toBe(true);
+    expect(isCommandLine("C:\\tools\\server.exe")).toBe(true);
+    expect(isReadableEndpoint("uvx mcp-server-git")).toBe(true);
+  });
+
+  test("leaves anything that starts like a URL to be one", () => {
+    expect(isCommandLine("https://mcp.example.com/mcp")).toBe(false);
+    expect(isCommandLine("htt")).toBe(false);
+    expect(isCommandLine("mcp.example.com/mcp")).toBe(false);
+    expect(isCommandLine("localhost:3000/mcp")).toBe(false);
+    expect(isCommandLine("${MCP_URL}")).toBe(false);
+  });
+});
+
+describe("endpointParameters", () => {
+  test("splits a command line into the command and its arguments", () => {
+    expect(endpointParameters("npx  -y @acme/server")).toEqual({ url: "", command: "npx", args: "-y, @acme/server" });
+    expect(endpointParameters("https://example.com/mcp")).toEqual({ url: "https://example.com/mcp", command: "", args: "" });
+  });
+
+  test("reads back as it was typed", () => {
+    expect(endpointText(endpointParameters("npx -y @acme/server"))).toBe("npx -y @acme/server");
+    expect(endpointText({ url: "https://example.com/mcp" })).toBe("https://example.com/mcp");
+  });
+});
+
 describe("deriveAppName", () => {
   test("keeps a server name that is already a handle", () => {
     expect(deriveAppName("https://mcp.deepwiki.com/mcp", "deepwiki")).toBe("deepwiki");
@@ -55,6 +98,12 @@ describe("deriveAppName", () => {
     expect(deriveAppName("https://example.com/mcp", "a".repeat(5000))).toBe("a".repeat(200));
   });
 
+  test("falls back to what the command launches", () => {
+    expect(deriveAppName("npx -y @acme/weather-mcp@1.2.0", "")).toBe("weather");
+    expect(deriveAppName("uvx mcp-server-git --repository .", "")).toBe("git");
+    expect(deriveAppName("/usr/local/bin/notes-server --stdio", "")).toBe("notes");
+  });
+
   test("says nothing when the host names the machine", () => {
     expect(deriveAppName("http://localhost:3000/mcp", "")).toBe("");
     expect(deriveAppName("http://127.0.0.1:3000/mcp", "")).toBe("");
@@ -75,6 +124,7 @@ describe("authNote", () => {
     expect(authNote(AUTH_APIKEY, "")).toEqual({ text: "X-API-Key: ‹key›", mono: true });
     expect(authNote(AUTH_APIKEY, "X-Tenant-Key")).toEqual({ text: "X-Tenant-Key: ‹key›", mono: true });
     expect(authNote(AUTH_NONE, "")).toEqual({ text: "", mono: false });
+    expect(authNote(AUTH_OAUTH, "").mono).toBe(false);
   });
 });
 
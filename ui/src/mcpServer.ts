@@ -6,6 +6,9 @@ import { variableReferences } from "./variableExpansion";
 export const AUTH_NONE = "none";
 export const AUTH_BEARER = "bearer";
 export const AUTH_APIKEY = "apikey";
+// Kaja signs in to the server itself, as MCP's authorization spec describes, and holds
+// the tokens it is given server-side.
+export const AUTH_OAUTH = "oauth";
 
 // The header an API key travels under when the app doesn't name one.
 export const DEFAULT_API_KEY_NAME = "X-API-Key";
@@ -22,6 +25,7 @@ export interface AuthSchemeDefinition {
 export const authSchemes: AuthSchemeDefinition[] = [
   { key: AUTH_BEARER, label: "Bearer token", summary: "A token in the Authorization header" },
   { key: AUTH_APIKEY, label: "API key", summary: "A key under a header the server picks" },
+  { key: AUTH_OAUTH, label: "Sign in with OAuth", summary: "Kaja signs in where the server says, and keeps the token" },
 ];
 
 export function apiKeyName(value: string): string {
@@ -33,6 +37,8 @@ export function authNote(auth: string, name: string): { text: string; mono: bool
   switch (auth) {
     case AUTH_APIKEY:
       return { text: `${apiKeyName(name)}: ‹key›`, mono: true };
+    case AUTH_OAUTH:
+      return { text: "The server sends you to sign in the first time. Kaja refreshes the token after that.", mono: false };
     case AUTH_NONE:
       return { text: "", mono: false };
     default:
@@ -46,12 +52,45 @@ export function holdsVariableReference(value: string): boolean {
   return variableReferences(value).length > 0;
 }
 
-// It only asks for an absolute HTTP URL: whether anything is serving MCP there is the
-// server's to find out.
+// A local server is named by the command that launches it rather than a URL. Anything
+// that starts like a URL - a scheme, a host and a path, or the start of "http://" - is
+// taken for one; a ${NAME} in front is a URL held in a variable, as it always has been.
+export function isCommandLine(value: string): boolean {
+  const trimmed = value.trim();
+  if (!trimmed || trimmed.startsWith("${")) return false;
+  const lower = trimmed.toLowerCase();
+  if ("https://".startsWith(lower) || "http://".startsWith(lower)) return false;
+  // One letter before the colon is a Windows drive, not a scheme.
+  if (/^[a-z][a-z0-9+.-]+:/i.test(trimmed)) return false;
+  return !/^[a-z0-9-]+(\.[a-z0-9-]+)+(:\d+)?\//i.test(trimmed);
+}
+
+// endpointParameters reads the one endpoint field into the app's parameters: a URL, or
+// a command and the arguments it is launched with. The arguments are passed as they
+// are, with no shell between, so a space is all that separates them.
+export function endpointParameters(value: string): { url: string; command: string; args: string } {
+  if (!isCommandLine(value)) return { url: value, command: "", args: "" };
+  const [command, ...args] = value.trim().split(/\s+/);
+  return { url: "", command, args: args.join(", ") };
+}
+
+// endpointText is the endpoint field as the parameters have it.
+export function endpointText(parameters: Record<string, string>): string {
+  const command = (parameters.command ?? "").trim();
+  if (!command) return parameters.url ?? "";
+  const args = (parameters.args ?? "")
+    .split(",")
+    .map((arg) => arg.trim())
+    .filter(Boolean);
+  return [command, ...args].join(" ");
+}
+
+// It only asks for an absolute HTTP URL, or a command to launch: whether anything is
+// serving MCP there is the server's to find out.
 export function isReadableEndpoint(value: string): boolean {
   const trimmed = value.trim();
   if (!trimmed) return false;
-  if (holdsVariableReference(trimmed)) return true;
+  if (holdsVariableReference(trimmed) || isCommandLine(trimmed)) return true;
   try {
     const url = new URL(trimmed);
     return (url.protocol === "https:" || url.protocol === "http:") && url.hostname !== "";
@@ -70,9 +109,24 @@ const HOST_NOISE = new Set(["mcp", "api", "apis", "www", "app", "server", "remot
 // in an import path. A server name is usually already a handle, so unlike an OpenAPI
 // title it is mostly kept: what goes is the part saying it is an MCP server ("GitHub
 // MCP Server" → "GitHub") and a registry namespace ("io.github.owner/sentry" →
-// "Sentry"). A server that names itself nothing useful is named by its host.
+// "Sentry"). A server that names itself nothing useful is named by its host, or by
+// the command that launches it.
 export function deriveAppName(url: string, serverName: string): string {
-  return nameFromServer(serverName) || nameFromEndpoint(url);
+  return nameFromServer(serverName) || (isCommandLine(url) ? nameFromCommand(url) : nameFromEndpoint(url));
+}
+
+// Programs that run a server rather than being one: the package after them is the name.
+const LAUNCHERS = new Set(["npx", "pnpx", "bunx", "uvx", "node", "bun", "deno", "python", "python3", "uv"]);
+
+// The half of deriveAppName a command line can answer: "npx -y @acme/weather-mcp@1.2"
+// is weather, "uvx mcp-server-git" is git.
+function nameFromCommand(commandLine: string): string {
+  const [command, ...args] = commandLine.trim().split(/\s+/);
+  const program = command.split(/[\\/]/).pop() ?? "";
+  const named = LAUNCHERS.has(program.replace(/\.exe$/i, "").toLowerCase()) ? args.find((arg) => !arg.startsWith("-") && arg !== "run") : command;
+  if (!named) return "";
+  const base = (named.split(/[\\/]/).pop() ?? "").replace(/(?<=.)@[^@]*$/, "").replace(/\.(m?js|ts|py|exe)$/i, "");
+  return nameFromServer(base);
 }
 
 // A name is a handle, so only the front of one can be. What a server reports for
@@ -572,6 +572,21 @@ export interface McpProblem {
      * @generated from protobuf field: string detail = 3
      */
     detail: string;
+    /**
+     * Where the user signs in, when the server wants an OAuth token kaja can get
+     * for them. Once they have, reading the server again succeeds.
+     *
+     * @generated from protobuf field: string authorization_url = 4
+     */
+    authorizationUrl: string;
+    /**
+     * The authorization server a server that wants a token names, when the app
+     * doesn't sign in yet. Nothing has been started there; the app signing in is
+     * what gets an authorization_url.
+     *
+     * @generated from protobuf field: string authorization_server = 5
+     */
+    authorizationServer: string;
 }
 /**
  * @generated from protobuf message CompileResponse
@@ -909,6 +924,42 @@ export interface ConfigurationApp {
          * @generated from protobuf field: McpApp mcp = 8
          */
         mcp: McpApp;
+    } | {
+        oneofKind: "anthropic";
+        /**
+         * @generated from protobuf field: AnthropicApp anthropic = 9
+         */
+        anthropic: AnthropicApp;
+    } | {
+        oneofKind: "sqlite";
+        /**
+         * @generated from protobuf field: SqliteApp sqlite = 10
+         */
+        sqlite: SqliteApp;
+    } | {
+        oneofKind: "graphql";
+        /**
+         * @generated from protobuf field: GraphqlApp graphql = 11
+         */
+        graphql: GraphqlApp;
+    } | {
+        oneofKind: "jsonrpc";
+        /**
+         * @generated from protobuf field: JsonrpcApp jsonrpc = 12
+         */
+        jsonrpc: JsonrpcApp;
+    } | {
+        oneofKind: "websocket";
+        /**
+         * @generated from protobuf field: WebsocketApp websocket = 13
+         */
+        websocket: WebsocketApp;
+    } | {
+        oneofKind: "http";
+        /**
+         * @generated from protobuf field: HttpApp http = 14
+         */
+        http: HttpApp;
     } | {
         oneofKind: undefined;
     };
@@ -1004,6 +1055,94 @@ export interface GrpcApp {
      * @generated from protobuf field: string api_key_name = 14
      */
     apiKeyName: string;
+    /**
+     * How request messages are compressed: "gzip", "zstd", or "none". Empty means
+     * none. The server answers in whichever of those it picks, and kaja reads any
+     * of them.
+     *
+     * @generated from protobuf field: string compression = 15
+     */
+    compression: string;
+    /**
+     * The largest response message kaja accepts, in bytes. Zero means gRPC's own
+     * 4 MB, which a long list outgrows quickly.
+     *
+     * @generated from protobuf field: int64 max_receive_bytes = 16
+     */
+    maxReceiveBytes: string;
+    /**
+     * The largest request message kaja sends, in bytes. Zero means no limit of
+     * kaja's own.
+     *
+     * @generated from protobuf field: int64 max_send_bytes = 17
+     */
+    maxSendBytes: string;
+    /**
+     * The protocol calls are spoken in: "grpc", "grpc-web" or "connect". Empty
+     * means grpc. gRPC-Web and Connect reach a service over plain HTTP/1.1, behind
+     * a proxy that doesn't pass HTTP/2 through; neither serves reflection, so
+     * those apps are described by proto_dir.
+     *
+     * @generated from protobuf field: string protocol = 18
+     */
+    protocol: string;
+    /**
+     * More replicas of the service at url, each a URL of the same form. Calls are
+     * spread across url and these in turn, a script can pin one with
+     * Call.on(endpoint), and the Headers view reports which served each call.
+     *
+     * @generated from protobuf field: repeated string endpoints = 19
+     */
+    endpoints: string[];
+    /**
+     * How calls are spread across the addresses one endpoint resolves to:
+     * "pick_first" or "round_robin". Empty means pick_first. round_robin is what
+     * a "dns:///" name with several replicas behind it wants.
+     *
+     * @generated from protobuf field: string load_balancing = 20
+     */
+    loadBalancing: string;
+    /**
+     * Retrying a call that fails in a way that passes. Off unless
+     * retry_max_attempts is above 1; it counts the first attempt. The wait before
+     * each retry starts at retry_backoff_ms (200 when zero) and doubles up to
+     * retry_max_backoff_ms (5000 when zero); a Retry-After header or a gRPC
+     * RetryInfo detail replaces it, and a call the server asks to wait longer than
+     * that fails instead. retry_codes are the failures retried, as gRPC code
+     * names and HTTP statuses; empty means UNAVAILABLE, 429, 502, 503 and 504.
+     *
+     * @generated from protobuf field: int64 retry_max_attempts = 21
+     */
+    retryMaxAttempts: string;
+    /**
+     * @generated from protobuf field: int64 retry_backoff_ms = 22
+     */
+    retryBackoffMs: string;
+    /**
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 23
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 24
+     */
+    retryCodes: string[];
+    /**
+     * How fast and how widely the app's calls go out: at most rate_limit calls a
+     * second, in bursts of up to rate_limit_burst (one second's worth when zero),
+     * and at most max_concurrency at once. Zero means no limit. A call over either
+     * waits its turn rather than failing, and the wait is reported with it.
+     *
+     * @generated from protobuf field: int64 rate_limit = 25
+     */
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 26
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 27
+     */
+    maxConcurrency: string;
 }
 /**
  * TwirpApp calls a Twirp service described by a workspace-relative proto_dir.
@@ -1025,6 +1164,92 @@ export interface TwirpApp {
     headers: {
         [key: string]: string;
     };
+    /**
+     * Retrying a call the proxy makes that comes back with a Twirp error whose
+     * HTTP status is in retry_codes, empty meaning 429, 502, 503 and 504 - so
+     * "unavailable" is retried and "internal" is not. The counts and waits are a
+     * GrpcApp's.
+     *
+     * @generated from protobuf field: int64 retry_max_attempts = 4
+     */
+    retryMaxAttempts: string;
+    /**
+     * @generated from protobuf field: int64 retry_backoff_ms = 5
+     */
+    retryBackoffMs: string;
+    /**
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 6
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 7
+     */
+    retryCodes: string[];
+    /**
+     * How fast and how widely calls to the service go out, shared by the proxy
+     * across the app's calls. Zero means no limit.
+     *
+     * @generated from protobuf field: int64 rate_limit = 8
+     */
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 9
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 10
+     */
+    maxConcurrency: string;
+    /**
+     * How the proxy reaches the service, and the credential it sends: each field
+     * means what it does for a GrpcApp, with the credential sent as an HTTP header
+     * rather than metadata. It never reaches the browser.
+     *
+     * @generated from protobuf field: string tls = 11
+     */
+    tls: string;
+    /**
+     * @generated from protobuf field: bool insecure_skip_verify = 12
+     */
+    insecureSkipVerify: boolean;
+    /**
+     * @generated from protobuf field: string ca_file = 13
+     */
+    caFile: string;
+    /**
+     * @generated from protobuf field: string client_cert_file = 14
+     */
+    clientCertFile: string;
+    /**
+     * @generated from protobuf field: string client_key_file = 15
+     */
+    clientKeyFile: string;
+    /**
+     * @generated from protobuf field: string auth = 16
+     */
+    auth: string;
+    /**
+     * @generated from protobuf field: string token = 17
+     */
+    token: string;
+    /**
+     * @generated from protobuf field: string username = 18
+     */
+    username: string;
+    /**
+     * @generated from protobuf field: string password = 19
+     */
+    password: string;
+    /**
+     * @generated from protobuf field: string api_key_name = 20
+     */
+    apiKeyName: string;
+    /**
+     * How calls are encoded on the wire: "protobuf" (the default) or "json".
+     *
+     * @generated from protobuf field: string encoding = 21
+     */
+    encoding: string;
 }
 /**
  * OpenApiApp calls a REST API from its OpenAPI 3.x document. The document is
@@ -1085,9 +1310,45 @@ export interface OpenApiApp {
      * @generated from protobuf field: string spec_header_value = 10
      */
     specHeaderValue: string;
+    /**
+     * Retrying a request to the API that fails to connect or comes back with a
+     * status in retry_codes, empty meaning 429, 502, 503 and 504. A Retry-After
+     * header sets the wait when the API sends one.
+     *
+     * @generated from protobuf field: int64 retry_max_attempts = 11
+     */
+    retryMaxAttempts: string;
+    /**
+     * @generated from protobuf field: int64 retry_backoff_ms = 12
+     */
+    retryBackoffMs: string;
+    /**
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 13
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 14
+     */
+    retryCodes: string[];
+    /**
+     * At most rate_limit requests to the API a second, bursting to
+     * rate_limit_burst, and max_concurrency in flight. Zero means no limit.
+     *
+     * @generated from protobuf field: int64 rate_limit = 15
+     */
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 16
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 17
+     */
+    maxConcurrency: string;
 }
 /**
- * OpenAiApp calls the OpenAI chat completions API.
+ * OpenAiApp calls the OpenAI API, or a gateway compatible with it. endpoint is
+ * the API's base URL.
  *
  * @generated from protobuf message OpenAiApp
  */
@@ -1106,10 +1367,109 @@ export interface OpenAiApp {
     headers: {
         [key: string]: string;
     };
+    /**
+     * Retrying a request the API rate limits (429) or is briefly down for (502,
+     * 503, 504), or the statuses retry_codes names instead. A streamed completion
+     * is retried only until the API starts answering it.
+     *
+     * @generated from protobuf field: int64 retry_max_attempts = 4
+     */
+    retryMaxAttempts: string;
+    /**
+     * @generated from protobuf field: int64 retry_backoff_ms = 5
+     */
+    retryBackoffMs: string;
+    /**
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 6
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 7
+     */
+    retryCodes: string[];
+    /**
+     * How many requests a second, and at once, go to the endpoint: a way to stay
+     * under an account's rate limits rather than retry past them.
+     *
+     * @generated from protobuf field: int64 rate_limit = 8
+     */
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 9
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 10
+     */
+    maxConcurrency: string;
+}
+/**
+ * AnthropicApp calls the Anthropic Messages API. endpoint is the API's base URL;
+ * empty means Anthropic's own. The token is held here and sent as x-api-key,
+ * never handed to the browser.
+ *
+ * @generated from protobuf message AnthropicApp
+ */
+export interface AnthropicApp {
+    /**
+     * @generated from protobuf field: string endpoint = 1
+     */
+    endpoint: string;
+    /**
+     * @generated from protobuf field: string token = 2
+     */
+    token: string;
+    /**
+     * @generated from protobuf field: map<string, string> headers = 3
+     */
+    headers: {
+        [key: string]: string;
+    };
+    /**
+     * The anthropic-version header. Empty means 2023-06-01.
+     *
+     * @generated from protobuf field: string version = 4
+     */
+    version: string;
+    /**
+     * Retrying a request the API rate limits (429) or is briefly down for (502,
+     * 503, 504). An overloaded API answers 529, which is retried only when
+     * retry_codes names it.
+     *
+     * @generated from protobuf field: int64 retry_max_attempts = 5
+     */
+    retryMaxAttempts: string;
+    /**
+     * @generated from protobuf field: int64 retry_backoff_ms = 6
+     */
+    retryBackoffMs: string;
+    /**
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 7
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 8
+     */
+    retryCodes: string[];
+    /**
+     * How many requests a second, and at once, go to the endpoint: a way to stay
+     * under an organization's rate limits rather than retry past them.
+     *
+     * @generated from protobuf field: int64 rate_limit = 9
+     */
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 10
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 11
+     */
+    maxConcurrency: string;
 }
 /**
- * FolderApp lists, creates, reads and appends to files in a folder on disk. It
- * is local, so it forwards no headers.
+ * FolderApp lists, searches, reads, writes, moves, deletes and watches files in a
+ * folder on disk. It is local, so it forwards no headers.
  *
  * @generated from protobuf message FolderApp
  */
@@ -1120,367 +1480,1387 @@ export interface FolderApp {
     path: string;
 }
 /**
- * McpApp explores another Model Context Protocol server over the Streamable HTTP
- * transport. Its proto surface is generated from what that server exposes: one
- * method per tool, one per prompt, and the list/read methods for its resources.
+ * SqliteApp queries and changes a SQLite database file on disk. It is local, so it
+ * forwards no headers.
  *
- * The credential is the app's, not the request's: kaja resolves it where it
- * holds it and applies it on the way out, so a "${secret}" token is never handed
- * to the browser.
+ * @generated from protobuf message SqliteApp
+ */
+export interface SqliteApp {
+    /**
+     * The database file, workspace-relative or absolute. It is created on the first
+     * write when it does not exist yet.
+     *
+     * @generated from protobuf field: string path = 1
+     */
+    path: string;
+    /**
+     * Open the database read-only: Query and the schema methods work, Exec and
+     * anything else that would write are refused.
+     *
+     * @generated from protobuf field: bool read_only = 2
+     */
+    readOnly: boolean;
+}
+/**
+ * GraphqlApp calls a GraphQL endpoint. Each query and mutation is a method whose
+ * request is the field's arguments and whose response is its value.
  *
- * @generated from protobuf message McpApp
+ * @generated from protobuf message GraphqlApp
  */
-export interface McpApp {
+export interface GraphqlApp {
     /**
-     * The server's MCP endpoint, e.g. "https://example.com/mcp".
+     * The endpoint queries are POSTed to, e.g. "https://example.com/graphql".
      *
      * @generated from protobuf field: string url = 1
      */
     url: string;
     /**
-     * @generated from protobuf field: map<string, string> headers = 2
+     * The schema as SDL or as an introspection result, workspace-relative or
+     * absolute. Empty asks the endpoint with an introspection query.
+     *
+     * @generated from protobuf field: string schema_file = 2
+     */
+    schemaFile: string;
+    /**
+     * @generated from protobuf field: map<string, string> headers = 3
      */
     headers: {
         [key: string]: string;
     };
     /**
-     * The credential sent with every request: "bearer", "apikey", or "none". Empty
-     * means bearer, which is what an MCP server behind a login nearly always
-     * wants.
+     * The credential sent with every request: "bearer", "basic", "apikey", or
+     * "none". Empty means bearer when a token is set and none otherwise.
      *
-     * @generated from protobuf field: string auth = 3
+     * @generated from protobuf field: string auth = 4
      */
     auth: string;
     /**
      * The bearer token, or the key for the "apikey" credential.
      *
-     * @generated from protobuf field: string token = 4
+     * @generated from protobuf field: string token = 5
      */
     token: string;
+    /**
+     * @generated from protobuf field: string username = 6
+     */
+    username: string;
+    /**
+     * @generated from protobuf field: string password = 7
+     */
+    password: string;
     /**
      * Header the "apikey" credential is sent under. Empty means "X-API-Key".
      *
-     * @generated from protobuf field: string api_key_name = 5
+     * @generated from protobuf field: string api_key_name = 8
      */
     apiKeyName: string;
-}
-/**
- * @generated from protobuf message UpdateConfigurationRequest
- */
-export interface UpdateConfigurationRequest {
     /**
-     * @generated from protobuf field: Configuration configuration = 1
+     * Retrying a query or mutation whose POST fails to connect or comes back with
+     * a status in retry_codes, empty meaning 429, 502, 503 and 504. An answer
+     * carrying GraphQL errors is the endpoint's answer, and is not retried.
+     *
+     * @generated from protobuf field: int64 retry_max_attempts = 9
      */
-    configuration?: Configuration;
-}
-/**
- * @generated from protobuf message UpdateConfigurationResponse
- */
-export interface UpdateConfigurationResponse {
+    retryMaxAttempts: string;
     /**
-     * @generated from protobuf field: Configuration configuration = 1
+     * @generated from protobuf field: int64 retry_backoff_ms = 10
      */
-    configuration?: Configuration;
+    retryBackoffMs: string;
     /**
-     * Where each variable's value came from, re-resolved against what was saved.
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 11
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 12
+     */
+    retryCodes: string[];
+    /**
+     * At most rate_limit queries and mutations a second, bursting to
+     * rate_limit_burst, and max_concurrency in flight. Zero means no limit.
      *
-     * @generated from protobuf field: repeated VariableStatus variable_status = 2
+     * @generated from protobuf field: int64 rate_limit = 13
      */
-    variableStatus: VariableStatus[];
-}
-/**
- * @generated from protobuf enum OpenStatus
- */
-export enum OpenStatus {
+    rateLimit: string;
     /**
-     * @generated from protobuf enum value: OPEN_STATUS_UNKNOWN = 0;
+     * @generated from protobuf field: int64 rate_limit_burst = 14
      */
-    UNKNOWN = 0,
+    rateLimitBurst: string;
     /**
-     * @generated from protobuf enum value: OPEN_STATUS_OK = 1;
+     * @generated from protobuf field: int64 max_concurrency = 15
      */
-    OK = 1,
+    maxConcurrency: string;
     /**
-     * @generated from protobuf enum value: OPEN_STATUS_ERROR = 2;
+     * How many levels of objects the selection set generated for a call follows,
+     * 1 to 8. Zero means 2. A call can ask for another depth, or write its own
+     * selection set.
+     *
+     * @generated from protobuf field: int64 depth = 16
      */
-    ERROR = 2
+    depth: string;
 }
 /**
- * @generated from protobuf enum GrpcProblemKind
+ * JsonrpcApp calls a JSON-RPC 2.0 server described by an OpenRPC document. Each
+ * method of the document is a method, and Batch sends several calls at once.
+ *
+ * @generated from protobuf message JsonrpcApp
  */
-export enum GrpcProblemKind {
+export interface JsonrpcApp {
     /**
-     * @generated from protobuf enum value: GRPC_PROBLEM_UNKNOWN = 0;
+     * The endpoint calls are POSTed to. Empty takes the document's first server.
+     *
+     * @generated from protobuf field: string url = 1
      */
-    GRPC_PROBLEM_UNKNOWN = 0,
+    url: string;
     /**
-     * The host couldn't be reached (DNS, TCP).
+     * Where the OpenRPC document is: a URL, or a file, workspace-relative or
+     * absolute. With neither, it is asked of url with rpc.discover.
      *
-     * @generated from protobuf enum value: GRPC_PROBLEM_UNREACHABLE = 1;
+     * @generated from protobuf field: string spec_url = 2
      */
-    GRPC_PROBLEM_UNREACHABLE = 1,
+    specUrl: string;
     /**
-     * The TLS handshake failed, which usually means the transport is set the
-     * wrong way round or the certificate isn't trusted.
-     *
-     * @generated from protobuf enum value: GRPC_PROBLEM_TLS = 2;
+     * @generated from protobuf field: string spec_file = 3
      */
-    GRPC_PROBLEM_TLS = 2,
+    specFile: string;
     /**
-     * The server answered but doesn't serve the reflection API.
-     *
-     * @generated from protobuf enum value: GRPC_PROBLEM_NO_REFLECTION = 3;
+     * @generated from protobuf field: map<string, string> headers = 4
      */
-    GRPC_PROBLEM_NO_REFLECTION = 3,
+    headers: {
+        [key: string]: string;
+    };
     /**
-     * Reflection is there but wants credentials.
+     * The credential sent with every call: "bearer", "basic", "apikey", or "none".
+     * Empty means bearer when a token is set and none otherwise.
      *
-     * @generated from protobuf enum value: GRPC_PROBLEM_UNAUTHENTICATED = 4;
+     * @generated from protobuf field: string auth = 5
      */
-    GRPC_PROBLEM_UNAUTHENTICATED = 4,
+    auth: string;
     /**
-     * Credentials were sent and rejected.
+     * The bearer token, or the key for the "apikey" credential.
      *
-     * @generated from protobuf enum value: GRPC_PROBLEM_PERMISSION_DENIED = 5;
+     * @generated from protobuf field: string token = 6
      */
-    GRPC_PROBLEM_PERMISSION_DENIED = 5,
+    token: string;
     /**
-     * Reflection answered with nothing but its own service.
-     *
-     * @generated from protobuf enum value: GRPC_PROBLEM_NO_SERVICES = 6;
+     * @generated from protobuf field: string username = 7
      */
-    GRPC_PROBLEM_NO_SERVICES = 6,
+    username: string;
     /**
-     * @generated from protobuf enum value: GRPC_PROBLEM_TIMEOUT = 7;
+     * @generated from protobuf field: string password = 8
      */
-    GRPC_PROBLEM_TIMEOUT = 7,
+    password: string;
     /**
-     * The proto directory holds no .proto files, or doesn't exist.
+     * Header the "apikey" credential is sent under. Empty means "X-API-Key".
      *
-     * @generated from protobuf enum value: GRPC_PROBLEM_NO_PROTO_FILES = 8;
+     * @generated from protobuf field: string api_key_name = 9
      */
-    GRPC_PROBLEM_NO_PROTO_FILES = 8,
+    apiKeyName: string;
     /**
-     * The proto files are there but don't compile.
+     * Retrying a call, or a whole Batch, whose POST fails to connect or comes back
+     * with a status in retry_codes, empty meaning 429, 502, 503 and 504. A
+     * JSON-RPC error object is the server's answer, and is not retried.
      *
-     * @generated from protobuf enum value: GRPC_PROBLEM_PROTO_INVALID = 9;
+     * @generated from protobuf field: int64 retry_max_attempts = 10
      */
-    GRPC_PROBLEM_PROTO_INVALID = 9,
+    retryMaxAttempts: string;
     /**
-     * The URL isn't a usable gRPC target.
+     * @generated from protobuf field: int64 retry_backoff_ms = 11
+     */
+    retryBackoffMs: string;
+    /**
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 12
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 13
+     */
+    retryCodes: string[];
+    /**
+     * At most rate_limit calls a second, bursting to rate_limit_burst, and
+     * max_concurrency in flight; a Batch counts once. Zero means no limit.
      *
-     * @generated from protobuf enum value: GRPC_PROBLEM_TARGET = 10;
+     * @generated from protobuf field: int64 rate_limit = 14
      */
-    GRPC_PROBLEM_TARGET = 10
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 15
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 16
+     */
+    maxConcurrency: string;
 }
 /**
- * @generated from protobuf enum OpenApiProblemKind
+ * WebsocketApp talks JSON to a server over a WebSocket: Send sends a frame,
+ * Request sends one and waits for the reply with the same correlation field, and
+ * Subscribe streams what the server sends. An AsyncAPI document adds a method of
+ * each for the messages it describes. The app's calls share one connection.
+ *
+ * @generated from protobuf message WebsocketApp
  */
-export enum OpenApiProblemKind {
+export interface WebsocketApp {
     /**
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_UNKNOWN = 0;
+     * The ws:// or wss:// URL to connect to. Empty takes the document's first
+     * WebSocket server.
+     *
+     * @generated from protobuf field: string url = 1
      */
-    OPEN_API_PROBLEM_UNKNOWN = 0,
+    url: string;
     /**
-     * The host couldn't be reached (DNS, TCP, TLS).
+     * Where the AsyncAPI document is, if there is one: a URL, or a file,
+     * workspace-relative or absolute. YAML or JSON.
      *
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_UNREACHABLE = 1;
+     * @generated from protobuf field: string asyncapi_url = 2
      */
-    OPEN_API_PROBLEM_UNREACHABLE = 1,
+    asyncapiUrl: string;
     /**
-     * The document itself is behind a login (401/403).
+     * @generated from protobuf field: string asyncapi_file = 3
+     */
+    asyncapiFile: string;
+    /**
+     * Sent with the handshake.
      *
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_UNAUTHORIZED = 2;
+     * @generated from protobuf field: map<string, string> headers = 4
      */
-    OPEN_API_PROBLEM_UNAUTHORIZED = 2,
+    headers: {
+        [key: string]: string;
+    };
     /**
-     * Any other non-200 response.
+     * The credential sent with the handshake: "bearer", "basic", "apikey", or
+     * "none". Empty means bearer when a token is set and none otherwise.
      *
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_HTTP_ERROR = 3;
+     * @generated from protobuf field: string auth = 5
      */
-    OPEN_API_PROBLEM_HTTP_ERROR = 3,
+    auth: string;
     /**
-     * The response is an HTML page, almost always a login redirect or docs page.
+     * The bearer token, or the key for the "apikey" credential.
      *
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_HTML = 4;
+     * @generated from protobuf field: string token = 6
      */
-    OPEN_API_PROBLEM_HTML = 4,
+    token: string;
     /**
-     * Parsed, but it isn't an OpenAPI document.
+     * @generated from protobuf field: string username = 7
+     */
+    username: string;
+    /**
+     * @generated from protobuf field: string password = 8
+     */
+    password: string;
+    /**
+     * Header the "apikey" credential is sent under. Empty means "X-API-Key".
      *
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_NOT_A_DOCUMENT = 5;
+     * @generated from protobuf field: string api_key_name = 9
      */
-    OPEN_API_PROBLEM_NOT_A_DOCUMENT = 5,
+    apiKeyName: string;
     /**
-     * A Swagger 2.0 document, which kaja doesn't read.
+     * The field a reply shares with the request it answers. Empty means "id"; a
+     * dotted name, such as "meta.requestId", looks inside an object.
      *
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_SWAGGER2 = 6;
+     * @generated from protobuf field: string correlation_field = 10
      */
-    OPEN_API_PROBLEM_SWAGGER2 = 6,
+    correlationField: string;
     /**
-     * Not parseable as JSON or YAML.
+     * Subprotocols offered in the handshake.
      *
-     * @generated from protobuf enum value: OPEN_API_PROBLEM_MALFORMED = 7;
+     * @generated from protobuf field: repeated string subprotocols = 11
      */
-    OPEN_API_PROBLEM_MALFORMED = 7
+    subprotocols: string[];
+    /**
+     * How fast and how widely Send, Request and Subscribe go out over the shared
+     * connection. There are no retries: a frame that was sent is not sent again.
+     *
+     * @generated from protobuf field: int64 rate_limit = 12
+     */
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 13
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 14
+     */
+    maxConcurrency: string;
 }
 /**
- * @generated from protobuf enum McpProblemKind
+ * HttpApp calls an HTTP API that has no document to describe it. Request sends
+ * any request to base_url, and each endpoint is a method of its own.
+ *
+ * @generated from protobuf message HttpApp
  */
-export enum McpProblemKind {
+export interface HttpApp {
     /**
-     * @generated from protobuf enum value: MCP_PROBLEM_UNKNOWN = 0;
+     * What request paths are joined to, e.g. "https://internal.example.com/api".
+     *
+     * @generated from protobuf field: string base_url = 1
      */
-    MCP_PROBLEM_UNKNOWN = 0,
+    baseUrl: string;
     /**
-     * The URL isn't a usable HTTP endpoint.
-     *
-     * @generated from protobuf enum value: MCP_PROBLEM_TARGET = 1;
+     * @generated from protobuf field: map<string, string> headers = 2
      */
-    MCP_PROBLEM_TARGET = 1,
+    headers: {
+        [key: string]: string;
+    };
     /**
-     * The host couldn't be reached (DNS, TCP, TLS).
+     * The credentials, as an OpenApiApp has them: a username or password is sent
+     * as HTTP Basic, and a token as a bearer token.
      *
-     * @generated from protobuf enum value: MCP_PROBLEM_UNREACHABLE = 2;
+     * @generated from protobuf field: string token = 3
      */
-    MCP_PROBLEM_UNREACHABLE = 2,
+    token: string;
     /**
-     * @generated from protobuf enum value: MCP_PROBLEM_TIMEOUT = 3;
+     * @generated from protobuf field: string username = 4
      */
-    MCP_PROBLEM_TIMEOUT = 3,
+    username: string;
     /**
-     * The server wants a credential.
-     *
-     * @generated from protobuf enum value: MCP_PROBLEM_UNAUTHORIZED = 4;
+     * @generated from protobuf field: string password = 5
      */
-    MCP_PROBLEM_UNAUTHORIZED = 4,
+    password: string;
     /**
-     * A credential was sent and rejected.
-     *
-     * @generated from protobuf enum value: MCP_PROBLEM_FORBIDDEN = 5;
+     * @generated from protobuf field: repeated HttpEndpoint endpoints = 6
      */
-    MCP_PROBLEM_FORBIDDEN = 5,
+    endpoints: HttpEndpoint[];
     /**
-     * Any other non-200 response.
+     * Retrying a request that fails to connect or comes back with a status in
+     * retry_codes, empty meaning 429, 502, 503 and 504. Any method is retried,
+     * POST included, so leave it off for an API whose writes aren't idempotent.
      *
-     * @generated from protobuf enum value: MCP_PROBLEM_HTTP_ERROR = 6;
+     * @generated from protobuf field: int64 retry_max_attempts = 7
      */
-    MCP_PROBLEM_HTTP_ERROR = 6,
+    retryMaxAttempts: string;
     /**
-     * Something answered, but it doesn't speak MCP.
-     *
-     * @generated from protobuf enum value: MCP_PROBLEM_NOT_MCP = 7;
+     * @generated from protobuf field: int64 retry_backoff_ms = 8
      */
-    MCP_PROBLEM_NOT_MCP = 7,
+    retryBackoffMs: string;
     /**
-     * It speaks MCP and exposes nothing.
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 9
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 10
+     */
+    retryCodes: string[];
+    /**
+     * At most rate_limit requests a second, bursting to rate_limit_burst, and
+     * max_concurrency in flight, whether through Request or an endpoint's method.
      *
-     * @generated from protobuf enum value: MCP_PROBLEM_EMPTY = 8;
+     * @generated from protobuf field: int64 rate_limit = 11
      */
-    MCP_PROBLEM_EMPTY = 8
+    rateLimit: string;
+    /**
+     * @generated from protobuf field: int64 rate_limit_burst = 12
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 13
+     */
+    maxConcurrency: string;
 }
 /**
- * @generated from protobuf enum CompileStatus
+ * HttpEndpoint is one request of an HttpApp that is a method of its own, whose
+ * request is the endpoint's parameters and body.
+ *
+ * @generated from protobuf message HttpEndpoint
  */
-export enum CompileStatus {
+export interface HttpEndpoint {
     /**
-     * @generated from protobuf enum value: STATUS_UNKNOWN = 0;
+     * The method's name, e.g. "get_user" or "GetUser".
+     *
+     * @generated from protobuf field: string name = 1
      */
-    STATUS_UNKNOWN = 0,
+    name: string;
     /**
-     * @generated from protobuf enum value: STATUS_READY = 1;
+     * Empty means GET.
+     *
+     * @generated from protobuf field: string method = 2
      */
-    STATUS_READY = 1,
+    method: string;
     /**
-     * @generated from protobuf enum value: STATUS_ERROR = 2;
+     * Joined to the app's base URL. Each "{name}" in it is a parameter of the
+     * method, e.g. "/users/{id}".
+     *
+     * @generated from protobuf field: string path = 3
      */
-    STATUS_ERROR = 2,
+    path: string;
     /**
-     * @generated from protobuf enum value: STATUS_RUNNING = 3;
+     * The query parameters and headers the method takes.
+     *
+     * @generated from protobuf field: repeated string query = 4
      */
-    STATUS_RUNNING = 3
+    query: string[];
+    /**
+     * @generated from protobuf field: repeated string headers = 5
+     */
+    headers: string[];
+    /**
+     * What the body is: "json", "text", "bytes", or "none". Empty means json for
+     * POST, PUT and PATCH and none otherwise.
+     *
+     * @generated from protobuf field: string body = 6
+     */
+    body: string;
+    /**
+     * @generated from protobuf field: string description = 7
+     */
+    description: string;
 }
 /**
- * @generated from protobuf enum LogLevel
+ * McpApp explores another Model Context Protocol server. With url set, kaja
+ * speaks to it over the Streamable HTTP transport; with command set, it launches
+ * the server itself and speaks to it over the stdio transport, the server's stdin
+ * and stdout. Exactly one of the two is set. Its proto surface is generated from
+ * what that server exposes: one method per tool, one per prompt, and the
+ * list/read methods for its resources.
+ *
+ * The credential is the app's, not the request's: kaja resolves it where it
+ * holds it and applies it on the way out, so a "${secret}" token is never handed
+ * to the browser. It is sent to a server at url only.
+ *
+ * @generated from protobuf message McpApp
  */
-export enum LogLevel {
+export interface McpApp {
     /**
-     * @generated from protobuf enum value: LEVEL_DEBUG = 0;
+     * The server's MCP endpoint, e.g. "https://example.com/mcp".
+     *
+     * @generated from protobuf field: string url = 1
      */
-    LEVEL_DEBUG = 0,
+    url: string;
     /**
-     * @generated from protobuf enum value: LEVEL_INFO = 1;
+     * @generated from protobuf field: map<string, string> headers = 2
      */
-    LEVEL_INFO = 1,
+    headers: {
+        [key: string]: string;
+    };
     /**
-     * @generated from protobuf enum value: LEVEL_WARN = 2;
+     * The credential sent with every request: "bearer", "apikey", "oauth", or
+     * "none". Empty means bearer, which is what an MCP server behind a login
+     * nearly always wants, and with no token sends nothing. "oauth" has kaja sign
+     * in to the server as its authorization spec describes - discovering the
+     * authorization server, registering, and the authorization code flow with
+     * PKCE - and hold and refresh the tokens itself.
+     *
+     * @generated from protobuf field: string auth = 3
      */
-    LEVEL_WARN = 2,
+    auth: string;
     /**
-     * @generated from protobuf enum value: LEVEL_ERROR = 3;
+     * The bearer token, or the key for the "apikey" credential.
+     *
+     * @generated from protobuf field: string token = 4
      */
-    LEVEL_ERROR = 3
-}
-/**
- * @generated from protobuf enum VariableSource
- */
-export enum VariableSource {
+    token: string;
     /**
-     * The variable names a source, but the source holds nothing on this machine.
+     * Header the "apikey" credential is sent under. Empty means "X-API-Key".
      *
-     * @generated from protobuf enum value: VARIABLE_SOURCE_UNSET = 0;
+     * @generated from protobuf field: string api_key_name = 5
      */
-    UNSET = 0,
+    apiKeyName: string;
     /**
-     * The value is written in kaja.json.
+     * Retrying a request to a server at url that fails to connect or comes back
+     * with a status in retry_codes, empty meaning 429, 502, 503 and 504. A tool
+     * that reports an error has answered, and is not retried.
      *
-     * @generated from protobuf enum value: VARIABLE_SOURCE_FILE = 1;
+     * @generated from protobuf field: int64 retry_max_attempts = 6
      */
-    FILE = 1,
+    retryMaxAttempts: string;
     /**
-     * The value came from the OS keychain.
+     * @generated from protobuf field: int64 retry_backoff_ms = 7
+     */
+    retryBackoffMs: string;
+    /**
+     * @generated from protobuf field: int64 retry_max_backoff_ms = 8
+     */
+    retryMaxBackoffMs: string;
+    /**
+     * @generated from protobuf field: repeated string retry_codes = 9
+     */
+    retryCodes: string[];
+    /**
+     * How fast and how widely tool calls, prompts and resource reads go out to
+     * the server. Zero means no limit.
      *
-     * @generated from protobuf enum value: VARIABLE_SOURCE_KEYCHAIN = 2;
+     * @generated from protobuf field: int64 rate_limit = 10
      */
-    KEYCHAIN = 2,
+    rateLimit: string;
     /**
-     * The value came from an environment variable.
+     * @generated from protobuf field: int64 rate_limit_burst = 11
+     */
+    rateLimitBurst: string;
+    /**
+     * @generated from protobuf field: int64 max_concurrency = 12
+     */
+    maxConcurrency: string;
+    /**
+     * A local server kaja launches in place of url, spoken to over its stdin and
+     * stdout. args are passed as they are, with no shell between; env holds
+     * NAME=value entries added to kaja's environment; working_dir is
+     * workspace-relative and defaults to the workspace.
      *
-     * @generated from protobuf enum value: VARIABLE_SOURCE_ENVIRONMENT = 3;
+     * @generated from protobuf field: string command = 13
      */
-    ENVIRONMENT = 3
+    command: string;
+    /**
+     * @generated from protobuf field: repeated string args = 14
+     */
+    args: string[];
+    /**
+     * @generated from protobuf field: repeated string env = 15
+     */
+    env: string[];
+    /**
+     * @generated from protobuf field: string working_dir = 16
+     */
+    workingDir: string;
+    /**
+     * What kaja answers when the server asks something of it mid-call. roots
+     * names the folder apps whose folders the server is told it may work in;
+     * empty means every one configured. sampling_app names the openai app the
+     * server's sampling requests are completed with, none when empty, and
+     * sampling_model the model asked; empty takes the server's first preference.
+     * Elicitation is always answered: its questions are put to whoever runs the
+     * call.
+     *
+     * @generated from protobuf field: repeated string roots = 17
+     */
+    roots: string[];
+    /**
+     * @generated from protobuf field: string sampling_app = 18
+     */
+    samplingApp: string;
+    /**
+     * @generated from protobuf field: string sampling_model = 19
+     */
+    samplingModel: string;
 }
-// @generated message type with reflection information, may provide speed optimized methods
-class CompileRequest$Type extends MessageType<CompileRequest> {
-    constructor() {
+/**
+ * @generated from protobuf message UpdateConfigurationRequest
+ */
+export interface UpdateConfigurationRequest {
+    /**
+     * @generated from protobuf field: Configuration configuration = 1
+     */
+    configuration?: Configuration;
+}
+/**
+ * @generated from protobuf message UpdateConfigurationResponse
+ */
+export interface UpdateConfigurationResponse {
+    /**
+     * @generated from protobuf field: Configuration configuration = 1
+     */
+    configuration?: Configuration;
+    /**
+     * Where each variable's value came from, re-resolved against what was saved.
+     *
+     * @generated from protobuf field: repeated VariableStatus variable_status = 2
+     */
+    variableStatus: VariableStatus[];
+}
+/**
+ * @generated from protobuf enum OpenStatus
+ */
+export enum OpenStatus {
+    /**
+     * @generated from protobuf enum value: OPEN_STATUS_UNKNOWN = 0;
+     */
+    UNKNOWN = 0,
+    /**
+     * @generated from protobuf enum value: OPEN_STATUS_OK = 1;
+     */
+    OK = 1,
+    /**
+     * @generated from protobuf enum value: OPEN_STATUS_ERROR = 2;
+     */
+    ERROR = 2
+}
+/**
+ * @generated from protobuf enum GrpcProblemKind
+ */
+export enum GrpcProblemKind {
+    /**
+     * @generated from protobuf enum value: GRPC_PROBLEM_UNKNOWN = 0;
+     */
+    GRPC_PROBLEM_UNKNOWN = 0,
+    /**
+     * The host couldn't be reached (DNS, TCP).
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_UNREACHABLE = 1;
+     */
+    GRPC_PROBLEM_UNREACHABLE = 1,
+    /**
+     * The TLS handshake failed, which usually means the transport is set the
+     * wrong way round or the certificate isn't trusted.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_TLS = 2;
+     */
+    GRPC_PROBLEM_TLS = 2,
+    /**
+     * The server answered but doesn't serve the reflection API.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_NO_REFLECTION = 3;
+     */
+    GRPC_PROBLEM_NO_REFLECTION = 3,
+    /**
+     * Reflection is there but wants credentials.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_UNAUTHENTICATED = 4;
+     */
+    GRPC_PROBLEM_UNAUTHENTICATED = 4,
+    /**
+     * Credentials were sent and rejected.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_PERMISSION_DENIED = 5;
+     */
+    GRPC_PROBLEM_PERMISSION_DENIED = 5,
+    /**
+     * Reflection answered with nothing but its own service.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_NO_SERVICES = 6;
+     */
+    GRPC_PROBLEM_NO_SERVICES = 6,
+    /**
+     * @generated from protobuf enum value: GRPC_PROBLEM_TIMEOUT = 7;
+     */
+    GRPC_PROBLEM_TIMEOUT = 7,
+    /**
+     * The proto directory holds no .proto files, or doesn't exist.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_NO_PROTO_FILES = 8;
+     */
+    GRPC_PROBLEM_NO_PROTO_FILES = 8,
+    /**
+     * The proto files are there but don't compile.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_PROTO_INVALID = 9;
+     */
+    GRPC_PROBLEM_PROTO_INVALID = 9,
+    /**
+     * The URL isn't a usable gRPC target.
+     *
+     * @generated from protobuf enum value: GRPC_PROBLEM_TARGET = 10;
+     */
+    GRPC_PROBLEM_TARGET = 10
+}
+/**
+ * @generated from protobuf enum OpenApiProblemKind
+ */
+export enum OpenApiProblemKind {
+    /**
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_UNKNOWN = 0;
+     */
+    OPEN_API_PROBLEM_UNKNOWN = 0,
+    /**
+     * The host couldn't be reached (DNS, TCP, TLS).
+     *
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_UNREACHABLE = 1;
+     */
+    OPEN_API_PROBLEM_UNREACHABLE = 1,
+    /**
+     * The document itself is behind a login (401/403).
+     *
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_UNAUTHORIZED = 2;
+     */
+    OPEN_API_PROBLEM_UNAUTHORIZED = 2,
+    /**
+     * Any other non-200 response.
+     *
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_HTTP_ERROR = 3;
+     */
+    OPEN_API_PROBLEM_HTTP_ERROR = 3,
+    /**
+     * The response is an HTML page, almost always a login redirect or docs page.
+     *
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_HTML = 4;
+     */
+    OPEN_API_PROBLEM_HTML = 4,
+    /**
+     * Parsed, but it isn't an OpenAPI document.
+     *
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_NOT_A_DOCUMENT = 5;
+     */
+    OPEN_API_PROBLEM_NOT_A_DOCUMENT = 5,
+    /**
+     * A Swagger 2.0 document, which kaja doesn't read.
+     *
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_SWAGGER2 = 6;
+     */
+    OPEN_API_PROBLEM_SWAGGER2 = 6,
+    /**
+     * Not parseable as JSON or YAML.
+     *
+     * @generated from protobuf enum value: OPEN_API_PROBLEM_MALFORMED = 7;
+     */
+    OPEN_API_PROBLEM_MALFORMED = 7
+}
+/**
+ * @generated from protobuf enum McpProblemKind
+ */
+export enum McpProblemKind {
+    /**
+     * @generated from protobuf enum value: MCP_PROBLEM_UNKNOWN = 0;
+     */
+    MCP_PROBLEM_UNKNOWN = 0,
+    /**
+     * The URL isn't a usable HTTP endpoint.
+     *
+     * @generated from protobuf enum value: MCP_PROBLEM_TARGET = 1;
+     */
+    MCP_PROBLEM_TARGET = 1,
+    /**
+     * The host couldn't be reached (DNS, TCP, TLS).
+     *
+     * @generated from protobuf enum value: MCP_PROBLEM_UNREACHABLE = 2;
+     */
+    MCP_PROBLEM_UNREACHABLE = 2,
+    /**
+     * @generated from protobuf enum value: MCP_PROBLEM_TIMEOUT = 3;
+     */
+    MCP_PROBLEM_TIMEOUT = 3,
+    /**
+     * The server wants a credential.
+     *
+     * @generated from protobuf enum value: MCP_PROBLEM_UNAUTHORIZED = 4;
+     */
+    MCP_PROBLEM_UNAUTHORIZED = 4,
+    /**
+     * A credential was sent and rejected.
+     *
+     * @generated from protobuf enum value: MCP_PROBLEM_FORBIDDEN = 5;
+     */
+    MCP_PROBLEM_FORBIDDEN = 5,
+    /**
+     * Any other non-200 response.
+     *
+     * @generated from protobuf enum value: MCP_PROBLEM_HTTP_ERROR = 6;
+     */
+    MCP_PROBLEM_HTTP_ERROR = 6,
+    /**
+     * Something answered, but it doesn't speak MCP.
+     *
+     * @generated from protobuf enum value: MCP_PROBLEM_NOT_MCP = 7;
+     */
+    MCP_PROBLEM_NOT_MCP = 7,
+    /**
+     * It speaks MCP and exposes nothing.
+     *
+     * @generated from protobuf enum value: MCP_PROBLEM_EMPTY = 8;
+     */
+    MCP_PROBLEM_EMPTY = 8
+}
+/**
+ * @generated from protobuf enum CompileStatus
+ */
+export enum CompileStatus {
+    /**
+     * @generated from protobuf enum value: STATUS_UNKNOWN = 0;
+     */
+    STATUS_UNKNOWN = 0,
+    /**
+     * @generated from protobuf enum value: STATUS_READY = 1;
+     */
+    STATUS_READY = 1,
+    /**
+     * @generated from protobuf enum value: STATUS_ERROR = 2;
+     */
+    STATUS_ERROR = 2,
+    /**
+     * @generated from protobuf enum value: STATUS_RUNNING = 3;
+     */
+    STATUS_RUNNING = 3
+}
+/**
+ * @generated from protobuf enum LogLevel
+ */
+export enum LogLevel {
+    /**
+     * @generated from protobuf enum value: LEVEL_DEBUG = 0;
+     */
+    LEVEL_DEBUG = 0,
+    /**
+     * @generated from protobuf enum value: LEVEL_INFO = 1;
+     */
+    LEVEL_INFO = 1,
+    /**
+     * @generated from protobuf enum value: LEVEL_WARN = 2;
+     */
+    LEVEL_WARN = 2,
+    /**
+     * @generated from protobuf enum value: LEVEL_ERROR = 3;
+     */
+    LEVEL_ERROR = 3
+}
+/**
+ * @generated from protobuf enum VariableSource
+ */
+export enum VariableSource {
+    /**
+     * The variable names a source, but the source holds nothing on this machine.
+     *
+     * @generated from protobuf enum value: VARIABLE_SOURCE_UNSET = 0;
+     */
+    UNSET = 0,
+    /**
+     * The value is written in kaja.json.
+     *
+     * @generated from protobuf enum value: VARIABLE_SOURCE_FILE = 1;
+     */
+    FILE = 1,
+    /**
+     * The value came from the OS keychain.
+     *
+     * @generated from protobuf enum value: VARIABLE_SOURCE_KEYCHAIN = 2;
+     */
+    KEYCHAIN = 2,
+    /**
+     * The value came from an environment variable.
+     *
+     * @generated from protobuf enum value: VARIABLE_SOURCE_ENVIRONMENT = 3;
+     */
+    ENVIRONMENT = 3
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class CompileRequest$Type extends MessageType<CompileRequest> {
+    constructor() {
         super("CompileRequest", [
             { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "log_offset", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
             { no: 3, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<CompileRequest>): CompileRequest {
+    create(value?: PartialMessage<CompileRequest>): CompileRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        message.logOffset = 0;
+        message.protoDir = "";
+        if (value !== undefined)
+            reflectionMergePartial<CompileRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CompileRequest): CompileRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                case /* int32 log_offset */ 2:
+                    message.logOffset = reader.int32();
+                    break;
+                case /* string proto_dir */ 3:
+                    message.protoDir = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: CompileRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* int32 log_offset = 2; */
+        if (message.logOffset !== 0)
+            writer.tag(2, WireType.Varint).int32(message.logOffset);
+        /* string proto_dir = 3; */
+        if (message.protoDir !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.protoDir);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message CompileRequest
+ */
+export const CompileRequest = new CompileRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class OpenAppRequest$Type extends MessageType<OpenAppRequest> {
+    constructor() {
+        super("OpenAppRequest", [
+            { no: 1, name: "app", kind: "message", T: () => ConfigurationApp }
+        ]);
+    }
+    create(value?: PartialMessage<OpenAppRequest>): OpenAppRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<OpenAppRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenAppRequest): OpenAppRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* ConfigurationApp app */ 1:
+                    message.app = ConfigurationApp.internalBinaryRead(reader, reader.uint32(), options, message.app);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: OpenAppRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* ConfigurationApp app = 1; */
+        if (message.app)
+            ConfigurationApp.internalBinaryWrite(message.app, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message OpenAppRequest
+ */
+export const OpenAppRequest = new OpenAppRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class OpenAppResponse$Type extends MessageType<OpenAppResponse> {
+    constructor() {
+        super("OpenAppResponse", [
+            { no: 1, name: "status", kind: "enum", T: () => ["OpenStatus", OpenStatus, "OPEN_STATUS_"] },
+            { no: 2, name: "logs", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Log },
+            { no: 3, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "target", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "protocol", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<OpenAppResponse>): OpenAppResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.status = 0;
+        message.logs = [];
+        message.protoDir = "";
+        message.target = "";
+        message.protocol = "";
+        if (value !== undefined)
+            reflectionMergePartial<OpenAppResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenAppResponse): OpenAppResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* OpenStatus status */ 1:
+                    message.status = reader.int32();
+                    break;
+                case /* repeated Log logs */ 2:
+                    message.logs.push(Log.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string proto_dir */ 3:
+                    message.protoDir = reader.string();
+                    break;
+                case /* string target */ 4:
+                    message.target = reader.string();
+                    break;
+                case /* string protocol */ 5:
+                    message.protocol = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: OpenAppResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* OpenStatus status = 1; */
+        if (message.status !== 0)
+            writer.tag(1, WireType.Varint).int32(message.status);
+        /* repeated Log logs = 2; */
+        for (let i = 0; i < message.logs.length; i++)
+            Log.internalBinaryWrite(message.logs[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* string proto_dir = 3; */
+        if (message.protoDir !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.protoDir);
+        /* string target = 4; */
+        if (message.target !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.target);
+        /* string protocol = 5; */
+        if (message.protocol !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.protocol);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message OpenAppResponse
+ */
+export const OpenAppResponse = new OpenAppResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class InspectGrpcRequest$Type extends MessageType<InspectGrpcRequest> {
+    constructor() {
+        super("InspectGrpcRequest", [
+            { no: 1, name: "grpc", kind: "message", T: () => GrpcApp }
+        ]);
+    }
+    create(value?: PartialMessage<InspectGrpcRequest>): InspectGrpcRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<InspectGrpcRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectGrpcRequest): InspectGrpcRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* GrpcApp grpc */ 1:
+                    message.grpc = GrpcApp.internalBinaryRead(reader, reader.uint32(), options, message.grpc);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: InspectGrpcRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* GrpcApp grpc = 1; */
+        if (message.grpc)
+            GrpcApp.internalBinaryWrite(message.grpc, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message InspectGrpcRequest
+ */
+export const InspectGrpcRequest = new InspectGrpcRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class InspectGrpcResponse$Type extends MessageType<InspectGrpcResponse> {
+    constructor() {
+        super("InspectGrpcResponse", [
+            { no: 1, name: "server", kind: "message", T: () => GrpcServer },
+            { no: 2, name: "problem", kind: "message", T: () => GrpcProblem }
+        ]);
+    }
+    create(value?: PartialMessage<InspectGrpcResponse>): InspectGrpcResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<InspectGrpcResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectGrpcResponse): InspectGrpcResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* GrpcServer server */ 1:
+                    message.server = GrpcServer.internalBinaryRead(reader, reader.uint32(), options, message.server);
+                    break;
+                case /* GrpcProblem problem */ 2:
+                    message.problem = GrpcProblem.internalBinaryRead(reader, reader.uint32(), options, message.problem);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: InspectGrpcResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* GrpcServer server = 1; */
+        if (message.server)
+            GrpcServer.internalBinaryWrite(message.server, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* GrpcProblem problem = 2; */
+        if (message.problem)
+            GrpcProblem.internalBinaryWrite(message.problem, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message InspectGrpcResponse
+ */
+export const InspectGrpcResponse = new InspectGrpcResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GrpcServer$Type extends MessageType<GrpcServer> {
+    constructor() {
+        super("GrpcServer", [
+            { no: 1, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "target", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "tls", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 4, name: "services", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => GrpcService },
+            { no: 5, name: "method_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 6, name: "reflection_version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "file_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 8, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "reachable", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<GrpcServer>): GrpcServer {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.source = "";
+        message.target = "";
+        message.tls = false;
+        message.services = [];
+        message.methodCount = 0;
+        message.reflectionVersion = "";
+        message.fileCount = 0;
+        message.protoDir = "";
+        message.reachable = false;
+        if (value !== undefined)
+            reflectionMergePartial<GrpcServer>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcServer): GrpcServer {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string source */ 1:
+                    message.source = reader.string();
+                    break;
+                case /* string target */ 2:
+                    message.target = reader.string();
+                    break;
+                case /* bool tls */ 3:
+                    message.tls = reader.bool();
+                    break;
+                case /* repeated GrpcService services */ 4:
+                    message.services.push(GrpcService.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* int32 method_count */ 5:
+                    message.methodCount = reader.int32();
+                    break;
+                case /* string reflection_version */ 6:
+                    message.reflectionVersion = reader.string();
+                    break;
+                case /* int32 file_count */ 7:
+                    message.fileCount = reader.int32();
+                    break;
+                case /* string proto_dir */ 8:
+                    message.protoDir = reader.string();
+                    break;
+                case /* bool reachable */ 9:
+                    message.reachable = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GrpcServer, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string source = 1; */
+        if (message.source !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.source);
+        /* string target = 2; */
+        if (message.target !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.target);
+        /* bool tls = 3; */
+        if (message.tls !== false)
+            writer.tag(3, WireType.Varint).bool(message.tls);
+        /* repeated GrpcService services = 4; */
+        for (let i = 0; i < message.services.length; i++)
+            GrpcService.internalBinaryWrite(message.services[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* int32 method_count = 5; */
+        if (message.methodCount !== 0)
+            writer.tag(5, WireType.Varint).int32(message.methodCount);
+        /* string reflection_version = 6; */
+        if (message.reflectionVersion !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.reflectionVersion);
+        /* int32 file_count = 7; */
+        if (message.fileCount !== 0)
+            writer.tag(7, WireType.Varint).int32(message.fileCount);
+        /* string proto_dir = 8; */
+        if (message.protoDir !== "")
+            writer.tag(8, WireType.LengthDelimited).string(message.protoDir);
+        /* bool reachable = 9; */
+        if (message.reachable !== false)
+            writer.tag(9, WireType.Varint).bool(message.reachable);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message GrpcServer
+ */
+export const GrpcServer = new GrpcServer$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GrpcService$Type extends MessageType<GrpcService> {
+    constructor() {
+        super("GrpcService", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "method_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "streaming_method_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<GrpcService>): GrpcService {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.methodCount = 0;
+        message.streamingMethodCount = 0;
+        if (value !== undefined)
+            reflectionMergePartial<GrpcService>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcService): GrpcService {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* int32 method_count */ 2:
+                    message.methodCount = reader.int32();
+                    break;
+                case /* int32 streaming_method_count */ 3:
+                    message.streamingMethodCount = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GrpcService, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* int32 method_count = 2; */
+        if (message.methodCount !== 0)
+            writer.tag(2, WireType.Varint).int32(message.methodCount);
+        /* int32 streaming_method_count = 3; */
+        if (message.streamingMethodCount !== 0)
+            writer.tag(3, WireType.Varint).int32(message.streamingMethodCount);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message GrpcService
+ */
+export const GrpcService = new GrpcService$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GrpcProblem$Type extends MessageType<GrpcProblem> {
+    constructor() {
+        super("GrpcProblem", [
+            { no: 1, name: "kind", kind: "enum", T: () => ["GrpcProblemKind", GrpcProblemKind] },
+            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "detail", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<GrpcProblem>): GrpcProblem {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.kind = 0;
+        message.message = "";
+        message.detail = "";
+        if (value !== undefined)
+            reflectionMergePartial<GrpcProblem>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcProblem): GrpcProblem {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* GrpcProblemKind kind */ 1:
+                    message.kind = reader.int32();
+                    break;
+                case /* string message */ 2:
+                    message.message = reader.string();
+                    break;
+                case /* string detail */ 3:
+                    message.detail = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GrpcProblem, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* GrpcProblemKind kind = 1; */
+        if (message.kind !== 0)
+            writer.tag(1, WireType.Varint).int32(message.kind);
+        /* string message = 2; */
+        if (message.message !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.message);
+        /* string detail = 3; */
+        if (message.detail !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.detail);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message GrpcProblem
+ */
+export const GrpcProblem = new GrpcProblem$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class InspectOpenApiRequest$Type extends MessageType<InspectOpenApiRequest> {
+    constructor() {
+        super("InspectOpenApiRequest", [
+            { no: 1, name: "openapi", kind: "message", T: () => OpenApiApp }
+        ]);
+    }
+    create(value?: PartialMessage<InspectOpenApiRequest>): InspectOpenApiRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.id = "";
-        message.logOffset = 0;
-        message.protoDir = "";
         if (value !== undefined)
-            reflectionMergePartial<CompileRequest>(this, message, value);
+            reflectionMergePartial<InspectOpenApiRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CompileRequest): CompileRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectOpenApiRequest): InspectOpenApiRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string id */ 1:
-                    message.id = reader.string();
-                    break;
-                case /* int32 log_offset */ 2:
-                    message.logOffset = reader.int32();
-                    break;
-                case /* string proto_dir */ 3:
-                    message.protoDir = reader.string();
+                case /* OpenApiApp openapi */ 1:
+                    message.openapi = OpenApiApp.internalBinaryRead(reader, reader.uint32(), options, message.openapi);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1493,16 +2873,10 @@ class CompileRequest$Type extends MessageType<CompileRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: CompileRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string id = 1; */
-        if (message.id !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.id);
-        /* int32 log_offset = 2; */
-        if (message.logOffset !== 0)
-            writer.tag(2, WireType.Varint).int32(message.logOffset);
-        /* string proto_dir = 3; */
-        if (message.protoDir !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.protoDir);
+    internalBinaryWrite(message: InspectOpenApiRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* OpenApiApp openapi = 1; */
+        if (message.openapi)
+            OpenApiApp.internalBinaryWrite(message.openapi, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1510,29 +2884,33 @@ class CompileRequest$Type extends MessageType<CompileRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message CompileRequest
+ * @generated MessageType for protobuf message InspectOpenApiRequest
  */
-export const CompileRequest = new CompileRequest$Type();
+export const InspectOpenApiRequest = new InspectOpenApiRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenAppRequest$Type extends MessageType<OpenAppRequest> {
+class InspectOpenApiResponse$Type extends MessageType<InspectOpenApiResponse> {
     constructor() {
-        super("OpenAppRequest", [
-            { no: 1, name: "app", kind: "message", T: () => ConfigurationApp }
+        super("InspectOpenApiResponse", [
+            { no: 1, name: "document", kind: "message", T: () => OpenApiDocument },
+            { no: 2, name: "problem", kind: "message", T: () => OpenApiProblem }
         ]);
     }
-    create(value?: PartialMessage<OpenAppRequest>): OpenAppRequest {
+    create(value?: PartialMessage<InspectOpenApiResponse>): InspectOpenApiResponse {
         const message = globalThis.Object.create((this.messagePrototype!));
         if (value !== undefined)
-            reflectionMergePartial<OpenAppRequest>(this, message, value);
+            reflectionMergePartial<InspectOpenApiResponse>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenAppRequest): OpenAppRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectOpenApiResponse): InspectOpenApiResponse {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* ConfigurationApp app */ 1:
-                    message.app = ConfigurationApp.internalBinaryRead(reader, reader.uint32(), options, message.app);
+                case /* OpenApiDocument document */ 1:
+                    message.document = OpenApiDocument.internalBinaryRead(reader, reader.uint32(), options, message.document);
+                    break;
+                case /* OpenApiProblem problem */ 2:
+                    message.problem = OpenApiProblem.internalBinaryRead(reader, reader.uint32(), options, message.problem);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1545,10 +2923,13 @@ class OpenAppRequest$Type extends MessageType<OpenAppRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: OpenAppRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* ConfigurationApp app = 1; */
-        if (message.app)
-            ConfigurationApp.internalBinaryWrite(message.app, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: InspectOpenApiResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* OpenApiDocument document = 1; */
+        if (message.document)
+            OpenApiDocument.internalBinaryWrite(message.document, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* OpenApiProblem problem = 2; */
+        if (message.problem)
+            OpenApiProblem.internalBinaryWrite(message.problem, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1556,50 +2937,151 @@ class OpenAppRequest$Type extends MessageType<OpenAppRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenAppRequest
+ * @generated MessageType for protobuf message InspectOpenApiResponse
  */
-export const OpenAppRequest = new OpenAppRequest$Type();
+export const InspectOpenApiResponse = new InspectOpenApiResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenAppResponse$Type extends MessageType<OpenAppResponse> {
+class OpenApiDocument$Type extends MessageType<OpenApiDocument> {
     constructor() {
-        super("OpenAppResponse", [
-            { no: 1, name: "status", kind: "enum", T: () => ["OpenStatus", OpenStatus, "OPEN_STATUS_"] },
-            { no: 2, name: "logs", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Log },
-            { no: 3, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "target", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "protocol", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("OpenApiDocument", [
+            { no: 1, name: "title", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "openapi_version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "operation_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 5, name: "tag_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 6, name: "servers", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => OpenApiServer },
+            { no: 7, name: "security_schemes", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => OpenApiSecurityScheme },
+            { no: 8, name: "guessed_base_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "per_operation_security", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<OpenAppResponse>): OpenAppResponse {
+    create(value?: PartialMessage<OpenApiDocument>): OpenApiDocument {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.status = 0;
-        message.logs = [];
-        message.protoDir = "";
-        message.target = "";
-        message.protocol = "";
+        message.title = "";
+        message.version = "";
+        message.openapiVersion = "";
+        message.operationCount = 0;
+        message.tagCount = 0;
+        message.servers = [];
+        message.securitySchemes = [];
+        message.guessedBaseUrl = "";
+        message.perOperationSecurity = false;
         if (value !== undefined)
-            reflectionMergePartial<OpenAppResponse>(this, message, value);
+            reflectionMergePartial<OpenApiDocument>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenAppResponse): OpenAppResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiDocument): OpenApiDocument {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* OpenStatus status */ 1:
-                    message.status = reader.int32();
+                case /* string title */ 1:
+                    message.title = reader.string();
                     break;
-                case /* repeated Log logs */ 2:
-                    message.logs.push(Log.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string version */ 2:
+                    message.version = reader.string();
                     break;
-                case /* string proto_dir */ 3:
-                    message.protoDir = reader.string();
+                case /* string openapi_version */ 3:
+                    message.openapiVersion = reader.string();
+                    break;
+                case /* int32 operation_count */ 4:
+                    message.operationCount = reader.int32();
+                    break;
+                case /* int32 tag_count */ 5:
+                    message.tagCount = reader.int32();
+                    break;
+                case /* repeated OpenApiServer servers */ 6:
+                    message.servers.push(OpenApiServer.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated OpenApiSecurityScheme security_schemes */ 7:
+                    message.securitySchemes.push(OpenApiSecurityScheme.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string guessed_base_url */ 8:
+                    message.guessedBaseUrl = reader.string();
+                    break;
+                case /* bool per_operation_security */ 9:
+                    message.perOperationSecurity = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: OpenApiDocument, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string title = 1; */
+        if (message.title !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.title);
+        /* string version = 2; */
+        if (message.version !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.version);
+        /* string openapi_version = 3; */
+        if (message.openapiVersion !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.openapiVersion);
+        /* int32 operation_count = 4; */
+        if (message.operationCount !== 0)
+            writer.tag(4, WireType.Varint).int32(message.operationCount);
+        /* int32 tag_count = 5; */
+        if (message.tagCount !== 0)
+            writer.tag(5, WireType.Varint).int32(message.tagCount);
+        /* repeated OpenApiServer servers = 6; */
+        for (let i = 0; i < message.servers.length; i++)
+            OpenApiServer.internalBinaryWrite(message.servers[i], writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        /* repeated OpenApiSecurityScheme security_schemes = 7; */
+        for (let i = 0; i < message.securitySchemes.length; i++)
+            OpenApiSecurityScheme.internalBinaryWrite(message.securitySchemes[i], writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* string guessed_base_url = 8; */
+        if (message.guessedBaseUrl !== "")
+            writer.tag(8, WireType.LengthDelimited).string(message.guessedBaseUrl);
+        /* bool per_operation_security = 9; */
+        if (message.perOperationSecurity !== false)
+            writer.tag(9, WireType.Varint).bool(message.perOperationSecurity);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message OpenApiDocument
+ */
+export const OpenApiDocument = new OpenApiDocument$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class OpenApiServer$Type extends MessageType<OpenApiServer> {
+    constructor() {
+        super("OpenApiServer", [
+            { no: 1, name: "url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "variables", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => OpenApiServerVariable }
+        ]);
+    }
+    create(value?: PartialMessage<OpenApiServer>): OpenApiServer {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.url = "";
+        message.description = "";
+        message.variables = [];
+        if (value !== undefined)
+            reflectionMergePartial<OpenApiServer>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiServer): OpenApiServer {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string url */ 1:
+                    message.url = reader.string();
                     break;
-                case /* string target */ 4:
-                    message.target = reader.string();
+                case /* string description */ 2:
+                    message.description = reader.string();
                     break;
-                case /* string protocol */ 5:
-                    message.protocol = reader.string();
+                case /* repeated OpenApiServerVariable variables */ 3:
+                    message.variables.push(OpenApiServerVariable.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1612,22 +3094,16 @@ class OpenAppResponse$Type extends MessageType<OpenAppResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: OpenAppResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* OpenStatus status = 1; */
-        if (message.status !== 0)
-            writer.tag(1, WireType.Varint).int32(message.status);
-        /* repeated Log logs = 2; */
-        for (let i = 0; i < message.logs.length; i++)
-            Log.internalBinaryWrite(message.logs[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* string proto_dir = 3; */
-        if (message.protoDir !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.protoDir);
-        /* string target = 4; */
-        if (message.target !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.target);
-        /* string protocol = 5; */
-        if (message.protocol !== "")
-            writer.tag(5, WireType.LengthDelimited).string(message.protocol);
+    internalBinaryWrite(message: OpenApiServer, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string url = 1; */
+        if (message.url !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.url);
+        /* string description = 2; */
+        if (message.description !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.description);
+        /* repeated OpenApiServerVariable variables = 3; */
+        for (let i = 0; i < message.variables.length; i++)
+            OpenApiServerVariable.internalBinaryWrite(message.variables[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1635,29 +3111,45 @@ class OpenAppResponse$Type extends MessageType<OpenAppResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenAppResponse
+ * @generated MessageType for protobuf message OpenApiServer
  */
-export const OpenAppResponse = new OpenAppResponse$Type();
+export const OpenApiServer = new OpenApiServer$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class InspectGrpcRequest$Type extends MessageType<InspectGrpcRequest> {
+class OpenApiServerVariable$Type extends MessageType<OpenApiServerVariable> {
     constructor() {
-        super("InspectGrpcRequest", [
-            { no: 1, name: "grpc", kind: "message", T: () => GrpcApp }
+        super("OpenApiServerVariable", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "default_value", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "enum_values", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<InspectGrpcRequest>): InspectGrpcRequest {
+    create(value?: PartialMessage<OpenApiServerVariable>): OpenApiServerVariable {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.defaultValue = "";
+        message.enumValues = [];
+        message.description = "";
         if (value !== undefined)
-            reflectionMergePartial<InspectGrpcRequest>(this, message, value);
+            reflectionMergePartial<OpenApiServerVariable>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectGrpcRequest): InspectGrpcRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiServerVariable): OpenApiServerVariable {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* GrpcApp grpc */ 1:
-                    message.grpc = GrpcApp.internalBinaryRead(reader, reader.uint32(), options, message.grpc);
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string default_value */ 2:
+                    message.defaultValue = reader.string();
+                    break;
+                case /* repeated string enum_values */ 3:
+                    message.enumValues.push(reader.string());
+                    break;
+                case /* string description */ 4:
+                    message.description = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1670,10 +3162,19 @@ class InspectGrpcRequest$Type extends MessageType<InspectGrpcRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: InspectGrpcRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* GrpcApp grpc = 1; */
-        if (message.grpc)
-            GrpcApp.internalBinaryWrite(message.grpc, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: OpenApiServerVariable, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string default_value = 2; */
+        if (message.defaultValue !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.defaultValue);
+        /* repeated string enum_values = 3; */
+        for (let i = 0; i < message.enumValues.length; i++)
+            writer.tag(3, WireType.LengthDelimited).string(message.enumValues[i]);
+        /* string description = 4; */
+        if (message.description !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.description);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1681,33 +3182,75 @@ class InspectGrpcRequest$Type extends MessageType<InspectGrpcRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message InspectGrpcRequest
+ * @generated MessageType for protobuf message OpenApiServerVariable
  */
-export const InspectGrpcRequest = new InspectGrpcRequest$Type();
+export const OpenApiServerVariable = new OpenApiServerVariable$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class InspectGrpcResponse$Type extends MessageType<InspectGrpcResponse> {
+class OpenApiSecurityScheme$Type extends MessageType<OpenApiSecurityScheme> {
     constructor() {
-        super("InspectGrpcResponse", [
-            { no: 1, name: "server", kind: "message", T: () => GrpcServer },
-            { no: 2, name: "problem", kind: "message", T: () => GrpcProblem }
+        super("OpenApiSecurityScheme", [
+            { no: 1, name: "key", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "scheme", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "bearer_format", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "in", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "parameter_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "open_id_connect_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "operation_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 10, name: "requires_others", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<InspectGrpcResponse>): InspectGrpcResponse {
+    create(value?: PartialMessage<OpenApiSecurityScheme>): OpenApiSecurityScheme {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.key = "";
+        message.type = "";
+        message.scheme = "";
+        message.bearerFormat = "";
+        message.in = "";
+        message.parameterName = "";
+        message.openIdConnectUrl = "";
+        message.description = "";
+        message.operationCount = 0;
+        message.requiresOthers = false;
         if (value !== undefined)
-            reflectionMergePartial<InspectGrpcResponse>(this, message, value);
+            reflectionMergePartial<OpenApiSecurityScheme>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectGrpcResponse): InspectGrpcResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiSecurityScheme): OpenApiSecurityScheme {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* GrpcServer server */ 1:
-                    message.server = GrpcServer.internalBinaryRead(reader, reader.uint32(), options, message.server);
+                case /* string key */ 1:
+                    message.key = reader.string();
                     break;
-                case /* GrpcProblem problem */ 2:
-                    message.problem = GrpcProblem.internalBinaryRead(reader, reader.uint32(), options, message.problem);
+                case /* string type */ 2:
+                    message.type = reader.string();
+                    break;
+                case /* string scheme */ 3:
+                    message.scheme = reader.string();
+                    break;
+                case /* string bearer_format */ 4:
+                    message.bearerFormat = reader.string();
+                    break;
+                case /* string in */ 5:
+                    message.in = reader.string();
+                    break;
+                case /* string parameter_name */ 6:
+                    message.parameterName = reader.string();
+                    break;
+                case /* string open_id_connect_url */ 7:
+                    message.openIdConnectUrl = reader.string();
+                    break;
+                case /* string description */ 8:
+                    message.description = reader.string();
+                    break;
+                case /* int32 operation_count */ 9:
+                    message.operationCount = reader.int32();
+                    break;
+                case /* bool requires_others */ 10:
+                    message.requiresOthers = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1720,13 +3263,37 @@ class InspectGrpcResponse$Type extends MessageType<InspectGrpcResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: InspectGrpcResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* GrpcServer server = 1; */
-        if (message.server)
-            GrpcServer.internalBinaryWrite(message.server, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* GrpcProblem problem = 2; */
-        if (message.problem)
-            GrpcProblem.internalBinaryWrite(message.problem, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: OpenApiSecurityScheme, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string key = 1; */
+        if (message.key !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.key);
+        /* string type = 2; */
+        if (message.type !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.type);
+        /* string scheme = 3; */
+        if (message.scheme !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.scheme);
+        /* string bearer_format = 4; */
+        if (message.bearerFormat !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.bearerFormat);
+        /* string in = 5; */
+        if (message.in !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.in);
+        /* string parameter_name = 6; */
+        if (message.parameterName !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.parameterName);
+        /* string open_id_connect_url = 7; */
+        if (message.openIdConnectUrl !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.openIdConnectUrl);
+        /* string description = 8; */
+        if (message.description !== "")
+            writer.tag(8, WireType.LengthDelimited).string(message.description);
+        /* int32 operation_count = 9; */
+        if (message.operationCount !== 0)
+            writer.tag(9, WireType.Varint).int32(message.operationCount);
+        /* bool requires_others = 10; */
+        if (message.requiresOthers !== false)
+            writer.tag(10, WireType.Varint).bool(message.requiresOthers);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1734,70 +3301,40 @@ class InspectGrpcResponse$Type extends MessageType<InspectGrpcResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message InspectGrpcResponse
+ * @generated MessageType for protobuf message OpenApiSecurityScheme
  */
-export const InspectGrpcResponse = new InspectGrpcResponse$Type();
+export const OpenApiSecurityScheme = new OpenApiSecurityScheme$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class GrpcServer$Type extends MessageType<GrpcServer> {
+class OpenApiProblem$Type extends MessageType<OpenApiProblem> {
     constructor() {
-        super("GrpcServer", [
-            { no: 1, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "target", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "tls", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
-            { no: 4, name: "services", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => GrpcService },
-            { no: 5, name: "method_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 6, name: "reflection_version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 7, name: "file_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 8, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 9, name: "reachable", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("OpenApiProblem", [
+            { no: 1, name: "kind", kind: "enum", T: () => ["OpenApiProblemKind", OpenApiProblemKind] },
+            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "detail", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<GrpcServer>): GrpcServer {
+    create(value?: PartialMessage<OpenApiProblem>): OpenApiProblem {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.source = "";
-        message.target = "";
-        message.tls = false;
-        message.services = [];
-        message.methodCount = 0;
-        message.reflectionVersion = "";
-        message.fileCount = 0;
-        message.protoDir = "";
-        message.reachable = false;
+        message.kind = 0;
+        message.message = "";
+        message.detail = "";
         if (value !== undefined)
-            reflectionMergePartial<GrpcServer>(this, message, value);
-        return message;
-    }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcServer): GrpcServer {
-        let message = target ?? this.create(), end = reader.pos + length;
-        while (reader.pos < end) {
-            let [fieldNo, wireType] = reader.tag();
-            switch (fieldNo) {
-                case /* string source */ 1:
-                    message.source = reader.string();
-                    break;
-                case /* string target */ 2:
-                    message.target = reader.string();
-                    break;
-                case /* bool tls */ 3:
-                    message.tls = reader.bool();
-                    break;
-                case /* repeated GrpcService services */ 4:
-                    message.services.push(GrpcService.internalBinaryRead(reader, reader.uint32(), options));
-                    break;
-                case /* int32 method_count */ 5:
-                    message.methodCount = reader.int32();
-                    break;
-                case /* string reflection_version */ 6:
-                    message.reflectionVersion = reader.string();
-                    break;
-                case /* int32 file_count */ 7:
-                    message.fileCount = reader.int32();
+            reflectionMergePartial<OpenApiProblem>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiProblem): OpenApiProblem {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* OpenApiProblemKind kind */ 1:
+                    message.kind = reader.int32();
                     break;
-                case /* string proto_dir */ 8:
-                    message.protoDir = reader.string();
+                case /* string message */ 2:
+                    message.message = reader.string();
                     break;
-                case /* bool reachable */ 9:
-                    message.reachable = reader.bool();
+                case /* string detail */ 3:
+                    message.detail = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1810,34 +3347,16 @@ class GrpcServer$Type extends MessageType<GrpcServer> {
         }
         return message;
     }
-    internalBinaryWrite(message: GrpcServer, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string source = 1; */
-        if (message.source !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.source);
-        /* string target = 2; */
-        if (message.target !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.target);
-        /* bool tls = 3; */
-        if (message.tls !== false)
-            writer.tag(3, WireType.Varint).bool(message.tls);
-        /* repeated GrpcService services = 4; */
-        for (let i = 0; i < message.services.length; i++)
-            GrpcService.internalBinaryWrite(message.services[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
-        /* int32 method_count = 5; */
-        if (message.methodCount !== 0)
-            writer.tag(5, WireType.Varint).int32(message.methodCount);
-        /* string reflection_version = 6; */
-        if (message.reflectionVersion !== "")
-            writer.tag(6, WireType.LengthDelimited).string(message.reflectionVersion);
-        /* int32 file_count = 7; */
-        if (message.fileCount !== 0)
-            writer.tag(7, WireType.Varint).int32(message.fileCount);
-        /* string proto_dir = 8; */
-        if (message.protoDir !== "")
-            writer.tag(8, WireType.LengthDelimited).string(message.protoDir);
-        /* bool reachable = 9; */
-        if (message.reachable !== false)
-            writer.tag(9, WireType.Varint).bool(message.reachable);
+    internalBinaryWrite(message: OpenApiProblem, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* OpenApiProblemKind kind = 1; */
+        if (message.kind !== 0)
+            writer.tag(1, WireType.Varint).int32(message.kind);
+        /* string message = 2; */
+        if (message.message !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.message);
+        /* string detail = 3; */
+        if (message.detail !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.detail);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1845,40 +3364,29 @@ class GrpcServer$Type extends MessageType<GrpcServer> {
     }
 }
 /**
- * @generated MessageType for protobuf message GrpcServer
+ * @generated MessageType for protobuf message OpenApiProblem
  */
-export const GrpcServer = new GrpcServer$Type();
+export const OpenApiProblem = new OpenApiProblem$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class GrpcService$Type extends MessageType<GrpcService> {
+class InspectMcpRequest$Type extends MessageType<InspectMcpRequest> {
     constructor() {
-        super("GrpcService", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "method_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 3, name: "streaming_method_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        super("InspectMcpRequest", [
+            { no: 1, name: "mcp", kind: "message", T: () => McpApp }
         ]);
     }
-    create(value?: PartialMessage<GrpcService>): GrpcService {
+    create(value?: PartialMessage<InspectMcpRequest>): InspectMcpRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.name = "";
-        message.methodCount = 0;
-        message.streamingMethodCount = 0;
         if (value !== undefined)
-            reflectionMergePartial<GrpcService>(this, message, value);
+            reflectionMergePartial<InspectMcpRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcService): GrpcService {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectMcpRequest): InspectMcpRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
-                    break;
-                case /* int32 method_count */ 2:
-                    message.methodCount = reader.int32();
-                    break;
-                case /* int32 streaming_method_count */ 3:
-                    message.streamingMethodCount = reader.int32();
+                case /* McpApp mcp */ 1:
+                    message.mcp = McpApp.internalBinaryRead(reader, reader.uint32(), options, message.mcp);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1891,16 +3399,10 @@ class GrpcService$Type extends MessageType<GrpcService> {
         }
         return message;
     }
-    internalBinaryWrite(message: GrpcService, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* int32 method_count = 2; */
-        if (message.methodCount !== 0)
-            writer.tag(2, WireType.Varint).int32(message.methodCount);
-        /* int32 streaming_method_count = 3; */
-        if (message.streamingMethodCount !== 0)
-            writer.tag(3, WireType.Varint).int32(message.streamingMethodCount);
+    internalBinaryWrite(message: InspectMcpRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* McpApp mcp = 1; */
+        if (message.mcp)
+            McpApp.internalBinaryWrite(message.mcp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1908,40 +3410,33 @@ class GrpcService$Type extends MessageType<GrpcService> {
     }
 }
 /**
- * @generated MessageType for protobuf message GrpcService
+ * @generated MessageType for protobuf message InspectMcpRequest
  */
-export const GrpcService = new GrpcService$Type();
+export const InspectMcpRequest = new InspectMcpRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class GrpcProblem$Type extends MessageType<GrpcProblem> {
+class InspectMcpResponse$Type extends MessageType<InspectMcpResponse> {
     constructor() {
-        super("GrpcProblem", [
-            { no: 1, name: "kind", kind: "enum", T: () => ["GrpcProblemKind", GrpcProblemKind] },
-            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "detail", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("InspectMcpResponse", [
+            { no: 1, name: "server", kind: "message", T: () => McpServer },
+            { no: 2, name: "problem", kind: "message", T: () => McpProblem }
         ]);
     }
-    create(value?: PartialMessage<GrpcProblem>): GrpcProblem {
+    create(value?: PartialMessage<InspectMcpResponse>): InspectMcpResponse {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.kind = 0;
-        message.message = "";
-        message.detail = "";
         if (value !== undefined)
-            reflectionMergePartial<GrpcProblem>(this, message, value);
+            reflectionMergePartial<InspectMcpResponse>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcProblem): GrpcProblem {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectMcpResponse): InspectMcpResponse {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* GrpcProblemKind kind */ 1:
-                    message.kind = reader.int32();
-                    break;
-                case /* string message */ 2:
-                    message.message = reader.string();
+                case /* McpServer server */ 1:
+                    message.server = McpServer.internalBinaryRead(reader, reader.uint32(), options, message.server);
                     break;
-                case /* string detail */ 3:
-                    message.detail = reader.string();
+                case /* McpProblem problem */ 2:
+                    message.problem = McpProblem.internalBinaryRead(reader, reader.uint32(), options, message.problem);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1954,16 +3449,13 @@ class GrpcProblem$Type extends MessageType<GrpcProblem> {
         }
         return message;
     }
-    internalBinaryWrite(message: GrpcProblem, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* GrpcProblemKind kind = 1; */
-        if (message.kind !== 0)
-            writer.tag(1, WireType.Varint).int32(message.kind);
-        /* string message = 2; */
-        if (message.message !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.message);
-        /* string detail = 3; */
-        if (message.detail !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.detail);
+    internalBinaryWrite(message: InspectMcpResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* McpServer server = 1; */
+        if (message.server)
+            McpServer.internalBinaryWrite(message.server, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* McpProblem problem = 2; */
+        if (message.problem)
+            McpProblem.internalBinaryWrite(message.problem, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1971,29 +3463,80 @@ class GrpcProblem$Type extends MessageType<GrpcProblem> {
     }
 }
 /**
- * @generated MessageType for protobuf message GrpcProblem
+ * @generated MessageType for protobuf message InspectMcpResponse
  */
-export const GrpcProblem = new GrpcProblem$Type();
+export const InspectMcpResponse = new InspectMcpResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class InspectOpenApiRequest$Type extends MessageType<InspectOpenApiRequest> {
+class McpServer$Type extends MessageType<McpServer> {
     constructor() {
-        super("InspectOpenApiRequest", [
-            { no: 1, name: "openapi", kind: "message", T: () => OpenApiApp }
+        super("McpServer", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "protocol_version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "handshake", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 5, name: "supported_versions", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "tool_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 7, name: "resource_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 8, name: "resource_template_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 9, name: "prompt_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 10, name: "tools", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => McpTool },
+            { no: 11, name: "instructions", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<InspectOpenApiRequest>): InspectOpenApiRequest {
+    create(value?: PartialMessage<McpServer>): McpServer {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.version = "";
+        message.protocolVersion = "";
+        message.handshake = false;
+        message.supportedVersions = [];
+        message.toolCount = 0;
+        message.resourceCount = 0;
+        message.resourceTemplateCount = 0;
+        message.promptCount = 0;
+        message.tools = [];
+        message.instructions = "";
         if (value !== undefined)
-            reflectionMergePartial<InspectOpenApiRequest>(this, message, value);
+            reflectionMergePartial<McpServer>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectOpenApiRequest): InspectOpenApiRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: McpServer): McpServer {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* OpenApiApp openapi */ 1:
-                    message.openapi = OpenApiApp.internalBinaryRead(reader, reader.uint32(), options, message.openapi);
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string version */ 2:
+                    message.version = reader.string();
+                    break;
+                case /* string protocol_version */ 3:
+                    message.protocolVersion = reader.string();
+                    break;
+                case /* bool handshake */ 4:
+                    message.handshake = reader.bool();
+                    break;
+                case /* repeated string supported_versions */ 5:
+                    message.supportedVersions.push(reader.string());
+                    break;
+                case /* int32 tool_count */ 6:
+                    message.toolCount = reader.int32();
+                    break;
+                case /* int32 resource_count */ 7:
+                    message.resourceCount = reader.int32();
+                    break;
+                case /* int32 resource_template_count */ 8:
+                    message.resourceTemplateCount = reader.int32();
+                    break;
+                case /* int32 prompt_count */ 9:
+                    message.promptCount = reader.int32();
+                    break;
+                case /* repeated McpTool tools */ 10:
+                    message.tools.push(McpTool.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string instructions */ 11:
+                    message.instructions = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2006,10 +3549,40 @@ class InspectOpenApiRequest$Type extends MessageType<InspectOpenApiRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: InspectOpenApiRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* OpenApiApp openapi = 1; */
-        if (message.openapi)
-            OpenApiApp.internalBinaryWrite(message.openapi, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: McpServer, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string version = 2; */
+        if (message.version !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.version);
+        /* string protocol_version = 3; */
+        if (message.protocolVersion !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.protocolVersion);
+        /* bool handshake = 4; */
+        if (message.handshake !== false)
+            writer.tag(4, WireType.Varint).bool(message.handshake);
+        /* repeated string supported_versions = 5; */
+        for (let i = 0; i < message.supportedVersions.length; i++)
+            writer.tag(5, WireType.LengthDelimited).string(message.supportedVersions[i]);
+        /* int32 tool_count = 6; */
+        if (message.toolCount !== 0)
+            writer.tag(6, WireType.Varint).int32(message.toolCount);
+        /* int32 resource_count = 7; */
+        if (message.resourceCount !== 0)
+            writer.tag(7, WireType.Varint).int32(message.resourceCount);
+        /* int32 resource_template_count = 8; */
+        if (message.resourceTemplateCount !== 0)
+            writer.tag(8, WireType.Varint).int32(message.resourceTemplateCount);
+        /* int32 prompt_count = 9; */
+        if (message.promptCount !== 0)
+            writer.tag(9, WireType.Varint).int32(message.promptCount);
+        /* repeated McpTool tools = 10; */
+        for (let i = 0; i < message.tools.length; i++)
+            McpTool.internalBinaryWrite(message.tools[i], writer.tag(10, WireType.LengthDelimited).fork(), options).join();
+        /* string instructions = 11; */
+        if (message.instructions !== "")
+            writer.tag(11, WireType.LengthDelimited).string(message.instructions);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2017,33 +3590,45 @@ class InspectOpenApiRequest$Type extends MessageType<InspectOpenApiRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message InspectOpenApiRequest
+ * @generated MessageType for protobuf message McpServer
  */
-export const InspectOpenApiRequest = new InspectOpenApiRequest$Type();
+export const McpServer = new McpServer$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class InspectOpenApiResponse$Type extends MessageType<InspectOpenApiResponse> {
+class McpTool$Type extends MessageType<McpTool> {
     constructor() {
-        super("InspectOpenApiResponse", [
-            { no: 1, name: "document", kind: "message", T: () => OpenApiDocument },
-            { no: 2, name: "problem", kind: "message", T: () => OpenApiProblem }
+        super("McpTool", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "title", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "read_only", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<InspectOpenApiResponse>): InspectOpenApiResponse {
+    create(value?: PartialMessage<McpTool>): McpTool {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.title = "";
+        message.description = "";
+        message.readOnly = false;
         if (value !== undefined)
-            reflectionMergePartial<InspectOpenApiResponse>(this, message, value);
+            reflectionMergePartial<McpTool>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectOpenApiResponse): InspectOpenApiResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: McpTool): McpTool {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* OpenApiDocument document */ 1:
-                    message.document = OpenApiDocument.internalBinaryRead(reader, reader.uint32(), options, message.document);
+                case /* string name */ 1:
+                    message.name = reader.string();
                     break;
-                case /* OpenApiProblem problem */ 2:
-                    message.problem = OpenApiProblem.internalBinaryRead(reader, reader.uint32(), options, message.problem);
+                case /* string title */ 2:
+                    message.title = reader.string();
+                    break;
+                case /* string description */ 3:
+                    message.description = reader.string();
+                    break;
+                case /* bool read_only */ 4:
+                    message.readOnly = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2056,13 +3641,19 @@ class InspectOpenApiResponse$Type extends MessageType<InspectOpenApiResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: InspectOpenApiResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* OpenApiDocument document = 1; */
-        if (message.document)
-            OpenApiDocument.internalBinaryWrite(message.document, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* OpenApiProblem problem = 2; */
-        if (message.problem)
-            OpenApiProblem.internalBinaryWrite(message.problem, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: McpTool, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string title = 2; */
+        if (message.title !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.title);
+        /* string description = 3; */
+        if (message.description !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.description);
+        /* bool read_only = 4; */
+        if (message.readOnly !== false)
+            writer.tag(4, WireType.Varint).bool(message.readOnly);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2070,70 +3661,50 @@ class InspectOpenApiResponse$Type extends MessageType<InspectOpenApiResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message InspectOpenApiResponse
+ * @generated MessageType for protobuf message McpTool
  */
-export const InspectOpenApiResponse = new InspectOpenApiResponse$Type();
+export const McpTool = new McpTool$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenApiDocument$Type extends MessageType<OpenApiDocument> {
+class McpProblem$Type extends MessageType<McpProblem> {
     constructor() {
-        super("OpenApiDocument", [
-            { no: 1, name: "title", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "openapi_version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "operation_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 5, name: "tag_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 6, name: "servers", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => OpenApiServer },
-            { no: 7, name: "security_schemes", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => OpenApiSecurityScheme },
-            { no: 8, name: "guessed_base_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 9, name: "per_operation_security", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("McpProblem", [
+            { no: 1, name: "kind", kind: "enum", T: () => ["McpProblemKind", McpProblemKind] },
+            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "detail", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "authorization_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "authorization_server", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<OpenApiDocument>): OpenApiDocument {
+    create(value?: PartialMessage<McpProblem>): McpProblem {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.title = "";
-        message.version = "";
-        message.openapiVersion = "";
-        message.operationCount = 0;
-        message.tagCount = 0;
-        message.servers = [];
-        message.securitySchemes = [];
-        message.guessedBaseUrl = "";
-        message.perOperationSecurity = false;
+        message.kind = 0;
+        message.message = "";
+        message.detail = "";
+        message.authorizationUrl = "";
+        message.authorizationServer = "";
         if (value !== undefined)
-            reflectionMergePartial<OpenApiDocument>(this, message, value);
+            reflectionMergePartial<McpProblem>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiDocument): OpenApiDocument {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: McpProblem): McpProblem {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string title */ 1:
-                    message.title = reader.string();
-                    break;
-                case /* string version */ 2:
-                    message.version = reader.string();
-                    break;
-                case /* string openapi_version */ 3:
-                    message.openapiVersion = reader.string();
-                    break;
-                case /* int32 operation_count */ 4:
-                    message.operationCount = reader.int32();
-                    break;
-                case /* int32 tag_count */ 5:
-                    message.tagCount = reader.int32();
+                case /* McpProblemKind kind */ 1:
+                    message.kind = reader.int32();
                     break;
-                case /* repeated OpenApiServer servers */ 6:
-                    message.servers.push(OpenApiServer.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string message */ 2:
+                    message.message = reader.string();
                     break;
-                case /* repeated OpenApiSecurityScheme security_schemes */ 7:
-                    message.securitySchemes.push(OpenApiSecurityScheme.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string detail */ 3:
+                    message.detail = reader.string();
                     break;
-                case /* string guessed_base_url */ 8:
-                    message.guessedBaseUrl = reader.string();
+                case /* string authorization_url */ 4:
+                    message.authorizationUrl = reader.string();
                     break;
-                case /* bool per_operation_security */ 9:
-                    message.perOperationSecurity = reader.bool();
+                case /* string authorization_server */ 5:
+                    message.authorizationServer = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2146,34 +3717,22 @@ class OpenApiDocument$Type extends MessageType<OpenApiDocument> {
         }
         return message;
     }
-    internalBinaryWrite(message: OpenApiDocument, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string title = 1; */
-        if (message.title !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.title);
-        /* string version = 2; */
-        if (message.version !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.version);
-        /* string openapi_version = 3; */
-        if (message.openapiVersion !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.openapiVersion);
-        /* int32 operation_count = 4; */
-        if (message.operationCount !== 0)
-            writer.tag(4, WireType.Varint).int32(message.operationCount);
-        /* int32 tag_count = 5; */
-        if (message.tagCount !== 0)
-            writer.tag(5, WireType.Varint).int32(message.tagCount);
-        /* repeated OpenApiServer servers = 6; */
-        for (let i = 0; i < message.servers.length; i++)
-            OpenApiServer.internalBinaryWrite(message.servers[i], writer.tag(6, WireType.LengthDelimited).fork(), options).join();
-        /* repeated OpenApiSecurityScheme security_schemes = 7; */
-        for (let i = 0; i < message.securitySchemes.length; i++)
-            OpenApiSecurityScheme.internalBinaryWrite(message.securitySchemes[i], writer.tag(7, WireType.LengthDelimited).fork(), options).join();
-        /* string guessed_base_url = 8; */
-        if (message.guessedBaseUrl !== "")
-            writer.tag(8, WireType.LengthDelimited).string(message.guessedBaseUrl);
-        /* bool per_operation_security = 9; */
-        if (message.perOperationSecurity !== false)
-            writer.tag(9, WireType.Varint).bool(message.perOperationSecurity);
+    internalBinaryWrite(message: McpProblem, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* McpProblemKind kind = 1; */
+        if (message.kind !== 0)
+            writer.tag(1, WireType.Varint).int32(message.kind);
+        /* string message = 2; */
+        if (message.message !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.message);
+        /* string detail = 3; */
+        if (message.detail !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.detail);
+        /* string authorization_url = 4; */
+        if (message.authorizationUrl !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.authorizationUrl);
+        /* string authorization_server = 5; */
+        if (message.authorizationServer !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.authorizationServer);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2181,40 +3740,45 @@ class OpenApiDocument$Type extends MessageType<OpenApiDocument> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenApiDocument
+ * @generated MessageType for protobuf message McpProblem
  */
-export const OpenApiDocument = new OpenApiDocument$Type();
+export const McpProblem = new McpProblem$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenApiServer$Type extends MessageType<OpenApiServer> {
+class CompileResponse$Type extends MessageType<CompileResponse> {
     constructor() {
-        super("OpenApiServer", [
-            { no: 1, name: "url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "variables", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => OpenApiServerVariable }
+        super("CompileResponse", [
+            { no: 1, name: "status", kind: "enum", T: () => ["CompileStatus", CompileStatus] },
+            { no: 2, name: "logs", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Log },
+            { no: 3, name: "sources", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Source },
+            { no: 4, name: "stub", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<OpenApiServer>): OpenApiServer {
+    create(value?: PartialMessage<CompileResponse>): CompileResponse {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.url = "";
-        message.description = "";
-        message.variables = [];
+        message.status = 0;
+        message.logs = [];
+        message.sources = [];
+        message.stub = "";
         if (value !== undefined)
-            reflectionMergePartial<OpenApiServer>(this, message, value);
+            reflectionMergePartial<CompileResponse>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiServer): OpenApiServer {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CompileResponse): CompileResponse {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string url */ 1:
-                    message.url = reader.string();
+                case /* CompileStatus status */ 1:
+                    message.status = reader.int32();
                     break;
-                case /* string description */ 2:
-                    message.description = reader.string();
+                case /* repeated Log logs */ 2:
+                    message.logs.push(Log.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* repeated OpenApiServerVariable variables */ 3:
-                    message.variables.push(OpenApiServerVariable.internalBinaryRead(reader, reader.uint32(), options));
+                case /* repeated Source sources */ 3:
+                    message.sources.push(Source.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string stub */ 4:
+                    message.stub = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2227,16 +3791,19 @@ class OpenApiServer$Type extends MessageType<OpenApiServer> {
         }
         return message;
     }
-    internalBinaryWrite(message: OpenApiServer, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string url = 1; */
-        if (message.url !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.url);
-        /* string description = 2; */
-        if (message.description !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.description);
-        /* repeated OpenApiServerVariable variables = 3; */
-        for (let i = 0; i < message.variables.length; i++)
-            OpenApiServerVariable.internalBinaryWrite(message.variables[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: CompileResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* CompileStatus status = 1; */
+        if (message.status !== 0)
+            writer.tag(1, WireType.Varint).int32(message.status);
+        /* repeated Log logs = 2; */
+        for (let i = 0; i < message.logs.length; i++)
+            Log.internalBinaryWrite(message.logs[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* repeated Source sources = 3; */
+        for (let i = 0; i < message.sources.length; i++)
+            Source.internalBinaryWrite(message.sources[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* string stub = 4; */
+        if (message.stub !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.stub);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2244,45 +3811,35 @@ class OpenApiServer$Type extends MessageType<OpenApiServer> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenApiServer
+ * @generated MessageType for protobuf message CompileResponse
  */
-export const OpenApiServer = new OpenApiServer$Type();
+export const CompileResponse = new CompileResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenApiServerVariable$Type extends MessageType<OpenApiServerVariable> {
+class Log$Type extends MessageType<Log> {
     constructor() {
-        super("OpenApiServerVariable", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "default_value", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "enum_values", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("Log", [
+            { no: 1, name: "level", kind: "enum", T: () => ["LogLevel", LogLevel] },
+            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<OpenApiServerVariable>): OpenApiServerVariable {
+    create(value?: PartialMessage<Log>): Log {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.name = "";
-        message.defaultValue = "";
-        message.enumValues = [];
-        message.description = "";
+        message.level = 0;
+        message.message = "";
         if (value !== undefined)
-            reflectionMergePartial<OpenApiServerVariable>(this, message, value);
+            reflectionMergePartial<Log>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiServerVariable): OpenApiServerVariable {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Log): Log {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
-                    break;
-                case /* string default_value */ 2:
-                    message.defaultValue = reader.string();
-                    break;
-                case /* repeated string enum_values */ 3:
-                    message.enumValues.push(reader.string());
+                case /* LogLevel level */ 1:
+                    message.level = reader.int32();
                     break;
-                case /* string description */ 4:
-                    message.description = reader.string();
+                case /* string message */ 2:
+                    message.message = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2295,19 +3852,13 @@ class OpenApiServerVariable$Type extends MessageType<OpenApiServerVariable> {
         }
         return message;
     }
-    internalBinaryWrite(message: OpenApiServerVariable, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* string default_value = 2; */
-        if (message.defaultValue !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.defaultValue);
-        /* repeated string enum_values = 3; */
-        for (let i = 0; i < message.enumValues.length; i++)
-            writer.tag(3, WireType.LengthDelimited).string(message.enumValues[i]);
-        /* string description = 4; */
-        if (message.description !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.description);
+    internalBinaryWrite(message: Log, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* LogLevel level = 1; */
+        if (message.level !== 0)
+            writer.tag(1, WireType.Varint).int32(message.level);
+        /* string message = 2; */
+        if (message.message !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.message);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2315,75 +3866,35 @@ class OpenApiServerVariable$Type extends MessageType<OpenApiServerVariable> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenApiServerVariable
+ * @generated MessageType for protobuf message Log
  */
-export const OpenApiServerVariable = new OpenApiServerVariable$Type();
+export const Log = new Log$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenApiSecurityScheme$Type extends MessageType<OpenApiSecurityScheme> {
+class Source$Type extends MessageType<Source> {
     constructor() {
-        super("OpenApiSecurityScheme", [
-            { no: 1, name: "key", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "scheme", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "bearer_format", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "in", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "parameter_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 7, name: "open_id_connect_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 8, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 9, name: "operation_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 10, name: "requires_others", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("Source", [
+            { no: 1, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<OpenApiSecurityScheme>): OpenApiSecurityScheme {
+    create(value?: PartialMessage<Source>): Source {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.key = "";
-        message.type = "";
-        message.scheme = "";
-        message.bearerFormat = "";
-        message.in = "";
-        message.parameterName = "";
-        message.openIdConnectUrl = "";
-        message.description = "";
-        message.operationCount = 0;
-        message.requiresOthers = false;
+        message.path = "";
+        message.content = "";
         if (value !== undefined)
-            reflectionMergePartial<OpenApiSecurityScheme>(this, message, value);
+            reflectionMergePartial<Source>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiSecurityScheme): OpenApiSecurityScheme {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Source): Source {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string key */ 1:
-                    message.key = reader.string();
-                    break;
-                case /* string type */ 2:
-                    message.type = reader.string();
-                    break;
-                case /* string scheme */ 3:
-                    message.scheme = reader.string();
-                    break;
-                case /* string bearer_format */ 4:
-                    message.bearerFormat = reader.string();
-                    break;
-                case /* string in */ 5:
-                    message.in = reader.string();
-                    break;
-                case /* string parameter_name */ 6:
-                    message.parameterName = reader.string();
-                    break;
-                case /* string open_id_connect_url */ 7:
-                    message.openIdConnectUrl = reader.string();
-                    break;
-                case /* string description */ 8:
-                    message.description = reader.string();
-                    break;
-                case /* int32 operation_count */ 9:
-                    message.operationCount = reader.int32();
+                case /* string path */ 1:
+                    message.path = reader.string();
                     break;
-                case /* bool requires_others */ 10:
-                    message.requiresOthers = reader.bool();
+                case /* string content */ 2:
+                    message.content = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2396,37 +3907,13 @@ class OpenApiSecurityScheme$Type extends MessageType<OpenApiSecurityScheme> {
         }
         return message;
     }
-    internalBinaryWrite(message: OpenApiSecurityScheme, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string key = 1; */
-        if (message.key !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.key);
-        /* string type = 2; */
-        if (message.type !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.type);
-        /* string scheme = 3; */
-        if (message.scheme !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.scheme);
-        /* string bearer_format = 4; */
-        if (message.bearerFormat !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.bearerFormat);
-        /* string in = 5; */
-        if (message.in !== "")
-            writer.tag(5, WireType.LengthDelimited).string(message.in);
-        /* string parameter_name = 6; */
-        if (message.parameterName !== "")
-            writer.tag(6, WireType.LengthDelimited).string(message.parameterName);
-        /* string open_id_connect_url = 7; */
-        if (message.openIdConnectUrl !== "")
-            writer.tag(7, WireType.LengthDelimited).string(message.openIdConnectUrl);
-        /* string description = 8; */
-        if (message.description !== "")
-            writer.tag(8, WireType.LengthDelimited).string(message.description);
-        /* int32 operation_count = 9; */
-        if (message.operationCount !== 0)
-            writer.tag(9, WireType.Varint).int32(message.operationCount);
-        /* bool requires_others = 10; */
-        if (message.requiresOthers !== false)
-            writer.tag(10, WireType.Varint).bool(message.requiresOthers);
+    internalBinaryWrite(message: Source, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string path = 1; */
+        if (message.path !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.path);
+        /* string content = 2; */
+        if (message.content !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.content);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2434,41 +3921,25 @@ class OpenApiSecurityScheme$Type extends MessageType<OpenApiSecurityScheme> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenApiSecurityScheme
+ * @generated MessageType for protobuf message Source
  */
-export const OpenApiSecurityScheme = new OpenApiSecurityScheme$Type();
+export const Source = new Source$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenApiProblem$Type extends MessageType<OpenApiProblem> {
+class GetConfigurationRequest$Type extends MessageType<GetConfigurationRequest> {
     constructor() {
-        super("OpenApiProblem", [
-            { no: 1, name: "kind", kind: "enum", T: () => ["OpenApiProblemKind", OpenApiProblemKind] },
-            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "detail", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
-        ]);
+        super("GetConfigurationRequest", []);
     }
-    create(value?: PartialMessage<OpenApiProblem>): OpenApiProblem {
+    create(value?: PartialMessage<GetConfigurationRequest>): GetConfigurationRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.kind = 0;
-        message.message = "";
-        message.detail = "";
         if (value !== undefined)
-            reflectionMergePartial<OpenApiProblem>(this, message, value);
+            reflectionMergePartial<GetConfigurationRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiProblem): OpenApiProblem {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetConfigurationRequest): GetConfigurationRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* OpenApiProblemKind kind */ 1:
-                    message.kind = reader.int32();
-                    break;
-                case /* string message */ 2:
-                    message.message = reader.string();
-                    break;
-                case /* string detail */ 3:
-                    message.detail = reader.string();
-                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2480,16 +3951,7 @@ class OpenApiProblem$Type extends MessageType<OpenApiProblem> {
         }
         return message;
     }
-    internalBinaryWrite(message: OpenApiProblem, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* OpenApiProblemKind kind = 1; */
-        if (message.kind !== 0)
-            writer.tag(1, WireType.Varint).int32(message.kind);
-        /* string message = 2; */
-        if (message.message !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.message);
-        /* string detail = 3; */
-        if (message.detail !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.detail);
+    internalBinaryWrite(message: GetConfigurationRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2497,29 +3959,43 @@ class OpenApiProblem$Type extends MessageType<OpenApiProblem> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenApiProblem
+ * @generated MessageType for protobuf message GetConfigurationRequest
  */
-export const OpenApiProblem = new OpenApiProblem$Type();
+export const GetConfigurationRequest = new GetConfigurationRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class InspectMcpRequest$Type extends MessageType<InspectMcpRequest> {
+class GetConfigurationResponse$Type extends MessageType<GetConfigurationResponse> {
     constructor() {
-        super("InspectMcpRequest", [
-            { no: 1, name: "mcp", kind: "message", T: () => McpApp }
+        super("GetConfigurationResponse", [
+            { no: 1, name: "configuration", kind: "message", T: () => Configuration },
+            { no: 2, name: "logs", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Log },
+            { no: 3, name: "variable_status", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => VariableStatus },
+            { no: 4, name: "runtime", kind: "message", T: () => Runtime }
         ]);
     }
-    create(value?: PartialMessage<InspectMcpRequest>): InspectMcpRequest {
+    create(value?: PartialMessage<GetConfigurationResponse>): GetConfigurationResponse {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.logs = [];
+        message.variableStatus = [];
         if (value !== undefined)
-            reflectionMergePartial<InspectMcpRequest>(this, message, value);
+            reflectionMergePartial<GetConfigurationResponse>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectMcpRequest): InspectMcpRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetConfigurationResponse): GetConfigurationResponse {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* McpApp mcp */ 1:
-                    message.mcp = McpApp.internalBinaryRead(reader, reader.uint32(), options, message.mcp);
+                case /* Configuration configuration */ 1:
+                    message.configuration = Configuration.internalBinaryRead(reader, reader.uint32(), options, message.configuration);
+                    break;
+                case /* repeated Log logs */ 2:
+                    message.logs.push(Log.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated VariableStatus variable_status */ 3:
+                    message.variableStatus.push(VariableStatus.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* Runtime runtime */ 4:
+                    message.runtime = Runtime.internalBinaryRead(reader, reader.uint32(), options, message.runtime);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2532,10 +4008,19 @@ class InspectMcpRequest$Type extends MessageType<InspectMcpRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: InspectMcpRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* McpApp mcp = 1; */
-        if (message.mcp)
-            McpApp.internalBinaryWrite(message.mcp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: GetConfigurationResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* Configuration configuration = 1; */
+        if (message.configuration)
+            Configuration.internalBinaryWrite(message.configuration, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated Log logs = 2; */
+        for (let i = 0; i < message.logs.length; i++)
+            Log.internalBinaryWrite(message.logs[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* repeated VariableStatus variable_status = 3; */
+        for (let i = 0; i < message.variableStatus.length; i++)
+            VariableStatus.internalBinaryWrite(message.variableStatus[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* Runtime runtime = 4; */
+        if (message.runtime)
+            Runtime.internalBinaryWrite(message.runtime, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2543,33 +4028,45 @@ class InspectMcpRequest$Type extends MessageType<InspectMcpRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message InspectMcpRequest
+ * @generated MessageType for protobuf message GetConfigurationResponse
  */
-export const InspectMcpRequest = new InspectMcpRequest$Type();
+export const GetConfigurationResponse = new GetConfigurationResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class InspectMcpResponse$Type extends MessageType<InspectMcpResponse> {
+class Runtime$Type extends MessageType<Runtime> {
     constructor() {
-        super("InspectMcpResponse", [
-            { no: 1, name: "server", kind: "message", T: () => McpServer },
-            { no: 2, name: "problem", kind: "message", T: () => McpProblem }
+        super("Runtime", [
+            { no: 1, name: "can_update_configuration", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 2, name: "git_ref", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "build_number", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "variable_store_available", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<InspectMcpResponse>): InspectMcpResponse {
+    create(value?: PartialMessage<Runtime>): Runtime {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.canUpdateConfiguration = false;
+        message.gitRef = "";
+        message.buildNumber = "";
+        message.variableStoreAvailable = false;
         if (value !== undefined)
-            reflectionMergePartial<InspectMcpResponse>(this, message, value);
+            reflectionMergePartial<Runtime>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: InspectMcpResponse): InspectMcpResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Runtime): Runtime {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* McpServer server */ 1:
-                    message.server = McpServer.internalBinaryRead(reader, reader.uint32(), options, message.server);
+                case /* bool can_update_configuration */ 1:
+                    message.canUpdateConfiguration = reader.bool();
                     break;
-                case /* McpProblem problem */ 2:
-                    message.problem = McpProblem.internalBinaryRead(reader, reader.uint32(), options, message.problem);
+                case /* string git_ref */ 2:
+                    message.gitRef = reader.string();
+                    break;
+                case /* string build_number */ 3:
+                    message.buildNumber = reader.string();
+                    break;
+                case /* bool variable_store_available */ 4:
+                    message.variableStoreAvailable = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2582,13 +4079,19 @@ class InspectMcpResponse$Type extends MessageType<InspectMcpResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: InspectMcpResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* McpServer server = 1; */
-        if (message.server)
-            McpServer.internalBinaryWrite(message.server, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* McpProblem problem = 2; */
-        if (message.problem)
-            McpProblem.internalBinaryWrite(message.problem, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Runtime, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bool can_update_configuration = 1; */
+        if (message.canUpdateConfiguration !== false)
+            writer.tag(1, WireType.Varint).bool(message.canUpdateConfiguration);
+        /* string git_ref = 2; */
+        if (message.gitRef !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.gitRef);
+        /* string build_number = 3; */
+        if (message.buildNumber !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.buildNumber);
+        /* bool variable_store_available = 4; */
+        if (message.variableStoreAvailable !== false)
+            writer.tag(4, WireType.Varint).bool(message.variableStoreAvailable);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2596,44 +4099,28 @@ class InspectMcpResponse$Type extends MessageType<InspectMcpResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message InspectMcpResponse
+ * @generated MessageType for protobuf message Runtime
  */
-export const InspectMcpResponse = new InspectMcpResponse$Type();
+export const Runtime = new Runtime$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class McpServer$Type extends MessageType<McpServer> {
+class VariableStatus$Type extends MessageType<VariableStatus> {
     constructor() {
-        super("McpServer", [
+        super("VariableStatus", [
             { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "protocol_version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "handshake", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
-            { no: 5, name: "supported_versions", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "tool_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 7, name: "resource_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 8, name: "resource_template_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 9, name: "prompt_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 10, name: "tools", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => McpTool },
-            { no: 11, name: "instructions", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 2, name: "source", kind: "enum", T: () => ["VariableSource", VariableSource, "VARIABLE_SOURCE_"] },
+            { no: 3, name: "env_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<McpServer>): McpServer {
+    create(value?: PartialMessage<VariableStatus>): VariableStatus {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.name = "";
-        message.version = "";
-        message.protocolVersion = "";
-        message.handshake = false;
-        message.supportedVersions = [];
-        message.toolCount = 0;
-        message.resourceCount = 0;
-        message.resourceTemplateCount = 0;
-        message.promptCount = 0;
-        message.tools = [];
-        message.instructions = "";
+        message.source = 0;
+        message.envName = "";
         if (value !== undefined)
-            reflectionMergePartial<McpServer>(this, message, value);
+            reflectionMergePartial<VariableStatus>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: McpServer): McpServer {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: VariableStatus): VariableStatus {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
@@ -2641,35 +4128,11 @@ class McpServer$Type extends MessageType<McpServer> {
                 case /* string name */ 1:
                     message.name = reader.string();
                     break;
-                case /* string version */ 2:
-                    message.version = reader.string();
-                    break;
-                case /* string protocol_version */ 3:
-                    message.protocolVersion = reader.string();
-                    break;
-                case /* bool handshake */ 4:
-                    message.handshake = reader.bool();
-                    break;
-                case /* repeated string supported_versions */ 5:
-                    message.supportedVersions.push(reader.string());
-                    break;
-                case /* int32 tool_count */ 6:
-                    message.toolCount = reader.int32();
-                    break;
-                case /* int32 resource_count */ 7:
-                    message.resourceCount = reader.int32();
-                    break;
-                case /* int32 resource_template_count */ 8:
-                    message.resourceTemplateCount = reader.int32();
-                    break;
-                case /* int32 prompt_count */ 9:
-                    message.promptCount = reader.int32();
-                    break;
-                case /* repeated McpTool tools */ 10:
-                    message.tools.push(McpTool.internalBinaryRead(reader, reader.uint32(), options));
+                case /* VariableSource source */ 2:
+                    message.source = reader.int32();
                     break;
-                case /* string instructions */ 11:
-                    message.instructions = reader.string();
+                case /* string env_name */ 3:
+                    message.envName = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2682,40 +4145,16 @@ class McpServer$Type extends MessageType<McpServer> {
         }
         return message;
     }
-    internalBinaryWrite(message: McpServer, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: VariableStatus, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         /* string name = 1; */
         if (message.name !== "")
             writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* string version = 2; */
-        if (message.version !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.version);
-        /* string protocol_version = 3; */
-        if (message.protocolVersion !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.protocolVersion);
-        /* bool handshake = 4; */
-        if (message.handshake !== false)
-            writer.tag(4, WireType.Varint).bool(message.handshake);
-        /* repeated string supported_versions = 5; */
-        for (let i = 0; i < message.supportedVersions.length; i++)
-            writer.tag(5, WireType.LengthDelimited).string(message.supportedVersions[i]);
-        /* int32 tool_count = 6; */
-        if (message.toolCount !== 0)
-            writer.tag(6, WireType.Varint).int32(message.toolCount);
-        /* int32 resource_count = 7; */
-        if (message.resourceCount !== 0)
-            writer.tag(7, WireType.Varint).int32(message.resourceCount);
-        /* int32 resource_template_count = 8; */
-        if (message.resourceTemplateCount !== 0)
-            writer.tag(8, WireType.Varint).int32(message.resourceTemplateCount);
-        /* int32 prompt_count = 9; */
-        if (message.promptCount !== 0)
-            writer.tag(9, WireType.Varint).int32(message.promptCount);
-        /* repeated McpTool tools = 10; */
-        for (let i = 0; i < message.tools.length; i++)
-            McpTool.internalBinaryWrite(message.tools[i], writer.tag(10, WireType.LengthDelimited).fork(), options).join();
-        /* string instructions = 11; */
-        if (message.instructions !== "")
-            writer.tag(11, WireType.LengthDelimited).string(message.instructions);
+        /* VariableSource source = 2; */
+        if (message.source !== 0)
+            writer.tag(2, WireType.Varint).int32(message.source);
+        /* string env_name = 3; */
+        if (message.envName !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.envName);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2723,30 +4162,26 @@ class McpServer$Type extends MessageType<McpServer> {
     }
 }
 /**
- * @generated MessageType for protobuf message McpServer
+ * @generated MessageType for protobuf message VariableStatus
  */
-export const McpServer = new McpServer$Type();
+export const VariableStatus = new VariableStatus$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class McpTool$Type extends MessageType<McpTool> {
+class SetStoredValueRequest$Type extends MessageType<SetStoredValueRequest> {
     constructor() {
-        super("McpTool", [
+        super("SetStoredValueRequest", [
             { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "title", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "read_only", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+            { no: 2, name: "value", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<McpTool>): McpTool {
+    create(value?: PartialMessage<SetStoredValueRequest>): SetStoredValueRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.name = "";
-        message.title = "";
-        message.description = "";
-        message.readOnly = false;
+        message.value = "";
         if (value !== undefined)
-            reflectionMergePartial<McpTool>(this, message, value);
+            reflectionMergePartial<SetStoredValueRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: McpTool): McpTool {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SetStoredValueRequest): SetStoredValueRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
@@ -2754,14 +4189,8 @@ class McpTool$Type extends MessageType<McpTool> {
                 case /* string name */ 1:
                     message.name = reader.string();
                     break;
-                case /* string title */ 2:
-                    message.title = reader.string();
-                    break;
-                case /* string description */ 3:
-                    message.description = reader.string();
-                    break;
-                case /* bool read_only */ 4:
-                    message.readOnly = reader.bool();
+                case /* string value */ 2:
+                    message.value = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2774,19 +4203,13 @@ class McpTool$Type extends MessageType<McpTool> {
         }
         return message;
     }
-    internalBinaryWrite(message: McpTool, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: SetStoredValueRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         /* string name = 1; */
         if (message.name !== "")
             writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* string title = 2; */
-        if (message.title !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.title);
-        /* string description = 3; */
-        if (message.description !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.description);
-        /* bool read_only = 4; */
-        if (message.readOnly !== false)
-            writer.tag(4, WireType.Varint).bool(message.readOnly);
+        /* string value = 2; */
+        if (message.value !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.value);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2794,40 +4217,30 @@ class McpTool$Type extends MessageType<McpTool> {
     }
 }
 /**
- * @generated MessageType for protobuf message McpTool
+ * @generated MessageType for protobuf message SetStoredValueRequest
  */
-export const McpTool = new McpTool$Type();
+export const SetStoredValueRequest = new SetStoredValueRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class McpProblem$Type extends MessageType<McpProblem> {
+class ClearStoredValueRequest$Type extends MessageType<ClearStoredValueRequest> {
     constructor() {
-        super("McpProblem", [
-            { no: 1, name: "kind", kind: "enum", T: () => ["McpProblemKind", McpProblemKind] },
-            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "detail", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("ClearStoredValueRequest", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<McpProblem>): McpProblem {
+    create(value?: PartialMessage<ClearStoredValueRequest>): ClearStoredValueRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.kind = 0;
-        message.message = "";
-        message.detail = "";
+        message.name = "";
         if (value !== undefined)
-            reflectionMergePartial<McpProblem>(this, message, value);
+            reflectionMergePartial<ClearStoredValueRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: McpProblem): McpProblem {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ClearStoredValueRequest): ClearStoredValueRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* McpProblemKind kind */ 1:
-                    message.kind = reader.int32();
-                    break;
-                case /* string message */ 2:
-                    message.message = reader.string();
-                    break;
-                case /* string detail */ 3:
-                    message.detail = reader.string();
+                case /* string name */ 1:
+                    message.name = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2840,16 +4253,10 @@ class McpProblem$Type extends MessageType<McpProblem> {
         }
         return message;
     }
-    internalBinaryWrite(message: McpProblem, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* McpProblemKind kind = 1; */
-        if (message.kind !== 0)
-            writer.tag(1, WireType.Varint).int32(message.kind);
-        /* string message = 2; */
-        if (message.message !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.message);
-        /* string detail = 3; */
-        if (message.detail !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.detail);
+    internalBinaryWrite(message: ClearStoredValueRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2857,45 +4264,30 @@ class McpProblem$Type extends MessageType<McpProblem> {
     }
 }
 /**
- * @generated MessageType for protobuf message McpProblem
+ * @generated MessageType for protobuf message ClearStoredValueRequest
  */
-export const McpProblem = new McpProblem$Type();
+export const ClearStoredValueRequest = new ClearStoredValueRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class CompileResponse$Type extends MessageType<CompileResponse> {
+class StoredValueResponse$Type extends MessageType<StoredValueResponse> {
     constructor() {
-        super("CompileResponse", [
-            { no: 1, name: "status", kind: "enum", T: () => ["CompileStatus", CompileStatus] },
-            { no: 2, name: "logs", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Log },
-            { no: 3, name: "sources", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Source },
-            { no: 4, name: "stub", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("StoredValueResponse", [
+            { no: 1, name: "variable_status", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => VariableStatus }
         ]);
     }
-    create(value?: PartialMessage<CompileResponse>): CompileResponse {
-        const message = globalThis.Object.create((this.messagePrototype!));
-        message.status = 0;
-        message.logs = [];
-        message.sources = [];
-        message.stub = "";
+    create(value?: PartialMessage<StoredValueResponse>): StoredValueResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.variableStatus = [];
         if (value !== undefined)
-            reflectionMergePartial<CompileResponse>(this, message, value);
+            reflectionMergePartial<StoredValueResponse>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CompileResponse): CompileResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: StoredValueResponse): StoredValueResponse {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* CompileStatus status */ 1:
-                    message.status = reader.int32();
-                    break;
-                case /* repeated Log logs */ 2:
-                    message.logs.push(Log.internalBinaryRead(reader, reader.uint32(), options));
-                    break;
-                case /* repeated Source sources */ 3:
-                    message.sources.push(Source.internalBinaryRead(reader, reader.uint32(), options));
-                    break;
-                case /* string stub */ 4:
-                    message.stub = reader.string();
+                case /* repeated VariableStatus variable_status */ 1:
+                    message.variableStatus.push(VariableStatus.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2908,19 +4300,10 @@ class CompileResponse$Type extends MessageType<CompileResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: CompileResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* CompileStatus status = 1; */
-        if (message.status !== 0)
-            writer.tag(1, WireType.Varint).int32(message.status);
-        /* repeated Log logs = 2; */
-        for (let i = 0; i < message.logs.length; i++)
-            Log.internalBinaryWrite(message.logs[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* repeated Source sources = 3; */
-        for (let i = 0; i < message.sources.length; i++)
-            Source.internalBinaryWrite(message.sources[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* string stub = 4; */
-        if (message.stub !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.stub);
+    internalBinaryWrite(message: StoredValueResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated VariableStatus variable_status = 1; */
+        for (let i = 0; i < message.variableStatus.length; i++)
+            VariableStatus.internalBinaryWrite(message.variableStatus[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2928,35 +4311,45 @@ class CompileResponse$Type extends MessageType<CompileResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message CompileResponse
+ * @generated MessageType for protobuf message StoredValueResponse
  */
-export const CompileResponse = new CompileResponse$Type();
+export const StoredValueResponse = new StoredValueResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Log$Type extends MessageType<Log> {
+class Script$Type extends MessageType<Script> {
     constructor() {
-        super("Log", [
-            { no: 1, name: "level", kind: "enum", T: () => ["LogLevel", LogLevel] },
-            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("Script", [
+            { no: 1, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "folder", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<Log>): Log {
+    create(value?: PartialMessage<Script>): Script {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.level = 0;
-        message.message = "";
+        message.path = "";
+        message.name = "";
+        message.content = "";
+        message.folder = "";
         if (value !== undefined)
-            reflectionMergePartial<Log>(this, message, value);
+            reflectionMergePartial<Script>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Log): Log {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Script): Script {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* LogLevel level */ 1:
-                    message.level = reader.int32();
+                case /* string path */ 1:
+                    message.path = reader.string();
                     break;
-                case /* string message */ 2:
-                    message.message = reader.string();
+                case /* string name */ 2:
+                    message.name = reader.string();
+                    break;
+                case /* string content */ 3:
+                    message.content = reader.string();
+                    break;
+                case /* string folder */ 4:
+                    message.folder = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2969,13 +4362,19 @@ class Log$Type extends MessageType<Log> {
         }
         return message;
     }
-    internalBinaryWrite(message: Log, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* LogLevel level = 1; */
-        if (message.level !== 0)
-            writer.tag(1, WireType.Varint).int32(message.level);
-        /* string message = 2; */
-        if (message.message !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.message);
+    internalBinaryWrite(message: Script, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string path = 1; */
+        if (message.path !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.path);
+        /* string name = 2; */
+        if (message.name !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.name);
+        /* string content = 3; */
+        if (message.content !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.content);
+        /* string folder = 4; */
+        if (message.folder !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.folder);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2983,36 +4382,25 @@ class Log$Type extends MessageType<Log> {
     }
 }
 /**
- * @generated MessageType for protobuf message Log
+ * @generated MessageType for protobuf message Script
  */
-export const Log = new Log$Type();
+export const Script = new Script$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Source$Type extends MessageType<Source> {
+class ListScriptsRequest$Type extends MessageType<ListScriptsRequest> {
     constructor() {
-        super("Source", [
-            { no: 1, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
-        ]);
+        super("ListScriptsRequest", []);
     }
-    create(value?: PartialMessage<Source>): Source {
+    create(value?: PartialMessage<ListScriptsRequest>): ListScriptsRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.path = "";
-        message.content = "";
         if (value !== undefined)
-            reflectionMergePartial<Source>(this, message, value);
+            reflectionMergePartial<ListScriptsRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Source): Source {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ListScriptsRequest): ListScriptsRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string path */ 1:
-                    message.path = reader.string();
-                    break;
-                case /* string content */ 2:
-                    message.content = reader.string();
-                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -3024,13 +4412,7 @@ class Source$Type extends MessageType<Source> {
         }
         return message;
     }
-    internalBinaryWrite(message: Source, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string path = 1; */
-        if (message.path !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.path);
-        /* string content = 2; */
-        if (message.content !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.content);
+    internalBinaryWrite(message: ListScriptsRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3038,25 +4420,31 @@ class Source$Type extends MessageType<Source> {
     }
 }
 /**
- * @generated MessageType for protobuf message Source
+ * @generated MessageType for protobuf message ListScriptsRequest
  */
-export const Source = new Source$Type();
+export const ListScriptsRequest = new ListScriptsRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class GetConfigurationRequest$Type extends MessageType<GetConfigurationRequest> {
+class ListScriptsResponse$Type extends MessageType<ListScriptsResponse> {
     constructor() {
-        super("GetConfigurationRequest", []);
+        super("ListScriptsResponse", [
+            { no: 1, name: "scripts", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Script }
+        ]);
     }
-    create(value?: PartialMessage<GetConfigurationRequest>): GetConfigurationRequest {
+    create(value?: PartialMessage<ListScriptsResponse>): ListScriptsResponse {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.scripts = [];
         if (value !== undefined)
-            reflectionMergePartial<GetConfigurationRequest>(this, message, value);
+            reflectionMergePartial<ListScriptsResponse>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetConfigurationRequest): GetConfigurationRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ListScriptsResponse): ListScriptsResponse {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
+                case /* repeated Script scripts */ 1:
+                    message.scripts.push(Script.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -3068,7 +4456,10 @@ class GetConfigurationRequest$Type extends MessageType<GetConfigurationRequest>
         }
         return message;
     }
-    internalBinaryWrite(message: GetConfigurationRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: ListScriptsResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated Script scripts = 1; */
+        for (let i = 0; i < message.scripts.length; i++)
+            Script.internalBinaryWrite(message.scripts[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3076,43 +4467,30 @@ class GetConfigurationRequest$Type extends MessageType<GetConfigurationRequest>
     }
 }
 /**
- * @generated MessageType for protobuf message GetConfigurationRequest
+ * @generated MessageType for protobuf message ListScriptsResponse
  */
-export const GetConfigurationRequest = new GetConfigurationRequest$Type();
+export const ListScriptsResponse = new ListScriptsResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class GetConfigurationResponse$Type extends MessageType<GetConfigurationResponse> {
+class ReadScriptRequest$Type extends MessageType<ReadScriptRequest> {
     constructor() {
-        super("GetConfigurationResponse", [
-            { no: 1, name: "configuration", kind: "message", T: () => Configuration },
-            { no: 2, name: "logs", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Log },
-            { no: 3, name: "variable_status", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => VariableStatus },
-            { no: 4, name: "runtime", kind: "message", T: () => Runtime }
+        super("ReadScriptRequest", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<GetConfigurationResponse>): GetConfigurationResponse {
+    create(value?: PartialMessage<ReadScriptRequest>): ReadScriptRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.logs = [];
-        message.variableStatus = [];
+        message.name = "";
         if (value !== undefined)
-            reflectionMergePartial<GetConfigurationResponse>(this, message, value);
+            reflectionMergePartial<ReadScriptRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetConfigurationResponse): GetConfigurationResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ReadScriptRequest): ReadScriptRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* Configuration configuration */ 1:
-                    message.configuration = Configuration.internalBinaryRead(reader, reader.uint32(), options, message.configuration);
-                    break;
-                case /* repeated Log logs */ 2:
-                    message.logs.push(Log.internalBinaryRead(reader, reader.uint32(), options));
-                    break;
-                case /* repeated VariableStatus variable_status */ 3:
-                    message.variableStatus.push(VariableStatus.internalBinaryRead(reader, reader.uint32(), options));
-                    break;
-                case /* Runtime runtime */ 4:
-                    message.runtime = Runtime.internalBinaryRead(reader, reader.uint32(), options, message.runtime);
+                case /* string name */ 1:
+                    message.name = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3125,19 +4503,10 @@ class GetConfigurationResponse$Type extends MessageType<GetConfigurationResponse
         }
         return message;
     }
-    internalBinaryWrite(message: GetConfigurationResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* Configuration configuration = 1; */
-        if (message.configuration)
-            Configuration.internalBinaryWrite(message.configuration, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* repeated Log logs = 2; */
-        for (let i = 0; i < message.logs.length; i++)
-            Log.internalBinaryWrite(message.logs[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* repeated VariableStatus variable_status = 3; */
-        for (let i = 0; i < message.variableStatus.length; i++)
-            VariableStatus.internalBinaryWrite(message.variableStatus[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* Runtime runtime = 4; */
-        if (message.runtime)
-            Runtime.internalBinaryWrite(message.runtime, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: ReadScriptRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3145,45 +4514,29 @@ class GetConfigurationResponse$Type extends MessageType<GetConfigurationResponse
     }
 }
 /**
- * @generated MessageType for protobuf message GetConfigurationResponse
+ * @generated MessageType for protobuf message ReadScriptRequest
  */
-export const GetConfigurationResponse = new GetConfigurationResponse$Type();
+export const ReadScriptRequest = new ReadScriptRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Runtime$Type extends MessageType<Runtime> {
+class ReadScriptResponse$Type extends MessageType<ReadScriptResponse> {
     constructor() {
-        super("Runtime", [
-            { no: 1, name: "can_update_configuration", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
-            { no: 2, name: "git_ref", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "build_number", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "variable_store_available", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("ReadScriptResponse", [
+            { no: 1, name: "script", kind: "message", T: () => Script }
         ]);
     }
-    create(value?: PartialMessage<Runtime>): Runtime {
+    create(value?: PartialMessage<ReadScriptResponse>): ReadScriptResponse {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.canUpdateConfiguration = false;
-        message.gitRef = "";
-        message.buildNumber = "";
-        message.variableStoreAvailable = false;
         if (value !== undefined)
-            reflectionMergePartial<Runtime>(this, message, value);
+            reflectionMergePartial<ReadScriptResponse>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Runtime): Runtime {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ReadScriptResponse): ReadScriptResponse {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* bool can_update_configuration */ 1:
-                    message.canUpdateConfiguration = reader.bool();
-                    break;
-                case /* string git_ref */ 2:
-                    message.gitRef = reader.string();
-                    break;
-                case /* string build_number */ 3:
-                    message.buildNumber = reader.string();
-                    break;
-                case /* bool variable_store_available */ 4:
-                    message.variableStoreAvailable = reader.bool();
+                case /* Script script */ 1:
+                    message.script = Script.internalBinaryRead(reader, reader.uint32(), options, message.script);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3196,19 +4549,10 @@ class Runtime$Type extends MessageType<Runtime> {
         }
         return message;
     }
-    internalBinaryWrite(message: Runtime, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* bool can_update_configuration = 1; */
-        if (message.canUpdateConfiguration !== false)
-            writer.tag(1, WireType.Varint).bool(message.canUpdateConfiguration);
-        /* string git_ref = 2; */
-        if (message.gitRef !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.gitRef);
-        /* string build_number = 3; */
-        if (message.buildNumber !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.buildNumber);
-        /* bool variable_store_available = 4; */
-        if (message.variableStoreAvailable !== false)
-            writer.tag(4, WireType.Varint).bool(message.variableStoreAvailable);
+    internalBinaryWrite(message: ReadScriptResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* Script script = 1; */
+        if (message.script)
+            Script.internalBinaryWrite(message.script, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3216,40 +4560,40 @@ class Runtime$Type extends MessageType<Runtime> {
     }
 }
 /**
- * @generated MessageType for protobuf message Runtime
+ * @generated MessageType for protobuf message ReadScriptResponse
  */
-export const Runtime = new Runtime$Type();
+export const ReadScriptResponse = new ReadScriptResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class VariableStatus$Type extends MessageType<VariableStatus> {
+class Configuration$Type extends MessageType<Configuration> {
     constructor() {
-        super("VariableStatus", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "source", kind: "enum", T: () => ["VariableSource", VariableSource, "VARIABLE_SOURCE_"] },
-            { no: 3, name: "env_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("Configuration", [
+            { no: 1, name: "path_prefix", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "apps", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => ConfigurationApp },
+            { no: 6, name: "variables", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } }
         ]);
     }
-    create(value?: PartialMessage<VariableStatus>): VariableStatus {
+    create(value?: PartialMessage<Configuration>): Configuration {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.name = "";
-        message.source = 0;
-        message.envName = "";
+        message.pathPrefix = "";
+        message.apps = [];
+        message.variables = {};
         if (value !== undefined)
-            reflectionMergePartial<VariableStatus>(this, message, value);
+            reflectionMergePartial<Configuration>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: VariableStatus): VariableStatus {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Configuration): Configuration {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
+                case /* string path_prefix */ 1:
+                    message.pathPrefix = reader.string();
                     break;
-                case /* VariableSource source */ 2:
-                    message.source = reader.int32();
+                case /* repeated ConfigurationApp apps */ 5:
+                    message.apps.push(ConfigurationApp.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* string env_name */ 3:
-                    message.envName = reader.string();
+                case /* map<string, string> variables */ 6:
+                    this.binaryReadMap6(message.variables, reader, options);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3262,16 +4606,32 @@ class VariableStatus$Type extends MessageType<VariableStatus> {
         }
         return message;
     }
-    internalBinaryWrite(message: VariableStatus, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* VariableSource source = 2; */
-        if (message.source !== 0)
-            writer.tag(2, WireType.Varint).int32(message.source);
-        /* string env_name = 3; */
-        if (message.envName !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.envName);
+    private binaryReadMap6(map: Configuration["variables"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof Configuration["variables"] | undefined, val: Configuration["variables"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for Configuration.variables");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: Configuration, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string path_prefix = 1; */
+        if (message.pathPrefix !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.pathPrefix);
+        /* repeated ConfigurationApp apps = 5; */
+        for (let i = 0; i < message.apps.length; i++)
+            ConfigurationApp.internalBinaryWrite(message.apps[i], writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* map<string, string> variables = 6; */
+        for (let k of globalThis.Object.keys(message.variables))
+            writer.tag(6, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.variables[k]).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3279,26 +4639,37 @@ class VariableStatus$Type extends MessageType<VariableStatus> {
     }
 }
 /**
- * @generated MessageType for protobuf message VariableStatus
+ * @generated MessageType for protobuf message Configuration
  */
-export const VariableStatus = new VariableStatus$Type();
+export const Configuration = new Configuration$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class SetStoredValueRequest$Type extends MessageType<SetStoredValueRequest> {
+class ConfigurationApp$Type extends MessageType<ConfigurationApp> {
     constructor() {
-        super("SetStoredValueRequest", [
+        super("ConfigurationApp", [
             { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "value", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 2, name: "grpc", kind: "message", oneof: "app", T: () => GrpcApp },
+            { no: 3, name: "twirp", kind: "message", oneof: "app", T: () => TwirpApp },
+            { no: 4, name: "openapi", kind: "message", oneof: "app", T: () => OpenApiApp },
+            { no: 5, name: "openai", kind: "message", oneof: "app", T: () => OpenAiApp },
+            { no: 7, name: "folder", kind: "message", oneof: "app", T: () => FolderApp },
+            { no: 8, name: "mcp", kind: "message", oneof: "app", T: () => McpApp },
+            { no: 9, name: "anthropic", kind: "message", oneof: "app", T: () => AnthropicApp },
+            { no: 10, name: "sqlite", kind: "message", oneof: "app", T: () => SqliteApp },
+            { no: 11, name: "graphql", kind: "message", oneof: "app", T: () => GraphqlApp },
+            { no: 12, name: "jsonrpc", kind: "message", oneof: "app", T: () => JsonrpcApp },
+            { no: 13, name: "websocket", kind: "message", oneof: "app", T: () => WebsocketApp },
+            { no: 14, name: "http", kind: "message", oneof: "app", T: () => HttpApp }
         ]);
     }
-    create(value?: PartialMessage<SetStoredValueRequest>): SetStoredValueRequest {
+    create(value?: PartialMessage<ConfigurationApp>): ConfigurationApp {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.name = "";
-        message.value = "";
+        message.app = { oneofKind: undefined };
         if (value !== undefined)
-            reflectionMergePartial<SetStoredValueRequest>(this, message, value);
+            reflectionMergePartial<ConfigurationApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SetStoredValueRequest): SetStoredValueRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ConfigurationApp): ConfigurationApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
@@ -3306,8 +4677,77 @@ class SetStoredValueRequest$Type extends MessageType<SetStoredValueRequest> {
                 case /* string name */ 1:
                     message.name = reader.string();
                     break;
-                case /* string value */ 2:
-                    message.value = reader.string();
+                case /* GrpcApp grpc */ 2:
+                    message.app = {
+                        oneofKind: "grpc",
+                        grpc: GrpcApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).grpc)
+                    };
+                    break;
+                case /* TwirpApp twirp */ 3:
+                    message.app = {
+                        oneofKind: "twirp",
+                        twirp: TwirpApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).twirp)
+                    };
+                    break;
+                case /* OpenApiApp openapi */ 4:
+                    message.app = {
+                        oneofKind: "openapi",
+                        openapi: OpenApiApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).openapi)
+                    };
+                    break;
+                case /* OpenAiApp openai */ 5:
+                    message.app = {
+                        oneofKind: "openai",
+                        openai: OpenAiApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).openai)
+                    };
+                    break;
+                case /* FolderApp folder */ 7:
+                    message.app = {
+                        oneofKind: "folder",
+                        folder: FolderApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).folder)
+                    };
+                    break;
+                case /* McpApp mcp */ 8:
+                    message.app = {
+                        oneofKind: "mcp",
+                        mcp: McpApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).mcp)
+                    };
+                    break;
+                case /* AnthropicApp anthropic */ 9:
+                    message.app = {
+                        oneofKind: "anthropic",
+                        anthropic: AnthropicApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).anthropic)
+                    };
+                    break;
+                case /* SqliteApp sqlite */ 10:
+                    message.app = {
+                        oneofKind: "sqlite",
+                        sqlite: SqliteApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).sqlite)
+                    };
+                    break;
+                case /* GraphqlApp graphql */ 11:
+                    message.app = {
+                        oneofKind: "graphql",
+                        graphql: GraphqlApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).graphql)
+                    };
+                    break;
+                case /* JsonrpcApp jsonrpc */ 12:
+                    message.app = {
+                        oneofKind: "jsonrpc",
+                        jsonrpc: JsonrpcApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).jsonrpc)
+                    };
+                    break;
+                case /* WebsocketApp websocket */ 13:
+                    message.app = {
+                        oneofKind: "websocket",
+                        websocket: WebsocketApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).websocket)
+                    };
+                    break;
+                case /* HttpApp http */ 14:
+                    message.app = {
+                        oneofKind: "http",
+                        http: HttpApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).http)
+                    };
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3320,13 +4760,46 @@ class SetStoredValueRequest$Type extends MessageType<SetStoredValueRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: SetStoredValueRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: ConfigurationApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         /* string name = 1; */
         if (message.name !== "")
             writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* string value = 2; */
-        if (message.value !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.value);
+        /* GrpcApp grpc = 2; */
+        if (message.app.oneofKind === "grpc")
+            GrpcApp.internalBinaryWrite(message.app.grpc, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* TwirpApp twirp = 3; */
+        if (message.app.oneofKind === "twirp")
+            TwirpApp.internalBinaryWrite(message.app.twirp, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* OpenApiApp openapi = 4; */
+        if (message.app.oneofKind === "openapi")
+            OpenApiApp.internalBinaryWrite(message.app.openapi, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* OpenAiApp openai = 5; */
+        if (message.app.oneofKind === "openai")
+            OpenAiApp.internalBinaryWrite(message.app.openai, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* FolderApp folder = 7; */
+        if (message.app.oneofKind === "folder")
+            FolderApp.internalBinaryWrite(message.app.folder, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* McpApp mcp = 8; */
+        if (message.app.oneofKind === "mcp")
+            McpApp.internalBinaryWrite(message.app.mcp, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* AnthropicApp anthropic = 9; */
+        if (message.app.oneofKind === "anthropic")
+            AnthropicApp.internalBinaryWrite(message.app.anthropic, writer.tag(9, WireType.LengthDelimited).fork(), options).join();
+        /* SqliteApp sqlite = 10; */
+        if (message.app.oneofKind === "sqlite")
+            SqliteApp.internalBinaryWrite(message.app.sqlite, writer.tag(10, WireType.LengthDelimited).fork(), options).join();
+        /* GraphqlApp graphql = 11; */
+        if (message.app.oneofKind === "graphql")
+            GraphqlApp.internalBinaryWrite(message.app.graphql, writer.tag(11, WireType.LengthDelimited).fork(), options).join();
+        /* JsonrpcApp jsonrpc = 12; */
+        if (message.app.oneofKind === "jsonrpc")
+            JsonrpcApp.internalBinaryWrite(message.app.jsonrpc, writer.tag(12, WireType.LengthDelimited).fork(), options).join();
+        /* WebsocketApp websocket = 13; */
+        if (message.app.oneofKind === "websocket")
+            WebsocketApp.internalBinaryWrite(message.app.websocket, writer.tag(13, WireType.LengthDelimited).fork(), options).join();
+        /* HttpApp http = 14; */
+        if (message.app.oneofKind === "http")
+            HttpApp.internalBinaryWrite(message.app.http, writer.tag(14, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3334,30 +4807,160 @@ class SetStoredValueRequest$Type extends MessageType<SetStoredValueRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message SetStoredValueRequest
+ * @generated MessageType for protobuf message ConfigurationApp
  */
-export const SetStoredValueRequest = new SetStoredValueRequest$Type();
+export const ConfigurationApp = new ConfigurationApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ClearStoredValueRequest$Type extends MessageType<ClearStoredValueRequest> {
+class GrpcApp$Type extends MessageType<GrpcApp> {
     constructor() {
-        super("ClearStoredValueRequest", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("GrpcApp", [
+            { no: 1, name: "url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "reflection", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 4, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 5, name: "tls", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "insecure_skip_verify", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 7, name: "ca_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "client_cert_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "client_key_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 10, name: "auth", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 11, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 12, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 13, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 14, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 15, name: "compression", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 16, name: "max_receive_bytes", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 17, name: "max_send_bytes", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 18, name: "protocol", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 19, name: "endpoints", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 20, name: "load_balancing", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 21, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 22, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 23, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 24, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 25, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 26, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 27, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<ClearStoredValueRequest>): ClearStoredValueRequest {
+    create(value?: PartialMessage<GrpcApp>): GrpcApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.name = "";
+        message.url = "";
+        message.protoDir = "";
+        message.reflection = false;
+        message.headers = {};
+        message.tls = "";
+        message.insecureSkipVerify = false;
+        message.caFile = "";
+        message.clientCertFile = "";
+        message.clientKeyFile = "";
+        message.auth = "";
+        message.token = "";
+        message.username = "";
+        message.password = "";
+        message.apiKeyName = "";
+        message.compression = "";
+        message.maxReceiveBytes = "0";
+        message.maxSendBytes = "0";
+        message.protocol = "";
+        message.endpoints = [];
+        message.loadBalancing = "";
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
         if (value !== undefined)
-            reflectionMergePartial<ClearStoredValueRequest>(this, message, value);
+            reflectionMergePartial<GrpcApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ClearStoredValueRequest): ClearStoredValueRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcApp): GrpcApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
+                case /* string url */ 1:
+                    message.url = reader.string();
+                    break;
+                case /* string proto_dir */ 2:
+                    message.protoDir = reader.string();
+                    break;
+                case /* bool reflection */ 3:
+                    message.reflection = reader.bool();
+                    break;
+                case /* map<string, string> headers */ 4:
+                    this.binaryReadMap4(message.headers, reader, options);
+                    break;
+                case /* string tls */ 5:
+                    message.tls = reader.string();
+                    break;
+                case /* bool insecure_skip_verify */ 6:
+                    message.insecureSkipVerify = reader.bool();
+                    break;
+                case /* string ca_file */ 7:
+                    message.caFile = reader.string();
+                    break;
+                case /* string client_cert_file */ 8:
+                    message.clientCertFile = reader.string();
+                    break;
+                case /* string client_key_file */ 9:
+                    message.clientKeyFile = reader.string();
+                    break;
+                case /* string auth */ 10:
+                    message.auth = reader.string();
+                    break;
+                case /* string token */ 11:
+                    message.token = reader.string();
+                    break;
+                case /* string username */ 12:
+                    message.username = reader.string();
+                    break;
+                case /* string password */ 13:
+                    message.password = reader.string();
+                    break;
+                case /* string api_key_name */ 14:
+                    message.apiKeyName = reader.string();
+                    break;
+                case /* string compression */ 15:
+                    message.compression = reader.string();
+                    break;
+                case /* int64 max_receive_bytes */ 16:
+                    message.maxReceiveBytes = reader.int64().toString();
+                    break;
+                case /* int64 max_send_bytes */ 17:
+                    message.maxSendBytes = reader.int64().toString();
+                    break;
+                case /* string protocol */ 18:
+                    message.protocol = reader.string();
+                    break;
+                case /* repeated string endpoints */ 19:
+                    message.endpoints.push(reader.string());
+                    break;
+                case /* string load_balancing */ 20:
+                    message.loadBalancing = reader.string();
+                    break;
+                case /* int64 retry_max_attempts */ 21:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 22:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 23:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 24:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 25:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 26:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 27:
+                    message.maxConcurrency = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3370,57 +4973,104 @@ class ClearStoredValueRequest$Type extends MessageType<ClearStoredValueRequest>
         }
         return message;
     }
-    internalBinaryWrite(message: ClearStoredValueRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        let u = options.writeUnknownFields;
-        if (u !== false)
-            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
-        return writer;
-    }
-}
-/**
- * @generated MessageType for protobuf message ClearStoredValueRequest
- */
-export const ClearStoredValueRequest = new ClearStoredValueRequest$Type();
-// @generated message type with reflection information, may provide speed optimized methods
-class StoredValueResponse$Type extends MessageType<StoredValueResponse> {
-    constructor() {
-        super("StoredValueResponse", [
-            { no: 1, name: "variable_status", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => VariableStatus }
-        ]);
-    }
-    create(value?: PartialMessage<StoredValueResponse>): StoredValueResponse {
-        const message = globalThis.Object.create((this.messagePrototype!));
-        message.variableStatus = [];
-        if (value !== undefined)
-            reflectionMergePartial<StoredValueResponse>(this, message, value);
-        return message;
-    }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: StoredValueResponse): StoredValueResponse {
-        let message = target ?? this.create(), end = reader.pos + length;
+    private binaryReadMap4(map: GrpcApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof GrpcApp["headers"] | undefined, val: GrpcApp["headers"][any] | undefined;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated VariableStatus variable_status */ 1:
-                    message.variableStatus.push(VariableStatus.internalBinaryRead(reader, reader.uint32(), options));
+                case 1:
+                    key = reader.string();
                     break;
-                default:
-                    let u = options.readUnknownField;
-                    if (u === "throw")
-                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
-                    let d = reader.skip(wireType);
-                    if (u !== false)
-                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for GrpcApp.headers");
             }
         }
-        return message;
+        map[key ?? ""] = val ?? "";
     }
-    internalBinaryWrite(message: StoredValueResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated VariableStatus variable_status = 1; */
-        for (let i = 0; i < message.variableStatus.length; i++)
-            VariableStatus.internalBinaryWrite(message.variableStatus[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: GrpcApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string url = 1; */
+        if (message.url !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.url);
+        /* string proto_dir = 2; */
+        if (message.protoDir !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.protoDir);
+        /* bool reflection = 3; */
+        if (message.reflection !== false)
+            writer.tag(3, WireType.Varint).bool(message.reflection);
+        /* map<string, string> headers = 4; */
+        for (let k of globalThis.Object.keys(message.headers))
+            writer.tag(4, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* string tls = 5; */
+        if (message.tls !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.tls);
+        /* bool insecure_skip_verify = 6; */
+        if (message.insecureSkipVerify !== false)
+            writer.tag(6, WireType.Varint).bool(message.insecureSkipVerify);
+        /* string ca_file = 7; */
+        if (message.caFile !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.caFile);
+        /* string client_cert_file = 8; */
+        if (message.clientCertFile !== "")
+            writer.tag(8, WireType.LengthDelimited).string(message.clientCertFile);
+        /* string client_key_file = 9; */
+        if (message.clientKeyFile !== "")
+            writer.tag(9, WireType.LengthDelimited).string(message.clientKeyFile);
+        /* string auth = 10; */
+        if (message.auth !== "")
+            writer.tag(10, WireType.LengthDelimited).string(message.auth);
+        /* string token = 11; */
+        if (message.token !== "")
+            writer.tag(11, WireType.LengthDelimited).string(message.token);
+        /* string username = 12; */
+        if (message.username !== "")
+            writer.tag(12, WireType.LengthDelimited).string(message.username);
+        /* string password = 13; */
+        if (message.password !== "")
+            writer.tag(13, WireType.LengthDelimited).string(message.password);
+        /* string api_key_name = 14; */
+        if (message.apiKeyName !== "")
+            writer.tag(14, WireType.LengthDelimited).string(message.apiKeyName);
+        /* string compression = 15; */
+        if (message.compression !== "")
+            writer.tag(15, WireType.LengthDelimited).string(message.compression);
+        /* int64 max_receive_bytes = 16; */
+        if (message.maxReceiveBytes !== "0")
+            writer.tag(16, WireType.Varint).int64(message.maxReceiveBytes);
+        /* int64 max_send_bytes = 17; */
+        if (message.maxSendBytes !== "0")
+            writer.tag(17, WireType.Varint).int64(message.maxSendBytes);
+        /* string protocol = 18; */
+        if (message.protocol !== "")
+            writer.tag(18, WireType.LengthDelimited).string(message.protocol);
+        /* repeated string endpoints = 19; */
+        for (let i = 0; i < message.endpoints.length; i++)
+            writer.tag(19, WireType.LengthDelimited).string(message.endpoints[i]);
+        /* string load_balancing = 20; */
+        if (message.loadBalancing !== "")
+            writer.tag(20, WireType.LengthDelimited).string(message.loadBalancing);
+        /* int64 retry_max_attempts = 21; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(21, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 22; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(22, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 23; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(23, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 24; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(24, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 25; */
+        if (message.rateLimit !== "0")
+            writer.tag(25, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 26; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(26, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 27; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(27, WireType.Varint).int64(message.maxConcurrency);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3428,45 +5078,130 @@ class StoredValueResponse$Type extends MessageType<StoredValueResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message StoredValueResponse
+ * @generated MessageType for protobuf message GrpcApp
  */
-export const StoredValueResponse = new StoredValueResponse$Type();
+export const GrpcApp = new GrpcApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Script$Type extends MessageType<Script> {
+class TwirpApp$Type extends MessageType<TwirpApp> {
     constructor() {
-        super("Script", [
-            { no: 1, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "folder", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("TwirpApp", [
+            { no: 1, name: "url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 4, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 7, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 9, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 10, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 11, name: "tls", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 12, name: "insecure_skip_verify", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 13, name: "ca_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 14, name: "client_cert_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 15, name: "client_key_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 16, name: "auth", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 17, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 18, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 19, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 20, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 21, name: "encoding", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<Script>): Script {
+    create(value?: PartialMessage<TwirpApp>): TwirpApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.path = "";
-        message.name = "";
-        message.content = "";
-        message.folder = "";
+        message.url = "";
+        message.protoDir = "";
+        message.headers = {};
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
+        message.tls = "";
+        message.insecureSkipVerify = false;
+        message.caFile = "";
+        message.clientCertFile = "";
+        message.clientKeyFile = "";
+        message.auth = "";
+        message.token = "";
+        message.username = "";
+        message.password = "";
+        message.apiKeyName = "";
+        message.encoding = "";
         if (value !== undefined)
-            reflectionMergePartial<Script>(this, message, value);
+            reflectionMergePartial<TwirpApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Script): Script {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TwirpApp): TwirpApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string path */ 1:
-                    message.path = reader.string();
+                case /* string url */ 1:
+                    message.url = reader.string();
                     break;
-                case /* string name */ 2:
-                    message.name = reader.string();
+                case /* string proto_dir */ 2:
+                    message.protoDir = reader.string();
                     break;
-                case /* string content */ 3:
-                    message.content = reader.string();
+                case /* map<string, string> headers */ 3:
+                    this.binaryReadMap3(message.headers, reader, options);
                     break;
-                case /* string folder */ 4:
-                    message.folder = reader.string();
+                case /* int64 retry_max_attempts */ 4:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 5:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 6:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 7:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 8:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 9:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 10:
+                    message.maxConcurrency = reader.int64().toString();
+                    break;
+                case /* string tls */ 11:
+                    message.tls = reader.string();
+                    break;
+                case /* bool insecure_skip_verify */ 12:
+                    message.insecureSkipVerify = reader.bool();
+                    break;
+                case /* string ca_file */ 13:
+                    message.caFile = reader.string();
+                    break;
+                case /* string client_cert_file */ 14:
+                    message.clientCertFile = reader.string();
+                    break;
+                case /* string client_key_file */ 15:
+                    message.clientKeyFile = reader.string();
+                    break;
+                case /* string auth */ 16:
+                    message.auth = reader.string();
+                    break;
+                case /* string token */ 17:
+                    message.token = reader.string();
+                    break;
+                case /* string username */ 18:
+                    message.username = reader.string();
+                    break;
+                case /* string password */ 19:
+                    message.password = reader.string();
+                    break;
+                case /* string api_key_name */ 20:
+                    message.apiKeyName = reader.string();
+                    break;
+                case /* string encoding */ 21:
+                    message.encoding = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3479,57 +5214,86 @@ class Script$Type extends MessageType<Script> {
         }
         return message;
     }
-    internalBinaryWrite(message: Script, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string path = 1; */
-        if (message.path !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.path);
-        /* string name = 2; */
-        if (message.name !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.name);
-        /* string content = 3; */
-        if (message.content !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.content);
-        /* string folder = 4; */
-        if (message.folder !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.folder);
-        let u = options.writeUnknownFields;
-        if (u !== false)
-            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
-        return writer;
-    }
-}
-/**
- * @generated MessageType for protobuf message Script
- */
-export const Script = new Script$Type();
-// @generated message type with reflection information, may provide speed optimized methods
-class ListScriptsRequest$Type extends MessageType<ListScriptsRequest> {
-    constructor() {
-        super("ListScriptsRequest", []);
-    }
-    create(value?: PartialMessage<ListScriptsRequest>): ListScriptsRequest {
-        const message = globalThis.Object.create((this.messagePrototype!));
-        if (value !== undefined)
-            reflectionMergePartial<ListScriptsRequest>(this, message, value);
-        return message;
-    }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ListScriptsRequest): ListScriptsRequest {
-        let message = target ?? this.create(), end = reader.pos + length;
+    private binaryReadMap3(map: TwirpApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof TwirpApp["headers"] | undefined, val: TwirpApp["headers"][any] | undefined;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                default:
-                    let u = options.readUnknownField;
-                    if (u === "throw")
-                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
-                    let d = reader.skip(wireType);
-                    if (u !== false)
-                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for TwirpApp.headers");
             }
         }
-        return message;
+        map[key ?? ""] = val ?? "";
     }
-    internalBinaryWrite(message: ListScriptsRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: TwirpApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string url = 1; */
+        if (message.url !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.url);
+        /* string proto_dir = 2; */
+        if (message.protoDir !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.protoDir);
+        /* map<string, string> headers = 3; */
+        for (let k of globalThis.Object.keys(message.headers))
+            writer.tag(3, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* int64 retry_max_attempts = 4; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(4, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 5; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(5, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 6; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(6, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 7; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(7, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 8; */
+        if (message.rateLimit !== "0")
+            writer.tag(8, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 9; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(9, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 10; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(10, WireType.Varint).int64(message.maxConcurrency);
+        /* string tls = 11; */
+        if (message.tls !== "")
+            writer.tag(11, WireType.LengthDelimited).string(message.tls);
+        /* bool insecure_skip_verify = 12; */
+        if (message.insecureSkipVerify !== false)
+            writer.tag(12, WireType.Varint).bool(message.insecureSkipVerify);
+        /* string ca_file = 13; */
+        if (message.caFile !== "")
+            writer.tag(13, WireType.LengthDelimited).string(message.caFile);
+        /* string client_cert_file = 14; */
+        if (message.clientCertFile !== "")
+            writer.tag(14, WireType.LengthDelimited).string(message.clientCertFile);
+        /* string client_key_file = 15; */
+        if (message.clientKeyFile !== "")
+            writer.tag(15, WireType.LengthDelimited).string(message.clientKeyFile);
+        /* string auth = 16; */
+        if (message.auth !== "")
+            writer.tag(16, WireType.LengthDelimited).string(message.auth);
+        /* string token = 17; */
+        if (message.token !== "")
+            writer.tag(17, WireType.LengthDelimited).string(message.token);
+        /* string username = 18; */
+        if (message.username !== "")
+            writer.tag(18, WireType.LengthDelimited).string(message.username);
+        /* string password = 19; */
+        if (message.password !== "")
+            writer.tag(19, WireType.LengthDelimited).string(message.password);
+        /* string api_key_name = 20; */
+        if (message.apiKeyName !== "")
+            writer.tag(20, WireType.LengthDelimited).string(message.apiKeyName);
+        /* string encoding = 21; */
+        if (message.encoding !== "")
+            writer.tag(21, WireType.LengthDelimited).string(message.encoding);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3537,30 +5301,110 @@ class ListScriptsRequest$Type extends MessageType<ListScriptsRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message ListScriptsRequest
+ * @generated MessageType for protobuf message TwirpApp
  */
-export const ListScriptsRequest = new ListScriptsRequest$Type();
+export const TwirpApp = new TwirpApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ListScriptsResponse$Type extends MessageType<ListScriptsResponse> {
+class OpenApiApp$Type extends MessageType<OpenApiApp> {
     constructor() {
-        super("ListScriptsResponse", [
-            { no: 1, name: "scripts", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Script }
+        super("OpenApiApp", [
+            { no: 1, name: "spec_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 6, name: "spec_content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "base_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "security_scheme", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "spec_header_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 10, name: "spec_header_value", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 11, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 12, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 13, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 14, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 15, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 16, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 17, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<ListScriptsResponse>): ListScriptsResponse {
+    create(value?: PartialMessage<OpenApiApp>): OpenApiApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.scripts = [];
+        message.specUrl = "";
+        message.token = "";
+        message.username = "";
+        message.password = "";
+        message.headers = {};
+        message.specContent = "";
+        message.baseUrl = "";
+        message.securityScheme = "";
+        message.specHeaderName = "";
+        message.specHeaderValue = "";
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
         if (value !== undefined)
-            reflectionMergePartial<ListScriptsResponse>(this, message, value);
+            reflectionMergePartial<OpenApiApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ListScriptsResponse): ListScriptsResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiApp): OpenApiApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated Script scripts */ 1:
-                    message.scripts.push(Script.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string spec_url */ 1:
+                    message.specUrl = reader.string();
+                    break;
+                case /* string token */ 2:
+                    message.token = reader.string();
+                    break;
+                case /* string username */ 3:
+                    message.username = reader.string();
+                    break;
+                case /* string password */ 4:
+                    message.password = reader.string();
+                    break;
+                case /* map<string, string> headers */ 5:
+                    this.binaryReadMap5(message.headers, reader, options);
+                    break;
+                case /* string spec_content */ 6:
+                    message.specContent = reader.string();
+                    break;
+                case /* string base_url */ 7:
+                    message.baseUrl = reader.string();
+                    break;
+                case /* string security_scheme */ 8:
+                    message.securityScheme = reader.string();
+                    break;
+                case /* string spec_header_name */ 9:
+                    message.specHeaderName = reader.string();
+                    break;
+                case /* string spec_header_value */ 10:
+                    message.specHeaderValue = reader.string();
+                    break;
+                case /* int64 retry_max_attempts */ 11:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 12:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 13:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 14:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 15:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 16:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 17:
+                    message.maxConcurrency = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3573,10 +5417,74 @@ class ListScriptsResponse$Type extends MessageType<ListScriptsResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: ListScriptsResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated Script scripts = 1; */
-        for (let i = 0; i < message.scripts.length; i++)
-            Script.internalBinaryWrite(message.scripts[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    private binaryReadMap5(map: OpenApiApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof OpenApiApp["headers"] | undefined, val: OpenApiApp["headers"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for OpenApiApp.headers");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: OpenApiApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string spec_url = 1; */
+        if (message.specUrl !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.specUrl);
+        /* string token = 2; */
+        if (message.token !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.token);
+        /* string username = 3; */
+        if (message.username !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.username);
+        /* string password = 4; */
+        if (message.password !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.password);
+        /* map<string, string> headers = 5; */
+        for (let k of globalThis.Object.keys(message.headers))
+            writer.tag(5, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* string spec_content = 6; */
+        if (message.specContent !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.specContent);
+        /* string base_url = 7; */
+        if (message.baseUrl !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.baseUrl);
+        /* string security_scheme = 8; */
+        if (message.securityScheme !== "")
+            writer.tag(8, WireType.LengthDelimited).string(message.securityScheme);
+        /* string spec_header_name = 9; */
+        if (message.specHeaderName !== "")
+            writer.tag(9, WireType.LengthDelimited).string(message.specHeaderName);
+        /* string spec_header_value = 10; */
+        if (message.specHeaderValue !== "")
+            writer.tag(10, WireType.LengthDelimited).string(message.specHeaderValue);
+        /* int64 retry_max_attempts = 11; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(11, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 12; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(12, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 13; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(13, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 14; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(14, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 15; */
+        if (message.rateLimit !== "0")
+            writer.tag(15, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 16; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(16, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 17; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(17, WireType.Varint).int64(message.maxConcurrency);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3584,30 +5492,75 @@ class ListScriptsResponse$Type extends MessageType<ListScriptsResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message ListScriptsResponse
+ * @generated MessageType for protobuf message OpenApiApp
  */
-export const ListScriptsResponse = new ListScriptsResponse$Type();
+export const OpenApiApp = new OpenApiApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ReadScriptRequest$Type extends MessageType<ReadScriptRequest> {
+class OpenAiApp$Type extends MessageType<OpenAiApp> {
     constructor() {
-        super("ReadScriptRequest", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("OpenAiApp", [
+            { no: 1, name: "endpoint", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 4, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 7, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 9, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 10, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<ReadScriptRequest>): ReadScriptRequest {
+    create(value?: PartialMessage<OpenAiApp>): OpenAiApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.name = "";
+        message.endpoint = "";
+        message.token = "";
+        message.headers = {};
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
         if (value !== undefined)
-            reflectionMergePartial<ReadScriptRequest>(this, message, value);
+            reflectionMergePartial<OpenAiApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ReadScriptRequest): ReadScriptRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenAiApp): OpenAiApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
+                case /* string endpoint */ 1:
+                    message.endpoint = reader.string();
+                    break;
+                case /* string token */ 2:
+                    message.token = reader.string();
+                    break;
+                case /* map<string, string> headers */ 3:
+                    this.binaryReadMap3(message.headers, reader, options);
+                    break;
+                case /* int64 retry_max_attempts */ 4:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 5:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 6:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 7:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 8:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 9:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 10:
+                    message.maxConcurrency = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3620,10 +5573,53 @@ class ReadScriptRequest$Type extends MessageType<ReadScriptRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: ReadScriptRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
+    private binaryReadMap3(map: OpenAiApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof OpenAiApp["headers"] | undefined, val: OpenAiApp["headers"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for OpenAiApp.headers");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: OpenAiApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string endpoint = 1; */
+        if (message.endpoint !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.endpoint);
+        /* string token = 2; */
+        if (message.token !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.token);
+        /* map<string, string> headers = 3; */
+        for (let k of globalThis.Object.keys(message.headers))
+            writer.tag(3, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* int64 retry_max_attempts = 4; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(4, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 5; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(5, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 6; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(6, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 7; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(7, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 8; */
+        if (message.rateLimit !== "0")
+            writer.tag(8, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 9; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(9, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 10; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(10, WireType.Varint).int64(message.maxConcurrency);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3631,29 +5627,80 @@ class ReadScriptRequest$Type extends MessageType<ReadScriptRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message ReadScriptRequest
+ * @generated MessageType for protobuf message OpenAiApp
  */
-export const ReadScriptRequest = new ReadScriptRequest$Type();
+export const OpenAiApp = new OpenAiApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ReadScriptResponse$Type extends MessageType<ReadScriptResponse> {
+class AnthropicApp$Type extends MessageType<AnthropicApp> {
     constructor() {
-        super("ReadScriptResponse", [
-            { no: 1, name: "script", kind: "message", T: () => Script }
+        super("AnthropicApp", [
+            { no: 1, name: "endpoint", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 4, name: "version", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 7, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 8, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 10, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 11, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<ReadScriptResponse>): ReadScriptResponse {
+    create(value?: PartialMessage<AnthropicApp>): AnthropicApp {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.endpoint = "";
+        message.token = "";
+        message.headers = {};
+        message.version = "";
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
         if (value !== undefined)
-            reflectionMergePartial<ReadScriptResponse>(this, message, value);
+            reflectionMergePartial<AnthropicApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ReadScriptResponse): ReadScriptResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: AnthropicApp): AnthropicApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* Script script */ 1:
-                    message.script = Script.internalBinaryRead(reader, reader.uint32(), options, message.script);
+                case /* string endpoint */ 1:
+                    message.endpoint = reader.string();
+                    break;
+                case /* string token */ 2:
+                    message.token = reader.string();
+                    break;
+                case /* map<string, string> headers */ 3:
+                    this.binaryReadMap3(message.headers, reader, options);
+                    break;
+                case /* string version */ 4:
+                    message.version = reader.string();
+                    break;
+                case /* int64 retry_max_attempts */ 5:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 6:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 7:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 8:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 9:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 10:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 11:
+                    message.maxConcurrency = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3666,10 +5713,56 @@ class ReadScriptResponse$Type extends MessageType<ReadScriptResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: ReadScriptResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* Script script = 1; */
-        if (message.script)
-            Script.internalBinaryWrite(message.script, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    private binaryReadMap3(map: AnthropicApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof AnthropicApp["headers"] | undefined, val: AnthropicApp["headers"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for AnthropicApp.headers");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: AnthropicApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string endpoint = 1; */
+        if (message.endpoint !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.endpoint);
+        /* string token = 2; */
+        if (message.token !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.token);
+        /* map<string, string> headers = 3; */
+        for (let k of globalThis.Object.keys(message.headers))
+            writer.tag(3, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* string version = 4; */
+        if (message.version !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.version);
+        /* int64 retry_max_attempts = 5; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(5, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 6; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(6, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 7; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(7, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 8; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(8, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 9; */
+        if (message.rateLimit !== "0")
+            writer.tag(9, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 10; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(10, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 11; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(11, WireType.Varint).int64(message.maxConcurrency);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3677,40 +5770,30 @@ class ReadScriptResponse$Type extends MessageType<ReadScriptResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message ReadScriptResponse
+ * @generated MessageType for protobuf message AnthropicApp
  */
-export const ReadScriptResponse = new ReadScriptResponse$Type();
+export const AnthropicApp = new AnthropicApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Configuration$Type extends MessageType<Configuration> {
+class FolderApp$Type extends MessageType<FolderApp> {
     constructor() {
-        super("Configuration", [
-            { no: 1, name: "path_prefix", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "apps", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => ConfigurationApp },
-            { no: 6, name: "variables", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } }
+        super("FolderApp", [
+            { no: 1, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<Configuration>): Configuration {
+    create(value?: PartialMessage<FolderApp>): FolderApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.pathPrefix = "";
-        message.apps = [];
-        message.variables = {};
+        message.path = "";
         if (value !== undefined)
-            reflectionMergePartial<Configuration>(this, message, value);
+            reflectionMergePartial<FolderApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Configuration): Configuration {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FolderApp): FolderApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string path_prefix */ 1:
-                    message.pathPrefix = reader.string();
-                    break;
-                case /* repeated ConfigurationApp apps */ 5:
-                    message.apps.push(ConfigurationApp.internalBinaryRead(reader, reader.uint32(), options));
-                    break;
-                case /* map<string, string> variables */ 6:
-                    this.binaryReadMap6(message.variables, reader, options);
+                case /* string path */ 1:
+                    message.path = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3723,32 +5806,10 @@ class Configuration$Type extends MessageType<Configuration> {
         }
         return message;
     }
-    private binaryReadMap6(map: Configuration["variables"], reader: IBinaryReader, options: BinaryReadOptions): void {
-        let len = reader.uint32(), end = reader.pos + len, key: keyof Configuration["variables"] | undefined, val: Configuration["variables"][any] | undefined;
-        while (reader.pos < end) {
-            let [fieldNo, wireType] = reader.tag();
-            switch (fieldNo) {
-                case 1:
-                    key = reader.string();
-                    break;
-                case 2:
-                    val = reader.string();
-                    break;
-                default: throw new globalThis.Error("unknown map entry field for Configuration.variables");
-            }
-        }
-        map[key ?? ""] = val ?? "";
-    }
-    internalBinaryWrite(message: Configuration, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string path_prefix = 1; */
-        if (message.pathPrefix !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.pathPrefix);
-        /* repeated ConfigurationApp apps = 5; */
-        for (let i = 0; i < message.apps.length; i++)
-            ConfigurationApp.internalBinaryWrite(message.apps[i], writer.tag(5, WireType.LengthDelimited).fork(), options).join();
-        /* map<string, string> variables = 6; */
-        for (let k of globalThis.Object.keys(message.variables))
-            writer.tag(6, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.variables[k]).join();
+    internalBinaryWrite(message: FolderApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string path = 1; */
+        if (message.path !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.path);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3756,73 +5817,35 @@ class Configuration$Type extends MessageType<Configuration> {
     }
 }
 /**
- * @generated MessageType for protobuf message Configuration
+ * @generated MessageType for protobuf message FolderApp
  */
-export const Configuration = new Configuration$Type();
+export const FolderApp = new FolderApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ConfigurationApp$Type extends MessageType<ConfigurationApp> {
+class SqliteApp$Type extends MessageType<SqliteApp> {
     constructor() {
-        super("ConfigurationApp", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "grpc", kind: "message", oneof: "app", T: () => GrpcApp },
-            { no: 3, name: "twirp", kind: "message", oneof: "app", T: () => TwirpApp },
-            { no: 4, name: "openapi", kind: "message", oneof: "app", T: () => OpenApiApp },
-            { no: 5, name: "openai", kind: "message", oneof: "app", T: () => OpenAiApp },
-            { no: 7, name: "folder", kind: "message", oneof: "app", T: () => FolderApp },
-            { no: 8, name: "mcp", kind: "message", oneof: "app", T: () => McpApp }
+        super("SqliteApp", [
+            { no: 1, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "read_only", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<ConfigurationApp>): ConfigurationApp {
+    create(value?: PartialMessage<SqliteApp>): SqliteApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.name = "";
-        message.app = { oneofKind: undefined };
+        message.path = "";
+        message.readOnly = false;
         if (value !== undefined)
-            reflectionMergePartial<ConfigurationApp>(this, message, value);
+            reflectionMergePartial<SqliteApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ConfigurationApp): ConfigurationApp {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SqliteApp): SqliteApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
-                    break;
-                case /* GrpcApp grpc */ 2:
-                    message.app = {
-                        oneofKind: "grpc",
-                        grpc: GrpcApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).grpc)
-                    };
-                    break;
-                case /* TwirpApp twirp */ 3:
-                    message.app = {
-                        oneofKind: "twirp",
-                        twirp: TwirpApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).twirp)
-                    };
-                    break;
-                case /* OpenApiApp openapi */ 4:
-                    message.app = {
-                        oneofKind: "openapi",
-                        openapi: OpenApiApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).openapi)
-                    };
-                    break;
-                case /* OpenAiApp openai */ 5:
-                    message.app = {
-                        oneofKind: "openai",
-                        openai: OpenAiApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).openai)
-                    };
-                    break;
-                case /* FolderApp folder */ 7:
-                    message.app = {
-                        oneofKind: "folder",
-                        folder: FolderApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).folder)
-                    };
+                case /* string path */ 1:
+                    message.path = reader.string();
                     break;
-                case /* McpApp mcp */ 8:
-                    message.app = {
-                        oneofKind: "mcp",
-                        mcp: McpApp.internalBinaryRead(reader, reader.uint32(), options, (message.app as any).mcp)
-                    };
+                case /* bool read_only */ 2:
+                    message.readOnly = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3835,28 +5858,13 @@ class ConfigurationApp$Type extends MessageType<ConfigurationApp> {
         }
         return message;
     }
-    internalBinaryWrite(message: ConfigurationApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* GrpcApp grpc = 2; */
-        if (message.app.oneofKind === "grpc")
-            GrpcApp.internalBinaryWrite(message.app.grpc, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* TwirpApp twirp = 3; */
-        if (message.app.oneofKind === "twirp")
-            TwirpApp.internalBinaryWrite(message.app.twirp, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* OpenApiApp openapi = 4; */
-        if (message.app.oneofKind === "openapi")
-            OpenApiApp.internalBinaryWrite(message.app.openapi, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
-        /* OpenAiApp openai = 5; */
-        if (message.app.oneofKind === "openai")
-            OpenAiApp.internalBinaryWrite(message.app.openai, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
-        /* FolderApp folder = 7; */
-        if (message.app.oneofKind === "folder")
-            FolderApp.internalBinaryWrite(message.app.folder, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
-        /* McpApp mcp = 8; */
-        if (message.app.oneofKind === "mcp")
-            McpApp.internalBinaryWrite(message.app.mcp, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: SqliteApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string path = 1; */
+        if (message.path !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.path);
+        /* bool read_only = 2; */
+        if (message.readOnly !== false)
+            writer.tag(2, WireType.Varint).bool(message.readOnly);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3864,50 +5872,54 @@ class ConfigurationApp$Type extends MessageType<ConfigurationApp> {
     }
 }
 /**
- * @generated MessageType for protobuf message ConfigurationApp
+ * @generated MessageType for protobuf message SqliteApp
  */
-export const ConfigurationApp = new ConfigurationApp$Type();
+export const SqliteApp = new SqliteApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class GrpcApp$Type extends MessageType<GrpcApp> {
+class GraphqlApp$Type extends MessageType<GraphqlApp> {
     constructor() {
-        super("GrpcApp", [
+        super("GraphqlApp", [
             { no: 1, name: "url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "reflection", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
-            { no: 4, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
-            { no: 5, name: "tls", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "insecure_skip_verify", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
-            { no: 7, name: "ca_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 8, name: "client_cert_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 9, name: "client_key_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 10, name: "auth", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 11, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 12, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 13, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 14, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 2, name: "schema_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 4, name: "auth", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 10, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 11, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 12, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 13, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 14, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 15, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 16, name: "depth", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<GrpcApp>): GrpcApp {
+    create(value?: PartialMessage<GraphqlApp>): GraphqlApp {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.url = "";
-        message.protoDir = "";
-        message.reflection = false;
+        message.schemaFile = "";
         message.headers = {};
-        message.tls = "";
-        message.insecureSkipVerify = false;
-        message.caFile = "";
-        message.clientCertFile = "";
-        message.clientKeyFile = "";
         message.auth = "";
         message.token = "";
         message.username = "";
         message.password = "";
         message.apiKeyName = "";
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
+        message.depth = "0";
         if (value !== undefined)
-            reflectionMergePartial<GrpcApp>(this, message, value);
+            reflectionMergePartial<GraphqlApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GrpcApp): GrpcApp {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GraphqlApp): GraphqlApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
@@ -3915,44 +5927,50 @@ class GrpcApp$Type extends MessageType<GrpcApp> {
                 case /* string url */ 1:
                     message.url = reader.string();
                     break;
-                case /* string proto_dir */ 2:
-                    message.protoDir = reader.string();
+                case /* string schema_file */ 2:
+                    message.schemaFile = reader.string();
                     break;
-                case /* bool reflection */ 3:
-                    message.reflection = reader.bool();
+                case /* map<string, string> headers */ 3:
+                    this.binaryReadMap3(message.headers, reader, options);
                     break;
-                case /* map<string, string> headers */ 4:
-                    this.binaryReadMap4(message.headers, reader, options);
+                case /* string auth */ 4:
+                    message.auth = reader.string();
                     break;
-                case /* string tls */ 5:
-                    message.tls = reader.string();
+                case /* string token */ 5:
+                    message.token = reader.string();
                     break;
-                case /* bool insecure_skip_verify */ 6:
-                    message.insecureSkipVerify = reader.bool();
+                case /* string username */ 6:
+                    message.username = reader.string();
                     break;
-                case /* string ca_file */ 7:
-                    message.caFile = reader.string();
+                case /* string password */ 7:
+                    message.password = reader.string();
                     break;
-                case /* string client_cert_file */ 8:
-                    message.clientCertFile = reader.string();
+                case /* string api_key_name */ 8:
+                    message.apiKeyName = reader.string();
                     break;
-                case /* string client_key_file */ 9:
-                    message.clientKeyFile = reader.string();
+                case /* int64 retry_max_attempts */ 9:
+                    message.retryMaxAttempts = reader.int64().toString();
                     break;
-                case /* string auth */ 10:
-                    message.auth = reader.string();
+                case /* int64 retry_backoff_ms */ 10:
+                    message.retryBackoffMs = reader.int64().toString();
                     break;
-                case /* string token */ 11:
-                    message.token = reader.string();
+                case /* int64 retry_max_backoff_ms */ 11:
+                    message.retryMaxBackoffMs = reader.int64().toString();
                     break;
-                case /* string username */ 12:
-                    message.username = reader.string();
+                case /* repeated string retry_codes */ 12:
+                    message.retryCodes.push(reader.string());
                     break;
-                case /* string password */ 13:
-                    message.password = reader.string();
+                case /* int64 rate_limit */ 13:
+                    message.rateLimit = reader.int64().toString();
                     break;
-                case /* string api_key_name */ 14:
-                    message.apiKeyName = reader.string();
+                case /* int64 rate_limit_burst */ 14:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 15:
+                    message.maxConcurrency = reader.int64().toString();
+                    break;
+                case /* int64 depth */ 16:
+                    message.depth = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3965,8 +5983,8 @@ class GrpcApp$Type extends MessageType<GrpcApp> {
         }
         return message;
     }
-    private binaryReadMap4(map: GrpcApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
-        let len = reader.uint32(), end = reader.pos + len, key: keyof GrpcApp["headers"] | undefined, val: GrpcApp["headers"][any] | undefined;
+    private binaryReadMap3(map: GraphqlApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof GraphqlApp["headers"] | undefined, val: GraphqlApp["headers"][any] | undefined;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
@@ -3976,54 +5994,60 @@ class GrpcApp$Type extends MessageType<GrpcApp> {
                 case 2:
                     val = reader.string();
                     break;
-                default: throw new globalThis.Error("unknown map entry field for GrpcApp.headers");
+                default: throw new globalThis.Error("unknown map entry field for GraphqlApp.headers");
             }
         }
         map[key ?? ""] = val ?? "";
     }
-    internalBinaryWrite(message: GrpcApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: GraphqlApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         /* string url = 1; */
         if (message.url !== "")
             writer.tag(1, WireType.LengthDelimited).string(message.url);
-        /* string proto_dir = 2; */
-        if (message.protoDir !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.protoDir);
-        /* bool reflection = 3; */
-        if (message.reflection !== false)
-            writer.tag(3, WireType.Varint).bool(message.reflection);
-        /* map<string, string> headers = 4; */
+        /* string schema_file = 2; */
+        if (message.schemaFile !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.schemaFile);
+        /* map<string, string> headers = 3; */
         for (let k of globalThis.Object.keys(message.headers))
-            writer.tag(4, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
-        /* string tls = 5; */
-        if (message.tls !== "")
-            writer.tag(5, WireType.LengthDelimited).string(message.tls);
-        /* bool insecure_skip_verify = 6; */
-        if (message.insecureSkipVerify !== false)
-            writer.tag(6, WireType.Varint).bool(message.insecureSkipVerify);
-        /* string ca_file = 7; */
-        if (message.caFile !== "")
-            writer.tag(7, WireType.LengthDelimited).string(message.caFile);
-        /* string client_cert_file = 8; */
-        if (message.clientCertFile !== "")
-            writer.tag(8, WireType.LengthDelimited).string(message.clientCertFile);
-        /* string client_key_file = 9; */
-        if (message.clientKeyFile !== "")
-            writer.tag(9, WireType.LengthDelimited).string(message.clientKeyFile);
-        /* string auth = 10; */
+            writer.tag(3, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* string auth = 4; */
         if (message.auth !== "")
-            writer.tag(10, WireType.LengthDelimited).string(message.auth);
-        /* string token = 11; */
+            writer.tag(4, WireType.LengthDelimited).string(message.auth);
+        /* string token = 5; */
         if (message.token !== "")
-            writer.tag(11, WireType.LengthDelimited).string(message.token);
-        /* string username = 12; */
+            writer.tag(5, WireType.LengthDelimited).string(message.token);
+        /* string username = 6; */
         if (message.username !== "")
-            writer.tag(12, WireType.LengthDelimited).string(message.username);
-        /* string password = 13; */
+            writer.tag(6, WireType.LengthDelimited).string(message.username);
+        /* string password = 7; */
         if (message.password !== "")
-            writer.tag(13, WireType.LengthDelimited).string(message.password);
-        /* string api_key_name = 14; */
+            writer.tag(7, WireType.LengthDelimited).string(message.password);
+        /* string api_key_name = 8; */
         if (message.apiKeyName !== "")
-            writer.tag(14, WireType.LengthDelimited).string(message.apiKeyName);
+            writer.tag(8, WireType.LengthDelimited).string(message.apiKeyName);
+        /* int64 retry_max_attempts = 9; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(9, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 10; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(10, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 11; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(11, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 12; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(12, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 13; */
+        if (message.rateLimit !== "0")
+            writer.tag(13, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 14; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(14, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 15; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(15, WireType.Varint).int64(message.maxConcurrency);
+        /* int64 depth = 16; */
+        if (message.depth !== "0")
+            writer.tag(16, WireType.Varint).int64(message.depth);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4031,28 +6055,54 @@ class GrpcApp$Type extends MessageType<GrpcApp> {
     }
 }
 /**
- * @generated MessageType for protobuf message GrpcApp
+ * @generated MessageType for protobuf message GraphqlApp
  */
-export const GrpcApp = new GrpcApp$Type();
+export const GraphqlApp = new GraphqlApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class TwirpApp$Type extends MessageType<TwirpApp> {
+class JsonrpcApp$Type extends MessageType<JsonrpcApp> {
     constructor() {
-        super("TwirpApp", [
+        super("JsonrpcApp", [
             { no: 1, name: "url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "proto_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } }
+            { no: 2, name: "spec_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "spec_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 5, name: "auth", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 10, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 11, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 12, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 13, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 14, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 15, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 16, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<TwirpApp>): TwirpApp {
+    create(value?: PartialMessage<JsonrpcApp>): JsonrpcApp {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.url = "";
-        message.protoDir = "";
+        message.specUrl = "";
+        message.specFile = "";
         message.headers = {};
+        message.auth = "";
+        message.token = "";
+        message.username = "";
+        message.password = "";
+        message.apiKeyName = "";
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
         if (value !== undefined)
-            reflectionMergePartial<TwirpApp>(this, message, value);
+            reflectionMergePartial<JsonrpcApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TwirpApp): TwirpApp {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: JsonrpcApp): JsonrpcApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
@@ -4060,11 +6110,50 @@ class TwirpApp$Type extends MessageType<TwirpApp> {
                 case /* string url */ 1:
                     message.url = reader.string();
                     break;
-                case /* string proto_dir */ 2:
-                    message.protoDir = reader.string();
+                case /* string spec_url */ 2:
+                    message.specUrl = reader.string();
                     break;
-                case /* map<string, string> headers */ 3:
-                    this.binaryReadMap3(message.headers, reader, options);
+                case /* string spec_file */ 3:
+                    message.specFile = reader.string();
+                    break;
+                case /* map<string, string> headers */ 4:
+                    this.binaryReadMap4(message.headers, reader, options);
+                    break;
+                case /* string auth */ 5:
+                    message.auth = reader.string();
+                    break;
+                case /* string token */ 6:
+                    message.token = reader.string();
+                    break;
+                case /* string username */ 7:
+                    message.username = reader.string();
+                    break;
+                case /* string password */ 8:
+                    message.password = reader.string();
+                    break;
+                case /* string api_key_name */ 9:
+                    message.apiKeyName = reader.string();
+                    break;
+                case /* int64 retry_max_attempts */ 10:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 11:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 12:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 13:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 14:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 15:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 16:
+                    message.maxConcurrency = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -4077,8 +6166,8 @@ class TwirpApp$Type extends MessageType<TwirpApp> {
         }
         return message;
     }
-    private binaryReadMap3(map: TwirpApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
-        let len = reader.uint32(), end = reader.pos + len, key: keyof TwirpApp["headers"] | undefined, val: TwirpApp["headers"][any] | undefined;
+    private binaryReadMap4(map: JsonrpcApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof JsonrpcApp["headers"] | undefined, val: JsonrpcApp["headers"][any] | undefined;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
@@ -4088,21 +6177,60 @@ class TwirpApp$Type extends MessageType<TwirpApp> {
                 case 2:
                     val = reader.string();
                     break;
-                default: throw new globalThis.Error("unknown map entry field for TwirpApp.headers");
+                default: throw new globalThis.Error("unknown map entry field for JsonrpcApp.headers");
             }
         }
         map[key ?? ""] = val ?? "";
     }
-    internalBinaryWrite(message: TwirpApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: JsonrpcApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         /* string url = 1; */
         if (message.url !== "")
             writer.tag(1, WireType.LengthDelimited).string(message.url);
-        /* string proto_dir = 2; */
-        if (message.protoDir !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.protoDir);
-        /* map<string, string> headers = 3; */
+        /* string spec_url = 2; */
+        if (message.specUrl !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.specUrl);
+        /* string spec_file = 3; */
+        if (message.specFile !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.specFile);
+        /* map<string, string> headers = 4; */
         for (let k of globalThis.Object.keys(message.headers))
-            writer.tag(3, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+            writer.tag(4, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* string auth = 5; */
+        if (message.auth !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.auth);
+        /* string token = 6; */
+        if (message.token !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.token);
+        /* string username = 7; */
+        if (message.username !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.username);
+        /* string password = 8; */
+        if (message.password !== "")
+            writer.tag(8, WireType.LengthDelimited).string(message.password);
+        /* string api_key_name = 9; */
+        if (message.apiKeyName !== "")
+            writer.tag(9, WireType.LengthDelimited).string(message.apiKeyName);
+        /* int64 retry_max_attempts = 10; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(10, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 11; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(11, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 12; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(12, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 13; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(13, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 14; */
+        if (message.rateLimit !== "0")
+            writer.tag(14, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 15; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(15, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 16; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(16, WireType.Varint).int64(message.maxConcurrency);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4110,75 +6238,95 @@ class TwirpApp$Type extends MessageType<TwirpApp> {
     }
 }
 /**
- * @generated MessageType for protobuf message TwirpApp
+ * @generated MessageType for protobuf message JsonrpcApp
  */
-export const TwirpApp = new TwirpApp$Type();
+export const JsonrpcApp = new JsonrpcApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenApiApp$Type extends MessageType<OpenApiApp> {
+class WebsocketApp$Type extends MessageType<WebsocketApp> {
     constructor() {
-        super("OpenApiApp", [
-            { no: 1, name: "spec_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
-            { no: 6, name: "spec_content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 7, name: "base_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 8, name: "security_scheme", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 9, name: "spec_header_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 10, name: "spec_header_value", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("WebsocketApp", [
+            { no: 1, name: "url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "asyncapi_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "asyncapi_file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 5, name: "auth", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 10, name: "correlation_field", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 11, name: "subprotocols", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 12, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 13, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 14, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<OpenApiApp>): OpenApiApp {
+    create(value?: PartialMessage<WebsocketApp>): WebsocketApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.specUrl = "";
+        message.url = "";
+        message.asyncapiUrl = "";
+        message.asyncapiFile = "";
+        message.headers = {};
+        message.auth = "";
         message.token = "";
         message.username = "";
         message.password = "";
-        message.headers = {};
-        message.specContent = "";
-        message.baseUrl = "";
-        message.securityScheme = "";
-        message.specHeaderName = "";
-        message.specHeaderValue = "";
+        message.apiKeyName = "";
+        message.correlationField = "";
+        message.subprotocols = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
         if (value !== undefined)
-            reflectionMergePartial<OpenApiApp>(this, message, value);
+            reflectionMergePartial<WebsocketApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenApiApp): OpenApiApp {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WebsocketApp): WebsocketApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string spec_url */ 1:
-                    message.specUrl = reader.string();
+                case /* string url */ 1:
+                    message.url = reader.string();
                     break;
-                case /* string token */ 2:
+                case /* string asyncapi_url */ 2:
+                    message.asyncapiUrl = reader.string();
+                    break;
+                case /* string asyncapi_file */ 3:
+                    message.asyncapiFile = reader.string();
+                    break;
+                case /* map<string, string> headers */ 4:
+                    this.binaryReadMap4(message.headers, reader, options);
+                    break;
+                case /* string auth */ 5:
+                    message.auth = reader.string();
+                    break;
+                case /* string token */ 6:
                     message.token = reader.string();
                     break;
-                case /* string username */ 3:
+                case /* string username */ 7:
                     message.username = reader.string();
                     break;
-                case /* string password */ 4:
+                case /* string password */ 8:
                     message.password = reader.string();
                     break;
-                case /* map<string, string> headers */ 5:
-                    this.binaryReadMap5(message.headers, reader, options);
+                case /* string api_key_name */ 9:
+                    message.apiKeyName = reader.string();
                     break;
-                case /* string spec_content */ 6:
-                    message.specContent = reader.string();
+                case /* string correlation_field */ 10:
+                    message.correlationField = reader.string();
                     break;
-                case /* string base_url */ 7:
-                    message.baseUrl = reader.string();
+                case /* repeated string subprotocols */ 11:
+                    message.subprotocols.push(reader.string());
                     break;
-                case /* string security_scheme */ 8:
-                    message.securityScheme = reader.string();
+                case /* int64 rate_limit */ 12:
+                    message.rateLimit = reader.int64().toString();
                     break;
-                case /* string spec_header_name */ 9:
-                    message.specHeaderName = reader.string();
+                case /* int64 rate_limit_burst */ 13:
+                    message.rateLimitBurst = reader.int64().toString();
                     break;
-                case /* string spec_header_value */ 10:
-                    message.specHeaderValue = reader.string();
+                case /* int64 max_concurrency */ 14:
+                    message.maxConcurrency = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -4191,8 +6339,8 @@ class OpenApiApp$Type extends MessageType<OpenApiApp> {
         }
         return message;
     }
-    private binaryReadMap5(map: OpenApiApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
-        let len = reader.uint32(), end = reader.pos + len, key: keyof OpenApiApp["headers"] | undefined, val: OpenApiApp["headers"][any] | undefined;
+    private binaryReadMap4(map: WebsocketApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof WebsocketApp["headers"] | undefined, val: WebsocketApp["headers"][any] | undefined;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
@@ -4202,42 +6350,54 @@ class OpenApiApp$Type extends MessageType<OpenApiApp> {
                 case 2:
                     val = reader.string();
                     break;
-                default: throw new globalThis.Error("unknown map entry field for OpenApiApp.headers");
+                default: throw new globalThis.Error("unknown map entry field for WebsocketApp.headers");
             }
         }
         map[key ?? ""] = val ?? "";
     }
-    internalBinaryWrite(message: OpenApiApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string spec_url = 1; */
-        if (message.specUrl !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.specUrl);
-        /* string token = 2; */
+    internalBinaryWrite(message: WebsocketApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string url = 1; */
+        if (message.url !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.url);
+        /* string asyncapi_url = 2; */
+        if (message.asyncapiUrl !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.asyncapiUrl);
+        /* string asyncapi_file = 3; */
+        if (message.asyncapiFile !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.asyncapiFile);
+        /* map<string, string> headers = 4; */
+        for (let k of globalThis.Object.keys(message.headers))
+            writer.tag(4, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* string auth = 5; */
+        if (message.auth !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.auth);
+        /* string token = 6; */
         if (message.token !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.token);
-        /* string username = 3; */
+            writer.tag(6, WireType.LengthDelimited).string(message.token);
+        /* string username = 7; */
         if (message.username !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.username);
-        /* string password = 4; */
+            writer.tag(7, WireType.LengthDelimited).string(message.username);
+        /* string password = 8; */
         if (message.password !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.password);
-        /* map<string, string> headers = 5; */
-        for (let k of globalThis.Object.keys(message.headers))
-            writer.tag(5, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
-        /* string spec_content = 6; */
-        if (message.specContent !== "")
-            writer.tag(6, WireType.LengthDelimited).string(message.specContent);
-        /* string base_url = 7; */
-        if (message.baseUrl !== "")
-            writer.tag(7, WireType.LengthDelimited).string(message.baseUrl);
-        /* string security_scheme = 8; */
-        if (message.securityScheme !== "")
-            writer.tag(8, WireType.LengthDelimited).string(message.securityScheme);
-        /* string spec_header_name = 9; */
-        if (message.specHeaderName !== "")
-            writer.tag(9, WireType.LengthDelimited).string(message.specHeaderName);
-        /* string spec_header_value = 10; */
-        if (message.specHeaderValue !== "")
-            writer.tag(10, WireType.LengthDelimited).string(message.specHeaderValue);
+            writer.tag(8, WireType.LengthDelimited).string(message.password);
+        /* string api_key_name = 9; */
+        if (message.apiKeyName !== "")
+            writer.tag(9, WireType.LengthDelimited).string(message.apiKeyName);
+        /* string correlation_field = 10; */
+        if (message.correlationField !== "")
+            writer.tag(10, WireType.LengthDelimited).string(message.correlationField);
+        /* repeated string subprotocols = 11; */
+        for (let i = 0; i < message.subprotocols.length; i++)
+            writer.tag(11, WireType.LengthDelimited).string(message.subprotocols[i]);
+        /* int64 rate_limit = 12; */
+        if (message.rateLimit !== "0")
+            writer.tag(12, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 13; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(13, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 14; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(14, WireType.Varint).int64(message.maxConcurrency);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4245,40 +6405,90 @@ class OpenApiApp$Type extends MessageType<OpenApiApp> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenApiApp
+ * @generated MessageType for protobuf message WebsocketApp
  */
-export const OpenApiApp = new OpenApiApp$Type();
+export const WebsocketApp = new WebsocketApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class OpenAiApp$Type extends MessageType<OpenAiApp> {
+class HttpApp$Type extends MessageType<HttpApp> {
     constructor() {
-        super("OpenAiApp", [
-            { no: 1, name: "endpoint", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } }
+        super("HttpApp", [
+            { no: 1, name: "base_url", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 3, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "username", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "password", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "endpoints", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => HttpEndpoint },
+            { no: 7, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 8, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 9, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 10, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 11, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 12, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 13, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<OpenAiApp>): OpenAiApp {
+    create(value?: PartialMessage<HttpApp>): HttpApp {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.endpoint = "";
-        message.token = "";
+        message.baseUrl = "";
         message.headers = {};
+        message.token = "";
+        message.username = "";
+        message.password = "";
+        message.endpoints = [];
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
         if (value !== undefined)
-            reflectionMergePartial<OpenAiApp>(this, message, value);
+            reflectionMergePartial<HttpApp>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: OpenAiApp): OpenAiApp {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: HttpApp): HttpApp {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string endpoint */ 1:
-                    message.endpoint = reader.string();
+                case /* string base_url */ 1:
+                    message.baseUrl = reader.string();
                     break;
-                case /* string token */ 2:
+                case /* map<string, string> headers */ 2:
+                    this.binaryReadMap2(message.headers, reader, options);
+                    break;
+                case /* string token */ 3:
                     message.token = reader.string();
                     break;
-                case /* map<string, string> headers */ 3:
-                    this.binaryReadMap3(message.headers, reader, options);
+                case /* string username */ 4:
+                    message.username = reader.string();
+                    break;
+                case /* string password */ 5:
+                    message.password = reader.string();
+                    break;
+                case /* repeated HttpEndpoint endpoints */ 6:
+                    message.endpoints.push(HttpEndpoint.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* int64 retry_max_attempts */ 7:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 8:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 9:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 10:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 11:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 12:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 13:
+                    message.maxConcurrency = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -4291,8 +6501,8 @@ class OpenAiApp$Type extends MessageType<OpenAiApp> {
         }
         return message;
     }
-    private binaryReadMap3(map: OpenAiApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
-        let len = reader.uint32(), end = reader.pos + len, key: keyof OpenAiApp["headers"] | undefined, val: OpenAiApp["headers"][any] | undefined;
+    private binaryReadMap2(map: HttpApp["headers"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof HttpApp["headers"] | undefined, val: HttpApp["headers"][any] | undefined;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
@@ -4302,21 +6512,51 @@ class OpenAiApp$Type extends MessageType<OpenAiApp> {
                 case 2:
                     val = reader.string();
                     break;
-                default: throw new globalThis.Error("unknown map entry field for OpenAiApp.headers");
+                default: throw new globalThis.Error("unknown map entry field for HttpApp.headers");
             }
         }
         map[key ?? ""] = val ?? "";
     }
-    internalBinaryWrite(message: OpenAiApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string endpoint = 1; */
-        if (message.endpoint !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.endpoint);
-        /* string token = 2; */
-        if (message.token !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.token);
-        /* map<string, string> headers = 3; */
+    internalBinaryWrite(message: HttpApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string base_url = 1; */
+        if (message.baseUrl !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.baseUrl);
+        /* map<string, string> headers = 2; */
         for (let k of globalThis.Object.keys(message.headers))
-            writer.tag(3, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+            writer.tag(2, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.headers[k]).join();
+        /* string token = 3; */
+        if (message.token !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.token);
+        /* string username = 4; */
+        if (message.username !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.username);
+        /* string password = 5; */
+        if (message.password !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.password);
+        /* repeated HttpEndpoint endpoints = 6; */
+        for (let i = 0; i < message.endpoints.length; i++)
+            HttpEndpoint.internalBinaryWrite(message.endpoints[i], writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        /* int64 retry_max_attempts = 7; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(7, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 8; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(8, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 9; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(9, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 10; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(10, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 11; */
+        if (message.rateLimit !== "0")
+            writer.tag(11, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 12; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(12, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 13; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(13, WireType.Varint).int64(message.maxConcurrency);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4324,31 +6564,61 @@ class OpenAiApp$Type extends MessageType<OpenAiApp> {
     }
 }
 /**
- * @generated MessageType for protobuf message OpenAiApp
+ * @generated MessageType for protobuf message HttpApp
  */
-export const OpenAiApp = new OpenAiApp$Type();
+export const HttpApp = new HttpApp$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FolderApp$Type extends MessageType<FolderApp> {
+class HttpEndpoint$Type extends MessageType<HttpEndpoint> {
     constructor() {
-        super("FolderApp", [
-            { no: 1, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("HttpEndpoint", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "method", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "path", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "query", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "headers", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "body", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<FolderApp>): FolderApp {
+    create(value?: PartialMessage<HttpEndpoint>): HttpEndpoint {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.method = "";
         message.path = "";
+        message.query = [];
+        message.headers = [];
+        message.body = "";
+        message.description = "";
         if (value !== undefined)
-            reflectionMergePartial<FolderApp>(this, message, value);
+            reflectionMergePartial<HttpEndpoint>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FolderApp): FolderApp {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: HttpEndpoint): HttpEndpoint {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string path */ 1:
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string method */ 2:
+                    message.method = reader.string();
+                    break;
+                case /* string path */ 3:
                     message.path = reader.string();
                     break;
+                case /* repeated string query */ 4:
+                    message.query.push(reader.string());
+                    break;
+                case /* repeated string headers */ 5:
+                    message.headers.push(reader.string());
+                    break;
+                case /* string body */ 6:
+                    message.body = reader.string();
+                    break;
+                case /* string description */ 7:
+                    message.description = reader.string();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -4360,10 +6630,28 @@ class FolderApp$Type extends MessageType<FolderApp> {
         }
         return message;
     }
-    internalBinaryWrite(message: FolderApp, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string path = 1; */
+    internalBinaryWrite(message: HttpEndpoint, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string method = 2; */
+        if (message.method !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.method);
+        /* string path = 3; */
         if (message.path !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.path);
+            writer.tag(3, WireType.LengthDelimited).string(message.path);
+        /* repeated string query = 4; */
+        for (let i = 0; i < message.query.length; i++)
+            writer.tag(4, WireType.LengthDelimited).string(message.query[i]);
+        /* repeated string headers = 5; */
+        for (let i = 0; i < message.headers.length; i++)
+            writer.tag(5, WireType.LengthDelimited).string(message.headers[i]);
+        /* string body = 6; */
+        if (message.body !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.body);
+        /* string description = 7; */
+        if (message.description !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.description);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4371,9 +6659,9 @@ class FolderApp$Type extends MessageType<FolderApp> {
     }
 }
 /**
- * @generated MessageType for protobuf message FolderApp
+ * @generated MessageType for protobuf message HttpEndpoint
  */
-export const FolderApp = new FolderApp$Type();
+export const HttpEndpoint = new HttpEndpoint$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class McpApp$Type extends MessageType<McpApp> {
     constructor() {
@@ -4382,7 +6670,21 @@ class McpApp$Type extends MessageType<McpApp> {
             { no: 2, name: "headers", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
             { no: 3, name: "auth", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 4, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 5, name: "api_key_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "retry_max_attempts", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 7, name: "retry_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 8, name: "retry_max_backoff_ms", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 9, name: "retry_codes", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 10, name: "rate_limit", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 11, name: "rate_limit_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 12, name: "max_concurrency", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 13, name: "command", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 14, name: "args", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 15, name: "env", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 16, name: "working_dir", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 17, name: "roots", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 18, name: "sampling_app", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 19, name: "sampling_model", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
     create(value?: PartialMessage<McpApp>): McpApp {
@@ -4392,6 +6694,20 @@ class McpApp$Type extends MessageType<McpApp> {
         message.auth = "";
         message.token = "";
         message.apiKeyName = "";
+        message.retryMaxAttempts = "0";
+        message.retryBackoffMs = "0";
+        message.retryMaxBackoffMs = "0";
+        message.retryCodes = [];
+        message.rateLimit = "0";
+        message.rateLimitBurst = "0";
+        message.maxConcurrency = "0";
+        message.command = "";
+        message.args = [];
+        message.env = [];
+        message.workingDir = "";
+        message.roots = [];
+        message.samplingApp = "";
+        message.samplingModel = "";
         if (value !== undefined)
             reflectionMergePartial<McpApp>(this, message, value);
         return message;
@@ -4416,6 +6732,48 @@ class McpApp$Type extends MessageType<McpApp> {
                 case /* string api_key_name */ 5:
                     message.apiKeyName = reader.string();
                     break;
+                case /* int64 retry_max_attempts */ 6:
+                    message.retryMaxAttempts = reader.int64().toString();
+                    break;
+                case /* int64 retry_backoff_ms */ 7:
+                    message.retryBackoffMs = reader.int64().toString();
+                    break;
+                case /* int64 retry_max_backoff_ms */ 8:
+                    message.retryMaxBackoffMs = reader.int64().toString();
+                    break;
+                case /* repeated string retry_codes */ 9:
+                    message.retryCodes.push(reader.string());
+                    break;
+                case /* int64 rate_limit */ 10:
+                    message.rateLimit = reader.int64().toString();
+                    break;
+                case /* int64 rate_limit_burst */ 11:
+                    message.rateLimitBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_concurrency */ 12:
+                    message.maxConcurrency = reader.int64().toString();
+                    break;
+                case /* string command */ 13:
+                    message.command = reader.string();
+                    break;
+                case /* repeated string args */ 14:
+                    message.args.push(reader.string());
+                    break;
+                case /* repeated string env */ 15:
+                    message.env.push(reader.string());
+                    break;
+                case /* string working_dir */ 16:
+                    message.workingDir = reader.string();
+                    break;
+                case /* repeated string roots */ 17:
+                    message.roots.push(reader.string());
+                    break;
+                case /* string sampling_app */ 18:
+                    message.samplingApp = reader.string();
+                    break;
+                case /* string sampling_model */ 19:
+                    message.samplingModel = reader.string();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -4459,6 +6817,48 @@ class McpApp$Type extends MessageType<McpApp> {
         /* string api_key_name = 5; */
         if (message.apiKeyName !== "")
             writer.tag(5, WireType.LengthDelimited).string(message.apiKeyName);
+        /* int64 retry_max_attempts = 6; */
+        if (message.retryMaxAttempts !== "0")
+            writer.tag(6, WireType.Varint).int64(message.retryMaxAttempts);
+        /* int64 retry_backoff_ms = 7; */
+        if (message.retryBackoffMs !== "0")
+            writer.tag(7, WireType.Varint).int64(message.retryBackoffMs);
+        /* int64 retry_max_backoff_ms = 8; */
+        if (message.retryMaxBackoffMs !== "0")
+            writer.tag(8, WireType.Varint).int64(message.retryMaxBackoffMs);
+        /* repeated string retry_codes = 9; */
+        for (let i = 0; i < message.retryCodes.length; i++)
+            writer.tag(9, WireType.LengthDelimited).string(message.retryCodes[i]);
+        /* int64 rate_limit = 10; */
+        if (message.rateLimit !== "0")
+            writer.tag(10, WireType.Varint).int64(message.rateLimit);
+        /* int64 rate_limit_burst = 11; */
+        if (message.rateLimitBurst !== "0")
+            writer.tag(11, WireType.Varint).int64(message.rateLimitBurst);
+        /* int64 max_concurrency = 12; */
+        if (message.maxConcurrency !== "0")
+            writer.tag(12, WireType.Varint).int64(message.maxConcurrency);
+        /* string command = 13; */
+        if (message.command !== "")
+            writer.tag(13, WireType.LengthDelimited).string(message.command);
+        /* repeated string args = 14; */
+        for (let i = 0; i < message.args.length; i++)
+            writer.tag(14, WireType.LengthDelimited).string(message.args[i]);
+        /* repeated string env = 15; */
+        for (let i = 0; i < message.env.length; i++)
+            writer.tag(15, WireType.LengthDelimited).string(message.env[i]);
+        /* string working_dir = 16; */
+        if (message.workingDir !== "")
+            writer.tag(16, WireType.LengthDelimited).string(message.workingDir);
+        /* repeated string roots = 17; */
+        for (let i = 0; i < message.roots.length; i++)
+            writer.tag(17, WireType.LengthDelimited).string(message.roots[i]);
+        /* string sampling_app = 18; */
+        if (message.samplingApp !== "")
+            writer.tag(18, WireType.LengthDelimited).string(message.samplingApp);
+        /* string sampling_model = 19; */
+        if (message.samplingModel !== "")
+            writer.tag(19, WireType.LengthDelimited).string(message.samplingModel);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -13,7 +13,8 @@ import { parseTwirpErrorResponse } from "@protobuf-ts/twirp-transport";
 import { isJsonObject, type JsonValue } from "@protobuf-ts/runtime";
 import { Twirp, Target, TargetServerStream, CancelStream } from "../wailsjs/go/main/App";
 import { EventsOn } from "../wailsjs/runtime";
-import { transportHeaders } from "../appTypes";
+import { ANSWER_HEADER, ENDPOINT_HEADER, transportHeaders, twirpSendsJson } from "../appTypes";
+import { QUESTION_TRAILER } from "../elicitation";
 import { UPSTREAM_REQUEST_HEADERS_TRAILER, UPSTREAM_RESPONSE_HEADERS_TRAILER } from "../upstreamHeaders";
 import { AppRef, Transport } from "../apps";
 
@@ -82,7 +83,7 @@ class UpstreamError extends Error {
 // body — becomes error fields, the same shape the web transport arrives at from
 // its trailer. The exchanged upstream headers are mirrored onto the error's
 // `meta` in the trailer shape the web transport uses, so the Headers view is
-// populated on a failure too.
+// populated on a failure too. A Twirp error's own meta stays beside them.
 function upstreamError(result: {
   body: unknown;
   statusCode: number;
@@ -104,7 +105,8 @@ function upstreamError(result: {
     const summary = [msg, message].find((m): m is string => typeof m === "string" && m !== "");
     error = new UpstreamError(summary || `HTTP ${result.statusCode} ${result.status}`, fields);
   }
-  const meta: RpcMetadata = {};
+  const errorMeta = (error as unknown as { meta?: unknown }).meta;
+  const meta: RpcMetadata = errorMeta && typeof errorMeta === "object" ? { ...(errorMeta as RpcMetadata) } : {};
   if (result.requestHeaders && Object.keys(result.requestHeaders).length > 0) {
     meta[UPSTREAM_REQUEST_HEADERS_TRAILER] = JSON.stringify(result.requestHeaders);
   }
@@ -218,7 +220,7 @@ export class WailsTransport implements RpcTransport {
     const inputArray = Array.from(inputBytes);
     const fullMethodPath = `${method.service.typeName}/${method.name}`;
     // The ${NAME} references travel unexpanded; the Go side resolves them.
-    const headersJson = JSON.stringify(transportHeaders(this.appRef!.configuration));
+    const headersJson = JSON.stringify(transportHeaders(this.appRef!.configuration, options.meta?.[ENDPOINT_HEADER] as string | undefined));
 
     TargetServerStream(this.appRef!.target, fullMethodPath, inputArray, headersJson, streamID).catch((err) => {
       responseStream.notifyError(err instanceof Error ? err : new Error(String(err)));
@@ -262,7 +264,7 @@ export class WailsTransport implements RpcTransport {
     input: I,
     options: RpcOptions,
   ): { response: Promise<O>; status: Promise<RpcStatus>; trailers: Promise<RpcMetadata> } {
-    const resultPromise = this.executeCall(method, input);
+    const resultPromise = this.executeCall(method, input, options);
     const responsePromise = resultPromise.then((result) => result.output);
     const statusPromise = resultPromise.then(() => ({ code: "OK", detail: "" }));
     const trailersPromise = resultPromise.then((result) => result.trailers);
@@ -274,7 +276,11 @@ export class WailsTransport implements RpcTransport {
     };
   }
 
-  private async executeCall<I extends object, O extends object>(method: MethodInfo<I, O>, input: I): Promise<{ output: O; trailers: RpcMetadata }> {
+  private async executeCall<I extends object, O extends object>(
+    method: MethodInfo<I, O>,
+    input: I,
+    options: RpcOptions,
+  ): Promise<{ output: O; trailers: RpcMetadata }> {
     try {
       // Serialize input using protobuf-ts. An empty result is valid: a method with
       // no parameters has nothing to encode.
@@ -297,8 +303,18 @@ export class WailsTransport implements RpcTransport {
       } else {
         // mode === "target" - read URL and headers dynamically from appRef
         const fullMethodPath = `${method.service.typeName}/${method.name}`;
-        const headersJson = JSON.stringify(transportHeaders(this.appRef!.configuration));
-        const result = await Target(this.appRef!.target, fullMethodPath, inputArray, this.protocol, headersJson);
+        const headers = transportHeaders(
+          this.appRef!.configuration,
+          options.meta?.[ENDPOINT_HEADER] as string | undefined,
+          options.meta?.[ANSWER_HEADER] as string | undefined,
+        );
+        // A twirp app that speaks JSON is sent JSON, and says so in its Content-Type.
+        const json = this.protocol === Transport.TWIRP && twirpSendsJson(this.appRef!.configuration);
+        const body = json ? Array.from(new TextEncoder().encode(method.I.toJsonString(input))) : inputArray;
+        if (json) {
+          headers["Content-Type"] = "application/json";
+        }
+        const result = await Target(this.appRef!.target, fullMethodPath, body, this.protocol, JSON.stringify(headers));
 
         if (result.statusCode >= 400) {
           // A structured error body: an upstream failure from an app, or a Twirp error.
@@ -313,7 +329,13 @@ export class WailsTransport implements RpcTransport {
         if (result.responseHeaders && Object.keys(result.responseHeaders).length > 0) {
           trailers[UPSTREAM_RESPONSE_HEADERS_TRAILER] = JSON.stringify(result.responseHeaders);
         }
+        if (result.question) {
+          trailers[QUESTION_TRAILER] = result.question;
+        }
 
+        if (json) {
+          return { output: method.O.fromJsonString(new TextDecoder().decode(responseBytes(result.body)), { ignoreUnknownFields: true }), trailers };
+        }
         responseBody = result.body;
       }
 
@@ -1,5 +1,5 @@
 import { expect, test } from "bun:test";
-import { parseUpstreamError, parseUpstreamHeaders, unwrapFailure, upstreamRequestLine } from "./upstreamHeaders";
+import { parseUpstreamError, parseUpstreamHeaders, queuedMs, unwrapFailure, upstreamRequestLine } from "./upstreamHeaders";
 
 // Trailers are percent-encoded on the way out because a gRPC-Web client reads
 // them byte by byte as Latin-1; without it an em dash arrives as "â€"".
@@ -92,3 +92,11 @@ test("returns undefined for a missing or unparseable trailer", () => {
   expect(parseUpstreamError(escape("[1,2]"))).toBeUndefined();
   expect(parseUpstreamHeaders(undefined)).toBeUndefined();
 });
+
+test("reads how long a call queued under its app's limits", () => {
+  expect(queuedMs({ "kaja-queued-ms": "250" })).toBe(250);
+  // A call that didn't wait reports nothing, and neither does one that went nowhere.
+  expect(queuedMs({ "kaja-queued-ms": "0" })).toBeUndefined();
+  expect(queuedMs({})).toBeUndefined();
+  expect(queuedMs(undefined)).toBeUndefined();
+});
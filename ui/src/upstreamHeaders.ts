@@ -10,6 +10,17 @@ export const UPSTREAM_REQUEST_HEADERS_TRAILER = "kaja-upstream-request-headers";
 export const UPSTREAM_RESPONSE_HEADERS_TRAILER = "kaja-upstream-response-headers";
 export const UPSTREAM_ERROR_TRAILER = "kaja-upstream-error";
 
+// The upstream request header a call that waited its turn under its app's limits
+// reports the wait in, in milliseconds.
+export const QUEUED_HEADER = "kaja-queued-ms";
+
+// queuedMs reads how long a call waited before it left kaja, or undefined for one
+// that didn't.
+export function queuedMs(headers?: MethodCallHeaders): number | undefined {
+  const value = Number(headers?.[QUEUED_HEADER]);
+  return Number.isFinite(value) && value > 0 ? value : undefined;
+}
+
 // An upstream HTTP call that failed, as the app reported it: the request that was
 // made, what came back, and nothing about the gRPC frame it travelled in.
 export interface UpstreamFailure {
@@ -23,7 +34,7 @@ export interface UpstreamFailure {
 // decodeTrailer reads a trailer value: RpcMetadata gives either a string or a
 // single-element array, and the value is percent-encoded (escapeTrailerValue
 // server-side) because trailers are read back byte by byte as Latin-1.
-function decodeTrailer(value: unknown): string | undefined {
+export function decodeTrailer(value: unknown): string | undefined {
   const raw = Array.isArray(value) ? value[0] : value;
   if (raw === undefined || raw === null) return undefined;
   try {
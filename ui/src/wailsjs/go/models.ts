@@ -44,6 +44,7 @@ export namespace main {
 	    status: string;
 	    requestHeaders?: Record<string, string>;
 	    responseHeaders?: Record<string, string>;
+	    question?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new TargetResult(source);
@@ -56,6 +57,7 @@ export namespace main {
 	        this.status = source["status"];
 	        this.requestHeaders = source["requestHeaders"];
 	        this.responseHeaders = source["responseHeaders"];
+	        this.question = source["question"];
 	    }
 	}
 